@@ -1,14 +1,8 @@
 package main
 
 import (
-	"bytes"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
-	"encoding/pem"
-	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
@@ -16,6 +10,7 @@ import (
 	"time"
 
 	"github.com/trisacrypto/directory/pkg"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/trisa/pkg/trust"
 	"github.com/urfave/cli"
 )
@@ -227,29 +222,8 @@ func initCA(c *cli.Context) (err error) {
 		DNSNames:              []string{"trisa.dev"},
 	}
 
-	// Create private key
-	var priv *rsa.PrivateKey
-	if priv, err = rsa.GenerateKey(rand.Reader, 4096); err != nil {
-		return cli.NewExitError(fmt.Errorf("could not create private key: %s", err), 1)
-	}
-
-	pub := &priv.PublicKey
-	signed, err := x509.CreateCertificate(rand.Reader, ca, ca, pub, priv)
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("create CA certs failed: %s", err), 1)
-	}
-
-	var chain bytes.Buffer
-	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: signed}); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
-	if err = pem.Encode(&chain, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
 	var provider *trust.Provider
-	if provider, err = trust.New(chain.Bytes()); err != nil {
+	if provider, err = certauth.NewCA(ca, 4096); err != nil {
 		return cli.NewExitError(err, 1)
 	}
 
@@ -287,16 +261,6 @@ func issue(c *cli.Context) (err error) {
 		return cli.NewExitError("specify path to CA certs", 1)
 	}
 
-	var catls tls.Certificate
-	if catls, err = ca.GetKeyPair(); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
-	var cacert *x509.Certificate
-	if cacert, err = x509.ParseCertificate(catls.Certificate[0]); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
 	cert := &x509.Certificate{
 		SerialNumber: big.NewInt(1945),
 		Subject: pkix.Name{
@@ -316,32 +280,6 @@ func issue(c *cli.Context) (err error) {
 		DNSNames:     []string{c.String("name")},
 	}
 
-	// Create private key
-	var priv *rsa.PrivateKey
-	if priv, err = rsa.GenerateKey(rand.Reader, 4096); err != nil {
-		return cli.NewExitError(fmt.Errorf("could not create private key: %s", err), 1)
-	}
-
-	pub := &priv.PublicKey
-	signed, err := x509.CreateCertificate(rand.Reader, cert, cacert, pub, catls.PrivateKey)
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("create CA certs failed: %s", err), 1)
-	}
-
-	// Encode the certificate
-	var chain bytes.Buffer
-	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: signed}); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
-	if err = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: cacert.Raw}); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
-	if err = pem.Encode(&chain, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
 	var (
 		provider *trust.Provider
 		sz       *trust.Serializer
@@ -349,7 +287,7 @@ func issue(c *cli.Context) (err error) {
 		outpath  string
 	)
 
-	if provider, err = trust.New(chain.Bytes()); err != nil {
+	if provider, err = certauth.Issue(ca, cert, 4096); err != nil {
 		return cli.NewExitError(err, 1)
 	}
 
@@ -21,6 +21,7 @@ import (
 	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	models "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
@@ -120,6 +121,11 @@ func main() {
 						Aliases: []string{"d"},
 						Usage:   "the JSON file containing the VASP data record",
 					},
+					&cli.StringFlag{
+						Name:    "csr",
+						Aliases: []string{"c"},
+						Usage:   "a PEM encoded certificate signing request to issue certificates from",
+					},
 				},
 			},
 			{
@@ -382,6 +388,11 @@ func main() {
 						Aliases: []string{"S"},
 						Usage:   "filter by verification status",
 					},
+					&cli.BoolFlag{
+						Name:    "archived",
+						Aliases: []string{"a"},
+						Usage:   "list deleted VASPs that have not been purged",
+					},
 				},
 			},
 			{
@@ -398,6 +409,54 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:     "admin:revoke",
+				Usage:    "revoke a certificate issued to a VASP",
+				Category: "admin",
+				Action:   adminRevokeCertificate,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP the certificate was issued to",
+					},
+					&cli.StringFlag{
+						Name:    "cert",
+						Aliases: []string{"c"},
+						Usage:   "the serial number of the certificate to revoke",
+					},
+					&cli.StringFlag{
+						Name:    "reason",
+						Aliases: []string{"r"},
+						Usage:   "the CRL reason for revocation, e.g. keyCompromise or superseded",
+					},
+					&cli.StringFlag{
+						Name:    "description",
+						Aliases: []string{"d"},
+						Usage:   "a description of the revocation to send to the VASP contacts",
+					},
+				},
+			},
+			{
+				Name:     "admin:csr",
+				Usage:    "upload a new certificate signing request for a VASP",
+				Category: "admin",
+				Action:   adminUploadCSR,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP to upload the CSR for",
+					},
+					&cli.StringFlag{
+						Name:    "csr",
+						Aliases: []string{"c"},
+						Usage:   "path to the PEM encoded CSR for the common name of the VASP",
+					},
+				},
+			},
 			{
 				Name:     "admin:detail",
 				Usage:    "retrieve a VASP detail record by id",
@@ -445,6 +504,63 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:     "admin:restore",
+				Usage:    "restore a deleted VASP record by id before it is purged",
+				Category: "admin",
+				Action:   adminRestoreVASP,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP to restore",
+					},
+				},
+			},
+			{
+				Name:     "admin:history",
+				Usage:    "list the revisions of a VASP record or show the changes made in a revision",
+				Category: "admin",
+				Action:   adminVASPHistory,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP to show the history of",
+					},
+					&cli.Uint64Flag{
+						Name:    "revision",
+						Aliases: []string{"r"},
+						Usage:   "show the fields changed in the specified revision",
+					},
+					&cli.Uint64Flag{
+						Name:    "against",
+						Aliases: []string{"a"},
+						Usage:   "compare the revision to this revision instead of the previous revision",
+					},
+				},
+			},
+			{
+				Name:     "admin:rollback",
+				Usage:    "roll back a VASP record to an earlier revision",
+				Category: "admin",
+				Action:   adminRollbackVASP,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the VASP to roll back",
+					},
+					&cli.Uint64Flag{
+						Name:    "revision",
+						Aliases: []string{"r"},
+						Usage:   "the revision to roll the VASP back to",
+					},
+				},
+			},
 			{
 				Name:     "admin:contact-replace",
 				Usage:    "replace a contact record by VASP id and contact kind",
@@ -579,6 +695,115 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:     "admin:actions",
+				Usage:    "list actions proposed by admins that require approval",
+				Category: "admin",
+				Action:   adminListActions,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "status",
+						Aliases: []string{"s"},
+						Usage:   "filter actions by status (e.g. action_proposed)",
+					},
+					&cli.StringFlag{
+						Name:    "vasp",
+						Aliases: []string{"v"},
+						Usage:   "filter actions by the uuid of the VASP",
+					},
+				},
+			},
+			{
+				Name:     "admin:action",
+				Usage:    "retrieve a pending action by id",
+				Category: "admin",
+				Action:   adminRetrieveAction,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the pending action",
+					},
+				},
+			},
+			{
+				Name:     "admin:action-approve",
+				Usage:    "approve and execute an action proposed by another admin",
+				Category: "admin",
+				Action:   adminApproveAction,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the pending action to approve",
+					},
+				},
+			},
+			{
+				Name:     "admin:action-cancel",
+				Usage:    "cancel a proposed action so that it is not executed",
+				Category: "admin",
+				Action:   adminCancelAction,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "id",
+						Aliases: []string{"i"},
+						Usage:   "the uuid of the pending action to cancel",
+					},
+				},
+			},
+			{
+				Name:     "admin:audit",
+				Usage:    "list the requests that modified the directory from the audit log",
+				Category: "admin",
+				Action:   adminAuditLog,
+				Before:   initAdminClient,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "actor",
+						Aliases: []string{"a"},
+						Usage:   "filter records by the email address of the admin",
+					},
+					&cli.StringFlag{
+						Name:  "action",
+						Usage: "filter records by the method and route of the request (substring match)",
+					},
+					&cli.StringFlag{
+						Name:    "target",
+						Aliases: []string{"t"},
+						Usage:   "filter records by the uuid of the VASP or pending action",
+					},
+					&cli.StringFlag{
+						Name:    "request-id",
+						Aliases: []string{"r"},
+						Usage:   "filter records by the X-Request-ID returned to the client",
+					},
+					&cli.StringFlag{
+						Name:    "start",
+						Aliases: []string{"s"},
+						Usage:   "only records on or after the start date (YYYY-MM-DD)",
+					},
+					&cli.StringFlag{
+						Name:    "end",
+						Aliases: []string{"e"},
+						Usage:   "only records on or before the end date (YYYY-MM-DD)",
+					},
+					&cli.IntFlag{
+						Name:    "page",
+						Aliases: []string{"p"},
+						Usage:   "the page of results to return",
+					},
+					&cli.IntFlag{
+						Name:    "page-size",
+						Aliases: []string{"S", "size"},
+						Usage:   "the number of results per page",
+					},
+				},
+			},
 			{
 				Name:     "members:list",
 				Usage:    "list all currently verified VASPs in the directory",
@@ -801,6 +1026,15 @@ func register(c *cli.Context) (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Attach the CSR so that the directory does not generate the private key
+	if path = c.String("csr"); path != "" {
+		var csr []byte
+		if csr, err = ioutil.ReadFile(path); err != nil {
+			return cli.Exit(err, 1)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, gds.CSRMetadataKey, string(csr))
+	}
+
 	rep, err := client.Register(ctx, req)
 	if err != nil {
 		return cli.Exit(err, 1)
@@ -1050,6 +1284,7 @@ func adminListVASPs(c *cli.Context) (err error) {
 		Page:          c.Int("page"),
 		PageSize:      c.Int("page-size"),
 		StatusFilters: c.StringSlice("status-filters"),
+		Archived:      c.Bool("archived"),
 	}
 
 	var rep *admin.ListVASPsReply
@@ -1072,6 +1307,52 @@ func adminListCertificates(c *cli.Context) (err error) {
 	return printJSON(rep)
 }
 
+func adminRevokeCertificate(c *cli.Context) (err error) {
+	req := &admin.RevokeCertificateRequest{
+		VASP:        c.String("id"),
+		Certificate: c.String("cert"),
+		Reason:      c.String("reason"),
+		Description: c.String("description"),
+	}
+
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	var rep *admin.RevokeCertificateReply
+	if rep, err = adminClient.RevokeCertificate(ctx, req); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminUploadCSR(c *cli.Context) (err error) {
+	req := &admin.UploadCSRRequest{
+		VASP: c.String("id"),
+	}
+
+	var path string
+	if path = c.String("csr"); path == "" {
+		return cli.Exit("specify path to the PEM encoded CSR to upload", 1)
+	}
+
+	var csr []byte
+	if csr, err = ioutil.ReadFile(path); err != nil {
+		return cli.Exit(err, 1)
+	}
+	req.CSR = string(csr)
+
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	var rep *admin.UploadCSRReply
+	if rep, err = adminClient.UploadCSR(ctx, req); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
 func adminRetrieveVASP(c *cli.Context) (err error) {
 	ctx, cancel := profile.Context()
 	defer cancel()
@@ -1131,6 +1412,68 @@ func adminDeleteVASP(c *cli.Context) (err error) {
 	return printJSON(rep)
 }
 
+func adminRestoreVASP(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	vaspID := c.String("id")
+	if vaspID == "" {
+		return cli.Exit("must specify VASP ID (--id)", 1)
+	}
+
+	var rep *admin.Reply
+	if rep, err = adminClient.RestoreVASP(ctx, vaspID); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminVASPHistory(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	vaspID := c.String("id")
+	if vaspID == "" {
+		return cli.Exit("must specify VASP ID (--id)", 1)
+	}
+
+	if revision := c.Uint64("revision"); revision > 0 {
+		var rep *admin.RevisionDiffReply
+		if rep, err = adminClient.RevisionDiff(ctx, vaspID, revision, &admin.RevisionDiffParams{Against: c.Uint64("against")}); err != nil {
+			return cli.Exit(err, 1)
+		}
+		return printJSON(rep)
+	}
+
+	var rep *admin.ListRevisionsReply
+	if rep, err = adminClient.ListRevisions(ctx, vaspID); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(rep)
+}
+
+func adminRollbackVASP(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	vaspID := c.String("id")
+	if vaspID == "" {
+		return cli.Exit("must specify VASP ID (--id)", 1)
+	}
+
+	revision := c.Uint64("revision")
+	if revision == 0 {
+		return cli.Exit("must specify the revision to roll back to (--revision)", 1)
+	}
+
+	var rep *admin.UpdateVASPReply
+	if rep, err = adminClient.RollbackVASP(ctx, vaspID, revision); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(rep)
+}
+
 func adminReplaceContact(c *cli.Context) (err error) {
 	ctx, cancel := profile.Context()
 	defer cancel()
@@ -1318,6 +1661,97 @@ func adminDeleteNote(c *cli.Context) (err error) {
 	return printJSON(rep)
 }
 
+func adminListActions(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	params := &admin.ListPendingActionsParams{
+		Status: c.String("status"),
+		VASP:   c.String("vasp"),
+	}
+
+	var rep *admin.ListPendingActionsReply
+	if rep, err = adminClient.ListPendingActions(ctx, params); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminRetrieveAction(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	actionID := c.String("id")
+	if actionID == "" {
+		return cli.Exit("must specify pending action ID (--id)", 1)
+	}
+
+	var rep *admin.PendingAction
+	if rep, err = adminClient.RetrievePendingAction(ctx, actionID); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminApproveAction(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	actionID := c.String("id")
+	if actionID == "" {
+		return cli.Exit("must specify pending action ID (--id)", 1)
+	}
+
+	var rep *admin.PendingAction
+	if rep, err = adminClient.ApprovePendingAction(ctx, actionID); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminCancelAction(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	actionID := c.String("id")
+	if actionID == "" {
+		return cli.Exit("must specify pending action ID (--id)", 1)
+	}
+
+	var rep *admin.PendingAction
+	if rep, err = adminClient.CancelPendingAction(ctx, actionID); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
+func adminAuditLog(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	params := &admin.ListAuditRecordsParams{
+		Actor:     c.String("actor"),
+		Action:    c.String("action"),
+		Target:    c.String("target"),
+		RequestID: c.String("request-id"),
+		Start:     c.String("start"),
+		End:       c.String("end"),
+		Page:      c.Int("page"),
+		PageSize:  c.Int("page-size"),
+	}
+
+	var rep *admin.ListAuditRecordsReply
+	if rep, err = adminClient.ListAuditRecords(ctx, params); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	return printJSON(rep)
+}
+
 func membersList(c *cli.Context) (err error) {
 	// Only fetch a single request if not fetching all
 	if !c.Bool("fetch-all") {
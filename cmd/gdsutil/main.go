@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,10 +37,16 @@ import (
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
+	"github.com/trisacrypto/directory/pkg/gds/store"
+	trtlstore "github.com/trisacrypto/directory/pkg/gds/store/trtl"
+	"github.com/trisacrypto/directory/pkg/trtl"
+	trtlpb "github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
@@ -350,6 +357,106 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:     "store:migrate",
+			Usage:    "copy all records from one directory store to another",
+			Category: "store",
+			Action:   storeMigrate,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "src",
+					Aliases:  []string{"s"},
+					Usage:    "dsn of the store to migrate records from",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "dst",
+					Aliases:  []string{"d"},
+					Usage:    "dsn of the store to migrate records to",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:    "insecure",
+					Aliases: []string{"I"},
+					Usage:   "connect to trtl stores without mTLS",
+				},
+				&cli.StringFlag{
+					Name:    "certs",
+					Aliases: []string{"c"},
+					Usage:   "path to mTLS client certificates to connect to trtl stores",
+				},
+				&cli.StringFlag{
+					Name:    "pool",
+					Aliases: []string{"p"},
+					Usage:   "path to the trusted certificate pool to connect to trtl stores",
+				},
+				&cli.BoolFlag{
+					Name:  "skip-verify",
+					Usage: "do not verify record counts and checksums after migrating",
+				},
+			},
+		},
+		{
+			Name:     "backup:list",
+			Usage:    "list the backup archives in the backup storage directory",
+			Category: "backup",
+			Action:   backupList,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "storage",
+					Aliases: []string{"s"},
+					Usage:   "path to the backup storage directory",
+					EnvVars: []string{"GDS_BACKUP_STORAGE"},
+				},
+			},
+		},
+		{
+			Name:      "backup:verify",
+			Usage:     "check the integrity of a backup archive and summarize its contents",
+			ArgsUsage: "archive",
+			Category:  "backup",
+			Action:    backupVerify,
+		},
+		{
+			Name:      "backup:restore",
+			Usage:     "restore a backup archive into an offline leveldb store or a running trtl store",
+			ArgsUsage: "archive",
+			Category:  "backup",
+			Action:    backupRestore,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "db",
+					Aliases:  []string{"d"},
+					Usage:    "dsn of the store to restore the archive into",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:    "namespaces",
+					Aliases: []string{"n"},
+					Usage:   "specify the namespaces to restore (if empty, all namespaces in the archive are restored, otherwise the indices are cleared so the gds rebuilds them)",
+				},
+				&cli.BoolFlag{
+					Name:    "dryrun",
+					Aliases: []string{"D"},
+					Usage:   "show changes that would occur, does not modify database",
+				},
+				&cli.BoolFlag{
+					Name:    "insecure",
+					Aliases: []string{"I"},
+					Usage:   "connect to trtl stores without mTLS",
+				},
+				&cli.StringFlag{
+					Name:    "certs",
+					Aliases: []string{"c"},
+					Usage:   "path to mTLS client certificates to connect to trtl stores",
+				},
+				&cli.StringFlag{
+					Name:    "pool",
+					Aliases: []string{"p"},
+					Usage:   "path to the trusted certificate pool to connect to trtl stores",
+				},
+			},
+		},
 	}
 
 	app.Run(os.Args)
@@ -1245,10 +1352,207 @@ func generateTokenKey(c *cli.Context) (err error) {
 	return nil
 }
 
+//===========================================================================
+// Store Actions
+//===========================================================================
+
+func storeMigrate(c *cli.Context) (err error) {
+	var src, dst store.Store
+	if src, err = openStore(c, c.String("src")); err != nil {
+		return cli.Exit(fmt.Errorf("could not open source store: %s", err), 1)
+	}
+	defer src.Close()
+
+	if dst, err = openStore(c, c.String("dst")); err != nil {
+		return cli.Exit(fmt.Errorf("could not open destination store: %s", err), 1)
+	}
+	defer dst.Close()
+
+	// If the migration is interrupted, running it again will skip any records that
+	// have already been copied to the destination.
+	var report *store.MigrationReport
+	if report, err = store.Migrate(src, dst); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	fmt.Printf("%-10s %8s %8s %8s\n", "namespace", "copied", "replaced", "skipped")
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceVASPs, report.VASPs.Copied, report.VASPs.Replaced, report.VASPs.Skipped)
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceCerts, report.Certs.Copied, report.Certs.Replaced, report.Certs.Skipped)
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceCertReqs, report.CertReqs.Copied, report.CertReqs.Replaced, report.CertReqs.Skipped)
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceActions, report.Actions.Copied, report.Actions.Replaced, report.Actions.Skipped)
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceAudit, report.Audit.Copied, report.Audit.Replaced, report.Audit.Skipped)
+	fmt.Printf("%-10s %8d %8d %8d\n", wire.NamespaceRevisions, report.Revisions.Copied, report.Revisions.Replaced, report.Revisions.Skipped)
+
+	if c.Bool("skip-verify") {
+		return nil
+	}
+
+	var srcsum, dstsum *store.StoreChecksum
+	if srcsum, dstsum, err = store.Verify(src, dst); err != nil && !errors.Is(err, store.ErrMigrationMismatch) {
+		return cli.Exit(err, 1)
+	}
+
+	fmt.Println()
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceVASPs, srcsum.VASPs, dstsum.VASPs)
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceCerts, srcsum.Certs, dstsum.Certs)
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceCertReqs, srcsum.CertReqs, dstsum.CertReqs)
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceActions, srcsum.Actions, dstsum.Actions)
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceAudit, srcsum.Audit, dstsum.Audit)
+	fmt.Printf("%s\n  src: %s\n  dst: %s\n", wire.NamespaceRevisions, srcsum.Revisions, dstsum.Revisions)
+
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	fmt.Println("migration verified")
+	return nil
+}
+
+//===========================================================================
+// Backup Actions
+//===========================================================================
+
+func backupList(c *cli.Context) (err error) {
+	storage := c.String("storage")
+	if storage == "" {
+		return cli.Exit("specify the backup storage directory", 1)
+	}
+
+	var archives []*backups.Archive
+	if archives, err = backups.List(storage); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	fmt.Printf("%-32s %-8s %-20s %12s\n", "archive", "format", "created", "size")
+	for _, archive := range archives {
+		fmt.Printf("%-32s %-8s %-20s %12d\n", filepath.Base(archive.Path), archive.Format, archive.Created.Format(time.RFC3339), archive.Size)
+	}
+	return nil
+}
+
+func backupVerify(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify the path to the backup archive", 1)
+	}
+
+	var archive *backups.Archive
+	if archive, err = backups.Open(c.Args().First()); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var summary *backups.Summary
+	if summary, err = archive.Verify(); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	namespaces := make([]string, 0, len(summary.Namespaces))
+	for namespace := range summary.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	fmt.Printf("%-10s %8s\n", "namespace", "objects")
+	for _, namespace := range namespaces {
+		fmt.Printf("%-10s %8d\n", namespace, summary.Namespaces[namespace])
+	}
+	fmt.Printf("\n%s verified: %d objects, %d tombstones\n", filepath.Base(archive.Path), summary.Objects, summary.Tombstones)
+	return nil
+}
+
+func backupRestore(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify the path to the backup archive", 1)
+	}
+
+	var archive *backups.Archive
+	if archive, err = backups.Open(c.Args().First()); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	// The directory indices are cleared on a partial restore so they are rebuilt from
+	// the restored records when the GDS next opens the database.
+	opts := backups.RestoreOptions{
+		Namespaces: c.StringSlice("namespaces"),
+		Derived:    []string{wire.NamespaceIndices},
+		DryRun:     c.Bool("dryrun"),
+	}
+
+	var dsn *store.DSN
+	if dsn, err = store.ParseDSN(c.String("db")); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var target backups.Target
+	switch dsn.Scheme {
+	case "leveldb":
+		// The leveldb store must not be opened by a running GDS
+		var db *leveldb.DB
+		if db, err = leveldb.OpenFile(dsn.Path, nil); err != nil {
+			return cli.Exit(fmt.Errorf("could not open leveldb store: %s", err), 1)
+		}
+		defer db.Close()
+		target = backups.LevelDB(db)
+	case "trtl":
+		var conn *grpc.ClientConn
+		if conn, err = trtlstore.Connect(config.DatabaseConfig{
+			URL:      c.String("db"),
+			Insecure: c.Bool("insecure"),
+			CertPath: c.String("certs"),
+			PoolPath: c.String("pool"),
+		}); err != nil {
+			return cli.Exit(fmt.Errorf("could not connect to trtl store: %s", err), 1)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		target = backups.Trtl(ctx, trtlpb.NewTrtlClient(conn))
+
+		// Reserved namespaces cannot be written through the trtl API
+		opts.Skip = trtl.Reserved
+	default:
+		return cli.Exit(fmt.Errorf("cannot restore backups into %s stores", dsn.Scheme), 1)
+	}
+
+	var report *backups.RestoreReport
+	if report, err = archive.Restore(target, opts); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	namespaces := make([]string, 0, len(report.Namespaces))
+	for namespace := range report.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	fmt.Printf("%-10s %8s %8s %8s %8s\n", "namespace", "created", "updated", "deleted", "unchanged")
+	for _, namespace := range namespaces {
+		changes := report.Namespaces[namespace]
+		fmt.Printf("%-10s %8d %8d %8d %8d\n", namespace, changes.Created, changes.Updated, changes.Deleted, changes.Unchanged)
+	}
+
+	if report.DryRun {
+		fmt.Printf("\ndry run: restoring %s would change %d objects\n", filepath.Base(archive.Path), report.Changed())
+		return nil
+	}
+	fmt.Printf("\nrestored %s: %d objects changed\n", filepath.Base(archive.Path), report.Changed())
+	return nil
+}
+
 //===========================================================================
 // Helper Functions
 //===========================================================================
 
+// openStore opens a directory store from the dsn, using the command line flags to
+// configure the connection to trtl stores. Stores are opened without reindexing.
+func openStore(c *cli.Context, dsn string) (store.Store, error) {
+	return store.Open(config.DatabaseConfig{
+		URL:      dsn,
+		Insecure: c.Bool("insecure"),
+		CertPath: c.String("certs"),
+		PoolPath: c.String("pool"),
+	})
+}
+
 // loadProfile runs before every command so it cannot return an error; if it cannot
 // load the profile, it will attempt to create a default profile unless a named profile
 // was given.
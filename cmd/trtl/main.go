@@ -2,11 +2,13 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rotationalio/honu"
@@ -17,10 +19,15 @@ import (
 	profiles "github.com/trisacrypto/directory/pkg/gds/client"
 	"github.com/trisacrypto/directory/pkg/trtl"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
@@ -98,6 +105,87 @@ func main() {
 			Category:  "server",
 			Action:    migrate,
 		},
+		{
+			Name:     "replica:bootstrap",
+			Usage:    "seed the local trtl database with a snapshot from a peer before serving",
+			Category: "server",
+			Action:   bootstrap,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "from",
+					Aliases:  []string{"f"},
+					Usage:    "address of the peer to transfer the snapshot from",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "db",
+					Aliases: []string{"d"},
+					Usage:   "dsn of the trtl database to bootstrap",
+					EnvVars: []string{"TRTL_DATABASE_URL"},
+				},
+				&cli.StringSliceFlag{
+					Name:    "namespaces",
+					Aliases: []string{"n"},
+					Usage:   "specify the namespaces to transfer (if empty, all replicated namespaces are transferred)",
+				},
+				&cli.IntFlag{
+					Name:    "chunk-size",
+					Aliases: []string{"c"},
+					Usage:   "maximum size in bytes of each chunk of the snapshot",
+				},
+				&cli.DurationFlag{
+					Name:    "timeout",
+					Aliases: []string{"t"},
+					Usage:   "maximum amount of time to wait for the snapshot transfer",
+					Value:   30 * time.Minute,
+				},
+			},
+		},
+		{
+			Name:     "backup:list",
+			Usage:    "list the backup archives in the backup storage directory",
+			Category: "backup",
+			Action:   backupList,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "storage",
+					Aliases: []string{"s"},
+					Usage:   "path to the backup storage directory",
+					EnvVars: []string{"TRTL_BACKUP_STORAGE"},
+				},
+			},
+		},
+		{
+			Name:      "backup:verify",
+			Usage:     "check the integrity of a backup archive and summarize its contents",
+			ArgsUsage: "archive",
+			Category:  "backup",
+			Action:    backupVerify,
+		},
+		{
+			Name:      "backup:restore",
+			Usage:     "restore a backup archive into a running or offline trtl database",
+			ArgsUsage: "archive",
+			Category:  "backup",
+			Action:    backupRestore,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "db",
+					Aliases: []string{"d"},
+					Usage:   "dsn of an offline trtl database to restore (otherwise restored via the trtl endpoint)",
+				},
+				&cli.StringSliceFlag{
+					Name:    "namespaces",
+					Aliases: []string{"n"},
+					Usage:   "specify the namespaces to restore (if empty, all namespaces in the archive are restored, otherwise the indices are cleared so the gds rebuilds them)",
+				},
+				&cli.BoolFlag{
+					Name:    "dryrun",
+					Aliases: []string{"D"},
+					Usage:   "show changes that would occur, does not modify database",
+				},
+			},
+		},
 		{
 			Name:     "status",
 			Usage:    "check the status of the trtl database and replication service",
@@ -213,6 +301,25 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:     "db:compact",
+			Usage:    "remove expired tombstones that have been seen by all peers",
+			Category: "client",
+			Before:   initDBClient,
+			Action:   dbCompact,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "namespace",
+					Aliases: []string{"n"},
+					Usage:   "specify a replicated namespace to compact (optional)",
+				},
+				&cli.BoolFlag{
+					Name:    "dry-run",
+					Aliases: []string{"d"},
+					Usage:   "report the tombstones that would be removed without removing them",
+				},
+			},
+		},
 		{
 			Name:     "peers:add",
 			Usage:    "add peers to the network by pid",
@@ -278,6 +385,56 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:      "namespaces:list",
+			Usage:     "list the replication policies of the namespaces",
+			ArgsUsage: "[namespace ...]",
+			Category:  "client",
+			Before:    initNamespacesClient,
+			Action:    listPolicies,
+		},
+		{
+			Name:      "namespaces:set",
+			Usage:     "set the replication policy of a namespace",
+			ArgsUsage: "namespace",
+			Category:  "client",
+			Before:    initNamespacesClient,
+			Action:    setPolicy,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "local",
+					Aliases: []string{"l"},
+					Usage:   "do not replicate the namespace to any peers",
+				},
+				&cli.StringSliceFlag{
+					Name:    "region",
+					Aliases: []string{"r"},
+					Usage:   "specify the regions the namespace is replicated in (default all regions)",
+				},
+				&cli.BoolFlag{
+					Name:    "same-region",
+					Aliases: []string{"s"},
+					Usage:   "only replicate the namespace with peers in the same region",
+				},
+				&cli.StringFlag{
+					Name:    "interval",
+					Aliases: []string{"i"},
+					Usage:   "specify the mean interval between anti-entropy sessions for the namespace",
+				},
+				&cli.StringFlag{
+					Name:  "sigma",
+					Usage: "specify the standard deviation of the interval between sessions",
+				},
+			},
+		},
+		{
+			Name:      "namespaces:rm",
+			Usage:     "remove the replication policy of a namespace, reverting it to its default",
+			ArgsUsage: "namespace",
+			Category:  "client",
+			Before:    initNamespacesClient,
+			Action:    rmPolicy,
+		},
 		{
 			Name:      "gossip",
 			Usage:     "initiate a gossip session with a remote replica (for debugging)",
@@ -491,12 +648,147 @@ func migrate(c *cli.Context) (err error) {
 	return nil
 }
 
+// bootstrap the local trtl database with a snapshot transferred from a peer.
+func bootstrap(c *cli.Context) (err error) {
+	// Load the configuration from the environment
+	var conf config.Config
+	if conf, err = config.New(); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if dburl := c.String("db"); dburl != "" {
+		conf.Database.URL = dburl
+	}
+
+	var db *honu.DB
+	if db, err = honu.Open(conf.Database.URL, conf.GetHonuConfig()); err != nil {
+		return cli.Exit(fmt.Errorf("could not open db at %q: %s", conf.Database.URL, err), 1)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+	defer cancel()
+
+	var cc *grpc.ClientConn
+	if cc, err = replica.Dial(ctx, conf.MTLS, c.String("from")); err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer cc.Close()
+
+	req := &snapshot.SnapshotRequest{
+		Namespaces: c.StringSlice("namespaces"),
+		ChunkSize:  int32(c.Int("chunk-size")),
+		Region:     conf.Replica.Region,
+	}
+
+	var stats *replica.BootstrapStats
+	if stats, err = replica.Bootstrap(ctx, db, snapshot.NewSnapshotClient(cc), req); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	fmt.Printf("bootstrapped %d objects from %s (%d already up to date)\n", stats.Updated, c.String("from"), stats.Skipped)
+	return nil
+}
+
+//===========================================================================
+// Backup Functions
+//===========================================================================
+
+// backupList prints the backup archives in the backup storage directory.
+func backupList(c *cli.Context) (err error) {
+	storage := c.String("storage")
+	if storage == "" {
+		return cli.Exit("specify the backup storage directory", 1)
+	}
+
+	var archives []*backups.Archive
+	if archives, err = backups.List(storage); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(archives)
+}
+
+// backupVerify checks the integrity of a backup archive.
+func backupVerify(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify the path to the backup archive", 1)
+	}
+
+	var archive *backups.Archive
+	if archive, err = backups.Open(c.Args().First()); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	var summary *backups.Summary
+	if summary, err = archive.Verify(); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(summary)
+}
+
+// backupRestore restores a backup archive into an offline trtl database if a dsn is
+// specified, otherwise into the running trtl database of the active profile.
+func backupRestore(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify the path to the backup archive", 1)
+	}
+
+	var archive *backups.Archive
+	if archive, err = backups.Open(c.Args().First()); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	// The directory indices are cleared on a partial restore so they are rebuilt from
+	// the restored records when the GDS next opens the database.
+	opts := backups.RestoreOptions{
+		Namespaces: c.StringSlice("namespaces"),
+		Derived:    []string{wire.NamespaceIndices},
+		DryRun:     c.Bool("dryrun"),
+	}
+
+	var target backups.Target
+	if dburl := c.String("db"); dburl != "" {
+		// Load the replica configuration from the environment so that restored objects
+		// are versioned by the replica that owns the database.
+		var conf config.Config
+		if conf, err = config.New(); err != nil {
+			return cli.Exit(err, 1)
+		}
+		conf.Database.URL = dburl
+
+		var db *honu.DB
+		if db, err = honu.Open(conf.Database.URL, conf.GetHonuConfig()); err != nil {
+			return cli.Exit(fmt.Errorf("could not open db at %q: %s", conf.Database.URL, err), 1)
+		}
+		defer db.Close()
+		target = backups.Honu(db)
+	} else {
+		if err = initDBClient(c); err != nil {
+			return err
+		}
+
+		ctx, cancel := profile.Context()
+		defer cancel()
+		target = backups.Trtl(ctx, dbClient)
+
+		// Reserved namespaces cannot be written through the trtl API
+		opts.Skip = trtl.Reserved
+	}
+
+	var report *backups.RestoreReport
+	if report, err = archive.Restore(target, opts); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(report)
+}
+
 //===========================================================================
 // Initialization Functions
 //===========================================================================
 
 var dbClient pb.TrtlClient
 var peersClient peers.PeerManagementClient
+var namespacesClient namespaces.NamespaceManagementClient
 
 // initDBClient starts a trtl client with a connection to a trtl database.
 func initDBClient(c *cli.Context) (err error) {
@@ -526,6 +818,20 @@ func initPeersClient(c *cli.Context) (err error) {
 	return nil
 }
 
+// initNamespacesClient starts a trtl client with a connection to a trtl database.
+func initNamespacesClient(c *cli.Context) (err error) {
+	if profile.TrtlProfiles == nil {
+		return cli.Exit("no trtl profile was loaded", 1)
+	}
+	if len(profile.TrtlProfiles) <= c.Int("trtl-index") {
+		return cli.Exit("could not find trtl profile by index", 1)
+	}
+	if namespacesClient, err = profile.TrtlProfiles[c.Int("trtl-index")].ConnectNamespaces(); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return nil
+}
+
 //===========================================================================
 // Trtl (DB) Client Functions
 //===========================================================================
@@ -676,6 +982,23 @@ func dbList(c *cli.Context) (err error) {
 	return nil
 }
 
+// dbCompact removes expired tombstones from the trtl database
+func dbCompact(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	req := &pb.CompactRequest{
+		Namespace: c.String("namespace"),
+		DryRun:    c.Bool("dry-run"),
+	}
+
+	var rep *pb.CompactReply
+	if rep, err = dbClient.Compact(ctx, req); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(rep)
+}
+
 // status prints the status of the trtl service.
 func status(c *cli.Context) (err error) {
 	ctx, cancel := profile.Context()
@@ -766,6 +1089,63 @@ func listPeers(c *cli.Context) (err error) {
 	return nil
 }
 
+//===========================================================================
+// Namespace Policies (Replica) Client Functions
+//===========================================================================
+
+// listPolicies calls the namespace management service to list replication policies.
+func listPolicies(c *cli.Context) (err error) {
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	var out *namespaces.PolicyList
+	if out, err = namespacesClient.GetPolicies(ctx, &namespaces.PolicyFilter{Namespaces: c.Args().Slice()}); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(out)
+}
+
+// setPolicy calls the namespace management service to set a replication policy.
+func setPolicy(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify exactly one namespace to set the policy of", 1)
+	}
+
+	policy := &namespaces.Policy{
+		Namespace:      c.Args().First(),
+		Replicated:     !c.Bool("local"),
+		Regions:        c.StringSlice("region"),
+		SameRegion:     c.Bool("same-region"),
+		GossipInterval: c.String("interval"),
+		GossipSigma:    c.String("sigma"),
+	}
+
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	var out *namespaces.Policy
+	if out, err = namespacesClient.SetPolicy(ctx, policy); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(out)
+}
+
+// rmPolicy calls the namespace management service to remove a replication policy.
+func rmPolicy(c *cli.Context) (err error) {
+	if c.NArg() != 1 {
+		return cli.Exit("specify exactly one namespace to remove the policy of", 1)
+	}
+
+	ctx, cancel := profile.Context()
+	defer cancel()
+
+	var out *namespaces.Policy
+	if out, err = namespacesClient.RmPolicy(ctx, &namespaces.Policy{Namespace: c.Args().First()}); err != nil {
+		return cli.Exit(err, 1)
+	}
+	return printJSON(out)
+}
+
 //===========================================================================
 // Anti-Entropy (Replica) Admin Functions
 //===========================================================================
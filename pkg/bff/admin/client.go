@@ -71,11 +71,18 @@ func (c *Credentials) Generate(api admin.DirectoryAdministrationClient) (err err
 		return nil
 	}
 
+	// The BFF only requires read-only access to the admin API
+	var permissions []string
+	if permissions, err = tokens.RolePermissions(tokens.ReadOnlyRole); err != nil {
+		return err
+	}
+
 	claims := map[string]interface{}{
-		"hd":      HD,
-		"email":   Email,
-		"name":    Name,
-		"picture": "",
+		"hd":          HD,
+		"email":       Email,
+		"name":        Name,
+		"picture":     "",
+		"permissions": permissions,
 	}
 
 	// Create the access token from the claims
@@ -180,7 +180,7 @@ func (s *bffTestSuite) TestAttention() {
 
 	// Start registration message should still be returned if the registration form state is empty
 	org.Registration = &records.RegistrationForm{}
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	reply, err = s.client.Attention(context.TODO())
 	require.NoError(err, "received error from attention endpoint")
 	require.Len(reply.Messages, 1, "expected start registration message")
@@ -188,7 +188,7 @@ func (s *bffTestSuite) TestAttention() {
 
 	// Start registration message should still be returned if the registration form has not been started
 	org.Registration.State = records.NewFormState()
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	reply, err = s.client.Attention(context.TODO())
 	require.NoError(err, "received error from attention endpoint")
 	require.Len(reply.Messages, 1, "expected start registration message")
@@ -196,7 +196,7 @@ func (s *bffTestSuite) TestAttention() {
 
 	// Complete registration message should be returned when the registration form has been started but not submitted
 	org.Registration.State.Started = time.Now().Format(time.RFC3339)
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	expected = &api.AttentionMessage{
 		Message:  bff.CompleteRegistration,
 		Severity: records.AttentionSeverity_INFO.String(),
@@ -211,7 +211,7 @@ func (s *bffTestSuite) TestAttention() {
 	org.Testnet = &records.DirectoryRecord{
 		Submitted: time.Now().Format(time.RFC3339),
 	}
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	expected = &api.AttentionMessage{
 		Message:  bff.SubmitMainnet,
 		Severity: records.AttentionSeverity_INFO.String(),
@@ -227,7 +227,7 @@ func (s *bffTestSuite) TestAttention() {
 	org.Mainnet = &records.DirectoryRecord{
 		Submitted: time.Now().Format(time.RFC3339),
 	}
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	submitTestnet := &api.AttentionMessage{
 		Message:  bff.SubmitTestnet,
 		Severity: records.AttentionSeverity_INFO.String(),
@@ -371,7 +371,7 @@ func (s *bffTestSuite) TestAttention() {
 	claims.VASPs["testnet"] = "alice0a0-a0a0-a0a0-a0a0-a0a0a0a0a0a0"
 	require.NoError(s.SetClientCredentials(claims), "could not create token with valid claims")
 	org.Testnet.Submitted = time.Now().Format(time.RFC3339)
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	vasp = &pb.VASP{}
 	require.NoError(wire.Unwire(testnetReply.VASP, vasp))
 	expires := time.Now().AddDate(0, 0, 28)
@@ -460,7 +460,7 @@ func (s *bffTestSuite) TestRegistrationStatus() {
 	org.Testnet = &records.DirectoryRecord{
 		Submitted: time.Now().Format(time.RFC3339),
 	}
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	reply, err = s.client.RegistrationStatus(context.TODO())
 	require.NoError(err, "received error from registration status endpoint")
 	require.Equal(org.Testnet.Submitted, reply.TestNetSubmitted, "expected testnet timestamp to be returned")
@@ -471,7 +471,7 @@ func (s *bffTestSuite) TestRegistrationStatus() {
 	org.Mainnet = &records.DirectoryRecord{
 		Submitted: time.Now().Format(time.RFC3339),
 	}
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	reply, err = s.client.RegistrationStatus(context.TODO())
 	require.NoError(err, "received error from registration status endpoint")
 	require.Equal(org.Mainnet.Submitted, reply.MainNetSubmitted, "expected mainnet timestamp to be returned")
@@ -480,7 +480,7 @@ func (s *bffTestSuite) TestRegistrationStatus() {
 	// Should return both timestamps when both registrations have been submitted
 	org.Testnet.Submitted = time.Now().Format(time.RFC3339)
 	org.Mainnet.Submitted = time.Now().Format(time.RFC3339)
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization in the database")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization in the database")
 	reply, err = s.client.RegistrationStatus(context.TODO())
 	require.NoError(err, "received error from registration status endpoint")
 	require.Equal(org.Testnet.Submitted, reply.TestNetSubmitted, "expected testnet timestamp to be returned")
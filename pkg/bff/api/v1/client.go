@@ -53,6 +53,7 @@ type APIv1 struct {
 	endpoint *url.URL
 	client   *http.Client
 	creds    Credentials
+	formETag string
 }
 
 // Ensure the API implments the BFFClient interface.
@@ -163,14 +164,20 @@ func (s *APIv1) LoadRegistrationForm(ctx context.Context) (form *models.Registra
 		return nil, err
 	}
 
+	var rep *http.Response
 	form = &models.RegistrationForm{}
-	if _, err = s.Do(req, form, true); err != nil {
+	if rep, err = s.Do(req, form, true); err != nil {
 		return nil, err
 	}
+
+	// Keep track of the version of the form so that it can be saved
+	s.formETag = rep.Header.Get("ETag")
 	return form, nil
 }
 
-// Save registration form data to the server in preparation for submitting it.
+// Save registration form data to the server in preparation for submitting it. The form
+// must have been loaded by the client first; if the form has been saved by another
+// client since it was loaded then the save fails with a conflict.
 func (s *APIv1) SaveRegistrationForm(ctx context.Context, form *models.RegistrationForm) (err error) {
 	// Make the HTTP request
 	var req *http.Request
@@ -178,9 +185,17 @@ func (s *APIv1) SaveRegistrationForm(ctx context.Context, form *models.Registrat
 		return err
 	}
 
-	if _, err = s.Do(req, nil, true); err != nil {
+	if s.formETag != "" {
+		req.Header.Set("If-Match", s.formETag)
+	}
+
+	var rep *http.Response
+	if rep, err = s.Do(req, nil, true); err != nil {
 		return err
 	}
+
+	// Keep track of the version of the saved form so that it can be saved again
+	s.formETag = rep.Header.Get("ETag")
 	return nil
 }
 
@@ -279,10 +279,15 @@ func TestSaveRegistrationForm(t *testing.T) {
 	err := loadFixture("testdata/registration.pb.json", fixture)
 	require.NoError(t, err, "could not load registration fixture")
 
-	// Create a Test Server
+	// Create a Test Server that expects the ETag of the last save in the If-Match header
+	var etag string
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(t, http.MethodPut, r.Method)
 		require.Equal(t, "/v1/register", r.URL.Path)
+		require.Equal(t, etag, r.Header.Get("If-Match"))
+
+		etag = fmt.Sprintf("%q", time.Now().Format(time.RFC3339Nano))
+		w.Header().Set("ETag", etag)
 		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer ts.Close()
@@ -293,6 +298,9 @@ func TestSaveRegistrationForm(t *testing.T) {
 
 	err = client.SaveRegistrationForm(context.TODO(), fixture)
 	require.NoError(t, err)
+
+	err = client.SaveRegistrationForm(context.TODO(), fixture)
+	require.NoError(t, err)
 }
 
 func TestSubmitRegistration(t *testing.T) {
@@ -98,15 +98,65 @@ func (db *DB) Get(ctx context.Context, key []byte, namespace string) (value []by
 	return rep.Value, nil
 }
 
+// GetVersion fetches the value of a key along with the version of the object so that
+// the value can be modified and stored with CheckAndPut for optimistic concurrency.
+func (db *DB) GetVersion(ctx context.Context, key []byte, namespace string) (value []byte, version *trtl.Version, err error) {
+	req := &trtl.GetRequest{
+		Key:       key,
+		Namespace: namespace,
+		Options: &trtl.Options{
+			ReturnMeta: true,
+		},
+	}
+
+	var rep *trtl.GetReply
+	if rep, err = db.trtl.Get(ctx, req); err != nil {
+		if serr, ok := status.FromError(err); ok {
+			if serr.Code() == codes.NotFound {
+				return nil, nil, ErrNotFound
+			}
+		}
+		return nil, nil, err
+	}
+	return rep.Value, rep.Meta.GetVersion(), nil
+}
+
 // Put is a high-level method for executing a put value to key request to a namespace in trtl.
 func (db *DB) Put(ctx context.Context, key, value []byte, namespace string) (err error) {
+	return db.put(ctx, key, value, namespace, &trtl.Options{ReturnMeta: false})
+}
+
+// Create puts the value to the key only if the key does not already exist, returning
+// ErrAlreadyExists otherwise.
+func (db *DB) Create(ctx context.Context, key, value []byte, namespace string) (err error) {
+	if err = db.put(ctx, key, value, namespace, &trtl.Options{IfAbsent: true}); err != nil {
+		if serr, ok := status.FromError(err); ok && serr.Code() == codes.FailedPrecondition {
+			return ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckAndPut puts the value to the key only if the object has not been modified since
+// the specified version was fetched, returning ErrConcurrentUpdate otherwise. If the
+// version is nil the value is put unconditionally.
+func (db *DB) CheckAndPut(ctx context.Context, key, value []byte, namespace string, version *trtl.Version) (err error) {
+	if err = db.put(ctx, key, value, namespace, &trtl.Options{ExpectedVersion: version}); err != nil {
+		if serr, ok := status.FromError(err); ok && serr.Code() == codes.FailedPrecondition {
+			return ErrConcurrentUpdate
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *DB) put(ctx context.Context, key, value []byte, namespace string, opts *trtl.Options) (err error) {
 	req := &trtl.PutRequest{
 		Key:       key,
 		Value:     value,
 		Namespace: namespace,
-		Options: &trtl.Options{
-			ReturnMeta: false,
-		},
+		Options:   opts,
 	}
 
 	var rep *trtl.PutReply
@@ -104,3 +104,43 @@ func (s *dbTestSuite) TestBasicOperations() {
 	_, err = s.db.Get(ctx, key, namespace)
 	require.ErrorIs(err, db.ErrNotFound, "expected not found error after Delete")
 }
+
+func (s *dbTestSuite) TestConditionalOperations() {
+	// Test Create, GetVersion, and CheckAndPut against the database
+	var err error
+	require := s.Require()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	key := []byte("thisistheconditionalkey")
+	namespace := "thisisthetestnamespace"
+	defer s.db.Delete(ctx, key, namespace)
+
+	// Version should not be returned when the key is not there
+	_, _, err = s.db.GetVersion(ctx, key, namespace)
+	require.ErrorIs(err, db.ErrNotFound, "expected not found error before Create")
+
+	// Should be able to Create the key only once
+	err = s.db.Create(ctx, key, []byte("first"), namespace)
+	require.NoError(err, "could not Create the key in the database")
+
+	err = s.db.Create(ctx, key, []byte("second"), namespace)
+	require.ErrorIs(err, db.ErrAlreadyExists, "expected already exists error on second Create")
+
+	value, version, err := s.db.GetVersion(ctx, key, namespace)
+	require.NoError(err, "could not fetch version of key just created")
+	require.Equal([]byte("first"), value)
+	require.NotNil(version, "no version returned")
+
+	// Should be able to put with the current version but not with a stale version
+	err = s.db.CheckAndPut(ctx, key, []byte("second"), namespace, version)
+	require.NoError(err, "could not CheckAndPut with the current version")
+
+	err = s.db.CheckAndPut(ctx, key, []byte("third"), namespace, version)
+	require.ErrorIs(err, db.ErrConcurrentUpdate, "expected concurrent update error with a stale version")
+
+	retrieved, err := s.db.Get(ctx, key, namespace)
+	require.NoError(err, "could not fetch key")
+	require.Equal([]byte("second"), retrieved, "stale put should not have modified the value")
+}
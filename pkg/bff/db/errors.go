@@ -6,6 +6,8 @@ var (
 	ErrNotFound           = errors.New("key not found in database")
 	ErrUnsuccessfulPut    = errors.New("unable to successfully make Put request to trtl")
 	ErrUnsuccessfulDelete = errors.New("unable to successfully make Delete request to trtl")
+	ErrAlreadyExists      = errors.New("key already exists in database")
+	ErrConcurrentUpdate   = errors.New("record was modified by another request")
 	ErrEmptyAnnouncement  = errors.New("cannot post a zero-valued announcement")
 	ErrUnboundedRecent    = errors.New("cannot specify zero-valued not before otherwise announcements fetch is unbounded")
 )
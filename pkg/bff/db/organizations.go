@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/trisacrypto/directory/pkg/bff/db/models/v1"
+	trtl "github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -56,7 +57,7 @@ func (o *Organizations) Create(ctx context.Context) (org *models.Organization, e
 		return nil, err
 	}
 
-	if err = o.db.Put(ctx, uu[:], value, o.namespace); err != nil {
+	if err = o.db.Create(ctx, uu[:], value, o.namespace); err != nil {
 		return nil, err
 	}
 
@@ -65,35 +66,50 @@ func (o *Organizations) Create(ctx context.Context) (org *models.Organization, e
 
 // Retrieve an organization by it's ID, which can be either a []byte, string, or uuid.
 func (o *Organizations) Retrieve(ctx context.Context, orgID interface{}) (org *models.Organization, err error) {
+	org, _, err = o.RetrieveVersion(ctx, orgID)
+	return org, err
+}
+
+// RetrieveVersion retrieves an organization along with the trtl version of the record
+// so that the organization can be modified and stored with Update without overwriting
+// the changes made by concurrent requests.
+func (o *Organizations) RetrieveVersion(ctx context.Context, orgID interface{}) (org *models.Organization, version *trtl.Version, err error) {
 	var uu uuid.UUID
 	if uu, err = models.ParseOrgID(orgID); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var data []byte
-	if data, err = o.db.Get(ctx, uu[:], o.namespace); err != nil {
-		return nil, err
+	if data, version, err = o.db.GetVersion(ctx, uu[:], o.namespace); err != nil {
+		return nil, nil, err
 	}
 
 	org = &models.Organization{}
 	if err = proto.Unmarshal(data, org); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return org, nil
+	return org, version, nil
 }
 
-// Update an organization with the record supplied.
-func (o *Organizations) Update(ctx context.Context, org *models.Organization) (err error) {
-	// Set modified timestamp and serialize
+// Update an organization with the record supplied. The version should be the version
+// returned by RetrieveVersion when the record was retrieved; if the organization has
+// been modified since then, ErrConcurrentUpdate is returned. If the version is nil the
+// organization is overwritten unconditionally.
+func (o *Organizations) Update(ctx context.Context, org *models.Organization, version *trtl.Version) (err error) {
+	// Set modified timestamp and serialize, restoring the original timestamp if the
+	// update fails so that the caller's record still matches the stored organization.
+	modified := org.Modified
 	org.Modified = time.Now().Format(time.RFC3339Nano)
 
 	var data []byte
 	if data, err = proto.Marshal(org); err != nil {
+		org.Modified = modified
 		return err
 	}
 
-	if err = o.db.Put(ctx, org.Key(), data, o.namespace); err != nil {
+	if err = o.db.CheckAndPut(ctx, org.Key(), data, o.namespace, version); err != nil {
+		org.Modified = modified
 		return err
 	}
 	return nil
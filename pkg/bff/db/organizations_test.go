@@ -27,7 +27,7 @@ func (s *dbTestSuite) TestOrganizations() {
 
 	// Update an organization
 	org.Name = "BestCoin SuperFun"
-	err = s.db.Organizations().Update(ctx, org)
+	err = s.db.Organizations().Update(ctx, org, nil)
 	require.NoError(err, "could not update organization")
 
 	// Retrieve an organization
@@ -41,6 +41,27 @@ func (s *dbTestSuite) TestOrganizations() {
 	require.Equal(org.Created, ret.Created, "original and retrieved created should be the same")
 	require.NotEqual(org.Created, ret.Modified, "original created and retrieved modified should not be the same")
 
+	// Updating a stale copy of the organization should fail without modifying it
+	current, version, err := s.db.Organizations().RetrieveVersion(ctx, org.Id)
+	require.NoError(err, "could not retrieve organization version")
+	require.NotNil(version, "expected the version of the organization to be returned")
+	stale, staleVersion, err := s.db.Organizations().RetrieveVersion(ctx, org.Id)
+	require.NoError(err, "could not retrieve organization version")
+
+	current.Name = "BestCoin Current"
+	err = s.db.Organizations().Update(ctx, current, version)
+	require.NoError(err, "could not update organization with the retrieved version")
+
+	modified := stale.Modified
+	stale.Name = "BestCoin Stale"
+	err = s.db.Organizations().Update(ctx, stale, staleVersion)
+	require.ErrorIs(err, ErrConcurrentUpdate, "expected concurrent update error on stale organization")
+	require.Equal(modified, stale.Modified, "modified timestamp should be restored on failed update")
+
+	ret, err = s.db.Organizations().Retrieve(ctx, org.Id)
+	require.NoError(err, "could not retrieve organization")
+	require.Equal(current.Name, ret.Name, "stale update should not have modified the organization")
+
 	// Delete an organization
 	err = s.db.Organizations().Delete(ctx, ret.Id)
 	require.NoError(err, "could not delete organization")
@@ -2,6 +2,7 @@ package bff
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,7 +11,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/bff/api/v1"
+	"github.com/trisacrypto/directory/pkg/bff/db"
 	records "github.com/trisacrypto/directory/pkg/bff/db/models/v1"
+	trtl "github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	"google.golang.org/grpc/codes"
@@ -210,7 +213,9 @@ func (s *Server) VerifyContact(c *gin.Context) {
 	c.JSON(http.StatusOK, out)
 }
 
-// Returns the user's current registration form if it's available
+// Returns the user's current registration form if it's available. The ETag header of
+// the response identifies the version of the form, which must be sent back in the
+// If-Match header when the form is saved.
 func (s *Server) LoadRegisterForm(c *gin.Context) {
 	// Load the organization from the claims
 	// NOTE: this method will handle the error logging and response.
@@ -223,17 +228,23 @@ func (s *Server) LoadRegisterForm(c *gin.Context) {
 	if org.Registration == nil {
 		org.Registration = records.NewRegisterForm()
 	}
+	c.Header("ETag", registerFormETag(org))
 	c.JSON(http.StatusOK, org.Registration)
 }
 
 // Saves the registration form on the BFF to allow multiple users to edit the
-// registration form before it is submitted to the directory service.
+// registration form before it is submitted to the directory service. The If-Match
+// header must contain the ETag of the form that was loaded or last saved by the client;
+// if the form has been saved by another session since then a 409 is returned so that
+// the user does not overwrite changes they haven't seen. The ETag of the saved form is
+// returned so that the client can continue to save the form.
 func (s *Server) SaveRegisterForm(c *gin.Context) {
 	// Parse the incoming JSON data from the client request
 	var (
-		err  error
-		form *records.RegistrationForm
-		org  *records.Organization
+		err     error
+		form    *records.RegistrationForm
+		org     *records.Organization
+		version *trtl.Version
 	)
 
 	// Unmarshal the registration form from the POST request
@@ -246,7 +257,23 @@ func (s *Server) SaveRegisterForm(c *gin.Context) {
 
 	// Load the organization from the claims
 	// NOTE: this method will handle the error logging and response.
-	if org, err = s.OrganizationFromClaims(c); err != nil {
+	if org, version, err = s.OrganizationVersionFromClaims(c); err != nil {
+		return
+	}
+
+	// The client must be saving the version of the form that is currently stored; the
+	// organization version ensures that the form is not saved if another session saves
+	// it between this check and the update.
+	match := c.GetHeader("If-Match")
+	if match == "" {
+		log.Warn().Str("orgID", org.Id).Msg("registration form saved without If-Match header")
+		c.JSON(http.StatusPreconditionRequired, api.ErrorResponse("the registration form must be loaded before it is saved"))
+		return
+	}
+
+	if match != registerFormETag(org) {
+		log.Warn().Str("orgID", org.Id).Msg("registration form was saved concurrently")
+		c.JSON(http.StatusConflict, api.ErrorResponse("registration form was modified by another session, please reload and try again"))
 		return
 	}
 
@@ -258,16 +285,29 @@ func (s *Server) SaveRegisterForm(c *gin.Context) {
 
 	// Update the organizations form
 	org.Registration = form
-	if err = s.db.Organizations().Update(c.Request.Context(), org); err != nil {
+	if err = s.db.Organizations().Update(c.Request.Context(), org, version); err != nil {
+		if errors.Is(err, db.ErrConcurrentUpdate) {
+			log.Warn().Err(err).Str("orgID", org.Id).Msg("registration form was saved concurrently")
+			c.JSON(http.StatusConflict, api.ErrorResponse("registration form was modified by another session, please reload and try again"))
+			return
+		}
 		log.Error().Err(err).Msg("could not update organization")
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not save registration form"))
 		return
 	}
 
 	// If successful respond with 204: No Content so that the front-end can continue.
+	c.Header("ETag", registerFormETag(org))
 	c.Status(http.StatusNoContent)
 }
 
+// registerFormETag returns the entity tag of the registration form on the organization.
+// The tag is derived from the modified timestamp of the organization, which is updated
+// every time the organization record is saved.
+func registerFormETag(org *records.Organization) string {
+	return `"` + org.Modified + `"`
+}
+
 // SubmitRegistration makes a request on behalf of the user to either the TestNet or the
 // MainNet GDS server based on the URL endpoint. The endpoint will first load the saved
 // registration form from the front-end and will parse it for some basic validity
@@ -283,8 +323,11 @@ func (s *Server) SubmitRegistration(c *gin.Context) {
 
 	// Load the organization from the claims
 	// NOTE: this method will handle the error logging and response.
-	var org *records.Organization
-	if org, err = s.OrganizationFromClaims(c); err != nil {
+	var (
+		org     *records.Organization
+		version *trtl.Version
+	)
+	if org, version, err = s.OrganizationVersionFromClaims(c); err != nil {
 		return
 	}
 
@@ -398,7 +441,9 @@ func (s *Server) SubmitRegistration(c *gin.Context) {
 		org.Mainnet = directoryRecord
 	}
 
-	if err = s.db.Organizations().Update(c.Request.Context(), org); err != nil {
+	if err = s.db.Organizations().Update(c.Request.Context(), org, version); err != nil {
+		// NOTE: a concurrent update cannot be returned as a conflict since the
+		// registration has already been submitted to the directory service.
 		log.Error().Err(err).Str("network", network).Msg("could not update organization with directory record")
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not complete registration submission"))
 		return
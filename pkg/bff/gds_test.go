@@ -125,7 +125,7 @@ func (s *bffTestSuite) TestLoadRegisterForm() {
 	require.NoError(err, "could not load registration form fixture")
 	require.False(proto.Equal(form, org.Registration), "expected fixture to not be empty")
 
-	err = s.db.Organizations().Update(context.TODO(), org)
+	err = s.db.Organizations().Update(context.TODO(), org, nil)
 	require.NoError(err, "could not update organization in database")
 
 	form, err = s.client.LoadRegistrationForm(context.TODO())
@@ -182,11 +182,25 @@ func (s *bffTestSuite) TestSaveRegisterForm() {
 		s.db.Organizations().Delete(context.TODO(), org.Id)
 	}()
 
-	// Create valid credentials for the remaining tests
+	// Create valid credentials for the remaining tests, the form must be loaded to be saved
 	claims.OrgID = org.Id
+	claims.Permissions = []string{"read:vasp", "update:vasp"}
 	require.NoError(s.SetClientCredentials(claims), "could not create token with valid claims")
 
+	// The form must be loaded before it can be saved
+	other, err := api.New(s.bff.GetURL())
+	require.NoError(err, "could not create a second BFF client")
+	token, err := s.auth.NewTokenWithClaims(claims)
+	require.NoError(err, "could not create token with valid claims")
+	other.(*api.APIv1).SetCredentials(api.Token(token))
+	other.(*api.APIv1).SetCSRFProtect(true)
+
+	err = other.SaveRegistrationForm(context.TODO(), form)
+	require.EqualError(err, "[428] the registration form must be loaded before it is saved", "expected error when the form was not loaded")
+
 	// Should be able to save an empty registration form
+	_, err = s.client.LoadRegistrationForm(context.TODO())
+	require.NoError(err, "could not load the registration form")
 	err = s.client.SaveRegistrationForm(context.TODO(), &records.RegistrationForm{})
 	require.NoError(err, "should not receive an error when saving an empty registration form")
 
@@ -212,6 +226,28 @@ func (s *bffTestSuite) TestSaveRegisterForm() {
 	org, err = s.db.Organizations().Retrieve(context.TODO(), org.Id)
 	require.NoError(err, "could not retrieve updated org from database")
 	require.False(proto.Equal(org.Registration, form), "expected form saved in database to be cleared")
+
+	// If two sessions load the form, only the first session to save the form succeeds
+	_, err = s.client.LoadRegistrationForm(context.TODO())
+	require.NoError(err, "could not load the registration form in the first session")
+	_, err = other.LoadRegistrationForm(context.TODO())
+	require.NoError(err, "could not load the registration form in the second session")
+
+	err = s.client.SaveRegistrationForm(context.TODO(), form)
+	require.NoError(err, "should be able to save the form in the first session")
+	err = other.SaveRegistrationForm(context.TODO(), &records.RegistrationForm{})
+	require.EqualError(err, "[409] registration form was modified by another session, please reload and try again", "expected conflict when saving a stale form")
+
+	org, err = s.db.Organizations().Retrieve(context.TODO(), org.Id)
+	require.NoError(err, "could not retrieve updated org from database")
+	org.Registration.State.Started = ""
+	require.True(proto.Equal(org.Registration, form), "expected form saved by the first session to be stored")
+
+	// After reloading the form the second session can save it
+	_, err = other.LoadRegistrationForm(context.TODO())
+	require.NoError(err, "could not reload the registration form in the second session")
+	err = other.SaveRegistrationForm(context.TODO(), &records.RegistrationForm{})
+	require.NoError(err, "should be able to save the form after reloading it")
 }
 
 func (s *bffTestSuite) TestSubmitRegistration() {
@@ -231,7 +267,7 @@ func (s *bffTestSuite) TestSubmitRegistration() {
 	// Save the registration form fixture on the organization
 	org.Registration = &records.RegistrationForm{}
 	require.NoError(loadFixture("testdata/registration_form.pb.json", org.Registration), "could not load registration form from the fixtures")
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization with registration form")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization with registration form")
 
 	// Test both the testnet and the mainnet registration
 	for _, network := range []string{"testnet", "mainnet"} {
@@ -394,7 +430,7 @@ func (s *bffTestSuite) TestSubmitRegistrationNotReady() {
 	require.False(org.Registration.ReadyToSubmit("both"), "registration should not be ready to submit")
 
 	// Save the registration form fixture on the organization
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization with registration form")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization with registration form")
 
 	// Create authenticated user context
 	claims := &authtest.Claims{
@@ -444,7 +480,7 @@ func (s *bffTestSuite) TestCannotResubmitRegistration() {
 	}
 
 	// Save the registration form fixture on the organization
-	require.NoError(s.db.Organizations().Update(context.TODO(), org), "could not update organization with registration form")
+	require.NoError(s.db.Organizations().Update(context.TODO(), org, nil), "could not update organization with registration form")
 
 	// Create authenticated user context
 	claims := &authtest.Claims{
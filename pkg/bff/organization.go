@@ -10,6 +10,7 @@ import (
 	"github.com/trisacrypto/directory/pkg/bff/auth"
 	"github.com/trisacrypto/directory/pkg/bff/db"
 	"github.com/trisacrypto/directory/pkg/bff/db/models/v1"
+	trtl "github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 )
 
 // OrganizationFromClaims is a helper method to retrieve the organization for a
@@ -17,33 +18,41 @@ import (
 // If there is an error fetching the organization, the appropriate error response is
 // made on the gin writer and logged. The caller should check for error and return.
 func (s *Server) OrganizationFromClaims(c *gin.Context) (org *models.Organization, err error) {
+	org, _, err = s.OrganizationVersionFromClaims(c)
+	return org, err
+}
+
+// OrganizationVersionFromClaims retrieves the organization for the request along with
+// the version of the organization record, which must be passed to Update so that the
+// organization is not modified if another request has updated it in the meantime.
+func (s *Server) OrganizationVersionFromClaims(c *gin.Context) (org *models.Organization, version *trtl.Version, err error) {
 	// Retrieve the organization ID from the claims
 	var claims *auth.Claims
 	if claims, err = auth.GetClaims(c); err != nil {
 		log.Error().Err(err).Msg("could not retrieve claims to fetch orgID")
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not identify organization"))
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If there is no organization ID, something went wrong
 	if claims.OrgID == "" {
 		log.Warn().Msg("claims do not contain an orgID")
 		api.MustRefreshToken(c, "missing claims info, try logging out and logging back in")
-		return nil, errors.New("missing organization ID in claims")
+		return nil, nil, errors.New("missing organization ID in claims")
 	}
 
 	// Fetch the record from the database
-	if org, err = s.db.Organizations().Retrieve(c.Request.Context(), claims.OrgID); err != nil {
+	if org, version, err = s.db.Organizations().RetrieveVersion(c.Request.Context(), claims.OrgID); err != nil {
 		if errors.Is(err, db.ErrNotFound) {
 			log.Warn().Err(err).Msg("could not find organization in database from orgID in claims")
 			api.MustRefreshToken(c, "no organization found, try logging out and logging back in")
-			return nil, err
+			return nil, nil, err
 		}
 
 		log.Error().Err(err).Msg("could not retrieve organization")
 		c.JSON(http.StatusInternalServerError, api.ErrorResponse("could not identify organization"))
-		return nil, err
+		return nil, nil, err
 	}
 
-	return org, nil
+	return org, version, nil
 }
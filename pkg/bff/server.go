@@ -314,7 +314,8 @@ func (s *Server) setupRoutes() (err error) {
 		cors.New(cors.Config{
 			AllowOrigins:     s.conf.AllowOrigins,
 			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD"},
-			AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-CSRF-TOKEN", "sentry-trace"},
+			AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-CSRF-TOKEN", "sentry-trace", "If-Match"},
+			ExposeHeaders:    []string{"ETag"},
 			AllowCredentials: true,
 			MaxAge:           12 * time.Hour,
 		}),
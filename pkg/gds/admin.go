@@ -1,12 +1,16 @@
 package gds
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -20,14 +24,20 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/api/idtoken"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/trisacrypto/directory/pkg"
 	admin "github.com/trisacrypto/directory/pkg/gds/admin/v2"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/gds/store"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	"github.com/trisacrypto/directory/pkg/gds/tokens"
+	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/utils"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
@@ -83,6 +93,7 @@ type Admin struct {
 	db      store.Store          // Database connection for loading objects (alias to s.svc.db)
 	router  *gin.Engine          // The HTTP handler and associated middleware
 	healthy bool                 // application state of the server
+	actions sync.Mutex           // Ensures pending actions are only resolved once
 }
 
 // Serve GRPC requests on the specified address.
@@ -195,12 +206,15 @@ func (s *Admin) setupRoutes() (err error) {
 		}
 	}
 
-	// Route-specific middleware
-	authorize := admin.Authorization(s.tokens)
+	// Route-specific middleware; authorize requires an access token that includes the
+	// specified permissions, which are granted to the user by their configured role.
+	authorize := func(permissions ...string) gin.HandlerFunc {
+		return admin.Authorization(s.tokens, permissions...)
+	}
 	csrf := admin.DoubleCookie()
 
-	// Add the v2 API routes
-	v2 := s.router.Group("/v2")
+	// Add the v2 API routes; all requests that modify the directory are audited.
+	v2 := s.router.Group("/v2", s.Audit())
 	{
 		// Heartbeat route (no authentication required)
 		v2.GET("/status", s.Status)
@@ -210,37 +224,54 @@ func (s *Admin) setupRoutes() (err error) {
 		v2.POST("/authenticate", csrf, s.Authenticate)
 		v2.POST("/reauthenticate", csrf, s.Reauthenticate)
 
-		// Information routes (must be authenticated)
-		v2.GET("/summary", authorize, s.Summary)
-		v2.GET("/autocomplete", authorize, s.Autocomplete)
-		v2.GET("/reviews", authorize, s.ReviewTimeline)
+		// Information routes (must be authenticated, read-only access)
+		v2.GET("/summary", authorize(tokens.ReadPermission), s.Summary)
+		v2.GET("/autocomplete", authorize(tokens.ReadPermission), s.Autocomplete)
+		v2.GET("/reviews", authorize(tokens.ReadPermission), s.ReviewTimeline)
+		v2.GET("/audit", authorize(tokens.ReadPermission), s.ListAuditRecords)
 
 		// VASP routes all must be authenticated (some CSRF protection required)
-		vasps := v2.Group("/vasps", authorize)
+		vasps := v2.Group("/vasps")
 		{
-			vasps.GET("", s.ListVASPs)
-			vasps.GET("/:vaspID", s.RetrieveVASP)
-			vasps.PATCH("/:vaspID", csrf, s.UpdateVASP)
-			vasps.DELETE("/:vaspID", csrf, s.DeleteVASP)
-			vasps.GET("/:vaspID/certificates", s.ListCertificates)
-			vasps.GET("/:vaspID/review", s.ReviewToken)
-			vasps.POST("/:vaspID/review", csrf, s.Review)
-			vasps.POST("/:vaspID/resend", csrf, s.Resend)
+			vasps.GET("", authorize(tokens.ReadPermission), s.ListVASPs)
+			vasps.GET("/:vaspID", authorize(tokens.ReadPermission), s.RetrieveVASP)
+			vasps.PATCH("/:vaspID", authorize(tokens.UpdatePermission), csrf, s.UpdateVASP)
+			vasps.DELETE("/:vaspID", authorize(tokens.DeletePermission), csrf, s.DeleteVASP)
+			vasps.POST("/:vaspID/restore", authorize(tokens.DeletePermission), csrf, s.RestoreVASP)
+			vasps.GET("/:vaspID/history", authorize(tokens.ReadPermission), s.ListRevisions)
+			vasps.GET("/:vaspID/history/:rev/diff", authorize(tokens.ReadPermission), s.RevisionDiff)
+			vasps.POST("/:vaspID/history/:rev/rollback", authorize(tokens.UpdatePermission), csrf, s.RollbackVASP)
+			vasps.GET("/:vaspID/certificates", authorize(tokens.ReadPermission), s.ListCertificates)
+			vasps.POST("/:vaspID/certificates/:certID/revoke", authorize(tokens.DeletePermission), csrf, s.RevokeCertificate)
+			vasps.POST("/:vaspID/csr", authorize(tokens.UpdatePermission), csrf, s.UploadCSR)
+			vasps.GET("/:vaspID/review", authorize(tokens.ReviewPermission), s.ReviewToken)
+			vasps.POST("/:vaspID/review", authorize(tokens.ReviewPermission), csrf, s.Review)
+			vasps.POST("/:vaspID/resend", authorize(tokens.ReviewPermission), csrf, s.Resend)
 
 			contacts := vasps.Group("/:vaspID/contacts")
 			{
-				contacts.PUT("/:kind", csrf, s.ReplaceContact)
-				contacts.DELETE("/:kind", csrf, s.DeleteContact)
+				contacts.PUT("/:kind", authorize(tokens.UpdatePermission), csrf, s.ReplaceContact)
+				contacts.DELETE("/:kind", authorize(tokens.UpdatePermission), csrf, s.DeleteContact)
 			}
 
 			notes := vasps.Group("/:vaspID/notes")
 			{
-				notes.GET("", s.ListReviewNotes)
-				notes.POST("", csrf, s.CreateReviewNote)
-				notes.PUT("/:noteID", csrf, s.UpdateReviewNote)
-				notes.DELETE("/:noteID", csrf, s.DeleteReviewNote)
+				notes.GET("", authorize(tokens.ReadPermission), s.ListReviewNotes)
+				notes.POST("", authorize(tokens.ReviewPermission), csrf, s.CreateReviewNote)
+				notes.PUT("/:noteID", authorize(tokens.ReviewPermission), csrf, s.UpdateReviewNote)
+				notes.DELETE("/:noteID", authorize(tokens.ReviewPermission), csrf, s.DeleteReviewNote)
 			}
 		}
+
+		// Pending action routes; the permission required to approve or cancel an action
+		// depends on the type of action and is checked by the handler.
+		actions := v2.Group("/actions")
+		{
+			actions.GET("", authorize(tokens.ReadPermission), s.ListPendingActions)
+			actions.GET("/:actionID", authorize(tokens.ReadPermission), s.RetrievePendingAction)
+			actions.POST("/:actionID/approve", authorize(tokens.ReadPermission), csrf, s.ApprovePendingAction)
+			actions.POST("/:actionID/cancel", authorize(tokens.ReadPermission), csrf, s.CancelPendingAction)
+		}
 	}
 
 	// NotFound and NotAllowed requests
@@ -314,6 +345,11 @@ func (s *Admin) Authenticate(c *gin.Context) {
 		return
 	}
 
+	// Record who attempted to login in the audit log
+	if email, ok := claims.Claims["email"].(string); ok {
+		c.Set(auditActor, email)
+	}
+
 	// Verify that the domain is one of our authorized domains
 	if err = s.checkAuthorizedDomain(claims); err != nil {
 		log.Warn().Err(err).Msg("access request from unauthorized domain")
@@ -376,6 +412,14 @@ func (s *Admin) createAuthReply(creds interface{}) (out *admin.AuthReply, expire
 		return nil, time.Time{}, err
 	}
 
+	// Users are granted the permissions of their current role whenever tokens are
+	// issued so that role changes take effect on reauthentication.
+	claims := accessToken.Claims.(*tokens.Claims)
+	if claims.Permissions, err = s.permissions(claims.Email); err != nil {
+		log.Error().Err(err).Str("email", claims.Email).Msg("could not assign permissions")
+		return nil, time.Time{}, err
+	}
+
 	if refreshToken, err = s.tokens.CreateRefreshToken(accessToken); err != nil {
 		log.Error().Err(err).Msg("could not create refresh token")
 		return nil, time.Time{}, err
@@ -397,6 +441,18 @@ func (s *Admin) createAuthReply(creds interface{}) (out *admin.AuthReply, expire
 	return out, expiresAt, nil
 }
 
+// Returns the permissions of the role assigned to the user in the configuration or of
+// the default role if the user has not been assigned a role.
+func (s *Admin) permissions(email string) ([]string, error) {
+	email = strings.TrimSpace(email)
+	for user, role := range s.conf.Roles {
+		if strings.EqualFold(user, email) {
+			return tokens.RolePermissions(role)
+		}
+	}
+	return tokens.RolePermissions(s.conf.DefaultRole)
+}
+
 // Reauthenticate allows the submission of a refresh token to reauthenticate an expired
 // or expiring access token and issues a new token pair. The access token must still be
 // provided in the Authorization header as a Bearer token, even if it is expired since
@@ -429,6 +485,7 @@ func (s *Admin) Reauthenticate(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, admin.ErrorResponse("request is not authorized"))
 		return
 	}
+	c.Set(auditActor, accessClaims.Email)
 
 	// Parse incoming JSON data from the client request (contains refresh token)
 	in = new(admin.AuthRequest)
@@ -496,6 +553,11 @@ func (s *Admin) Summary(c *gin.Context) {
 			continue
 		}
 
+		// Deleted VASPs are not included in the summary
+		if models.IsArchived(vasp) {
+			continue
+		}
+
 		// Count VASPs
 		out.VASPsCount++
 
@@ -552,6 +614,22 @@ func (s *Admin) Summary(c *gin.Context) {
 	}
 	iter2.Release()
 
+	// Count the proposed actions that are awaiting approval
+	s.actions.Lock()
+	actions, err := s.listActions()
+	s.actions.Unlock()
+	if err != nil {
+		log.Error().Err(err).Msg("could not list pending actions in store")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
+		return
+	}
+
+	for _, action := range actions {
+		if action.Status == models.PendingActionState_ACTION_PROPOSED {
+			out.PendingActions++
+		}
+	}
+
 	// Successful request, return the VASP list JSON data
 	c.JSON(http.StatusOK, out)
 }
@@ -579,6 +657,11 @@ func (s *Admin) Autocomplete(c *gin.Context) {
 			continue
 		}
 
+		// Deleted VASPs cannot be searched for
+		if models.IsArchived(vasp) {
+			continue
+		}
+
 		// Add top level names to the autocomplete
 		out.Names[vasp.CommonName] = vasp.Id
 		out.Names[vasp.Website] = vasp.Website
@@ -689,6 +772,7 @@ func (s *Admin) ReviewTimeline(c *gin.Context) {
 			Week:          week.Date.Format(timeFormat),
 			VASPsUpdated:  0,
 			Registrations: make(map[string]int),
+			Actions:       make(map[string]int),
 		}
 
 		// Need to intialize the map entries so that all verification states show up in
@@ -697,6 +781,9 @@ func (s *Admin) ReviewTimeline(c *gin.Context) {
 		for s = 0; s <= int32(pb.VerificationState_ERRORED); s++ {
 			record.Registrations[pb.VerificationState_name[s]] = 0
 		}
+		for s = 0; s <= int32(models.PendingActionState_ACTION_FAILED); s++ {
+			record.Actions[models.PendingActionState_name[s]] = 0
+		}
 		out.Weeks = append(out.Weeks, record)
 		vaspCounts = append(vaspCounts, make(map[string]bool))
 	}
@@ -751,6 +838,30 @@ func (s *Admin) ReviewTimeline(c *gin.Context) {
 		return
 	}
 
+	// Count the actions proposed each week by their current status
+	var actions []*models.PendingAction
+	s.actions.Lock()
+	actions, err = s.listActions()
+	s.actions.Unlock()
+	if err != nil {
+		log.Error().Err(err).Msg("could not list pending actions in store")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
+		return
+	}
+
+	for _, action := range actions {
+		var timestamp time.Time
+		if timestamp, err = time.Parse(time.RFC3339, action.Created); err != nil {
+			log.Warn().Err(err).Str("action", action.Id).Msg("could not parse pending action created timestamp")
+			continue
+		}
+
+		weekNum := utils.NewWeek(timestamp).Sub(weekIter.Start)
+		if weekNum >= 0 && weekNum < len(out.Weeks) {
+			out.Weeks[weekNum].Actions[action.Status.String()]++
+		}
+	}
+
 	c.JSON(http.StatusOK, out)
 }
 
@@ -772,7 +883,7 @@ func (s *Admin) ListVASPs(c *gin.Context) {
 	}
 
 	// Determine status filter
-	filters := make(map[pb.VerificationState]struct{})
+	query := &search.Query{Archived: in.Archived}
 	if in.StatusFilters != nil {
 		for i, s := range in.StatusFilters {
 			in.StatusFilters[i] = strings.ToUpper(strings.ReplaceAll(s, " ", "_"))
@@ -782,7 +893,7 @@ func (s *Admin) ListVASPs(c *gin.Context) {
 				c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("unknown verification status %q", in.StatusFilters[i])))
 				return
 			}
-			filters[pb.VerificationState(sn)] = struct{}{}
+			query.VerificationStatus = append(query.VerificationStatus, pb.VerificationState(sn))
 		}
 	}
 
@@ -793,76 +904,58 @@ func (s *Admin) ListVASPs(c *gin.Context) {
 	if in.PageSize <= 0 {
 		in.PageSize = 100
 	}
+	query.Page = int32(in.Page)
+	query.PageSize = int32(in.PageSize)
+	log.Debug().Int("page", in.Page).Int("page_size", in.PageSize).Msg("paginating vasps")
 
-	// Determine pagination index range (indexed by 1)
-	minIndex := (in.Page - 1) * in.PageSize
-	maxIndex := minIndex + in.PageSize
-	log.Debug().Int("page", in.Page).Int("page_size", in.PageSize).Int("min_index", minIndex).Int("max_index", maxIndex).Msg("paginating vasps")
+	// Query the page of VASPs from the data store
+	var page *search.Page
+	if page, err = s.db.QueryVASPs(query); err != nil {
+		log.Error().Err(err).Msg("could not query vasps in store")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
+		return
+	}
 
 	out = &admin.ListVASPsReply{
-		VASPs:    make([]admin.VASPSnippet, 0),
+		VASPs:    make([]admin.VASPSnippet, 0, len(page.Results)),
+		Count:    page.Total,
 		Page:     in.Page,
 		PageSize: in.PageSize,
 	}
 
-	// Query the list of VASPs from the data store
-	iter := s.db.ListVASPs()
-	defer iter.Release()
-	for out.Count = 0; iter.Next(); out.Count++ {
-		if out.Count >= minIndex && out.Count < maxIndex {
-			// In the page range so add to the list reply
-			// Fetch VASP from the database
-			var vasp *pb.VASP
-			var err error
-			if vasp, err = iter.VASP(); err != nil {
-				log.Error().Err(err).Msg("could not parse VASP from database")
-				out.Count--
-				continue
-			}
+	for _, result := range page.Results {
+		vasp := result.VASP
 
-			// Check against the status filters before continuing
-			if _, ok := filters[vasp.VerificationStatus]; len(filters) > 0 && !ok {
-				out.Count--
-				continue
-			}
-
-			// Build the snippet
-			snippet := admin.VASPSnippet{
-				ID:                  vasp.Id,
-				CommonName:          vasp.CommonName,
-				RegisteredDirectory: vasp.RegisteredDirectory,
-				VerificationStatus:  vasp.VerificationStatus.String(),
-				LastUpdated:         vasp.LastUpdated,
-				VerifiedOn:          vasp.VerifiedOn,
-				Traveler:            models.IsTraveler(vasp),
-			}
+		// Build the snippet
+		snippet := admin.VASPSnippet{
+			ID:                  vasp.Id,
+			CommonName:          vasp.CommonName,
+			RegisteredDirectory: vasp.RegisteredDirectory,
+			VerificationStatus:  vasp.VerificationStatus.String(),
+			LastUpdated:         vasp.LastUpdated,
+			VerifiedOn:          vasp.VerifiedOn,
+			Traveler:            models.IsTraveler(vasp),
+		}
 
-			// Add certificate serial number if it exists
-			if vasp.IdentityCertificate != nil {
-				snippet.CertificateSerial = fmt.Sprintf("%X", vasp.IdentityCertificate.SerialNumber)
-				snippet.CertificateExpiration = vasp.IdentityCertificate.NotAfter
-			}
+		// Add certificate serial number if it exists
+		if vasp.IdentityCertificate != nil {
+			snippet.CertificateSerial = fmt.Sprintf("%X", vasp.IdentityCertificate.SerialNumber)
+			snippet.CertificateExpiration = vasp.IdentityCertificate.NotAfter
+		}
 
-			// Name is a computed value, ignore errors in finding the name.
-			snippet.Name, _ = vasp.Name()
+		// Name is a computed value, ignore errors in finding the name.
+		snippet.Name, _ = vasp.Name()
 
-			// Add verified contacts to snippet
-			var errs *multierror.Error
-			if snippet.VerifiedContacts, errs = models.ContactVerifications(vasp); errs != nil {
-				for _, err := range errs.Errors {
-					log.Error().Err(err).Msg("could not get contact verifications")
-				}
+		// Add verified contacts to snippet
+		var errs *multierror.Error
+		if snippet.VerifiedContacts, errs = models.ContactVerifications(vasp); errs != nil {
+			for _, err := range errs.Errors {
+				log.Error().Err(err).Msg("could not get contact verifications")
 			}
-
-			// Append to list in reply
-			out.VASPs = append(out.VASPs, snippet)
 		}
-	}
 
-	if err = iter.Error(); err != nil {
-		log.Error().Err(err).Msg("could not iterate over vasps in store")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(err))
-		return
+		// Append to list in reply
+		out.VASPs = append(out.VASPs, snippet)
 	}
 
 	// Successful request, return the VASP list JSON data
@@ -914,6 +1007,13 @@ func (s *Admin) prepareVASPDetail(vasp *pb.VASP, log zerolog.Logger) (out *admin
 		log.Error().Err(err).Msg("could not get VASP name")
 	}
 
+	// Indicate if the VASP has been deleted so that it can be restored
+	if archived, err := models.GetArchived(vasp); err != nil {
+		log.Warn().Err(err).Msg("could not get archived timestamp for VASP detail")
+	} else if !archived.IsZero() {
+		out.Archived = archived.Format(time.RFC3339)
+	}
+
 	// Add the audit log to the response, on error, create empty audit log response
 	if auditLog, err := models.GetAuditLog(vasp); err != nil {
 		log.Warn().Err(err).Msg("could not get audit log for VASP detail")
@@ -927,6 +1027,13 @@ func (s *Admin) prepareVASPDetail(vasp *pb.VASP, log zerolog.Logger) (out *admin
 				out.AuditLog = nil
 				break
 			} else {
+				// Action fields are only set on entries that record an action rather than
+				// a state change; omit them when empty to keep the response unchanged.
+				for _, key := range []string{"action", "certificate_id"} {
+					if val, ok := rewiredEntry[key]; ok && val == "" {
+						delete(rewiredEntry, key)
+					}
+				}
 				out.AuditLog = append(out.AuditLog, rewiredEntry)
 			}
 		}
@@ -935,12 +1042,7 @@ func (s *Admin) prepareVASPDetail(vasp *pb.VASP, log zerolog.Logger) (out *admin
 	// Remove extra data from the VASP
 	// Must be done after verified contacts is computed
 	// WARNING: This is safe because nothing is saved back to the database!
-	vasp.Extra = nil
-	iter := models.NewContactIterator(vasp.Contacts, false, false)
-	for iter.Next() {
-		contact, _ := iter.Value()
-		contact.Extra = nil
-	}
+	redactVASP(vasp)
 
 	// Rewire the VASP from protocol buffers to specific JSON serialization context
 	if out.VASP, err = wire.Rewire(vasp); err != nil {
@@ -1014,6 +1116,13 @@ func (s *Admin) UpdateVASP(c *gin.Context) {
 		return
 	}
 
+	// Deleted VASPs must be restored before they can be modified
+	if models.IsArchived(vasp) {
+		logctx.Warn().Msg("cannot update a deleted vasp")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has been deleted and must be restored first"))
+		return
+	}
+
 	// Get user claims for audit log tracing
 	if claims, err = s.getClaims(c); err != nil {
 		logctx.Error().Err(err).Msg("could not get user claims for audit log")
@@ -1110,7 +1219,8 @@ func (s *Admin) UpdateVASP(c *gin.Context) {
 	// TODO: transactions would be super nice here so we could rollback any certificate request changes
 	if err = s.db.UpdateVASP(vasp); err != nil {
 		logctx.Error().Err(err).Msg("could not save VASP after update")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP"))
+		status, reason := updateVASPError(err, "could not update VASP")
+		c.JSON(status, admin.ErrorResponse(reason))
 		return
 	}
 
@@ -1295,14 +1405,17 @@ func (s *Admin) updateVASPEndpoint(vasp *pb.VASP, commonName, endpoint, source s
 	return true, http.StatusOK, nil
 }
 
-// DeleteVASP removes a VASP and its associated certificate requests if and only if the
-// VASP verification status is in PENDING_REVIEW or earlier or ERRORED.
+// DeleteVASP proposes the deletion of a VASP, which is only executed once it is approved
+// by a second admin. VASPs can only be deleted if the verification status is in
+// PENDING_REVIEW or earlier or ERRORED. Deleted VASPs are archived so that they can be
+// restored until the archive manager purges them with their certificate requests.
 func (s *Admin) DeleteVASP(c *gin.Context) {
 	var (
-		vaspID     string
-		vasp       *pb.VASP
-		certReqIDs []string
-		err        error
+		vaspID string
+		vasp   *pb.VASP
+		action *models.PendingAction
+		claims *tokens.Claims
+		err    error
 	)
 
 	vaspID = c.Param("vaspID")
@@ -1314,922 +1427,2298 @@ func (s *Admin) DeleteVASP(c *gin.Context) {
 		return
 	}
 
+	if models.IsArchived(vasp) {
+		log.Warn().Msg("VASP has already been deleted")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has already been deleted"))
+		return
+	}
+
 	// Only allow deletions if the VASP has not been reviewed yet
-	if vasp.VerificationStatus > pb.VerificationState_PENDING_REVIEW && vasp.VerificationStatus < pb.VerificationState_ERRORED {
+	if !vaspDeletable(vasp) {
 		log.Warn().Str("status", vasp.VerificationStatus.String()).Msg("VASP is in invalid state for deletion")
 		c.JSON(http.StatusBadRequest, admin.ErrorResponse("cannot delete VASP in its current state"))
 		return
 	}
 
-	// Retrieve the associated certificate requests
-	if certReqIDs, err = models.GetCertReqIDs(vasp); err != nil {
-		log.Error().Err(err).Msg("could not retrieve certificate request IDs for VASP")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve certificate requests for VASP"))
+	// Retrieve user claims for access to provided user info
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
 		return
 	}
 
-	// Delete the certificate requests
-	for _, id := range certReqIDs {
-		if err = s.db.DeleteCertReq(id); err != nil {
-			log.Error().Err(err).Str("certreq_id", id).Msg("could not delete certificate request")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not delete associated certificate request"))
-			return
-		}
+	// Propose the deletion so that it can be approved by another admin
+	if action, err = s.proposeAction(models.PendingActionType_DELETE_VASP, vasp.Id, nil, claims); err != nil {
+		s.proposeActionError(c, err)
+		return
 	}
 
-	// Delete the VASP object to finalize the VASP deletion
-	if err = s.db.DeleteVASP(vaspID); err != nil {
-		log.Error().Err(err).Msg("could not delete VASP from database")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not delete VASP record by ID"))
-		return
+	c.JSON(http.StatusAccepted, admin.Reply{Success: true, PendingAction: preparePendingAction(action)})
+}
+
+// VASPs can only be deleted if the VASP has not been reviewed yet.
+func vaspDeletable(vasp *pb.VASP) bool {
+	return vasp.VerificationStatus <= pb.VerificationState_PENDING_REVIEW || vasp.VerificationStatus >= pb.VerificationState_ERRORED
+}
+
+// Archive the VASP once the deletion has been approved, returning a message describing
+// the result or an error and status code. The VASP and its certificate requests remain
+// in the store until they are purged after the archive retention period.
+func (s *Admin) deleteVASP(vaspID string, claims *tokens.Claims) (msg string, _ int, err error) {
+	var vasp *pb.VASP
+
+	// Retrieve the VASP from the database
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Msg("could not retrieve VASP from database")
+		return "", http.StatusNotFound, errors.New("could not retrieve VASP record by ID")
 	}
 
-	c.JSON(http.StatusOK, admin.Reply{Success: true})
+	// The VASP may have been reviewed since the deletion was proposed
+	if !vaspDeletable(vasp) {
+		log.Warn().Str("status", vasp.VerificationStatus.String()).Msg("VASP is in invalid state for deletion")
+		return "", http.StatusBadRequest, errors.New("cannot delete VASP in its current state")
+	}
+
+	if err = models.ArchiveVASP(vasp, claims.Email); err != nil {
+		log.Warn().Err(err).Msg("could not archive VASP")
+		return "", http.StatusBadRequest, errors.New("VASP has already been deleted")
+	}
+
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("could not archive VASP in database")
+		status, reason := updateVASPError(err, "could not delete VASP record by ID")
+		return "", status, reason
+	}
+
+	var name string
+	if name, err = vasp.Name(); err != nil {
+		name = vasp.Id
+	}
+	return fmt.Sprintf("%s has been deleted and can be restored until it is purged", name), http.StatusOK, nil
 }
 
-// ListCertificates returns a list of certificates for the VASP.
-func (s *Admin) ListCertificates(c *gin.Context) {
-	var err error
+// RestoreVASP returns a deleted VASP to the directory if it has not yet been purged.
+// The VASP is restored in the verification state it was in when it was deleted.
+func (s *Admin) RestoreVASP(c *gin.Context) {
+	var (
+		vaspID string
+		vasp   *pb.VASP
+		claims *tokens.Claims
+		err    error
+	)
 
-	// Get vaspID from the URL
-	vaspID := c.Param("vaspID")
+	vaspID = c.Param("vaspID")
 
 	// Retrieve the VASP from the database
-	var vasp *pb.VASP
 	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve VASP from database")
+		log.Warn().Err(err).Msg("could not retrieve VASP from database")
 		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Retrieve the Certificate IDs from the VASP
-	var ids []string
-	if ids, err = models.GetCertIDs(vasp); err != nil {
-		log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve certificate IDs for VASP")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve certificate IDs for VASP"))
+	// Retrieve user claims for access to provided user info
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
 		return
 	}
 
-	// Construct the reply
-	out := &admin.ListCertificatesReply{
-		Certificates: make([]admin.Certificate, 0),
+	if err = models.RestoreVASP(vasp, claims.Email); err != nil {
+		log.Warn().Err(err).Msg("could not restore VASP")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has not been deleted"))
+		return
 	}
 
-	for _, id := range ids {
-		// Retrieve the Certificate from the database
-		var cert *models.Certificate
-		if cert, err = s.db.RetrieveCert(id); err != nil {
-			log.Error().Err(err).Str("cert_id", id).Msg("could not retrieve certificate from database")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve certificate by ID"))
-			return
-		}
-
-		// Construct an entry for the reply
-		entry := admin.Certificate{
-			SerialNumber: id,
-			IssuedAt:     cert.Details.NotBefore,
-			ExpiresAt:    cert.Details.NotAfter,
-			Status:       cert.Status.String(),
-		}
-		if entry.Details, err = wire.Rewire(cert.Details); err != nil {
-			log.Error().Err(err).Str("cert_id", id).Msg("could not serialize certificate details")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not serialize certificate details"))
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		// The names of deleted VASPs are released so the VASP may have been registered again
+		if errors.Is(err, storeerrors.ErrDuplicateEntity) {
+			log.Warn().Err(err).Msg("could not restore VASP whose name is in use")
+			c.JSON(http.StatusConflict, admin.ErrorResponse("the name of the VASP is in use by another VASP"))
 			return
 		}
 
-		out.Certificates = append(out.Certificates, entry)
+		log.Error().Err(err).Msg("could not restore VASP in database")
+		status, reason := updateVASPError(err, "could not restore VASP record by ID")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
 	}
 
-	c.JSON(http.StatusOK, out)
+	log.Info().Str("vasp_id", vasp.Id).Str("restored_by", claims.Email).Msg("deleted VASP restored")
+	c.JSON(http.StatusOK, admin.Reply{Success: true})
 }
 
-// ReplaceContact completely replaces a contact on a VASP with a new contact.
-func (s *Admin) ReplaceContact(c *gin.Context) {
+// ListRevisions returns the revision history of a VASP record, most recent first. A
+// revision is stored by the directory every time the VASP record is saved.
+func (s *Admin) ListRevisions(c *gin.Context) {
 	var (
-		in           *admin.ReplaceContactRequest
-		contact      *pb.Contact
-		vasp         *pb.VASP
-		emailUpdated bool
-		err          error
+		err       error
+		vaspID    string
+		revisions []*models.VASPRevision
+		out       *admin.ListRevisionsReply
 	)
 
-	// Get vaspID from the URL
-	vaspID := c.Param("vaspID")
-	kind := c.Param("kind")
+	vaspID = c.Param("vaspID")
+	logctx := log.With().Str("id", vaspID).Logger()
 
-	// Parse incoming JSON data from the client request
-	in = new(admin.ReplaceContactRequest)
-	if err = c.ShouldBind(&in); err != nil {
-		log.Warn().Err(err).Msg("could not bind request")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+	// The history of deleted VASPs is available until they are purged
+	if _, err = s.db.RetrieveVASP(vaspID); err != nil {
+		logctx.Warn().Err(err).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Sanity check: validate VASP ID
-	if in.VASP != "" && in.VASP != vaspID {
-		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not mactch the URL endpoint"))
+	if revisions, err = s.db.ListRevisions(vaspID).All(); err != nil {
+		logctx.Error().Err(err).Msg("could not list vasp revisions")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not list VASP revisions"))
 		return
 	}
 
-	// Sanity check: validate contact kind
-	if in.Kind != "" && in.Kind != kind {
-		log.Warn().Str("kind", in.Kind).Str("kind", kind).Msg("mismatched contact kind and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the contact kind does not match the URL endpoint"))
-		return
+	out = &admin.ListRevisionsReply{
+		Revisions: make([]admin.Revision, 0, len(revisions)),
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		out.Revisions = append(out.Revisions, admin.Revision{
+			Revision:           revisions[i].Revision,
+			Created:            revisions[i].Created,
+			Version:            revisions[i].Record.GetVersion().GetVersion(),
+			VerificationStatus: revisions[i].Record.GetVerificationStatus().String(),
+		})
 	}
+	c.JSON(http.StatusOK, out)
+}
 
-	// Kind must be one of the accepted values
-	if !models.ContactKindIsValid(kind) {
-		log.Warn().Str("kind", kind).Msg("invalid contact kind")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
+// RevisionDiff returns the fields of the VASP record that changed between the
+// specified revision and an earlier revision, by default the previous revision.
+func (s *Admin) RevisionDiff(c *gin.Context) {
+	var (
+		err      error
+		vaspID   string
+		in       *admin.RevisionDiffParams
+		out      *admin.RevisionDiffReply
+		rev      *models.VASPRevision
+		against  *models.VASPRevision
+		original []byte
+		modified []byte
+		changes  []*models.FieldChange
+	)
+
+	vaspID = c.Param("vaspID")
+	logctx := log.With().Str("id", vaspID).Str("revision", c.Param("rev")).Logger()
+
+	in = new(admin.RevisionDiffParams)
+	if err = c.ShouldBindQuery(&in); err != nil {
+		logctx.Warn().Err(err).Msg("could not bind request with query params")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Contact data must be provided
-	if len(in.Contact) == 0 {
-		log.Warn().Msg("missing contact data on ReplaceContact request")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("contact data is required for ReplaceContact request"))
+	out = &admin.RevisionDiffReply{Against: in.Against}
+	if out.Revision, err = strconv.ParseUint(c.Param("rev"), 10, 64); err != nil || out.Revision == 0 {
+		logctx.Warn().Err(err).Msg("could not parse revision number")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid revision number"))
 		return
 	}
 
-	// Retrieve the VASP from the database
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Msg("could not retrieve VASP from database")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
-		return
+	// Compare the revision to the previous revision by default; the first revision is
+	// compared to an empty record so that every field is shown as created.
+	if out.Against == 0 {
+		out.Against = out.Revision - 1
 	}
 
-	// Remarshal the JSON contact data
-	update := &pb.Contact{}
-	if err = wire.Unwire(in.Contact, update); err != nil {
-		log.Warn().Err(err).Msg("could not unmarshal contact data")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+	if rev, err = s.db.RetrieveRevision(vaspID, out.Revision); err != nil {
+		logctx.Warn().Err(err).Msg("could not retrieve vasp revision")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP revision"))
 		return
 	}
 
-	if contact = models.ContactFromType(vasp.Contacts, kind); contact == nil {
-		// If the contact doesn't exist then create it
-		if err = models.AddContact(vasp, kind, update); err != nil {
-			log.Warn().Err(err).Msg("could not add contact to VASP")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
-			return
-		}
-		contact = update
-		emailUpdated = true
-
-		if contact.IsZero() {
-			log.Warn().Msg("cannot create empty contact on update")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact data: missing required fields"))
+	if out.Against > 0 {
+		if against, err = s.db.RetrieveRevision(vaspID, out.Against); err != nil {
+			logctx.Warn().Err(err).Uint64("against", out.Against).Msg("could not retrieve vasp revision")
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP revision to compare against"))
 			return
 		}
 
-	} else {
-		// Otherwise replace the existing contact info
-		contact.Name = update.Name
-		contact.Phone = update.Phone
-		contact.Person = update.Person
-		if contact.Email != update.Email {
-			contact.Email = update.Email
-			emailUpdated = true
-		}
-
-		if contact.IsZero() {
-			log.Warn().Msg("invalid contact record after update")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact data: missing required fields"))
+		if original, err = marshalRevision(against); err != nil {
+			logctx.Error().Err(err).Uint64("against", out.Against).Msg("could not marshal vasp revision")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not compare VASP revisions"))
 			return
 		}
 	}
 
-	// New VASP record must be valid
-	if err = vasp.Validate(true); err != nil {
-		log.Warn().Err(err).Msg("invalid VASP record after update")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("validation error: %s", err)))
+	if modified, err = marshalRevision(rev); err != nil {
+		logctx.Error().Err(err).Msg("could not marshal vasp revision")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not compare VASP revisions"))
 		return
 	}
 
-	if emailUpdated {
-		// The email address changed, so the contact needs to be verified
-		if err = models.SetContactVerification(contact, secrets.CreateToken(models.VerificationTokenLength), false); err != nil {
-			log.Error().Err(err).Msg("could not set contact verification")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update verification status for the indicated contact"))
-			return
-		}
-
-		// Send the verification email
-		if err = s.svc.email.SendVerifyContact(vasp, contact); err != nil {
-			log.Error().Err(err).Msg("could not send verification email")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not send verification email to the new contact"))
-			return
-		}
+	if changes, err = models.DiffJSON(original, modified); err != nil {
+		logctx.Error().Err(err).Msg("could not compute changes between vasp revisions")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not compare VASP revisions"))
+		return
 	}
 
-	// Commit the contact changes to the database
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("could not update VASP in database")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record by ID"))
-		return
+	out.Changes = make([]admin.FieldChange, 0, len(changes))
+	for _, change := range changes {
+		out.Changes = append(out.Changes, admin.FieldChange{
+			Path:   change.Path,
+			Before: change.Before,
+			After:  change.After,
+		})
 	}
+	c.JSON(http.StatusOK, out)
+}
 
-	c.JSON(http.StatusOK, admin.Reply{Success: true})
+// Returns the protojson encoded record of the revision without the extra data of the
+// VASP and its contacts.
+func marshalRevision(rev *models.VASPRevision) ([]byte, error) {
+	vasp := proto.Clone(rev.Record).(*pb.VASP)
+	redactVASP(vasp)
+	return protojson.Marshal(vasp)
 }
 
-// DeleteContact deletes a contact on a VASP.
-func (s *Admin) DeleteContact(c *gin.Context) {
-	var (
-		vasp *pb.VASP
-		err  error
+// Removes the extra data of the VASP and its contacts, which contain secrets such as
+// the verification tokens, so that the VASP can be returned by the admin API. The VASP
+// is modified in place so it must not be saved back to the database.
+func redactVASP(vasp *pb.VASP) {
+	vasp.Extra = nil
+	iter := models.NewContactIterator(vasp.Contacts, false, false)
+	for iter.Next() {
+		contact, _ := iter.Value()
+		contact.Extra = nil
+	}
+}
+
+// RollbackVASP restores the business information, IVMS 101 entity, TRIXO form, common
+// name, and TRISA endpoint of a VASP to what they were in an earlier revision. The
+// contacts, certificates, verification status, and extra data are not rolled back since
+// they are managed by the registration and certificate workflows. The rollback is saved
+// as a new revision so that it can also be undone.
+func (s *Admin) RollbackVASP(c *gin.Context) {
+	var (
+		err      error
+		vaspID   string
+		revision uint64
+		vasp     *pb.VASP
+		rev      *models.VASPRevision
+		out      *admin.RetrieveVASPReply
+		claims   *tokens.Claims
 	)
 
-	// Get vaspID from the URL
-	vaspID := c.Param("vaspID")
-	kind := c.Param("kind")
+	vaspID = c.Param("vaspID")
+	logctx := log.With().Str("id", vaspID).Str("revision", c.Param("rev")).Logger()
 
-	// Retrieve the VASP from the database
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Msg("could not retrieve VASP from database")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+	if revision, err = strconv.ParseUint(c.Param("rev"), 10, 64); err != nil || revision == 0 {
+		logctx.Warn().Err(err).Msg("could not parse revision number")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid revision number"))
 		return
 	}
 
-	// Kind must be one of the accepted values
-	if !models.ContactKindIsValid(kind) {
-		log.Warn().Str("kind", kind).Msg("invalid contact kind")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		logctx.Debug().Err(err).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Delete the contact from the VASP
-	if err = models.DeleteContact(vasp, kind); err != nil {
-		log.Warn().Err(err).Msg("could not delete contact from VASP")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
+	// Deleted VASPs must be restored before they can be modified
+	if models.IsArchived(vasp) {
+		logctx.Warn().Msg("cannot roll back a deleted vasp")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has been deleted and must be restored first"))
 		return
 	}
 
-	// New VASP record must be valid
-	if err = vasp.Validate(true); err != nil {
-		log.Warn().Err(err).Msg("invalid VASP record after update")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("validation error: %s", err)))
+	if rev, err = s.db.RetrieveRevision(vaspID, revision); err != nil {
+		logctx.Warn().Err(err).Msg("could not retrieve vasp revision")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP revision"))
 		return
 	}
 
-	// Commit the contact changes to the database
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("could not update VASP in database")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record by ID"))
+	// Get user claims for audit log tracing
+	if claims, err = s.getClaims(c); err != nil {
+		logctx.Error().Err(err).Msg("could not get user claims for audit log")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record audit log"))
 		return
 	}
 
-	c.JSON(http.StatusOK, admin.Reply{Success: true})
-}
-
-// CreateReviewNote creates a new review note given the vaspID param and a CreateReviewNoteRequest.
-func (s *Admin) CreateReviewNote(c *gin.Context) {
-	var (
-		err    error
-		in     *admin.ModifyReviewNoteRequest
-		note   *models.ReviewNote
-		vasp   *pb.VASP
-		claims *tokens.Claims
-		vaspID string
-		noteID string
-	)
-
-	// Get vaspID from the URL
-	vaspID = c.Param("vaspID")
+	original := proto.Clone(vasp).(*pb.VASP)
+	vasp.Website = rev.Record.Website
+	vasp.BusinessCategory = rev.Record.BusinessCategory
+	vasp.VaspCategories = rev.Record.VaspCategories
+	vasp.EstablishedOn = rev.Record.EstablishedOn
+	vasp.Entity = rev.Record.Entity
+	vasp.Trixo = rev.Record.Trixo
 
-	// Parse incoming JSON data from the client request
-	in = new(admin.ModifyReviewNoteRequest)
-	if err = c.ShouldBind(&in); err != nil {
-		log.Warn().Err(err).Msg("could not bind request")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+	// Validate the record before the common name is restored since changing the common
+	// name also modifies the certificate requests that have not been submitted yet.
+	if err = vasp.Validate(true); err != nil {
+		logctx.Warn().Err(err).Msg("invalid or incomplete VASP record on rollback")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("validation error: %s", err)))
 		return
 	}
 
-	// Validate VASP ID
-	if in.VASP != "" && in.VASP != vaspID {
-		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+	var code int
+	if _, code, err = s.updateVASPEndpoint(vasp, rev.Record.CommonName, rev.Record.TrisaEndpoint, claims.Email, logctx); err != nil {
+		// NOTE: logging happens in the update helper function
+		c.JSON(code, admin.ErrorResponse(err))
 		return
 	}
 
-	// Retrieve author email
-	if claims, err = s.getClaims(c); err != nil {
-		log.Error().Err(err).Msg("could not retrieve user claims")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+	if proto.Equal(original, vasp) {
+		logctx.Debug().Msg("vasp already matches revision")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP record already matches the revision"))
 		return
 	}
 
-	if in.NoteID == "" {
-		// Create note ID if not provided
-		noteID = uuid.New().String()
-	} else {
-		noteID = in.NoteID
-		// Only allow reasonably-lengthed note IDs (generated IDs are also 36 characters)
-		if len(noteID) > 36 {
-			log.Warn().Err(err).Msg("invalid note ID")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("note ID cannot be longer than 36 characters"))
-			return
-		}
-
-		// Only allow note IDs that can be used in request URLs
-		if escaped := url.QueryEscape(noteID); noteID != escaped {
-			log.Warn().Err(err).Msg("invalid note ID")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("note ID contains unescaped characters: %s", noteID)))
-			return
-		}
-	}
-
-	// Lookup the VASP record associated with the request
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+	if err = models.UpdateVerificationStatus(vasp, vasp.VerificationStatus, fmt.Sprintf("VASP record rolled back to revision %d by admin", revision), claims.Email); err != nil {
+		logctx.Error().Err(err).Msg("could not add audit log entry by updating the verification status")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP audit log"))
 		return
 	}
 
-	// Create the note
-	if note, err = models.CreateReviewNote(vasp, noteID, claims.Email, in.Text); err != nil {
-		log.Warn().Err(err).Msg("error creating review note")
-		if err == models.ErrorAlreadyExists {
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("note already exists"))
-		} else {
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not create review note"))
-		}
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		logctx.Error().Err(err).Msg("could not save VASP after rollback")
+		status, reason := updateVASPError(err, "could not update VASP")
+		c.JSON(status, admin.ErrorResponse(reason))
 		return
 	}
 
-	// Persist the VASP record to the database
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("error updating VASP record")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+	// NOTE: VASP is modified in this step, must not save VASP after this!
+	if out, err = s.prepareVASPDetail(vasp, logctx); err != nil {
+		// NOTE: logging occurs in prepareVASPDetail
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not create VASP detail"))
 		return
 	}
 
-	c.JSON(http.StatusCreated, &admin.ReviewNote{
-		ID:       note.Id,
-		Created:  note.Created,
-		Modified: note.Modified,
-		Author:   note.Author,
-		Editor:   note.Editor,
-		Text:     note.Text,
-	})
+	logctx.Info().Str("rolled_back_by", claims.Email).Msg("vasp rolled back to revision")
+	c.JSON(http.StatusOK, admin.UpdateVASPReply(*out))
 }
 
-// ListReviewNotes returns a list of review notes given a vaspID param.
-func (s *Admin) ListReviewNotes(c *gin.Context) {
-	var (
-		err    error
-		out    *admin.ListReviewNotesReply
-		vasp   *pb.VASP
-		vaspID string
-		notes  map[string]*models.ReviewNote
-	)
+// ListCertificates returns a list of certificates for the VASP.
+func (s *Admin) ListCertificates(c *gin.Context) {
+	var err error
 
 	// Get vaspID from the URL
-	vaspID = c.Param("vaspID")
+	vaspID := c.Param("vaspID")
 
-	// Lookup the VASP record associated with the request
+	// Retrieve the VASP from the database
+	var vasp *pb.VASP
 	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		log.Warn().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve VASP from database")
 		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Retrieve the slice of notes
-	if notes, err = models.GetReviewNotes(vasp); err != nil {
-		log.Error().Err(err).Msg("error retrieving review notes")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve review notes"))
+	// Lookup the certificates issued to the VASP using the certificate index
+	var certs []*models.Certificate
+	if certs, err = s.db.ListCertsByVASP(vasp.Id); err != nil {
+		log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve certificates for VASP")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve certificates for VASP"))
 		return
 	}
 
-	// Compose the JSON response
-	out = &admin.ListReviewNotesReply{
-		Notes: []admin.ReviewNote{},
+	// Construct the reply
+	out := &admin.ListCertificatesReply{
+		Certificates: make([]admin.Certificate, 0, len(certs)),
 	}
-	for _, n := range notes {
-		out.Notes = append(out.Notes, admin.ReviewNote{
-			ID:       n.Id,
-			Created:  n.Created,
-			Modified: n.Modified,
-			Author:   n.Author,
-			Editor:   n.Editor,
-			Text:     n.Text,
-		})
+
+	for _, cert := range certs {
+		// Construct an entry for the reply
+		entry := admin.Certificate{
+			SerialNumber: cert.Id,
+			IssuedAt:     cert.Details.NotBefore,
+			ExpiresAt:    cert.Details.NotAfter,
+			Status:       cert.Status.String(),
+		}
+		if entry.Details, err = wire.Rewire(cert.Details); err != nil {
+			log.Error().Err(err).Str("cert_id", cert.Id).Msg("could not serialize certificate details")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not serialize certificate details"))
+			return
+		}
+
+		out.Certificates = append(out.Certificates, entry)
 	}
 
 	c.JSON(http.StatusOK, out)
 }
 
-// UpdateReivewNote updates the text of a review note given vaspIP and noteID params
-// and an UpdateReviewNoteRequest.
-func (s *Admin) UpdateReviewNote(c *gin.Context) {
+// RevokeCertificate revokes a certificate issued to the VASP with the certificate
+// authority, marks the certificate record as revoked, and notifies the VASP contacts.
+func (s *Admin) RevokeCertificate(c *gin.Context) {
 	var (
-		err    error
-		in     *admin.ModifyReviewNoteRequest
-		note   *models.ReviewNote
+		in     *admin.RevokeCertificateRequest
+		out    *admin.RevokeCertificateReply
 		vasp   *pb.VASP
-		claims *tokens.Claims
-		vaspID string
-		noteID string
+		cert   *models.Certificate
+		reason sectigo.CRLReason
+		err    error
 	)
 
-	// Get vaspID and noteID from the URL
-	vaspID = c.Param("vaspID")
-	noteID = c.Param("noteID")
+	// Get vaspID and certID from the URL
+	vaspID := c.Param("vaspID")
+	certID := c.Param("certID")
 
 	// Parse incoming JSON data from the client request
-	in = new(admin.ModifyReviewNoteRequest)
+	in = new(admin.RevokeCertificateRequest)
 	if err = c.ShouldBind(&in); err != nil {
 		log.Warn().Err(err).Msg("could not bind request")
 		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Validate VASP ID
-	if in.VASP != "" && in.VASP != vaspID {
-		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request VASP ID does not match the URL endpoint"))
+	// Validate revoke request
+	if (in.VASP != "" && in.VASP != vaspID) || (in.Certificate != "" && in.Certificate != certID) {
+		log.Warn().Str("vasp", in.VASP).Str("certificate", in.Certificate).Str("vasp_id", vaspID).Str("cert_id", certID).Msg("mismatched request IDs and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request IDs do not match the URL endpoint"))
 		return
 	}
 
-	// Validate note ID
-	if in.NoteID != "" && in.NoteID != noteID {
-		log.Warn().Str("id", in.NoteID).Str("note_id", noteID).Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request Note ID does not match the URL endpoint"))
+	if reason, err = sectigo.RevokeReasonCode(in.Reason); err != nil {
+		log.Warn().Err(err).Str("reason", in.Reason).Msg("invalid revocation reason")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Retrieve author email
+	// Retrieve the VASP and the certificate from the database
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve VASP from database")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	if cert, err = s.db.RetrieveCert(certID); err != nil || cert.Vasp != vasp.Id {
+		log.Warn().Err(err).Str("vasp_id", vaspID).Str("cert_id", certID).Msg("could not retrieve certificate for VASP")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve certificate record by ID"))
+		return
+	}
+
+	if cert.Status != models.CertificateState_ISSUED {
+		log.Warn().Str("cert_id", certID).Str("status", cert.Status.String()).Msg("certificate is in invalid state for revocation")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("cannot revoke certificate in %s state", cert.Status)))
+		return
+	}
+
+	// Retrieve user claims for access to provided user info
+	var claims *tokens.Claims
 	if claims, err = s.getClaims(c); err != nil {
 		log.Error().Err(err).Msg("could not retrieve user claims")
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
 		return
 	}
 
-	// Lookup the VASP record associated with the request
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
-		return
+	description := in.Description
+	if description == "" {
+		if description = in.Reason; description == "" {
+			description = "unspecified"
+		}
 	}
 
-	// Update the note
-	if note, err = models.UpdateReviewNote(vasp, noteID, claims.Email, in.Text); err != nil {
-		log.Error().Err(err).Msg("error updating review note")
-		if err == models.ErrorNotFound {
-			c.JSON(http.StatusNotFound, admin.ErrorResponse("review note not found"))
-		} else {
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update review note"))
+	out = &admin.RevokeCertificateReply{}
+	if out.Sent, err = s.revokeCertificate(vasp, cert, reason, description, claims); err != nil {
+		if errors.Is(err, certauth.ErrNotSupported) {
+			log.Warn().Str("vasp_id", vaspID).Str("cert_id", certID).Str("authority", s.svc.conf.CertMan.Authority).Msg("certificate authority cannot revoke certificates")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("the certificate authority does not support revocation"))
+			return
 		}
+
+		log.Error().Err(err).Str("vasp_id", vaspID).Str("cert_id", certID).Msg("could not revoke certificate")
+		status, reason := updateVASPError(err, "could not revoke certificate")
+		c.JSON(status, admin.ErrorResponse(reason))
 		return
 	}
 
-	// Persist the VASP record to the database
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("error updating VASP record")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+	out.Certificate = admin.Certificate{
+		SerialNumber: cert.Id,
+		IssuedAt:     cert.Details.NotBefore,
+		ExpiresAt:    cert.Details.NotAfter,
+		Status:       cert.Status.String(),
+	}
+	if out.Certificate.Details, err = wire.Rewire(cert.Details); err != nil {
+		log.Error().Err(err).Str("cert_id", cert.Id).Msg("could not serialize certificate details")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not serialize certificate details"))
 		return
 	}
 
-	c.JSON(http.StatusOK, &admin.ReviewNote{
-		ID:       note.Id,
-		Created:  note.Created,
-		Modified: note.Modified,
-		Author:   note.Author,
-		Editor:   note.Editor,
-		Text:     note.Text,
-	})
+	out.Message = fmt.Sprintf("certificate %s has been revoked and %d contacts notified", cert.Id, out.Sent)
+	log.Info().Str("vasp_id", vasp.Id).Str("cert_id", cert.Id).Int("reason", int(reason)).Int("sent", out.Sent).Msg("certificate revoked")
+	c.JSON(http.StatusOK, out)
 }
 
-// DeleteReviewNote deletes a review note given vaspID and noteID params.
-func (s *Admin) DeleteReviewNote(c *gin.Context) {
-	var (
-		err    error
-		vasp   *pb.VASP
-		vaspID string
-		noteID string
-	)
-
-	// Get vaspID and noteID from the URL
-	vaspID = c.Param("vaspID")
-	noteID = c.Param("noteID")
+// Prefix of the audit log description of a certificate revocation.
+const revocationDescription = "certificate revoked: "
 
-	// Lookup the VASP record associated with the request
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
-		return
+// Returns the audit log entry of the most recent certificate revocation of the VASP or
+// nil if none of the certificates of the VASP have been revoked.
+func lastRevocation(vasp *pb.VASP) (_ *models.AuditLogEntry, err error) {
+	var auditLog []*models.AuditLogEntry
+	if auditLog, err = models.GetAuditLog(vasp); err != nil {
+		return nil, err
 	}
 
-	// Delete the note
-	if err = models.DeleteReviewNote(vasp, noteID); err != nil {
-		log.Warn().Err(err).Msg("error deleting review note")
-		if err == models.ErrorNotFound {
-			c.JSON(http.StatusNotFound, admin.ErrorResponse("review note not found"))
-		} else {
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not delete review note"))
+	for i := len(auditLog) - 1; i >= 0; i-- {
+		if auditLog[i].Action == models.ActionCertificateRevoked {
+			return auditLog[i], nil
 		}
-		return
+	}
+	return nil, nil
+}
+
+// Revoke the certificate with the certificate authority, then record the revocation on
+// the certificate and in the VASP audit log before notifying the VASP contacts. Email
+// delivery errors are logged but not returned since the certificate has been revoked.
+func (s *Admin) revokeCertificate(vasp *pb.VASP, cert *models.Certificate, reason sectigo.CRLReason, description string, claims *tokens.Claims) (sent int, err error) {
+	// The certificate ID is the capital hex encoded serial number used by Sectigo
+	if err = s.svc.certs.Revoke(cert.Id, reason); err != nil {
+		return 0, fmt.Errorf("could not revoke certificate with the certificate authority: %w", err)
+	}
+
+	cert.Status = models.CertificateState_REVOKED
+	cert.Details.Revoked = true
+	if err = s.db.UpdateCert(cert); err != nil {
+		return 0, err
+	}
+
+	// Mark the identity certificate of the VASP as revoked if it was revoked
+	if vasp.IdentityCertificate != nil && bytes.Equal(vasp.IdentityCertificate.SerialNumber, cert.Details.SerialNumber) {
+		vasp.IdentityCertificate.Revoked = true
+	}
+
+	entry := &models.AuditLogEntry{
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PreviousState: vasp.VerificationStatus,
+		CurrentState:  vasp.VerificationStatus,
+		Description:   revocationDescription + description,
+		Source:        claims.Email,
+		Action:        models.ActionCertificateRevoked,
+		CertificateId: cert.Id,
+	}
+	if err = models.AppendAuditLog(vasp, entry); err != nil {
+		return 0, err
 	}
 
-	// Persist the VASP record to the database
 	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("error updating VASP record")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
-		return
+		return 0, err
 	}
 
-	c.JSON(http.StatusOK, &admin.Reply{Success: true})
+	if sent, err = s.svc.email.SendCertificateRevoked(vasp, cert.Details, description); err != nil {
+		log.Error().Err(err).Str("vasp_id", vasp.Id).Int("sent", sent).Msg("could not send certificate revoked emails")
+	}
+
+	// Save the email logs on the VASP contacts
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return sent, err
+	}
+	return sent, nil
 }
 
-// ReviewToken returns the admin verification token of the VASP if the VASP is in a
-// state that it can be reviewed in, e.g. PENDING_REVIEW, otherwise a 404 is returned.
-func (s *Admin) ReviewToken(c *gin.Context) {
+// UploadCSR supplies a new certificate signing request for a VASP after registration so
+// that the VASP can rotate the key that its certificates are issued for. The CSR must be
+// for the common name of the VASP. If the VASP has a certificate request that has not
+// been submitted to the certificate authority yet then the CSR is attached to it,
+// otherwise the CSR is stored on the VASP and is used by the next certificate request,
+// e.g. when the certificate of the VASP is reissued.
+func (s *Admin) UploadCSR(c *gin.Context) {
 	var (
-		err    error
-		out    *admin.ReviewTokenReply
-		vasp   *pb.VASP
-		vaspID string
+		err     error
+		in      *admin.UploadCSRRequest
+		out     *admin.UploadCSRReply
+		vasp    *pb.VASP
+		certreq *models.CertificateRequest
+		claims  *tokens.Claims
 	)
 
 	// Get vaspID from the URL
-	vaspID = c.Param("vaspID")
+	vaspID := c.Param("vaspID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.UploadCSRRequest)
+	if err = c.ShouldBind(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	if in.VASP != "" && in.VASP != vaspID {
+		log.Warn().Str("vasp", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+		return
+	}
 
-	// Lookup the VASP record associated with the request
 	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		log.Warn().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve VASP from database")
 		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Check if the VASP is in a state where it can be reviewed
-	if vasp.VerificationStatus != pb.VerificationState_PENDING_REVIEW {
-		log.Debug().Str("id", vaspID).Str("status", vasp.VerificationStatus.String()).Msg("could not retrieve admin verification token in current state")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("admin verification token not available if VASP is not pending review"))
+	if models.IsArchived(vasp) {
+		log.Warn().Str("vasp_id", vaspID).Msg("cannot upload a csr for a deleted vasp")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has been deleted and must be restored first"))
 		return
 	}
 
-	// Construct the reply
-	out = &admin.ReviewTokenReply{}
-	if out.AdminVerificationToken, err = models.GetAdminVerificationToken(vasp); err != nil {
-		log.Error().Err(err).Str("id", vaspID).Msg("could not retrieve admin verification token")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve admin verification token"))
+	// Validate the CSR against the common name of the VASP
+	validated := &models.CertificateRequest{CommonName: vasp.CommonName}
+	if err = models.AttachCSR(validated, []byte(in.CSR)); err != nil {
+		log.Warn().Err(err).Str("vasp_id", vaspID).Msg("invalid csr")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Check that an admin verification token will be returned
-	if out.AdminVerificationToken == "" {
-		log.Error().Str("id", vaspID).Str("status", vasp.VerificationStatus.String()).Msg("admin verification token not available to review VASP")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve admin verification token"))
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
 		return
 	}
 
-	// Return the request with the admin verification token
+	if certreq, err = s.unsubmittedCertReq(vasp); err != nil {
+		log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not retrieve certificate requests for vasp")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve certificate requests for VASP"))
+		return
+	}
+
+	out = &admin.UploadCSRReply{}
+	if certreq != nil {
+		certreq.Csr = validated.Csr
+		if err = s.db.UpdateCertReq(certreq); err != nil {
+			log.Error().Err(err).Str("certreq_id", certreq.Id).Msg("could not save certificate request")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not attach csr to certificate request"))
+			return
+		}
+
+		// Any previously uploaded CSR has been superseded
+		if err = models.SetPendingCSR(vasp, ""); err != nil {
+			log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not clear pending csr")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+			return
+		}
+		out.CertificateRequest = certreq.Id
+		out.Message = fmt.Sprintf("csr attached to certificate request %s", certreq.Id)
+	} else {
+		if err = models.SetPendingCSR(vasp, validated.Csr); err != nil {
+			log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not set pending csr")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+			return
+		}
+		out.Message = "csr will be used for the next certificate request"
+	}
+
+	entry := &models.AuditLogEntry{
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PreviousState: vasp.VerificationStatus,
+		CurrentState:  vasp.VerificationStatus,
+		Description:   "certificate signing request uploaded",
+		Source:        claims.Email,
+	}
+	if err = models.AppendAuditLog(vasp, entry); err != nil {
+		log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not append entry to audit log")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+		return
+	}
+
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Str("vasp_id", vaspID).Msg("could not save vasp")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	log.Info().Str("vasp_id", vasp.Id).Str("certreq_id", out.CertificateRequest).Msg("certificate signing request uploaded")
 	c.JSON(http.StatusOK, out)
 }
 
-// Review a registration request and either accept or reject it. On accept, the
-// certificate request that was created on verify is used to send a Sectigo request and
-// the certificate manager process watches it until the certificate has been issued. On
-// reject, the VASP and certificate request records are deleted and the reject reason is
-// sent to the technical contact.
-func (s *Admin) Review(c *gin.Context) {
+// Returns the most recent certificate request of the VASP that has not been submitted
+// to the certificate authority yet, or nil if all of its requests have been submitted.
+func (s *Admin) unsubmittedCertReq(vasp *pb.VASP) (_ *models.CertificateRequest, err error) {
+	var ids []string
+	if ids, err = models.GetCertReqIDs(vasp); err != nil {
+		return nil, err
+	}
+
+	for i := len(ids) - 1; i >= 0; i-- {
+		var certreq *models.CertificateRequest
+		if certreq, err = s.db.RetrieveCertReq(ids[i]); err != nil {
+			return nil, fmt.Errorf("could not retrieve certificate request %s: %s", ids[i], err)
+		}
+
+		switch certreq.Status {
+		case models.CertificateRequestState_INITIALIZED, models.CertificateRequestState_READY_TO_SUBMIT:
+			return certreq, nil
+		}
+	}
+	return nil, nil
+}
+
+// ReplaceContact proposes to completely replace a contact on a VASP with a new contact,
+// which is only executed once it is approved by a second admin. The new contact is
+// validated against the current VASP record before the replacement is proposed.
+func (s *Admin) ReplaceContact(c *gin.Context) {
 	var (
-		err    error
-		in     *admin.ReviewRequest
-		out    *admin.ReviewReply
-		vasp   *pb.VASP
-		vaspID string
+		in      *admin.ReplaceContactRequest
+		vasp    *pb.VASP
+		claims  *tokens.Claims
+		action  *models.PendingAction
+		contact []byte
+		err     error
 	)
 
 	// Get vaspID from the URL
-	vaspID = c.Param("vaspID")
+	vaspID := c.Param("vaspID")
+	kind := c.Param("kind")
 
 	// Parse incoming JSON data from the client request
-	in = new(admin.ReviewRequest)
-	if err := c.ShouldBind(&in); err != nil {
+	in = new(admin.ReplaceContactRequest)
+	if err = c.ShouldBind(&in); err != nil {
 		log.Warn().Err(err).Msg("could not bind request")
 		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Validate review request
-	if in.ID != "" && in.ID != vaspID {
-		log.Warn().Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+	// Sanity check: validate VASP ID
+	if in.VASP != "" && in.VASP != vaspID {
+		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not mactch the URL endpoint"))
 		return
 	}
 
-	if in.AdminVerificationToken == "" {
-		log.Warn().Msg("no verification token specified")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the admin verification token is required"))
+	// Sanity check: validate contact kind
+	if in.Kind != "" && in.Kind != kind {
+		log.Warn().Str("kind", in.Kind).Str("kind", kind).Msg("mismatched contact kind and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the contact kind does not match the URL endpoint"))
 		return
 	}
 
-	if !in.Accept && in.RejectReason == "" {
-		log.Warn().Msg("missing reject reason")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("if rejecting the request, a reason must be supplied"))
+	// Kind must be one of the accepted values
+	if !models.ContactKindIsValid(kind) {
+		log.Warn().Str("kind", kind).Msg("invalid contact kind")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
 		return
 	}
 
-	// Lookup the VASP record associated with the request
+	// Contact data must be provided
+	if len(in.Contact) == 0 {
+		log.Warn().Msg("missing contact data on ReplaceContact request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("contact data is required for ReplaceContact request"))
+		return
+	}
+
+	// Retrieve the VASP from the database
 	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		log.Warn().Err(err).Msg("could not retrieve VASP from database")
 		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
 		return
 	}
 
-	// Check that the administration verification token is correct
-	var adminVerificationToken string
-	if adminVerificationToken, err = models.GetAdminVerificationToken(vasp); err != nil {
-		log.Error().Err(err).Str("id", vaspID).Msg("could not retrieve admin verification token")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve admin verification token"))
+	// Remarshal the JSON contact data
+	update := &pb.Contact{}
+	if err = wire.Unwire(in.Contact, update); err != nil {
+		log.Warn().Err(err).Msg("could not unmarshal contact data")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
-	if in.AdminVerificationToken != adminVerificationToken {
-		log.Warn().Err(err).Str("vasp", vaspID).Msg("incorrect admin verification token")
-		c.JSON(http.StatusUnauthorized, admin.ErrorResponse("admin verification token not accepted"))
+
+	// Validate the replacement on a copy of the VASP, the VASP is not modified until
+	// the replacement is approved.
+	if _, _, err = replaceContact(proto.Clone(vasp).(*pb.VASP), kind, update); err != nil {
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
 	// Retrieve user claims for access to provided user info
-	var claims *tokens.Claims
 	if claims, err = s.getClaims(c); err != nil {
 		log.Error().Err(err).Msg("could not retrieve user claims")
 		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
 		return
 	}
 
-	// Accept or reject the request
-	out = &admin.ReviewReply{}
-	if in.Accept {
-		if out.Message, err = s.acceptRegistration(vasp, claims); err != nil {
-			log.Error().Err(err).Msg("could not accept VASP registration")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to accept VASP registration request"))
-			return
-		}
-	} else {
-		if out.Message, err = s.rejectRegistration(vasp, in.RejectReason, claims); err != nil {
-			log.Error().Err(err).Msg("could not reject VASP registration")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to reject VASP registration request"))
-			return
-		}
+	// Propose the replacement so that it can be approved by another admin
+	if contact, err = protojson.Marshal(update); err != nil {
+		log.Error().Err(err).Msg("could not marshal contact")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not propose contact replacement"))
+		return
 	}
 
-	// Persist the VASP record to the database
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Err(err).Msg("error updating VASP record")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update VASP record"))
+	params := map[string]string{actionParamKind: kind, actionParamContact: string(contact)}
+	if action, err = s.proposeAction(models.PendingActionType_REPLACE_CONTACT, vasp.Id, params, claims); err != nil {
+		s.proposeActionError(c, err)
 		return
 	}
 
-	name, _ := vasp.Name()
-	out.Status = vasp.VerificationStatus.String()
-	log.Info().Str("vasp", vasp.Id).Str("name", name).Bool("accepted", in.Accept).Msg("registration reviewed")
-	c.JSON(http.StatusOK, out)
+	c.JSON(http.StatusAccepted, admin.Reply{Success: true, PendingAction: preparePendingAction(action)})
 }
 
-// Accept the VASP registration and begin the certificate issuance process.
-func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims) (msg string, err error) {
-	// Change the VASP verification status
-	if err = models.SetAdminVerificationToken(vasp, ""); err != nil {
-		return "", err
-	}
-	vasp.VerifiedOn = time.Now().Format(time.RFC3339)
-	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REVIEWED, "registration request received", claims.Email); err != nil {
-		return "", err
+// Replace the contact of the specified kind on the VASP and validate the VASP record,
+// returning the modified contact and if its email address was changed. Errors are
+// caused by invalid contact data and can be returned to the user.
+func replaceContact(vasp *pb.VASP, kind string, update *pb.Contact) (contact *pb.Contact, emailUpdated bool, err error) {
+	if contact = models.ContactFromType(vasp.Contacts, kind); contact == nil {
+		// If the contact doesn't exist then create it
+		if err = models.AddContact(vasp, kind, update); err != nil {
+			log.Warn().Err(err).Msg("could not add contact to VASP")
+			return nil, false, errors.New("invalid contact kind provided")
+		}
+		contact = update
+		emailUpdated = true
+
+		if contact.IsZero() {
+			log.Warn().Msg("cannot create empty contact on update")
+			return nil, false, errors.New("invalid contact data: missing required fields")
+		}
+
+	} else {
+		// Otherwise replace the existing contact info
+		contact.Name = update.Name
+		contact.Phone = update.Phone
+		contact.Person = update.Person
+		if contact.Email != update.Email {
+			contact.Email = update.Email
+			emailUpdated = true
+		}
+
+		if contact.IsZero() {
+			log.Warn().Msg("invalid contact record after update")
+			return nil, false, errors.New("invalid contact data: missing required fields")
+		}
 	}
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		return "", err
+
+	// New VASP record must be valid
+	if err = vasp.Validate(true); err != nil {
+		log.Warn().Err(err).Msg("invalid VASP record after update")
+		return nil, false, fmt.Errorf("validation error: %s", err)
 	}
+	return contact, emailUpdated, nil
+}
 
-	// Mark any initialized certificate requests for this VASP as ready to submit
-	// NOTE: there should only be one certificate request per VASP, but no errors occur
-	// if there are more than one (other than a logged warning).
+// Replace the contact on the VASP once the replacement has been approved, sending a
+// verification email to the new contact if its email address changed.
+func (s *Admin) executeReplaceContact(vaspID string, params map[string]string) (msg string, _ int, err error) {
 	var (
-		ncertreqs int
-		careqs    []string
+		vasp         *pb.VASP
+		contact      *pb.Contact
+		emailUpdated bool
 	)
 
-	if careqs, err = models.GetCertReqIDs(vasp); err != nil {
-		return "", err
+	// Retrieve the VASP from the database
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Msg("could not retrieve VASP from database")
+		return "", http.StatusNotFound, errors.New("could not retrieve VASP record by ID")
 	}
 
-	for _, careqID := range careqs {
-		var careq *models.CertificateRequest
-		if careq, err = s.db.RetrieveCertReq(careqID); err != nil {
-			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careqID).Msg("could not retrieve certificate request for VASP")
-			continue
-		}
+	update := &pb.Contact{}
+	if err = protojson.Unmarshal([]byte(params[actionParamContact]), update); err != nil {
+		log.Error().Err(err).Msg("could not unmarshal proposed contact")
+		return "", http.StatusInternalServerError, errors.New("could not parse proposed contact")
+	}
 
-		// Sanity check
-		if careq.Vasp != vasp.Id {
-			log.Warn().Str("vasp", vasp.Id).Str("certreq", careqID).Msg("vasp associated with unrelated certificate request")
+	// The VASP may have been modified since the replacement was proposed
+	kind := params[actionParamKind]
+	if contact, emailUpdated, err = replaceContact(vasp, kind, update); err != nil {
+		return "", http.StatusBadRequest, err
+	}
+
+	if emailUpdated {
+		// The email address changed, so the contact needs to be verified
+		if err = models.SetContactVerification(contact, secrets.CreateToken(models.VerificationTokenLength), false); err != nil {
+			log.Error().Err(err).Msg("could not set contact verification")
+			return "", http.StatusInternalServerError, errors.New("could not update verification status for the indicated contact")
+		}
+
+		// Send the verification email
+		if err = s.svc.email.SendVerifyContact(vasp, contact); err != nil {
+			log.Error().Err(err).Msg("could not send verification email")
+			return "", http.StatusInternalServerError, errors.New("could not send verification email to the new contact")
+		}
+	}
+
+	// Commit the contact changes to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("could not update VASP in database")
+		status, reason := updateVASPError(err, "could not update VASP record by ID")
+		return "", status, reason
+	}
+
+	return fmt.Sprintf("%s contact has been replaced", kind), http.StatusOK, nil
+}
+
+// DeleteContact deletes a contact on a VASP.
+func (s *Admin) DeleteContact(c *gin.Context) {
+	var (
+		vasp *pb.VASP
+		err  error
+	)
+
+	// Get vaspID from the URL
+	vaspID := c.Param("vaspID")
+	kind := c.Param("kind")
+
+	// Retrieve the VASP from the database
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Msg("could not retrieve VASP from database")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Kind must be one of the accepted values
+	if !models.ContactKindIsValid(kind) {
+		log.Warn().Str("kind", kind).Msg("invalid contact kind")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
+		return
+	}
+
+	// Delete the contact from the VASP
+	if err = models.DeleteContact(vasp, kind); err != nil {
+		log.Warn().Err(err).Msg("could not delete contact from VASP")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("invalid contact kind provided"))
+		return
+	}
+
+	// New VASP record must be valid
+	if err = vasp.Validate(true); err != nil {
+		log.Warn().Err(err).Msg("invalid VASP record after update")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("validation error: %s", err)))
+		return
+	}
+
+	// Commit the contact changes to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("could not update VASP in database")
+		status, reason := updateVASPError(err, "could not update VASP record by ID")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	c.JSON(http.StatusOK, admin.Reply{Success: true})
+}
+
+// CreateReviewNote creates a new review note given the vaspID param and a CreateReviewNoteRequest.
+func (s *Admin) CreateReviewNote(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.ModifyReviewNoteRequest
+		note   *models.ReviewNote
+		vasp   *pb.VASP
+		claims *tokens.Claims
+		vaspID string
+		noteID string
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.ModifyReviewNoteRequest)
+	if err = c.ShouldBind(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Validate VASP ID
+	if in.VASP != "" && in.VASP != vaspID {
+		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+		return
+	}
+
+	// Retrieve author email
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	if in.NoteID == "" {
+		// Create note ID if not provided
+		noteID = uuid.New().String()
+	} else {
+		noteID = in.NoteID
+		// Only allow reasonably-lengthed note IDs (generated IDs are also 36 characters)
+		if len(noteID) > 36 {
+			log.Warn().Err(err).Msg("invalid note ID")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("note ID cannot be longer than 36 characters"))
+			return
+		}
+
+		// Only allow note IDs that can be used in request URLs
+		if escaped := url.QueryEscape(noteID); noteID != escaped {
+			log.Warn().Err(err).Msg("invalid note ID")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("note ID contains unescaped characters: %s", noteID)))
+			return
+		}
+	}
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Create the note
+	if note, err = models.CreateReviewNote(vasp, noteID, claims.Email, in.Text); err != nil {
+		log.Warn().Err(err).Msg("error creating review note")
+		if err == models.ErrorAlreadyExists {
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("note already exists"))
+		} else {
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not create review note"))
+		}
+		return
+	}
+
+	// Persist the VASP record to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("error updating VASP record")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	c.JSON(http.StatusCreated, &admin.ReviewNote{
+		ID:       note.Id,
+		Created:  note.Created,
+		Modified: note.Modified,
+		Author:   note.Author,
+		Editor:   note.Editor,
+		Text:     note.Text,
+	})
+}
+
+// ListReviewNotes returns a list of review notes given a vaspID param.
+func (s *Admin) ListReviewNotes(c *gin.Context) {
+	var (
+		err    error
+		out    *admin.ListReviewNotesReply
+		vasp   *pb.VASP
+		vaspID string
+		notes  map[string]*models.ReviewNote
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Retrieve the slice of notes
+	if notes, err = models.GetReviewNotes(vasp); err != nil {
+		log.Error().Err(err).Msg("error retrieving review notes")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve review notes"))
+		return
+	}
+
+	// Compose the JSON response
+	out = &admin.ListReviewNotesReply{
+		Notes: []admin.ReviewNote{},
+	}
+	for _, n := range notes {
+		out.Notes = append(out.Notes, admin.ReviewNote{
+			ID:       n.Id,
+			Created:  n.Created,
+			Modified: n.Modified,
+			Author:   n.Author,
+			Editor:   n.Editor,
+			Text:     n.Text,
+		})
+	}
+
+	c.JSON(http.StatusOK, out)
+}
+
+// UpdateReivewNote updates the text of a review note given vaspIP and noteID params
+// and an UpdateReviewNoteRequest.
+func (s *Admin) UpdateReviewNote(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.ModifyReviewNoteRequest
+		note   *models.ReviewNote
+		vasp   *pb.VASP
+		claims *tokens.Claims
+		vaspID string
+		noteID string
+	)
+
+	// Get vaspID and noteID from the URL
+	vaspID = c.Param("vaspID")
+	noteID = c.Param("noteID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.ModifyReviewNoteRequest)
+	if err = c.ShouldBind(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Validate VASP ID
+	if in.VASP != "" && in.VASP != vaspID {
+		log.Warn().Str("id", in.VASP).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request VASP ID does not match the URL endpoint"))
+		return
+	}
+
+	// Validate note ID
+	if in.NoteID != "" && in.NoteID != noteID {
+		log.Warn().Str("id", in.NoteID).Str("note_id", noteID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request Note ID does not match the URL endpoint"))
+		return
+	}
+
+	// Retrieve author email
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Update the note
+	if note, err = models.UpdateReviewNote(vasp, noteID, claims.Email, in.Text); err != nil {
+		log.Error().Err(err).Msg("error updating review note")
+		if err == models.ErrorNotFound {
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("review note not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not update review note"))
+		}
+		return
+	}
+
+	// Persist the VASP record to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("error updating VASP record")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	c.JSON(http.StatusOK, &admin.ReviewNote{
+		ID:       note.Id,
+		Created:  note.Created,
+		Modified: note.Modified,
+		Author:   note.Author,
+		Editor:   note.Editor,
+		Text:     note.Text,
+	})
+}
+
+// DeleteReviewNote deletes a review note given vaspID and noteID params.
+func (s *Admin) DeleteReviewNote(c *gin.Context) {
+	var (
+		err    error
+		vasp   *pb.VASP
+		vaspID string
+		noteID string
+	)
+
+	// Get vaspID and noteID from the URL
+	vaspID = c.Param("vaspID")
+	noteID = c.Param("noteID")
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Delete the note
+	if err = models.DeleteReviewNote(vasp, noteID); err != nil {
+		log.Warn().Err(err).Msg("error deleting review note")
+		if err == models.ErrorNotFound {
+			c.JSON(http.StatusNotFound, admin.ErrorResponse("review note not found"))
+		} else {
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not delete review note"))
+		}
+		return
+	}
+
+	// Persist the VASP record to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("error updating VASP record")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	c.JSON(http.StatusOK, &admin.Reply{Success: true})
+}
+
+// ReviewToken returns the admin verification token of the VASP if the VASP is in a
+// state that it can be reviewed in, e.g. PENDING_REVIEW, otherwise a 404 is returned.
+func (s *Admin) ReviewToken(c *gin.Context) {
+	var (
+		err    error
+		out    *admin.ReviewTokenReply
+		vasp   *pb.VASP
+		vaspID string
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Check if the VASP is in a state where it can be reviewed
+	if vasp.VerificationStatus != pb.VerificationState_PENDING_REVIEW {
+		log.Debug().Str("id", vaspID).Str("status", vasp.VerificationStatus.String()).Msg("could not retrieve admin verification token in current state")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("admin verification token not available if VASP is not pending review"))
+		return
+	}
+
+	// Construct the reply
+	out = &admin.ReviewTokenReply{}
+	if out.AdminVerificationToken, err = models.GetAdminVerificationToken(vasp); err != nil {
+		log.Error().Err(err).Str("id", vaspID).Msg("could not retrieve admin verification token")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve admin verification token"))
+		return
+	}
+
+	// Check that an admin verification token will be returned
+	if out.AdminVerificationToken == "" {
+		log.Error().Str("id", vaspID).Str("status", vasp.VerificationStatus.String()).Msg("admin verification token not available to review VASP")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve admin verification token"))
+		return
+	}
+
+	// Return the request with the admin verification token
+	c.JSON(http.StatusOK, out)
+}
+
+// Review a registration request and either accept or reject it. On accept, the
+// certificate request that was created on verify is used to send a Sectigo request and
+// the certificate manager process watches it until the certificate has been issued. On
+// reject, the rejection is proposed and once it is approved by a second admin the
+// certificate request records are deleted and the reject reason is sent to the
+// technical contact.
+func (s *Admin) Review(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.ReviewRequest
+		out    *admin.ReviewReply
+		vasp   *pb.VASP
+		vaspID string
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.ReviewRequest)
+	if err := c.ShouldBind(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Validate review request
+	if in.ID != "" && in.ID != vaspID {
+		log.Warn().Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+		return
+	}
+
+	if in.AdminVerificationToken == "" {
+		log.Warn().Msg("no verification token specified")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the admin verification token is required"))
+		return
+	}
+
+	if !in.Accept && in.RejectReason == "" {
+		log.Warn().Msg("missing reject reason")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("if rejecting the request, a reason must be supplied"))
+		return
+	}
+
+	// Lookup the VASP record associated with the request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Deleted VASPs must be restored before they can be reviewed
+	if models.IsArchived(vasp) {
+		log.Warn().Str("id", vaspID).Msg("cannot review a deleted vasp")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has been deleted and must be restored first"))
+		return
+	}
+
+	// Check that the administration verification token is correct
+	var adminVerificationToken string
+	if adminVerificationToken, err = models.GetAdminVerificationToken(vasp); err != nil {
+		log.Error().Err(err).Str("id", vaspID).Msg("could not retrieve admin verification token")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve admin verification token"))
+		return
+	}
+	if in.AdminVerificationToken != adminVerificationToken {
+		log.Warn().Err(err).Str("vasp", vaspID).Msg("incorrect admin verification token")
+		c.JSON(http.StatusUnauthorized, admin.ErrorResponse("admin verification token not accepted"))
+		return
+	}
+
+	// Retrieve user claims for access to provided user info
+	var claims *tokens.Claims
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	// Rejections must be approved by another admin before the VASP is modified
+	out = &admin.ReviewReply{}
+	if !in.Accept {
+		var action *models.PendingAction
+		params := map[string]string{actionParamReason: in.RejectReason}
+		if action, err = s.proposeAction(models.PendingActionType_REJECT_REGISTRATION, vasp.Id, params, claims); err != nil {
+			s.proposeActionError(c, err)
+			return
+		}
+
+		out.Status = vasp.VerificationStatus.String()
+		out.Message = "the rejection of the registration request must be approved by another admin"
+		out.PendingAction = preparePendingAction(action)
+		c.JSON(http.StatusAccepted, out)
+		return
+	}
+
+	// Accept the request
+	if out.Message, err = s.acceptRegistration(vasp, claims); err != nil {
+		log.Error().Err(err).Msg("could not accept VASP registration")
+		status, reason := updateVASPError(err, "unable to accept VASP registration request")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	// Persist the VASP record to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("error updating VASP record")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	name, _ := vasp.Name()
+	out.Status = vasp.VerificationStatus.String()
+	log.Info().Str("vasp", vasp.Id).Str("name", name).Bool("accepted", in.Accept).Msg("registration reviewed")
+	c.JSON(http.StatusOK, out)
+}
+
+// Accept the VASP registration and begin the certificate issuance process.
+func (s *Admin) acceptRegistration(vasp *pb.VASP, claims *tokens.Claims) (msg string, err error) {
+	// Change the VASP verification status
+	if err = models.SetAdminVerificationToken(vasp, ""); err != nil {
+		return "", err
+	}
+	vasp.VerifiedOn = time.Now().Format(time.RFC3339)
+	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REVIEWED, "registration request received", claims.Email); err != nil {
+		return "", err
+	}
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return "", err
+	}
+
+	// Mark any initialized certificate requests for this VASP as ready to submit
+	// NOTE: there should only be one certificate request per VASP, but no errors occur
+	// if there are more than one (other than a logged warning).
+	var (
+		ncertreqs int
+		careqs    []string
+	)
+
+	if careqs, err = models.GetCertReqIDs(vasp); err != nil {
+		return "", err
+	}
+
+	for _, careqID := range careqs {
+		var careq *models.CertificateRequest
+		if careq, err = s.db.RetrieveCertReq(careqID); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careqID).Msg("could not retrieve certificate request for VASP")
+			continue
+		}
+
+		// Sanity check
+		if careq.Vasp != vasp.Id {
+			log.Warn().Str("vasp", vasp.Id).Str("certreq", careqID).Msg("vasp associated with unrelated certificate request")
+			continue
+		}
+
+		if careq.Status == models.CertificateRequestState_INITIALIZED {
+			if err = models.UpdateCertificateRequestStatus(careq, models.CertificateRequestState_READY_TO_SUBMIT, "registration request received", claims.Email); err != nil {
+				return "", err
+			}
+			if err = s.db.UpdateCertReq(careq); err != nil {
+				return "", err
+			}
+			ncertreqs++
+		}
+	}
+
+	switch ncertreqs {
+	case 0:
+		return "", errors.New("no certificate requests found for VASP registration")
+	case 1:
+		log.Debug().Str("vasp", vasp.Id).Msg("certificate request marked as ready to submit")
+	default:
+		log.Warn().Str("vasp", vasp.Id).Int("requests", ncertreqs).Msg("multiple certificate requests marked as ready to submit")
+	}
+
+	// Send successful response
+	var name string
+	if name, err = vasp.Name(); err != nil {
+		name = vasp.Id
+	}
+	return fmt.Sprintf("registration request for %s has been approved and a Sectigo certificate will be requested", name), nil
+}
+
+// Reject the VASP registration once the rejection has been approved.
+func (s *Admin) executeRejectRegistration(vaspID string, params map[string]string, claims *tokens.Claims) (msg string, _ int, err error) {
+	var vasp *pb.VASP
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		return "", http.StatusNotFound, errors.New("could not retrieve VASP record by ID")
+	}
+
+	// The VASP may have been reviewed since the rejection was proposed
+	if vasp.VerificationStatus != pb.VerificationState_PENDING_REVIEW {
+		log.Warn().Str("id", vaspID).Str("status", vasp.VerificationStatus.String()).Msg("cannot reject registration in current state")
+		return "", http.StatusBadRequest, errors.New("VASP registration is no longer pending review")
+	}
+
+	if msg, err = s.rejectRegistration(vasp, params[actionParamReason], claims); err != nil {
+		log.Error().Err(err).Msg("could not reject VASP registration")
+		status, reason := updateVASPError(err, "unable to reject VASP registration request")
+		return "", status, reason
+	}
+
+	// Persist the VASP record to the database
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Err(err).Msg("error updating VASP record")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		return "", status, reason
+	}
+
+	name, _ := vasp.Name()
+	log.Info().Str("vasp", vasp.Id).Str("name", name).Bool("accepted", false).Msg("registration reviewed")
+	return msg, http.StatusOK, nil
+}
+
+// Reject the VASP registration and notify the contacts of the result.
+func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.Claims) (msg string, err error) {
+	// Change the VASP verification status
+	if err = models.SetAdminVerificationToken(vasp, ""); err != nil {
+		return "", err
+	}
+	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REJECTED, "registration rejected", claims.Email); err != nil {
+		return "", err
+	}
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return "", err
+	}
+
+	// Delete all pending certificate requests
+	var (
+		ncertreqs int
+		careqs    []string
+	)
+
+	if careqs, err = models.GetCertReqIDs(vasp); err != nil {
+		return "", err
+	}
+
+	for _, careqID := range careqs {
+		var careq *models.CertificateRequest
+		if careq, err = s.db.RetrieveCertReq(careqID); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careqID).Msg("could not retrieve certificate request for VASP")
+			continue
+		}
+
+		// Sanity check
+		if careq.Vasp != vasp.Id {
+			log.Warn().Str("vasp", vasp.Id).Str("certreq", careqID).Msg("vasp associated with unrelated certificate request")
+			continue
+		}
+
+		// Delete the certificate request
+		if err = s.db.DeleteCertReq(careq.Id); err != nil {
+			log.Error().Err(err).Str("id", careq.Id).Msg("could not delete certificate request")
+			continue
+		}
+
+		// Delete the VASP reference to the certificate request
+		if err = models.DeleteCertReqID(vasp, careq.Id); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careq.Id).Msg("could not delete certificate request ID from VASP")
+		} else if err = s.db.UpdateVASP(vasp); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not update VASP with deleted certificate request ID")
+		}
+		ncertreqs++
+	}
+
+	// Log deletion of certificate requests
+	switch ncertreqs {
+	case 0:
+		log.Warn().Str("vasp", vasp.Id).Msg("no certificate requests deleted")
+	case 1:
+		log.Debug().Str("vasp", vasp.Id).Msg("certificate request deleted")
+	default:
+		log.Warn().Str("vasp", vasp.Id).Msg("multiple certificate requests deleted")
+	}
+
+	// Notify the VASP contacts that the registration request has been rejected.
+	if _, err = s.svc.email.SendRejectRegistration(vasp, reason); err != nil {
+		return "", err
+	}
+
+	// Send successful response
+	var name string
+	if name, err = vasp.Name(); err != nil {
+		name = vasp.Id
+	}
+	return fmt.Sprintf("registration request for %s has been rejected and its contacts notified", name), nil
+}
+
+// Resend emails in case they went to spam or the initial email send failed.
+func (s *Admin) Resend(c *gin.Context) {
+	var (
+		err    error
+		in     *admin.ResendRequest
+		out    *admin.ResendReply
+		vasp   *pb.VASP
+		vaspID string
+	)
+
+	// Get vaspID from the URL
+	vaspID = c.Param("vaspID")
+
+	// Parse incoming JSON data from the client request
+	in = new(admin.ResendRequest)
+	if err := c.ShouldBind(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	// Validate resend request
+	if in.ID != "" && in.ID != vaspID {
+		log.Warn().Str("id", in.ID).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
+		return
+	}
+
+	// Lookup the VASP record associated with the resend request
+	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
+		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
+		return
+	}
+
+	// Do not send emails on behalf of deleted VASPs
+	if models.IsArchived(vasp) {
+		log.Warn().Str("id", vaspID).Msg("cannot resend emails for a deleted vasp")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP has been deleted and must be restored first"))
+		return
+	}
+
+	// Handle different resend request types
+	out = &admin.ResendReply{}
+	switch in.Action {
+	case admin.ResendVerifyContact:
+		if out.Sent, err = s.svc.email.SendVerifyContacts(vasp); err != nil {
+			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend verify contacts emails")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend contact verification emails: %s", err)))
+			return
+		}
+		out.Message = "contact verification emails resent to all unverified contacts"
+
+	case admin.ResendReview:
+		if out.Sent, err = s.svc.email.SendReviewRequest(vasp); err != nil {
+			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend review request")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend review request: %s", err)))
+			return
+		}
+		out.Message = "review request resent to TRISA admins"
+
+	case admin.ResendDeliverCerts:
+		// TODO: check verification state and cert request state
+		// TODO: in order to implement this, we'd have to fetch the certs from Google Secrets
+		// TODO: if implemented, log which contact was sent the certs (e.g. technical, admin, etc.)
+		// TODO: when above implemented, also log which contact was sent certs in acceptRegistration
+		log.Warn().Msg("resend cert delivery not yet implemented")
+		c.JSON(http.StatusNotImplemented, admin.ErrorResponse("resend cert delivery not yet implemented"))
+		return
+
+	case admin.ResendRejection:
+		// Only send a rejection email if we're in the rejected state
+		if vasp.VerificationStatus != pb.VerificationState_REJECTED {
+			log.Warn().Err(err).Str("status", vasp.VerificationStatus.String()).Msg("cannot resend rejection emails in current state")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP record verification status cannot send rejection email"))
+			return
+		}
+
+		// A reason must be specified to send a rejection email (it's not stored)
+		if in.Reason == "" {
+			log.Warn().Str("resend_type", string(in.Action)).Msg("invalid resend request: missing reason argument")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("must specify reason for rejection to resend email"))
+			return
+		}
+		if out.Sent, err = s.svc.email.SendRejectRegistration(vasp, in.Reason); err != nil {
+			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend rejection emails")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend rejection emails: %s", err)))
+			return
+		}
+		out.Message = "rejection emails resent to all verified contacts"
+
+	case admin.CertificateRevoked:
+		// Resend the notification for the most recently revoked certificate of the VASP
+		var entry *models.AuditLogEntry
+		if entry, err = lastRevocation(vasp); err != nil {
+			log.Error().Err(err).Str("id", vasp.Id).Msg("could not get audit log for vasp")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not resend certificate revocation emails"))
+			return
+		}
+
+		if entry == nil {
+			log.Warn().Str("id", vasp.Id).Msg("cannot resend certificate revocation emails without a revoked certificate")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP does not have a revoked certificate"))
+			return
+		}
+
+		var cert *models.Certificate
+		if cert, err = s.db.RetrieveCert(entry.CertificateId); err != nil {
+			log.Error().Err(err).Str("cert_id", entry.CertificateId).Msg("could not retrieve revoked certificate")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not retrieve revoked certificate record"))
+			return
+		}
+
+		// Use the description of the revocation unless a new reason is specified
+		reason := in.Reason
+		if reason == "" {
+			reason = strings.TrimPrefix(entry.Description, revocationDescription)
+		}
+
+		if out.Sent, err = s.svc.email.SendCertificateRevoked(vasp, cert.Details, reason); err != nil {
+			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend certificate revocation emails")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend certificate revocation emails: %s", err)))
+			return
+		}
+		out.Message = "certificate revocation emails resent to all verified contacts"
+
+	default:
+		log.Warn().Str("resend_type", string(in.Action)).Msg("invalid resend request: unhandled resend request type")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("unknown resend request type %q", in.Action)))
+		return
+	}
+
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		log.Error().Str("id", vasp.Id).Msg("error updating email logs on VASP")
+		status, reason := updateVASPError(err, "could not update VASP record")
+		c.JSON(status, admin.ErrorResponse(reason))
+		return
+	}
+
+	log.Info().Str("id", vasp.Id).Int("sent", out.Sent).Str("resend_type", string(in.Action)).Msg("resend request complete")
+	c.JSON(http.StatusOK, out)
+}
+
+// Parameters of pending actions that are required to execute the action.
+const (
+	actionParamReason  = "reason"
+	actionParamKind    = "kind"
+	actionParamContact = "contact"
+)
+
+// The permission that is required to propose each type of action, which an admin must
+// also have to approve the action or to cancel an action proposed by another admin.
+var actionPermissions = map[models.PendingActionType]string{
+	models.PendingActionType_DELETE_VASP:         tokens.DeletePermission,
+	models.PendingActionType_REJECT_REGISTRATION: tokens.ReviewPermission,
+	models.PendingActionType_REPLACE_CONTACT:     tokens.UpdatePermission,
+}
+
+var (
+	errDuplicateAction  = errors.New("an identical action has already been proposed and is awaiting approval")
+	errConcurrentUpdate = errors.New("the VASP record was modified by another request, reload it and try again")
+)
+
+// ListPendingActions returns the actions that have been proposed by admins, optionally
+// filtered by status or by VASP, with the most recently proposed actions first.
+func (s *Admin) ListPendingActions(c *gin.Context) {
+	var (
+		err     error
+		in      *admin.ListPendingActionsParams
+		out     *admin.ListPendingActionsReply
+		status  int32
+		actions []*models.PendingAction
+	)
+
+	in = new(admin.ListPendingActionsParams)
+	if err = c.ShouldBindQuery(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request with query params")
+		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
+		return
+	}
+
+	if in.Status != "" {
+		var ok bool
+		if status, ok = models.PendingActionState_value[strings.ToUpper(in.Status)]; !ok {
+			log.Warn().Str("status", in.Status).Msg("invalid pending action status")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("unknown pending action status %q", in.Status)))
+			return
+		}
+	}
+
+	s.actions.Lock()
+	actions, err = s.listActions()
+	s.actions.Unlock()
+	if err != nil {
+		log.Error().Err(err).Msg("could not list pending actions")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not list pending actions"))
+		return
+	}
+
+	out = &admin.ListPendingActionsReply{Actions: make([]admin.PendingAction, 0, len(actions))}
+	for _, action := range actions {
+		if in.Status != "" && int32(action.Status) != status {
+			continue
+		}
+
+		if in.VASP != "" && action.Vasp != in.VASP {
+			continue
+		}
+		out.Actions = append(out.Actions, *preparePendingAction(action))
+	}
+
+	// RFC3339 timestamps can be sorted lexicographically
+	sort.SliceStable(out.Actions, func(i, j int) bool {
+		return out.Actions[i].Created > out.Actions[j].Created
+	})
+	c.JSON(http.StatusOK, out)
+}
+
+// RetrievePendingAction returns a pending action by ID.
+func (s *Admin) RetrievePendingAction(c *gin.Context) {
+	s.actions.Lock()
+	defer s.actions.Unlock()
+
+	action, err := s.db.RetrieveAction(c.Param("actionID"))
+	if err != nil {
+		log.Warn().Err(err).Msg("could not retrieve pending action from database")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve pending action by ID"))
+		return
+	}
+
+	s.expireAction(action)
+	c.JSON(http.StatusOK, preparePendingAction(action))
+}
+
+// ApprovePendingAction executes an action that was proposed by a different admin. The
+// approver must have the permission that is required to propose the action. If the
+// action cannot be executed, e.g. because the VASP was modified after the action was
+// proposed, the action fails and cannot be approved again.
+func (s *Admin) ApprovePendingAction(c *gin.Context) {
+	var (
+		err    error
+		action *models.PendingAction
+		claims *tokens.Claims
+		msg    string
+		code   int
+	)
+
+	// Retrieve user claims for access to provided user info
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	// Ensure that the action is only resolved once
+	s.actions.Lock()
+	defer s.actions.Unlock()
+
+	if action, err = s.db.RetrieveAction(c.Param("actionID")); err != nil {
+		log.Warn().Err(err).Msg("could not retrieve pending action from database")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve pending action by ID"))
+		return
+	}
+
+	if s.expireAction(action) || action.Status != models.PendingActionState_ACTION_PROPOSED {
+		log.Warn().Str("action", action.Id).Str("status", action.Status.String()).Msg("cannot approve resolved pending action")
+		c.JSON(http.StatusConflict, admin.ErrorResponse(fmt.Errorf("pending action is %s and can no longer be approved", action.Status)))
+		return
+	}
+
+	if strings.EqualFold(action.ProposedBy, claims.Email) {
+		log.Warn().Str("action", action.Id).Msg("admin attempted to approve their own pending action")
+		c.JSON(http.StatusForbidden, admin.ErrorResponse("actions must be approved by a different admin than the one who proposed them"))
+		return
+	}
+
+	if !claims.HasPermission(actionPermissions[action.Type]) {
+		log.Warn().Str("action", action.Id).Str("type", action.Type.String()).Msg("admin does not have permission to approve pending action")
+		c.JSON(http.StatusForbidden, admin.ErrorResponse("user does not have permission to perform this operation"))
+		return
+	}
+
+	// Execute the action, recording the failure if the action could not be executed
+	if msg, code, err = s.executeAction(action, claims); err != nil {
+		if rerr := models.ResolvePendingAction(action, models.PendingActionState_ACTION_FAILED, claims.Email, err.Error()); rerr != nil {
+			log.Error().Err(rerr).Str("action", action.Id).Msg("could not resolve pending action")
+		} else if rerr = s.db.UpdateAction(action); rerr != nil {
+			log.Error().Err(rerr).Str("action", action.Id).Msg("could not update failed pending action")
+		}
+		c.JSON(code, admin.ErrorResponse(err))
+		return
+	}
+
+	if err = models.ResolvePendingAction(action, models.PendingActionState_ACTION_EXECUTED, claims.Email, msg); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not resolve pending action")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("action was executed but could not be marked as approved"))
+		return
+	}
+
+	if err = s.db.UpdateAction(action); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not update executed pending action")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("action was executed but could not be marked as approved"))
+		return
+	}
+
+	log.Info().Str("action", action.Id).Str("type", action.Type.String()).Str("vasp", action.Vasp).Str("proposed_by", action.ProposedBy).Str("approved_by", claims.Email).Msg("pending action approved")
+	c.JSON(http.StatusOK, preparePendingAction(action))
+}
+
+// CancelPendingAction cancels a proposed action so that it is never executed. Admins
+// can withdraw their own proposals; canceling an action proposed by another admin
+// requires the permission that is required to propose the action.
+func (s *Admin) CancelPendingAction(c *gin.Context) {
+	var (
+		err    error
+		action *models.PendingAction
+		claims *tokens.Claims
+	)
+
+	// Retrieve user claims for access to provided user info
+	if claims, err = s.getClaims(c); err != nil {
+		log.Error().Err(err).Msg("could not retrieve user claims")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("unable to retrieve user info"))
+		return
+	}
+
+	// Ensure that the action is only resolved once
+	s.actions.Lock()
+	defer s.actions.Unlock()
+
+	if action, err = s.db.RetrieveAction(c.Param("actionID")); err != nil {
+		log.Warn().Err(err).Msg("could not retrieve pending action from database")
+		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve pending action by ID"))
+		return
+	}
+
+	if s.expireAction(action) || action.Status != models.PendingActionState_ACTION_PROPOSED {
+		log.Warn().Str("action", action.Id).Str("status", action.Status.String()).Msg("cannot cancel resolved pending action")
+		c.JSON(http.StatusConflict, admin.ErrorResponse(fmt.Errorf("pending action is %s and can no longer be canceled", action.Status)))
+		return
+	}
+
+	if !strings.EqualFold(action.ProposedBy, claims.Email) && !claims.HasPermission(actionPermissions[action.Type]) {
+		log.Warn().Str("action", action.Id).Str("type", action.Type.String()).Msg("admin does not have permission to cancel pending action")
+		c.JSON(http.StatusForbidden, admin.ErrorResponse("user does not have permission to perform this operation"))
+		return
+	}
+
+	if err = models.ResolvePendingAction(action, models.PendingActionState_ACTION_CANCELED, claims.Email, ""); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not resolve pending action")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not cancel pending action"))
+		return
+	}
+
+	if err = s.db.UpdateAction(action); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not update canceled pending action")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not cancel pending action"))
+		return
+	}
+
+	log.Info().Str("action", action.Id).Str("type", action.Type.String()).Str("vasp", action.Vasp).Str("canceled_by", claims.Email).Msg("pending action canceled")
+	c.JSON(http.StatusOK, preparePendingAction(action))
+}
+
+// Propose an action on a VASP that is executed once it is approved by another admin. If
+// an identical action is already awaiting approval, errDuplicateAction is returned.
+func (s *Admin) proposeAction(actionType models.PendingActionType, vaspID string, params map[string]string, claims *tokens.Claims) (action *models.PendingAction, err error) {
+	s.actions.Lock()
+	defer s.actions.Unlock()
+
+	var actions []*models.PendingAction
+	if actions, err = s.listActions(); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range actions {
+		if existing.Status != models.PendingActionState_ACTION_PROPOSED || existing.Type != actionType || existing.Vasp != vaspID {
 			continue
 		}
 
-		if careq.Status == models.CertificateRequestState_INITIALIZED {
-			if err = models.UpdateCertificateRequestStatus(careq, models.CertificateRequestState_READY_TO_SUBMIT, "registration request received", claims.Email); err != nil {
-				return "", err
-			}
-			if err = s.db.UpdateCertReq(careq); err != nil {
-				return "", err
-			}
-			ncertreqs++
+		// Replacements of different contacts on the same VASP are not duplicates
+		if existing.Params[actionParamKind] == params[actionParamKind] {
+			return nil, errDuplicateAction
 		}
 	}
 
-	switch ncertreqs {
-	case 0:
-		return "", errors.New("no certificate requests found for VASP registration")
-	case 1:
-		log.Debug().Str("vasp", vasp.Id).Msg("certificate request marked as ready to submit")
-	default:
-		log.Warn().Str("vasp", vasp.Id).Int("requests", ncertreqs).Msg("multiple certificate requests marked as ready to submit")
+	action = models.NewPendingAction(actionType, vaspID, params, claims.Email, s.conf.PendingActionTimeout)
+	if _, err = s.db.CreateAction(action); err != nil {
+		return nil, err
 	}
 
-	// Send successful response
-	var name string
-	if name, err = vasp.Name(); err != nil {
-		name = vasp.Id
+	log.Info().Str("action", action.Id).Str("type", action.Type.String()).Str("vasp", vaspID).Str("proposed_by", claims.Email).Msg("pending action proposed")
+	return action, nil
+}
+
+// Returns the status code and error to return to the user for a VASP record that could
+// not be updated. If the record was modified by another request after it was retrieved,
+// the update is a conflict that the user can resolve by reloading the record.
+func updateVASPError(err error, msg string) (int, error) {
+	if errors.Is(err, storeerrors.ErrConcurrentUpdate) {
+		return http.StatusConflict, errConcurrentUpdate
 	}
-	return fmt.Sprintf("registration request for %s has been approved and a Sectigo certificate will be requested", name), nil
+	return http.StatusInternalServerError, errors.New(msg)
 }
 
-// Reject the VASP registration and notify the contacts of the result.
-func (s *Admin) rejectRegistration(vasp *pb.VASP, reason string, claims *tokens.Claims) (msg string, err error) {
-	// Change the VASP verification status
-	if err = models.SetAdminVerificationToken(vasp, ""); err != nil {
-		return "", err
+// Write the error response for an action that could not be proposed.
+func (s *Admin) proposeActionError(c *gin.Context, err error) {
+	if errors.Is(err, errDuplicateAction) {
+		log.Warn().Err(err).Msg("could not propose pending action")
+		c.JSON(http.StatusConflict, admin.ErrorResponse(err))
+		return
 	}
-	if err := models.UpdateVerificationStatus(vasp, pb.VerificationState_REJECTED, "registration rejected", claims.Email); err != nil {
-		return "", err
+
+	log.Error().Err(err).Msg("could not create pending action")
+	c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not propose action"))
+}
+
+// Execute an approved action, returning a message describing the result or an error
+// that can be returned to the user with the status code.
+func (s *Admin) executeAction(action *models.PendingAction, claims *tokens.Claims) (msg string, code int, err error) {
+	switch action.Type {
+	case models.PendingActionType_DELETE_VASP:
+		return s.deleteVASP(action.Vasp, claims)
+	case models.PendingActionType_REJECT_REGISTRATION:
+		return s.executeRejectRegistration(action.Vasp, action.Params, claims)
+	case models.PendingActionType_REPLACE_CONTACT:
+		return s.executeReplaceContact(action.Vasp, action.Params)
+	default:
+		log.Error().Str("action", action.Id).Str("type", action.Type.String()).Msg("unhandled pending action type")
+		return "", http.StatusInternalServerError, fmt.Errorf("cannot execute %s action", action.Type)
 	}
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		return "", err
+}
+
+// List all of the pending actions in the store, expiring any proposals that were not
+// approved in time. The caller must hold the actions lock.
+func (s *Admin) listActions() (actions []*models.PendingAction, err error) {
+	if actions, err = s.db.ListActions().All(); err != nil {
+		return nil, err
 	}
 
-	// Delete all pending certificate requests
-	var (
-		ncertreqs int
-		careqs    []string
-	)
+	for _, action := range actions {
+		s.expireAction(action)
+	}
+	return actions, nil
+}
 
-	if careqs, err = models.GetCertReqIDs(vasp); err != nil {
-		return "", err
+// Proposals are expired when they are accessed rather than by a background routine;
+// returns true if the action has expired and was updated in the store.
+func (s *Admin) expireAction(action *models.PendingAction) bool {
+	if !action.IsExpired(time.Now()) {
+		return false
 	}
 
-	for _, careqID := range careqs {
-		var careq *models.CertificateRequest
-		if careq, err = s.db.RetrieveCertReq(careqID); err != nil {
-			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careqID).Msg("could not retrieve certificate request for VASP")
-			continue
+	if err := models.ResolvePendingAction(action, models.PendingActionState_ACTION_EXPIRED, "", "proposal expired before it was approved"); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not expire pending action")
+		return false
+	}
+
+	if err := s.db.UpdateAction(action); err != nil {
+		log.Error().Err(err).Str("action", action.Id).Msg("could not update expired pending action")
+	}
+	return true
+}
+
+// Convert a pending action into its admin API representation.
+func preparePendingAction(action *models.PendingAction) *admin.PendingAction {
+	return &admin.PendingAction{
+		ID:         action.Id,
+		Type:       action.Type.String(),
+		VASP:       action.Vasp,
+		Params:     action.Params,
+		Status:     action.Status.String(),
+		ProposedBy: action.ProposedBy,
+		ResolvedBy: action.ResolvedBy,
+		Created:    action.Created,
+		Modified:   action.Modified,
+		Expires:    action.Expires,
+		Resolved:   action.Resolved,
+		Message:    action.Message,
+	}
+}
+
+//===========================================================================
+// Admin Audit Log
+//===========================================================================
+
+const (
+	// The request ID is returned to the client so that requests can be found in the
+	// audit log, e.g. when reporting an error.
+	requestIDHeader = "X-Request-ID"
+
+	// Context key for handlers of unauthorized routes to identify the admin making the
+	// request, e.g. the admin that is logging in.
+	auditActor = "audit_actor"
+)
+
+// Audit is middleware that appends a record of every request that can modify the
+// directory (any request other than GET, HEAD, or OPTIONS) to the admin audit log,
+// including requests that fail. The records that the route operates on are retrieved
+// before and after the request is handled to determine what the request changed, so
+// the changes may include concurrent modifications of the same records.
+func (s *Admin) Audit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := uuid.New().String()
+		c.Header(requestIDHeader, requestID)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
 		}
 
-		// Sanity check
-		if careq.Vasp != vasp.Id {
-			log.Warn().Str("vasp", vasp.Id).Str("certreq", careqID).Msg("vasp associated with unrelated certificate request")
-			continue
+		record := &models.AuditRecord{
+			Timestamp: time.Now().Format(time.RFC3339Nano),
+			Action:    c.Request.Method + " " + c.FullPath(),
+			RequestId: requestID,
+			ClientIp:  c.ClientIP(),
 		}
 
-		// Delete the certificate request
-		if err = s.db.DeleteCertReq(careq.Id); err != nil {
-			log.Error().Err(err).Str("id", careq.Id).Msg("could not delete certificate request")
-			continue
+		if len(c.Params) > 0 {
+			record.Params = make(map[string]string, len(c.Params))
+			for _, param := range c.Params {
+				record.Params[param.Key] = param.Value
+			}
 		}
 
-		// Delete the VASP reference to the certificate request
-		if err = models.DeleteCertReqID(vasp, careq.Id); err != nil {
-			log.Error().Err(err).Str("vasp", vasp.Id).Str("certreq", careq.Id).Msg("could not delete certificate request ID from VASP")
-		} else if err = s.db.UpdateVASP(vasp); err != nil {
-			log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not update VASP with deleted certificate request ID")
+		if record.Target = c.Param("vaspID"); record.Target == "" {
+			record.Target = c.Param("actionID")
+		}
+
+		// Handle the request
+		before := s.auditSnapshot(c)
+		c.Next()
+
+		record.Status = int32(c.Writer.Status())
+		if claims, err := s.getClaims(c); err == nil {
+			record.Actor = claims.Email
+		} else {
+			record.Actor = c.GetString(auditActor)
+		}
+
+		var err error
+		if record.Changes, err = models.DiffJSON(before, s.auditSnapshot(c)); err != nil {
+			log.Error().Err(err).Str("request_id", requestID).Msg("could not compute changes made by request")
+		}
+
+		if _, err = s.db.CreateAuditRecord(record); err != nil {
+			log.Error().Err(err).Str("request_id", requestID).Str("action", record.Action).Str("actor", record.Actor).Msg("could not append record to the audit log")
 		}
-		ncertreqs++
 	}
+}
 
-	// Log deletion of certificate requests
-	switch ncertreqs {
-	case 0:
-		log.Warn().Str("vasp", vasp.Id).Msg("no certificate requests deleted")
-	case 1:
-		log.Debug().Str("vasp", vasp.Id).Msg("certificate request deleted")
-	default:
-		log.Warn().Str("vasp", vasp.Id).Msg("multiple certificate requests deleted")
+// Returns a JSON document containing the protojson encoded records that a request on
+// the route operates on keyed by the type of record, e.g. {"vasp": {...}}. Records
+// that do not exist are omitted so that creations and deletions are diffed. The extra
+// data of the VASP is redacted so that secrets are not written to the audit log.
+func (s *Admin) auditSnapshot(c *gin.Context) []byte {
+	records := make(map[string]json.RawMessage)
+	snapshot := func(name string, record proto.Message) {
+		data, err := protojson.Marshal(record)
+		if err != nil {
+			log.Warn().Err(err).Str("record", name).Msg("could not marshal record for the audit log")
+			return
+		}
+		records[name] = data
 	}
 
-	// Notify the VASP contacts that the registration request has been rejected.
-	if _, err = s.svc.email.SendRejectRegistration(vasp, reason); err != nil {
-		return "", err
+	vaspID := c.Param("vaspID")
+	if actionID := c.Param("actionID"); actionID != "" {
+		if action, err := s.db.RetrieveAction(actionID); err == nil {
+			snapshot("action", action)
+			vaspID = action.Vasp
+		}
 	}
 
-	// Send successful response
-	var name string
-	if name, err = vasp.Name(); err != nil {
-		name = vasp.Id
+	if vaspID != "" {
+		if vasp, err := s.db.RetrieveVASP(vaspID); err == nil {
+			redactVASP(vasp)
+			snapshot("vasp", vasp)
+		}
 	}
-	return fmt.Sprintf("registration request for %s has been rejected and its contacts notified", name), nil
+
+	if certID := c.Param("certID"); certID != "" {
+		if cert, err := s.db.RetrieveCert(certID); err == nil {
+			snapshot("certificate", cert)
+		}
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not marshal records for the audit log")
+		return nil
+	}
+	return data
 }
 
-// Resend emails in case they went to spam or the initial email send failed.
-func (s *Admin) Resend(c *gin.Context) {
+// ListAuditRecords returns a page of the admin audit log, most recent first. Records
+// can be filtered by the actor, the target, the request ID, whether the action
+// contains the specified string (e.g. "/notes"), and by the dates they were recorded.
+func (s *Admin) ListAuditRecords(c *gin.Context) {
+	// Go needs this constant to determine the time format
+	const timeFormat = "2006-01-02"
 	var (
-		err    error
-		in     *admin.ResendRequest
-		out    *admin.ResendReply
-		vasp   *pb.VASP
-		vaspID string
+		err       error
+		in        *admin.ListAuditRecordsParams
+		out       *admin.ListAuditRecordsReply
+		startTime time.Time
+		endTime   time.Time
 	)
 
-	// Get vaspID from the URL
-	vaspID = c.Param("vaspID")
-
-	// Parse incoming JSON data from the client request
-	in = new(admin.ResendRequest)
-	if err := c.ShouldBind(&in); err != nil {
-		log.Warn().Err(err).Msg("could not bind request")
+	in = new(admin.ListAuditRecordsParams)
+	if err = c.ShouldBindQuery(&in); err != nil {
+		log.Warn().Err(err).Msg("could not bind request with query params")
 		c.JSON(http.StatusBadRequest, admin.ErrorResponse(err))
 		return
 	}
 
-	// Validate resend request
-	if in.ID != "" && in.ID != vaspID {
-		log.Warn().Str("id", in.ID).Str("vasp_id", vaspID).Msg("mismatched request ID and URL")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse("the request ID does not match the URL endpoint"))
-		return
-	}
-
-	// Lookup the VASP record associated with the resend request
-	if vasp, err = s.db.RetrieveVASP(vaspID); err != nil {
-		log.Warn().Err(err).Str("id", vaspID).Msg("could not retrieve vasp")
-		c.JSON(http.StatusNotFound, admin.ErrorResponse("could not retrieve VASP record by ID"))
-		return
+	if in.Start != "" {
+		if startTime, err = time.Parse(timeFormat, in.Start); err != nil {
+			log.Warn().Err(err).Msg("could not parse start date")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("invalid start date: %s", in.Start)))
+			return
+		}
 	}
 
-	// Handle different resend request types
-	out = &admin.ResendReply{}
-	switch in.Action {
-	case admin.ResendVerifyContact:
-		if out.Sent, err = s.svc.email.SendVerifyContacts(vasp); err != nil {
-			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend verify contacts emails")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend contact verification emails: %s", err)))
+	if in.End != "" {
+		if endTime, err = time.Parse(timeFormat, in.End); err != nil {
+			log.Warn().Err(err).Msg("could not parse end date")
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("invalid end date: %s", in.End)))
 			return
 		}
-		out.Message = "contact verification emails resent to all unverified contacts"
 
-	case admin.ResendReview:
-		if out.Sent, err = s.svc.email.SendReviewRequest(vasp); err != nil {
-			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend review request")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend review request: %s", err)))
+		// The end date is inclusive
+		endTime = endTime.AddDate(0, 0, 1)
+		if endTime.Before(startTime) {
+			c.JSON(http.StatusBadRequest, admin.ErrorResponse("start date must be before end date"))
 			return
 		}
-		out.Message = "review request resent to TRISA admins"
+	}
 
-	case admin.ResendDeliverCerts:
-		// TODO: check verification state and cert request state
-		// TODO: in order to implement this, we'd have to fetch the certs from Google Secrets
-		// TODO: if implemented, log which contact was sent the certs (e.g. technical, admin, etc.)
-		// TODO: when above implemented, also log which contact was sent certs in acceptRegistration
-		log.Warn().Msg("resend cert delivery not yet implemented")
-		c.JSON(http.StatusNotImplemented, admin.ErrorResponse("resend cert delivery not yet implemented"))
-		return
+	// Set pagination defaults if not specified in query
+	if in.Page <= 0 {
+		in.Page = 1
+	}
+	if in.PageSize <= 0 {
+		in.PageSize = 100
+	}
 
-	case admin.ResendRejection:
-		// Only send a rejection email if we're in the rejected state
-		if vasp.VerificationStatus != pb.VerificationState_REJECTED {
-			log.Warn().Err(err).Str("status", vasp.VerificationStatus.String()).Msg("cannot resend rejection emails in current state")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("VASP record verification status cannot send rejection email"))
+	out = &admin.ListAuditRecordsReply{
+		Records:  make([]admin.AuditRecord, 0, in.PageSize),
+		Page:     in.Page,
+		PageSize: in.PageSize,
+	}
+
+	// Audit records are listed most recent first so the iterator seeks past the records
+	// created after the end date and stops at the first record created before the start
+	// date; only the records on the requested page are kept in memory.
+	iter := s.db.ListAuditRecords()
+	defer iter.Release()
+
+	var ok bool
+	if !endTime.IsZero() {
+		ok = iter.SeekTimestamp(endTime)
+	} else {
+		ok = iter.Next()
+	}
+
+	offset := (in.Page - 1) * in.PageSize
+	for ; ok; ok = iter.Next() {
+		var record *models.AuditRecord
+		if record, err = iter.Record(); err != nil {
+			log.Error().Err(err).Msg("could not parse audit record from database")
+			c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not list audit records"))
 			return
 		}
 
-		// A reason must be specified to send a rejection email (it's not stored)
-		if in.Reason == "" {
-			log.Warn().Str("resend_type", string(in.Action)).Msg("invalid resend request: missing reason argument")
-			c.JSON(http.StatusBadRequest, admin.ErrorResponse("must specify reason for rejection to resend email"))
-			return
+		var ts time.Time
+		if ts, err = time.Parse(time.RFC3339Nano, record.Timestamp); err != nil {
+			log.Warn().Err(err).Str("record", record.Id).Msg("could not parse audit record timestamp")
 		}
-		if out.Sent, err = s.svc.email.SendRejectRegistration(vasp, in.Reason); err != nil {
-			log.Error().Err(err).Int("sent", out.Sent).Msg("could not resend rejection emails")
-			c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not resend rejection emails: %s", err)))
-			return
+
+		if !startTime.IsZero() && ts.Before(startTime) {
+			break
 		}
-		out.Message = "rejection emails resent to all verified contacts"
 
-	default:
-		log.Warn().Str("resend_type", string(in.Action)).Msg("invalid resend request: unhandled resend request type")
-		c.JSON(http.StatusBadRequest, admin.ErrorResponse(fmt.Errorf("unknown resend request type %q", in.Action)))
-		return
+		if (in.Actor != "" && !strings.EqualFold(record.Actor, in.Actor)) ||
+			(in.Action != "" && !strings.Contains(record.Action, in.Action)) ||
+			(in.Target != "" && record.Target != in.Target) ||
+			(in.RequestID != "" && record.RequestId != in.RequestID) ||
+			(!endTime.IsZero() && !ts.Before(endTime)) {
+			continue
+		}
+
+		if out.Count >= offset && len(out.Records) < in.PageSize {
+			out.Records = append(out.Records, prepareAuditRecord(record))
+		}
+		out.Count++
 	}
 
-	if err = s.db.UpdateVASP(vasp); err != nil {
-		log.Error().Str("id", vasp.Id).Msg("error updating email logs on VASP")
-		c.JSON(http.StatusInternalServerError, admin.ErrorResponse(fmt.Errorf("could not update VASP record: %s", err)))
+	if err = iter.Error(); err != nil {
+		log.Error().Err(err).Msg("could not list audit records")
+		c.JSON(http.StatusInternalServerError, admin.ErrorResponse("could not list audit records"))
 		return
 	}
-
-	log.Info().Str("id", vasp.Id).Int("sent", out.Sent).Str("resend_type", string(in.Action)).Msg("resend request complete")
 	c.JSON(http.StatusOK, out)
 }
 
+// Convert an audit record into its admin API representation.
+func prepareAuditRecord(record *models.AuditRecord) admin.AuditRecord {
+	out := admin.AuditRecord{
+		ID:        record.Id,
+		Timestamp: record.Timestamp,
+		Actor:     record.Actor,
+		Action:    record.Action,
+		Target:    record.Target,
+		Params:    record.Params,
+		RequestID: record.RequestId,
+		Status:    int(record.Status),
+		ClientIP:  record.ClientIp,
+		Changes:   make([]admin.FieldChange, 0, len(record.Changes)),
+	}
+
+	for _, change := range record.Changes {
+		out.Changes = append(out.Changes, admin.FieldChange{
+			Path:   change.Path,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return out
+}
+
 const (
 	serverStatusOK          = "ok"
 	serverStatusMaintenance = "maintenance"
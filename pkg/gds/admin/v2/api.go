@@ -24,7 +24,13 @@ type DirectoryAdministrationClient interface {
 	RetrieveVASP(ctx context.Context, id string) (out *RetrieveVASPReply, err error)
 	UpdateVASP(ctx context.Context, in *UpdateVASPRequest) (out *UpdateVASPReply, err error)
 	DeleteVASP(ctx context.Context, id string) (out *Reply, err error)
+	RestoreVASP(ctx context.Context, id string) (out *Reply, err error)
+	ListRevisions(ctx context.Context, vaspID string) (out *ListRevisionsReply, err error)
+	RevisionDiff(ctx context.Context, vaspID string, revision uint64, params *RevisionDiffParams) (out *RevisionDiffReply, err error)
+	RollbackVASP(ctx context.Context, vaspID string, revision uint64) (out *UpdateVASPReply, err error)
 	ListCertificates(ctx context.Context, vaspID string) (out *ListCertificatesReply, err error)
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest) (out *RevokeCertificateReply, err error)
+	UploadCSR(ctx context.Context, in *UploadCSRRequest) (out *UploadCSRReply, err error)
 	ReplaceContact(ctx context.Context, in *ReplaceContactRequest) (out *Reply, err error)
 	DeleteContact(ctx context.Context, vaspID string, kind string) (out *Reply, err error)
 	CreateReviewNote(ctx context.Context, in *ModifyReviewNoteRequest) (out *ReviewNote, err error)
@@ -34,16 +40,24 @@ type DirectoryAdministrationClient interface {
 	ReviewToken(ctx context.Context, vaspID string) (out *ReviewTokenReply, err error)
 	Review(ctx context.Context, in *ReviewRequest) (out *ReviewReply, err error)
 	Resend(ctx context.Context, in *ResendRequest) (out *ResendReply, err error)
+	ListPendingActions(ctx context.Context, params *ListPendingActionsParams) (out *ListPendingActionsReply, err error)
+	RetrievePendingAction(ctx context.Context, id string) (out *PendingAction, err error)
+	ApprovePendingAction(ctx context.Context, id string) (out *PendingAction, err error)
+	CancelPendingAction(ctx context.Context, id string) (out *PendingAction, err error)
+	ListAuditRecords(ctx context.Context, params *ListAuditRecordsParams) (out *ListAuditRecordsReply, err error)
 }
 
 //===========================================================================
 // Top Level Requests and Responses
 //===========================================================================
 
-// Reply contains standard fields that are used for generic API responses and errors
+// Reply contains standard fields that are used for generic API responses and errors.
+// If the request proposed an action that must be approved by a second admin before it
+// is executed, the pending action is returned in the reply.
 type Reply struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+	Success       bool           `json:"success"`
+	Error         string         `json:"error,omitempty" yaml:"error,omitempty"`
+	PendingAction *PendingAction `json:"pending_action,omitempty" yaml:"pending_action,omitempty"`
 }
 
 // StatusReply is returned on status requests. Note that no request is needed.
@@ -81,6 +95,7 @@ type SummaryReply struct {
 	ContactsCount        int            `json:"contacts_count"`        // the number of contacts in the system
 	VerifiedContacts     int            `json:"verified_contacts"`     // the number of verified contacts in the system
 	CertificatesIssued   int            `json:"certificates_issued"`   // the number of certificates issued by the GDS
+	PendingActions       int            `json:"pending_actions"`       // the number of proposed actions awaiting approval
 	Statuses             map[string]int `json:"statuses"`              // the counts of all statuses in the system
 	CertReqs             map[string]int `json:"certreqs"`              // The counts of all certificate request statuses
 }
@@ -90,11 +105,13 @@ type AutocompleteReply struct {
 	Names map[string]string `json:"names"`
 }
 
-// ReviewTimelineRecord contains counts of VASP registration states over a single week.
+// ReviewTimelineRecord contains counts of VASP registration states over a single week
+// and the counts of the pending actions proposed that week by their current status.
 type ReviewTimelineRecord struct {
 	Week          string         `json:"week"`
 	VASPsUpdated  int            `json:"vasps_updated"`
 	Registrations map[string]int `json:"registrations"`
+	Actions       map[string]int `json:"actions"`
 }
 
 // ReviewTimelineReply returns a list of time series records containing registration counts.
@@ -110,6 +127,7 @@ type ReviewTimelineReply struct {
 // GET request. All query params are optional and modify how and what data is retrieved.
 type ListVASPsParams struct {
 	StatusFilters []string `url:"status,omitempty" form:"status"`
+	Archived      bool     `url:"archived,omitempty" form:"archived"`                 // list deleted VASPs that have not been purged
 	Page          int      `url:"page,omitempty" form:"page" default:"1"`             // defaults to page 1 if not included
 	PageSize      int      `url:"page_size,omitempty" form:"page_size" default:"100"` // defaults to 100 if not included
 }
@@ -145,12 +163,15 @@ type VASPSnippet struct {
 // serialized pb.VASP record is returned to make sure that the Admin API keeps up with
 // changes in the TRISA library. Admin API developers should reference the
 // trisacrypto/trisa library to ensure they have all of the requried data that is
-// returned. Go developers should unmarshal the data into a *pb.VASP struct.
+// returned. Go developers should unmarshal the data into a *pb.VASP struct. If the VASP
+// has been deleted, Archived is the timestamp of the deletion; the VASP can be restored
+// until it is purged.
 type RetrieveVASPReply struct {
 	Name             string                   `json:"name"`
 	VASP             map[string]interface{}   `json:"vasp"`
 	VerifiedContacts map[string]string        `json:"verified_contacts"`
 	Traveler         bool                     `json:"traveler"`
+	Archived         string                   `json:"archived,omitempty"`
 	AuditLog         []map[string]interface{} `json:"audit_log"`
 }
 
@@ -190,6 +211,40 @@ type UpdateVASPRequest struct {
 // UpdateVASPReply is identical to RetrieveVASPReply, simply renamed for clarity.
 type UpdateVASPReply RetrieveVASPReply
 
+//===========================================================================
+// VASP Revision History RPCs
+//===========================================================================
+
+// Revision summarizes a copy of the VASP record that was stored when the record was
+// created or updated, whether by an admin, by the VASP registering, or by certificate
+// reissuance. Revisions are numbered sequentially starting at 1.
+type Revision struct {
+	Revision           uint64 `json:"revision"`
+	Created            string `json:"created"`
+	Version            uint64 `json:"version"`
+	VerificationStatus string `json:"verification_status"`
+}
+
+// ListRevisionsReply contains the revisions of a VASP record, most recent first.
+type ListRevisionsReply struct {
+	Revisions []Revision `json:"revisions"`
+}
+
+// RevisionDiffParams specifies which revision the requested revision is compared to;
+// if not specified the revision is compared to the previous revision.
+type RevisionDiffParams struct {
+	Against uint64 `url:"against,omitempty" form:"against"`
+}
+
+// RevisionDiffReply contains the fields of the VASP record that changed between the
+// two revisions. The first revision is compared to an empty record (against is 0).
+// Extra data such as the audit log and verification tokens is not compared.
+type RevisionDiffReply struct {
+	Revision uint64        `json:"revision"`
+	Against  uint64        `json:"against"`
+	Changes  []FieldChange `json:"changes"`
+}
+
 //===========================================================================
 // Certificate Management RPCs
 //===========================================================================
@@ -211,6 +266,47 @@ type ListCertificatesReply struct {
 	Certificates []Certificate `json:"certificates"`
 }
 
+// RevokeCertificateRequest revokes a certificate issued to a VASP with the certificate
+// authority and notifies the VASP contacts that the certificate has been revoked.
+type RevokeCertificateRequest struct {
+	// The ID of the VASP and the certificate (optional - are part of the URL)
+	VASP        string `json:"vasp,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+
+	// The RFC 5280 revocation reason, e.g. "key compromise" or "cessation of operation".
+	// If the reason is empty then the certificate is revoked with an unspecified reason.
+	Reason string `json:"reason,omitempty"`
+
+	// An explanation of the revocation that is recorded in the audit log and sent to the
+	// VASP contacts. If not specified, the reason is used instead.
+	Description string `json:"description,omitempty"`
+}
+
+// RevokeCertificateReply returns the revoked certificate and the number of contacts
+// that were notified of the revocation.
+type RevokeCertificateReply struct {
+	Certificate Certificate `json:"certificate"`
+	Sent        int         `json:"sent"`
+	Message     string      `json:"message"`
+}
+
+// UploadCSRRequest supplies a new PEM encoded certificate signing request for a VASP
+// after it has registered, e.g. to rotate the key that a reissued certificate is issued
+// for. The CSR must be for the common name of the VASP.
+type UploadCSRRequest struct {
+	// The ID of the VASP (optional - is part of the URL)
+	VASP string `json:"vasp,omitempty"`
+	CSR  string `json:"csr"`
+}
+
+// UploadCSRReply describes how the CSR will be used: if the VASP has a certificate
+// request that has not been submitted yet then the CSR is attached to that request,
+// otherwise it is stored on the VASP and used for the next certificate request.
+type UploadCSRReply struct {
+	CertificateRequest string `json:"certificate_request,omitempty"`
+	Message            string `json:"message"`
+}
+
 //===========================================================================
 // Contact management RPCs
 //===========================================================================
@@ -305,11 +401,14 @@ type ReviewRequest struct {
 	RejectReason string `json:"reject_reason,omitempty"`
 }
 
-// ReviewReply returns verification status of the VASP Registration.
+// ReviewReply returns verification status of the VASP Registration. Rejections must be
+// approved by a second admin, so the pending action is returned instead of executing
+// the rejection and the status of the VASP is unchanged.
 type ReviewReply struct {
 	// Status must be a valid trisa.gds.models.v1beta1.VerificationState
-	Status  string `json:"status"`
-	Message string `json:"message"`
+	Status        string         `json:"status"`
+	Message       string         `json:"message"`
+	PendingAction *PendingAction `json:"pending_action,omitempty"`
 }
 
 // ResendActions to use in ResendRequests
@@ -322,6 +421,7 @@ const (
 	ResendRejection     ResendAction = "rejection"
 	ReissuanceReminder  ResendAction = "reissuance_reminder"
 	ReissuanceStarted   ResendAction = "reissuance_started"
+	CertificateRevoked  ResendAction = "certificate_revoked"
 )
 
 // ResendRequest allows extra attempts to resend emails to be made if they were not
@@ -332,7 +432,9 @@ type ResendRequest struct {
 	ID string `json:"vasp_id,omitempty"`
 
 	// The resend action type, must parse to a ResendAction enumeration. If the action
-	// is "rejection" then a reason must be supplied for the rejection as well.
+	// is "rejection" then a reason must be supplied for the rejection as well. If the
+	// action is "certificate_revoked" then the notification of the most recently revoked
+	// certificate is resent, using the reason as the description if it is supplied.
 	Action ResendAction `json:"action"`
 	Reason string       `json:"reason,omitempty"`
 }
@@ -342,3 +444,105 @@ type ResendReply struct {
 	Sent    int    `json:"sent"`
 	Message string `json:"message"`
 }
+
+//===========================================================================
+// Pending Action RPCs
+//===========================================================================
+
+// PendingAction is a destructive action (deleting a VASP, rejecting a registration, or
+// replacing a contact) that has been proposed by one admin and is only executed by the
+// GDS once it has been approved by a second, different admin. Proposals that are not
+// approved or canceled before they expire can no longer be executed.
+type PendingAction struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	VASP string `json:"vasp"`
+
+	// Parameters of the action, e.g. the reject reason or the kind of contact and the
+	// protojson marshaled gds.models.v1beta1.Contact that replaces it.
+	Params map[string]string `json:"params,omitempty"`
+
+	// Status must be a valid gds.models.v1.PendingActionState
+	Status     string `json:"status"`
+	ProposedBy string `json:"proposed_by"`
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	Created    string `json:"created"`
+	Modified   string `json:"modified"`
+	Expires    string `json:"expires"`
+	Resolved   string `json:"resolved,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// ListPendingActionsParams filters the pending actions that are returned; by default
+// actions in all states for all VASPs are returned.
+type ListPendingActionsParams struct {
+	Status string `url:"status,omitempty" form:"status"`
+	VASP   string `url:"vasp,omitempty" form:"vasp"`
+}
+
+// ListPendingActionsReply contains the pending actions ordered by when they were
+// proposed, most recent first.
+type ListPendingActionsReply struct {
+	Actions []PendingAction `json:"actions"`
+}
+
+//===========================================================================
+// Audit Log RPCs
+//===========================================================================
+
+// AuditRecord describes a request made to the admin API that modified the directory,
+// including requests that failed. The audit log is append-only and, unlike the audit
+// log of a VASP, is retained when the VASP is deleted.
+type AuditRecord struct {
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp"`
+
+	// The email address of the admin and the method and route of the request, e.g.
+	// "PATCH /v2/vasps/:vaspID". The actor is empty if the request was unauthenticated.
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+
+	// The ID of the VASP or pending action the request was made on and the parameters
+	// of the route, e.g. the kind of contact or the ID of the note.
+	Target string            `json:"target,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+
+	// The request ID is returned to the client in the X-Request-ID header
+	RequestID string `json:"request_id"`
+	Status    int    `json:"status"`
+	ClientIP  string `json:"client_ip,omitempty"`
+
+	// The fields of the VASP, pending action, or certificate modified by the request
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange is a field identified by its path in the JSON representation of the
+// record with its JSON encoded value before and after the request. An empty value
+// means that the field was not set, e.g. because the record was created or deleted.
+type FieldChange struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ListAuditRecordsParams filters the records in the audit log. The start and end
+// dates are inclusive and must be formatted as YYYY-MM-DD.
+type ListAuditRecordsParams struct {
+	Actor     string `url:"actor,omitempty" form:"actor"`
+	Action    string `url:"action,omitempty" form:"action"`
+	Target    string `url:"target,omitempty" form:"target"`
+	RequestID string `url:"request_id,omitempty" form:"request_id"`
+	Start     string `url:"start,omitempty" form:"start"`
+	End       string `url:"end,omitempty" form:"end"`
+	Page      int    `url:"page,omitempty" form:"page" default:"1"`             // defaults to page 1 if not included
+	PageSize  int    `url:"page_size,omitempty" form:"page_size" default:"100"` // defaults to 100 if not included
+}
+
+// ListAuditRecordsReply contains a page of the audit records that match the filters,
+// most recent first, along with the total number of matching records.
+type ListAuditRecordsReply struct {
+	Records  []AuditRecord `json:"records"`
+	Count    int           `json:"count"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
@@ -18,7 +18,9 @@ const (
 
 // Authorization middleware ensures that the request has a valid Bearer JWT in the
 // Authorization header of the request otherwise it returns a 401 unauthorized error.
-func Authorization(tm *tokens.TokenManager) gin.HandlerFunc {
+// If permissions are specified, the claims of the access token must include all of
+// them otherwise a 403 forbidden error is returned.
+func Authorization(tm *tokens.TokenManager, permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
 			err         error
@@ -40,6 +42,13 @@ func Authorization(tm *tokens.TokenManager) gin.HandlerFunc {
 			return
 		}
 
+		// Verify that the user has the permissions required by the endpoint
+		if !claims.HasPermission(permissions...) {
+			log.Debug().Str("email", claims.Email).Strs("required", permissions).Strs("permissions", claims.Permissions).Msg("user does not have required permissions")
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse("user does not have permission to perform this operation"))
+			return
+		}
+
 		// Add claims to context for use in downstream processing
 		c.Set(UserClaims, claims)
 
@@ -94,6 +94,60 @@ func TestAuthorization(t *testing.T) {
 	require.Equal(t, "a valid authorization is required to access this endpoint", data["error"].(string))
 }
 
+func TestAuthorizationPermissions(t *testing.T) {
+	// Test Authorization middleware with required permissions
+	router := gin.New()
+	tm, err := tokens.MockTokenManager()
+	require.NoError(t, err)
+
+	router.GET("/", admin.Authorization(tm, tokens.ReadPermission), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+	router.DELETE("/", admin.Authorization(tm, tokens.ReadPermission, tokens.DeletePermission), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// Create a reviewer access token
+	permissions, err := tokens.RolePermissions(tokens.ReviewerRole)
+	require.NoError(t, err)
+
+	creds := map[string]interface{}{
+		"hd":          "rotational.io",
+		"email":       "kate@rotational.io",
+		"permissions": permissions,
+	}
+
+	accessToken, err := tm.CreateAccessToken(creds)
+	require.NoError(t, err)
+	tks, err := tm.Sign(accessToken)
+	require.NoError(t, err)
+
+	// Reviewers can access read-only endpoints
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tks)
+
+	rep, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rep.StatusCode)
+
+	// Reviewers cannot access endpoints that require the delete permission
+	req, err = http.NewRequest(http.MethodDelete, server.URL+"/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+tks)
+
+	rep, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, rep.StatusCode)
+
+	data, err := readJSON(rep)
+	require.NoError(t, err)
+	require.Equal(t, "user does not have permission to perform this operation", data["error"].(string))
+}
+
 func TestGetAccessToken(t *testing.T) {
 	// Create a gin router that constructs a client from the context
 	router := gin.New()
@@ -390,6 +390,130 @@ func (s *APIv2) DeleteVASP(ctx context.Context, id string) (out *Reply, err erro
 	return out, nil
 }
 
+func (s *APIv2) RestoreVASP(ctx context.Context, id string) (out *Reply, err error) {
+	// vaspID is required for the endpoint
+	if id == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/restore", id)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &Reply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) ListRevisions(ctx context.Context, vaspID string) (out *ListRevisionsReply, err error) {
+	// vaspID is required for the endpoint
+	if vaspID == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/history", vaspID)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &ListRevisionsReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) RevisionDiff(ctx context.Context, vaspID string, revision uint64, in *RevisionDiffParams) (out *RevisionDiffReply, err error) {
+	// vaspID and revision are required for the endpoint
+	if vaspID == "" || revision == 0 {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/history/%d/diff", vaspID, revision)
+
+	// Create the query params from the input
+	var params url.Values
+	if in != nil {
+		if params, err = query.Values(in); err != nil {
+			return nil, fmt.Errorf("could not encode query params: %s", err)
+		}
+	}
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, path, nil, &params); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &RevisionDiffReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) RollbackVASP(ctx context.Context, vaspID string, revision uint64) (out *UpdateVASPReply, err error) {
+	// vaspID and revision are required for the endpoint
+	if vaspID == "" || revision == 0 {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/history/%d/rollback", vaspID, revision)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &UpdateVASPReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (s *APIv2) ListCertificates(ctx context.Context, id string) (out *ListCertificatesReply, err error) {
 	// vaspID is required for the endpoint
 	if id == "" {
@@ -419,6 +543,64 @@ func (s *APIv2) ListCertificates(ctx context.Context, id string) (out *ListCerti
 	return out, nil
 }
 
+func (s *APIv2) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest) (out *RevokeCertificateReply, err error) {
+	// vaspID and certID are required for the endpoint
+	if in.VASP == "" || in.Certificate == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/certificates/%s/revoke", in.VASP, in.Certificate)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, path, in, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &RevokeCertificateReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) UploadCSR(ctx context.Context, in *UploadCSRRequest) (out *UploadCSRReply, err error) {
+	// vaspID is required for the endpoint
+	if in.VASP == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/vasps/%s/csr", in.VASP)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, path, in, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &UploadCSRReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (s *APIv2) ReplaceContact(ctx context.Context, in *ReplaceContactRequest) (out *Reply, err error) {
 	// vaspID is required for the endpoint
 	if in.VASP == "" {
@@ -680,6 +862,126 @@ func (s *APIv2) Resend(ctx context.Context, in *ResendRequest) (out *ResendReply
 	return out, nil
 }
 
+func (s *APIv2) ListPendingActions(ctx context.Context, in *ListPendingActionsParams) (out *ListPendingActionsReply, err error) {
+	// Create the query params from the input
+	var params url.Values
+	if params, err = query.Values(in); err != nil {
+		return nil, fmt.Errorf("could not encode query params: %s", err)
+	}
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	//  Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, "/v2/actions", nil, &params); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &ListPendingActionsReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) RetrievePendingAction(ctx context.Context, id string) (out *PendingAction, err error) {
+	// The ID is required to determine the endpoint
+	if id == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/actions/%s", id)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	//  Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &PendingAction{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) ApprovePendingAction(ctx context.Context, id string) (out *PendingAction, err error) {
+	return s.resolvePendingAction(ctx, id, "approve")
+}
+
+func (s *APIv2) CancelPendingAction(ctx context.Context, id string) (out *PendingAction, err error) {
+	return s.resolvePendingAction(ctx, id, "cancel")
+}
+
+func (s *APIv2) resolvePendingAction(ctx context.Context, id, resolution string) (out *PendingAction, err error) {
+	// The ID is required to determine the endpoint
+	if id == "" {
+		return nil, ErrIDRequred
+	}
+
+	// Determine the path from the request
+	path := fmt.Sprintf("/v2/actions/%s/%s", id, resolution)
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	//  Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &PendingAction{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (s *APIv2) ListAuditRecords(ctx context.Context, in *ListAuditRecordsParams) (out *ListAuditRecordsReply, err error) {
+	// Create the query params from the input
+	var params url.Values
+	if params, err = query.Values(in); err != nil {
+		return nil, fmt.Errorf("could not encode query params: %s", err)
+	}
+
+	// Must be authenticated
+	if err = s.checkAuthentication(ctx); err != nil {
+		return nil, err
+	}
+
+	//  Make the HTTP request
+	var req *http.Request
+	if req, err = s.NewRequest(ctx, http.MethodGet, "/v2/audit", nil, &params); err != nil {
+		return nil, err
+	}
+
+	// Execute the request and get a response
+	out = &ListAuditRecordsReply{}
+	if _, err = s.Do(req, out, true); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 //===========================================================================
 // Helper Methods
 //===========================================================================
@@ -592,6 +592,140 @@ func TestDeleteVASP(t *testing.T) {
 	require.Equal(t, fixture, out)
 }
 
+func TestRestoreVASP(t *testing.T) {
+	id := "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5"
+	fixture := &admin.Reply{
+		Success: true,
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/restore", r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a VASP ID is required to restore a VASP
+	_, err = client.RestoreVASP(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	// Correctly formatted request
+	out, err := client.RestoreVASP(context.TODO(), id)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, fixture, out)
+}
+
+func TestListRevisions(t *testing.T) {
+	fixture := &admin.ListRevisionsReply{
+		Revisions: []admin.Revision{
+			{Revision: 2, Created: "2022-08-15T12:32:41Z", Version: 2, VerificationStatus: "PENDING_REVIEW"},
+			{Revision: 1, Created: "2022-08-14T09:12:03Z", Version: 1, VerificationStatus: "SUBMITTED"},
+		},
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/history", r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a VASP ID is required to list revisions
+	_, err = client.ListRevisions(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.ListRevisions(context.TODO(), "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5")
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestRevisionDiff(t *testing.T) {
+	fixture := &admin.RevisionDiffReply{
+		Revision: 3,
+		Against:  1,
+		Changes: []admin.FieldChange{
+			{Path: "website", Before: `"https://example.com"`, After: `"https://example.org"`},
+		},
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/history/3/diff", r.URL.Path)
+		require.Equal(t, "against=1", r.URL.RawQuery)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a VASP ID and revision are required to diff a revision
+	_, err = client.RevisionDiff(context.TODO(), "", 3, nil)
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+	_, err = client.RevisionDiff(context.TODO(), "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5", 0, nil)
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.RevisionDiff(context.TODO(), "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5", 3, &admin.RevisionDiffParams{Against: 1})
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestRollbackVASP(t *testing.T) {
+	fixture := &admin.UpdateVASPReply{
+		Name: "Alice VASP",
+		VASP: map[string]interface{}{
+			"id":          "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+			"common_name": "trisa.alice.us",
+			"website":     "https://example.com",
+		},
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/history/2/rollback", r.URL.Path)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure a VASP ID and revision are required to roll back a VASP
+	_, err = client.RollbackVASP(context.TODO(), "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5", 0)
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.RollbackVASP(context.TODO(), "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5", 2)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
 func TestListCertificates(t *testing.T) {
 	id := "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5"
 	fixture := &admin.ListCertificatesReply{
@@ -643,6 +777,117 @@ func TestListCertificates(t *testing.T) {
 	require.Equal(t, fixture, out)
 }
 
+func TestRevokeCertificate(t *testing.T) {
+	fixture := &admin.RevokeCertificateReply{
+		Certificate: admin.Certificate{
+			SerialNumber: "DEF83132333435363738",
+			IssuedAt:     time.Now().Format(time.RFC3339),
+			ExpiresAt:    time.Now().AddDate(1, 0, 0).Format(time.RFC3339),
+			Status:       models.CertificateState_REVOKED.String(),
+			Details: map[string]interface{}{
+				"common_name": "trisa.alice.us",
+			},
+		},
+		Sent:    2,
+		Message: "certificate has been revoked",
+	}
+
+	req := &admin.RevokeCertificateRequest{
+		VASP:        "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+		Certificate: "DEF83132333435363738",
+		Reason:      "key compromise",
+		Description: "the private key was published",
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Double cookie protect GET request w/o middleware
+		// The client must a call to GET /v2/authenticate before authentication
+		if r.Method == http.MethodGet && r.URL.Path == "/v2/authenticate" {
+			w.Header().Add("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/certificates/DEF83132333435363738/revoke", r.URL.Path)
+
+		// Must be able to deserialize the request
+		in := new(admin.RevokeCertificateRequest)
+		err := json.NewDecoder(r.Body).Decode(in)
+		require.NoError(t, err)
+		require.Equal(t, req, in)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure the VASP and certificate IDs are required
+	_, err = client.RevokeCertificate(context.TODO(), &admin.RevokeCertificateRequest{VASP: req.VASP})
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	// Correctly formatted request
+	out, err := client.RevokeCertificate(context.TODO(), req)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
+func TestUploadCSR(t *testing.T) {
+	fixture := &admin.UploadCSRReply{
+		CertificateRequest: "a9ab8d2f-6d1c-4ccb-aad4-1a70a4c8d5b9",
+		Message:            "csr attached to certificate request",
+	}
+
+	req := &admin.UploadCSRRequest{
+		VASP: "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+		CSR:  "-----BEGIN CERTIFICATE REQUEST-----",
+	}
+
+	// Create a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Double cookie protect GET request w/o middleware
+		// The client must a call to GET /v2/authenticate before authentication
+		if r.Method == http.MethodGet && r.URL.Path == "/v2/authenticate" {
+			w.Header().Add("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/v2/vasps/83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5/csr", r.URL.Path)
+
+		// Must be able to deserialize the request
+		in := new(admin.UploadCSRRequest)
+		err := json.NewDecoder(r.Body).Decode(in)
+		require.NoError(t, err)
+		require.Equal(t, req, in)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// Ensure the VASP ID is required
+	_, err = client.UploadCSR(context.TODO(), &admin.UploadCSRRequest{CSR: req.CSR})
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	// Correctly formatted request
+	out, err := client.UploadCSR(context.TODO(), req)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
+
 func TestCreateReviewNote(t *testing.T) {
 	req := &admin.ModifyReviewNoteRequest{
 		VASP: "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
@@ -950,3 +1195,166 @@ func TestResend(t *testing.T) {
 	require.Equal(t, fixture.Sent, out.Sent)
 	require.Equal(t, fixture.Message, out.Message)
 }
+
+func TestListPendingActions(t *testing.T) {
+	fixture := &admin.ListPendingActionsReply{
+		Actions: []admin.PendingAction{
+			{
+				ID:         "3c3d9f6f-1c61-4a5b-8c4d-92c3e5e8b0a1",
+				Type:       "DELETE_VASP",
+				VASP:       "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+				Status:     "ACTION_PROPOSED",
+				ProposedBy: "alice@example.com",
+				Created:    "2022-08-15T12:32:41Z",
+				Modified:   "2022-08-15T12:32:41Z",
+				Expires:    "2022-08-18T12:32:41Z",
+			},
+		},
+	}
+
+	params := &admin.ListPendingActionsParams{
+		Status: "ACTION_PROPOSED",
+		VASP:   "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+	}
+
+	// Create a Test Server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+
+		switch r.URL.Path {
+		case "/v2/actions":
+			require.Equal(t, "status=ACTION_PROPOSED&vasp=83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5", r.URL.RawQuery)
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(fixture)
+		case "/v2/actions/3c3d9f6f-1c61-4a5b-8c4d-92c3e5e8b0a1":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(fixture.Actions[0])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(admin.Reply{Error: "resource not found"})
+		}
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	out, err := client.ListPendingActions(context.TODO(), params)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+
+	// An action ID is required to retrieve an action
+	_, err = client.RetrievePendingAction(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	action, err := client.RetrievePendingAction(context.TODO(), fixture.Actions[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, &fixture.Actions[0], action)
+}
+
+func TestResolvePendingAction(t *testing.T) {
+	fixture := &admin.PendingAction{
+		ID:         "3c3d9f6f-1c61-4a5b-8c4d-92c3e5e8b0a1",
+		Type:       "DELETE_VASP",
+		VASP:       "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+		ProposedBy: "alice@example.com",
+		ResolvedBy: "bob@example.com",
+	}
+
+	// Create a Test Server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Double cookie protect GET request w/o middleware
+		// The client must a call to GET /v2/authenticate before authentication
+		if r.Method == http.MethodGet && r.URL.Path == "/v2/authenticate" {
+			w.Header().Add("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		require.Equal(t, http.MethodPost, r.Method)
+		out := *fixture
+		switch r.URL.Path {
+		case "/v2/actions/3c3d9f6f-1c61-4a5b-8c4d-92c3e5e8b0a1/approve":
+			out.Status = "ACTION_EXECUTED"
+		case "/v2/actions/3c3d9f6f-1c61-4a5b-8c4d-92c3e5e8b0a1/cancel":
+			out.Status = "ACTION_CANCELED"
+		default:
+			require.Fail(t, "unexpected request path", r.URL.Path)
+		}
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(out)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	// An action ID is required to resolve an action
+	_, err = client.ApprovePendingAction(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+	_, err = client.CancelPendingAction(context.TODO(), "")
+	require.EqualError(t, err, "request requires a valid ID to determine endpoint")
+
+	out, err := client.ApprovePendingAction(context.TODO(), fixture.ID)
+	require.NoError(t, err)
+	require.Equal(t, "ACTION_EXECUTED", out.Status)
+	require.Equal(t, fixture.ResolvedBy, out.ResolvedBy)
+
+	out, err = client.CancelPendingAction(context.TODO(), fixture.ID)
+	require.NoError(t, err)
+	require.Equal(t, "ACTION_CANCELED", out.Status)
+}
+
+func TestListAuditRecords(t *testing.T) {
+	fixture := &admin.ListAuditRecordsReply{
+		Records: []admin.AuditRecord{
+			{
+				ID:        "0b5a5c23-ff7f-4a3b-9f1e-1d2b65c0a6a3",
+				Timestamp: "2022-08-15T12:32:41Z",
+				Actor:     "alice@example.com",
+				Action:    "PATCH /v2/vasps/:vaspID",
+				Target:    "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5",
+				Params:    map[string]string{"vaspID": "83dc8b6a-c3a8-4cb2-bc9d-b0d3fbd090c5"},
+				RequestID: "5d0ddf86-3a2c-4a48-a0c7-f0e54d8e7a16",
+				Status:    http.StatusOK,
+				Changes: []admin.FieldChange{
+					{Path: "vasp.website", Before: `"https://example.com"`, After: `"https://example.org"`},
+				},
+			},
+		},
+		Count:    1,
+		Page:     1,
+		PageSize: 10,
+	}
+
+	params := &admin.ListAuditRecordsParams{
+		Actor:    "alice@example.com",
+		Start:    "2022-08-01",
+		PageSize: 10,
+	}
+
+	// Create a Test Server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v2/audit", r.URL.Path)
+		require.Equal(t, "actor=alice%40example.com&page_size=10&start=2022-08-01", r.URL.RawQuery)
+
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(fixture)
+	}))
+	defer ts.Close()
+
+	// Create a Client that makes requests to the test server
+	client, err := admin.New(ts.URL, nil)
+	require.NoError(t, err)
+
+	out, err := client.ListAuditRecords(context.TODO(), params)
+	require.NoError(t, err)
+	require.Equal(t, fixture, out)
+}
@@ -17,7 +17,7 @@ func (a *ResendAction) UnmarshalJSON(b []byte) (err error) {
 	action := ResendAction(s)
 
 	switch action {
-	case ResendVerifyContact, ResendReview, ResendDeliverCerts, ResendRejection:
+	case ResendVerifyContact, ResendReview, ResendDeliverCerts, ResendRejection, CertificateRevoked:
 		*a = action
 		return nil
 	default:
@@ -10,7 +10,7 @@ import (
 
 func TestResendActionSerialization(t *testing.T) {
 	// Test valid enums
-	cases := []ResendAction{ResendVerifyContact, ResendReview, ResendDeliverCerts, ResendRejection}
+	cases := []ResendAction{ResendVerifyContact, ResendReview, ResendDeliverCerts, ResendRejection, CertificateRevoked}
 	for _, tc := range cases {
 		data, err := json.Marshal(tc)
 		require.NoError(t, err)
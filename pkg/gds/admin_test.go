@@ -2,13 +2,20 @@ package gds_test
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,9 +26,11 @@ import (
 	admin "github.com/trisacrypto/directory/pkg/gds/admin/v2"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	"github.com/trisacrypto/directory/pkg/gds/tokens"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
 )
 
 // httpRequest is a helper struct to make it easier to organize all the different
@@ -117,6 +126,25 @@ func (s *gdsTestSuite) APIError(expectedCode int, expectedMessage string, rep *h
 	require.Equal(expectedMessage, data.Error, "error message mismatch")
 }
 
+// approveAction approves a pending action as a different admin than the one who
+// proposed it and returns the response from the approval handler.
+func (s *gdsTestSuite) approveAction(action *admin.PendingAction, reply interface{}) *http.Response {
+	s.Require().NotNil(action, "no pending action was proposed")
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/actions/" + action.ID + "/approve",
+		params: map[string]string{
+			"actionID": action.ID,
+		},
+		claims: &tokens.Claims{
+			Email:       "approver@example.com",
+			Permissions: []string{tokens.ReadPermission, tokens.ReviewPermission, tokens.UpdatePermission, tokens.DeletePermission},
+		},
+	}
+	c, w := s.makeRequest(request)
+	return s.doRequest(s.svc.GetAdmin().ApprovePendingAction, c, w, reply)
+}
+
 // Test that the middleware returns the corect error when making unauthenticated
 // requests to protected endpoints.
 func (s *gdsTestSuite) TestMiddleware() {
@@ -136,11 +164,17 @@ func (s *gdsTestSuite) TestMiddleware() {
 		{"reviews", http.MethodGet, "/v2/reviews", true, false},
 		{"listVASPs", http.MethodGet, "/v2/vasps", true, false},
 		{"retrieveVASP", http.MethodGet, "/v2/vasps/42", true, false},
+		{"listRevisions", http.MethodGet, "/v2/vasps/42/history", true, false},
+		{"revisionDiff", http.MethodGet, "/v2/vasps/42/history/1/diff", true, false},
 		{"listReviewNotes", http.MethodGet, "/v2/vasps/42/notes", true, false},
 		{"listCertificates", http.MethodGet, "/v2/vasps/42/certificates", true, false},
+		{"listPendingActions", http.MethodGet, "/v2/actions", true, false},
+		{"retrievePendingAction", http.MethodGet, "/v2/actions/42", true, false},
 		// Authenticated and CSRF protected endpoints
 		{"updateVASP", http.MethodPatch, "/v2/vasps/42", true, true},
 		{"deleteVASP", http.MethodDelete, "/v2/vasps/42", true, true},
+		{"restoreVASP", http.MethodPost, "/v2/vasps/42/restore", true, true},
+		{"rollbackVASP", http.MethodPost, "/v2/vasps/42/history/1/rollback", true, true},
 		{"replaceContact", http.MethodPut, "/v2/vasps/42/contacts/kind", true, true},
 		{"deleteContact", http.MethodDelete, "/v2/vasps/42/contacts/kind", true, true},
 		{"review", http.MethodPost, "/v2/vasps/42/review", true, true},
@@ -148,6 +182,8 @@ func (s *gdsTestSuite) TestMiddleware() {
 		{"createReviewNote", http.MethodPost, "/v2/vasps/42/notes", true, true},
 		{"updateReviewNote", http.MethodPut, "/v2/vasps/42/notes/1", true, true},
 		{"deleteReviewNote", http.MethodDelete, "/v2/vasps/42/notes/1", true, true},
+		{"approvePendingAction", http.MethodPost, "/v2/actions/42/approve", true, true},
+		{"cancelPendingAction", http.MethodPost, "/v2/actions/42/cancel", true, true},
 	}
 	serv := httptest.NewServer(s.svc.GetAdmin().GetRouter())
 	defer serv.Close()
@@ -181,11 +217,12 @@ func (s *gdsTestSuite) TestMiddleware() {
 				r, err = http.NewRequest(endpoint.method, serv.URL+endpoint.path, nil)
 				require.NoError(t, err)
 				creds := map[string]interface{}{
-					"sub":     "102374163855881761273",
-					"hd":      "example.com",
-					"email":   "jon@example.com",
-					"name":    "Jon Doe",
-					"picture": "https://foo.googleusercontent.com/test!/Aoh14gJceTrUA",
+					"sub":         "102374163855881761273",
+					"hd":          "example.com",
+					"email":       "jon@example.com",
+					"name":        "Jon Doe",
+					"picture":     "https://foo.googleusercontent.com/test!/Aoh14gJceTrUA",
+					"permissions": []string{tokens.ReadPermission, tokens.ReviewPermission, tokens.UpdatePermission, tokens.DeletePermission},
 				}
 				access := s.createAccessString(creds)
 				r.Header.Add("Authorization", "Bearer "+access)
@@ -215,6 +252,51 @@ func (s *gdsTestSuite) TestMiddleware() {
 	}
 }
 
+// Test that users cannot access endpoints that require permissions their role does not
+// grant them.
+func (s *gdsTestSuite) TestRoutePermissions() {
+	endpoints := []struct {
+		name   string
+		method string
+		path   string
+		role   string
+	}{
+		{"review", http.MethodPost, "/v2/vasps/42/review", tokens.ReadOnlyRole},
+		{"createReviewNote", http.MethodPost, "/v2/vasps/42/notes", tokens.ReadOnlyRole},
+		{"updateVASP", http.MethodPatch, "/v2/vasps/42", tokens.ReviewerRole},
+		{"replaceContact", http.MethodPut, "/v2/vasps/42/contacts/kind", tokens.ReviewerRole},
+		{"deleteVASP", http.MethodDelete, "/v2/vasps/42", tokens.ReviewerRole},
+		{"restoreVASP", http.MethodPost, "/v2/vasps/42/restore", tokens.ReviewerRole},
+		{"rollbackVASP", http.MethodPost, "/v2/vasps/42/history/1/rollback", tokens.ReviewerRole},
+		{"revokeCertificate", http.MethodPost, "/v2/vasps/42/certificates/1/revoke", tokens.ReviewerRole},
+	}
+
+	serv := httptest.NewServer(s.svc.GetAdmin().GetRouter())
+	defer serv.Close()
+	s.svc.GetAdmin().SetHealth(true)
+
+	for _, endpoint := range endpoints {
+		s.T().Run(endpoint.name, func(t *testing.T) {
+			permissions, err := tokens.RolePermissions(endpoint.role)
+			require.NoError(t, err)
+
+			creds := map[string]interface{}{
+				"hd":          "gds.dev",
+				"email":       "jon@gds.dev",
+				"permissions": permissions,
+			}
+
+			r, err := http.NewRequest(endpoint.method, serv.URL+endpoint.path, nil)
+			require.NoError(t, err)
+			r.Header.Add("Authorization", "Bearer "+s.createAccessString(creds))
+
+			res, err := http.DefaultClient.Do(r)
+			require.NoError(t, err)
+			s.APIError(http.StatusForbidden, "user does not have permission to perform this operation", res)
+		})
+	}
+}
+
 // Test that we get a good response from ProtectAuthenticate.
 func (s *gdsTestSuite) TestProtectAuthenticate() {
 	a := s.svc.GetAdmin()
@@ -285,8 +367,9 @@ func (s *gdsTestSuite) TestAuthenticate() {
 	request.in = &admin.AuthRequest{
 		Credential: access,
 	}
+	reply := &admin.AuthReply{}
 	c, w = s.makeRequest(request)
-	res = s.doRequest(a.Authenticate, c, w, nil)
+	res = s.doRequest(a.Authenticate, c, w, reply)
 	require.Equal(http.StatusOK, res.StatusCode)
 	// Double cookie tokens should be set
 	cookies := res.Cookies()
@@ -294,6 +377,26 @@ func (s *gdsTestSuite) TestAuthenticate() {
 	for _, cookie := range cookies {
 		require.Equal(s.svc.GetConf().Admin.CookieDomain, cookie.Domain)
 	}
+
+	// Users without a configured role should be granted the default role permissions
+	claims, err := a.GetTokenManager().Verify(reply.AccessToken)
+	require.NoError(err)
+	require.Equal([]string{tokens.ReadPermission}, claims.Permissions)
+
+	// Users with a configured role should be granted the permissions of their role
+	creds["email"] = "Admin@gds.dev"
+	request.in = &admin.AuthRequest{
+		Credential: s.createAccessString(creds),
+	}
+	c, w = s.makeRequest(request)
+	res = s.doRequest(a.Authenticate, c, w, reply)
+	require.Equal(http.StatusOK, res.StatusCode)
+
+	claims, err = a.GetTokenManager().Verify(reply.AccessToken)
+	require.NoError(err)
+	permissions, err := tokens.RolePermissions(tokens.SuperuserRole)
+	require.NoError(err)
+	require.Equal(permissions, claims.Permissions)
 }
 
 // Test the Reauthenticate endpoint.
@@ -311,6 +414,8 @@ func (s *gdsTestSuite) TestReauthenticate() {
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now()),
 		},
+		Email:       "admin@gds.dev",
+		Permissions: []string{tokens.ReadPermission},
 	}
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	refreshToken, err := tm.CreateRefreshToken(accessToken)
@@ -379,8 +484,9 @@ func (s *gdsTestSuite) TestReauthenticate() {
 	request.headers = map[string]string{
 		"Authorization": "Bearer " + access,
 	}
+	reply := &admin.AuthReply{}
 	c, w = s.makeRequest(request)
-	res = s.doRequest(a.Reauthenticate, c, w, nil)
+	res = s.doRequest(a.Reauthenticate, c, w, reply)
 	require.Equal(http.StatusOK, res.StatusCode)
 	// Double cookie tokens should be set
 	cookies := res.Cookies()
@@ -388,6 +494,13 @@ func (s *gdsTestSuite) TestReauthenticate() {
 	for _, cookie := range cookies {
 		require.Equal(s.svc.GetConf().Admin.CookieDomain, cookie.Domain)
 	}
+
+	// The permissions should be recomputed from the current role of the user
+	reissued, err := tm.Verify(reply.AccessToken)
+	require.NoError(err)
+	permissions, err := tokens.RolePermissions(tokens.SuperuserRole)
+	require.NoError(err)
+	require.Equal(permissions, reissued.Permissions)
 }
 
 // Test that the Summary endpoint returns the correct response.
@@ -797,22 +910,36 @@ func (s *gdsTestSuite) TestDeleteVASP() {
 		s.APIError(http.StatusBadRequest, msg, rep)
 	}
 
-	// Successfully deleting a VASP and its certificate requests
+	// Successfully deleting a VASP once approved
 	id := golf.Id
 	for status := pb.VerificationState_NO_VERIFICATION; status < pb.VerificationState_REVIEWED; status++ {
 		s.SetVerificationStatus(id, status)
 		request.path = "/v2/vasps/" + id
 		request.params["vaspID"] = id
 		c, w = s.makeRequest(request)
-		rep = s.doRequest(a.DeleteVASP, c, w, nil)
+		reply := &admin.Reply{}
+		rep = s.doRequest(a.DeleteVASP, c, w, reply)
+		require.Equal(http.StatusAccepted, rep.StatusCode)
+		require.True(reply.Success)
+		require.Equal(models.PendingActionType_DELETE_VASP.String(), reply.PendingAction.Type)
+		require.Equal(id, reply.PendingAction.VASP)
+
+		// The VASP is not deleted until the action is approved
+		_, err := s.svc.GetStore().RetrieveVASP(id)
+		require.NoError(err)
+
+		rep = s.approveAction(reply.PendingAction, nil)
 		require.Equal(http.StatusOK, rep.StatusCode)
-		_, err := s.svc.GetStore().RetrieveVASP(golf.Id)
-		require.Error(err)
 
-		// Recreate the VASP
-		golf.Id = ""
-		id, err = s.svc.GetStore().CreateVASP(golf)
+		// The VASP is archived in its current state rather than removed from the store
+		vasp, err := s.svc.GetStore().RetrieveVASP(id)
 		require.NoError(err)
+		require.True(models.IsArchived(vasp))
+		require.Equal(status, vasp.VerificationStatus)
+
+		// Restore the VASP so that it can be deleted again
+		require.NoError(models.RestoreVASP(vasp, "admin@example.com"))
+		require.NoError(s.svc.GetStore().UpdateVASP(vasp))
 	}
 
 	// Make sure we can also delete a VASP in the ERRORED state
@@ -820,12 +947,636 @@ func (s *gdsTestSuite) TestDeleteVASP() {
 	request.path = "/v2/vasps/" + julietID
 	request.params["vaspID"] = julietID
 	c, w = s.makeRequest(request)
+	reply := &admin.Reply{}
+	rep = s.doRequest(a.DeleteVASP, c, w, reply)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+
+	// Cannot propose the same deletion twice
+	c, w = s.makeRequest(request)
 	rep = s.doRequest(a.DeleteVASP, c, w, nil)
+	s.APIError(http.StatusConflict, "an identical action has already been proposed and is awaiting approval", rep)
+
+	rep = s.approveAction(reply.PendingAction, nil)
 	require.Equal(http.StatusOK, rep.StatusCode)
-	_, err := s.svc.GetStore().RetrieveVASP(julietID)
-	require.Error(err)
+	juliet, err := s.svc.GetStore().RetrieveVASP(julietID)
+	require.NoError(err)
+	require.True(models.IsArchived(juliet))
+
+	// The certificate requests are retained until the VASP is purged
 	_, err = s.svc.GetStore().RetrieveCertReq(xrayID)
-	require.Error(err)
+	require.NoError(err)
+
+	// Cannot delete a VASP that has already been deleted
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.DeleteVASP, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP has already been deleted", rep)
+}
+
+// Test that the RestoreVASP endpoint returns a deleted VASP to the directory.
+func (s *gdsTestSuite) TestRestoreVASP() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	charlieID := s.fixtures[vasps]["charliebank"].(*pb.VASP).Id
+
+	// Attempt to restore a VASP that doesn't exist
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/invalid/restore",
+		params: map[string]string{
+			"vaspID": "invalid",
+		},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RestoreVASP, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP record by ID", rep)
+
+	// Cannot restore a VASP that has not been deleted
+	request.path = "/v2/vasps/" + charlieID + "/restore"
+	request.params["vaspID"] = charlieID
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RestoreVASP, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP has not been deleted", rep)
+
+	// Archive the VASP
+	charlie, err := db.RetrieveVASP(charlieID)
+	require.NoError(err)
+	require.NoError(models.ArchiveVASP(charlie, "deleter@example.com"))
+	require.NoError(db.UpdateVASP(charlie))
+
+	// Archived VASPs are only listed when requested
+	page, err := db.QueryVASPs(&search.Query{})
+	require.NoError(err)
+	for _, result := range page.Results {
+		require.NotEqual(charlieID, result.VASP.Id)
+	}
+
+	page, err = db.QueryVASPs(&search.Query{Archived: true})
+	require.NoError(err)
+	found := false
+	for _, result := range page.Results {
+		require.True(models.IsArchived(result.VASP))
+		if result.VASP.Id == charlieID {
+			found = true
+		}
+	}
+	require.True(found, "archived VASP should be listed")
+
+	// Admins can still retrieve archived VASPs but cannot modify them
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/vasps/" + charlieID, params: map[string]string{"vaspID": charlieID}})
+	detail := &admin.RetrieveVASPReply{}
+	rep = s.doRequest(a.RetrieveVASP, c, w, detail)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.NotEmpty(detail.Archived)
+
+	c, w = s.makeRequest(&httpRequest{
+		method: http.MethodPatch,
+		path:   "/v2/vasps/" + charlieID,
+		params: map[string]string{"vaspID": charlieID},
+		in:     &admin.UpdateVASPRequest{VASP: charlieID, Website: "https://charliebank.example.com"},
+		claims: request.claims,
+	})
+	rep = s.doRequest(a.UpdateVASP, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP has been deleted and must be restored first", rep)
+
+	// The names of archived VASPs are released so that the VASP can register again
+	duplicate := proto.Clone(charlie).(*pb.VASP)
+	duplicate.Id, duplicate.Extra, duplicate.Version = "", nil, nil
+	duplicateID, err := db.CreateVASP(duplicate)
+	require.NoError(err, "should be able to register a VASP with the name of an archived VASP")
+
+	// Cannot restore the VASP while its name is used by another VASP
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RestoreVASP, c, w, nil)
+	s.APIError(http.StatusConflict, "the name of the VASP is in use by another VASP", rep)
+	require.NoError(db.DeleteVASP(duplicateID))
+
+	// Successfully restore the VASP
+	c, w = s.makeRequest(request)
+	reply := &admin.Reply{}
+	rep = s.doRequest(a.RestoreVASP, c, w, reply)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.True(reply.Success)
+
+	charlie, err = db.RetrieveVASP(charlieID)
+	require.NoError(err)
+	require.False(models.IsArchived(charlie))
+
+	auditLog, err := models.GetAuditLog(charlie)
+	require.NoError(err)
+	require.Equal(models.ActionRestored, auditLog[len(auditLog)-1].Action)
+	require.Equal("admin@example.com", auditLog[len(auditLog)-1].Source)
+}
+
+// Test listing, comparing, and rolling back the revisions of a VASP record.
+func (s *gdsTestSuite) TestVASPHistory() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	charlieID := s.fixtures[vasps]["charliebank"].(*pb.VASP).Id
+	historyPath := "/v2/vasps/" + charlieID + "/history"
+	claims := &tokens.Claims{Email: "admin@example.com"}
+
+	// Cannot list the history of a VASP that doesn't exist
+	c, w := s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/vasps/invalid/history", params: map[string]string{"vaspID": "invalid"}})
+	rep := s.doRequest(a.ListRevisions, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP record by ID", rep)
+
+	// Each update of the VASP stores a new revision
+	revisions, err := db.ListRevisions(charlieID).All()
+	require.NoError(err)
+	first := uint64(len(revisions)) + 1
+	second := first + 1
+
+	charlie, err := db.RetrieveVASP(charlieID)
+	require.NoError(err)
+	original := proto.Clone(charlie).(*pb.VASP)
+
+	charlie.Website = "https://charliebank.example.com"
+	require.NoError(db.UpdateVASP(charlie))
+	charlie.BusinessCategory = pb.BusinessCategoryNonCommercial
+	charlie.VaspCategories = []string{"Exchange"}
+	require.NoError(db.UpdateVASP(charlie))
+
+	history := &admin.ListRevisionsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: historyPath, params: map[string]string{"vaspID": charlieID}})
+	rep = s.doRequest(a.ListRevisions, c, w, history)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(history.Revisions, int(second))
+	require.Equal(second, history.Revisions[0].Revision)
+	require.Equal(charlie.Version.Version, history.Revisions[0].Version)
+	require.Equal(charlie.VerificationStatus.String(), history.Revisions[0].VerificationStatus)
+	require.Equal(first, history.Revisions[1].Revision)
+
+	// Revisions are compared to the previous revision by default
+	diffRequest := func(rev string, query string) *httpRequest {
+		return &httpRequest{
+			method: http.MethodGet,
+			path:   historyPath + "/" + rev + "/diff" + query,
+			params: map[string]string{"vaspID": charlieID, "rev": rev},
+		}
+	}
+
+	diff := &admin.RevisionDiffReply{}
+	c, w = s.makeRequest(diffRequest(fmt.Sprint(second), ""))
+	rep = s.doRequest(a.RevisionDiff, c, w, diff)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(second, diff.Revision)
+	require.Equal(first, diff.Against)
+
+	paths := make(map[string]admin.FieldChange)
+	for _, change := range diff.Changes {
+		require.False(strings.HasPrefix(change.Path, "extra"), "extra data should not be compared")
+		paths[change.Path] = change
+	}
+	require.Contains(paths, "businessCategory")
+	require.Contains(paths, "vaspCategories")
+	require.Contains(paths, "version.version")
+	require.NotContains(paths, "website")
+
+	// Compare against a specific revision
+	c, w = s.makeRequest(diffRequest(fmt.Sprint(second), fmt.Sprintf("?against=%d", second)))
+	rep = s.doRequest(a.RevisionDiff, c, w, diff)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Empty(diff.Changes)
+
+	// The first revision is compared to an empty record
+	c, w = s.makeRequest(diffRequest("1", ""))
+	rep = s.doRequest(a.RevisionDiff, c, w, diff)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(uint64(0), diff.Against)
+	require.Len(diff.Changes, 1)
+	require.Equal("", diff.Changes[0].Path)
+	require.Empty(diff.Changes[0].Before)
+
+	// Invalid and missing revisions
+	c, w = s.makeRequest(diffRequest("foo", ""))
+	rep = s.doRequest(a.RevisionDiff, c, w, nil)
+	s.APIError(http.StatusBadRequest, "invalid revision number", rep)
+
+	c, w = s.makeRequest(diffRequest(fmt.Sprint(second+1), ""))
+	rep = s.doRequest(a.RevisionDiff, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP revision", rep)
+
+	c, w = s.makeRequest(diffRequest(fmt.Sprint(second), fmt.Sprintf("?against=%d", second+1)))
+	rep = s.doRequest(a.RevisionDiff, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP revision to compare against", rep)
+
+	// Roll back the VASP to the first revision made by the test
+	rollbackRequest := func(rev uint64) *httpRequest {
+		return &httpRequest{
+			method: http.MethodPost,
+			path:   fmt.Sprintf("%s/%d/rollback", historyPath, rev),
+			params: map[string]string{"vaspID": charlieID, "rev": fmt.Sprint(rev)},
+			claims: claims,
+		}
+	}
+
+	c, w = s.makeRequest(rollbackRequest(second + 1))
+	rep = s.doRequest(a.RollbackVASP, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP revision", rep)
+
+	c, w = s.makeRequest(rollbackRequest(first))
+	detail := &admin.UpdateVASPReply{}
+	rep = s.doRequest(a.RollbackVASP, c, w, detail)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal("https://charliebank.example.com", detail.VASP["website"])
+
+	charlie, err = db.RetrieveVASP(charlieID)
+	require.NoError(err)
+	require.Equal("https://charliebank.example.com", charlie.Website)
+	require.Equal(original.BusinessCategory, charlie.BusinessCategory)
+	require.Equal(original.VaspCategories, charlie.VaspCategories)
+	require.Equal(original.VerificationStatus, charlie.VerificationStatus)
+
+	auditLog, err := models.GetAuditLog(charlie)
+	require.NoError(err)
+	require.Equal(fmt.Sprintf("VASP record rolled back to revision %d by admin", first), auditLog[len(auditLog)-1].Description)
+	require.Equal(claims.Email, auditLog[len(auditLog)-1].Source)
+
+	// The rollback is stored as a new revision
+	revisions, err = db.ListRevisions(charlieID).All()
+	require.NoError(err)
+	require.Len(revisions, int(second)+1)
+	require.True(proto.Equal(charlie, revisions[second].Record))
+
+	// Cannot roll back to a revision the VASP already matches
+	c, w = s.makeRequest(rollbackRequest(first))
+	rep = s.doRequest(a.RollbackVASP, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP record already matches the revision", rep)
+
+	// Cannot roll back a deleted VASP
+	require.NoError(models.ArchiveVASP(charlie, claims.Email))
+	require.NoError(db.UpdateVASP(charlie))
+
+	c, w = s.makeRequest(rollbackRequest(second))
+	rep = s.doRequest(a.RollbackVASP, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP has been deleted and must be restored first", rep)
+}
+
+// Test proposing, listing, approving, canceling, and expiring pending actions.
+func (s *gdsTestSuite) TestPendingActions() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+
+	golfID := s.fixtures[vasps]["golfbucks"].(*pb.VASP).Id
+	s.SetVerificationStatus(golfID, pb.VerificationState_SUBMITTED)
+
+	// Propose the deletion of a VASP
+	request := &httpRequest{
+		method: http.MethodDelete,
+		path:   "/v2/vasps/" + golfID,
+		params: map[string]string{
+			"vaspID": golfID,
+		},
+		claims: &tokens.Claims{
+			Email:       "admin@example.com",
+			Permissions: []string{tokens.ReadPermission, tokens.DeletePermission},
+		},
+	}
+	proposed := &admin.Reply{}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.DeleteVASP, c, w, proposed)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	action := proposed.PendingAction
+	require.NotNil(action)
+	require.NotEmpty(action.ID)
+	require.Equal(models.PendingActionState_ACTION_PROPOSED.String(), action.Status)
+	require.Equal("admin@example.com", action.ProposedBy)
+	require.NotEmpty(action.Expires)
+
+	// The proposal should be counted in the summary
+	summary := &admin.SummaryReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/summary"})
+	rep = s.doRequest(a.Summary, c, w, summary)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(1, summary.PendingActions)
+
+	// List the pending actions with and without filters
+	list := &admin.ListPendingActionsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions"})
+	rep = s.doRequest(a.ListPendingActions, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.Actions, 1)
+	require.Equal(*action, list.Actions[0])
+
+	list = &admin.ListPendingActionsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions?status=action_executed"})
+	rep = s.doRequest(a.ListPendingActions, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.Actions, 0)
+
+	list = &admin.ListPendingActionsReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions?vasp=" + golfID})
+	rep = s.doRequest(a.ListPendingActions, c, w, list)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Len(list.Actions, 1)
+
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions?status=foo"})
+	rep = s.doRequest(a.ListPendingActions, c, w, nil)
+	s.APIError(http.StatusBadRequest, `unknown pending action status "foo"`, rep)
+
+	// Retrieve a pending action
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions/invalid", params: map[string]string{"actionID": "invalid"}})
+	rep = s.doRequest(a.RetrievePendingAction, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve pending action by ID", rep)
+
+	retrieved := &admin.PendingAction{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/actions/" + action.ID, params: map[string]string{"actionID": action.ID}})
+	rep = s.doRequest(a.RetrievePendingAction, c, w, retrieved)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(action, retrieved)
+
+	// Admins cannot approve their own proposals
+	request = &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/actions/" + action.ID + "/approve",
+		params: map[string]string{
+			"actionID": action.ID,
+		},
+		claims: &tokens.Claims{
+			Email:       "Admin@Example.com",
+			Permissions: []string{tokens.ReadPermission, tokens.DeletePermission},
+		},
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ApprovePendingAction, c, w, nil)
+	s.APIError(http.StatusForbidden, "actions must be approved by a different admin than the one who proposed them", rep)
+
+	// The approver must have the permission required to propose the action
+	request.claims = &tokens.Claims{
+		Email:       "reviewer@example.com",
+		Permissions: []string{tokens.ReadPermission, tokens.ReviewPermission},
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.ApprovePendingAction, c, w, nil)
+	s.APIError(http.StatusForbidden, "user does not have permission to perform this operation", rep)
+
+	// Admins without permission cannot cancel the proposals of other admins
+	request.path = "/v2/actions/" + action.ID + "/cancel"
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.CancelPendingAction, c, w, nil)
+	s.APIError(http.StatusForbidden, "user does not have permission to perform this operation", rep)
+
+	// The VASP should not have been deleted
+	_, err := s.svc.GetStore().RetrieveVASP(golfID)
+	require.NoError(err)
+
+	// Admins can withdraw their own proposals
+	request.claims = &tokens.Claims{
+		Email:       "admin@example.com",
+		Permissions: []string{tokens.ReadPermission},
+	}
+	canceled := &admin.PendingAction{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.CancelPendingAction, c, w, canceled)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(models.PendingActionState_ACTION_CANCELED.String(), canceled.Status)
+	require.Equal("admin@example.com", canceled.ResolvedBy)
+	require.NotEmpty(canceled.Resolved)
+
+	// Canceled actions cannot be approved or canceled again
+	rep = s.approveAction(action, nil)
+	s.APIError(http.StatusConflict, "pending action is ACTION_CANCELED and can no longer be approved", rep)
+
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.CancelPendingAction, c, w, nil)
+	s.APIError(http.StatusConflict, "pending action is ACTION_CANCELED and can no longer be canceled", rep)
+
+	_, err = s.svc.GetStore().RetrieveVASP(golfID)
+	require.NoError(err)
+
+	// Once the proposal is resolved the action can be proposed again
+	request = &httpRequest{
+		method: http.MethodDelete,
+		path:   "/v2/vasps/" + golfID,
+		params: map[string]string{
+			"vaspID": golfID,
+		},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	proposed = &admin.Reply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.DeleteVASP, c, w, proposed)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	require.NotEqual(action.ID, proposed.PendingAction.ID)
+
+	// Proposals that are not approved in time expire
+	expired, err := s.svc.GetStore().RetrieveAction(proposed.PendingAction.ID)
+	require.NoError(err)
+	expired.Expires = time.Now().Add(-1 * time.Minute).Format(time.RFC3339)
+	require.NoError(s.svc.GetStore().UpdateAction(expired))
+
+	rep = s.approveAction(proposed.PendingAction, nil)
+	s.APIError(http.StatusConflict, "pending action is ACTION_EXPIRED and can no longer be approved", rep)
+
+	expired, err = s.svc.GetStore().RetrieveAction(proposed.PendingAction.ID)
+	require.NoError(err)
+	require.Equal(models.PendingActionState_ACTION_EXPIRED, expired.Status)
+
+	_, err = s.svc.GetStore().RetrieveVASP(golfID)
+	require.NoError(err)
+
+	// Only the proposed actions are counted in the summary
+	summary = &admin.SummaryReply{}
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/summary"})
+	rep = s.doRequest(a.Summary, c, w, summary)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(0, summary.PendingActions)
+
+	// Actions that cannot be executed when they are approved fail
+	proposed = &admin.Reply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.DeleteVASP, c, w, proposed)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+
+	s.SetVerificationStatus(golfID, pb.VerificationState_VERIFIED)
+	rep = s.approveAction(proposed.PendingAction, nil)
+	s.APIError(http.StatusBadRequest, "cannot delete VASP in its current state", rep)
+
+	failed, err := s.svc.GetStore().RetrieveAction(proposed.PendingAction.ID)
+	require.NoError(err)
+	require.Equal(models.PendingActionState_ACTION_FAILED, failed.Status)
+	require.Equal("approver@example.com", failed.ResolvedBy)
+	require.Equal("cannot delete VASP in its current state", failed.Message)
+
+	_, err = s.svc.GetStore().RetrieveVASP(golfID)
+	require.NoError(err)
+}
+
+// Test that requests that modify the directory are recorded in the audit log by the
+// middleware and that the audit log can be filtered.
+func (s *gdsTestSuite) TestAuditLog() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	golfID := s.fixtures[vasps]["golfbucks"].(*pb.VASP).Id
+	s.SetVerificationStatus(golfID, pb.VerificationState_SUBMITTED)
+
+	serv := httptest.NewServer(a.GetRouter())
+	defer serv.Close()
+	a.SetHealth(true)
+
+	// Make an authenticated request with CSRF protection to the server
+	do := func(method, path, email string, in interface{}, out interface{}) *http.Response {
+		var body io.Reader
+		if in != nil {
+			data, err := json.Marshal(in)
+			require.NoError(err)
+			body = bytes.NewReader(data)
+		}
+
+		r, err := http.NewRequest(method, serv.URL+path, body)
+		require.NoError(err)
+		r.Header.Add("Content-Type", "application/json")
+
+		if email != "" {
+			creds := map[string]interface{}{
+				"hd":          "gds.dev",
+				"email":       email,
+				"permissions": []string{tokens.ReadPermission, tokens.ReviewPermission, tokens.UpdatePermission, tokens.DeletePermission},
+			}
+			r.Header.Add("Authorization", "Bearer "+s.createAccessString(creds))
+			r.Header.Add(admin.CSRFHeader, "csrftoken")
+			r.AddCookie(&http.Cookie{Name: admin.CSRFReferenceCookie, Value: "csrftoken"})
+		}
+
+		rep, err := http.DefaultClient.Do(r)
+		require.NoError(err)
+		if out != nil {
+			defer rep.Body.Close()
+			require.NoError(json.NewDecoder(rep.Body).Decode(out))
+		}
+		return rep
+	}
+
+	// List the audit log with the specified query
+	list := func(query string) *admin.ListAuditRecordsReply {
+		reply := &admin.ListAuditRecordsReply{}
+		c, w := s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/audit?" + query})
+		rep := s.doRequest(a.ListAuditRecords, c, w, reply)
+		require.Equal(http.StatusOK, rep.StatusCode)
+		return reply
+	}
+
+	// Requests that do not modify the directory are not audited
+	rep := do(http.MethodGet, "/v2/vasps/"+golfID, "auditor@gds.dev", nil, nil)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.NotEmpty(rep.Header.Get("X-Request-ID"))
+	require.Equal(0, list("request_id="+rep.Header.Get("X-Request-ID")).Count)
+
+	// Create a review note
+	note := &admin.ReviewNote{}
+	rep = do(http.MethodPost, "/v2/vasps/"+golfID+"/notes", "auditor@gds.dev", &admin.ModifyReviewNoteRequest{Text: "looks good"}, note)
+	require.Equal(http.StatusCreated, rep.StatusCode)
+	requestID := rep.Header.Get("X-Request-ID")
+	require.NotEmpty(requestID)
+
+	records := list("request_id=" + requestID)
+	require.Equal(1, records.Count)
+	record := records.Records[0]
+	require.Equal("auditor@gds.dev", record.Actor)
+	require.Equal("POST /v2/vasps/:vaspID/notes", record.Action)
+	require.Equal(golfID, record.Target)
+	require.Equal(map[string]string{"vaspID": golfID}, record.Params)
+	require.Equal(http.StatusCreated, record.Status)
+	require.NotEmpty(record.Timestamp)
+	require.NotEmpty(record.Changes)
+	for _, change := range record.Changes {
+		require.True(strings.HasPrefix(change.Path, "vasp."), "unexpected change %s", change.Path)
+	}
+
+	// Failed requests are also audited
+	rep = do(http.MethodDelete, "/v2/vasps/"+golfID+"/notes/"+note.ID, "", nil, nil)
+	require.Equal(http.StatusUnauthorized, rep.StatusCode)
+	records = list("request_id=" + rep.Header.Get("X-Request-ID"))
+	require.Equal(1, records.Count)
+	require.Equal("", records.Records[0].Actor)
+	require.Equal(map[string]string{"vaspID": golfID, "noteID": note.ID}, records.Records[0].Params)
+	require.Equal(http.StatusUnauthorized, records.Records[0].Status)
+	require.Empty(records.Records[0].Changes)
+
+	// Propose and approve the deletion of the VASP
+	proposed := &admin.Reply{}
+	rep = do(http.MethodDelete, "/v2/vasps/"+golfID, "auditor@gds.dev", nil, proposed)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+
+	rep = do(http.MethodPost, "/v2/actions/"+proposed.PendingAction.ID+"/approve", "approver@gds.dev", nil, nil)
+	require.Equal(http.StatusOK, rep.StatusCode)
+
+	// The VASP is archived rather than removed from the store
+	golf, err := s.svc.GetStore().RetrieveVASP(golfID)
+	require.NoError(err)
+	require.True(models.IsArchived(golf))
+
+	records = list("request_id=" + rep.Header.Get("X-Request-ID"))
+	require.Equal(1, records.Count)
+	record = records.Records[0]
+	require.Equal("approver@gds.dev", record.Actor)
+	require.Equal("POST /v2/actions/:actionID/approve", record.Action)
+	require.Equal(proposed.PendingAction.ID, record.Target)
+
+	changes := make(map[string]admin.FieldChange)
+	for _, change := range record.Changes {
+		changes[change.Path] = change
+	}
+	require.Equal(`"ACTION_EXECUTED"`, changes["action.status"].After)
+	require.Contains(changes, "vasp.version.version")
+
+	// The extra data of the VASP contains secrets so it is not written to the audit log
+	for path := range changes {
+		require.NotContains(path, "extra", "audit record contains extra data")
+	}
+
+	// Filter the audit log
+	records = list("")
+	require.Equal(record, records.Records[0], "records should be ordered most recent first")
+
+	records = list("target=" + golfID)
+	require.Equal(3, records.Count)
+
+	records = list("actor=AUDITOR@gds.dev")
+	require.Equal(2, records.Count)
+
+	records = list("action=/notes&target=" + golfID)
+	require.Equal(2, records.Count)
+
+	records = list("target=" + golfID + "&page=2&page_size=2")
+	require.Equal(3, records.Count)
+	require.Len(records.Records, 1)
+
+	today := time.Now().Format("2006-01-02")
+	records = list("target=" + golfID + "&start=" + today + "&end=" + today)
+	require.Equal(3, records.Count)
+
+	records = list("target=" + golfID + "&end=2021-01-01")
+	require.Equal(0, records.Count)
+
+	c, w := s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/audit?start=01-01-2021"})
+	rep = s.doRequest(a.ListAuditRecords, c, w, nil)
+	s.APIError(http.StatusBadRequest, "invalid start date: 01-01-2021", rep)
+
+	c, w = s.makeRequest(&httpRequest{method: http.MethodGet, path: "/v2/audit?start=2022-01-01&end=2021-01-01"})
+	rep = s.doRequest(a.ListAuditRecords, c, w, nil)
+	s.APIError(http.StatusBadRequest, "start date must be before end date", rep)
 }
 
 // Test the ListCertificates endpoint
@@ -909,6 +1660,293 @@ func (s *gdsTestSuite) TestListCertificates() {
 	require.ElementsMatch(certificates, actual.Certificates)
 }
 
+// Test the RevokeCertificate endpoint
+func (s *gdsTestSuite) TestRevokeCertificate() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	emails.PurgeMockEmails()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	charlieID := s.fixtures[vasps]["charliebank"].(*pb.VASP).Id
+	uniform := s.fixtures[certs]["uniform"].(*models.Certificate)
+	zulu := s.fixtures[certs]["zulu"].(*models.Certificate)
+
+	// Attempt to revoke a certificate for a VASP that doesn't exist
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/invalid/certificates/" + uniform.Id + "/revoke",
+		params: map[string]string{
+			"vaspID": "invalid",
+			"certID": uniform.Id,
+		},
+		in: &admin.RevokeCertificateRequest{
+			Reason: "keyCompromise",
+		},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP record by ID", rep)
+
+	// IDs in the request must match the URL
+	request.path = "/v2/vasps/" + hotelID + "/certificates/" + uniform.Id + "/revoke"
+	request.params["vaspID"] = hotelID
+	request.in = &admin.RevokeCertificateRequest{
+		VASP:   charlieID,
+		Reason: "keyCompromise",
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, "the request IDs do not match the URL endpoint", rep)
+
+	// Reason must be a valid CRL reason
+	request.in = &admin.RevokeCertificateRequest{
+		Reason: "notareason",
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	require.Equal(http.StatusBadRequest, rep.StatusCode)
+
+	// Certificate must belong to the VASP
+	request.path = "/v2/vasps/" + charlieID + "/certificates/" + uniform.Id + "/revoke"
+	request.params["vaspID"] = charlieID
+	request.in = &admin.RevokeCertificateRequest{
+		Reason: "keyCompromise",
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve certificate record by ID", rep)
+
+	// Certificate that has already been revoked cannot be revoked again
+	request.path = "/v2/vasps/" + hotelID + "/certificates/" + zulu.Id + "/revoke"
+	request.params["vaspID"] = hotelID
+	request.params["certID"] = zulu.Id
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, "cannot revoke certificate in REVOKED state", rep)
+
+	// Successfully revoke the certificate
+	request.path = "/v2/vasps/" + hotelID + "/certificates/" + uniform.Id + "/revoke"
+	request.params["certID"] = uniform.Id
+	request.in = &admin.RevokeCertificateRequest{
+		VASP:        hotelID,
+		Certificate: uniform.Id,
+		Reason:      "keyCompromise",
+		Description: "private key was leaked",
+	}
+	actual := &admin.RevokeCertificateReply{}
+	c, w = s.makeRequest(request)
+	sent := time.Now()
+	rep = s.doRequest(a.RevokeCertificate, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(uniform.Id, actual.Certificate.SerialNumber)
+	require.Equal("REVOKED", actual.Certificate.Status)
+	require.Equal(actual.Certificate.Details["revoked"], true)
+	require.Contains(actual.Message, "has been revoked")
+
+	// Certificate record should be revoked
+	cert, err := db.RetrieveCert(uniform.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateState_REVOKED, cert.Status)
+	require.True(cert.Details.Revoked)
+
+	// Revocation should be recorded in the audit log
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	ok, err := models.HasAuditLogAction(hotel, models.ActionCertificateRevoked, uniform.Id)
+	require.NoError(err)
+	require.True(ok)
+
+	log, err := models.GetAuditLog(hotel)
+	require.NoError(err)
+	entry := log[len(log)-1]
+	require.Equal("admin@example.com", entry.Source)
+	require.Contains(entry.Description, "private key was leaked")
+	require.Equal(hotel.VerificationStatus, entry.CurrentState)
+
+	// Verified contacts should be notified of the revocation
+	messages := make([]*emailMeta, 0)
+	iter := models.NewContactIterator(hotel.Contacts, true, true)
+	for iter.Next() {
+		contact, _ := iter.Value()
+		messages = append(messages, &emailMeta{
+			contact:   contact,
+			to:        contact.Email,
+			from:      s.svc.GetConf().Email.ServiceEmail,
+			subject:   emails.CertificateRevokedRE,
+			reason:    string(admin.CertificateRevoked),
+			timestamp: sent,
+		})
+	}
+	require.NotEmpty(messages, "hotel should have verified contacts")
+	require.Equal(len(messages), actual.Sent)
+	s.CheckEmails(messages)
+
+	// Certificate cannot be revoked twice
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, "cannot revoke certificate in REVOKED state", rep)
+
+	// Revocation emails cannot be resent if no certificates have been revoked
+	request = &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/" + charlieID + "/resend",
+		in:     &admin.ResendRequest{Action: admin.CertificateRevoked},
+		params: map[string]string{"vaspID": charlieID},
+	}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.Resend, c, w, nil)
+	s.APIError(http.StatusBadRequest, "VASP does not have a revoked certificate", rep)
+
+	// Revocation emails can be resent to the verified contacts
+	request.path = "/v2/vasps/" + hotelID + "/resend"
+	request.params["vaspID"] = hotelID
+	resent := &admin.ResendReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.Resend, c, w, resent)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(len(messages), resent.Sent)
+	require.Contains(resent.Message, "certificate revocation emails resent")
+}
+
+// Test that the RevokeCertificate endpoint refuses to revoke certificates that were
+// issued by the local CA since it does not publish revocations.
+func (s *gdsTestSuite) TestRevokeCertificateLocalCA() {
+	s.setupLocalCertManager()
+	defer s.teardownCertManager()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	uniform := s.fixtures[certs]["uniform"].(*models.Certificate)
+
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/" + hotelID + "/certificates/" + uniform.Id + "/revoke",
+		params: map[string]string{
+			"vaspID": hotelID,
+			"certID": uniform.Id,
+		},
+		in: &admin.RevokeCertificateRequest{
+			Reason: "keyCompromise",
+		},
+		claims: &tokens.Claims{
+			Email: "admin@example.com",
+		},
+	}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.RevokeCertificate, c, w, nil)
+	s.APIError(http.StatusBadRequest, "the certificate authority does not support revocation", rep)
+
+	// The certificate should not have been marked as revoked
+	cert, err := db.RetrieveCert(uniform.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateState_ISSUED, cert.Status)
+	require.False(cert.Details.Revoked)
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	ok, err := models.HasAuditLogAction(hotel, models.ActionCertificateRevoked, uniform.Id)
+	require.NoError(err)
+	require.False(ok)
+}
+
+// Test the UploadCSR endpoint
+func (s *gdsTestSuite) TestUploadCSR() {
+	s.LoadFullFixtures()
+	defer s.ResetFixtures()
+
+	require := s.Require()
+	a := s.svc.GetAdmin()
+	db := s.svc.GetStore()
+
+	echo := s.fixtures[vasps]["echo"].(*pb.VASP)
+	hotel := s.fixtures[vasps]["hotel"].(*pb.VASP)
+	quebec := s.fixtures[certreqs]["quebec"].(*models.CertificateRequest)
+
+	// Attempt to upload a CSR for a VASP that doesn't exist
+	request := &httpRequest{
+		method: http.MethodPost,
+		path:   "/v2/vasps/invalid/csr",
+		params: map[string]string{"vaspID": "invalid"},
+		in:     &admin.UploadCSRRequest{CSR: makeCSR(s.T(), "invalid.example.com")},
+		claims: &tokens.Claims{Email: "admin@example.com"},
+	}
+	c, w := s.makeRequest(request)
+	rep := s.doRequest(a.UploadCSR, c, w, nil)
+	s.APIError(http.StatusNotFound, "could not retrieve VASP record by ID", rep)
+
+	// The CSR must be for the common name of the VASP
+	request.path = "/v2/vasps/" + echo.Id + "/csr"
+	request.params["vaspID"] = echo.Id
+	request.in = &admin.UploadCSRRequest{CSR: makeCSR(s.T(), hotel.CommonName)}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.UploadCSR, c, w, nil)
+	s.APIError(http.StatusBadRequest, fmt.Sprintf("csr is not for common name %q", echo.CommonName), rep)
+
+	request.in = &admin.UploadCSRRequest{CSR: makeCSR(s.T(), echo.CommonName, echo.CommonName, hotel.CommonName)}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.UploadCSR, c, w, nil)
+	require.Equal(http.StatusBadRequest, rep.StatusCode)
+
+	// The CSR is attached to the certificate request that has not been submitted
+	csr := makeCSR(s.T(), echo.CommonName)
+	request.in = &admin.UploadCSRRequest{CSR: csr}
+	actual := &admin.UploadCSRReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.UploadCSR, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Equal(quebec.Id, actual.CertificateRequest)
+
+	certreq, err := db.RetrieveCertReq(quebec.Id)
+	require.NoError(err)
+	require.Equal(csr, certreq.Csr)
+
+	// If all certificate requests have been submitted the CSR is used for the next one
+	csr = makeCSR(s.T(), hotel.CommonName)
+	request.path = "/v2/vasps/" + hotel.Id + "/csr"
+	request.params["vaspID"] = hotel.Id
+	request.in = &admin.UploadCSRRequest{CSR: csr}
+	actual = &admin.UploadCSRReply{}
+	c, w = s.makeRequest(request)
+	rep = s.doRequest(a.UploadCSR, c, w, actual)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	require.Empty(actual.CertificateRequest)
+	require.Contains(actual.Message, "next certificate request")
+
+	v, err := db.RetrieveVASP(hotel.Id)
+	require.NoError(err)
+	pending, err := models.GetPendingCSR(v)
+	require.NoError(err)
+	require.Equal(csr, pending)
+
+	log, err := models.GetAuditLog(v)
+	require.NoError(err)
+	require.Equal("certificate signing request uploaded", log[len(log)-1].Description)
+	require.Equal("admin@example.com", log[len(log)-1].Source)
+}
+
+// makeCSR returns a PEM encoded CSR for the common name with the DNS names.
+func makeCSR(t *testing.T, commonName string, dnsNames ...string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: dnsNames,
+	}, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
 // Test the ReplaceContact endpoint
 func (s *gdsTestSuite) TestReplaceContact() {
 	s.LoadSmallFixtures()
@@ -997,10 +2035,21 @@ func (s *gdsTestSuite) TestReplaceContact() {
 		Contact: contactRequest,
 	}
 	c, w = s.makeRequest(request)
-	rep = s.doRequest(a.ReplaceContact, c, w, nil)
-	require.Equal(http.StatusOK, rep.StatusCode)
+	reply := &admin.Reply{}
+	rep = s.doRequest(a.ReplaceContact, c, w, reply)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	require.Equal(models.PendingActionType_REPLACE_CONTACT.String(), reply.PendingAction.Type)
+	require.Equal("administrative", reply.PendingAction.Params["kind"])
+
+	// The contact is not replaced until the action is approved
 	vasp, err := s.svc.GetStore().RetrieveVASP(charlieID)
 	require.NoError(err, "could not retrieve VASP record")
+	require.NotEqual(contact.Name, vasp.Contacts.Administrative.Name)
+
+	rep = s.approveAction(reply.PendingAction, nil)
+	require.Equal(http.StatusOK, rep.StatusCode)
+	vasp, err = s.svc.GetStore().RetrieveVASP(charlieID)
+	require.NoError(err, "could not retrieve VASP record")
 	require.Equal(contact.Name, vasp.Contacts.Administrative.Name)
 	require.Equal(contact.Email, vasp.Contacts.Administrative.Email)
 	require.Equal(contact.Phone, vasp.Contacts.Administrative.Phone)
@@ -1018,7 +2067,10 @@ func (s *gdsTestSuite) TestReplaceContact() {
 	}
 	c, w = s.makeRequest(request)
 	adminSent := time.Now()
-	rep = s.doRequest(a.ReplaceContact, c, w, nil)
+	reply = &admin.Reply{}
+	rep = s.doRequest(a.ReplaceContact, c, w, reply)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	rep = s.approveAction(reply.PendingAction, nil)
 	require.Equal(http.StatusOK, rep.StatusCode)
 	vasp, err = s.svc.GetStore().RetrieveVASP(charlieID)
 	require.NoError(err, "could not retrieve VASP record")
@@ -1046,7 +2098,10 @@ func (s *gdsTestSuite) TestReplaceContact() {
 	}
 	c, w = s.makeRequest(request)
 	technicalSent := time.Now()
-	rep = s.doRequest(a.ReplaceContact, c, w, nil)
+	reply = &admin.Reply{}
+	rep = s.doRequest(a.ReplaceContact, c, w, reply)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	rep = s.approveAction(reply.PendingAction, nil)
 	require.Equal(http.StatusOK, rep.StatusCode)
 	vasp, err = s.svc.GetStore().RetrieveVASP(charlieID)
 	require.NoError(err, "could not retrieve VASP record")
@@ -1662,12 +2717,24 @@ func (s *gdsTestSuite) TestReviewReject() {
 	c, w = s.makeRequest(request)
 	sent := time.Now()
 	rep = s.doRequest(a.Review, c, w, actual)
+	require.Equal(http.StatusAccepted, rep.StatusCode)
+	require.Equal(pb.VerificationState_PENDING_REVIEW.String(), actual.Status)
+	require.Equal(models.PendingActionType_REJECT_REGISTRATION.String(), actual.PendingAction.Type)
+	require.Equal("some reason", actual.PendingAction.Params["reason"])
+
+	// The registration is not rejected until the action is approved
+	v, err := s.svc.GetStore().RetrieveVASP(julietVASP.Id)
+	require.NoError(err)
+	require.Equal(pb.VerificationState_PENDING_REVIEW, v.VerificationStatus)
+
+	approved := &admin.PendingAction{}
+	rep = s.approveAction(actual.PendingAction, approved)
 	require.Equal(http.StatusOK, rep.StatusCode)
-	require.Equal(pb.VerificationState_REJECTED.String(), actual.Status)
-	require.Contains(actual.Message, "has been rejected")
+	require.Equal(models.PendingActionState_ACTION_EXECUTED.String(), approved.Status)
+	require.Contains(approved.Message, "has been rejected")
 
 	// VASP state should be changed to REJECTED
-	v, err := s.svc.GetStore().RetrieveVASP(julietVASP.Id)
+	v, err = s.svc.GetStore().RetrieveVASP(julietVASP.Id)
 	require.NoError(err)
 	require.Equal(pb.VerificationState_REJECTED, v.VerificationStatus)
 
@@ -1680,7 +2747,7 @@ func (s *gdsTestSuite) TestReviewReject() {
 	require.Equal(pb.VerificationState_PENDING_REVIEW, log[2].CurrentState)
 	require.Equal(pb.VerificationState_PENDING_REVIEW, log[3].PreviousState)
 	require.Equal(pb.VerificationState_REJECTED, log[3].CurrentState)
-	require.Equal(request.claims.Email, log[3].Source)
+	require.Equal(approved.ResolvedBy, log[3].Source)
 
 	// Certificate request should be deleted from the VASP extra
 	ids, err := models.GetCertReqIDs(v)
@@ -1893,6 +2960,13 @@ func (s *gdsTestSuite) TestReviewTimeline() {
 					pb.VerificationState_APPEALED.String():            0,
 					pb.VerificationState_ERRORED.String():             0,
 				},
+				Actions: map[string]int{
+					models.PendingActionState_ACTION_PROPOSED.String(): 0,
+					models.PendingActionState_ACTION_EXECUTED.String(): 0,
+					models.PendingActionState_ACTION_CANCELED.String(): 0,
+					models.PendingActionState_ACTION_EXPIRED.String():  0,
+					models.PendingActionState_ACTION_FAILED.String():   0,
+				},
 			},
 			{
 				Week:         "2021-08-30",
@@ -1909,6 +2983,13 @@ func (s *gdsTestSuite) TestReviewTimeline() {
 					pb.VerificationState_APPEALED.String():            0,
 					pb.VerificationState_ERRORED.String():             0,
 				},
+				Actions: map[string]int{
+					models.PendingActionState_ACTION_PROPOSED.String(): 0,
+					models.PendingActionState_ACTION_EXECUTED.String(): 0,
+					models.PendingActionState_ACTION_CANCELED.String(): 0,
+					models.PendingActionState_ACTION_EXPIRED.String():  0,
+					models.PendingActionState_ACTION_FAILED.String():   0,
+				},
 			},
 		},
 	}
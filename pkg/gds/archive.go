@@ -0,0 +1,139 @@
+package gds
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// ArchiveManager is a go routine that periodically purges VASPs that were deleted by
+// the admins. Deleted VASPs are archived rather than removed from the store so that
+// they can be restored; once they have been archived for longer than the configured
+// retention period they are permanently deleted along with their certificate requests,
+// certificates, and revision history.
+func (s *Service) ArchiveManager(stop <-chan struct{}) {
+	if !s.conf.Archive.Enabled {
+		log.Warn().Msg("archive manager is not enabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.conf.Archive.Interval)
+	log.Info().Dur("interval", s.conf.Archive.Interval).Dur("retention", s.conf.Archive.Retention).Msg("archive manager started")
+
+	for {
+		// Wait for next tick or a stop signal
+		select {
+		case <-stop:
+			log.Info().Msg("archive manager received stop signal")
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.PurgeArchivedVASPs(); err != nil {
+			log.Error().Err(err).Msg("could not purge archived vasps")
+		}
+	}
+}
+
+// PurgeArchivedVASPs permanently deletes the VASPs that were archived before the start
+// of the retention period, returning the number of VASPs that were purged. Errors for
+// individual VASPs are logged so that one failure does not prevent the other VASPs from
+// being purged; they will be retried on the next call.
+func (s *Service) PurgeArchivedVASPs() (npurged int, err error) {
+	cutoff := time.Now().Add(-s.conf.Archive.Retention)
+	log.Debug().Time("cutoff", cutoff).Msg("archive manager checking for expired vasps")
+
+	// Collect the expired VASPs before deleting them so the store is not modified
+	// while it is being iterated over.
+	expired := make([]*pb.VASP, 0)
+	iter := s.db.ListVASPs()
+	for iter.Next() {
+		var vasp *pb.VASP
+		if vasp, err = iter.VASP(); err != nil {
+			log.Error().Err(err).Msg("could not parse VASP from database")
+			continue
+		}
+
+		var archived time.Time
+		if archived, err = models.GetArchived(vasp); err != nil {
+			log.Error().Err(err).Str("vasp_id", vasp.Id).Msg("could not determine when vasp was archived")
+			continue
+		}
+
+		if !archived.IsZero() && archived.Before(cutoff) {
+			expired = append(expired, vasp)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		iter.Release()
+		return 0, fmt.Errorf("could not iterate over vasps: %s", err)
+	}
+	iter.Release()
+
+	for _, vasp := range expired {
+		if err = s.purgeVASP(vasp); err != nil {
+			log.Error().Err(err).Str("vasp_id", vasp.Id).Msg("could not purge archived vasp")
+			continue
+		}
+		npurged++
+	}
+
+	log.Debug().Int("expired", len(expired)).Int("purged", npurged).Msg("archive manager purge complete")
+	return npurged, nil
+}
+
+// Permanently delete the VASP along with its certificate requests, certificates, and
+// revision history. The certificates are deleted through the store so that they are
+// also removed from the serial number, VASP, and expiration indices.
+func (s *Service) purgeVASP(vasp *pb.VASP) (err error) {
+	var certReqIDs []string
+	if certReqIDs, err = models.GetCertReqIDs(vasp); err != nil {
+		return fmt.Errorf("could not retrieve certificate request IDs: %s", err)
+	}
+
+	for _, id := range certReqIDs {
+		if err = s.db.DeleteCertReq(id); err != nil {
+			return fmt.Errorf("could not delete certificate request %s: %s", id, err)
+		}
+	}
+
+	// Certificates are looked up both on the record and in the store since the VASP
+	// may not reference every certificate that was issued to it.
+	var certIDs []string
+	if certIDs, err = models.GetCertIDs(vasp); err != nil {
+		return fmt.Errorf("could not retrieve certificate IDs: %s", err)
+	}
+
+	var certs []*models.Certificate
+	if certs, err = s.db.ListCertsByVASP(vasp.Id); err != nil {
+		return fmt.Errorf("could not list certificates: %s", err)
+	}
+
+	purged := make(map[string]struct{}, len(certIDs)+len(certs))
+	for _, cert := range certs {
+		certIDs = append(certIDs, cert.Id)
+	}
+
+	for _, id := range certIDs {
+		if _, ok := purged[id]; ok {
+			continue
+		}
+
+		if err = s.db.DeleteCert(id); err != nil {
+			return fmt.Errorf("could not delete certificate %s: %s", id, err)
+		}
+		purged[id] = struct{}{}
+	}
+
+	// Deleting the VASP also deletes its revision history
+	if err = s.db.DeleteVASP(vasp.Id); err != nil {
+		return fmt.Errorf("could not delete vasp: %s", err)
+	}
+
+	log.Info().Str("vasp_id", vasp.Id).Int("certreqs", len(certReqIDs)).Int("certs", len(purged)).Msg("archived vasp purged")
+	return nil
+}
@@ -0,0 +1,136 @@
+package gds_test
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Test that deleted VASPs are purged with their certificate requests, certificates, and
+// revisions once they have been archived for longer than the retention period.
+func (s *gdsTestSuite) TestPurgeArchivedVASPs() {
+	require := s.Require()
+	conf := gds.MockConfig()
+	conf.Archive = config.ArchiveConfig{
+		Enabled:   true,
+		Interval:  time.Millisecond,
+		Retention: 24 * time.Hour,
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	defer s.ResetConfig()
+	defer s.ResetFixtures()
+
+	db := s.svc.GetStore()
+	golfID := s.fixtures[vasps]["golfbucks"].(*pb.VASP).Id
+	julietID := s.fixtures[vasps]["juliet"].(*pb.VASP).Id
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	xrayID := s.fixtures[certreqs]["xray"].(*models.CertificateRequest).Id
+
+	// Nothing is purged if no VASPs have been archived
+	npurged, err := s.svc.PurgeArchivedVASPs()
+	require.NoError(err)
+	require.Zero(npurged)
+
+	// Archive one VASP within the retention period and one before it
+	golf, err := db.RetrieveVASP(golfID)
+	require.NoError(err)
+	require.NoError(models.ArchiveVASP(golf, "admin@example.com"))
+	require.NoError(db.UpdateVASP(golf))
+
+	juliet, err := db.RetrieveVASP(julietID)
+	require.NoError(err)
+	require.NoError(models.ArchiveVASP(juliet, "admin@example.com"))
+	s.setArchived(juliet, time.Now().Add(-48*time.Hour))
+	require.NoError(db.UpdateVASP(juliet))
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	require.NoError(models.ArchiveVASP(hotel, "admin@example.com"))
+	s.setArchived(hotel, time.Now().Add(-48*time.Hour))
+	require.NoError(db.UpdateVASP(hotel))
+
+	certReqIDs, err := models.GetCertReqIDs(juliet)
+	require.NoError(err)
+	require.Contains(certReqIDs, xrayID)
+
+	hotelCerts, err := db.ListCertsByVASP(hotelID)
+	require.NoError(err)
+	require.NotEmpty(hotelCerts)
+	for _, cert := range hotelCerts {
+		_, err = db.RetrieveCertBySerial(hex.EncodeToString(cert.Details.SerialNumber))
+		require.NoError(err)
+	}
+
+	allCerts, err := db.ListCerts().All()
+	require.NoError(err)
+
+	npurged, err = s.svc.PurgeArchivedVASPs()
+	require.NoError(err)
+	require.Equal(2, npurged)
+
+	// Nothing should remain of the purged VASPs
+	for _, id := range []string{julietID, hotelID} {
+		_, err = db.RetrieveVASP(id)
+		require.Error(err)
+
+		revisions, err := db.ListRevisions(id).All()
+		require.NoError(err)
+		require.Empty(revisions, "revisions should be purged with the VASP")
+
+		certs, err := db.ListCertsByVASP(id)
+		require.NoError(err)
+		require.Empty(certs, "certificates should be purged with the VASP")
+	}
+
+	for _, id := range certReqIDs {
+		_, err = db.RetrieveCertReq(id)
+		require.Error(err, "certificate requests should be purged with the VASP")
+	}
+
+	// The certificates should also be removed from the serial number and expiration indices
+	for _, cert := range hotelCerts {
+		_, err = db.RetrieveCert(cert.Id)
+		require.Error(err, "certificates should be purged with the VASP")
+
+		_, err = db.RetrieveCertBySerial(hex.EncodeToString(cert.Details.SerialNumber))
+		require.Error(err)
+	}
+
+	expiring, err := db.ListCertsByExpiration(time.Time{}, time.Time{})
+	require.NoError(err)
+	for _, cert := range expiring {
+		require.NotEqual(hotelID, cert.Vasp)
+	}
+
+	// The certificates of the other VASPs should not be purged
+	remaining, err := db.ListCerts().All()
+	require.NoError(err)
+	require.Len(remaining, len(allCerts)-len(hotelCerts))
+
+	// The recently archived VASP can still be restored
+	golf, err = db.RetrieveVASP(golfID)
+	require.NoError(err)
+	require.True(models.IsArchived(golf))
+
+	npurged, err = s.svc.PurgeArchivedVASPs()
+	require.NoError(err)
+	require.Zero(npurged)
+}
+
+// Modify when the VASP was archived to simulate the passage of time.
+func (s *gdsTestSuite) setArchived(vasp *pb.VASP, archived time.Time) {
+	require := s.Require()
+	extra := &models.GDSExtraData{}
+	require.NoError(vasp.Extra.UnmarshalTo(extra))
+	extra.Archived = archived.Format(time.RFC3339)
+
+	var err error
+	vasp.Extra, err = anypb.New(extra)
+	require.NoError(err)
+}
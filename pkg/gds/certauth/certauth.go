@@ -0,0 +1,99 @@
+/*
+Package certauth defines the certificate authorities that the GDS certificate manager
+uses to issue identity certificates to verified VASPs. Certificates are either issued by
+Sectigo or by a self-hosted authority that signs certificates with a local intermediate,
+which allows TestNet deployments and integration tests to issue real mTLS certificates
+without a Sectigo account.
+*/
+package certauth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+)
+
+// Names of the certificate authorities that can be configured.
+const (
+	AuthoritySectigo = "sectigo"
+	AuthorityLocal   = "local"
+)
+
+// ErrNotSupported is returned if the certificate authority cannot perform an operation,
+// e.g. the local authority cannot revoke certificates since it does not publish a CRL.
+var ErrNotSupported = errors.New("operation is not supported by the certificate authority")
+
+// Batch statuses reported by the certificate authorities. The statuses are the same as
+// the Sectigo batch statuses since they are stored on the certificate requests.
+const (
+	BatchStatusProcessing       = sectigo.BatchStatusProcessing
+	BatchStatusRejected         = sectigo.BatchStatusRejected
+	BatchStatusReadyForDownload = sectigo.BatchStatusReadyForDownload
+)
+
+// CertificateAuthority issues identity certificates in batches: a request is submitted
+// to the authority, its status is checked until the batch has been processed, and then
+// the PKCS12 encrypted certificates are downloaded and delivered to the VASP.
+type CertificateAuthority interface {
+	// Profile returns the name of the certificate profile certificates are issued with.
+	Profile() string
+
+	// Submit a request for a single certificate with the specified parameters, which
+	// must include the commonName, dNSName, and pkcs12Password of the certificate.
+	Submit(batchName string, params map[string]string) (*Batch, error)
+
+	// SubmitCSR submits a PEM encoded certificate signing request supplied by the VASP
+	// so that the certificate is issued without the authority generating a private key.
+	// The downloaded batch contains only the public certificate chain.
+	SubmitCSR(batchName string, csr []byte, params map[string]string) (*Batch, error)
+
+	// BatchDetail returns the current status of a submitted batch.
+	BatchDetail(batchID int) (*Batch, error)
+
+	// ProcessingInfo returns the number of certificates in the batch that are still
+	// being processed, that have been issued, and that have failed.
+	ProcessingInfo(batchID int) (*ProcessingInfo, error)
+
+	// Download the certificates of a processed batch as a zip file to the specified
+	// directory, returning the path to the file. The certificates are PKCS12 encrypted
+	// unless the batch was submitted with a CSR.
+	Download(batchID int, dir string) (path string, err error)
+
+	// Revoke the certificate with the specified capital hex encoded serial number,
+	// returning ErrNotSupported if the authority cannot revoke certificates.
+	Revoke(serialNumber string, reason sectigo.CRLReason) error
+}
+
+// Batch describes a certificate request that has been submitted to an authority.
+type Batch struct {
+	AuthorityID  int
+	BatchID      int
+	BatchName    string
+	Status       string
+	OrderNumber  int
+	CreationDate string
+	Profile      string
+	RejectReason string
+}
+
+// ProcessingInfo describes the progress of the certificates in a batch.
+type ProcessingInfo struct {
+	Active  int
+	Success int
+	Failed  int
+}
+
+// New creates the certificate authority specified by the certificate manager config.
+func New(conf config.CertManConfig, sectigoConf sectigo.Config) (CertificateAuthority, error) {
+	switch strings.ToLower(conf.Authority) {
+	case "", AuthoritySectigo:
+		return NewSectigo(sectigoConf)
+	case AuthorityLocal:
+		return NewLocal(conf)
+	default:
+		return nil, fmt.Errorf("unknown certificate authority %q", conf.Authority)
+	}
+}
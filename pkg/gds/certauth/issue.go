@@ -0,0 +1,172 @@
+package certauth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+// DefaultKeySize is the number of bits of the RSA keys that are generated for issued
+// certificates if no key size is specified.
+const DefaultKeySize = 4096
+
+// NewCA creates a self-signed certificate authority from the template, generating a
+// new RSA private key with the specified number of bits. The returned provider contains
+// the CA certificate and its private key and can be used to issue certificates.
+func NewCA(template *x509.Certificate, bits int) (ca *trust.Provider, err error) {
+	if bits == 0 {
+		bits = DefaultKeySize
+	}
+
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage |= x509.KeyUsageCertSign
+
+	var priv *rsa.PrivateKey
+	if priv, err = rsa.GenerateKey(rand.Reader, bits); err != nil {
+		return nil, fmt.Errorf("could not create private key: %s", err)
+	}
+
+	var signed []byte
+	if signed, err = x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv); err != nil {
+		return nil, fmt.Errorf("could not create CA certificate: %s", err)
+	}
+
+	var chain bytes.Buffer
+	if err = pem.Encode(&chain, &pem.Block{Type: trust.BlockCertificate, Bytes: signed}); err != nil {
+		return nil, err
+	}
+
+	if err = pem.Encode(&chain, &pem.Block{Type: trust.BlockRSAPrivateKey, Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
+		return nil, err
+	}
+
+	return trust.New(chain.Bytes())
+}
+
+// Issue a certificate from the template signed by the certificate authority, generating
+// a new RSA private key with the specified number of bits. If the template does not
+// have a serial number then a random 128 bit serial number is assigned. The returned
+// provider contains the issued certificate followed by the CA chain and the private key.
+func Issue(ca *trust.Provider, template *x509.Certificate, bits int) (provider *trust.Provider, err error) {
+	if bits == 0 {
+		bits = DefaultKeySize
+	}
+
+	var priv *rsa.PrivateKey
+	if priv, err = rsa.GenerateKey(rand.Reader, bits); err != nil {
+		return nil, fmt.Errorf("could not create private key: %s", err)
+	}
+
+	var chain []byte
+	if chain, err = sign(ca, template, &priv.PublicKey); err != nil {
+		return nil, err
+	}
+
+	var key []byte
+	if key = pem.EncodeToMemory(&pem.Block{Type: trust.BlockRSAPrivateKey, Bytes: x509.MarshalPKCS1PrivateKey(priv)}); key == nil {
+		return nil, errors.New("could not encode private key")
+	}
+
+	return trust.New(append(chain, key...))
+}
+
+// Sign a certificate from the template for the public key of the certificate signing
+// request. The returned provider contains the issued certificate followed by the CA
+// chain but no private key, since the private key is held by the requester.
+func Sign(ca *trust.Provider, template *x509.Certificate, csr *x509.CertificateRequest) (provider *trust.Provider, err error) {
+	if err = csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid csr signature: %s", err)
+	}
+
+	var chain []byte
+	if chain, err = sign(ca, template, csr.PublicKey); err != nil {
+		return nil, err
+	}
+	return trust.New(chain)
+}
+
+// parseCSR parses the PEM encoded certificate signing request and checks that it was
+// signed by the requester and that it only requests the specified common name, both as
+// the subject and as the only subject alternative name. The certificate is issued for
+// the common name of the certificate request, so the CSR is rejected rather than
+// silently issuing a certificate for different names than the VASP requested.
+func parseCSR(csrPEM []byte, commonName string) (csr *x509.CertificateRequest, err error) {
+	if commonName == "" {
+		return nil, errors.New("common name is required to issue a certificate")
+	}
+
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, errors.New("csr must be a PEM encoded certificate request")
+	}
+
+	if csr, err = x509.ParseCertificateRequest(block.Bytes); err != nil {
+		return nil, fmt.Errorf("could not parse csr: %s", err)
+	}
+
+	if err = csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("invalid csr signature: %s", err)
+	}
+
+	if csr.Subject.CommonName != commonName {
+		return nil, fmt.Errorf("csr is not for common name %q", commonName)
+	}
+
+	for _, name := range csr.DNSNames {
+		if name != commonName {
+			return nil, fmt.Errorf("csr dns name %q does not match common name %q", name, commonName)
+		}
+	}
+
+	if len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return nil, errors.New("csr can only request the common name as a subject alternative name")
+	}
+	return csr, nil
+}
+
+// Sign the template for the public key with the CA, returning the PEM encoded issued
+// certificate followed by the CA chain.
+func sign(ca *trust.Provider, template *x509.Certificate, pub interface{}) (_ []byte, err error) {
+	var catls tls.Certificate
+	if catls, err = ca.GetKeyPair(); err != nil {
+		return nil, fmt.Errorf("could not load CA key pair: %s", err)
+	}
+
+	var cacert *x509.Certificate
+	if cacert, err = x509.ParseCertificate(catls.Certificate[0]); err != nil {
+		return nil, fmt.Errorf("could not parse CA certificate: %s", err)
+	}
+
+	if template.SerialNumber == nil {
+		if template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128)); err != nil {
+			return nil, fmt.Errorf("could not generate serial number: %s", err)
+		}
+	}
+
+	var signed []byte
+	if signed, err = x509.CreateCertificate(rand.Reader, template, cacert, pub, catls.PrivateKey); err != nil {
+		return nil, fmt.Errorf("could not sign certificate: %s", err)
+	}
+
+	// Encode the certificate followed by the CA chain
+	var chain bytes.Buffer
+	if err = pem.Encode(&chain, &pem.Block{Type: trust.BlockCertificate, Bytes: signed}); err != nil {
+		return nil, err
+	}
+
+	for _, cert := range catls.Certificate {
+		if err = pem.Encode(&chain, &pem.Block{Type: trust.BlockCertificate, Bytes: cert}); err != nil {
+			return nil, err
+		}
+	}
+	return chain.Bytes(), nil
+}
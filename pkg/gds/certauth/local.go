@@ -0,0 +1,307 @@
+package certauth
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+const (
+	// ProfileLocal is the profile of certificates issued by the local authority.
+	ProfileLocal = "Local CA"
+
+	// The local authority only has a single signing authority.
+	localAuthorityID = 1
+
+	localBatchPrefix = "batch-"
+	localBatchExt    = ".zip"
+)
+
+// Local is a self-hosted certificate authority that signs identity certificates with
+// a local intermediate certificate and key. Certificates are issued synchronously when
+// they are submitted and are stored as zip files in the storage directory until they
+// are downloaded, so that the batches survive restarts of the certificate manager.
+// Certificates issued from a CSR are stored without a private key. The local authority
+// does not publish a CRL, so it cannot revoke certificates.
+type Local struct {
+	sync.Mutex
+	ca       *trust.Provider
+	issuer   *x509.Certificate
+	storage  string
+	validity time.Duration
+	keySize  int
+	nextID   int
+}
+
+var _ CertificateAuthority = &Local{}
+
+// NewLocal loads the intermediate certificate and private key of the local authority
+// from the configured path. Issued batches are stored in the local_ca directory of the
+// certificate manager storage or in a temporary directory if no storage is configured.
+func NewLocal(conf config.CertManConfig) (ca *Local, err error) {
+	ca = &Local{
+		validity: conf.LocalCA.Validity,
+		keySize:  conf.LocalCA.KeySize,
+		nextID:   1,
+	}
+
+	var sz *trust.Serializer
+	if sz, err = trust.NewSerializer(false); err != nil {
+		return nil, err
+	}
+
+	if ca.ca, err = sz.ReadFile(conf.LocalCA.Path); err != nil {
+		return nil, fmt.Errorf("could not read local ca: %s", err)
+	}
+
+	if !ca.ca.IsPrivate() {
+		return nil, errors.New("local ca requires the private key of the intermediate certificate")
+	}
+
+	if ca.issuer, err = ca.ca.GetLeafCertificate(); err != nil {
+		return nil, fmt.Errorf("could not parse local ca certificate: %s", err)
+	}
+
+	if conf.Storage != "" {
+		ca.storage = filepath.Join(conf.Storage, "local_ca")
+		if err = os.MkdirAll(ca.storage, 0755); err != nil {
+			return nil, fmt.Errorf("could not create local ca storage: %s", err)
+		}
+	} else {
+		if ca.storage, err = ioutil.TempDir("", "gds_local_ca"); err != nil {
+			return nil, err
+		}
+		log.Warn().Str("storage", ca.storage).Msg("using a temporary directory for local ca batches")
+	}
+
+	// Continue numbering batches from the batches that are already stored
+	var entries []os.FileInfo
+	if entries, err = ioutil.ReadDir(ca.storage); err != nil {
+		return nil, fmt.Errorf("could not read local ca storage: %s", err)
+	}
+
+	for _, entry := range entries {
+		if id, ok := parseBatchFilename(entry.Name()); ok && id >= ca.nextID {
+			ca.nextID = id + 1
+		}
+	}
+
+	log.Info().Str("issuer", ca.issuer.Subject.CommonName).Str("storage", ca.storage).Msg("local certificate authority loaded")
+	return ca, nil
+}
+
+func (l *Local) Profile() string {
+	return ProfileLocal
+}
+
+// Submit issues the certificate immediately and stores the PKCS12 encrypted certificate
+// in a new batch that is ready for download.
+func (l *Local) Submit(batchName string, params map[string]string) (_ *Batch, err error) {
+	commonName := params["commonName"]
+	if commonName == "" {
+		return nil, errors.New("common name is required to issue a certificate")
+	}
+
+	password := params["pkcs12Password"]
+	if password == "" {
+		return nil, errors.New("pkcs12 password is required to issue a certificate")
+	}
+
+	dnsNames := []string{commonName}
+	if dnsName := params["dNSName"]; dnsName != "" && dnsName != commonName {
+		dnsNames = append(dnsNames, dnsName)
+	}
+
+	template := l.template(subjectFromParams(commonName, params), dnsNames)
+
+	var provider *trust.Provider
+	if provider, err = Issue(l.ca, template, l.keySize); err != nil {
+		return nil, err
+	}
+
+	var sz *trust.Serializer
+	if sz, err = trust.NewSerializer(true, password, trust.CompressionZIP); err != nil {
+		return nil, err
+	}
+	return l.store(batchName, template, provider, sz)
+}
+
+// SubmitCSR signs the public key of the certificate request and stores the certificate
+// chain without a private key in a new batch that is ready for download. The subject
+// and DNS names of the certificate are built from the params of the request in the
+// same way as Submit, the CSR only supplies the public key and must be for the common
+// name of the request.
+func (l *Local) SubmitCSR(batchName string, csrPEM []byte, params map[string]string) (_ *Batch, err error) {
+	commonName := params["commonName"]
+
+	var csr *x509.CertificateRequest
+	if csr, err = parseCSR(csrPEM, commonName); err != nil {
+		return nil, err
+	}
+
+	dnsNames := []string{commonName}
+	if dnsName := params["dNSName"]; dnsName != "" && dnsName != commonName {
+		dnsNames = append(dnsNames, dnsName)
+	}
+
+	template := l.template(subjectFromParams(commonName, params), dnsNames)
+
+	var provider *trust.Provider
+	if provider, err = Sign(l.ca, template, csr); err != nil {
+		return nil, err
+	}
+
+	var sz *trust.Serializer
+	if sz, err = trust.NewSerializer(false, "", trust.CompressionZIP); err != nil {
+		return nil, err
+	}
+	return l.store(batchName, template, provider, sz)
+}
+
+func (l *Local) BatchDetail(batchID int) (_ *Batch, err error) {
+	var stat os.FileInfo
+	if stat, err = l.statBatch(batchID); err != nil {
+		return nil, err
+	}
+
+	return &Batch{
+		AuthorityID:  localAuthorityID,
+		BatchID:      batchID,
+		Status:       BatchStatusReadyForDownload,
+		OrderNumber:  batchID,
+		CreationDate: stat.ModTime().Format(time.RFC3339),
+		Profile:      ProfileLocal,
+	}, nil
+}
+
+// ProcessingInfo reports a single successful certificate since local batches are
+// issued when they are submitted.
+func (l *Local) ProcessingInfo(batchID int) (_ *ProcessingInfo, err error) {
+	if _, err = l.statBatch(batchID); err != nil {
+		return nil, err
+	}
+	return &ProcessingInfo{Success: 1}, nil
+}
+
+func (l *Local) Download(batchID int, dir string) (path string, err error) {
+	src := l.batchPath(batchID)
+	path = filepath.Join(dir, filepath.Base(src))
+	if path == src {
+		return path, nil
+	}
+
+	var data []byte
+	if data, err = ioutil.ReadFile(src); err != nil {
+		return "", fmt.Errorf("could not read batch %d: %s", batchID, err)
+	}
+
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("could not write batch %d: %s", batchID, err)
+	}
+	return path, nil
+}
+
+// Revoke is not supported since the local authority does not publish a CRL, so a
+// revoked certificate would continue to be trusted by its peers.
+func (l *Local) Revoke(serialNumber string, reason sectigo.CRLReason) error {
+	log.Warn().Str("serial_number", serialNumber).Str("reason", reason.String()).Msg("local ca does not publish revocations")
+	return ErrNotSupported
+}
+
+// Create a certificate template that is valid for the configured validity period.
+func (l *Local) template(subject pkix.Name, dnsNames []string) *x509.Certificate {
+	now := time.Now()
+	template := &x509.Certificate{
+		Subject:     subject,
+		NotBefore:   now,
+		NotAfter:    now.Add(l.validity),
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		DNSNames:    dnsNames,
+	}
+
+	// The issued certificate cannot outlive the issuer
+	if template.NotAfter.After(l.issuer.NotAfter) {
+		template.NotAfter = l.issuer.NotAfter
+	}
+	return template
+}
+
+// Write the issued certificates to the next batch using the serializer.
+func (l *Local) store(batchName string, template *x509.Certificate, provider *trust.Provider, sz *trust.Serializer) (_ *Batch, err error) {
+	l.Lock()
+	defer l.Unlock()
+	batchID := l.nextID
+	if err = sz.WriteFile(provider, l.batchPath(batchID)); err != nil {
+		return nil, fmt.Errorf("could not write batch: %s", err)
+	}
+	l.nextID++
+
+	log.Debug().Int("batch_id", batchID).Str("common_name", template.Subject.CommonName).Str("serial_number", fmt.Sprintf("%X", template.SerialNumber)).Msg("local ca issued certificate")
+	return &Batch{
+		AuthorityID:  localAuthorityID,
+		BatchID:      batchID,
+		BatchName:    batchName,
+		Status:       BatchStatusReadyForDownload,
+		OrderNumber:  batchID,
+		CreationDate: template.NotBefore.Format(time.RFC3339),
+		Profile:      ProfileLocal,
+	}, nil
+}
+
+func (l *Local) batchPath(batchID int) string {
+	return filepath.Join(l.storage, localBatchPrefix+strconv.Itoa(batchID)+localBatchExt)
+}
+
+func (l *Local) statBatch(batchID int) (stat os.FileInfo, err error) {
+	if stat, err = os.Stat(l.batchPath(batchID)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("batch %d not found", batchID)
+		}
+		return nil, err
+	}
+	return stat, nil
+}
+
+func parseBatchFilename(name string) (id int, ok bool) {
+	if !strings.HasPrefix(name, localBatchPrefix) || !strings.HasSuffix(name, localBatchExt) {
+		return 0, false
+	}
+
+	var err error
+	if id, err = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, localBatchPrefix), localBatchExt)); err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// Create the certificate subject from the Sectigo certificate request parameters.
+func subjectFromParams(commonName string, params map[string]string) pkix.Name {
+	subject := pkix.Name{CommonName: commonName}
+	if org := params["organizationName"]; org != "" {
+		subject.Organization = []string{org}
+	}
+	if locality := params["localityName"]; locality != "" {
+		subject.Locality = []string{locality}
+	}
+	if province := params["stateOrProvinceName"]; province != "" {
+		subject.Province = []string{province}
+	}
+	if country := params["countryName"]; country != "" {
+		subject.Country = []string{country}
+	}
+	return subject
+}
@@ -0,0 +1,208 @@
+package certauth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+	"github.com/trisacrypto/trisa/pkg/trust"
+)
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.CertManConfig{
+		Storage:   filepath.Join(dir, "certs"),
+		Authority: certauth.AuthorityLocal,
+		LocalCA: config.LocalCAConfig{
+			Path:     filepath.Join(dir, "ca.gz"),
+			Validity: 24 * time.Hour,
+			KeySize:  2048,
+		},
+	}
+
+	// The local CA cannot be created without the intermediate certificate
+	_, err := certauth.New(conf, sectigo.Config{})
+	require.Error(t, err)
+
+	ca, err := certauth.NewCA(&x509.Certificate{
+		Subject:   pkix.Name{CommonName: "localca.trisa.dev"},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().AddDate(1, 0, 0),
+	}, 2048)
+	require.NoError(t, err)
+
+	sz, err := trust.NewSerializer(false)
+	require.NoError(t, err)
+	require.NoError(t, sz.WriteFile(ca, conf.LocalCA.Path))
+
+	authority, err := certauth.New(conf, sectigo.Config{})
+	require.NoError(t, err)
+	require.Equal(t, certauth.ProfileLocal, authority.Profile())
+
+	// Required parameters must be specified
+	_, err = authority.Submit("missing-password", map[string]string{"commonName": "alice.vaspbot.net"})
+	require.EqualError(t, err, "pkcs12 password is required to issue a certificate")
+
+	params := map[string]string{
+		"commonName":       "alice.vaspbot.net",
+		"dNSName":          "alice.vaspbot.net",
+		"pkcs12Password":   "supersecret",
+		"organizationName": "Alice VASP",
+		"countryName":      "US",
+	}
+	batch, err := authority.Submit("alice-certreq", params)
+	require.NoError(t, err)
+	require.Equal(t, 1, batch.BatchID)
+	require.Equal(t, "alice-certreq", batch.BatchName)
+	require.Equal(t, certauth.BatchStatusReadyForDownload, batch.Status)
+	require.Greater(t, batch.AuthorityID, 0)
+
+	detail, err := authority.BatchDetail(batch.BatchID)
+	require.NoError(t, err)
+	require.Equal(t, certauth.BatchStatusReadyForDownload, detail.Status)
+
+	proc, err := authority.ProcessingInfo(batch.BatchID)
+	require.NoError(t, err)
+	require.Equal(t, &certauth.ProcessingInfo{Success: 1}, proc)
+
+	_, err = authority.BatchDetail(42)
+	require.EqualError(t, err, "batch 42 not found")
+
+	// The downloaded certificates are decrypted with the pkcs12 password
+	path, err := authority.Download(batch.BatchID, dir)
+	require.NoError(t, err)
+
+	sz, err = trust.NewSerializer(true, params["pkcs12Password"], trust.CompressionZIP)
+	require.NoError(t, err)
+	provider, err := sz.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, provider.IsPrivate())
+
+	cert, err := provider.GetLeafCertificate()
+	require.NoError(t, err)
+	require.Equal(t, "alice.vaspbot.net", cert.Subject.CommonName)
+	require.Equal(t, []string{"Alice VASP"}, cert.Subject.Organization)
+	require.Equal(t, []string{"alice.vaspbot.net"}, cert.DNSNames)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	// The certificate must be signed by the local CA
+	pool, err := ca.GetCertPool()
+	require.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     pool,
+		DNSName:   "alice.vaspbot.net",
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	require.NoError(t, err)
+
+	// Batch numbering continues when the authority is reloaded
+	authority, err = certauth.New(conf, sectigo.Config{})
+	require.NoError(t, err)
+	batch, err = authority.Submit("bob-certreq", map[string]string{"commonName": "bob.vaspbot.net", "pkcs12Password": "supersecret"})
+	require.NoError(t, err)
+	require.Equal(t, 2, batch.BatchID)
+
+	require.ErrorIs(t, authority.Revoke("DEADBEEF", sectigo.CRLRKeyCompromise), certauth.ErrNotSupported)
+}
+
+func TestLocalCSR(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.CertManConfig{
+		Storage:   filepath.Join(dir, "certs"),
+		Authority: certauth.AuthorityLocal,
+		LocalCA: config.LocalCAConfig{
+			Path:     filepath.Join(dir, "ca.gz"),
+			Validity: 24 * time.Hour,
+			KeySize:  2048,
+		},
+	}
+
+	ca, err := certauth.NewCA(&x509.Certificate{
+		Subject:   pkix.Name{CommonName: "localca.trisa.dev"},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().AddDate(1, 0, 0),
+	}, 2048)
+	require.NoError(t, err)
+
+	sz, err := trust.NewSerializer(false)
+	require.NoError(t, err)
+	require.NoError(t, sz.WriteFile(ca, conf.LocalCA.Path))
+
+	authority, err := certauth.New(conf, sectigo.Config{})
+	require.NoError(t, err)
+
+	params := map[string]string{
+		"commonName":       "alice.vaspbot.net",
+		"dNSName":          "alice.vaspbot.net",
+		"organizationName": "Alice VASP",
+	}
+
+	// The CSR must be PEM encoded
+	_, err = authority.SubmitCSR("alice-certreq", []byte("foo"), params)
+	require.EqualError(t, err, "csr must be a PEM encoded certificate request")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	makeCSR := func(template *x509.CertificateRequest) []byte {
+		der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	}
+
+	// The CSR cannot request names other than the common name of the request
+	csr := makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "bob.vaspbot.net"}})
+	_, err = authority.SubmitCSR("alice-certreq", csr, params)
+	require.EqualError(t, err, `csr is not for common name "alice.vaspbot.net"`)
+
+	csr = makeCSR(&x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "alice.vaspbot.net"},
+		DNSNames: []string{"alice.vaspbot.net", "bob.vaspbot.net"},
+	})
+	_, err = authority.SubmitCSR("alice-certreq", csr, params)
+	require.EqualError(t, err, `csr dns name "bob.vaspbot.net" does not match common name "alice.vaspbot.net"`)
+
+	// The common name of the request is required
+	csr = makeCSR(&x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "alice.vaspbot.net", Organization: []string{"Mallory VASP"}},
+		DNSNames: []string{"alice.vaspbot.net"},
+	})
+	_, err = authority.SubmitCSR("alice-certreq", csr, nil)
+	require.EqualError(t, err, "common name is required to issue a certificate")
+
+	batch, err := authority.SubmitCSR("alice-certreq", csr, params)
+	require.NoError(t, err)
+	require.Equal(t, certauth.BatchStatusReadyForDownload, batch.Status)
+
+	// The downloaded certificates do not contain a private key
+	path, err := authority.Download(batch.BatchID, dir)
+	require.NoError(t, err)
+
+	sz, err = trust.NewSerializer(false, "", trust.CompressionZIP)
+	require.NoError(t, err)
+	provider, err := sz.ReadFile(path)
+	require.NoError(t, err)
+	require.False(t, provider.IsPrivate())
+
+	// The subject is taken from the request rather than from the CSR
+	cert, err := provider.GetLeafCertificate()
+	require.NoError(t, err)
+	require.Equal(t, "alice.vaspbot.net", cert.Subject.CommonName)
+	require.Equal(t, []string{"Alice VASP"}, cert.Subject.Organization)
+	require.Equal(t, []string{"alice.vaspbot.net"}, cert.DNSNames)
+	require.True(t, key.PublicKey.Equal(cert.PublicKey), "certificate was not issued for the csr public key")
+}
+
+func TestNewUnknownAuthority(t *testing.T) {
+	_, err := certauth.New(config.CertManConfig{Authority: "foo"}, sectigo.Config{})
+	require.EqualError(t, err, `unknown certificate authority "foo"`)
+}
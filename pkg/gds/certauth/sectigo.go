@@ -0,0 +1,140 @@
+package certauth
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/sectigo"
+)
+
+// Sectigo issues certificates using the Sectigo IoT Portal batch API.
+type Sectigo struct {
+	client *sectigo.Sectigo
+}
+
+var _ CertificateAuthority = &Sectigo{}
+
+// NewSectigo creates a certificate authority with a Sectigo API client.
+func NewSectigo(conf sectigo.Config) (_ *Sectigo, err error) {
+	ca := &Sectigo{}
+	if ca.client, err = sectigo.New(conf); err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+func (s *Sectigo) Profile() string {
+	return s.client.Profile()
+}
+
+// Submit a single certificate batch to the first authority with an available balance.
+func (s *Sectigo) Submit(batchName string, params map[string]string) (_ *Batch, err error) {
+	var authority int
+	if authority, err = s.findAuthority(); err != nil {
+		return nil, err
+	}
+
+	var rep *sectigo.BatchResponse
+	if rep, err = s.client.CreateSingleCertBatch(authority, batchName, params); err != nil {
+		return nil, fmt.Errorf("could not create batch with authority %d: %s", authority, err)
+	}
+
+	batch := batchFromResponse(rep)
+	batch.AuthorityID = authority
+	return batch, nil
+}
+
+// SubmitCSR uploads the CSR to Sectigo using the profile of the certificate authority.
+// Sectigo issues the certificate for the names in the CSR, so the CSR must only request
+// the common name of the certificate request.
+func (s *Sectigo) SubmitCSR(batchName string, csr []byte, params map[string]string) (_ *Batch, err error) {
+	if _, err = parseCSR(csr, params["commonName"]); err != nil {
+		return nil, err
+	}
+
+	var profileID int
+	if profileID, err = sectigo.ProfileID(s.client.Profile()); err != nil {
+		return nil, err
+	}
+
+	var rep *sectigo.BatchResponse
+	if rep, err = s.client.UploadCSRBatch(profileID, batchName+".csr", csr, params); err != nil {
+		return nil, fmt.Errorf("could not upload csr batch with profile %d: %s", profileID, err)
+	}
+
+	batch := batchFromResponse(rep)
+	if batch.BatchName == "" {
+		batch.BatchName = batchName
+	}
+	return batch, nil
+}
+
+// BatchDetail refreshes the batch info from Sectigo. If the batch is in an unhandled
+// state the batch status is fetched directly.
+func (s *Sectigo) BatchDetail(batchID int) (_ *Batch, err error) {
+	var info *sectigo.BatchResponse
+	if info, err = s.client.BatchDetail(batchID); err != nil {
+		return nil, err
+	}
+
+	batch := batchFromResponse(info)
+	if info.Status == sectigo.BatchStatusCollected || info.Status == "" {
+		log.Warn().Int("batch_id", batchID).Str("batch_status", info.Status).Msg("unknown batch info status, refreshing batch status directly")
+		if batch.Status, err = s.client.BatchStatus(batchID); err != nil {
+			return nil, fmt.Errorf("could not fetch batch status: %s", err)
+		}
+	}
+	return batch, nil
+}
+
+func (s *Sectigo) ProcessingInfo(batchID int) (_ *ProcessingInfo, err error) {
+	var proc *sectigo.ProcessingInfoResponse
+	if proc, err = s.client.ProcessingInfo(batchID); err != nil {
+		return nil, err
+	}
+	return &ProcessingInfo{Active: proc.Active, Success: proc.Success, Failed: proc.Failed}, nil
+}
+
+func (s *Sectigo) Download(batchID int, dir string) (path string, err error) {
+	return s.client.Download(batchID, dir)
+}
+
+func (s *Sectigo) Revoke(serialNumber string, reason sectigo.CRLReason) (err error) {
+	var profileID int
+	if profileID, err = sectigo.ProfileID(s.client.Profile()); err != nil {
+		return err
+	}
+	return s.client.RevokeCertificate(profileID, int(reason), serialNumber)
+}
+
+// finds the first authority with an available balance greater than 0.
+func (s *Sectigo) findAuthority() (id int, err error) {
+	var authorities []*sectigo.AuthorityResponse
+	if authorities, err = s.client.UserAuthorities(); err != nil {
+		return 0, fmt.Errorf("could not fetch user authorities: %s", err)
+	}
+
+	for _, authority := range authorities {
+		var balance int
+		if balance, err = s.client.AuthorityAvailableBalance(authority.ID); err != nil {
+			log.Error().Err(err).Int("authority", authority.ID).Msg("could not fetch authority balance")
+		}
+		if balance > 0 {
+			return authority.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("could not find authority with available balance out of %d available authorities", len(authorities))
+}
+
+func batchFromResponse(rep *sectigo.BatchResponse) *Batch {
+	return &Batch{
+		BatchID:      rep.BatchID,
+		BatchName:    rep.BatchName,
+		Status:       rep.Status,
+		OrderNumber:  rep.OrderNumber,
+		CreationDate: rep.CreationDate,
+		Profile:      rep.Profile,
+		RejectReason: rep.RejectReason,
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/sectigo"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
@@ -24,8 +25,8 @@ import (
 
 // CertManager is a go routine that periodically checks on the status of certificate
 // requests and moves them through the request pipeline. Once CertManager detects a
-// certificate request that is ready to submit, it submits the request via the Sectigo
-// API. If processing, it checks the batch status, and when it detects that the bact
+// certificate request that is ready to submit, it submits the request to the configured
+// certificate authority. If processing, it checks the batch status, and when it detects that the bact
 // is done processing it downloads the certs and emails them to the technical conacts.
 // If the certificate processing fails for any reason, it sends and error message to
 // the TRISA admins since this will prevent the integrator from joining the network.
@@ -154,41 +155,53 @@ func (s *Service) submitCertificateRequest(r *models.CertificateRequest, vasp *p
 		return fmt.Errorf("could not update VASP status: %s", err)
 	}
 
-	// Step 1: find an authority with an available balance
-	var authority int
-	if authority, err = s.findCertAuthority(); err != nil {
-		return err
-	}
-
-	// Step 2: get the password
-	secretType := "password"
-	pkcs12Password, err := s.secret.With(r.Id).GetLatestVersion(context.Background(), secretType)
-	if err != nil {
-		return fmt.Errorf("could not retrieve pkcs12password: %s", err)
+	// Step 1: get the password if the VASP did not supply a CSR; if a CSR was supplied
+	// then the directory does not generate or store the private key.
+	var pkcs12Password []byte
+	if r.Csr == "" {
+		secretType := "password"
+		if pkcs12Password, err = s.secret.With(r.Id).GetLatestVersion(context.Background(), secretType); err != nil {
+			return fmt.Errorf("could not retrieve pkcs12password: %s", err)
+		}
 	}
 
 	profile := s.certs.Profile()
 	var params map[string]string
-	if profile == sectigo.ProfileCipherTraceEndEntityCertificate || profile == sectigo.ProfileIDCipherTraceEndEntityCertificate {
+	switch profile {
+	case sectigo.ProfileCipherTraceEndEntityCertificate, sectigo.ProfileIDCipherTraceEndEntityCertificate:
 		params = r.Params
 		if params == nil {
 			log.Error().Str("vasp", vasp.Id).Str("certreq", r.Id).Msg("certificate request params are nil")
 			return errors.New("no params are available on the certificate request")
 		}
-	} else {
+	case certauth.ProfileLocal:
+		// The local authority uses the subject params if they are available
+		params = make(map[string]string, len(r.Params)+3)
+		for key, value := range r.Params {
+			params[key] = value
+		}
+	default:
 		params = make(map[string]string)
 	}
 
 	params["commonName"] = r.CommonName
 	params["dNSName"] = r.CommonName
-	params["pkcs12Password"] = string(pkcs12Password)
+	if r.Csr == "" {
+		params["pkcs12Password"] = string(pkcs12Password)
+	}
 
-	// Step 3: submit the certificate
-	var rep *sectigo.BatchResponse
+	// Step 2: submit the certificate
+	var batch *certauth.Batch
 	batchName := fmt.Sprintf("%s-certreq-%s)", s.conf.DirectoryID, r.Id)
-	if rep, err = s.certs.CreateSingleCertBatch(authority, batchName, params); err != nil {
+	if r.Csr != "" {
+		batch, err = s.certs.SubmitCSR(batchName, []byte(r.Csr), params)
+	} else {
+		batch, err = s.certs.Submit(batchName, params)
+	}
+
+	if err != nil {
 		// Although the error may be logged again by the calling function, log the error
-		// here as well to provide debugging information about why the Sectigo request failed.
+		// here as well to provide debugging information about why the request failed.
 		dict := zerolog.Dict()
 		for key, value := range params {
 			// NOTE: Do not log any passwords or secrets!
@@ -198,7 +211,6 @@ func (s *Service) submitCertificateRequest(r *models.CertificateRequest, vasp *p
 			dict.Str(key, value)
 		}
 		log.Error().Err(err).
-			Int("authority", authority).
 			Str("batch_name", batchName).
 			Dict("params", dict).
 			Str("profile", profile).
@@ -206,15 +218,15 @@ func (s *Service) submitCertificateRequest(r *models.CertificateRequest, vasp *p
 		return fmt.Errorf("could not create single certificate batch: %s", err)
 	}
 
-	// Step 4: update the certificate request with the batch details
-	r.AuthorityId = int64(authority)
-	r.BatchId = int64(rep.BatchID)
-	r.BatchName = rep.BatchName
-	r.BatchStatus = rep.Status
-	r.OrderNumber = int64(rep.OrderNumber)
-	r.CreationDate = rep.CreationDate
-	r.Profile = rep.Profile
-	r.RejectReason = rep.RejectReason
+	// Step 3: update the certificate request with the batch details
+	r.AuthorityId = int64(batch.AuthorityID)
+	r.BatchId = int64(batch.BatchID)
+	r.BatchName = batch.BatchName
+	r.BatchStatus = batch.Status
+	r.OrderNumber = int64(batch.OrderNumber)
+	r.CreationDate = batch.CreationDate
+	r.Profile = batch.Profile
+	r.RejectReason = batch.RejectReason
 
 	// Mark the certificate request as processing so downstream status checks occur
 	if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_PROCESSING, "certificate submitted", "automated"); err != nil {
@@ -232,8 +244,8 @@ func (s *Service) checkCertificateRequest(r *models.CertificateRequest) (err err
 		return errors.New("missing batch ID - cannot retrieve status")
 	}
 
-	// Step 1: refresh batch info from the sectigo service
-	var info *sectigo.BatchResponse
+	// Step 1: refresh batch info from the certificate authority
+	var info *certauth.Batch
 	if info, err = s.certs.BatchDetail(int(r.BatchId)); err != nil {
 		return fmt.Errorf("could not fetch batch info for id %d: %s", r.BatchId, err)
 	}
@@ -242,16 +254,8 @@ func (s *Service) checkCertificateRequest(r *models.CertificateRequest) (err err
 	r.BatchStatus = info.Status
 	r.RejectReason = info.RejectReason
 
-	// Step 1c: check if the batch is in an unhandled state, and if so, refresh batch status
-	if info.Status == sectigo.BatchStatusCollected || info.Status == "" {
-		log.Warn().Int64("batch_id", r.BatchId).Str("batch_status", info.Status).Msg("unknown batch info status, refreshing batch status directly")
-		if r.BatchStatus, err = s.certs.BatchStatus(int(r.BatchId)); err != nil {
-			return fmt.Errorf("could not fetch batch status for id %d: %s", r.BatchId, err)
-		}
-	}
-
 	// Step 2: get the processing info for the batch
-	var proc *sectigo.ProcessingInfoResponse
+	var proc *certauth.ProcessingInfo
 	if proc, err = s.certs.ProcessingInfo(int(r.BatchId)); err != nil {
 		return fmt.Errorf("could not fetch batch processing info for id %d: %s", r.BatchId, err)
 	}
@@ -285,14 +289,14 @@ func (s *Service) checkCertificateRequest(r *models.CertificateRequest) (err err
 			Str("name", r.BatchName).
 			Logger()
 
-		if proc.Success > 0 || r.BatchStatus == sectigo.BatchStatusReadyForDownload {
+		if proc.Success > 0 || r.BatchStatus == certauth.BatchStatusReadyForDownload {
 			// This may mean that some certificates can be downloaded, so just log
 			// errors and continue with download processing
 			logctx.Warn().Msg("certificate request mixed success/failure")
 		} else {
 			// In this case there were no successes, so set certificate request status accordingly
 			// and do not continue processing the certificate request
-			if r.RejectReason != "" || r.BatchStatus == sectigo.BatchStatusRejected {
+			if r.RejectReason != "" || r.BatchStatus == certauth.BatchStatusRejected {
 				// Assume the certificate was rejected
 				if err = models.UpdateCertificateRequestStatus(r, models.CertificateRequestState_CR_REJECTED, "certificate request rejected", "automated"); err != nil {
 					return fmt.Errorf("could not update certificate request status: %s", err)
@@ -314,8 +318,8 @@ func (s *Service) checkCertificateRequest(r *models.CertificateRequest) (err err
 	}
 
 	// Step 5: Check to make sure we can download certificates
-	if proc.Success == 0 || r.BatchStatus != sectigo.BatchStatusReadyForDownload {
-		// We should not be in this state, it should have been handled in Step 1c or 4
+	if proc.Success == 0 || r.BatchStatus != certauth.BatchStatusReadyForDownload {
+		// We should not be in this state, it should have been handled in Step 1 or 4
 		// so this is a developer error on our part, or a change in the Sectigo API
 		// NOTE: using WithLevel and Fatal does not Exit the program like log.Fatal()
 		// this ensures that we issue a CRITICAL severity without stopping the server.
@@ -360,26 +364,6 @@ func (s *Service) checkCertificateRequest(r *models.CertificateRequest) (err err
 	return nil
 }
 
-// finds the first authority with an available balance greater than 0.
-func (s *Service) findCertAuthority() (id int, err error) {
-	var authorities []*sectigo.AuthorityResponse
-	if authorities, err = s.certs.UserAuthorities(); err != nil {
-		return 0, fmt.Errorf("could not fetch user authorities: %s", err)
-	}
-
-	for _, authority := range authorities {
-		var balance int
-		if balance, err = s.certs.AuthorityAvailableBalance(authority.ID); err != nil {
-			log.Error().Err(err).Int("authority", authority.ID).Msg("could not fetch authority balance")
-		}
-		if balance > 0 {
-			return authority.ID, nil
-		}
-	}
-
-	return 0, fmt.Errorf("could not find authority with available balance out of %d available authorities", len(authorities))
-}
-
 // a go routine that downloads the certificate in the background, then sends the certs
 // as an attachment to the technical contact if available.
 func (s *Service) downloadCertificateRequest(r *models.CertificateRequest, vasp *pb.VASP) {
@@ -434,12 +418,15 @@ func (s *Service) downloadCertificateRequest(r *models.CertificateRequest, vasp
 
 	log.Info().Str(path, path).Msg("certificates written to secret manager")
 
-	// Retrieve the latest secret version for the password
-	secretType = "password"
-	pkcs12password, err := s.secret.With(r.Id).GetLatestVersion(sctx, secretType)
-	if err != nil {
-		log.Error().Err(err).Msg("could not retrieve password from secret manager to extract public key")
-		return
+	// Retrieve the latest secret version for the password; certificates issued from a
+	// CSR are not encrypted since the archive only contains the public chain.
+	var pkcs12password []byte
+	if r.Csr == "" {
+		secretType = "password"
+		if pkcs12password, err = s.secret.With(r.Id).GetLatestVersion(sctx, secretType); err != nil {
+			log.Error().Err(err).Msg("could not retrieve password from secret manager to extract public key")
+			return
+		}
 	}
 
 	if vasp.IdentityCertificate, err = extractCertificate(path, string(pkcs12password)); err != nil {
@@ -478,7 +465,13 @@ func (s *Service) downloadCertificateRequest(r *models.CertificateRequest, vasp
 	}
 
 	// Email the certificates to the technical contacts
-	if _, err = s.email.SendDeliverCertificates(vasp, path); err != nil {
+	if r.Csr != "" {
+		_, err = s.email.SendDeliverPublicCertificates(vasp, path)
+	} else {
+		_, err = s.email.SendDeliverCertificates(vasp, path)
+	}
+
+	if err != nil {
 		// If there is an error delivering emails, return here so we don't mark as completed
 		log.Error().Err(err).Msg("could not deliver certificates to technical contact")
 		return
@@ -505,9 +498,11 @@ func (s *Service) downloadCertificateRequest(r *models.CertificateRequest, vasp
 		Msg("certificates extracted and delivered")
 }
 
+// Extract the public certificate from the zip archive at the path. If the password is
+// empty, the archive is expected to contain only the public certificate chain.
 func extractCertificate(path, pkcs12password string) (pub *pb.Certificate, err error) {
 	var archive *trust.Serializer
-	if archive, err = trust.NewSerializer(true, pkcs12password, trust.CompressionZIP); err != nil {
+	if archive, err = trust.NewSerializer(pkcs12password != "", pkcs12password, trust.CompressionZIP); err != nil {
 		return nil, err
 	}
 
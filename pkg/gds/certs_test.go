@@ -2,20 +2,29 @@ package gds_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/trisacrypto/directory/pkg/gds"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/sectigo/mock"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"github.com/trisacrypto/trisa/pkg/trust"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -156,6 +165,93 @@ func (s *gdsTestSuite) TestCertManager() {
 	require.Equal("automated", certReq.AuditLog[5].Source)
 }
 
+// Test that the certificate manager issues certificates with the local certificate
+// authority without submitting requests to Sectigo.
+func (s *gdsTestSuite) TestCertManagerLocalCA() {
+	certDir := s.setupLocalCertManager()
+	defer s.teardownCertManager()
+	require := s.Require()
+
+	echoVASP := s.fixtures[vasps]["echo"].(*pb.VASP)
+	quebecCertReq := s.fixtures[certreqs]["quebec"].(*models.CertificateRequest)
+
+	sm := s.svc.GetSecretManager().With(quebecCertReq.Id)
+	ctx := context.Background()
+	require.NoError(sm.CreateSecret(ctx, "password"))
+	require.NoError(sm.AddSecretVersion(ctx, "password", []byte("qDhAwnfMjgDEzzUC")))
+
+	// Submit the certificate request to the local CA
+	require.NoError(s.svc.HandleCertificateRequests(certDir))
+	certReq, err := s.svc.GetStore().RetrieveCertReq(quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_PROCESSING, certReq.Status)
+	require.Equal(certauth.ProfileLocal, certReq.Profile)
+	require.Equal(certauth.BatchStatusReadyForDownload, certReq.BatchStatus)
+
+	// Download and deliver the certificates
+	require.NoError(s.svc.HandleCertificateRequests(certDir))
+	certReq, err = s.svc.GetStore().RetrieveCertReq(quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_COMPLETED, certReq.Status)
+
+	// The identity certificate should be issued by the local CA
+	v, err := s.svc.GetStore().RetrieveVASP(echoVASP.Id)
+	require.NoError(err)
+	require.Equal(pb.VerificationState_VERIFIED, v.VerificationStatus)
+	require.NotNil(v.IdentityCertificate)
+	require.Equal(echoVASP.CommonName, v.IdentityCertificate.Subject.CommonName)
+	require.Equal("localca.gds.dev", v.IdentityCertificate.Issuer.CommonName)
+
+	cert, err := s.svc.GetStore().RetrieveCert(certReq.Certificate)
+	require.NoError(err)
+	require.Equal(models.CertificateState_ISSUED, cert.Status)
+	require.True(proto.Equal(v.IdentityCertificate, cert.Details))
+}
+
+// Test that a certificate request with a CSR supplied by the VASP is issued without a
+// PKCS12 password and that only the public certificate chain is delivered.
+func (s *gdsTestSuite) TestCertManagerCSR() {
+	certDir := s.setupLocalCertManager()
+	defer s.teardownCertManager()
+	require := s.Require()
+
+	echoVASP := s.fixtures[vasps]["echo"].(*pb.VASP)
+	quebecCertReq := s.fixtures[certreqs]["quebec"].(*models.CertificateRequest)
+
+	// Attach a CSR to the certificate request; no password secret is created
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: quebecCertReq.CommonName},
+	}, key)
+	require.NoError(err)
+	certReq := proto.Clone(quebecCertReq).(*models.CertificateRequest)
+	require.NoError(models.AttachCSR(certReq, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})))
+	require.NoError(s.svc.GetStore().UpdateCertReq(certReq))
+
+	// Submit the certificate request and then download and deliver the certificates
+	require.NoError(s.svc.HandleCertificateRequests(certDir))
+	require.NoError(s.svc.HandleCertificateRequests(certDir))
+	certReq, err = s.svc.GetStore().RetrieveCertReq(quebecCertReq.Id)
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_COMPLETED, certReq.Status)
+
+	// The identity certificate must be issued for the public key of the CSR
+	v, err := s.svc.GetStore().RetrieveVASP(echoVASP.Id)
+	require.NoError(err)
+	require.Equal(pb.VerificationState_VERIFIED, v.VerificationStatus)
+	block, _ := pem.Decode(v.IdentityCertificate.Data)
+	require.NotNil(block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(err)
+	require.True(key.PublicKey.Equal(cert.PublicKey), "certificate was not issued for the csr public key")
+
+	// The delivery email should only contain the public chain
+	require.Len(emails.MockEmails, 1)
+	require.Contains(string(emails.MockEmails[0]), "certificate signing request")
+	require.NotContains(string(emails.MockEmails[0]), "PKCS12")
+}
+
 // Test that the certificate manager rejects requests when the VASP state is invalid.
 func (s *gdsTestSuite) TestCertManagerBadState() {
 	certDir := s.setupCertManager(sectigo.ProfileCipherTraceEE)
@@ -193,13 +289,15 @@ func (s *gdsTestSuite) TestCertManagerBadState() {
 	// Set VASP to verified for correct submission
 	echoVASP.VerificationStatus = pb.VerificationState_VERIFIED
 	require.NoError(s.svc.GetStore().UpdateVASP(echoVASP))
-	quebecCertReq.Status = models.CertificateRequestState_READY_TO_SUBMIT
-	require.NoError(s.svc.GetStore().UpdateCertReq(quebecCertReq))
+	certReq.Status = models.CertificateRequestState_READY_TO_SUBMIT
+	require.NoError(s.svc.GetStore().UpdateCertReq(certReq))
 
 	// Move the certificate to processing
 	require.NoError(s.svc.HandleCertificateRequests(certDir), "certman loop unsuccessful")
 
-	// Set VASP to rejected
+	// Set VASP to rejected, reloading the VASP since the cert manager has updated it
+	echoVASP, err = s.svc.GetStore().RetrieveVASP(echoVASP.Id)
+	require.NoError(err)
 	echoVASP.VerificationStatus = pb.VerificationState_REJECTED
 	require.NoError(s.svc.GetStore().UpdateVASP(echoVASP))
 
@@ -639,6 +737,41 @@ func (s *gdsTestSuite) setupCertManager(profile string) (certDir string) {
 	return certDir
 }
 
+// Configures the certificate manager to issue certificates with a local CA that is
+// created in the cert storage directory.
+func (s *gdsTestSuite) setupLocalCertManager() (certDir string) {
+	require := s.Require()
+	certDir, err := ioutil.TempDir("testdata", "certs-*")
+	require.NoError(err)
+
+	// Create the intermediate certificate of the local CA
+	ca, err := certauth.NewCA(&x509.Certificate{
+		Subject:   pkix.Name{CommonName: "localca.gds.dev"},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().AddDate(1, 0, 0),
+	}, 2048)
+	require.NoError(err)
+	sz, err := trust.NewSerializer(false)
+	require.NoError(err)
+	caPath := filepath.Join(certDir, "ca.gz")
+	require.NoError(sz.WriteFile(ca, caPath))
+
+	conf := gds.MockConfig()
+	conf.CertMan = config.CertManConfig{
+		Interval:  time.Millisecond,
+		Storage:   certDir,
+		Authority: certauth.AuthorityLocal,
+		LocalCA: config.LocalCAConfig{
+			Path:     caPath,
+			Validity: 24 * time.Hour,
+			KeySize:  2048,
+		},
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	return certDir
+}
+
 func (s *gdsTestSuite) teardownCertManager() {
 	s.ResetConfig()
 	s.ResetFixtures()
@@ -113,11 +113,19 @@ func (p *AdminProfile) GenerateTokens(api admin.DirectoryAdministrationClient) (
 
 	var accessToken, refreshToken *jwt.Token
 
+	// Locally generated tokens are used by operators who hold the signing keys; the GDS
+	// reissues the permissions of the role assigned to this email on reauthentication.
+	var permissions []string
+	if permissions, err = tokens.RolePermissions(tokens.SuperuserRole); err != nil {
+		return nil, err
+	}
+
 	claims := map[string]interface{}{
-		"hd":      "rotational.io",
-		"email":   "admin@rotational.io",
-		"name":    "GDS Admin CLI",
-		"picture": "",
+		"hd":          "rotational.io",
+		"email":       "admin@rotational.io",
+		"name":        "GDS Admin CLI",
+		"picture":     "",
+		"permissions": permissions,
 	}
 
 	// Create the access and refresh tokens from the claims
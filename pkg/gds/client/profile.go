@@ -13,6 +13,7 @@ import (
 	"github.com/trisacrypto/directory/pkg/gds/admin/v2"
 	members "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	"github.com/trisacrypto/directory/pkg/gds/store"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
 	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
@@ -232,6 +233,15 @@ func (p *TrtlProfile) ConnectPeers() (_ peers.PeerManagementClient, err error) {
 	return peers.NewPeerManagementClient(cc), nil
 }
 
+// Connect to the trtl database server and return a gRPC client
+func (p *TrtlProfile) ConnectNamespaces() (_ namespaces.NamespaceManagementClient, err error) {
+	cc, err := p.Connect()
+	if err != nil {
+		return nil, err
+	}
+	return namespaces.NewNamespaceManagementClient(cc), nil
+}
+
 // Connect to the TRISA Members Service and return a gRPC client
 func (p *MembersProfile) Connect() (_ members.TRISAMembersClient, err error) {
 	var opts []grpc.DialOption
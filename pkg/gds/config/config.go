@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rs/zerolog"
+	"github.com/trisacrypto/directory/pkg/gds/tokens"
 	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
@@ -30,6 +31,8 @@ type Config struct {
 	Email       EmailConfig
 	CertMan     CertManConfig
 	Backup      BackupConfig
+	Reissuance  ReissuanceConfig
+	Archive     ArchiveConfig
 	Secrets     SecretsConfig
 	Sentry      sentry.Config
 	processed   bool
@@ -54,6 +57,20 @@ type AdminConfig struct {
 	// Multiple keys are used in order to rotate keys regularly; keyids therefore must
 	// be sortable; in general we prefer to use ksuid for key ids.
 	TokenKeys map[string]string `split_words:"true"`
+
+	// Roles assign a role to admin users by email address; the GDS_ADMIN_ROLES
+	// environment variable should be a comma separated list of email:role, e.g.
+	// "jane@example.com:superuser,jon@example.com:reviewer". Valid roles are readonly,
+	// reviewer, and superuser. No roles are assigned by default so every user is given
+	// the default role when they authenticate or reauthenticate; superusers must be
+	// explicitly configured, including the admin CLI identity (admin@rotational.io) if
+	// its tokens are expected to retain superuser permissions when they are reissued.
+	Roles       map[string]string `split_words:"true"`
+	DefaultRole string            `split_words:"true" default:"readonly"`
+
+	// Destructive actions proposed by one admin must be approved by a second admin
+	// before this timeout or the proposal expires and can no longer be executed.
+	PendingActionTimeout time.Duration `split_words:"true" default:"72h"`
 }
 
 type OauthConfig struct {
@@ -89,8 +106,19 @@ type EmailConfig struct {
 }
 
 type CertManConfig struct {
-	Interval time.Duration `split_words:"true" default:"10m"`
-	Storage  string        `split_words:"true" required:"false"`
+	Interval  time.Duration `split_words:"true" default:"10m"`
+	Storage   string        `split_words:"true" required:"false"`
+	Authority string        `split_words:"true" default:"sectigo"`
+	LocalCA   LocalCAConfig `split_words:"true"`
+}
+
+// LocalCAConfig configures the self-hosted certificate authority that signs identity
+// certificates with a local intermediate rather than submitting them to Sectigo. It is
+// only used if the certificate manager authority is "local".
+type LocalCAConfig struct {
+	Path     string        `split_words:"true" required:"false"`
+	Validity time.Duration `split_words:"true" default:"8760h"`
+	KeySize  int           `split_words:"true" default:"4096"`
 }
 
 type BackupConfig struct {
@@ -100,6 +128,27 @@ type BackupConfig struct {
 	Keep     int           `split_words:"true" default:"1"`
 }
 
+// ReissuanceConfig specifies when the reissuance manager notifies the TRISA admins and
+// the VASP contacts about an expiring identity certificate and when it starts the
+// reissuance. Each of the durations is measured backwards from the certificate's
+// not after timestamp.
+type ReissuanceConfig struct {
+	Enabled           bool          `split_words:"true" default:"false"`
+	Interval          time.Duration `split_words:"true" default:"24h"`
+	AdminNoticeBefore time.Duration `split_words:"true" default:"720h"`
+	ReminderBefore    time.Duration `split_words:"true" default:"504h"`
+	ReissueBefore     time.Duration `split_words:"true" default:"240h"`
+}
+
+// ArchiveConfig specifies how long deleted VASPs are retained so that they can be
+// restored by an admin before the archive manager permanently purges them along with
+// their certificate requests.
+type ArchiveConfig struct {
+	Enabled   bool          `split_words:"true" default:"false"`
+	Interval  time.Duration `split_words:"true" default:"24h"`
+	Retention time.Duration `split_words:"true" default:"720h"`
+}
+
 type SecretsConfig struct {
 	Credentials string `envconfig:"GOOGLE_APPLICATION_CREDENTIALS" required:"false"`
 	Project     string `envconfig:"GOOGLE_PROJECT_NAME" required:"false"`
@@ -165,10 +214,22 @@ func (c Config) Validate() (err error) {
 		return err
 	}
 
+	if err = c.CertMan.Validate(); err != nil {
+		return err
+	}
+
 	if err = c.Email.Validate(); err != nil {
 		return err
 	}
 
+	if err = c.Reissuance.Validate(); err != nil {
+		return err
+	}
+
+	if err = c.Archive.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -195,6 +256,20 @@ func (c AdminConfig) Validate() error {
 		if len(c.TokenKeys) == 0 {
 			return errors.New("invalid configuration: token keys required for enabled admin")
 		}
+
+		if _, err := tokens.RolePermissions(c.DefaultRole); err != nil {
+			return fmt.Errorf("invalid configuration: default role: %s", err)
+		}
+
+		for email, role := range c.Roles {
+			if _, err := tokens.RolePermissions(role); err != nil {
+				return fmt.Errorf("invalid configuration: role for %s: %s", email, err)
+			}
+		}
+
+		if c.PendingActionTimeout <= 0 {
+			return errors.New("invalid configuration: pending action timeout must be greater than zero")
+		}
 	}
 
 	return nil
@@ -245,3 +320,54 @@ func (c EmailConfig) Validate() error {
 
 	return nil
 }
+
+func (c CertManConfig) Validate() error {
+	switch strings.ToLower(c.Authority) {
+	case "", "sectigo":
+	case "local":
+		if c.LocalCA.Path == "" {
+			return errors.New("invalid configuration: path to the local ca is required")
+		}
+
+		if c.LocalCA.Validity <= 0 {
+			return errors.New("invalid configuration: local ca certificate validity must be positive")
+		}
+
+		if c.LocalCA.KeySize < 2048 {
+			return errors.New("invalid configuration: local ca key size must be at least 2048 bits")
+		}
+	default:
+		return fmt.Errorf("invalid configuration: %q is not a valid certificate authority", c.Authority)
+	}
+	return nil
+}
+
+func (c ReissuanceConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 {
+			return errors.New("invalid configuration: reissuance interval must be positive")
+		}
+
+		if c.ReissueBefore <= 0 {
+			return errors.New("invalid configuration: reissue before must be positive")
+		}
+
+		if c.AdminNoticeBefore < c.ReminderBefore || c.ReminderBefore < c.ReissueBefore {
+			return errors.New("invalid configuration: reissuance admin notice must precede the reminder which must precede the reissuance")
+		}
+	}
+	return nil
+}
+
+func (c ArchiveConfig) Validate() error {
+	if c.Enabled {
+		if c.Interval <= 0 {
+			return errors.New("invalid configuration: archive interval must be positive")
+		}
+
+		if c.Retention <= 0 {
+			return errors.New("invalid configuration: archive retention must be positive")
+		}
+	}
+	return nil
+}
@@ -29,6 +29,9 @@ var testEnv = map[string]string{
 	"GDS_ADMIN_ALLOW_ORIGINS":                  "https://admin.trisatest.net",
 	"GDS_ADMIN_COOKIE_DOMAIN":                  "admin.trisatest.net",
 	"GDS_ADMIN_AUDIENCE":                       "https://api.admin.trisatest.net",
+	"GDS_ADMIN_ROLES":                          "lead@trisa.io:superuser,junior@trisa.io:reviewer",
+	"GDS_ADMIN_DEFAULT_ROLE":                   "readonly",
+	"GDS_ADMIN_PENDING_ACTION_TIMEOUT":         "48h",
 	"GDS_MEMBERS_ENABLED":                      "true",
 	"GDS_MEMBERS_BIND_ADDR":                    ":445",
 	"GDS_MEMBERS_INSECURE":                     "true",
@@ -52,10 +55,22 @@ var testEnv = map[string]string{
 	"GDS_EMAIL_STORAGE":                        "fixtures/emails",
 	"GDS_CERTMAN_INTERVAL":                     "60s",
 	"GDS_CERTMAN_STORAGE":                      "fixtures/certs",
+	"GDS_CERTMAN_AUTHORITY":                    "local",
+	"GDS_CERTMAN_LOCAL_CA_PATH":                "fixtures/certs/ca.gz",
+	"GDS_CERTMAN_LOCAL_CA_VALIDITY":            "720h",
+	"GDS_CERTMAN_LOCAL_CA_KEY_SIZE":            "2048",
 	"GDS_BACKUP_ENABLED":                       "true",
 	"GDS_BACKUP_INTERVAL":                      "36h",
 	"GDS_BACKUP_STORAGE":                       "fixtures/backups",
 	"GDS_BACKUP_KEEP":                          "7",
+	"GDS_REISSUANCE_ENABLED":                   "true",
+	"GDS_REISSUANCE_INTERVAL":                  "12h",
+	"GDS_REISSUANCE_ADMIN_NOTICE_BEFORE":       "600h",
+	"GDS_REISSUANCE_REMINDER_BEFORE":           "480h",
+	"GDS_REISSUANCE_REISSUE_BEFORE":            "120h",
+	"GDS_ARCHIVE_ENABLED":                      "true",
+	"GDS_ARCHIVE_INTERVAL":                     "6h",
+	"GDS_ARCHIVE_RETENTION":                    "168h",
 	"GOOGLE_APPLICATION_CREDENTIALS":           "test.json",
 	"GOOGLE_PROJECT_NAME":                      "test",
 	"GDS_SECRETS_TESTING":                      "true",
@@ -102,6 +117,9 @@ func TestConfig(t *testing.T) {
 	require.Len(t, conf.Admin.AllowOrigins, 1)
 	require.Equal(t, testEnv["GDS_ADMIN_COOKIE_DOMAIN"], conf.Admin.CookieDomain)
 	require.Equal(t, testEnv["GDS_ADMIN_AUDIENCE"], conf.Admin.Audience)
+	require.Equal(t, map[string]string{"lead@trisa.io": "superuser", "junior@trisa.io": "reviewer"}, conf.Admin.Roles)
+	require.Equal(t, testEnv["GDS_ADMIN_DEFAULT_ROLE"], conf.Admin.DefaultRole)
+	require.Equal(t, 48*time.Hour, conf.Admin.PendingActionTimeout)
 	require.True(t, conf.Members.Enabled)
 	require.Equal(t, testEnv["GDS_MEMBERS_BIND_ADDR"], conf.Members.BindAddr)
 	require.True(t, conf.Members.Insecure)
@@ -126,10 +144,22 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, testEnv["GDS_DIRECTORY_ID"], conf.Email.DirectoryID)
 	require.Equal(t, 1*time.Minute, conf.CertMan.Interval)
 	require.Equal(t, testEnv["GDS_CERTMAN_STORAGE"], conf.CertMan.Storage)
+	require.Equal(t, testEnv["GDS_CERTMAN_AUTHORITY"], conf.CertMan.Authority)
+	require.Equal(t, testEnv["GDS_CERTMAN_LOCAL_CA_PATH"], conf.CertMan.LocalCA.Path)
+	require.Equal(t, 720*time.Hour, conf.CertMan.LocalCA.Validity)
+	require.Equal(t, 2048, conf.CertMan.LocalCA.KeySize)
 	require.Equal(t, true, conf.Backup.Enabled)
 	require.Equal(t, 36*time.Hour, conf.Backup.Interval)
 	require.Equal(t, testEnv["GDS_BACKUP_STORAGE"], conf.Backup.Storage)
 	require.Equal(t, 7, conf.Backup.Keep)
+	require.True(t, conf.Reissuance.Enabled)
+	require.Equal(t, 12*time.Hour, conf.Reissuance.Interval)
+	require.Equal(t, 600*time.Hour, conf.Reissuance.AdminNoticeBefore)
+	require.Equal(t, 480*time.Hour, conf.Reissuance.ReminderBefore)
+	require.Equal(t, 120*time.Hour, conf.Reissuance.ReissueBefore)
+	require.True(t, conf.Archive.Enabled)
+	require.Equal(t, 6*time.Hour, conf.Archive.Interval)
+	require.Equal(t, 168*time.Hour, conf.Archive.Retention)
 	require.Equal(t, testEnv["GOOGLE_APPLICATION_CREDENTIALS"], conf.Secrets.Credentials)
 	require.Equal(t, testEnv["GOOGLE_PROJECT_NAME"], conf.Secrets.Project)
 	require.Equal(t, testEnv["GDS_SENTRY_DSN"], conf.Sentry.DSN)
@@ -223,6 +253,10 @@ func TestRequiredConfig(t *testing.T) {
 	require.Equal(t, testEnv["GDS_ADMIN_OAUTH_GOOGLE_AUDIENCE"], conf.Admin.Oauth.GoogleAudience)
 	require.Len(t, conf.Admin.TokenKeys, 2)
 	require.Len(t, conf.Admin.Oauth.AuthorizedEmailDomains, 3)
+
+	// No users should be superusers unless they are explicitly assigned the role
+	require.Empty(t, conf.Admin.Roles)
+	require.Equal(t, "readonly", conf.Admin.DefaultRole)
 }
 
 func TestEmailConfigValidation(t *testing.T) {
@@ -247,6 +281,63 @@ func TestEmailConfigValidation(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCertManConfigValidation(t *testing.T) {
+	// Sectigo is the default certificate authority
+	conf := config.CertManConfig{}
+	require.NoError(t, conf.Validate())
+
+	conf.Authority = "foo"
+	require.EqualError(t, conf.Validate(), `invalid configuration: "foo" is not a valid certificate authority`)
+
+	conf.Authority = "local"
+	require.EqualError(t, conf.Validate(), "invalid configuration: path to the local ca is required")
+
+	conf.LocalCA.Path = "fixtures/certs/ca.gz"
+	require.EqualError(t, conf.Validate(), "invalid configuration: local ca certificate validity must be positive")
+
+	conf.LocalCA.Validity = 8760 * time.Hour
+	conf.LocalCA.KeySize = 1024
+	require.EqualError(t, conf.Validate(), "invalid configuration: local ca key size must be at least 2048 bits")
+
+	conf.LocalCA.KeySize = 4096
+	require.NoError(t, conf.Validate())
+}
+
+func TestReissuanceConfigValidation(t *testing.T) {
+	// A disabled reissuance manager is not validated
+	conf := config.ReissuanceConfig{}
+	require.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: reissuance interval must be positive")
+
+	conf.Interval = 24 * time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: reissue before must be positive")
+
+	conf.AdminNoticeBefore = 240 * time.Hour
+	conf.ReminderBefore = 480 * time.Hour
+	conf.ReissueBefore = 120 * time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: reissuance admin notice must precede the reminder which must precede the reissuance")
+
+	conf.AdminNoticeBefore = 720 * time.Hour
+	require.NoError(t, conf.Validate())
+}
+
+func TestArchiveConfigValidation(t *testing.T) {
+	// A disabled archive manager is not validated
+	conf := config.ArchiveConfig{}
+	require.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: archive interval must be positive")
+
+	conf.Interval = 24 * time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: archive retention must be positive")
+
+	conf.Retention = 720 * time.Hour
+	require.NoError(t, conf.Validate())
+}
+
 func TestAdminConfigValidation(t *testing.T) {
 	conf := config.AdminConfig{
 		Mode: "invalid",
@@ -264,6 +355,16 @@ func TestAdminConfigValidation(t *testing.T) {
 	require.EqualError(t, conf.Validate(), "invalid configuration: token keys required for enabled admin")
 
 	conf.TokenKeys = map[string]string{"keyid": "path/to/key.pem"}
+	require.EqualError(t, conf.Validate(), `invalid configuration: default role: unknown role ""`)
+
+	conf.DefaultRole = "readonly"
+	conf.Roles = map[string]string{"jane@example.com": "superuser", "jon@example.com": "janitor"}
+	require.EqualError(t, conf.Validate(), `invalid configuration: role for jon@example.com: unknown role "janitor"`)
+
+	conf.Roles["jon@example.com"] = "reviewer"
+	require.EqualError(t, conf.Validate(), "invalid configuration: pending action timeout must be greater than zero")
+
+	conf.PendingActionTimeout = 72 * time.Hour
 	require.NoError(t, conf.Validate())
 }
 
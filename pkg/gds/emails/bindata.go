@@ -1,13 +1,17 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// deliver_certs.html (1.591kB)
-// deliver_certs.txt (1.274kB)
+// certificate_revoked.html (1.139kB)
+// certificate_revoked.txt (863B)
+// deliver_certs.html (2.014kB)
+// deliver_certs.txt (1.678kB)
 // expires_admin_notification.html (1.11kB)
 // expires_admin_notification.txt (816B)
+// reissuance_csr_required.html (1.147kB)
+// reissuance_csr_required.txt (883B)
 // reissuance_reminder.html (2.042kB)
 // reissuance_reminder.txt (1.705kB)
-// reissuance_started.html (1.28kB)
-// reissuance_started.txt (981B)
+// reissuance_started.html (1.652kB)
+// reissuance_started.txt (1.334kB)
 // reject_registration.html (601B)
 // reject_registration.txt (476B)
 // review_request.html (1.225kB)
@@ -82,7 +86,47 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _deliver_certsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x54\x4d\x6f\xe4\x36\x0c\x3d\xd7\xbf\x82\x0d\x7a\x68\x81\xc4\xc6\xee\x31\x70\x8d\x6e\x37\xbb\xed\xa0\x8b\x74\x91\x99\x16\xe8\x91\x23\x71\xc6\x6a\x24\x51\xa5\xe8\x99\x7a\x17\xf9\xef\x0b\xd9\xf3\x91\x0c\x72\xb3\x25\x3e\xf2\xf1\xe9\x91\x6d\xea\x7e\x27\xef\x19\xbe\x7e\x85\xfa\x1e\x03\xc1\xd3\xd3\x75\xdb\xa4\xae\xaa\xda\xd4\xfd\xc3\x83\xc0\xea\x61\xb1\x7c\x07\x91\x74\xcf\xf2\x08\x42\x5b\x97\x55\x50\x1d\x47\xe8\x31\xc3\x9a\x28\x02\xa6\x24\xbc\x23\xfb\x3d\x4c\x10\x96\x2d\x46\xf7\xe5\x22\x68\x2b\x18\x95\x6c\xe5\x2c\x45\x75\x3a\x82\x21\x51\xb7\x71\x06\x95\x32\xec\xd0\x3b\x8b\xea\xe2\x16\xc6\x92\x23\x50\x58\x93\xe4\xde\x25\x50\x06\xd6\x9e\x8e\x54\x0e\x37\xb0\x73\x08\xda\xd3\x7c\x5a\xfd\xe6\x79\x8d\x1e\xee\x9c\x90\x51\x96\xb1\x86\x77\xaa\x68\x7a\xb2\x05\xaf\xbd\xcb\x40\x01\x9d\x07\x14\x82\xcf\x7f\xbc\x5f\xbe\x79\x0b\x14\x8d\x8c\x49\xc9\xbe\xa4\x92\x4b\x3c\x6a\xe1\x51\x19\x8c\xe0\x42\xf2\x14\x28\xea\xb9\x1c\x24\x61\x65\xc3\x1e\x86\x5c\x28\x87\xd5\xa7\x25\xec\x9d\xf6\x07\xa6\x47\xb9\x8e\x5c\x95\x81\xfe\x37\x3d\xc6\x2d\x55\x2b\xc1\x1d\x79\x78\x18\x3c\x81\xe1\x90\xbc\xc3\x68\x08\x5c\xdc\xb0\x84\x49\xb3\xfa\xf4\x02\xab\x9e\x20\x89\x0b\x28\x23\x58\x52\x74\x3e\x03\x6f\x66\x85\xec\xb1\x55\xa0\xa8\x32\x4e\x8d\x61\x86\x0d\x7b\xcf\xfb\x7c\x7b\xc8\x31\xf8\xae\xfa\xae\xf5\xae\x6b\xb3\x0a\xc7\x6d\xb7\xb8\xbb\x6d\x9b\xc3\xf7\xf4\xec\x7f\x2f\xee\xe0\xe9\xa9\x6d\xbc\xeb\x2a\x80\xe7\xa1\x0f\xd3\x63\x93\x90\x3d\xeb\x7a\x01\x3e\x87\x9c\x22\x4e\xc9\x5e\x94\x7d\xcf\x21\x70\x84\xe2\xb1\x8b\x14\xf3\xcd\xc1\x7c\xaf\x20\x97\x24\x0e\x3d\xdc\x0f\x45\xca\x0b\xec\x7c\x37\x5f\xbd\x8e\xfe\x10\x6d\x62\x17\xf5\x02\x78\x3c\x3e\x81\xda\xa6\x28\x35\x69\xce\x60\x69\x32\xc6\xac\xf3\x73\x6f\x5c\x43\x4b\xa1\x1b\x79\x80\xbd\xf3\x1e\x22\x15\x7b\xf5\x27\x43\x25\xcc\x79\xcf\x62\xdb\x86\x42\x77\x36\x91\x90\x21\xb7\x23\x0b\xfb\x9e\x62\x39\x81\x8d\x93\xac\x90\x87\x75\x70\x5a\xfc\x37\x15\x7a\x3e\x5b\x35\x3c\xa3\x51\x2a\x0c\xf1\x8b\x4b\xe9\xd2\xaa\x1c\xcb\x65\x65\x38\x04\x8c\x16\xbc\x8b\x74\x3d\x15\x28\xbe\x1d\x32\x41\x6b\xd8\x52\xc7\x89\x62\xce\xbe\x6d\xa6\xbf\x67\x2e\x81\x1f\x4f\xbd\xac\x8b\xd3\x38\x4c\xe3\x50\xec\x1a\x95\x64\xe6\x75\xec\xea\xa7\xa3\xa7\x92\x50\xf7\x03\x1c\x92\x42\x7a\x34\xf9\xcd\x5b\xb8\x71\x11\x16\xf7\x1f\x17\x9f\x3e\xd4\xa9\xfc\xf2\xa0\xf0\xe7\x5f\xab\xe9\xc0\x88\xc2\x4d\x64\x4b\xb9\x6d\x0a\x78\xd2\xf9\x23\x0b\x04\x96\x17\xce\x2f\x0d\xb9\xa8\xb4\x95\x79\x15\x4c\x23\x75\x9e\xba\xc3\x58\x5d\x43\xf2\x84\x99\x20\x13\x01\x0f\x52\x59\x36\x43\x19\xd0\x39\x07\x2a\xb4\x08\xbd\xd0\xe6\xe7\xab\x5e\x35\xe5\xdb\xa6\x51\x71\x19\x6b\x4b\xbb\xe6\xaa\x3b\x7d\xb7\x0d\x76\x35\x2c\xa6\x79\x82\x1e\x77\x04\x18\xc7\xea\xbf\x81\x72\xc9\x93\x67\x25\x03\x8e\x60\x38\x2a\x1a\x85\x21\xbf\x48\x5e\xf6\x89\xf2\x6d\x1e\x52\x62\xd1\x5f\x84\x67\x02\xe8\x6b\xc7\x57\xdd\xab\xc7\xa5\x24\xb0\x54\xff\xb2\x2b\x0f\x54\xfa\x2d\x12\xbf\xce\xf7\xa6\x34\x9b\x13\x1a\xaa\xb3\x47\xf3\x58\x1b\x0e\x57\xdd\xb2\x7c\x42\x59\x26\x91\xfc\xdc\xc2\xe7\x59\x0e\xcb\x10\x59\x41\x28\xf9\xf1\xb0\x1e\xfc\xf8\x72\xfd\x9d\x57\xcb\xaf\x94\x15\x1e\x68\x8b\x62\xf3\x75\xbb\x16\x68\xba\x6a\x56\xf9\x72\x95\xc2\x92\x64\xe7\x0c\xc1\x8a\x30\x4c\xf8\x6f\x01\x00\x00\xff\xff\xba\xd3\x71\x5d\x37\x06\x00\x00")
+var _certificate_revokedHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x93\x4d\x8f\xda\x30\x10\x86\xef\xfc\x8a\xd1\x9e\x51\xb8\xaf\xd2\xa8\x2d\x54\x2d\x52\xb5\xaa\x00\x55\xea\xd1\x49\x06\x70\x71\x3c\xe9\x78\x02\xa2\xab\xfd\xef\x1d\x9b\x2c\xb0\x29\xb4\x97\x28\xb1\xe7\x7d\xe7\xeb\x49\xde\x16\x5f\xd0\x39\x82\xe7\x67\xc8\x9e\x4c\x83\xf0\xf2\x32\xce\x27\x6d\x31\x1a\xe5\x6d\xb1\xda\x22\xac\x16\xf3\xe5\x07\x98\xd7\xe8\xc5\xca\x11\xa6\xc8\x62\xd7\xb6\x32\x82\x60\x43\xe8\xb0\x06\x21\xc8\xb1\x29\xa2\xc3\x94\x9a\x86\x7c\xef\x93\x4f\xf4\x14\xca\x23\xc8\xd9\xe6\xb3\xa3\xd2\x38\x98\x59\xc6\x4a\x88\x8f\xb0\x44\xde\xdb\x0a\x61\x6b\x02\x94\x88\x1e\x18\xf7\xb4\xc3\x3a\x83\x98\x9b\xd1\x04\xf2\x49\x5f\x5d\xe5\x3d\x68\x70\x1f\xa7\x35\x80\x7e\xad\x49\x7b\x38\x84\xc7\xbe\xf2\xd2\x51\xb5\xfb\xd5\x91\x60\xaa\x6a\x71\xb2\x89\x15\x5d\xdd\xa4\x0e\x7f\x50\xc7\x40\xbc\x31\xde\xfe\x36\x62\x35\xaa\x32\x1e\x3c\x81\x23\xbf\x41\x86\x2e\x60\x4a\xff\x9a\xee\xba\x0c\xed\xbb\xd2\x7e\x3b\xdf\x57\x65\x65\x0b\xa4\xc1\x0c\x0d\x36\x25\x72\x00\x5a\x5f\xf5\xee\x51\x0e\xc4\xbb\x0c\xa6\xd4\x79\x41\x6e\x8d\x5a\x61\x50\x9d\x73\xea\xff\x53\x27\x02\xcd\xea\xeb\x52\x4d\xbd\xd7\x0f\x2d\x26\xa8\xdc\x08\xb4\x8c\x41\xc7\x7f\xaf\x90\xec\xcd\xbe\x6a\x14\x63\x5d\xca\x7d\x8c\xcd\xd5\xe7\x59\xab\x85\x3e\x8d\xaf\xef\x76\x64\x18\x6f\x4c\xb3\x73\xc5\x08\x20\x77\xb6\xc8\x83\xb0\xce\xa5\x98\xcf\xf4\xae\x7f\x4f\xe4\x7c\x9f\xcf\xd2\x78\x35\x66\x10\xba\xc0\x8d\x0d\xda\xad\x66\x3a\x6f\x7d\x20\xbe\x84\x5c\xb8\xb8\x6d\x76\xc2\x0b\x22\x5f\x03\x8f\x01\x78\x7f\x4b\x15\x34\xab\xe4\x3d\x75\x71\x33\x03\xf1\xe9\xee\x74\x75\x47\xfe\xc9\xd7\x2d\x59\x2f\x03\xe5\xeb\xf1\x59\x95\x4f\xe2\xb4\xe2\x2e\xe6\x69\xfe\x0a\xb5\xb3\xb8\xc7\x7f\x33\xec\x01\x99\x29\x82\x98\x34\x1e\xf5\xd0\xe3\xe1\x5a\x10\xc6\xd0\x3a\xe5\x18\x23\x1e\x62\x94\x95\x4e\xc9\x17\xc8\x0d\x6c\x19\xd7\xef\x1e\x1a\xdd\xba\xd0\x63\xe8\xda\x96\x58\xde\x33\x49\xe2\xd9\xb8\xcc\xd2\x43\x71\xf3\x38\x9f\x98\x22\x83\x6f\x27\xdb\x9a\x14\x7b\xd1\x9a\x5a\x77\xec\xa1\xd1\x17\x65\x5c\xb6\xfa\x8f\x61\xb4\xbf\x80\xf6\x11\x83\x80\xee\xcd\x70\x1d\xc6\x79\xc9\x30\x29\x46\xff\xf9\xc3\x57\x68\x9a\xa4\xff\x03\x5d\x08\x10\x74\x73\x04\x00\x00")
+
+func certificate_revokedHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_certificate_revokedHtml,
+		"certificate_revoked.html",
+	)
+}
+
+func certificate_revokedHtml() (*asset, error) {
+	bytes, err := certificate_revokedHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "certificate_revoked.html", size: 1139, mode: os.FileMode(0644), modTime: time.Unix(1792160081, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8f, 0x78, 0xb8, 0x70, 0x71, 0x17, 0x83, 0x19, 0xab, 0x87, 0x29, 0x2c, 0xa3, 0xc7, 0x48, 0x3d, 0x70, 0x0e, 0x3e, 0x9e, 0x5d, 0xda, 0x1c, 0x10, 0x01, 0x21, 0x17, 0x31, 0x3e, 0x9e, 0x8e, 0x03}}
+	return a, nil
+}
+
+var _certificate_revokedTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x52\xcb\x6e\xdb\x40\x0c\xbc\xef\x57\xf0\x03\x0c\x7d\x80\x4f\x6d\xed\xa2\x15\x50\x18\x85\x6d\x04\xc8\x71\x2d\xd1\xf6\x26\x2b\x52\xe0\x52\x36\xd4\xc0\xff\x5e\xae\xd6\x71\x5c\x14\x49\x2e\x82\xc8\xe5\xf0\x31\x33\x3f\x31\x46\x86\x97\x17\xa8\x56\xbe\x43\xb8\x5c\x66\xce\x6d\x8f\x08\xdb\x75\xbd\xf9\x0a\x75\x8b\xa4\x41\x47\x58\xa0\x68\xd8\x87\xc6\x2b\x42\x48\x69\xc0\x16\xb4\xc0\x16\xdc\x75\x4c\x57\x30\xec\x46\xd0\x1b\xfa\x47\xe4\x9d\x8f\xb0\x0c\x82\x8d\xb2\x8c\xb0\x41\x39\x85\x06\xe1\xe8\x13\xec\x10\x09\x04\x4f\xfc\x8c\x6d\x05\x79\xa4\xa0\x4f\x4c\x13\xbe\xb9\x1b\x77\xb6\xe2\x6b\x9d\x8d\x06\x8b\xf6\x6c\x3b\x9f\xd3\xdc\xb9\xbc\xc0\xba\xc0\x2e\x17\xe7\x1e\x79\x10\x60\x39\x78\x0a\x7f\xbc\x06\xcb\x36\x9e\x80\x18\x22\xd3\x01\x05\x86\x84\x53\xfb\xd7\x76\xf7\x63\xec\x9c\xc6\x4e\x19\xe8\x3a\x35\xe8\x11\xd8\x8a\x05\x3a\xec\x76\x28\x09\x78\x7f\x77\x1b\xa1\x9e\x59\x9e\x2b\x58\xf0\x40\x8a\xd2\x7b\x6b\x85\xc9\x70\x31\x5a\xff\x27\xbb\x18\xba\xed\xaf\x8d\x35\x25\xb2\xc0\x96\x49\x06\xf7\x0a\xbd\x60\x32\x56\xdf\x5b\xa4\x2a\xfc\xb7\xa8\x3e\xc4\x69\xe8\x98\xaf\x6a\x6f\x24\x1a\xd6\xbe\x9e\xda\x77\x4f\xf1\x82\xff\xd2\x54\x2f\xe7\x93\x56\x0f\xf5\x32\xf3\xb4\xc6\x43\x48\xb6\xb3\xc1\x6e\xda\x94\x82\xb7\x97\x37\xd1\x0c\x50\x34\x86\x2c\xf2\xfc\x7f\xd1\x9d\xc9\x1a\x4c\xe7\xd5\x90\x79\x2a\x05\x25\x55\x32\xb9\xe4\x3b\xb5\x3d\x07\xd2\xf2\xfa\x1a\x4d\xa2\xd5\xd3\x85\xe6\x87\x18\xf0\x84\x1f\xcb\x4f\x80\x22\x9c\x35\x9e\x30\x84\x96\x24\x3c\xdf\x03\xd2\x0c\xfa\x68\x96\xc0\xcc\xbc\x7a\x93\x61\x30\xd3\x28\xa4\xa1\xef\x59\xf4\x8b\xb0\x4e\xd6\xf0\xb1\x0a\x5c\xc1\xef\x52\xdb\xb2\xd9\x44\x6d\x50\x1f\xc7\x2b\xd7\xf6\x63\x9e\xd0\xa3\x79\x0e\x3b\xd3\xc2\x84\xf9\x86\x49\xc1\x38\xf2\xd2\xa6\x99\xfb\xc4\xe4\x5b\xf4\x9d\xfb\x0b\x13\x69\xf5\xd7\x5f\x03\x00\x00")
+
+func certificate_revokedTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_certificate_revokedTxt,
+		"certificate_revoked.txt",
+	)
+}
+
+func certificate_revokedTxt() (*asset, error) {
+	bytes, err := certificate_revokedTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "certificate_revoked.txt", size: 863, mode: os.FileMode(0644), modTime: time.Unix(1792160081, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6d, 0x25, 0x3b, 0xf4, 0x69, 0x06, 0x72, 0x9f, 0x3e, 0x2b, 0x28, 0x0d, 0x68, 0x85, 0x8d, 0x99, 0x2f, 0x51, 0xcf, 0x6d, 0x58, 0xa8, 0x5e, 0xc0, 0x37, 0x7f, 0x7d, 0x02, 0xb4, 0x5d, 0x8b, 0x2d}}
+	return a, nil
+}
+
+var _deliver_certsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x55\x4d\x8f\xdb\x36\x10\x3d\x57\xbf\x62\xb2\xe8\x21\x01\xbc\x12\xda\xe3\x42\x15\x92\xee\x26\xa9\xd1\x60\xbb\x58\xbb\x01\x72\xa4\xa5\xb1\xc5\x2c\x45\xb2\x24\x65\xd7\x09\xf2\xdf\xfb\x48\x59\xf2\x07\xdc\x8b\x21\x8b\x33\x8f\x33\x6f\xde\x1b\x95\xb6\xfa\x83\x95\x32\xf4\xfd\x3b\xe5\x8f\xa2\x63\xfa\xf1\x63\x56\x16\xb6\xca\xb2\xd2\x56\x5f\x4c\xef\x68\xf9\x3c\x5f\xbc\x23\xcd\x61\x67\xdc\x0b\x39\xde\x48\x1f\x9c\x08\xd2\x68\x6a\x85\xa7\x15\xb3\x26\x61\xad\x33\x5b\x6e\x5e\x51\x4a\x31\x6e\x23\xb4\xfc\x76\x11\xb4\x71\x42\x07\x6e\x32\xd9\xb0\x0e\x32\xec\xa9\x66\x17\xe4\x5a\xd6\x22\xb0\xa7\xad\x50\xb2\x41\x86\xde\xd0\x3e\x62\x74\xdc\xad\xd8\xf9\x56\x5a\x0a\x86\x4c\x68\x79\x2c\xe5\x70\x42\x5b\x29\x08\xaf\x87\xb7\xd9\x47\x65\x56\x42\xd1\x83\x74\x5c\x07\xe3\xf6\x79\x6c\x49\xae\x29\x7f\xea\x57\x4a\xd6\xf7\xad\x90\x1a\xcd\xbd\x0b\x41\xd4\x2d\x37\x11\x34\xb4\xd2\x13\x77\x42\x2a\xc2\x43\x84\xb2\x29\xf6\xb4\xb0\xac\x4e\x89\xd2\xfb\x1e\x49\x6b\x67\xba\x14\x78\x12\x41\x5e\x6e\x74\x2c\xdb\xf1\x3f\x3d\xfb\x40\xaf\xef\x17\xcf\x6f\x10\x25\x42\xec\x84\x7c\xbf\xea\x64\x40\xe3\xa8\x87\x95\xe7\x93\x22\xb2\xf3\x22\x84\x63\x7a\xfa\xf3\x7e\xf1\xcb\xaf\xc4\xba\x76\x7b\x8b\xa4\x33\x92\x22\x82\x6e\x00\x40\xde\x1c\x2f\xa8\x05\xea\xeb\xac\xe2\x0e\xbc\xc6\xea\xb2\x81\x27\x8c\x24\x98\xda\x28\xea\x7d\x2c\xaf\x5b\x7e\x5a\xd0\x4e\x86\xf6\x40\xe6\x38\xd1\x91\x4e\xd4\xc2\xff\xa2\x5b\xbd\x01\xa5\x4e\x6c\x59\xd1\x73\xaf\xd0\xaa\x01\xb6\x14\xba\xe6\x4c\xea\xb5\x71\x5d\x1a\x6b\x3e\x89\x64\x19\x79\x73\xb2\x13\x6e\x4f\x0d\x07\x34\xe2\xc9\xac\x87\x21\x36\xe3\x34\x50\x77\xc0\x6f\xec\x10\x72\x58\x1b\x48\x6e\xe7\xef\x0e\x18\xbd\xaa\xb2\x9f\x4a\x25\xab\x12\xca\x32\x7a\x53\xcd\x1f\x70\x74\x78\x4e\xca\xfc\x3c\x7f\x40\xd7\x65\x81\x98\x8c\xe8\x34\xf4\x39\xe9\x91\x1d\x98\x9a\x46\x7f\x91\x7c\x0c\x99\x22\x26\xb0\xb3\x6b\xef\x4d\xd7\x41\xb0\xd1\x06\x17\x10\xc3\xc9\xc1\x1f\x57\x32\x17\xec\x24\xc4\xf7\xd8\x47\x2a\x2f\x72\x87\xb3\xe1\xe8\x7a\xf6\x7b\xdd\x58\x23\x75\xb8\x48\x1c\x5f\x4f\x49\x65\x11\x99\xca\xae\xea\x7a\x9c\xc4\x91\x71\xcf\x6e\x2b\x6b\x4e\xfe\xd3\x26\xd0\x86\x35\xc3\xb7\x20\xca\x38\xf2\x08\xc1\x93\x88\x93\xdb\x46\x11\xbf\xf0\x1e\x63\x71\x51\x3e\xfe\x4c\xde\x3e\xcf\x96\x06\x12\xe2\x78\xd4\xa5\x98\xa8\xa4\x19\x59\x21\x53\x38\xf5\xfa\x9b\xb4\xf6\x5c\xaa\x34\xd8\x26\xc9\xad\xe4\xae\x0a\x83\x48\xa6\xab\x46\xf1\x66\x00\x4e\x5e\xac\x1d\xc7\xb3\xa4\x1a\xf8\xa7\x2c\x90\x34\x68\xec\x68\x9b\xd4\xa3\x81\xc8\x92\x37\x86\xd8\xd3\x4a\x67\xe9\xaa\x68\x89\x9d\x54\x0a\x02\x8f\xd0\xed\xe4\x29\x2b\xbc\x87\xe2\x9b\x84\x7d\xac\x00\x7c\xb1\xc4\xee\xa2\x5d\x8b\x25\x15\xb3\xd7\xd2\xc1\xc6\x93\x6f\x87\x8b\x4e\x17\x5f\x4e\x27\x65\xfc\x1f\x05\x70\x81\x4e\x6e\x84\x81\x3a\x01\xdb\x2a\xa9\x79\x36\x39\x36\x32\x5a\xd6\xa6\xe1\xca\x58\xd6\xde\xab\xb2\x48\xff\x4e\xfc\x41\xaf\xa7\x5e\x56\x91\x3e\x18\x31\x0c\x6c\xc1\x4d\x10\x53\xaa\x6b\xec\xea\xcd\xe8\x26\xeb\xb8\xfa\x99\x0e\xa0\x64\x5f\x6a\x8f\xde\x6f\x31\x8c\xf9\xe3\x87\xf9\xa7\xf7\xb9\x8d\x7f\x4d\x1f\xe8\xaf\xbf\x97\xe9\x45\xed\x02\xdd\x6a\xdc\xed\x81\x80\xe4\x6c\x5a\x33\xc9\xdf\x1f\xe2\xc4\xa1\x16\x3a\x71\x7f\x6c\x0d\xca\xe4\x8d\x1b\x36\x76\x9a\xf3\xb4\x8b\xc7\xd5\x02\x8d\x28\x16\xe8\xd3\x33\x13\x6a\xcd\x1a\x53\xf7\x71\x49\x0d\x18\xe0\xbf\x14\xd4\x3a\x5e\xff\x76\xd3\x86\x60\xfd\x5d\x51\x04\x27\xbd\xc8\x1b\xde\x16\x37\xd5\xf4\x5c\x16\xa2\xca\x69\x9e\x76\x0a\xe4\xbc\xc5\x0e\xd1\xfb\x2c\x2d\x5b\xe0\xf8\x81\xd3\x4e\xe0\x83\x62\x80\x5d\x07\x70\x7b\x06\x1e\x97\x6b\x30\x77\xbe\xb7\xd6\xb8\xf0\x16\x6b\x31\x15\x20\x54\x2e\xcd\x4d\x75\xf5\x75\xbc\x12\x3e\xc9\xbe\xc2\x80\x11\x2e\xb6\x0c\xb2\xaf\xd7\x7b\x1b\x9b\xf5\x56\xd4\x9c\x7b\x25\xea\x97\x1c\x13\xbf\xa9\x16\xf1\x31\xfa\x40\x6b\x56\x43\x0b\x4f\x03\x1d\x8d\x49\x86\x74\x6c\xd5\xfe\x60\x58\x3c\x9c\x7d\x0b\x8e\xeb\xf5\xf7\xf8\x49\xc1\x0a\x13\xae\xf1\xb3\x72\xe5\xa8\xa8\x0e\xfb\xfd\xf2\x8b\x47\x8b\x83\xe3\x97\x2c\xba\x94\xff\x1f\xf0\xc1\xa7\x44\xde\x07\x00\x00")
 
 func deliver_certsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -97,12 +141,12 @@ func deliver_certsHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "deliver_certs.html", size: 1591, mode: os.FileMode(0644), modTime: time.Unix(1658847751, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd0, 0x1e, 0x2, 0xe1, 0xbe, 0xdf, 0x98, 0xd1, 0x19, 0x6e, 0x46, 0xeb, 0xf2, 0x2c, 0xef, 0x58, 0x78, 0x47, 0x12, 0xc4, 0x43, 0x70, 0x47, 0x70, 0x57, 0xcd, 0x97, 0xca, 0xf, 0x37, 0xa0, 0xa0}}
+	info := bindataFileInfo{name: "deliver_certs.html", size: 2014, mode: os.FileMode(0644), modTime: time.Unix(1792160932, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x89, 0xe6, 0xe3, 0xb9, 0xb2, 0x2d, 0x69, 0x60, 0xcc, 0x22, 0x68, 0x58, 0x3b, 0x6d, 0xc0, 0x84, 0xdd, 0x84, 0xf5, 0xc1, 0xb3, 0x10, 0x2f, 0x18, 0xe6, 0x2d, 0x94, 0xbb, 0x08, 0xd0, 0xc1, 0xe1}}
 	return a, nil
 }
 
-var _deliver_certsTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x54\xc1\x8e\xdb\x46\x0c\xbd\xeb\x2b\x5e\x6f\x2d\xe0\xd5\x22\x39\xee\xa9\x69\x36\x69\x8d\x06\xdb\x60\xed\x16\xe8\x91\x1e\xd1\xd6\x74\x67\x86\x53\x0e\x25\x57\x09\xf6\xdf\x8b\x91\xec\xb5\x37\xc8\x51\x24\x87\x7c\x7c\xef\x51\xbf\x71\x08\x82\xaf\x5f\xd1\x3e\x50\x64\x3c\x3f\xaf\x9a\xe6\x6f\x19\x14\xdb\xc7\xf5\xe6\x1d\x12\xdb\x51\xf4\x09\xca\x07\x5f\x4c\xc9\xbc\x24\xf4\x54\xb0\x63\x4e\xa0\x9c\x55\x46\xee\x7e\xc0\xfc\x44\xf4\x40\xc9\x7f\xf9\xa6\xe8\xa0\x94\x8c\x3b\xf8\x8e\x93\x79\x9b\xe0\x58\xcd\xef\xbd\x23\xe3\x82\x91\x82\xef\xc8\x7c\x3a\x60\xaa\x3d\x22\xc7\x1d\x6b\xe9\x7d\x86\x09\xc4\x7a\x3e\x43\x39\x65\x30\x7a\x82\xf5\x7c\x8a\xfe\x1a\x64\x47\x01\xf7\x5e\xd9\x99\xe8\xd4\xe2\x9d\x19\xb9\x9e\xbb\xfa\xde\x7a\x5f\xc0\x91\x7c\x00\x29\xe3\xf3\xef\xef\x37\x6f\xde\x82\x93\xd3\x29\x57\x4c\xaf\xa0\x94\x5a\x4f\x56\x71\xc0\x51\x82\x8f\x39\x70\xe4\x64\x57\xe3\xb2\x8a\x89\x93\x80\xa1\x54\xc8\x71\xfb\x69\x83\xa3\xb7\xfe\x84\xf4\x4c\xd7\x19\xab\x09\xf8\x3f\xd7\x53\x3a\x30\xb6\x4a\x23\x07\x3c\x0e\x81\xe1\x24\xe6\xe0\x29\x39\x86\x4f\x7b\xd1\x38\x73\xd6\x36\xcd\xb6\x67\x64\xf5\x91\x74\x42\xc7\x46\x3e\x14\xc8\x7e\xa1\xa6\x3b\xef\x08\x4e\xa6\xd3\xbc\x11\x15\xec\x25\x04\x39\x96\xbb\xa6\x59\xdf\xdf\xcd\x4a\xfe\xb5\xbe\xc7\xf3\x73\xf3\x38\x6b\xc6\xca\xdd\x85\x9e\xa5\xe0\x92\x79\x49\xd4\x07\xef\x25\x46\x49\xa8\x46\x58\xea\x96\xc0\xc9\x18\xcd\x86\xd5\x53\xc0\xc3\x50\x77\x5b\x0a\x96\xd0\x12\xa9\x25\x1f\x52\x97\xc5\x27\x5b\xb2\xe7\xaf\x9a\x69\xb6\x82\x8e\x67\xde\x97\x6d\xae\xa9\x5f\xcd\x9c\x1f\x7d\x08\x48\x5c\x95\xeb\x5f\xb4\xca\x54\xca\x51\xb4\xbb\x48\xa3\xec\xd8\x8f\xdc\xe1\xd8\x73\x9a\x23\x7b\xaf\xc5\x50\x86\x5d\xf4\x56\x55\x9d\xfb\x5f\x3b\xb6\xc5\xd5\xf4\xda\x7c\x48\x5f\x7c\xce\xdf\x1a\x40\xd2\x9c\x74\x12\x23\xa5\x0e\xc1\x27\x5e\xbd\xb8\x61\x28\x0c\xc9\x9c\x4a\x09\x57\xac\xe3\xc7\x17\xe4\xbb\xaa\x9c\xc4\xd9\x57\x55\xf7\x64\xac\x0b\x94\xf3\x0e\x3f\xdd\x35\xcd\xb9\x45\x7e\x72\xe5\xcd\x5b\xdc\xf8\x84\xf5\xc3\xc7\xf5\xa7\x0f\x6d\xae\x9f\x32\x18\xfe\xf8\x73\x3b\x07\x9c\x1a\x6e\x92\x74\x5c\x9a\xe6\xa3\x28\xa2\xe8\x2b\xbb\x54\xbc\x3e\x19\x1f\x74\xb9\x9f\xd9\x87\x17\xab\x9e\xbc\xb8\x42\x0e\x4c\x85\x31\xfa\xe2\x0d\xb3\x91\xc4\x0d\xd5\xd7\x4b\x17\x32\xf4\x66\xb9\xdc\xdd\xde\x9a\xfa\x42\x6d\xc7\xe3\x6d\x8b\xf5\xec\x3a\xf4\x34\x32\x28\x4d\xf8\x77\xe0\x52\xeb\x4f\x62\x45\x9a\xe0\x24\x19\x39\xc3\x50\x6a\x93\x32\xe4\x2c\x6a\x3f\xab\x2c\x8d\x29\xb4\x5e\x20\x8a\x7f\xc4\x57\xfa\x2a\xdc\x3a\x7d\x13\xc8\x3d\xa1\x1e\x45\xe2\x80\x79\xe4\x4d\x05\x5a\x32\x39\x6e\x4b\xcd\xb6\x4e\x62\x8b\xcf\x0b\xee\x4e\x90\xc4\xa0\x9c\xc3\x74\xba\x81\x30\xbd\x3e\xee\xb6\x69\x7e\xe1\x62\x78\xe4\x03\x69\x57\x56\xcd\xf7\xff\x0d\xd8\xb0\x8e\xde\x31\xb6\x4c\xb1\xf9\x3f\x00\x00\xff\xff\xe9\xe3\x44\xe9\xfa\x04\x00\x00")
+var _deliver_certsTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x54\xc1\x6e\xdb\x38\x10\xbd\xeb\x2b\xa6\xb7\x16\x70\x14\x6c\x8f\x39\x6d\x9b\xb4\x5d\xa3\x45\x36\x88\xdd\x02\x7b\xa4\xa5\xb1\x35\x0d\x45\x72\x49\x4a\x5e\xb7\xe8\xbf\xf7\x0d\x65\xcb\xce\xa2\xbd\x18\x32\x67\x38\x7c\x6f\xde\x9b\xf9\x8b\xad\xf5\xf4\xfd\x3b\xd5\xf7\xa6\x67\xfa\xf1\x63\x51\x55\xff\xf8\x21\xd2\xfa\x71\xb9\x7a\x43\x8e\xf3\xde\xc7\x27\x8a\xbc\x93\x94\xa3\xc9\xe2\x1d\x75\x26\xd1\x86\xd9\x91\x09\x21\xfa\x91\xdb\x17\x54\xae\xf8\xb8\x33\x4e\xbe\xfd\x2f\x69\x17\x8d\xcb\xdc\x92\xb4\xec\xb2\xe4\x03\x35\x1c\xb3\x6c\xa5\x31\x99\x13\x8d\xc6\x4a\x8b\x1b\x6e\x47\x07\xad\xd1\x73\xbf\xe1\x98\x3a\x09\x94\x3d\xf9\xdc\xf1\x09\xca\x31\x42\xa3\x18\xc2\xf1\xf1\xf4\x83\xf5\x1b\x63\xe9\x4e\x22\x37\xd9\xc7\x43\xad\x5c\x64\x4b\xf5\xc3\xb0\xb1\xd2\xdc\x76\x46\x1c\x58\xbd\xc9\xd9\x34\x1d\x50\xa0\x68\xee\x24\x11\xf7\x46\x2c\xe1\x43\x4b\x85\x92\x7b\x09\x8c\x9a\x72\x51\x52\x1a\x70\x69\x1b\x7d\x5f\x12\x2f\x33\x92\xec\x9c\xc2\x8e\xfc\xef\xc0\x29\xd3\xcb\xdb\xd5\xe3\x2b\x64\x99\xac\x4c\x28\x0d\x9b\x5e\x32\x88\x03\x0f\xdb\xc4\xbf\x07\x61\x22\xd3\xc3\xc7\xdb\xd5\x1f\xaf\x89\x5d\x13\x0f\x41\xbb\x75\xd9\x24\xad\xe0\x5a\x14\xa0\xe4\xcf\x0f\x34\x06\xf8\xfa\x60\xb9\x47\x5f\x2f\x3a\x02\x49\xb2\x6f\xbc\xa5\x21\x29\xbc\x7e\xfd\x69\x45\x7b\xc9\xdd\xb1\x99\x27\x45\x4f\xed\x04\x16\xfe\x0f\x6c\xdd\x0e\x05\xa2\x19\xd9\xd2\xe3\x60\x41\xd5\xa3\xb6\x18\xd7\x30\x89\xdb\xfa\xd8\x17\x59\xeb\xaa\x5a\x6b\xc3\xa2\xf4\x26\x1e\xa8\xe5\x0c\x06\x89\xfc\x76\x52\xaf\x3d\xc9\x00\xc0\x19\xbf\x4a\x0d\x3e\xd8\x7a\x98\x6c\x9f\x6e\xaa\x6a\x79\x77\x53\xcc\xf6\x65\x79\x07\x3e\xd5\x63\xb1\x15\x47\x10\x9e\x15\x9c\x12\xce\x91\x39\xa0\x17\x6e\x7d\xdf\xc3\x5c\xea\xd5\x29\x6f\x3a\x38\x7a\xb7\x5a\x71\x14\xb8\xe1\x7e\x50\x6e\x53\xc2\x74\x34\x9d\x68\xca\x3b\xd7\x06\x2f\x2e\x4f\xd1\xd3\x3f\x8d\x54\xbf\x74\x8e\xd2\x3d\xd3\x4a\x1c\x47\x41\x4b\xd4\xdd\xce\x67\xda\xb1\x63\x4c\x05\xf0\xfb\x48\x09\x29\xf8\x32\xda\x9e\x51\x2d\xf2\xc4\x07\x70\x8f\x2a\x4e\x7a\x66\x9e\x54\xd3\xda\x43\x20\xd6\x50\x5f\x72\x54\xa7\x05\x05\x23\x25\x9d\x06\xf7\x4d\x42\x78\x6e\x84\xa3\x29\x8b\x98\x79\x52\x61\x7e\x66\xb6\x05\x8a\x16\x83\x35\x91\x35\x56\x64\x81\x33\xeb\xea\xec\x43\x3c\xdd\x72\x31\xda\x14\xbe\x04\xb6\x28\x45\xf6\x62\x2d\x9c\xa2\x95\xba\xd9\x9c\xc1\xa4\x04\xeb\xb4\xe7\xb7\xd0\x15\x16\xcc\x3f\xed\x3b\x0c\xba\x9e\x6c\x25\x62\x14\x66\xef\x4f\xf5\x2f\x97\x47\x21\x7e\x7a\xfd\x77\x44\x61\x28\x37\xcd\x1b\xd4\x35\xb0\xbe\x15\xc7\x8b\xd9\xf5\xda\x37\x1f\xd8\xa5\x64\x2f\xdc\x45\x2f\x67\xe4\x1b\xed\x0d\xfc\x9b\xa7\x56\xc0\x8b\xd0\xbe\x40\x39\x71\x78\x05\x2f\x9e\x4a\x84\xa7\x26\x81\xde\x15\x5a\xbb\xbc\x7f\xbf\xfc\xf4\xae\x0e\xfa\xd7\x0f\x99\xfe\xfe\xbc\x2e\x07\x4d\xcc\x74\xe5\x7c\xcb\xa9\x9a\x87\xb1\x7a\xaf\xaa\x41\xf1\xcb\xf9\x50\xe0\xb0\x13\xef\xe2\xb4\xd3\x66\xad\x9e\xad\x53\xe8\x6c\xd9\x80\xc5\x28\x49\x32\x95\xc9\xf1\xcd\xa0\x83\x3c\x55\x41\x7f\xbb\x9c\x43\xba\xb9\xbe\xce\x51\x92\xa9\x5b\x1e\xaf\x6b\x5a\x96\x31\x83\xf9\x46\x8c\x95\x3b\x50\x59\x3c\xc8\x3f\xaa\xd6\x1b\x2c\x57\x8f\x1a\x4d\x46\x8f\xb4\x48\x1a\x42\xf0\x31\xff\x89\x95\x50\x0a\x1b\x5b\x8b\x57\xab\x7e\x85\xe9\x35\x47\x11\xe3\xf5\x95\x35\xcd\x93\xda\xcb\x39\xcc\x7f\x79\xf2\x4a\x81\xa6\x60\x1a\xae\x93\x46\x6b\x68\x51\xd3\xc3\x84\xbb\xf5\xc5\xfd\x91\x83\x3d\x1c\xa7\x03\x1f\xcf\xd6\x1a\x36\xc5\x5b\x5d\x8b\x18\x64\x13\xdb\xb4\xa8\x7e\xbd\xaf\x69\x75\x9c\xa8\x35\x9b\xbe\xfa\x09\x39\x6f\xa0\x86\x8e\x06\x00\x00")
 
 func deliver_certsTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -117,8 +161,8 @@ func deliver_certsTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "deliver_certs.txt", size: 1274, mode: os.FileMode(0644), modTime: time.Unix(1658847751, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x61, 0x39, 0xab, 0xc4, 0xc3, 0xc8, 0xb4, 0x7c, 0x13, 0xa, 0x1, 0x4c, 0xdb, 0xda, 0xec, 0x53, 0xb0, 0xb0, 0xc5, 0x15, 0x68, 0xce, 0xf1, 0xa3, 0x41, 0x22, 0x3d, 0x7, 0x33, 0x5, 0x1c, 0xcd}}
+	info := bindataFileInfo{name: "deliver_certs.txt", size: 1678, mode: os.FileMode(0644), modTime: time.Unix(1792160930, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0e, 0x9d, 0x67, 0xb3, 0xd3, 0x8f, 0x4e, 0xa6, 0x2e, 0x1e, 0x44, 0x7b, 0x51, 0x84, 0x27, 0xae, 0x2a, 0x61, 0x7a, 0x05, 0x08, 0x69, 0xe3, 0x18, 0xb0, 0xa4, 0x40, 0xbd, 0x27, 0xde, 0xfd, 0xe3}}
 	return a, nil
 }
 
@@ -162,6 +206,46 @@ func expires_admin_notificationTxt() (*asset, error) {
 	return a, nil
 }
 
+var _reissuance_csr_requiredHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x54\x4d\x6f\xe2\x40\x0c\x3d\x2f\xbf\xc2\xea\xa9\x2b\xad\xe0\xde\x66\x23\x51\xa8\x76\x91\x56\x55\x05\xb4\xd2\x1e\x4d\xc6\xa1\xa3\x4e\xc6\xd9\xf9\x80\xa2\xaa\xff\x7d\x3d\x49\x1a\x20\xa2\x17\x34\xb1\xfd\xfc\x6c\x3f\x9b\xac\xce\x7f\x93\x31\x0c\xeb\xe5\x62\x35\x85\xa9\xaa\xb4\xf5\x3f\xb2\x49\x9d\x8f\x46\x59\x9d\xaf\x5f\xa8\xf3\x2c\x14\xd9\xa0\xc3\x01\x66\xe4\x82\x2e\x75\x81\x81\xa0\x64\x07\xef\xef\x30\x9e\x71\x55\xb1\x7d\xc0\x8a\xe0\xe3\x03\xe8\xad\xd6\x8e\x3c\xb0\x6d\x9c\xf7\xe9\x13\x83\x66\x3b\x4f\x18\x09\xd8\xc4\x00\x3a\x40\xc1\xd1\x28\xb0\x1c\x60\x43\x80\x31\x70\x25\x51\x05\x1a\x73\x00\x47\xda\xfb\x48\x4a\x3c\x05\x46\x4f\x29\x7c\x8f\x1e\x3a\x6b\xe9\xb8\x02\x84\xe2\xa4\x14\xaf\xb7\x56\xdb\xad\x20\xff\x45\xf2\x01\xae\x67\xab\xe5\x77\xf0\xb1\xae\x8d\x4e\x79\x0e\x10\xa4\x97\xe7\xe9\xea\x71\x7c\xd6\xdd\x2f\xc3\x1b\x34\x30\x97\x8a\x8b\xc0\xee\x00\x2b\x72\x3b\x5d\x10\x28\x96\x16\x52\x71\x5d\x2d\xa7\x6c\xfe\xb3\x82\xda\xd1\x4e\x73\xf4\x20\x6c\xe0\x59\x38\x30\x34\x44\xaf\x74\x90\x01\x94\xcd\xbb\x1d\x60\x45\xd5\x86\x1c\xa0\x23\x70\x1c\x24\x89\x1a\xc3\xa3\x21\x94\xe6\x0a\xb6\x01\x8b\xd0\x57\x08\x68\x15\xa0\x7f\x4d\x86\x0a\x02\x43\xac\x0d\xa3\x98\xc0\xd2\x3e\x51\xdd\x36\xa1\xa7\xed\xef\xb5\x31\x69\x8c\xfd\xe0\x9a\x02\x53\x54\x07\xe9\xde\x6f\xc2\xa2\x45\xa6\xf4\xd5\xc6\xa2\x95\x66\x2b\xb4\xb8\x95\xea\x5c\xb4\xfe\x38\x9f\x59\x74\x4e\x54\x3f\x23\x4a\xa5\xa9\x7e\x58\xe2\x95\x5f\x45\x01\xb5\xf1\x37\x1d\x30\x9a\x7c\xf4\x2d\x33\x3a\xcf\x7c\x70\x6c\xb7\xf9\x62\x2e\xae\xee\xdd\xac\xc4\xf3\x62\x2e\x7b\x90\x4d\x24\xe6\x3c\x72\x49\x5b\xed\x03\x39\x69\xa0\x57\x64\x80\x3d\x86\x1c\x35\xbb\x98\xab\x5d\x4a\x48\x5b\x39\x48\x71\xb6\xae\x17\x90\xf7\x56\xd5\xac\x6d\x18\xc0\x3e\xcd\x97\x41\xb2\x37\x5a\x16\xe9\x21\x26\x99\x07\xc8\xd6\xd7\xba\xbe\xa0\xec\x8f\x64\x48\x3a\xbc\x9e\x16\x9c\x4d\xd2\x94\x93\x48\x7f\x39\x42\x81\x16\x76\x5a\x84\x4e\xb2\x96\x51\x56\x41\x26\xc3\x4e\xa5\x13\x3c\x6e\x60\x73\xdc\xf0\xb4\xb8\xe9\x05\xce\x10\x5e\x1c\x95\x3f\xaf\x12\x53\xe3\x5e\x52\xca\xf3\xb4\xfc\x23\x4c\x57\xf9\x45\x73\x36\xc1\xbc\xcf\x70\x97\x6e\x4d\x24\x41\xa7\xe4\x6f\x63\xe3\x60\x92\x8f\x5a\xb6\x2f\x0f\x6b\x4d\x58\x25\xfc\x7f\xc2\x11\x69\x0a\x7b\x04\x00\x00")
+
+func reissuance_csr_requiredHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_reissuance_csr_requiredHtml,
+		"reissuance_csr_required.html",
+	)
+}
+
+func reissuance_csr_requiredHtml() (*asset, error) {
+	bytes, err := reissuance_csr_requiredHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "reissuance_csr_required.html", size: 1147, mode: os.FileMode(0644), modTime: time.Unix(1792177222, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x26, 0xb0, 0x6c, 0xef, 0x5d, 0x3a, 0xb2, 0x9b, 0x13, 0xd7, 0xe8, 0xec, 0x34, 0xec, 0x8f, 0x77, 0x85, 0xa8, 0x79, 0x07, 0xac, 0xa0, 0xe6, 0x90, 0x25, 0x68, 0xc7, 0x94, 0x33, 0x59, 0x80, 0x2e}}
+	return a, nil
+}
+
+var _reissuance_csr_requiredTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\x53\xcb\x6e\xdb\x30\x10\xbc\xeb\x2b\xf6\xd8\x02\x85\x3f\xc0\x3d\xb9\x76\xd0\x0a\x28\x82\x40\x76\x02\xf4\xb8\x26\x57\x2e\x11\x92\xab\xf2\x61\x47\x08\xf2\xef\x5d\x52\x8a\xec\xa2\xed\x45\xe0\xbe\x67\x67\x47\xdf\xc8\x5a\x86\x43\xd7\xee\x37\xb0\xd1\xce\xf8\xf8\xa9\x69\x0e\x3f\x69\x76\xb5\x9a\x7c\x32\x69\x84\x2d\x85\x64\x7a\xa3\x30\x11\xf4\x1c\xe0\xf5\x15\x56\x5b\x76\x8e\xfd\x3d\x3a\x82\xb7\x37\xa0\x97\xc1\x04\x8a\xc0\xbe\x06\xef\x8a\x89\xc9\xb0\xdf\x95\x1a\x49\x38\xe6\x04\x26\x81\xe2\x6c\x35\x78\x4e\x70\x24\xc0\x9c\xd8\x49\x96\x42\x6b\x47\x08\x64\x62\xcc\xa4\x25\xa2\x30\x47\x2a\xe9\x17\x8c\x30\x7b\xfb\xc0\x0e\x10\xd4\x0d\x94\x68\x4e\xde\xf8\x93\x54\xfe\xca\x14\x13\x7c\xd8\xee\xbb\x8f\x10\xf3\x30\x58\x53\xfa\x8c\x90\x64\x97\xa7\xcd\xfe\x61\x35\xad\xf5\xd5\xf2\x11\x2d\xec\x04\xaa\x4a\x1c\x46\xd8\x53\x38\x1b\x45\xa0\x59\xb0\x17\x54\x33\x88\xdb\x31\xf1\x7d\xf4\x10\xe8\x6c\x38\x47\x90\x31\x10\x59\x9a\x63\xaa\x13\x9e\x69\x94\xcd\xfb\xfa\x9e\x98\x73\xe4\x8e\x14\x00\x03\x41\xe0\x24\x4d\xf4\x0a\x1e\x2c\xa1\x6c\xa5\xd8\x27\x54\x69\x81\x06\xe8\x35\x60\x7c\x2e\x0e\x07\x89\x21\x0f\x96\x51\x5c\xe0\xe9\x52\x46\x7d\xae\xa9\xb7\x7b\x5f\x8c\xb5\x85\xbf\x85\xb1\x0a\xb0\x64\xcd\x25\xf3\xfb\x45\xa6\x18\xb9\x4f\xb1\xa6\x5c\xf4\xb2\xac\x43\x8f\x27\x41\x17\xb2\x8f\x42\xcc\x36\x87\x20\x77\xfe\x63\x42\xc1\xa4\x17\x96\x24\x2a\x5f\x4d\x09\x8d\x8d\xeb\xa6\x69\x77\xeb\x7a\xe5\xa7\x76\x27\xa7\x6d\x3a\x3a\x99\x98\x28\x08\x90\x85\xd9\x29\xe1\x1a\xb9\x52\x2e\x05\x93\x74\xa0\x68\x67\xfd\xb7\x96\x9a\x3b\xaf\x07\x36\x3e\x4d\xb1\x77\xab\x44\xe4\x5c\x46\xee\x77\x9f\x0b\xbb\x53\x78\x72\x4d\x9e\x5a\xbc\x28\x6f\xfd\x6f\x25\x36\xcd\x0f\xce\xa0\xd0\xc3\xd9\x08\x5b\x85\x9b\x3e\x0b\x9f\x82\x8f\x83\x2e\x02\xbe\x9e\xb1\xfe\x13\xf0\xd8\xca\xce\xa5\x57\x35\x3b\x2a\x75\x8f\xdd\xf7\xda\xeb\x4b\xd1\x9d\xac\x89\x41\xcb\xbf\x33\x55\xfd\x57\x65\x07\x42\xf7\x1b\x87\x82\x40\x96\x73\x03\x00\x00")
+
+func reissuance_csr_requiredTxtBytes() ([]byte, error) {
+	return bindataRead(
+		_reissuance_csr_requiredTxt,
+		"reissuance_csr_required.txt",
+	)
+}
+
+func reissuance_csr_requiredTxt() (*asset, error) {
+	bytes, err := reissuance_csr_requiredTxtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "reissuance_csr_required.txt", size: 883, mode: os.FileMode(0644), modTime: time.Unix(1792177222, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7e, 0x55, 0x7d, 0x75, 0x52, 0x0d, 0xb7, 0x27, 0x73, 0xcd, 0x3a, 0x89, 0xb0, 0xa8, 0x3c, 0x4e, 0xe1, 0xfb, 0xfc, 0xda, 0x4d, 0x63, 0x31, 0x6f, 0xab, 0x79, 0x67, 0x9c, 0x9c, 0xe8, 0x35, 0x8c}}
+	return a, nil
+}
+
 var _reissuance_reminderHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x55\xc1\x6e\xdb\x46\x10\x3d\xd7\x5f\x31\xc8\xa1\x27\x45\x46\x7b\x74\x59\xa2\xa9\x1d\x34\x46\x81\x34\xb0\x8d\x1a\x3d\x8e\x96\x23\x71\x90\xe5\x0e\x33\x3b\x94\xca\x06\xf9\xf7\x62\x77\x29\x4a\xa6\xd5\xde\x6c\xee\xce\xbc\xd9\xf7\xde\x3c\x55\x7d\xfd\x81\xbc\x17\xf8\xfa\x15\xd6\x1f\xb1\x23\xf8\xf6\x6d\x55\x5d\xf7\xf5\xd5\x55\xd5\xd7\xcf\x04\x07\x8e\x2d\x98\x80\x52\xc7\xa1\x81\x51\x06\xb0\x16\x2d\xfd\xa1\xf0\xf4\x70\xff\xf8\x0e\xee\x1b\x0a\xc6\x36\xc2\x2d\xa9\xf1\x96\x1d\x1a\x45\xd8\x8a\x42\x45\x5d\x9d\x1a\xdf\x4a\xd7\x49\x98\xda\x57\xd7\xd4\xd5\x70\x60\xef\x21\x8a\x04\xd8\x10\xd0\xdf\x3d\x2b\x87\xdd\x1a\x9e\xda\xe9\x3f\x34\x96\x00\x0d\x1a\x81\x84\x82\xe6\x06\x55\x0a\x06\xee\x1c\x86\xe3\x8c\xf2\x7e\xae\xbb\x4b\x65\x13\xd2\x7a\x7e\x4d\xea\xfd\x9b\x97\x0d\x7a\xb8\x63\x25\x67\xa2\x23\x3c\x92\xee\xd9\x51\x99\x07\x07\x93\x0e\x8d\x1d\x7a\x3f\x82\x12\xc7\x38\xd0\x04\x7e\x0e\x2a\x01\xaa\x68\x2a\x61\x97\x81\x1f\xca\xc5\x19\x75\x3a\x5a\x3f\xb7\x14\xc0\x5a\x7a\x59\x8c\x4a\xc7\xd6\xcd\x2a\x13\x9a\xb1\x95\x1c\xf1\x9e\xc0\x0e\x02\xd8\x34\x9c\xde\x81\x1e\xa8\x43\xf6\x11\xbe\xef\x1a\x8c\xed\x4f\x20\x61\x1a\xd5\x49\x30\xe4\x89\x99\x1e\x63\x3c\x88\x36\x6f\x7b\x15\x23\x67\xd4\x2c\x20\x43\x93\xe7\x10\x6b\x49\x2f\xd4\x7f\xfa\xfd\xf6\xf1\x87\x1f\xe7\x36\x6b\xf8\xeb\x38\xd5\x10\x29\x57\x2e\x6e\x24\x47\x34\xe4\x74\xec\x2d\x1f\x07\x3a\xbc\x40\x3c\x91\x9e\xc4\xf9\xe4\x09\x23\x41\x10\xa3\x9b\x8b\xdd\x32\xd4\x86\x20\x26\x79\xf7\x8c\x80\x60\xdc\xd1\xdb\x48\x21\xb2\x25\x56\x22\xb9\x41\x09\x3c\x87\xcf\x2b\x88\x02\x7d\x69\x99\x6a\xd2\x77\x13\xf0\x22\x9f\x41\x06\xcb\xc6\xdb\x88\xb5\x13\xc9\x18\x5c\x86\x2e\xa3\x25\x53\x4d\x94\x26\x52\xa4\x2f\x0a\x75\xc0\x5d\x47\x0d\xa3\x91\x1f\xd7\xd9\x37\xf3\x0b\xfe\x48\x0d\x32\x4d\xcb\x57\x42\x8b\xfb\x34\x03\x05\x38\x93\x53\x67\x3b\xbf\xbc\x3c\xa9\xdc\x25\xd6\xf7\xe8\xb9\x81\x21\x18\xfb\x4c\xc8\xc2\xf2\x6b\xf8\x20\x07\xda\x93\xae\x96\xf6\x81\x86\x2c\x4f\xef\x39\x26\x99\xd3\x63\x33\xa6\xe8\x0e\x03\xff\x53\x7a\x70\xb1\xdd\xff\x9b\x7d\x43\x30\xf4\x09\x2d\xab\x79\x51\x45\x78\x17\x46\x70\x32\x04\x23\xed\x51\x6d\x2c\xbb\xff\x65\x20\x65\x8a\xaf\x16\x63\x1e\x0e\xb7\x46\x9a\x5b\x9e\x69\xf0\xd2\xe5\xaf\xe1\x8e\xd5\x2b\x38\xb4\xec\x5a\xe8\x70\x2c\x04\xa7\xbb\xbd\x58\x0a\x19\xf4\x20\x5b\x70\x38\xc4\x4c\xaf\x84\xad\x67\x67\x89\x5b\x6b\x01\xc3\x08\xa6\x18\xe2\x96\x34\x96\x49\xc5\xb9\x41\x61\x43\x5b\xd1\xac\x61\x69\x78\x7c\x76\x11\x55\x9a\xec\x9f\xa3\xd3\x5f\x93\xf0\x4c\x40\xc1\xc9\xa0\xb8\x2b\x4d\x4c\x80\x43\x34\xf4\xfe\x62\x05\x60\x4c\x82\x52\xde\xe1\x21\xfa\x31\x7d\xe8\x25\x46\xde\x78\x3a\x6d\xc6\xb4\x15\x4a\x7b\xa6\x43\x79\xa4\x72\x87\x3a\xce\x34\xca\xb6\x8c\xd8\xcc\x02\x52\x30\x1d\xb3\x73\xd3\x5e\x4d\x81\x58\x02\xf5\x6c\x82\x9b\x09\x63\xf0\xf5\xd5\x77\x95\xe7\xfa\x18\x56\xf7\x77\x37\x73\x3a\xe5\xc4\xff\xf3\xfe\x2e\x27\x96\xe7\xfa\x0a\xe0\xfc\xea\x03\xed\x92\xc3\x94\x9a\x93\x7f\x16\xc5\xa7\x2b\x27\x87\x1d\x9b\xbd\x80\x2d\xf1\x0f\x29\xff\x17\x2d\x16\x3f\x0c\xaf\x2a\x1f\x49\x93\xe6\x1f\x87\x6e\x43\xba\xa8\x2d\x67\xe5\xe8\x72\xf5\xfb\xd0\xf4\xc2\xc1\x16\x85\xc7\xcf\x73\x51\x75\x9d\x98\x4a\x9a\xdc\x6f\x4f\x2e\x49\x76\xfa\x32\x50\x4c\x1b\x15\x41\x14\x5c\x8b\x61\x47\x71\x75\x0c\x9f\x1c\xa1\xce\x60\x88\x80\x06\x15\x42\xab\xb4\xfd\xf9\x4d\x4a\x17\x93\x9b\x38\xf4\xbd\xa8\xfd\xa2\x62\x58\xb2\x7c\xcd\xf2\xa6\xbe\xf8\xb9\xba\xc6\x7a\x0d\x93\x21\x1a\x49\x71\x05\x4a\xbd\x1f\x27\xe9\xfd\x58\x56\x94\x63\x09\xaf\x75\x35\x79\xe8\x57\x8a\x06\x0f\xb4\x43\x6d\xe2\xaa\xda\x28\x5c\xd7\x57\xe5\x47\xf9\x3f\x77\xff\x89\xb0\x4b\xfe\xf8\x37\x00\x00\xff\xff\x85\x9c\xc5\xe1\xfa\x07\x00\x00")
 
 func reissuance_reminderHtmlBytes() ([]byte, error) {
@@ -202,7 +286,7 @@ func reissuance_reminderTxt() (*asset, error) {
 	return a, nil
 }
 
-var _reissuance_startedHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x54\xd1\x6e\xe3\x36\x10\x7c\xae\xbf\x62\x9a\x67\xc3\x46\xdb\x87\x02\x81\x2a\xf4\x9a\x1c\x5a\xa3\xc5\xf5\x90\xa4\x3d\xf4\x71\x2d\x8d\x23\xe2\x28\x52\x5d\xae\xa2\x0a\x07\xff\x7b\x41\xc9\x56\x12\x5f\xde\x6c\x92\x3b\x33\xbb\x33\xab\xa2\x2b\x7f\xa3\xf7\x11\x5f\xbe\x60\xf3\x41\x5a\xe2\x78\x5c\x17\xdb\xae\x5c\xad\x8a\xae\xfc\x44\x88\x12\x83\x3a\x73\xe1\x11\x16\xe1\x69\x18\x63\x8f\xcf\x21\x0e\xb0\x46\xa6\x7f\x8a\x87\xbb\xdd\xfd\x3b\xec\x6a\x06\x73\x36\xe2\x86\x6a\xee\xe0\x2a\x31\x26\x1c\xa2\xc2\x1a\xa2\x76\xca\xca\xa2\x8e\x50\x3e\xba\x64\x2a\xe6\x62\x40\xcd\x54\xa9\xdb\xb3\xc6\x9e\x3e\x0e\x68\xe4\x89\xd8\x93\x01\x4a\x97\x52\xcf\x7a\x0d\x49\x33\x4d\xf4\x35\xaa\x97\xd8\x03\x95\x08\x14\x9d\xe4\x35\x74\x0a\xfe\xd7\xb9\x33\xb4\x18\x37\xf8\x27\xf6\x18\x9c\xf7\x48\x31\x06\xec\x09\x65\x45\xf7\x94\x2b\x04\x89\x9d\xa8\x18\xc1\x56\x9c\x47\x15\x83\x89\x0b\xf9\x6e\x22\x0c\x1c\xf0\xf1\xf7\x9b\xfb\xef\xbe\x07\x43\xa5\x63\x67\x7c\xad\x60\xb3\xcc\xea\xa1\x21\x3a\x75\xad\xe8\x88\x9a\x26\xce\x27\xc4\xc3\x0c\xf3\xdc\x3a\x83\xe9\x38\x0d\x55\xf2\x64\xbc\x8f\x43\xba\x3e\x61\xf4\xbe\x5c\x7d\x53\x78\x57\x16\xc9\x34\x86\xc7\x72\x77\x7b\x5d\x6c\x4f\xbf\x27\x83\xfe\xde\xdd\xe2\x78\x2c\xb6\xde\x95\x2b\xe0\xe5\xd3\xbb\x69\xa4\x54\xd6\xb8\x3d\x93\x5d\x14\x3f\x3f\x59\x5e\x2c\x60\xaf\x68\x6f\x62\xdb\xc6\x80\x9c\x86\x0b\x88\xf9\xe6\x14\x93\x37\x2a\xdf\x87\xba\x8b\x2e\xd8\x45\xd9\xf9\x78\x29\x2a\xb6\xb9\xd7\x79\x6a\x2e\xbd\x9e\x7d\x9a\x5c\xa9\x7a\x25\xbc\x0b\x9f\x73\xe8\x72\x7a\x4e\x2e\x74\x92\xd2\x10\xb5\xc6\xd0\xb8\xaa\x99\xa2\xd8\xf6\xc9\xd0\x27\xe6\x97\x35\x27\x93\xa6\x8a\xec\xdd\xdb\x56\x9d\xe5\x7e\xf8\xf3\xe1\xfd\x0b\xa9\x1f\x3d\x25\x71\x86\xca\xb2\x26\xfa\xc1\x59\x83\x4a\x94\xdf\x62\x67\x73\x8e\xa4\xb7\xd8\x8a\xb9\x4a\xbc\x1f\xe7\xbc\x11\x72\x30\x2a\x7e\x44\x2d\x63\x42\x54\xfc\x00\xa9\x2a\xa6\x04\x31\x63\xdb\x59\x5a\xcf\x92\xf9\x44\x45\x15\xdb\xbc\x18\x4e\x93\x6d\x50\xb0\x2d\xdf\x4d\xd5\x59\xf5\x44\xda\x48\x3a\xe1\xd6\xeb\x7c\x9a\x77\x30\x33\xef\x89\x10\x31\xc8\xf8\xb2\xd7\x25\xa8\x17\xcd\xb2\x2d\xbf\xea\xb8\x10\x34\xca\xc3\x4f\x57\xd9\x96\x4f\x8d\x4b\x1d\xf5\xaf\xbb\x3f\x70\x3c\x5e\x95\x5f\x1d\x15\x5b\x29\x97\xe9\x2c\x50\xa7\x29\x29\x0f\x54\x48\x18\xf1\x6f\xcf\x94\xd7\x2d\x65\x51\x0b\x41\xb6\xd4\xe2\x75\xea\xbb\x2e\xaa\xfd\xac\xd1\xa6\x9d\x14\xbf\x71\xf1\xaa\x7c\xf3\x38\xf3\x6d\xce\x2e\xd4\x11\x21\x1a\x94\x9d\x1f\x4f\xfb\xe3\xc7\x39\x0c\xe7\xc4\x6c\x8a\x93\xa4\x5f\x98\x0c\x77\x7c\x14\xad\xd3\xba\xd8\x2b\xb6\xe5\x6a\xfe\x22\xfd\xea\xe3\x5e\xfc\xf3\x4a\xe0\x9e\xfa\xe4\x2a\xe2\x81\xd2\xe6\x96\xfe\x0f\x00\x00\xff\xff\x70\x8d\x2e\x28\x00\x05\x00\x00")
+var _reissuance_startedHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x55\x4d\x6f\x13\x31\x10\x3d\x93\x5f\x31\xf4\x04\x52\x94\x08\x38\x20\x55\xcb\x8a\x92\x56\x10\x81\x4a\x95\x04\x2a\x8e\x8e\x77\x92\x1d\xd5\x6b\x2f\xb6\x37\xcb\xaa\xea\x7f\x67\xec\xfd\xc8\x26\xed\x25\x4a\x3c\xdf\xef\xbd\x99\x24\x65\xfa\x0d\x95\x32\xf0\xf8\x08\xb3\x5b\x51\x20\x3c\x3d\x4d\x93\x79\x99\x4e\x26\x49\x99\xde\x23\x08\x8b\x50\x5b\xf2\xa4\xf7\xe0\x0d\x28\xf4\xd0\x98\x0a\x1e\xb4\xa9\xc1\xe7\x22\xfe\xb2\xb0\x59\x2d\xd7\x57\xb0\xcc\x50\x7b\xf2\x0d\x2c\xd0\x7a\xda\x91\x14\x1e\x1d\xec\x8c\x65\x4f\x84\x8c\x2c\x4a\x6f\x6c\x03\x16\xf7\xe4\xbc\x15\x9e\x8c\x86\x0c\x9d\xb4\xb4\xc5\x0c\xb6\xa8\x38\x69\x2e\x0e\xc8\x5f\x51\xb3\x1b\x39\x57\x61\x36\x05\xe1\xda\x32\x46\x65\x20\xc7\xb9\x6b\xe4\xf6\x34\x0a\x1b\xdb\xcb\x91\x2c\xe0\xbf\x92\xfa\xd4\xec\x33\x83\x3f\xdc\x6e\x4d\x4a\x81\x33\xfc\xb6\x45\xce\x2b\x91\x0e\x21\x42\x80\xc3\x52\xb0\x37\x02\x16\x82\x14\x48\xa3\xbd\x20\x1d\x6c\xb1\xa0\xc6\x3a\x20\x43\x3b\x98\xdd\xe7\xe4\x4a\xb4\xbf\x56\x3f\x18\xa2\xbb\xef\x8b\xf5\xbb\xf7\x80\x5a\xda\xa6\xf4\x78\xda\x15\x07\xa0\x72\x01\xc9\xb2\xda\x2a\x92\x63\x23\xc8\x9c\xf3\x07\x0f\x9d\xb1\xc3\x6c\x80\x7a\xc3\x08\x95\x96\x0a\xc1\xf8\x64\xc8\x4d\x28\x07\x66\xd7\x76\x71\x44\x8e\xf1\xe5\xcf\xc0\x89\x08\xc0\x32\x71\xb5\xbb\xec\x72\x54\x2a\x9d\xbc\x4a\x14\xa5\x09\x63\x6b\xf4\x3e\x5d\x5e\xb3\xa9\xfb\x1e\xf9\xfd\xbd\xbc\xe6\x9a\xc9\x9c\x7d\x26\x00\x63\xd7\x55\x64\x84\xc1\xcc\xe0\xba\x2f\x76\x16\x7c\x74\x19\x3c\x86\x64\x27\x65\x17\xa6\x28\x18\xe8\x20\xa6\xb3\x14\xad\xa5\x53\xd9\x0b\x91\x37\x3a\x2b\x0d\x69\x7f\x16\xd6\x3f\x0f\x41\xc9\x3c\xcc\x3a\x79\x89\x98\x16\x4a\x72\xa7\x7c\xba\xc8\xb4\xac\x18\x37\x45\xfa\x21\x08\x39\x28\xb2\x63\xb1\x14\xce\xd5\xc6\x66\x50\xe7\x24\xf3\x28\xef\xa2\x72\x1e\x2a\xe6\x90\x3d\x33\x8c\x24\xc7\x88\xa0\x87\x31\xd5\x47\xfe\xfa\x19\x6e\x7f\x6e\x6e\x46\xfd\xdf\x29\x14\x9c\x26\xa6\x0a\x6d\xc5\xf2\x35\xf9\x1c\x24\xb3\xf8\x1a\x96\xbe\xd5\xa6\xa8\xbc\x29\x58\xb5\x52\x28\xd5\xb4\x1a\x66\x8e\x77\x8c\x37\x7c\x64\x1d\x37\x2c\x06\x0b\x1f\x40\x48\x89\x8e\xa7\xf1\x1e\x8b\xd2\xbb\x69\xdb\x32\x1e\xd8\x4d\x9a\x22\x2c\x1b\x59\xe7\x67\x90\x60\x91\x5e\xc5\xe8\xd0\x75\x2c\x9a\xb3\x62\xda\xbc\xbc\x50\xfc\x1a\xf6\x3a\x54\xe6\x85\xd0\x06\x6a\xd1\x8c\x67\x1d\xc4\x7f\x36\x2c\xa7\x7d\x36\x71\x22\x20\xb7\xb8\xfb\x74\x11\xb8\x3a\x21\xe3\x22\x7d\xf6\x94\xcc\x45\x3a\xa0\x13\x53\x1d\xb7\xa5\x5f\x83\xf3\xba\x51\xef\x5b\x0c\x4b\xd9\x1e\x04\xd8\x59\x53\xc4\xc9\xc6\x9b\xe5\x68\x1f\x17\xd7\xe2\xdf\x0a\x99\xbe\x37\x8b\xf5\xea\xed\x70\xa3\x78\xb9\xf0\x40\xa6\x72\x0c\xaf\xab\xca\x52\x51\xc0\xc1\x99\xb3\xd3\xe4\xd0\x1e\x48\x62\x44\x4b\x1b\x0f\x7b\xd4\x18\xee\x43\xc6\x0a\xe2\xf5\x3c\x84\x42\x0f\xd8\xf4\x47\xad\x68\xa1\xee\x58\x0e\x6a\x23\x5d\x45\xd5\xb4\x8c\xe3\x49\xd0\xd0\x0b\x1b\xb3\xe0\x24\x2d\x06\x5b\x44\x9b\xbb\x1d\x01\x3c\x5c\x88\x08\x75\x97\x9f\x51\x66\x46\x85\x6e\x20\x4e\xc8\x27\xce\x85\x2c\x03\x01\x41\xf2\xde\x5c\x86\xf1\x8c\xf5\x9f\xad\xf1\xf1\x0e\x0a\x35\x23\x73\x91\xbe\xf8\x1c\xf8\x98\xf5\x2a\xcd\x4c\x9c\xd9\x62\xc9\x20\xb5\x98\xa8\xa6\x5d\x96\x7e\xa3\x66\x49\xc7\xfe\x97\x00\x31\xdf\x04\x61\x33\x37\x4d\xb6\x16\xe6\xe9\xa4\xfd\x17\xf8\xaa\xcc\x56\xa8\xe3\x1d\x81\x75\x87\xe9\x06\x45\x11\x86\xfb\x0f\xb7\x8e\x22\x3c\x74\x06\x00\x00")
 
 func reissuance_startedHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -217,12 +301,12 @@ func reissuance_startedHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "reissuance_started.html", size: 1280, mode: os.FileMode(0644), modTime: time.Unix(1658847751, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x85, 0x1c, 0x4d, 0x46, 0x7e, 0xd3, 0xde, 0xfd, 0xfd, 0x91, 0xc9, 0xf2, 0xf5, 0x4e, 0x7e, 0x7b, 0x63, 0xd5, 0xb, 0xfb, 0x26, 0xdb, 0xb7, 0xcc, 0xa1, 0xf7, 0x72, 0xd, 0x62, 0x8c, 0x7d, 0x6d}}
+	info := bindataFileInfo{name: "reissuance_started.html", size: 1652, mode: os.FileMode(0644), modTime: time.Unix(1792161004, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x80, 0x12, 0xe4, 0xeb, 0x86, 0x6c, 0xd6, 0xb0, 0x62, 0x02, 0x3f, 0x00, 0x67, 0x31, 0x9a, 0x8c, 0x1d, 0x8f, 0x89, 0xd7, 0x70, 0xa7, 0x2a, 0x25, 0x89, 0x68, 0xc3, 0xc3, 0x6a, 0x0c, 0x21}}
 	return a, nil
 }
 
-var _reissuance_startedTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x53\x4d\x6f\xd3\x40\x10\xbd\xfb\x57\x3c\xee\x51\x24\xe0\x80\xd4\x13\xa5\xad\x20\x02\x95\x2a\x0d\x54\x1c\x27\xf6\x4b\xbd\xea\x7a\xd7\xcc\x8e\x63\xac\x2a\xff\x1d\xed\x3a\xe9\xd7\xd1\x3b\xf3\x66\xe6\x7d\xf8\x1b\xbd\x8f\x78\x7c\xc4\xf2\x5a\x3a\xe2\x70\x58\x54\xd5\x1d\x21\x4a\x8c\xea\xcc\x85\x7b\x58\x84\xa7\x61\x8a\x03\x1e\x42\x1c\x61\xad\x94\x2f\xc5\x66\xbd\xba\x3d\xc7\xaa\x61\x30\x67\x13\x2e\xa8\xe6\x76\xae\x16\x63\xc2\x2e\x2a\xac\x25\x1a\xa7\xac\x2d\xea\x04\xe5\xbd\x4b\xa6\x62\x2e\x06\x34\x4c\xb5\xba\x2d\x1b\x6c\xe9\xe3\x88\x56\xf6\xc4\x96\x0c\x50\xba\x94\x06\x36\x0b\x48\x9a\xd7\x44\xdf\xa0\x7e\x39\x7b\xa4\x12\x81\xa2\xe5\xbc\x96\x4e\xc1\x7f\xbd\x3b\x8d\x16\xe3\x12\x7f\xe2\x80\xd1\x79\x8f\x14\x63\xc0\x96\x50\xd6\x74\xfb\x8c\x10\x24\xf6\xa2\x62\x04\x3b\x71\x1e\x75\x0c\x26\x2e\xe4\x5a\x59\x18\x38\xe2\xe6\xfb\xc5\xed\xfb\x0f\x60\xa8\x75\xea\x8d\xaf\x2f\x58\x56\xd5\xa6\x25\x7a\x75\x9d\xe8\x84\x86\x26\xce\x27\xc4\xdd\x8c\x7f\xe6\xcc\x60\x3a\x15\x35\x25\x4b\xe2\x7d\x1c\xd3\x59\x55\xad\x2e\xcf\x8a\xe6\xbf\x57\x97\x38\x1c\xaa\x75\x51\x86\xca\x06\x97\x27\xe8\xdc\xf0\x5c\x79\x2a\x64\xc0\x45\xec\xba\x18\x90\x2d\x9b\xfb\xe6\x87\xa3\x85\xd5\x55\x68\xfa\xe8\x82\xcd\xb5\xd3\x57\xae\x54\x9b\xd6\xa5\xd7\xac\x53\xd1\xa3\x1e\x94\xf0\x2e\x3c\x64\xbb\xb3\x6f\x47\xfe\xbd\xa4\x34\x46\x6d\x30\xb6\xae\x6e\x4b\x08\xba\x21\x19\x86\xc4\xdc\xd9\xb0\xc8\x53\x10\x59\xb5\x37\x22\x5d\xff\xdc\x5c\x9d\xe1\xc6\x53\x12\x67\x48\x5e\x5f\xd6\x8c\xce\x5a\xd4\xa2\x7c\x87\x95\xcd\x4e\xc9\x60\xb1\x13\x73\xb5\x78\x3f\xcd\x8e\x12\xb2\x33\x2a\x3e\xa1\x91\x29\x21\x2a\x3e\x42\xea\x9a\x29\x41\xcc\xd8\xf5\x96\x16\xf3\x69\xdc\x53\x51\xc7\x2e\x47\xcf\x69\xb2\x25\xce\x0b\x32\x5f\x56\x16\xb6\x92\x8e\x33\x9b\x45\x7e\xcd\x09\xcf\x5b\xb7\x44\x88\x18\x65\x7a\xc9\xe7\x29\x06\x6f\x08\x65\x3d\xef\x5a\x97\x7a\xea\xaf\xf5\x8f\xa2\xe8\x91\x9d\x72\x47\x85\x84\x09\x7f\x07\xa6\x1c\xc4\x94\x07\xa6\xa1\xef\xa3\xda\x67\x8d\x56\xd2\x29\x7e\xe9\xe2\xf2\x24\x49\x13\x11\xa2\x41\xd9\xfb\xe9\x98\x1a\x3f\xcd\x0e\x9c\x6c\x5a\x56\xd5\x17\x26\xc3\x9a\xf7\xa2\x4d\x5a\x54\xf3\x4f\xf7\xd5\xc7\xad\xf8\xe7\xb8\xe0\x96\xba\x77\x35\xb1\xa1\x74\xff\x03\x00\x00\xff\xff\x66\x0d\x2e\xfb\xd5\x03\x00\x00")
+var _reissuance_startedTxt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x94\x4d\x6f\xd3\x40\x10\x86\xef\xfe\x15\xc3\x0d\xa4\xc8\x12\x70\x40\xea\x89\x92\x56\x10\x81\x4a\x95\x04\x2a\x8e\x9b\xdd\x71\x3c\xea\x7a\xd7\xec\xae\x63\xac\xaa\xff\x9d\x99\xdd\x7c\x56\xbd\x44\xb1\xe7\xfb\x99\x77\xfc\x0d\xad\xf5\xf0\xf4\x04\xf5\x9d\xea\x10\x9e\x9f\x67\x55\xf5\x80\xa0\x02\xc2\x18\x28\x91\xdb\x42\xf2\x60\x31\xc1\xe4\x07\x78\x74\x7e\x84\xd4\xaa\xfc\x14\x60\xbd\x5c\xac\xae\x61\x61\xd0\x25\x4a\x13\xcc\x31\x24\x6a\x48\xab\x84\x11\x1a\x1f\xd8\x13\xc1\x50\x40\x9d\x7c\x98\x20\xe0\x96\x62\x0a\x2a\x91\x77\x60\x30\xea\x40\x1b\x34\xb0\x41\xcb\x49\x5b\xb5\x43\xfe\x8b\x8e\xdd\x28\xc6\x01\xcd\x0c\x54\x2c\x65\xbc\x35\xa0\xcf\x73\x8f\xc8\xed\x39\x54\x21\xb7\xd7\x22\x05\xc0\x7f\x3d\x1d\x52\xb3\x4f\x0d\x7f\xb8\xdd\x91\xac\x85\xe8\xf9\xdd\x06\x39\xaf\x46\xda\x49\x84\x82\x88\xbd\x62\x6f\x04\xec\x14\x59\xd0\xde\x25\x45\x4e\x6c\xb9\xa0\xc3\x51\x90\x50\x03\xf5\x43\x4b\xb1\xc7\xf0\x6b\xf9\x83\xd9\xdc\x7f\x9f\xaf\xde\x7f\x00\x74\x3a\x4c\x7d\xc2\xcb\xae\x38\x00\x6d\x14\x84\xfd\xb0\xb1\xa4\xcf\x8d\xa0\x5b\xce\x2f\x1e\xce\xb0\x43\x5d\x55\x6b\x46\xd3\x07\xea\x14\x83\x31\xc8\xd5\x6d\x04\xdf\x94\xf2\x27\x64\x0c\x96\x7f\x65\x19\x4a\x88\xf2\xaa\xc6\x78\x55\x55\x8b\x9b\xab\xbc\xb2\xdf\x8b\x1b\xce\x56\x2d\x33\x58\x66\x62\xe0\xe6\x10\x5a\x1c\x4e\x96\xa3\x41\x02\xe6\xbe\xeb\x18\x8a\x6c\xbc\xf8\x95\x17\x7b\x05\x54\xb7\xce\xf4\x9e\x5c\x2a\xb6\xc3\x93\x58\xaa\xd7\xa8\xac\xf9\xe1\x12\x64\xcc\x88\xf5\xc0\x7d\x5b\x72\x8f\xa2\x20\x91\xc2\x1e\x5f\xaf\x62\x1c\x7d\x30\x30\xb6\xa4\xdb\xac\xab\x6e\x88\x09\x06\x86\xc7\x9e\x06\x33\xdd\x1c\x21\x8b\x38\x67\xcc\xe0\xee\x7e\xae\x6f\xaf\xe0\xde\xa2\x62\xf7\x1c\x22\xe5\x73\x99\x91\x52\x0b\x9a\x69\xbd\x81\x45\x2a\xcb\x57\x43\xf2\x1d\xcb\x42\x2b\x6b\xa7\x22\x12\x66\xd9\x30\x12\xf8\xc4\x42\x99\x18\x7a\x80\x8f\xa0\xb4\xc6\xc8\x5d\xa7\x84\x5d\x9f\xe2\xac\xb4\x86\x3b\x76\xd3\xbe\x13\x35\x53\x88\xa9\x86\xeb\x1c\x29\x9d\xe5\x82\x2d\x6f\xa5\xe4\x64\xb5\xf2\x5b\x39\x1a\xa9\xca\x6a\x73\x1e\x46\x35\x9d\xcf\x73\x54\xd6\x8b\x81\x84\xf1\x05\xcf\xea\xa4\xa4\xf5\x2b\x0c\xb2\x1c\x36\x28\x62\x2d\x87\x02\x4d\xf0\x5d\x6e\xea\x5c\x71\x91\xb6\x59\xd0\x01\xff\x0e\xc8\x74\xdf\xce\x57\xcb\x77\xc7\xdb\x65\xed\xe1\x8e\xfc\x10\x99\x4a\x1c\xfa\xde\x92\x8c\x10\xfd\x8b\x93\x8d\x18\x76\xa4\x31\x0f\xea\x7c\x82\x2d\x3a\x94\xbb\x31\xbc\x60\x56\xef\x4e\x0a\x3d\xe2\x74\x38\xf6\xae\x3e\x2c\x46\x84\x40\x6e\xc8\x0b\x2d\x4b\xc2\x8b\x80\x63\x1f\x6c\x34\xe2\xa4\x03\x8a\x2d\x43\xe2\x4e\xeb\xea\x78\x2c\xd5\x3e\x65\xc0\x86\xd9\x2b\x37\x41\x1e\x88\x2f\x3d\x4a\xa0\x74\xef\x43\xfa\x1c\x7c\xca\xe7\xaf\x6c\x4d\xfe\xd8\x87\xf1\xb9\xef\x80\x3d\x0f\x5a\xe6\xb2\x53\xd1\xe3\x41\xb4\xbc\x82\x2f\x02\x88\x6f\x45\x05\x13\x67\x55\xf9\xaa\x7d\xb5\x7e\xa3\xec\xe9\xa0\x60\xb5\x67\xb1\x46\xd5\xfd\x07\x54\xcd\x20\xdb\x36\x05\x00\x00")
 
 func reissuance_startedTxtBytes() ([]byte, error) {
 	return bindataRead(
@@ -237,8 +321,8 @@ func reissuance_startedTxt() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "reissuance_started.txt", size: 981, mode: os.FileMode(0644), modTime: time.Unix(1658847751, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8f, 0xfc, 0x71, 0xd2, 0x64, 0x22, 0xbc, 0x55, 0x7f, 0xb4, 0x9b, 0xaf, 0xfe, 0x3d, 0x12, 0xc3, 0xe8, 0xb1, 0x9c, 0x11, 0x1e, 0xdd, 0x3, 0x1e, 0x88, 0x9, 0xbd, 0x3f, 0x4e, 0xce, 0x9e, 0x25}}
+	info := bindataFileInfo{name: "reissuance_started.txt", size: 1334, mode: os.FileMode(0644), modTime: time.Unix(1792161004, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd3, 0xb1, 0x20, 0x8d, 0x97, 0xb2, 0xb6, 0x5c, 0x92, 0x35, 0x60, 0x26, 0x7f, 0x28, 0x7e, 0xb4, 0x77, 0x65, 0x14, 0x27, 0xca, 0x32, 0xda, 0x64, 0x76, 0x8f, 0xe2, 0x7b, 0xb6, 0x64, 0x8e, 0x93}}
 	return a, nil
 }
 
@@ -453,10 +537,14 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"certificate_revoked.html":        certificate_revokedHtml,
+	"certificate_revoked.txt":         certificate_revokedTxt,
 	"deliver_certs.html":              deliver_certsHtml,
 	"deliver_certs.txt":               deliver_certsTxt,
 	"expires_admin_notification.html": expires_admin_notificationHtml,
 	"expires_admin_notification.txt":  expires_admin_notificationTxt,
+	"reissuance_csr_required.html":    reissuance_csr_requiredHtml,
+	"reissuance_csr_required.txt":     reissuance_csr_requiredTxt,
 	"reissuance_reminder.html":        reissuance_reminderHtml,
 	"reissuance_reminder.txt":         reissuance_reminderTxt,
 	"reissuance_started.html":         reissuance_startedHtml,
@@ -513,10 +601,14 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
+	"certificate_revoked.html": {certificate_revokedHtml, map[string]*bintree{}},
+	"certificate_revoked.txt": {certificate_revokedTxt, map[string]*bintree{}},
 	"deliver_certs.html": {deliver_certsHtml, map[string]*bintree{}},
 	"deliver_certs.txt": {deliver_certsTxt, map[string]*bintree{}},
 	"expires_admin_notification.html": {expires_admin_notificationHtml, map[string]*bintree{}},
 	"expires_admin_notification.txt": {expires_admin_notificationTxt, map[string]*bintree{}},
+	"reissuance_csr_required.html": {reissuance_csr_requiredHtml, map[string]*bintree{}},
+	"reissuance_csr_required.txt": {reissuance_csr_requiredTxt, map[string]*bintree{}},
 	"reissuance_reminder.html": {reissuance_reminderHtml, map[string]*bintree{}},
 	"reissuance_reminder.txt": {reissuance_reminderTxt, map[string]*bintree{}},
 	"reissuance_started.html": {reissuance_startedHtml, map[string]*bintree{}},
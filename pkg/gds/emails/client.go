@@ -272,6 +272,17 @@ func (m *EmailManager) SendRejectRegistration(vasp *pb.VASP, reason string) (sen
 // email), ranking the contact emails by priority. Caller must update the VASP record on
 // the data store after calling this function.
 func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent int, err error) {
+	return m.sendDeliverCertificates(vasp, path, false)
+}
+
+// SendDeliverPublicCertificates sends the public certificate chain issued from a CSR
+// that was supplied by the VASP, informing the contact that the directory does not have
+// the private key. The delivery is otherwise identical to SendDeliverCertificates.
+func (m *EmailManager) SendDeliverPublicCertificates(vasp *pb.VASP, path string) (sent int, err error) {
+	return m.sendDeliverCertificates(vasp, path, true)
+}
+
+func (m *EmailManager) sendDeliverCertificates(vasp *pb.VASP, path string, publicChain bool) (sent int, err error) {
 	var errs *multierror.Error
 	ctx := DeliverCertsData{
 		VID:                 vasp.Id,
@@ -279,6 +290,7 @@ func (m *EmailManager) SendDeliverCertificates(vasp *pb.VASP, path string) (sent
 		SerialNumber:        hex.EncodeToString(vasp.IdentityCertificate.SerialNumber),
 		Endpoint:            vasp.TrisaEndpoint,
 		RegisteredDirectory: m.conf.DirectoryID,
+		PublicChain:         publicChain,
 	}
 
 	// Attempt at least one delivery, don't give up just because one email failed
@@ -365,6 +377,40 @@ func (m *EmailManager) SendExpiresAdminNotification(vasp *pb.VASP, reissueDate t
 	return 1, nil
 }
 
+// SendReissuanceCSRRequired notifies the admins that the identity certificate of the
+// VASP cannot be automatically reissued because it was issued from a CSR supplied by
+// the VASP and the VASP has not uploaded a new CSR for the reissued certificate.
+func (m *EmailManager) SendReissuanceCSRRequired(vasp *pb.VASP) (sent int, err error) {
+	// Create the template context
+	ctx := ExpiresAdminNotificationData{
+		VID:                 vasp.Id,
+		CommonName:          vasp.CommonName,
+		Endpoint:            vasp.TrisaEndpoint,
+		RegisteredDirectory: m.conf.DirectoryID,
+		BaseURL:             m.conf.AdminReviewBaseURL,
+	}
+
+	if vasp.IdentityCertificate != nil {
+		ctx.SerialNumber = strings.ToUpper(hex.EncodeToString(vasp.IdentityCertificate.SerialNumber))
+		ctx.Expiration, _ = time.Parse(time.RFC3339, vasp.IdentityCertificate.NotAfter)
+	}
+
+	msg, err := ReissuanceCSRRequiredEmail(
+		m.serviceEmail.Name, m.serviceEmail.Address,
+		m.adminsEmail.Name, m.adminsEmail.Address,
+		ctx,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = m.Send(msg); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
 // SendReissuanceReminder sends a reminder to all verified contacts that their identity
 // certificates will be expiring soon and that the system will automatically reissue the
 // certs on a particular date.
@@ -428,7 +474,8 @@ func (m *EmailManager) SendReissuanceReminder(vasp *pb.VASP, reissueDate time.Ti
 
 // SendReissuanceStarted sends the PKCS12 password via a secure one time link. This
 // method only sends the PKCS12 password to one email (to limit the delivery of secure
-// emails), ranking the contact emails by priority.
+// emails), ranking the contact emails by priority. If the whisper link is empty then
+// the certificates are being reissued from the CSR of the VASP and no password is sent.
 func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, whisperLink string) (sent int, err error) {
 	var errs *multierror.Error
 	ctx := ReissuanceStartedData{
@@ -485,3 +532,63 @@ func (m *EmailManager) SendReissuanceStarted(vasp *pb.VASP, whisperLink string)
 
 	return sent, errs.ErrorOrNil()
 }
+
+// SendCertificateRevoked notifies all verified contacts that the identity certificate
+// of their VASP has been revoked and for what reason. The certificate is passed in
+// rather than taken from the VASP since the revoked certificate may no longer be the
+// identity certificate of the VASP.
+func (m *EmailManager) SendCertificateRevoked(vasp *pb.VASP, cert *pb.Certificate, reason string) (sent int, err error) {
+	var errs *multierror.Error
+	ctx := CertificateRevokedData{
+		VID:                 vasp.Id,
+		CommonName:          vasp.CommonName,
+		Endpoint:            vasp.TrisaEndpoint,
+		RegisteredDirectory: m.conf.DirectoryID,
+		Reason:              reason,
+	}
+
+	if cert != nil {
+		ctx.SerialNumber = strings.ToUpper(hex.EncodeToString(cert.SerialNumber))
+	}
+
+	// Attempt at least one delivery, don't give up just because one email failed.
+	// Track how many emails and errors occurred during delivery.
+	iter := models.NewContactIterator(vasp.Contacts, true, true)
+	for iter.Next() {
+		contact, kind := iter.Value()
+		ctx.Name = contact.Name
+
+		msg, err := CertificateRevokedEmail(
+			m.serviceEmail.Name, m.serviceEmail.Address,
+			contact.Name, contact.Email,
+			ctx,
+		)
+
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("could not create certificate revoked email for %s contact: %s", kind, err))
+			continue
+		}
+
+		if err = m.Send(msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("could not send certificate revoked email for %s contact: %s", kind, err))
+			continue
+		}
+
+		sent++
+
+		if err = models.AppendEmailLog(contact, string(admin.CertificateRevoked), msg.Subject); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("could not log certificate revoked email for %s contact: %s", kind, err))
+			continue
+		}
+	}
+
+	if iterErrs := iter.Error(); iterErrs != nil {
+		errs = multierror.Append(errs, iterErrs)
+	}
+
+	if sent == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("no certificate revoked emails were successfully sent"))
+	}
+
+	return sent, errs.ErrorOrNil()
+}
@@ -119,6 +119,10 @@ func TestClientSendEmails(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, sent)
 
+	sent, err = email.SendReissuanceCSRRequired(vasp)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
 	sent, err = email.SendReissuanceReminder(vasp, reissueDate)
 	require.NoError(t, err)
 	require.Equal(t, 2, sent)
@@ -161,4 +165,21 @@ func TestClientSendEmails(t *testing.T) {
 	emailLog, err = models.GetEmailLog(vasp.Contacts.Billing)
 	require.NoError(t, err)
 	require.Len(t, emailLog, 0)
+
+	// Certificate revocation emails are sent to all verified contacts
+	sent, err = email.SendCertificateRevoked(vasp, vasp.IdentityCertificate, "key compromise")
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+
+	emailLog, err = models.GetEmailLog(vasp.Contacts.Technical)
+	require.NoError(t, err)
+	require.Len(t, emailLog, 5)
+	require.Equal(t, string(admin.CertificateRevoked), emailLog[4].Reason)
+	require.Equal(t, emails.CertificateRevokedRE, emailLog[4].Subject)
+
+	emailLog, err = models.GetEmailLog(vasp.Contacts.Administrative)
+	require.NoError(t, err)
+	require.Len(t, emailLog, 3)
+	require.Equal(t, string(admin.CertificateRevoked), emailLog[2].Reason)
+	require.Equal(t, emails.CertificateRevokedRE, emailLog[2].Subject)
 }
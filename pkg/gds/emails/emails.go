@@ -114,6 +114,7 @@ type DeliverCertsData struct {
 	SerialNumber        string // The serial number of the certificate
 	Endpoint            string // The expected endpoint for the TRISA service
 	RegisteredDirectory string // The directory name for the certificates being issued
+	PublicChain         bool   // If the certificates were issued from a VASP supplied CSR
 }
 
 // ExpiresAdminNotificationData to complete expires admin notification email templates.
@@ -202,6 +203,17 @@ type ReissuanceStartedData struct {
 	WhisperURL          string // Secure one-time whisper link for password retrieval
 }
 
+// CertificateRevokedData to complete certificate revoked email templates.
+type CertificateRevokedData struct {
+	Name                string // Used to address the email
+	VID                 string // The ID of the VASP/Registration
+	CommonName          string // The common name assigned to the cert
+	SerialNumber        string // The serial number of the revoked certificate
+	Endpoint            string // The expected endpoint for the TRISA service
+	RegisteredDirectory string // The directory name for the certificates being revoked
+	Reason              string // A description of why the certificate was revoked
+}
+
 //===========================================================================
 // Email Builders
 //===========================================================================
@@ -308,6 +320,27 @@ func ExpiresAdminNotificationEmail(sender, senderEmail, recipient, recipientEmai
 	return message, nil
 }
 
+// ReissuanceCSRRequiredEmail creates a new admin notification that a certificate cannot
+// be reissued until the VASP uploads a new CSR, ready for sending by rendering the text
+// and html templates with the supplied data. The notice uses the same data as the
+// expires admin notification email.
+func ReissuanceCSRRequiredEmail(sender, senderEmail, recipient, recipientEmail string, data ExpiresAdminNotificationData) (message *mail.SGMailV3, err error) {
+	var text, html string
+	if text, html, err = Render("reissuance_csr_required", data); err != nil {
+		return nil, err
+	}
+
+	message = mail.NewSingleEmail(
+		mail.NewEmail(sender, senderEmail),
+		ReissuanceCSRRequiredRE,
+		mail.NewEmail(recipient, recipientEmail),
+		text,
+		html,
+	)
+
+	return message, nil
+}
+
 // ReissuanceReminderEmail creates a new reissuance reminder email, ready for sending by
 // rendering the text and html templates with the supplied data.
 func ReissuanceReminderEmail(sender, senderEmail, recipient, recipientEmail string, data ReissuanceReminderData) (message *mail.SGMailV3, err error) {
@@ -346,6 +379,25 @@ func ReissuanceStartedEmail(sender, senderEmail, recipient, recipientEmail strin
 	return message, nil
 }
 
+// CertificateRevokedEmail creates a new certificate revoked email, ready for sending by
+// rendering the text and html templates with the supplied data.
+func CertificateRevokedEmail(sender, senderEmail, recipient, recipientEmail string, data CertificateRevokedData) (message *mail.SGMailV3, err error) {
+	var text, html string
+	if text, html, err = Render("certificate_revoked", data); err != nil {
+		return nil, err
+	}
+
+	message = mail.NewSingleEmail(
+		mail.NewEmail(sender, senderEmail),
+		CertificateRevokedRE,
+		mail.NewEmail(recipient, recipientEmail),
+		text,
+		html,
+	)
+
+	return message, nil
+}
+
 //===========================================================================
 // Template Builders
 //===========================================================================
@@ -75,6 +75,15 @@ func TestEmailBuilders(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, emails.DeliverCertsRE, mail.Subject)
 	generateMIME(t, mail, "deliver-certs.mim")
+	require.NotContains(t, mail.Content[0].Value, "certificate signing request")
+
+	dcdata.PublicChain = true
+	mail, err = emails.DeliverCertsEmail(sender, senderEmail, recipient, recipientEmail, "testdata/foo.zip", dcdata)
+	require.NoError(t, err)
+	require.Equal(t, emails.DeliverCertsRE, mail.Subject)
+	require.Contains(t, mail.Content[0].Value, "certificate signing request")
+	require.NotContains(t, mail.Content[0].Value, "PKCS12")
+	generateMIME(t, mail, "deliver-public-certs.mim")
 
 	expires := time.Date(2022, time.July, 18, 12, 11, 35, 0, time.UTC)
 	reissuance := time.Date(2022, time.July, 25, 14, 0, 0, 0, time.UTC)
@@ -85,6 +94,12 @@ func TestEmailBuilders(t *testing.T) {
 	require.Equal(t, emails.ExpiresAdminNotificationRE, mail.Subject, "incorrect subject")
 	generateMIME(t, mail, "expires-admin-notification.mim")
 
+	mail, err = emails.ReissuanceCSRRequiredEmail(sender, senderEmail, recipient, recipientEmail, eandata)
+	require.NoError(t, err)
+	require.Equal(t, emails.ReissuanceCSRRequiredRE, mail.Subject, "incorrect subject")
+	require.Contains(t, mail.Content[0].Value, "upload a new CSR")
+	generateMIME(t, mail, "reissuance-csr-required.mim")
+
 	rmdata := emails.ReissuanceReminderData{Name: recipient, VID: "42", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443", RegisteredDirectory: "trisatest.net", Expiration: expires, Reissuance: reissuance}
 	mail, err = emails.ReissuanceReminderEmail(sender, senderEmail, recipient, recipientEmail, rmdata)
 	require.NoError(t, err)
@@ -96,6 +111,20 @@ func TestEmailBuilders(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, emails.ReissuanceStartedRE, mail.Subject, "incorrect subject")
 	generateMIME(t, mail, "reissuance-started.mim")
+
+	// If the certificates are reissued from a CSR there is no whisper link
+	rsdata.WhisperURL = ""
+	mail, err = emails.ReissuanceStartedEmail(sender, senderEmail, recipient, recipientEmail, rsdata)
+	require.NoError(t, err)
+	require.Contains(t, mail.Content[0].Value, "certificate signing request")
+	require.NotContains(t, mail.Content[0].Value, "PKCS12")
+	generateMIME(t, mail, "reissuance-started-csr.mim")
+
+	crdata := emails.CertificateRevokedData{Name: recipient, VID: "42", CommonName: "example.com", SerialNumber: "1234abcdef56789", Endpoint: "trisa.example.com:443", RegisteredDirectory: "trisatest.net", Reason: "key compromise"}
+	mail, err = emails.CertificateRevokedEmail(sender, senderEmail, recipient, recipientEmail, crdata)
+	require.NoError(t, err)
+	require.Equal(t, emails.CertificateRevokedRE, mail.Subject, "incorrect subject")
+	generateMIME(t, mail, "certificate-revoked.mim")
 }
 
 func TestVerifyContactURL(t *testing.T) {
@@ -440,3 +469,37 @@ func (suite *EmailTestSuite) TestSendReissuanceStartedEmail() {
 
 	generateMIME(suite.T(), msg, "reissuance-started.mim")
 }
+
+func (suite *EmailTestSuite) TestSendCertificateRevokedEmail() {
+	// Load the test suite config
+	require := suite.Require()
+	sender, err := mail.ParseAddress(suite.conf.ServiceEmail)
+	require.NoError(err)
+	recipient, err := mail.ParseAddress(suite.conf.AdminEmail)
+	require.NoError(err)
+
+	// Init the mocked SendGrid client
+	email, err := emails.New(suite.conf)
+	require.NoError(err)
+
+	// Create the certificate revoked email.
+	data := emails.CertificateRevokedData{
+		Name:                recipient.Name,
+		VID:                 "42",
+		CommonName:          "test.example.com",
+		Endpoint:            "test.example.com:443",
+		RegisteredDirectory: "trisatest.net",
+		SerialNumber:        "1234abcdef56789",
+		Reason:              "key compromise",
+	}
+	msg, err := emails.CertificateRevokedEmail(sender.Name, sender.Address, recipient.Name, recipient.Address, data)
+
+	require.NoError(err)
+	require.NoError(email.Send(msg))
+	require.Len(emails.MockEmails, 1)
+	expected, err := json.Marshal(msg)
+	require.NoError(err)
+	require.Equal(expected, emails.MockEmails[0])
+
+	generateMIME(suite.T(), msg, "certificate-revoked.mim")
+}
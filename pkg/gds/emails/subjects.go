@@ -9,4 +9,6 @@ const (
 	ExpiresAdminNotificationRE = "A TRISA Identity Certificate is Expiring Soon"
 	ReissuanceReminderRE       = "TRISA Identity Certificate Expiration"
 	ReissuanceStartedRE        = "TRISA PKCS12 Password for Certificate Reissuance"
+	ReissuanceCSRRequiredRE    = "A TRISA Identity Certificate Requires a New CSR"
+	CertificateRevokedRE       = "TRISA Identity Certificate Revoked"
 )
@@ -22,6 +22,7 @@ import (
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -152,6 +153,14 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		}
 	}
 
+	// Validate the certificate signing request if the VASP supplied one so that the
+	// directory does not have to generate a private key for the identity certificate.
+	var csr string
+	if csr, err = csrFromContext(ctx, vasp.CommonName); err != nil {
+		log.Warn().Err(err).Str("common_name", vasp.CommonName).Msg("invalid certificate signing request")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid certificate signing request: %s", err)
+	}
+
 	// Validate partial VASP record to ensure that it can be registered.
 	if err = vasp.Validate(true); err != nil {
 		// TODO: Ignore ErrCompleteNationalIdentifierLegalPerson until validation See #34
@@ -236,13 +245,16 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		}
 	}
 
-	// Create PKCS12 password along with certificate request.
-	var certRequest *models.CertificateRequest
-	password := secrets.CreateToken(16)
+	// Create PKCS12 password along with certificate request unless a CSR was supplied.
+	var (
+		certRequest *models.CertificateRequest
+		password    string
+	)
 	if certRequest, err = models.NewCertificateRequest(vasp); err != nil {
 		log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not create certificate request")
 		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
 	}
+	certRequest.Csr = csr
 
 	if err = models.UpdateCertificateRequestStatus(certRequest, models.CertificateRequestState_INITIALIZED, "created certificate request", email); err != nil {
 		log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not update certificate request status")
@@ -250,14 +262,17 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 	}
 
 	// Make a new secret of type "password"
-	secretType := "password"
-	if err = s.svc.secret.With(certRequest.Id).CreateSecret(ctx, secretType); err != nil {
-		log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not create new secret for pkcs12 password")
-		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
-	}
-	if err = s.svc.secret.With(certRequest.Id).AddSecretVersion(ctx, secretType, []byte(password)); err != nil {
-		log.Error().Err(err).Str("vasp", vasp.Id).Msg("unable to add secret version for pkcs12 password")
-		return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+	if certRequest.Csr == "" {
+		password = secrets.CreateToken(16)
+		secretType := "password"
+		if err = s.svc.secret.With(certRequest.Id).CreateSecret(ctx, secretType); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Msg("could not create new secret for pkcs12 password")
+			return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+		}
+		if err = s.svc.secret.With(certRequest.Id).AddSecretVersion(ctx, secretType, []byte(password)); err != nil {
+			log.Error().Err(err).Str("vasp", vasp.Id).Msg("unable to add secret version for pkcs12 password")
+			return nil, status.Error(codes.Internal, "internal error with registration, please contact admins")
+		}
 	}
 
 	// Create certificate request
@@ -286,9 +301,38 @@ func (s *GDS) Register(ctx context.Context, in *api.RegisterRequest) (out *api.R
 		Message:             "a verification code has been sent to contact emails, please check spam folder if it has not arrived; pkcs12 password attached, this is the only time it will be available -- do not lose!",
 		Pkcs12Password:      password,
 	}
+
+	if certRequest.Csr != "" {
+		out.Message = "a verification code has been sent to contact emails, please check spam folder if it has not arrived; certificates will be issued from the supplied certificate signing request"
+	}
 	return out, nil
 }
 
+// CSRMetadataKey is the gRPC metadata key that VASPs can use to supply a PEM encoded
+// certificate signing request with their registration, since the TRISA RegisterRequest
+// does not have a CSR field.
+const CSRMetadataKey = "csr-bin"
+
+// Returns the PEM encoded certificate signing request from the incoming metadata if one
+// was supplied, validating that it is for the common name of the registration.
+func csrFromContext(ctx context.Context, commonName string) (_ string, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(CSRMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", nil
+	}
+
+	req := &models.CertificateRequest{CommonName: commonName}
+	if err = models.AttachCSR(req, []byte(values[0])); err != nil {
+		return "", err
+	}
+	return req.Csr, nil
+}
+
 // Lookup a VASP entity by name or ID to get full details including the TRISA certification
 // if it exists and the entity has been verified.
 func (s *GDS) Lookup(ctx context.Context, in *api.LookupRequest) (out *api.LookupReply, err error) {
@@ -296,7 +340,8 @@ func (s *GDS) Lookup(ctx context.Context, in *api.LookupRequest) (out *api.Looku
 	switch {
 	case in.Id != "":
 		// TODO: add registered directory to lookup
-		if vasp, err = s.db.RetrieveVASP(in.Id); err != nil {
+		// Deleted VASPs are retained until they are purged but cannot be looked up
+		if vasp, err = s.db.RetrieveVASP(in.Id); err != nil || models.IsArchived(vasp) {
 			log.Debug().Err(err).Str("id", in.Id).Str("registered_directory", in.RegisteredDirectory).Msg("could not find VASP by ID")
 			return nil, status.Error(codes.NotFound, "could not find VASP by ID")
 		}
@@ -371,6 +416,17 @@ func (s *GDS) Search(ctx context.Context, in *api.SearchRequest) (out *api.Searc
 		return nil, status.Error(codes.Aborted, err.Error())
 	}
 
+	// If the names did not match, they may contain a typo; retry with a fuzzy full text
+	// search of the names, which returns results ordered by relevance.
+	if len(vasps) == 0 && len(in.Name) > 0 {
+		query["text"] = in.Name
+		query["fuzzy"] = true
+		if vasps, err = s.db.SearchVASPs(query); err != nil {
+			log.Error().Err(err).Msg("fuzzy vasp search failed")
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+	}
+
 	// Build search results to return
 	out = &api.SearchReply{
 		Results: make([]*api.SearchReply_Result, 0, len(vasps)),
@@ -401,7 +457,8 @@ func (s *GDS) Verification(ctx context.Context, in *api.VerificationRequest) (ou
 	switch {
 	case in.Id != "":
 		// TODO: add registered directory to retrieve
-		if vasp, err = s.db.RetrieveVASP(in.Id); err != nil {
+		// Deleted VASPs are retained until they are purged but cannot be looked up
+		if vasp, err = s.db.RetrieveVASP(in.Id); err != nil || models.IsArchived(vasp) {
 			log.Debug().Err(err).Str("id", in.Id).Str("registered_directory", in.RegisteredDirectory).Msg("could not find VASP by ID")
 			return nil, status.Error(codes.NotFound, "could not find VASP by ID")
 		}
@@ -451,7 +508,7 @@ func (s *GDS) VerifyContact(ctx context.Context, in *api.VerifyContactRequest) (
 
 	// Retrieve VASP associated with contact from the database.
 	var vasp *pb.VASP
-	if vasp, err = s.db.RetrieveVASP(in.Id); err != nil {
+	if vasp, err = s.db.RetrieveVASP(in.Id); err != nil || models.IsArchived(vasp) {
 		log.Warn().Err(err).Str("id", in.Id).Msg("could not retrieve vasp")
 		return nil, status.Error(codes.NotFound, "could not find associated VASP record by ID")
 	}
@@ -2,6 +2,12 @@ package gds_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"testing"
 	"time"
@@ -13,6 +19,7 @@ import (
 	api "github.com/trisacrypto/trisa/pkg/trisa/gds/api/v1beta1"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
@@ -204,6 +211,73 @@ func (s *gdsTestSuite) TestRegister() {
 	s.CheckEmails(messages)
 }
 
+// TestRegisterCSR tests that a VASP can supply a CSR with its registration so that no
+// PKCS12 password is created for the certificate request.
+func (s *gdsTestSuite) TestRegisterCSR() {
+	s.LoadEmptyFixtures()
+	s.SetupGDS()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	require := s.Require()
+	ctx := context.Background()
+	refVASP := s.fixtures[vasps]["charliebank"].(*pb.VASP)
+
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := api.NewTRISADirectoryClient(s.grpc.Conn)
+
+	contacts := &pb.Contacts{
+		Technical: &pb.Contact{Name: "Technical Person", Email: "technical@example.com"},
+	}
+	request := &api.RegisterRequest{
+		Entity:           refVASP.Entity,
+		Contacts:         contacts,
+		TrisaEndpoint:    "trisatest.net:443",
+		Website:          refVASP.Website,
+		BusinessCategory: refVASP.BusinessCategory,
+		VaspCategories:   refVASP.VaspCategories,
+		EstablishedOn:    refVASP.EstablishedOn,
+		Trixo:            refVASP.Trixo,
+	}
+
+	// The CSR must be PEM encoded
+	md := metadata.AppendToOutgoingContext(ctx, gds.CSRMetadataKey, "foo")
+	_, err := client.Register(md, request)
+	s.StatusError(err, codes.InvalidArgument, "invalid certificate signing request: csr must be a PEM encoded certificate request")
+
+	// The CSR must be for the common name of the registration
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}}, key)
+	require.NoError(err)
+	md = metadata.AppendToOutgoingContext(ctx, gds.CSRMetadataKey, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})))
+	_, err = client.Register(md, request)
+	s.StatusError(err, codes.InvalidArgument, `invalid certificate signing request: csr is not for common name "trisatest.net"`)
+
+	// Successful registration with a CSR does not return a password
+	der, err = x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisatest.net"}}, key)
+	require.NoError(err)
+	csr := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	md = metadata.AppendToOutgoingContext(ctx, gds.CSRMetadataKey, string(csr))
+	reply, err := client.Register(md, request)
+	require.NoError(err)
+	require.Empty(reply.Pkcs12Password)
+	require.Contains(reply.Message, "certificate signing request")
+
+	// The CSR should be stored on the certificate request without a password secret
+	v, err := s.svc.GetStore().RetrieveVASP(reply.Id)
+	require.NoError(err)
+	ids, err := models.GetCertReqIDs(v)
+	require.NoError(err)
+	require.Len(ids, 1)
+	certReq, err := s.svc.GetStore().RetrieveCertReq(ids[0])
+	require.NoError(err)
+	require.Equal(string(csr), certReq.Csr)
+
+	_, err = s.svc.GetSecretManager().With(certReq.Id).GetLatestVersion(ctx, "password")
+	require.Error(err)
+}
+
 // TestLookup test that the Lookup RPC correctly returns details for a VASP.
 func (s *gdsTestSuite) TestLookup() {
 	// Load the fixtures and start the GDS server
@@ -249,6 +323,22 @@ func (s *gdsTestSuite) TestLookup() {
 	request.CommonName = "invalid.name"
 	_, err = client.Lookup(ctx, request)
 	require.Error(err)
+
+	// Deleted VASPs cannot be looked up by ID or common name
+	defer s.ResetFixtures()
+	vasp, err := s.svc.GetStore().RetrieveVASP(charlieVASP.Id)
+	require.NoError(err)
+	require.NoError(models.ArchiveVASP(vasp, "admin@example.com"))
+	require.NoError(s.svc.GetStore().UpdateVASP(vasp))
+
+	request.CommonName = charlieVASP.CommonName
+	_, err = client.Lookup(ctx, request)
+	require.Equal(codes.NotFound, status.Code(err))
+
+	request.CommonName = ""
+	request.Id = charlieVASP.Id
+	_, err = client.Lookup(ctx, request)
+	require.Equal(codes.NotFound, status.Code(err))
 }
 
 // TestSearch tests that the Search RPC returns the correct search results.
@@ -306,6 +396,14 @@ func (s *gdsTestSuite) TestSearch() {
 	require.Empty(reply.Error)
 	require.Len(reply.Results, 2)
 
+	// Names with typos should fall back to a fuzzy search
+	request.Name = []string{"CharleiBank"}
+	reply, err = client.Search(ctx, request)
+	require.NoError(err)
+	require.Empty(reply.Error)
+	require.Len(reply.Results, 1)
+	require.Equal(charlieVASP.Id, reply.Results[0].Id)
+
 	// Search by website
 	request = &api.SearchRequest{
 		Website: []string{"https://trisa.charliebank.io"},
@@ -13,6 +13,7 @@ import (
 	api "github.com/trisacrypto/directory/pkg/gds/members/v1alpha1"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/gds/store"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"github.com/trisacrypto/trisa/pkg/trisa/mtls"
 	"github.com/trisacrypto/trisa/pkg/trust"
@@ -147,83 +148,36 @@ func (s *Members) List(ctx context.Context, in *api.ListRequest) (out *api.ListR
 		in.PageSize = defaultPageSize
 	}
 
-	// If a page cursor is provided, load it - otherwise create a cursor for iteration
-	cursor := &models.PageCursor{}
-	if in.PageToken != "" {
-		if err = cursor.Load(in.PageToken); err != nil {
+	// Query the verified VASPs; the page token is validated by the store.
+	query := &search.Query{
+		VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED},
+		PageSize:           in.PageSize,
+		PageToken:          in.PageToken,
+	}
+
+	var page *search.Page
+	if page, err = s.db.QueryVASPs(query); err != nil {
+		switch err {
+		case search.ErrInvalidPageToken:
 			log.Warn().Err(err).Msg("invalid page token on list request")
 			return nil, status.Error(codes.InvalidArgument, "invalid page token")
-		}
-
-		// Validate the request has not changed
-		if cursor.PageSize != in.PageSize {
-			log.Debug().Int32("cursor", cursor.PageSize).Int32("opts", in.PageSize).Msg("invalid members list request: mismatched page size")
+		case search.ErrPageSizeChanged:
+			log.Debug().Int32("opts", in.PageSize).Msg("invalid members list request: mismatched page size")
 			return nil, status.Error(codes.InvalidArgument, "page size cannot change between requests")
+		default:
+			log.Error().Err(err).Msg("could not query VASPs")
+			return nil, status.Error(codes.Internal, "could not iterate over directory service")
 		}
-
-	} else {
-		// Update the cursor with the input request
-		cursor.PageSize = in.PageSize
 	}
 
 	// Create response
 	out = &api.ListReply{
-		Vasps: make([]*api.VASPMember, 0, cursor.PageSize),
+		Vasps:         make([]*api.VASPMember, 0, len(page.Results)),
+		NextPageToken: page.NextPageToken,
 	}
 
-	// Create the VASPs iterator to begin collecting validated VASPs data
-	iter := s.db.ListVASPs()
-	defer iter.Release()
-
-	// If necessary, seek to the next key specified by the cursor.
-	if cursor.NextVasp != "" {
-		// If iter.SeekId() returns false (e.g. seek did not find the specified key) then
-		// iter.Next() should also return false, so it isn't necessary to check the return.
-		// NOTE: next key must be deleted after it's used for seeking so that the last
-		// page doesn't retain the old key and loop forever.
-		iter.SeekId(cursor.NextVasp)
-		cursor.NextVasp = ""
-
-		// Because we're going to be calling Next, we need to back up one key to ensure
-		// that we start on the right key in the for loop.
-		iter.Prev()
-	}
-
-	// Iterate over VASPs, collecting the one we're looking for.
-	for iter.Next() {
-		// Check if we're done iterating - if so and there is next data, there is
-		// another page, so create the page token to return it.
-		if len(out.Vasps) == int(cursor.PageSize) {
-			cursor.NextVasp = iter.Id()
-			break
-		}
-
-		// Collect the VASP from the iterator
-		var vasp *pb.VASP
-		if vasp, err = iter.VASP(); err != nil {
-			log.Error().Err(err).Msg("could not parse VASP from database")
-			continue
-		}
-
-		// Skip any VASPs that are not verified yet
-		if vasp.VerificationStatus != pb.VerificationState_VERIFIED {
-			continue
-		}
-
-		out.Vasps = append(out.Vasps, GetVASPMember(vasp))
-	}
-
-	if err = iter.Error(); err != nil {
-		log.Error().Err(err).Msg("could not iterate over VASPs")
-		return nil, status.Error(codes.Internal, "could not iterate over directory service")
-	}
-
-	// Check if there is a next page cursor
-	if cursor.NextVasp != "" {
-		if out.NextPageToken, err = cursor.Dump(); err != nil {
-			log.Error().Err(err).Msg("could not serialize next page token on vasp member list")
-			return nil, status.Error(codes.Internal, "could not create next page token")
-		}
+	for _, result := range page.Results {
+		out.Vasps = append(out.Vasps, GetVASPMember(result.VASP))
 	}
 
 	// Request Complete
@@ -258,7 +212,7 @@ func (s *Members) Summary(ctx context.Context, in *api.SummaryRequest) (out *api
 	if in.MemberId != "" {
 		// Fetch the requested VASP if provided
 		var vasp *pb.VASP
-		if vasp, err = s.db.RetrieveVASP(in.MemberId); err != nil {
+		if vasp, err = s.db.RetrieveVASP(in.MemberId); err != nil || models.IsArchived(vasp) {
 			log.Warn().Err(err).Str("vasp_id", in.MemberId).Msg("VASP not found")
 			return nil, status.Error(codes.NotFound, "requested VASP not found")
 		}
@@ -280,6 +234,11 @@ func (s *Members) Summary(ctx context.Context, in *api.SummaryRequest) (out *api
 			continue
 		}
 
+		// Deleted VASPs are no longer members of the directory
+		if models.IsArchived(vasp) {
+			continue
+		}
+
 		// Only count verified VASPs
 		if vasp.VerificationStatus == pb.VerificationState_VERIFIED {
 			// Parse verified timestamp
@@ -316,7 +275,7 @@ func (s *Members) Summary(ctx context.Context, in *api.SummaryRequest) (out *api
 func (s *Members) Details(ctx context.Context, in *api.DetailsRequest) (out *api.MemberDetails, err error) {
 	// Fetch the requested VASP if provided
 	var vasp *pb.VASP
-	if vasp, err = s.db.RetrieveVASP(in.MemberId); err != nil {
+	if vasp, err = s.db.RetrieveVASP(in.MemberId); err != nil || models.IsArchived(vasp) {
 		log.Warn().Err(err).Str("vasp_id", in.MemberId).Msg("VASP not found")
 		return nil, status.Error(codes.NotFound, "requested VASP not found")
 	}
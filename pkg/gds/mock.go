@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
@@ -84,7 +85,7 @@ func NewMock(conf config.Config, trtlConn *grpc.ClientConn) (s *Service, err err
 		}
 	}
 
-	if svc.certs, err = sectigo.New(conf.Sectigo); err != nil {
+	if svc.certs, err = certauth.New(conf.CertMan, conf.Sectigo); err != nil {
 		return nil, err
 	}
 
@@ -116,7 +117,10 @@ func MockConfig() config.Config {
 				GoogleAudience:         "http://localhost",
 				AuthorizedEmailDomains: []string{"gds.dev"},
 			},
-			TokenKeys: nil,
+			TokenKeys:            nil,
+			Roles:                map[string]string{"admin@gds.dev": "superuser"},
+			DefaultRole:          "readonly",
+			PendingActionTimeout: 72 * time.Hour,
 		},
 		Members: config.MembersConfig{
 			Enabled:  true,
@@ -151,6 +155,18 @@ func MockConfig() config.Config {
 			Storage:  "testdata/backups",
 			Keep:     1,
 		},
+		Reissuance: config.ReissuanceConfig{
+			Enabled:           false,
+			Interval:          24 * time.Hour,
+			AdminNoticeBefore: 30 * 24 * time.Hour,
+			ReminderBefore:    21 * 24 * time.Hour,
+			ReissueBefore:     10 * 24 * time.Hour,
+		},
+		Archive: config.ArchiveConfig{
+			Enabled:   false,
+			Interval:  24 * time.Hour,
+			Retention: 30 * 24 * time.Hour,
+		},
 		Secrets: config.SecretsConfig{
 			Credentials: "",
 			Project:     "",
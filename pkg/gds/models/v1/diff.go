@@ -0,0 +1,108 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// DiffJSON compares two JSON documents and returns the fields that differ between them
+// ordered by their path. Objects are compared field by field and arrays are compared
+// element by element if they have the same length; otherwise the entire value is
+// reported as changed. An empty document is treated as null, e.g. when a record is
+// created or deleted.
+func DiffJSON(before, after []byte) (changes []*FieldChange, err error) {
+	var prev, next interface{}
+	if prev, err = decodeJSON(before); err != nil {
+		return nil, fmt.Errorf("could not decode original document: %w", err)
+	}
+
+	if next, err = decodeJSON(after); err != nil {
+		return nil, fmt.Errorf("could not decode modified document: %w", err)
+	}
+
+	changes = make([]*FieldChange, 0)
+	if err = diffValues("", prev, next, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func decodeJSON(data []byte) (value interface{}, err error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func diffValues(path string, before, after interface{}, changes *[]*FieldChange) (err error) {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+
+	switch prev := before.(type) {
+	case map[string]interface{}:
+		if next, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(prev)+len(next))
+			for key := range prev {
+				keys = append(keys, key)
+			}
+			for key := range next {
+				if _, ok := prev[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				field := key
+				if path != "" {
+					field = path + "." + key
+				}
+
+				if err = diffValues(field, prev[key], next[key], changes); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []interface{}:
+		if next, ok := after.([]interface{}); ok && len(prev) == len(next) {
+			for i := range prev {
+				if err = diffValues(path+"["+strconv.Itoa(i)+"]", prev[i], next[i], changes); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	change := &FieldChange{Path: path}
+	if change.Before, err = encodeJSON(before); err != nil {
+		return err
+	}
+	if change.After, err = encodeJSON(after); err != nil {
+		return err
+	}
+	*changes = append(*changes, change)
+	return nil
+}
+
+func encodeJSON(value interface{}) (_ string, err error) {
+	if value == nil {
+		return "", nil
+	}
+
+	var data []byte
+	if data, err = json.Marshal(value); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDiffJSON(t *testing.T) {
+	// Identical documents have no changes
+	changes, err := models.DiffJSON([]byte(`{"a": 1, "b": [1, 2]}`), []byte(`{"b": [1, 2], "a": 1}`))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Nested fields and array elements are compared individually
+	before := []byte(`{"name": "alice", "contacts": {"legal": {"email": "a@example.com"}}, "tags": ["x", "y"], "urls": ["a"]}`)
+	after := []byte(`{"name": "alice", "contacts": {"legal": {"email": "b@example.com", "phone": "555"}}, "tags": ["x", "z"], "urls": ["a", "b"], "website": "example.com"}`)
+	changes, err = models.DiffJSON(before, after)
+	require.NoError(t, err)
+	require.Equal(t, []*models.FieldChange{
+		{Path: "contacts.legal.email", Before: `"a@example.com"`, After: `"b@example.com"`},
+		{Path: "contacts.legal.phone", Before: "", After: `"555"`},
+		{Path: "tags[1]", Before: `"y"`, After: `"z"`},
+		{Path: "urls", Before: `["a"]`, After: `["a","b"]`},
+		{Path: "website", Before: "", After: `"example.com"`},
+	}, changes)
+
+	// Deleting a document is a single change
+	changes, err = models.DiffJSON([]byte(`{"vasp": {"id": "42"}}`), []byte(`{}`))
+	require.NoError(t, err)
+	require.Equal(t, []*models.FieldChange{{Path: "vasp", Before: `{"id":"42"}`, After: ""}}, changes)
+
+	changes, err = models.DiffJSON([]byte(`{"id": "42"}`), nil)
+	require.NoError(t, err)
+	require.Equal(t, []*models.FieldChange{{Path: "", Before: `{"id":"42"}`, After: ""}}, changes)
+
+	// Cannot diff invalid JSON
+	_, err = models.DiffJSON([]byte(`{"id":`), nil)
+	require.Error(t, err)
+
+	// Compare protojson encoded VASP records
+	vasp := &pb.VASP{Id: "42", CommonName: "example.com", Website: "https://example.com"}
+	updated := proto.Clone(vasp).(*pb.VASP)
+	updated.Website = "https://example.org"
+	updated.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+
+	original, err := protojson.Marshal(vasp)
+	require.NoError(t, err)
+	modified, err := protojson.Marshal(updated)
+	require.NoError(t, err)
+
+	changes, err = models.DiffJSON(original, modified)
+	require.NoError(t, err)
+	require.Equal(t, []*models.FieldChange{
+		{Path: "verificationStatus", Before: "", After: `"PENDING_REVIEW"`},
+		{Path: "website", Before: `"https://example.com"`, After: `"https://example.org"`},
+	}, changes)
+}
@@ -1,8 +1,11 @@
 package models
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -17,6 +20,18 @@ var (
 	ErrorNotFound      = errors.New("not found")
 )
 
+// Audit log actions that record events which do not change the verification state of
+// the VASP, e.g. the steps of automated processes so that they are not repeated.
+const (
+	ActionExpiresAdminNotice = "expires_admin_notice"
+	ActionReissuanceReminder = "reissuance_reminder"
+	ActionReissuanceStarted  = "reissuance_started"
+	ActionCSRRequired        = "csr_required"
+	ActionCertificateRevoked = "certificate_revoked"
+	ActionArchived           = "archived"
+	ActionRestored           = "restored"
+)
+
 // GetAdminVerificationToken from the extra data on the VASP record.
 func GetAdminVerificationToken(vasp *pb.VASP) (_ string, err error) {
 	// If the extra data is nil, return empty string with no error
@@ -125,6 +140,160 @@ func AppendAuditLog(vasp *pb.VASP, entry *AuditLogEntry) (err error) {
 	return nil
 }
 
+// HasAuditLogAction returns true if the audit log of the VASP contains an entry for the
+// specified action and certificate ID.
+func HasAuditLogAction(vasp *pb.VASP, action, certID string) (_ bool, err error) {
+	var auditLog []*AuditLogEntry
+	if auditLog, err = GetAuditLog(vasp); err != nil {
+		return false, err
+	}
+
+	for _, entry := range auditLog {
+		if entry.Action == action && entry.CertificateId == certID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsArchived returns true if the VASP has been deleted by an admin but has not yet been
+// purged from the store. Archived VASPs must not be returned by directory lookups or
+// searches. A VASP whose extra data cannot be decoded is treated as not archived.
+func IsArchived(vasp *pb.VASP) bool {
+	if vasp.Extra == nil {
+		return false
+	}
+
+	extra := &GDSExtraData{}
+	if err := vasp.Extra.UnmarshalTo(extra); err != nil {
+		log.Warn().Err(err).Str("vasp_id", vasp.Id).Msg("could not deserialize extra to determine if vasp is archived")
+		return false
+	}
+	return extra.Archived != ""
+}
+
+// GetArchived returns when the VASP was deleted by an admin; the zero time is returned
+// if the VASP has not been archived.
+func GetArchived(vasp *pb.VASP) (archived time.Time, err error) {
+	if vasp.Extra == nil {
+		return archived, nil
+	}
+
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return archived, err
+	}
+
+	if extra.Archived == "" {
+		return archived, nil
+	}
+
+	if archived, err = time.Parse(time.RFC3339, extra.Archived); err != nil {
+		return archived, fmt.Errorf("could not parse archived timestamp: %s", err)
+	}
+	return archived, nil
+}
+
+// ArchiveVASP marks the VASP as deleted by the specified admin and records the deletion
+// in the audit log without changing the verification state of the VASP. The caller must
+// store the updated VASP; it is retained until it is restored or purged.
+func ArchiveVASP(vasp *pb.VASP, source string) (err error) {
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	if extra.Archived != "" {
+		return errors.New("vasp has already been archived")
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	extra.Archived = now
+	extra.ArchivedBy = source
+	extra.AuditLog = append(extra.AuditLog, &AuditLogEntry{
+		Timestamp:     now,
+		PreviousState: vasp.VerificationStatus,
+		CurrentState:  vasp.VerificationStatus,
+		Description:   "vasp deleted",
+		Source:        source,
+		Action:        ActionArchived,
+	})
+
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreVASP removes the archived mark from a deleted VASP so that it is returned by
+// the directory again and records the restoration in the audit log.
+func RestoreVASP(vasp *pb.VASP, source string) (err error) {
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	if extra.Archived == "" {
+		return errors.New("vasp has not been archived")
+	}
+
+	extra.Archived = ""
+	extra.ArchivedBy = ""
+	extra.AuditLog = append(extra.AuditLog, &AuditLogEntry{
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PreviousState: vasp.VerificationStatus,
+		CurrentState:  vasp.VerificationStatus,
+		Description:   "vasp restored",
+		Source:        source,
+		Action:        ActionRestored,
+	})
+
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPendingCSR returns the CSR supplied by the VASP for its next certificate or an
+// empty string if the VASP has not supplied one.
+func GetPendingCSR(vasp *pb.VASP) (_ string, err error) {
+	// If the extra data is nil, return empty string with no error
+	if vasp.Extra == nil {
+		return "", nil
+	}
+
+	// Unmarshal the extra data field on the VASP
+	extra := &GDSExtraData{}
+	if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+		return "", err
+	}
+	return extra.GetCsr(), nil
+}
+
+// SetPendingCSR stores the CSR that the next certificate of the VASP is issued from on
+// the extra data of the VASP record; an empty string clears the pending CSR.
+func SetPendingCSR(vasp *pb.VASP, csr string) (err error) {
+	// Must unmarshal previous extra to ensure that data besides the CSR is not overwritten.
+	extra := &GDSExtraData{}
+	if vasp.Extra != nil {
+		if err = vasp.Extra.UnmarshalTo(extra); err != nil {
+			return fmt.Errorf("could not deserialize previous extra: %s", err)
+		}
+	}
+
+	extra.Csr = csr
+
+	// Serialize the extra back to the VASP.
+	if vasp.Extra, err = anypb.New(extra); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetCertReqIDs returns the list of associated CertificateRequest IDs for the VASP record.
 func GetCertReqIDs(vasp *pb.VASP) (_ []string, err error) {
 	// If the extra data is nil, return nil (no certificate requests).
@@ -350,6 +519,49 @@ func NewCertificateRequest(vasp *pb.VASP) (certRequest *CertificateRequest, err
 	return certRequest, nil
 }
 
+// AttachCSR validates the PEM encoded certificate signing request supplied by the VASP
+// and attaches it to the certificate request so that the certificate is issued from
+// the CSR. The CSR signature must be valid, the subject common name of the CSR must be
+// the common name of the certificate request, and the CSR cannot request any subject
+// alternative names other than the common name so that a VASP cannot use its request
+// to obtain a certificate for another VASP's endpoint.
+func AttachCSR(request *CertificateRequest, csr []byte) (err error) {
+	if request == nil {
+		return errors.New("must supply a certificate request to attach the csr to")
+	}
+
+	block, _ := pem.Decode(csr)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return errors.New("csr must be a PEM encoded certificate request")
+	}
+
+	var req *x509.CertificateRequest
+	if req, err = x509.ParseCertificateRequest(block.Bytes); err != nil {
+		return fmt.Errorf("could not parse csr: %s", err)
+	}
+
+	if err = req.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid csr signature: %s", err)
+	}
+
+	if request.CommonName == "" || req.Subject.CommonName != request.CommonName {
+		return fmt.Errorf("csr is not for common name %q", request.CommonName)
+	}
+
+	for _, name := range req.DNSNames {
+		if name != request.CommonName {
+			return fmt.Errorf("csr dns name %q does not match common name %q", name, request.CommonName)
+		}
+	}
+
+	if len(req.IPAddresses) > 0 || len(req.EmailAddresses) > 0 || len(req.URIs) > 0 {
+		return errors.New("csr can only request the common name as a subject alternative name")
+	}
+
+	request.Csr = string(pem.EncodeToMemory(block))
+	return nil
+}
+
 // UpdateCertificateRequestStatus changes the status of a CertificateRequest and appends
 // an entry to the audit log.
 func UpdateCertificateRequestStatus(request *CertificateRequest, state CertificateRequestState, description string, source string) (err error) {
@@ -378,6 +590,76 @@ func UpdateCertificateRequestStatus(request *CertificateRequest, state Certifica
 	return nil
 }
 
+// NewPendingAction creates a proposal to perform an action on a VASP that expires after
+// the specified timeout if it is not approved or canceled by then.
+func NewPendingAction(actionType PendingActionType, vaspID string, params map[string]string, proposedBy string, timeout time.Duration) *PendingAction {
+	return &PendingAction{
+		Type:       actionType,
+		Vasp:       vaspID,
+		Params:     params,
+		Status:     PendingActionState_ACTION_PROPOSED,
+		ProposedBy: proposedBy,
+		Expires:    time.Now().Add(timeout).Format(time.RFC3339),
+	}
+}
+
+// IsExpired returns true if the action is still proposed but its expiration timestamp
+// has passed. Proposals with an invalid expiration timestamp are also expired.
+func (a *PendingAction) IsExpired(now time.Time) bool {
+	if a.Status != PendingActionState_ACTION_PROPOSED {
+		return false
+	}
+
+	expires, err := time.Parse(time.RFC3339, a.Expires)
+	if err != nil {
+		return true
+	}
+	return !now.Before(expires)
+}
+
+// ResolvePendingAction records the outcome of a proposed action and the admin (or
+// process) that resolved it. Actions can only be resolved once.
+func ResolvePendingAction(action *PendingAction, state PendingActionState, resolvedBy string, message string) (err error) {
+	// PendingAction must be non-nil
+	if action == nil {
+		return fmt.Errorf("cannot resolve a nil PendingAction")
+	}
+
+	if action.Status != PendingActionState_ACTION_PROPOSED {
+		return fmt.Errorf("cannot resolve pending action in state %s", action.Status)
+	}
+
+	// Validate the new state.
+	if state <= PendingActionState_ACTION_PROPOSED || state > PendingActionState_ACTION_FAILED {
+		return fmt.Errorf("cannot resolve pending action with unsupported state %d", state)
+	}
+
+	action.Status = state
+	action.ResolvedBy = resolvedBy
+	action.Resolved = time.Now().Format(time.RFC3339)
+	action.Message = message
+	return nil
+}
+
+// NewAuditRecordID returns a unique ID for the audit record based on its timestamp (or
+// the current time if the timestamp is not set). IDs sort in reverse chronological
+// order so that stores list the most recent audit records first and the audit log can
+// be paged by seeking to the key of a timestamp.
+func NewAuditRecordID(r *AuditRecord) string {
+	ts, err := time.Parse(time.RFC3339Nano, r.Timestamp)
+	if err != nil {
+		ts = time.Now()
+	}
+	return AuditRecordSeekKey(ts) + "-" + uuid.New().String()
+}
+
+// AuditRecordSeekKey returns a key that sorts before the IDs of all of the audit
+// records created at or before the timestamp and after the IDs of all of the records
+// created after it.
+func AuditRecordSeekKey(ts time.Time) string {
+	return fmt.Sprintf("%016x", uint64(math.MaxInt64-ts.UnixNano()))
+}
+
 // GetReviewNotes returns all of the review notes for a VASP as a map.
 func GetReviewNotes(vasp *pb.VASP) (_ map[string]*ReviewNote, err error) {
 	// If the extra data is nil, return an empty map (no review notes).
@@ -1,7 +1,14 @@
 package models_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
+	"encoding/pem"
+	"net"
 	"testing"
 	"time"
 
@@ -96,6 +103,23 @@ func TestVASPExtra(t *testing.T) {
 	require.Equal(t, "", notes["boats"].Editor)
 	require.Equal(t, "boats are cool", notes["boats"].Text)
 
+	// Set and clear the pending CSR without changing the verification token
+	csr, err := GetPendingCSR(vasp)
+	require.NoError(t, err)
+	require.Empty(t, csr)
+	require.NoError(t, SetPendingCSR(vasp, "-----BEGIN CERTIFICATE REQUEST-----"))
+	csr, err = GetPendingCSR(vasp)
+	require.NoError(t, err)
+	require.Equal(t, "-----BEGIN CERTIFICATE REQUEST-----", csr)
+	require.NoError(t, SetPendingCSR(vasp, ""))
+	csr, err = GetPendingCSR(vasp)
+	require.NoError(t, err)
+	require.Empty(t, csr)
+
+	token, err = GetAdminVerificationToken(vasp)
+	require.NoError(t, err)
+	require.Equal(t, "jetskis", token)
+
 	// Deleting certificate request IDs from an empty slice should not error
 	require.NoError(t, DeleteCertReqID(vasp, "b5841869-105f-411c-8722-4045aad72717"))
 
@@ -231,6 +255,34 @@ func TestAuditLog(t *testing.T) {
 	require.True(t, proto.Equal(entry, auditLog[0]))
 	require.True(t, proto.Equal(expected2, auditLog[1]))
 	require.True(t, proto.Equal(entry3, auditLog[2]))
+
+	// Actions are looked up by the action and the certificate ID
+	ok, err := HasAuditLogAction(vasp, ActionReissuanceReminder, "cert1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	entry4 := &AuditLogEntry{
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PreviousState: pb.VerificationState_REJECTED,
+		CurrentState:  pb.VerificationState_REJECTED,
+		Description:   "sent reissuance reminder",
+		Source:        "automated",
+		Action:        ActionReissuanceReminder,
+		CertificateId: "cert1",
+	}
+	require.NoError(t, AppendAuditLog(vasp, entry4))
+
+	ok, err = HasAuditLogAction(vasp, ActionReissuanceReminder, "cert1")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = HasAuditLogAction(vasp, ActionReissuanceReminder, "cert2")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = HasAuditLogAction(vasp, ActionReissuanceStarted, "cert1")
+	require.NoError(t, err)
+	require.False(t, ok)
 }
 
 func TestReviewNotes(t *testing.T) {
@@ -646,6 +698,58 @@ func TestNewCertificateRequest(t *testing.T) {
 	require.Equal(t, "CA", cr.Params["countryName"])
 }
 
+func TestAttachCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	makeCSR := func(template *x509.CertificateRequest) []byte {
+		der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+		require.NoError(t, err)
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	}
+
+	request := &CertificateRequest{CommonName: "trisa.charlie.net"}
+	require.Error(t, AttachCSR(nil, nil))
+
+	// CSR must be a PEM encoded certificate request
+	require.EqualError(t, AttachCSR(request, []byte("not a csr")), "csr must be a PEM encoded certificate request")
+	require.EqualError(t, AttachCSR(request, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("foo")})), "csr must be a PEM encoded certificate request")
+
+	// CSR must be for the common name of the request
+	csr := makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.alice.net"}})
+	require.EqualError(t, AttachCSR(request, csr), `csr is not for common name "trisa.charlie.net"`)
+	require.Empty(t, request.Csr)
+
+	// CSR signature must be valid
+	block, _ := pem.Decode(makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.charlie.net"}}))
+	block.Bytes[len(block.Bytes)-1] ^= 0xff
+	require.Error(t, AttachCSR(request, pem.EncodeToMemory(block)))
+	require.Empty(t, request.Csr)
+
+	// Subject common name must be the common name, not just a DNS name
+	csr = makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "Charlie"}, DNSNames: []string{"trisa.charlie.net"}})
+	require.EqualError(t, AttachCSR(request, csr), `csr is not for common name "trisa.charlie.net"`)
+	require.Empty(t, request.Csr)
+
+	// CSR cannot request subject alternative names for other endpoints
+	csr = makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.charlie.net"}, DNSNames: []string{"trisa.charlie.net", "trisa.alice.net"}})
+	require.EqualError(t, AttachCSR(request, csr), `csr dns name "trisa.alice.net" does not match common name "trisa.charlie.net"`)
+	require.Empty(t, request.Csr)
+
+	csr = makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.charlie.net"}, IPAddresses: []net.IP{net.ParseIP("10.0.0.1")}})
+	require.EqualError(t, AttachCSR(request, csr), "csr can only request the common name as a subject alternative name")
+	require.Empty(t, request.Csr)
+
+	// Common name may also be specified as the DNS name
+	csr = makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.charlie.net"}, DNSNames: []string{"trisa.charlie.net"}})
+	require.NoError(t, AttachCSR(request, csr))
+	require.Equal(t, string(csr), request.Csr)
+
+	csr = makeCSR(&x509.CertificateRequest{Subject: pkix.Name{CommonName: "trisa.charlie.net"}})
+	require.NoError(t, AttachCSR(request, csr))
+	require.Equal(t, string(csr), request.Csr)
+}
+
 func TestUpdateCertificateRequestStatus(t *testing.T) {
 	// Attempt to set request status on a nil object
 	err := UpdateCertificateRequestStatus(nil, CertificateRequestState_READY_TO_SUBMIT, "ready to submit", "automated")
@@ -703,6 +807,83 @@ func TestUpdateCertificateRequestStatus(t *testing.T) {
 	require.Equal(t, "automated", request.AuditLog[1].Source)
 }
 
+func TestPendingAction(t *testing.T) {
+	action := NewPendingAction(PendingActionType_DELETE_VASP, "b5841869-105f-411c-8722-4045aad72717", nil, "alice@example.com", time.Hour)
+	require.Equal(t, PendingActionState_ACTION_PROPOSED, action.Status)
+	require.Equal(t, "alice@example.com", action.ProposedBy)
+
+	// The action should expire after the timeout
+	require.False(t, action.IsExpired(time.Now()))
+	require.True(t, action.IsExpired(time.Now().Add(time.Hour)))
+
+	// Invalid expiration timestamps are expired
+	action.Expires = "tomorrow"
+	require.True(t, action.IsExpired(time.Now()))
+	action.Expires = time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	// Cannot resolve a nil action or resolve to an invalid state
+	require.Error(t, ResolvePendingAction(nil, PendingActionState_ACTION_EXECUTED, "bob@example.com", ""))
+	require.Error(t, ResolvePendingAction(action, PendingActionState_ACTION_PROPOSED, "bob@example.com", ""))
+	require.Error(t, ResolvePendingAction(action, PendingActionState_ACTION_FAILED+1, "bob@example.com", ""))
+
+	err := ResolvePendingAction(action, PendingActionState_ACTION_EXECUTED, "bob@example.com", "vasp deleted")
+	require.NoError(t, err)
+	require.Equal(t, PendingActionState_ACTION_EXECUTED, action.Status)
+	require.Equal(t, "bob@example.com", action.ResolvedBy)
+	require.Equal(t, "vasp deleted", action.Message)
+	require.NotEmpty(t, action.Resolved)
+
+	// Resolved actions cannot expire or be resolved again
+	require.False(t, action.IsExpired(time.Now().Add(time.Hour)))
+	require.Error(t, ResolvePendingAction(action, PendingActionState_ACTION_CANCELED, "alice@example.com", ""))
+}
+
+func TestArchiveVASP(t *testing.T) {
+	vasp := &pb.VASP{Id: "b5841869-105f-411c-8722-4045aad72717", VerificationStatus: pb.VerificationState_VERIFIED}
+
+	// No extra, the VASP is not archived
+	require.False(t, IsArchived(vasp))
+	archived, err := GetArchived(vasp)
+	require.NoError(t, err)
+	require.True(t, archived.IsZero())
+
+	// Cannot restore a VASP that has not been archived
+	require.EqualError(t, RestoreVASP(vasp, "bob@example.com"), "vasp has not been archived")
+
+	require.NoError(t, AppendCertID(vasp, "9676bf6a-ffdb-4185-8fa5-87cdae6f6eef"))
+	require.NoError(t, ArchiveVASP(vasp, "alice@example.com"))
+	require.True(t, IsArchived(vasp))
+	require.Equal(t, pb.VerificationState_VERIFIED, vasp.VerificationStatus)
+
+	archived, err = GetArchived(vasp)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), archived, time.Minute)
+
+	// Archiving should not overwrite the other extra data
+	ids, err := GetCertIDs(vasp)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	// Cannot archive a VASP twice
+	require.EqualError(t, ArchiveVASP(vasp, "alice@example.com"), "vasp has already been archived")
+
+	require.NoError(t, RestoreVASP(vasp, "bob@example.com"))
+	require.False(t, IsArchived(vasp))
+	archived, err = GetArchived(vasp)
+	require.NoError(t, err)
+	require.True(t, archived.IsZero())
+
+	// Both the deletion and the restoration should be in the audit log
+	auditLog, err := GetAuditLog(vasp)
+	require.NoError(t, err)
+	require.Len(t, auditLog, 2)
+	require.Equal(t, ActionArchived, auditLog[0].Action)
+	require.Equal(t, "alice@example.com", auditLog[0].Source)
+	require.Equal(t, ActionRestored, auditLog[1].Action)
+	require.Equal(t, "bob@example.com", auditLog[1].Source)
+	require.Equal(t, pb.VerificationState_VERIFIED, auditLog[1].CurrentState)
+}
+
 func TestIsTraveler(t *testing.T) {
 	vasp := &pb.VASP{CommonName: "trisa.example.com"}
 	require.False(t, IsTraveler(vasp))
@@ -0,0 +1,383 @@
+package gds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/secrets"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/whisper"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+const whisperPasswordTemplate = "Below is the PKCS12 password which you must use to decrypt your new certificates:\n\n%s\n"
+
+// ErrCSRRequired is returned when a certificate that was issued from a CSR supplied by
+// the VASP cannot be reissued because the VASP has not uploaded a new CSR.
+var ErrCSRRequired = errors.New("certificate cannot be reissued until the vasp uploads a new csr")
+
+// ReissuanceManager is a go routine that periodically checks for identity certificates
+// that are approaching expiration. On a configurable schedule before the certificate
+// expires it notifies the TRISA admins, reminds the VASP contacts that the certificate
+// will be reissued, and finally creates a new certificate request for the CertManager
+// to submit, sending the PKCS12 password to the VASP via a one time whisper link.
+func (s *Service) ReissuanceManager(stop <-chan struct{}) {
+	if !s.conf.Reissuance.Enabled {
+		log.Warn().Msg("reissuance manager is not enabled")
+		return
+	}
+
+	ticker := time.NewTicker(s.conf.Reissuance.Interval)
+	log.Info().Dur("interval", s.conf.Reissuance.Interval).Msg("reissuance manager started")
+
+	for {
+		// Wait for next tick or a stop signal
+		select {
+		case <-stop:
+			log.Info().Msg("reissuance manager received stop signal")
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.HandleCertificateReissuance(); err != nil {
+			log.Error().Err(err).Msg("could not handle certificate reissuance")
+		}
+	}
+}
+
+// HandleCertificateReissuance performs the reissuance steps that are due for all of the
+// certificates that expire within the admin notice window. Each step is recorded in the
+// audit log of the VASP and skipped if it has already been recorded, so this method can
+// be called repeatedly without sending duplicate notifications or certificate requests.
+// Errors for individual certificates are logged so that one failure does not prevent
+// the other certificates from being handled.
+func (s *Service) HandleCertificateReissuance() (err error) {
+	now := time.Now()
+	log.Debug().Msg("reissuance manager checking for expiring certificates")
+
+	var certs []*models.Certificate
+	if certs, err = s.db.ListCertsByExpiration(now, now.Add(s.conf.Reissuance.AdminNoticeBefore)); err != nil {
+		return fmt.Errorf("could not list expiring certificates: %s", err)
+	}
+
+	var nsteps int
+	for _, cert := range certs {
+		logctx := log.With().Str("cert_id", cert.Id).Str("vasp_id", cert.Vasp).Logger()
+
+		var n int
+		if n, err = s.reissueCertificate(cert, now); err != nil {
+			logctx.Error().Err(err).Msg("could not handle certificate reissuance")
+			continue
+		}
+		nsteps += n
+	}
+
+	log.Debug().Int("certificates", len(certs)).Int("steps", nsteps).Msg("reissuance manager check complete")
+	return nil
+}
+
+// reissueCertificate performs all of the reissuance steps that are due at the specified
+// time and that have not already been performed for the certificate, returning the
+// number of steps that were performed.
+func (s *Service) reissueCertificate(cert *models.Certificate, now time.Time) (nsteps int, err error) {
+	// Only the current identity certificate of a verified VASP is reissued
+	if cert.Status != models.CertificateState_ISSUED || cert.Details == nil {
+		return 0, nil
+	}
+
+	var expires time.Time
+	if expires, err = time.Parse(time.RFC3339, cert.Details.NotAfter); err != nil {
+		return 0, fmt.Errorf("could not parse certificate expiration: %s", err)
+	}
+
+	var vasp *pb.VASP
+	if vasp, err = s.db.RetrieveVASP(cert.Vasp); err != nil {
+		return 0, fmt.Errorf("could not retrieve vasp: %s", err)
+	}
+
+	if vasp.VerificationStatus != pb.VerificationState_VERIFIED || vasp.IdentityCertificate == nil {
+		return 0, nil
+	}
+
+	if !bytes.Equal(vasp.IdentityCertificate.SerialNumber, cert.Details.SerialNumber) {
+		return 0, nil
+	}
+
+	reissueDate := expires.Add(-s.conf.Reissuance.ReissueBefore)
+	steps := []struct {
+		action string
+		due    time.Time
+		run    func(*pb.VASP) error
+	}{
+		{
+			action: models.ActionExpiresAdminNotice,
+			due:    expires.Add(-s.conf.Reissuance.AdminNoticeBefore),
+			run: func(vasp *pb.VASP) (err error) {
+				if _, err = s.email.SendExpiresAdminNotification(vasp, reissueDate); err != nil {
+					return err
+				}
+				return s.recordReissuanceStep(vasp, models.ActionExpiresAdminNotice, cert.Id, "sent certificate expiration notice to admins")
+			},
+		},
+		{
+			action: models.ActionReissuanceReminder,
+			due:    expires.Add(-s.conf.Reissuance.ReminderBefore),
+			run: func(vasp *pb.VASP) (err error) {
+				if _, err = s.email.SendReissuanceReminder(vasp, reissueDate); err != nil {
+					return err
+				}
+				return s.recordReissuanceStep(vasp, models.ActionReissuanceReminder, cert.Id, "sent certificate reissuance reminder")
+			},
+		},
+		{
+			action: models.ActionReissuanceStarted,
+			due:    reissueDate,
+			run: func(vasp *pb.VASP) error {
+				return s.startReissuance(vasp, cert)
+			},
+		},
+	}
+
+	for _, step := range steps {
+		if now.Before(step.due) {
+			break
+		}
+
+		var done bool
+		if done, err = models.HasAuditLogAction(vasp, step.action, cert.Id); err != nil {
+			return nsteps, fmt.Errorf("could not read audit log: %s", err)
+		}
+
+		if done {
+			continue
+		}
+
+		if err = step.run(vasp); err != nil {
+			return nsteps, fmt.Errorf("could not perform %s: %s", step.action, err)
+		}
+		nsteps++
+		log.Info().Str("vasp_id", vasp.Id).Str("cert_id", cert.Id).Str("action", step.action).Msg("certificate reissuance step completed")
+	}
+	return nsteps, nil
+}
+
+// startReissuance creates a certificate request that is ready to be submitted by the
+// CertManager along with its PKCS12 password, or with the CSR that the VASP uploaded,
+// and records the reissuance in the audit log of the VASP.
+// The certificate request is marked with the ID of the expiring certificate and saved on
+// the VASP so that if recording the step fails, the next attempt reuses the request
+// rather than creating a second one. The VASP contacts are only notified after the reissuance has been
+// recorded so that a failure to deliver the email does not cause the VASP to receive a
+// second certificate request; undelivered emails are logged for the admins to follow up.
+func (s *Service) startReissuance(vasp *pb.VASP, cert *models.Certificate) (err error) {
+	var certreq *models.CertificateRequest
+	if certreq, err = s.findReissuanceRequest(vasp, cert); err != nil {
+		return err
+	}
+
+	var whisperLink string
+	if certreq == nil {
+		if certreq, whisperLink, err = s.createReissuanceRequest(vasp, cert); err != nil {
+			return err
+		}
+	} else {
+		log.Info().Str("vasp_id", vasp.Id).Str("certreq_id", certreq.Id).Msg("resuming certificate reissuance with existing certificate request")
+		if certreq.Csr == "" {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			var password []byte
+			if password, err = s.secret.With(certreq.Id).GetLatestVersion(ctx, "password"); err != nil {
+				return fmt.Errorf("could not retrieve password secret: %s", err)
+			}
+
+			if whisperLink, err = whisper.CreateSecretLink(fmt.Sprintf(whisperPasswordTemplate, password), "", 3, time.Now().Add(7*24*time.Hour)); err != nil {
+				return fmt.Errorf("could not create whisper link: %s", err)
+			}
+		}
+	}
+
+	// The pending CSR has been used by the certificate request, so it is cleared when
+	// the reissuance step is recorded.
+	var pending string
+	if pending, err = models.GetPendingCSR(vasp); err != nil {
+		return fmt.Errorf("could not get pending csr: %s", err)
+	}
+
+	if pending != "" && pending == certreq.Csr {
+		if err = models.SetPendingCSR(vasp, ""); err != nil {
+			return fmt.Errorf("could not clear pending csr: %s", err)
+		}
+	}
+
+	if err = s.recordReissuanceStep(vasp, models.ActionReissuanceStarted, cert.Id, "started certificate reissuance"); err != nil {
+		return err
+	}
+
+	if _, err = s.email.SendReissuanceStarted(vasp, whisperLink); err != nil {
+		log.WithLevel(zerolog.FatalLevel).Err(err).Str("vasp_id", vasp.Id).Str("certreq_id", certreq.Id).Msg("could not send reissuance started email")
+	}
+
+	// Save the email logs on the contacts of the VASP
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return fmt.Errorf("could not save vasp: %s", err)
+	}
+	return nil
+}
+
+// createReissuanceRequest creates and saves the certificate request that reissues the
+// expiring certificate, returning a whisper link to the PKCS12 password if the
+// directory generates the private key.
+func (s *Service) createReissuanceRequest(vasp *pb.VASP, cert *models.Certificate) (certreq *models.CertificateRequest, whisperLink string, err error) {
+	var pending string
+	if pending, err = models.GetPendingCSR(vasp); err != nil {
+		return nil, "", fmt.Errorf("could not get pending csr: %s", err)
+	}
+
+	// A certificate that was issued from a CSR supplied by the VASP is not reissued from
+	// the same CSR since the key of the VASP would never be rotated, instead the admins
+	// are notified that the VASP must upload a new CSR before it can be reissued.
+	if pending == "" && cert.Request != "" {
+		var prev *models.CertificateRequest
+		if prev, err = s.db.RetrieveCertReq(cert.Request); err != nil {
+			return nil, "", fmt.Errorf("could not retrieve certificate request of expiring certificate: %s", err)
+		}
+
+		if prev.Csr != "" {
+			return nil, "", s.requireNewCSR(vasp, cert)
+		}
+	}
+
+	if certreq, err = models.NewCertificateRequest(vasp); err != nil {
+		return nil, "", fmt.Errorf("could not create certificate request: %s", err)
+	}
+	certreq.Reissues = cert.Id
+
+	if err = models.UpdateCertificateRequestStatus(certreq, models.CertificateRequestState_READY_TO_SUBMIT, "automatically reissuing certificates", "automated"); err != nil {
+		return nil, "", fmt.Errorf("could not mark certificate request ready to submit: %s", err)
+	}
+
+	// If the VASP has uploaded a new CSR then the certificate is reissued from it so that
+	// the VASP rotates its key and the directory never has the private key.
+	if pending != "" {
+		if err = models.AttachCSR(certreq, []byte(pending)); err != nil {
+			return nil, "", fmt.Errorf("could not attach pending csr: %s", err)
+		}
+	}
+
+	// Create the PKCS12 password that the CertManager uses to submit the request
+	if certreq.Csr == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		secretType := "password"
+		password := secrets.CreateToken(16)
+		if err = s.secret.With(certreq.Id).CreateSecret(ctx, secretType); err != nil {
+			return nil, "", fmt.Errorf("could not create password secret: %s", err)
+		}
+
+		if err = s.secret.With(certreq.Id).AddSecretVersion(ctx, secretType, []byte(password)); err != nil {
+			return nil, "", fmt.Errorf("could not create password version: %s", err)
+		}
+
+		if whisperLink, err = whisper.CreateSecretLink(fmt.Sprintf(whisperPasswordTemplate, password), "", 3, time.Now().Add(7*24*time.Hour)); err != nil {
+			return nil, "", fmt.Errorf("could not create whisper link: %s", err)
+		}
+	}
+
+	if err = s.db.UpdateCertReq(certreq); err != nil {
+		return nil, "", fmt.Errorf("could not save certificate request: %s", err)
+	}
+
+	// Save the certificate request on the VASP right away so that if the reissuance
+	// step cannot be recorded the next attempt finds and reuses this request.
+	if err = models.AppendCertReqID(vasp, certreq.Id); err != nil {
+		return nil, "", fmt.Errorf("could not append certificate request to vasp: %s", err)
+	}
+
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return nil, "", fmt.Errorf("could not save vasp: %s", err)
+	}
+	return certreq, whisperLink, nil
+}
+
+// requireNewCSR notifies the admins that the certificate cannot be reissued until the
+// VASP uploads a new CSR and returns ErrCSRRequired so that reissuance is not recorded
+// as started and is attempted again once the VASP has uploaded a CSR. The admins are
+// only notified once for each certificate.
+func (s *Service) requireNewCSR(vasp *pb.VASP, cert *models.Certificate) (err error) {
+	var notified bool
+	if notified, err = models.HasAuditLogAction(vasp, models.ActionCSRRequired, cert.Id); err != nil {
+		return fmt.Errorf("could not read audit log: %s", err)
+	}
+
+	if !notified {
+		if _, err = s.email.SendReissuanceCSRRequired(vasp); err != nil {
+			return fmt.Errorf("could not notify admins that a new csr is required: %s", err)
+		}
+
+		if err = s.recordReissuanceStep(vasp, models.ActionCSRRequired, cert.Id, "certificate reissuance requires a new csr"); err != nil {
+			return err
+		}
+	}
+	return ErrCSRRequired
+}
+
+// findReissuanceRequest returns the certificate request that was previously created to
+// reissue the certificate, or nil if reissuance has not been started. The request is
+// looked up from the certificate requests of the VASP, which are searched from the most
+// recent since the reissuance request is created after the expiring certificate.
+func (s *Service) findReissuanceRequest(vasp *pb.VASP, cert *models.Certificate) (_ *models.CertificateRequest, err error) {
+	var certReqIDs []string
+	if certReqIDs, err = models.GetCertReqIDs(vasp); err != nil {
+		return nil, fmt.Errorf("could not retrieve certificate request IDs: %s", err)
+	}
+
+	for i := len(certReqIDs) - 1; i >= 0; i-- {
+		var certreq *models.CertificateRequest
+		if certreq, err = s.db.RetrieveCertReq(certReqIDs[i]); err != nil {
+			if errors.Is(err, storeerrors.ErrEntityNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("could not retrieve certificate request %s: %s", certReqIDs[i], err)
+		}
+
+		if certreq.Reissues == cert.Id {
+			return certreq, nil
+		}
+	}
+	return nil, nil
+}
+
+// recordReissuanceStep appends the completed step to the audit log of the VASP and
+// saves the VASP so that the step is not performed again.
+func (s *Service) recordReissuanceStep(vasp *pb.VASP, action, certID, description string) (err error) {
+	if action == "" || certID == "" {
+		return errors.New("reissuance steps require an action and a certificate ID")
+	}
+
+	entry := &models.AuditLogEntry{
+		Timestamp:     time.Now().Format(time.RFC3339),
+		PreviousState: vasp.VerificationStatus,
+		CurrentState:  vasp.VerificationStatus,
+		Description:   description,
+		Source:        "automated",
+		Action:        action,
+		CertificateId: certID,
+	}
+
+	if err = models.AppendAuditLog(vasp, entry); err != nil {
+		return fmt.Errorf("could not append to audit log: %s", err)
+	}
+
+	if err = s.db.UpdateVASP(vasp); err != nil {
+		return fmt.Errorf("could not save vasp: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,417 @@
+package gds_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	api "github.com/rotationalio/whisper/pkg/api/v1"
+	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
+	"github.com/trisacrypto/directory/pkg/gds"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/emails"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils/whisper"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Test that the reissuance manager performs each reissuance step once as the identity
+// certificate of a VASP approaches expiration.
+func (s *gdsTestSuite) TestHandleCertificateReissuance() {
+	require := s.Require()
+	conf := gds.MockConfig()
+	conf.Reissuance = config.ReissuanceConfig{
+		Enabled:           true,
+		Interval:          time.Millisecond,
+		AdminNoticeBefore: 30 * 24 * time.Hour,
+		ReminderBefore:    21 * 24 * time.Hour,
+		ReissueBefore:     10 * 24 * time.Hour,
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	defer s.ResetConfig()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	emails.PurgeMockEmails()
+
+	// Mock the whisper service that the PKCS12 password is sent with
+	whisperServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&api.CreateSecretReply{Token: "abcdefghijklmnop", Expires: time.Now().AddDate(0, 0, 7)})
+	}))
+	defer whisperServer.Close()
+	require.NoError(whisper.ConnectClient(whisperServer.URL))
+	defer whisper.ResetClient()
+
+	db := s.svc.GetStore()
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	serial := []byte{0x0a, 0x1b, 0x2c, 0x3d}
+
+	// Create the current identity certificate of the VASP that expires after the reminder
+	cert := &models.Certificate{
+		Vasp:   hotelID,
+		Status: models.CertificateState_ISSUED,
+		Details: &pb.Certificate{
+			SerialNumber: serial,
+			NotAfter:     time.Now().AddDate(0, 0, 25).Format(time.RFC3339),
+		},
+	}
+	var err error
+	cert.Id, err = db.CreateCert(cert)
+	require.NoError(err)
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	hotel.IdentityCertificate.SerialNumber = serial
+	hotel.IdentityCertificate.NotAfter = cert.Details.NotAfter
+	require.NoError(db.UpdateVASP(hotel))
+
+	certReqs, err := models.GetCertReqIDs(hotel)
+	require.NoError(err)
+	nCertReqs := len(certReqs)
+
+	// The reminder is sent to all of the verified contacts
+	var nContacts int
+	iter := models.NewContactIterator(hotel.Contacts, true, true)
+	for iter.Next() {
+		nContacts++
+	}
+	require.NotZero(nContacts)
+
+	// Only the admins are notified before the reminder date
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceEmails(map[string]int{emails.ExpiresAdminNotificationRE: 1})
+	s.checkReissuanceAction(hotelID, models.ActionExpiresAdminNotice, cert.Id, true)
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceReminder, cert.Id, false)
+
+	// Steps are not repeated
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceEmails(map[string]int{emails.ExpiresAdminNotificationRE: 1})
+
+	// The contacts are reminded after the reminder date
+	cert.Details.NotAfter = time.Now().AddDate(0, 0, 15).Format(time.RFC3339)
+	require.NoError(db.UpdateCert(cert))
+	require.NoError(s.svc.HandleCertificateReissuance())
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceEmails(map[string]int{emails.ExpiresAdminNotificationRE: 1, emails.ReissuanceReminderRE: nContacts})
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceReminder, cert.Id, true)
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, false)
+
+	// A certificate request is created on the reissuance date
+	cert.Details.NotAfter = time.Now().AddDate(0, 0, 5).Format(time.RFC3339)
+	require.NoError(db.UpdateCert(cert))
+	require.NoError(s.svc.HandleCertificateReissuance())
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceEmails(map[string]int{emails.ExpiresAdminNotificationRE: 1, emails.ReissuanceReminderRE: nContacts, emails.ReissuanceStartedRE: 1})
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, true)
+
+	hotel, err = db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	certReqs, err = models.GetCertReqIDs(hotel)
+	require.NoError(err)
+	require.Len(certReqs, nCertReqs+1)
+
+	certReq, err := db.RetrieveCertReq(certReqs[len(certReqs)-1])
+	require.NoError(err)
+	require.Equal(models.CertificateRequestState_READY_TO_SUBMIT, certReq.Status)
+
+	password, err := s.svc.GetSecretManager().With(certReq.Id).GetLatestVersion(context.Background(), "password")
+	require.NoError(err)
+	require.NotEmpty(password)
+
+	// Certificates that are not the current identity certificate are not reissued
+	emails.PurgeMockEmails()
+	other := &models.Certificate{
+		Vasp:   hotelID,
+		Status: models.CertificateState_ISSUED,
+		Details: &pb.Certificate{
+			SerialNumber: []byte{0x01, 0x02},
+			NotAfter:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
+		},
+	}
+	other.Id, err = db.CreateCert(other)
+	require.NoError(err)
+	require.NoError(s.svc.HandleCertificateReissuance())
+	require.Empty(emails.MockEmails)
+	s.checkReissuanceAction(hotelID, models.ActionExpiresAdminNotice, other.Id, false)
+}
+
+// Test that if a previous attempt created the reissuance certificate request but did
+// not record the step, the reissuance manager resumes with the existing request.
+func (s *gdsTestSuite) TestResumeCertificateReissuance() {
+	require := s.Require()
+	conf := gds.MockConfig()
+	conf.Reissuance = config.ReissuanceConfig{
+		Enabled:           true,
+		Interval:          time.Millisecond,
+		AdminNoticeBefore: 30 * 24 * time.Hour,
+		ReminderBefore:    21 * 24 * time.Hour,
+		ReissueBefore:     10 * 24 * time.Hour,
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	defer s.ResetConfig()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	emails.PurgeMockEmails()
+
+	whisperServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(&api.CreateSecretReply{Token: "abcdefghijklmnop", Expires: time.Now().AddDate(0, 0, 7)})
+	}))
+	defer whisperServer.Close()
+	require.NoError(whisper.ConnectClient(whisperServer.URL))
+	defer whisper.ResetClient()
+
+	db := s.svc.GetStore()
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	serial := []byte{0x0a, 0x1b, 0x2c, 0x3d}
+
+	cert := &models.Certificate{
+		Vasp:   hotelID,
+		Status: models.CertificateState_ISSUED,
+		Details: &pb.Certificate{
+			SerialNumber: serial,
+			NotAfter:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
+		},
+	}
+	var err error
+	cert.Id, err = db.CreateCert(cert)
+	require.NoError(err)
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	hotel.IdentityCertificate.SerialNumber = serial
+	hotel.IdentityCertificate.NotAfter = cert.Details.NotAfter
+
+	// Simulate a previous attempt that saved the certificate request and its password
+	// but failed before the reissuance step was recorded.
+	prev, err := models.NewCertificateRequest(hotel)
+	require.NoError(err)
+	prev.Reissues = cert.Id
+	prev.Status = models.CertificateRequestState_READY_TO_SUBMIT
+	require.NoError(s.svc.GetSecretManager().With(prev.Id).CreateSecret(context.Background(), "password"))
+	require.NoError(s.svc.GetSecretManager().With(prev.Id).AddSecretVersion(context.Background(), "password", []byte("supersecret")))
+	require.NoError(db.UpdateCertReq(prev))
+	require.NoError(models.AppendCertReqID(hotel, prev.Id))
+	require.NoError(db.UpdateVASP(hotel))
+
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, true)
+
+	// No other certificate request is created for the certificate
+	var nreqs int
+	iter := db.ListCertReqs()
+	for iter.Next() {
+		certReq, err := iter.CertReq()
+		require.NoError(err)
+		if certReq.Reissues == cert.Id {
+			require.Equal(prev.Id, certReq.Id)
+			nreqs++
+		}
+	}
+	iter.Release()
+	require.NoError(iter.Error())
+	require.Equal(1, nreqs)
+
+	hotel, err = db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	certReqs, err := models.GetCertReqIDs(hotel)
+	require.NoError(err)
+	require.Contains(certReqs, prev.Id)
+}
+
+// Test that a CSR uploaded by the VASP is used to reissue its certificate.
+func (s *gdsTestSuite) TestReissueWithPendingCSR() {
+	require := s.Require()
+	conf := gds.MockConfig()
+	conf.Reissuance = config.ReissuanceConfig{
+		Enabled:           true,
+		Interval:          time.Millisecond,
+		AdminNoticeBefore: 30 * 24 * time.Hour,
+		ReminderBefore:    21 * 24 * time.Hour,
+		ReissueBefore:     10 * 24 * time.Hour,
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	defer s.ResetConfig()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	emails.PurgeMockEmails()
+
+	db := s.svc.GetStore()
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	serial := []byte{0x0a, 0x1b, 0x2c, 0x3d}
+
+	cert := &models.Certificate{
+		Vasp:   hotelID,
+		Status: models.CertificateState_ISSUED,
+		Details: &pb.Certificate{
+			SerialNumber: serial,
+			NotAfter:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
+		},
+	}
+	var err error
+	cert.Id, err = db.CreateCert(cert)
+	require.NoError(err)
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	hotel.IdentityCertificate.SerialNumber = serial
+	hotel.IdentityCertificate.NotAfter = cert.Details.NotAfter
+
+	csr := makeCSR(s.T(), hotel.CommonName)
+	require.NoError(models.SetPendingCSR(hotel, csr))
+	require.NoError(db.UpdateVASP(hotel))
+
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, true)
+
+	// The certificate request is created from the uploaded CSR without a password
+	var certreq *models.CertificateRequest
+	iter := db.ListCertReqs()
+	for iter.Next() {
+		r, err := iter.CertReq()
+		require.NoError(err)
+		if r.Reissues == cert.Id {
+			certreq = r
+		}
+	}
+	iter.Release()
+	require.NoError(iter.Error())
+	require.NotNil(certreq)
+	require.Equal(csr, certreq.Csr)
+
+	_, err = s.svc.GetSecretManager().With(certreq.Id).GetLatestVersion(context.Background(), "password")
+	require.Error(err)
+
+	// The pending CSR is cleared so it is not used for another certificate
+	hotel, err = db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	pending, err := models.GetPendingCSR(hotel)
+	require.NoError(err)
+	require.Empty(pending)
+}
+
+// Test that a certificate issued from a CSR supplied by the VASP is not reissued from the
+// same CSR; the admins are notified that a new CSR is required instead.
+func (s *gdsTestSuite) TestReissueRequiresNewCSR() {
+	require := s.Require()
+	conf := gds.MockConfig()
+	conf.Reissuance = config.ReissuanceConfig{
+		Enabled:           true,
+		Interval:          time.Millisecond,
+		AdminNoticeBefore: 30 * 24 * time.Hour,
+		ReminderBefore:    21 * 24 * time.Hour,
+		ReissueBefore:     10 * 24 * time.Hour,
+	}
+	s.SetConfig(conf)
+	s.LoadFullFixtures()
+	defer s.ResetConfig()
+	defer s.ResetFixtures()
+	defer emails.PurgeMockEmails()
+	emails.PurgeMockEmails()
+
+	db := s.svc.GetStore()
+	hotelID := s.fixtures[vasps]["hotel"].(*pb.VASP).Id
+	serial := []byte{0x0a, 0x1b, 0x2c, 0x3d}
+
+	hotel, err := db.RetrieveVASP(hotelID)
+	require.NoError(err)
+
+	// The expiring certificate was issued from a CSR supplied by the VASP
+	prev, err := models.NewCertificateRequest(hotel)
+	require.NoError(err)
+	prev.Status = models.CertificateRequestState_COMPLETED
+	require.NoError(models.AttachCSR(prev, []byte(makeCSR(s.T(), hotel.CommonName))))
+	require.NoError(db.UpdateCertReq(prev))
+
+	cert := &models.Certificate{
+		Vasp:    hotelID,
+		Request: prev.Id,
+		Status:  models.CertificateState_ISSUED,
+		Details: &pb.Certificate{
+			SerialNumber: serial,
+			NotAfter:     time.Now().AddDate(0, 0, 5).Format(time.RFC3339),
+		},
+	}
+	cert.Id, err = db.CreateCert(cert)
+	require.NoError(err)
+
+	hotel.IdentityCertificate.SerialNumber = serial
+	hotel.IdentityCertificate.NotAfter = cert.Details.NotAfter
+	require.NoError(db.UpdateVASP(hotel))
+
+	// The certificate is not reissued and the admins are notified once
+	require.NoError(s.svc.HandleCertificateReissuance())
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceAction(hotelID, models.ActionCSRRequired, cert.Id, true)
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, false)
+
+	subjects := make(map[string]int)
+	for _, data := range emails.MockEmails {
+		msg := &sgmail.SGMailV3{}
+		require.NoError(json.Unmarshal(data, msg))
+		subjects[msg.Subject]++
+	}
+	require.Equal(1, subjects[emails.ReissuanceCSRRequiredRE])
+	require.Zero(subjects[emails.ReissuanceStartedRE])
+
+	var nreqs int
+	iter := db.ListCertReqs()
+	for iter.Next() {
+		r, err := iter.CertReq()
+		require.NoError(err)
+		if r.Reissues == cert.Id {
+			nreqs++
+		}
+	}
+	iter.Release()
+	require.NoError(iter.Error())
+	require.Zero(nreqs)
+
+	// The certificate is reissued once the VASP uploads a new CSR
+	hotel, err = db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	csr := makeCSR(s.T(), hotel.CommonName)
+	require.NoError(models.SetPendingCSR(hotel, csr))
+	require.NoError(db.UpdateVASP(hotel))
+
+	require.NoError(s.svc.HandleCertificateReissuance())
+	s.checkReissuanceAction(hotelID, models.ActionReissuanceStarted, cert.Id, true)
+
+	hotel, err = db.RetrieveVASP(hotelID)
+	require.NoError(err)
+	certReqs, err := models.GetCertReqIDs(hotel)
+	require.NoError(err)
+	certreq, err := db.RetrieveCertReq(certReqs[len(certReqs)-1])
+	require.NoError(err)
+	require.Equal(cert.Id, certreq.Reissues)
+	require.Equal(csr, certreq.Csr)
+}
+
+// Check the number of mock emails that have been sent with each subject.
+func (s *gdsTestSuite) checkReissuanceEmails(expected map[string]int) {
+	require := s.Require()
+	subjects := make(map[string]int)
+	for _, data := range emails.MockEmails {
+		msg := &sgmail.SGMailV3{}
+		require.NoError(json.Unmarshal(data, msg))
+		subjects[msg.Subject]++
+	}
+	require.Equal(expected, subjects)
+}
+
+// Check whether the reissuance action has been recorded in the VASP audit log.
+func (s *gdsTestSuite) checkReissuanceAction(vaspID, action, certID string, expected bool) {
+	require := s.Require()
+	vasp, err := s.svc.GetStore().RetrieveVASP(vaspID)
+	require.NoError(err)
+	ok, err := models.HasAuditLogAction(vasp, action, certID)
+	require.NoError(err)
+	require.Equal(expected, ok, "unexpected audit log entry for %s", action)
+}
@@ -9,11 +9,11 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/certauth"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/emails"
 	"github.com/trisacrypto/directory/pkg/gds/secrets"
 	"github.com/trisacrypto/directory/pkg/gds/store"
-	"github.com/trisacrypto/directory/pkg/sectigo"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 )
 
@@ -93,8 +93,8 @@ func New(conf config.Config) (s *Service, err error) {
 		return nil, err
 	}
 
-	// Create the Sectigo API client
-	if s.certs, err = sectigo.New(conf.Sectigo); err != nil {
+	// Create the certificate authority that issues identity certificates
+	if s.certs, err = certauth.New(conf.CertMan, conf.Sectigo); err != nil {
 		return nil, err
 	}
 
@@ -140,7 +140,7 @@ type Service struct {
 	admin   *Admin
 	members *Members
 	conf    config.Config
-	certs   *sectigo.Sectigo
+	certs   certauth.CertificateAuthority
 	email   *emails.EmailManager
 	secret  *secrets.SecretManager
 	echan   chan error
@@ -171,6 +171,12 @@ func (s *Service) Serve() (err error) {
 
 		// Start the backup manager go routine process
 		go s.BackupManager(nil)
+
+		// Start the reissuance manager go routine process
+		go s.ReissuanceManager(nil)
+
+		// Start the archive manager go routine process
+		go s.ArchiveManager(nil)
 	}
 
 	// The TRISADirectoryService service can run in maintenance mode
@@ -36,11 +36,12 @@ import (
 )
 
 const (
-	vasps    = "vasps"
-	certs    = "certs"
-	certreqs = "certreqs"
-	index    = "index"
-	bufSize  = 1024 * 1024
+	vasps     = "vasps"
+	certs     = "certs"
+	certreqs  = "certreqs"
+	index     = "index"
+	revisions = "revisions"
+	bufSize   = 1024 * 1024
 )
 
 var (
@@ -301,7 +302,7 @@ func (s *gdsTestSuite) countLevelDBFixtures(db *leveldb.DB) (counts map[string]i
 			certreq := &models.CertificateRequest{}
 			require.NoError(proto.Unmarshal(iter.Value(), certreq))
 			obj = certreq
-		case index:
+		case index, revisions:
 			continue
 		default:
 			require.Fail("unrecognized object for namespace %q", prefix)
@@ -377,13 +378,11 @@ func (s *gdsTestSuite) CompareFixture(namespace, key string, obj interface{}, re
 	switch namespace {
 	case vasps:
 		var a *pb.VASP
-		var data pb.VASP
 		for _, f := range s.fixtures[namespace] {
 			ref := f.(*pb.VASP)
 			if ref.Id == key {
-				// Avoid modifying the object in the fixtures map
-				data = *ref
-				a = &data
+				// Avoid modifying the object or its contacts in the fixtures map
+				a = proto.Clone(ref).(*pb.VASP)
 				break
 			}
 		}
@@ -3,6 +3,7 @@ package errors
 import "errors"
 
 var (
+	ErrConcurrentUpdate  = errors.New("entity was modified concurrently")
 	ErrCorruptedIndex    = errors.New("search indices are invalid")
 	ErrCorruptedSequence = errors.New("primary key sequence is invalid")
 	ErrDuplicateEntity   = errors.New("entity unique constraints violated")
@@ -0,0 +1,193 @@
+package index
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Expirations map record IDs to the timestamp that the record expires, e.g. the not
+// after timestamp of a certificate. Unlike the other indices, the record ID is the key
+// of the index since each record has exactly one expiration, which allows the index to
+// be updated when a record is modified without knowing the previous expiration.
+type Expirations map[string]time.Time
+
+// Add the RFC3339 timestamp to the index for the specified record ID, replacing any
+// previous expiration for the record. Returns false if the timestamp cannot be parsed.
+func (c Expirations) Add(timestamp, id string) bool {
+	if id == "" {
+		return false
+	}
+
+	ts, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return false
+	}
+
+	c[id] = ts.UTC()
+	return true
+}
+
+// Remove the record from the index, returning false if it was not indexed.
+func (c Expirations) Remove(id string) bool {
+	if _, ok := c[id]; !ok {
+		return false
+	}
+	delete(c, id)
+	return true
+}
+
+// Find the expiration of the specified record.
+func (c Expirations) Find(id string) (ts time.Time, ok bool) {
+	ts, ok = c[id]
+	return ts, ok
+}
+
+// Reverse find - returns the RFC3339 expiration timestamp of the specified record.
+func (c Expirations) Reverse(id string) ([]string, bool) {
+	if ts, ok := c[id]; ok {
+		return []string{ts.Format(time.RFC3339)}, true
+	}
+	return nil, false
+}
+
+// Range returns the IDs of the records that expire on or after the after timestamp and
+// strictly before the before timestamp, ordered by expiration (soonest first) and then
+// by ID. A zero timestamp means that the range is unbounded on that side.
+func (c Expirations) Range(after, before time.Time) []string {
+	results := make([]string, 0)
+	for id, ts := range c {
+		if !after.IsZero() && ts.Before(after) {
+			continue
+		}
+
+		if !before.IsZero() && !ts.Before(before) {
+			continue
+		}
+		results = append(results, id)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		ti, tj := c[results[i]], c[results[j]]
+		if ti.Equal(tj) {
+			return results[i] < results[j]
+		}
+		return ti.Before(tj)
+	})
+	return results
+}
+
+// Dump an expirations index to a byte representation for storage on disk.
+func (c Expirations) Dump() (data []byte, err error) {
+	// Create a compressed writer to encode JSON into
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+
+	// Marshal the JSON representation of the index
+	encoder := json.NewEncoder(gz)
+	if err = encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("could not encode index: %s", err)
+	}
+
+	if err = gz.Close(); err != nil {
+		return nil, fmt.Errorf("could not compress index: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Load an expirations index from a byte representation on disk.
+func (c Expirations) Load(data []byte) (err error) {
+	// Create a compressed reader to decode the JSON from.
+	buf := bytes.NewBuffer(data)
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(buf); err != nil {
+		return fmt.Errorf("could not decompress index: %s", err)
+	}
+
+	decoder := json.NewDecoder(gz)
+	if err = decoder.Decode(&c); err != nil {
+		return fmt.Errorf("could not decode index: %s", err)
+	}
+	return nil
+}
+
+// Expiration index maintains the search function with the expirations index.
+type expirationIndex struct {
+	index  Expirations
+	search Searcher
+}
+
+func (c *expirationIndex) Add(key, value string) bool {
+	return c.index.Add(key, value)
+}
+
+func (c *expirationIndex) Remove(value string) bool {
+	return c.index.Remove(value)
+}
+
+func (c *expirationIndex) Find(value string) (time.Time, bool) {
+	return c.index.Find(value)
+}
+
+func (c *expirationIndex) Reverse(value string) ([]string, bool) {
+	return c.index.Reverse(value)
+}
+
+func (c *expirationIndex) Range(after, before time.Time) []string {
+	return c.index.Range(after, before)
+}
+
+func (c *expirationIndex) Load(data []byte) error {
+	return c.index.Load(data)
+}
+
+func (c *expirationIndex) Dump() ([]byte, error) {
+	return c.index.Dump()
+}
+
+func (c *expirationIndex) Len() int {
+	return len(c.index)
+}
+
+func (c *expirationIndex) Empty() bool {
+	return len(c.index) == 0
+}
+
+func (c *expirationIndex) Search(query map[string]interface{}) []string {
+	return c.search(query)
+}
+
+// WithinRange returns a search function that returns the records that expire within
+// the range specified by the "after" and "before" keys of the query. The values of the
+// keys can either be a time.Time or an RFC3339 timestamp. If neither key is in the
+// query then no records are returned.
+func (c *expirationIndex) WithinRange(indexName string) Searcher {
+	return func(query map[string]interface{}) (results []string) {
+		after, hasAfter := parseTimeQuery(query["after"])
+		before, hasBefore := parseTimeQuery(query["before"])
+		if hasAfter || hasBefore {
+			log.Debug().Str("index", indexName).Time("after", after).Time("before", before).Msg("time range search")
+			results = c.index.Range(after, before)
+		}
+		return results
+	}
+}
+
+func parseTimeQuery(val interface{}) (time.Time, bool) {
+	switch ts := val.(type) {
+	case time.Time:
+		return ts, true
+	case string:
+		if t, err := time.Parse(time.RFC3339, ts); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,71 @@
+package index_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/store/index"
+)
+
+func TestExpirationIndex(t *testing.T) {
+	idx := index.NewExpirationIndex()
+	require.True(t, idx.Empty())
+
+	require.True(t, idx.Add("2022-03-01T00:00:00Z", "c"))
+	require.True(t, idx.Add("2022-01-01T00:00:00Z", "a"))
+	require.True(t, idx.Add("2022-02-01T00:00:00-05:00", "b"))
+	require.True(t, idx.Add("2022-02-01T05:00:00Z", "d"))
+	require.False(t, idx.Add("", "e"))
+	require.False(t, idx.Add("2022-01-01T00:00:00Z", ""))
+	require.Equal(t, 4, idx.Len())
+
+	ts, ok := idx.Find("b")
+	require.True(t, ok)
+	require.True(t, ts.Equal(time.Date(2022, 2, 1, 5, 0, 0, 0, time.UTC)))
+
+	keys, ok := idx.Reverse("b")
+	require.True(t, ok)
+	require.Equal(t, []string{"2022-02-01T05:00:00Z"}, keys)
+
+	// Ranges are inclusive of after and exclusive of before
+	jan := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2022, 2, 1, 5, 0, 0, 0, time.UTC)
+	require.Equal(t, []string{"a", "b", "d", "c"}, idx.Range(time.Time{}, time.Time{}))
+	require.Equal(t, []string{"a"}, idx.Range(jan, feb))
+	require.Equal(t, []string{"b", "d", "c"}, idx.Range(feb, time.Time{}))
+
+	// Adding a record again replaces its expiration
+	require.True(t, idx.Add("2021-12-01T00:00:00Z", "c"))
+	require.Equal(t, []string{"c", "a"}, idx.Range(time.Time{}, feb))
+
+	// Search uses the after and before keys of the query
+	require.Equal(t, []string{"a"}, idx.Search(map[string]interface{}{"after": jan, "before": "2022-01-02T00:00:00Z"}))
+	require.Empty(t, idx.Search(map[string]interface{}{"name": "a"}))
+
+	require.True(t, idx.Remove("c"))
+	require.False(t, idx.Remove("c"))
+	_, ok = idx.Find("c")
+	require.False(t, ok)
+
+	// Dump and load the index
+	data, err := idx.Dump()
+	require.NoError(t, err)
+
+	loaded := index.NewExpirationIndex()
+	require.NoError(t, loaded.Load(data))
+	require.Equal(t, idx.Len(), loaded.Len())
+	require.Equal(t, idx.Range(time.Time{}, time.Time{}), loaded.Range(time.Time{}, time.Time{}))
+}
+
+func TestNormalizeSerialNumber(t *testing.T) {
+	require.Equal(t, "0A1B2C", index.NormalizeSerialNumber("0a1b2c"))
+	require.Equal(t, "0A1B2C", index.NormalizeSerialNumber("0a:1b:2c"))
+	require.Equal(t, "0A1B2C", index.NormalizeSerialNumber(" 0A 1B 2C\n"))
+
+	idx := index.NewSerialNumberIndex()
+	require.True(t, idx.Add("0a:1b:2c", "cert1"))
+	id, ok := idx.Find("0A1B2C")
+	require.True(t, ok)
+	require.Equal(t, "cert1", id)
+}
@@ -0,0 +1,397 @@
+package index
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// Tokens shorter than this length are not indexed since they are rarely meaningful.
+const tokenMinLength = 2
+
+// Scoring weights for the different ways that a query token can match an index token.
+// An exact match is always ranked higher than a prefix match, which is ranked higher
+// than a fuzzy match with the same edit distance.
+const (
+	exactMatchWeight  = 1.0
+	prefixMatchWeight = 0.75
+	fuzzyMatchWeight  = 0.5
+)
+
+// Tokens that are ignored because they appear in nearly every website or address.
+var stopTokens = map[string]struct{}{
+	"http":  {},
+	"https": {},
+	"www":   {},
+}
+
+// FullText is an inverted index that maps normalized tokens to the records that contain
+// them along with the number of times the token appears in the record. The frequency
+// is used to rank results and ensures that removing text added for a record only
+// removes the record from the index when no other text for that record has the token.
+type FullText map[string]map[string]uint32
+
+// Add the tokens of the text to the index for the specified record ID. Returns true if
+// any tokens were added to the index.
+func (c FullText) Add(text, id string) bool {
+	tokens := Tokenize(text)
+	for _, token := range tokens {
+		records, ok := c[token]
+		if !ok {
+			records = make(map[string]uint32)
+			c[token] = records
+		}
+		records[id]++
+	}
+	return len(tokens) > 0
+}
+
+// Remove the tokens of the text from the index for the specified record ID, the text
+// should be the same text that was added for the record. Returns true if any of the
+// tokens were decremented or removed.
+func (c FullText) Remove(text, id string) (removed bool) {
+	for _, token := range Tokenize(text) {
+		records, ok := c[token]
+		if !ok {
+			continue
+		}
+
+		if count, ok := records[id]; ok {
+			removed = true
+			if count <= 1 {
+				delete(records, id)
+			} else {
+				records[id] = count - 1
+			}
+		}
+
+		if len(records) == 0 {
+			delete(c, token)
+		}
+	}
+	return removed
+}
+
+// Find the records that contain the token, returns nil if the token is not indexed.
+func (c FullText) Find(token string) ([]string, bool) {
+	records, ok := c[Normalize(token)]
+	if !ok {
+		return nil, false
+	}
+
+	results := make([]string, 0, len(records))
+	for id := range records {
+		results = insort(results, id)
+	}
+	return results, true
+}
+
+// Reverse find - find all tokens that are indexed for the specified record.
+func (c FullText) Reverse(id string) ([]string, bool) {
+	results := make([]string, 0)
+	for token, records := range c {
+		if _, ok := records[id]; ok {
+			results = insort(results, token)
+		}
+	}
+	return results, len(results) > 0
+}
+
+// Rank returns the records that match the query text ordered by descending relevance.
+// Every token of the query is matched against the index either exactly, by prefix (if
+// the query token meets the minimum prefix length) or, if fuzzy is true, by edit
+// distance. The score of a record is the sum over all query tokens of the best match
+// weight multiplied by the inverse document frequency of the matched token and a
+// saturated term frequency so that rare tokens contribute more than common ones.
+func (c FullText) Rank(query string, fuzzy bool) []Hit {
+	scores := make(map[string]float64)
+	ndocs := float64(c.records())
+
+	for _, term := range Tokenize(query) {
+		// Only the best scoring match for each query token is used for each record
+		best := make(map[string]float64)
+		for token, records := range c {
+			weight := matchWeight(term, token, fuzzy)
+			if weight == 0 {
+				continue
+			}
+
+			idf := math.Log(1 + ndocs/float64(len(records)))
+			for id, count := range records {
+				tf := float64(count) / float64(count+1)
+				if score := weight * idf * tf; score > best[id] {
+					best[id] = score
+				}
+			}
+		}
+
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score == hits[j].Score {
+			return hits[i].ID < hits[j].ID
+		}
+		return hits[i].Score > hits[j].Score
+	})
+	return hits
+}
+
+// records returns the number of unique records in the index.
+func (c FullText) records() int {
+	ids := make(map[string]struct{})
+	for _, records := range c {
+		for id := range records {
+			ids[id] = struct{}{}
+		}
+	}
+	return len(ids)
+}
+
+// Dump a full text index to a byte representation for storage on disk.
+func (c FullText) Dump() (data []byte, err error) {
+	// Create a compressed writer to encode JSON into
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+
+	// Marshal the JSON representation of the index
+	encoder := json.NewEncoder(gz)
+	if err = encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("could not encode index: %s", err)
+	}
+
+	if err = gz.Close(); err != nil {
+		return nil, fmt.Errorf("could not compress index: %s", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Load a full text index from a byte representation on disk.
+func (c FullText) Load(data []byte) (err error) {
+	// Create a compressed reader to decode the JSON from.
+	buf := bytes.NewBuffer(data)
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(buf); err != nil {
+		return fmt.Errorf("could not decompress index: %s", err)
+	}
+
+	decoder := json.NewDecoder(gz)
+	if err = decoder.Decode(&c); err != nil {
+		return fmt.Errorf("could not decode index: %s", err)
+	}
+	return nil
+}
+
+// Hit is a record ID returned from a ranked full text search along with its score.
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Full text index maintains the search function with the full text index.
+type fullTextIndex struct {
+	index  FullText
+	search Searcher
+}
+
+func (c *fullTextIndex) Add(key, value string) bool {
+	return c.index.Add(key, value)
+}
+
+func (c *fullTextIndex) Remove(key, value string) bool {
+	return c.index.Remove(key, value)
+}
+
+func (c *fullTextIndex) Find(key string) ([]string, bool) {
+	return c.index.Find(key)
+}
+
+func (c *fullTextIndex) Reverse(value string) ([]string, bool) {
+	return c.index.Reverse(value)
+}
+
+func (c *fullTextIndex) Rank(query string, fuzzy bool) []Hit {
+	return c.index.Rank(query, fuzzy)
+}
+
+func (c *fullTextIndex) Load(data []byte) error {
+	return c.index.Load(data)
+}
+
+func (c *fullTextIndex) Dump() ([]byte, error) {
+	return c.index.Dump()
+}
+
+func (c *fullTextIndex) Len() int {
+	return len(c.index)
+}
+
+func (c *fullTextIndex) Empty() bool {
+	return len(c.index) == 0
+}
+
+func (c *fullTextIndex) Search(query map[string]interface{}) []string {
+	return c.search(query)
+}
+
+// RankedMatch returns a search function that returns the records matching the full
+// text query in order of relevance. Fuzzy matching is enabled if the query contains a
+// "fuzzy" key with a true value. If multiple query strings are specified, they are
+// combined into a single query.
+func (c *fullTextIndex) RankedMatch(indexName string) Searcher {
+	return func(query map[string]interface{}) (results []string) {
+		terms, ok := ParseQuery(indexName, query, nil)
+		if ok {
+			fuzzy, _ := query["fuzzy"].(bool)
+			log.Debug().Str("index", indexName).Strs("terms", terms).Bool("fuzzy", fuzzy).Msg("full text search")
+
+			hits := c.index.Rank(strings.Join(terms, " "), fuzzy)
+			results = make([]string, 0, len(hits))
+			for _, hit := range hits {
+				results = append(results, hit.ID)
+			}
+		}
+		return results
+	}
+}
+
+// Tokenize normalizes the text and splits it into tokens on any character that is not
+// a letter or a number; short tokens and stop tokens are omitted. The same token may
+// be returned multiple times if it appears multiple times in the text.
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(Normalize(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len([]rune(field)) < tokenMinLength {
+			continue
+		}
+
+		if _, ok := stopTokens[field]; ok {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// SearchText returns the text of a VASP record that should be full text indexed: the
+// common name, all legal, trading and local names, the website and the geographic
+// addresses of the entity.
+func SearchText(v *pb.VASP) []string {
+	text := make([]string, 0, 8)
+	text = append(text, v.CommonName, v.Website)
+
+	if v.Entity != nil {
+		text = append(text, v.Entity.Names()...)
+		for _, addr := range v.Entity.GeographicAddresses {
+			text = append(text, addr.AddressLine...)
+			text = append(text, addr.StreetName, addr.BuildingName, addr.TownName, addr.DistrictName, addr.CountrySubDivision)
+		}
+	}
+	return text
+}
+
+// matchWeight returns the weight of the match between the query term and the index
+// token or zero if they do not match.
+func matchWeight(term, token string, fuzzy bool) float64 {
+	if term == token {
+		return exactMatchWeight
+	}
+
+	if len(term) >= searchPrefixMinLength && strings.HasPrefix(token, term) {
+		// Prefer prefixes that cover more of the token
+		return prefixMatchWeight * float64(len(term)) / float64(len(token))
+	}
+
+	if fuzzy {
+		if maxEdits := fuzzyMaxEdits(term); maxEdits > 0 {
+			if dist := EditDistance(term, token); dist <= maxEdits {
+				return fuzzyMatchWeight * (1 - float64(dist)/float64(len([]rune(term))+1))
+			}
+		}
+	}
+	return 0
+}
+
+// FuzzyMatch returns true if the index token is within the edit distance that is
+// allowed for the query term by a fuzzy full text search.
+func FuzzyMatch(term, token string) bool {
+	maxEdits := fuzzyMaxEdits(term)
+	return maxEdits > 0 && EditDistance(term, token) <= maxEdits
+}
+
+// fuzzyMaxEdits returns the maximum edit distance allowed for a term based on its
+// length so that short terms do not match too many unrelated tokens.
+func fuzzyMaxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// EditDistance computes the Levenshtein distance between two strings, e.g. the minimum
+// number of single character insertions, deletions and substitutions required to
+// transform one string into the other.
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	// Only two rows of the distance matrix are required at any time
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = minimum(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func minimum(vals ...int) int {
+	m := vals[0]
+	for _, v := range vals[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,124 @@
+package index_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/store/index"
+)
+
+func TestTokenize(t *testing.T) {
+	tt := []struct {
+		in       string
+		expected []string
+	}{
+		{"", []string{}},
+		{"Alice VASP", []string{"alice", "vasp"}},
+		{"  CipherTrace,  Inc.  ", []string{"ciphertrace", "inc"}},
+		{"https://www.trisa.example.io/path", []string{"trisa", "example", "io", "path"}},
+		{"23 Rue de la Paix, 75002 Paris", []string{"23", "rue", "de", "la", "paix", "75002", "paris"}},
+		{"A b CD", []string{"cd"}},
+		{"Bärbel Straße", []string{"bärbel", "straße"}},
+	}
+
+	for i, tc := range tt {
+		require.Equal(t, tc.expected, index.Tokenize(tc.in), "test case %d failed", i)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tt := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"alice", "alcie", 2},
+		{"alice", "alicee", 1},
+		{"ciphertrace", "cyphertrace", 1},
+		{"straße", "strasse", 2},
+	}
+
+	for i, tc := range tt {
+		require.Equal(t, tc.expected, index.EditDistance(tc.a, tc.b), "test case %d failed", i)
+		require.Equal(t, tc.expected, index.EditDistance(tc.b, tc.a), "test case %d is not symmetric", i)
+	}
+}
+
+func TestFullTextIndex(t *testing.T) {
+	idx := index.NewFullTextIndex()
+	require.True(t, idx.Empty())
+
+	require.True(t, idx.Add("Alice VASP", "alice"))
+	require.True(t, idx.Add("https://alice.us", "alice"))
+	require.True(t, idx.Add("Bob VASP", "bob"))
+	require.True(t, idx.Add("Bob Trading Services", "bob"))
+	require.True(t, idx.Add("Charlie Bank", "charlie"))
+	require.False(t, idx.Add("", "charlie"))
+	require.False(t, idx.Empty())
+
+	records, ok := idx.Find("VASP")
+	require.True(t, ok)
+	require.Equal(t, []string{"alice", "bob"}, records)
+
+	tokens, ok := idx.Reverse("bob")
+	require.True(t, ok)
+	require.Equal(t, []string{"bob", "services", "trading", "vasp"}, tokens)
+
+	// Exact matches rank higher than other matches
+	hits := idx.Rank("bob vasp", false)
+	require.Len(t, hits, 2)
+	require.Equal(t, "bob", hits[0].ID)
+	require.Equal(t, "alice", hits[1].ID)
+	require.Greater(t, hits[0].Score, hits[1].Score)
+
+	// Prefix matches
+	hits = idx.Rank("char", false)
+	require.Len(t, hits, 1)
+	require.Equal(t, "charlie", hits[0].ID)
+
+	// Typos do not match without fuzzy search
+	require.Len(t, idx.Rank("Alcie", false), 0)
+	require.Len(t, idx.Rank("Charlei Bnak", false), 0)
+
+	// Typos do match with fuzzy search
+	hits = idx.Rank("Alicee", true)
+	require.Len(t, hits, 1)
+	require.Equal(t, "alice", hits[0].ID)
+
+	hits = idx.Rank("Charli Bnak", true)
+	require.Len(t, hits, 1)
+	require.Equal(t, "charlie", hits[0].ID)
+
+	// Short terms are not fuzzy matched
+	require.Len(t, idx.Rank("bb", true), 0)
+
+	// Search uses the text and fuzzy keys of the query
+	require.Equal(t, []string{"bob", "alice"}, idx.Search(map[string]interface{}{"text": "bob vasp"}))
+	require.Equal(t, []string{"bob"}, idx.Search(map[string]interface{}{"text": []string{"trading", "servces"}, "fuzzy": true}))
+	require.Empty(t, idx.Search(map[string]interface{}{"name": "bob"}))
+
+	// Removing text only removes the record when no other text has the token
+	require.True(t, idx.Remove("Bob Trading Services", "bob"))
+	records, ok = idx.Find("bob")
+	require.True(t, ok)
+	require.Equal(t, []string{"bob"}, records)
+	_, ok = idx.Find("trading")
+	require.False(t, ok)
+
+	require.True(t, idx.Remove("Bob VASP", "bob"))
+	_, ok = idx.Find("bob")
+	require.False(t, ok)
+	require.False(t, idx.Remove("Bob VASP", "bob"))
+
+	// Dump and load the index
+	data, err := idx.Dump()
+	require.NoError(t, err)
+
+	loaded := index.NewFullTextIndex()
+	require.NoError(t, loaded.Load(data))
+	require.Equal(t, idx.Len(), loaded.Len())
+	require.Equal(t, idx.Rank("alice charlie", false), loaded.Rank("alice charlie", false))
+}
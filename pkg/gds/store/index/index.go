@@ -1,5 +1,7 @@
 package index
 
+import "time"
+
 // Index types provide in-memory map index and helper methods for lookups and
 // constraints to improve the performance of the store without disk access. The indices
 // are intended to be checkpointed and synchronized to disk regularly but can also be
@@ -36,6 +38,29 @@ type MultiIndex interface {
 	Contains(key, value string) bool
 }
 
+// TextIndex is a full text index that maps the tokens of indexed text to record IDs.
+// Unlike the other indices, search results are returned in ranked order with the most
+// relevant records first, and the index can be queried with fuzzy matching so that
+// small typos in the query still return results.
+type TextIndex interface {
+	Index
+	Serializer
+	Find(token string) ([]string, bool)
+	Remove(key, value string) bool
+	Rank(query string, fuzzy bool) []Hit
+}
+
+// TimeIndex maps record IDs to a timestamp, e.g. the expiration of a certificate, so
+// that the records within a time range can be found without scanning the database.
+// Keys added to the index are RFC3339 timestamps and values are record IDs.
+type TimeIndex interface {
+	Index
+	Serializer
+	Find(id string) (time.Time, bool)
+	Remove(id string) bool
+	Range(after, before time.Time) []string
+}
+
 // Serializer allows indices to be loaded and dumped to disk.
 type Serializer interface {
 	Load([]byte) error
@@ -81,3 +106,41 @@ func NewCategoryIndex() MultiIndex {
 	idx.search = idx.ContainsRecord("category")
 	return idx
 }
+
+func NewFullTextIndex() TextIndex {
+	idx := &fullTextIndex{
+		index: make(FullText),
+	}
+
+	idx.search = idx.RankedMatch("text")
+	return idx
+}
+
+func NewSerialNumberIndex() SingleIndex {
+	idx := &normalizedUnique{
+		index: make(Unique),
+		norm:  NormalizeSerialNumber,
+	}
+
+	idx.search = idx.ExactMatch("serial_number")
+	return idx
+}
+
+func NewVASPCertsIndex() MultiIndex {
+	idx := &normalizedContainer{
+		index: make(Container),
+		norm:  Normalize,
+	}
+
+	idx.search = idx.ContainsRecord("vasp")
+	return idx
+}
+
+func NewExpirationIndex() TimeIndex {
+	idx := &expirationIndex{
+		index: make(Expirations),
+	}
+
+	idx.search = idx.WithinRange("expires")
+	return idx
+}
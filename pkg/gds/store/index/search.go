@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/trisa/pkg/iso3166"
@@ -41,6 +42,18 @@ func NormalizeURL(s string) string {
 	return u.Hostname()
 }
 
+// Normalize serial number returns the upper case hex encoding of a certificate serial
+// number, removing any whitespace or colon separators so that serial numbers copied
+// from different tools (e.g. openssl or the Sectigo portal) are indexed identically.
+func NormalizeSerialNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ':' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
+
 // Searcher is a function that takes a query, parses it and returns a list of the found
 // values in the index. Searcher functions are produced by the ExactMatch, PrefixMatch,
 // and ContainsRecord functions to be used with different Indices.
@@ -150,6 +163,24 @@ func ParseQuery(index string, query map[string]interface{}, norm Normalizer) ([]
 	}
 }
 
+// RankedOrder returns the IDs of the records with the ranked results that are still in
+// the records set first (in rank order) followed by any other records. The ranked IDs
+// are removed from the records set.
+func RankedOrder(ranked []string, records map[string]struct{}) []string {
+	order := make([]string, 0, len(records))
+	for _, id := range ranked {
+		if _, ok := records[id]; ok {
+			order = append(order, id)
+			delete(records, id)
+		}
+	}
+
+	for id := range records {
+		order = append(order, id)
+	}
+	return order
+}
+
 func insort(arr []string, item string) []string {
 	if len(arr) == 0 {
 		arr = append(arr, item)
@@ -203,3 +203,18 @@ func TestWebsiteSearch(t *testing.T) {
 	require.Contains(t, results, aliceID, "search doesn't contain alice")
 	require.Contains(t, results, bobID, "search doesn't contain bob")
 }
+
+func TestRankedOrder(t *testing.T) {
+	records := map[string]struct{}{"a": {}, "b": {}, "c": {}, "d": {}}
+
+	// Ranked records that are not in the records set are skipped and the unranked records
+	// are returned after the ranked records.
+	order := index.RankedOrder([]string{"c", "x", "a"}, records)
+	require.Len(t, order, 4)
+	require.Equal(t, []string{"c", "a"}, order[:2])
+	require.ElementsMatch(t, []string{"b", "d"}, order[2:])
+
+	// No ranked results returns all of the records
+	order = index.RankedOrder(nil, map[string]struct{}{"a": {}})
+	require.Equal(t, []string{"a"}, order)
+}
@@ -1,6 +1,8 @@
 package iterator
 
 import (
+	"time"
+
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 )
@@ -35,3 +37,25 @@ type CertificateIterator interface {
 	Cert() (*models.Certificate, error)
 	All() ([]*models.Certificate, error)
 }
+
+// PendingActionIterator allows access to PendingActionStore models
+type PendingActionIterator interface {
+	Iterator
+	Action() (*models.PendingAction, error)
+	All() ([]*models.PendingAction, error)
+}
+
+// AuditRecordIterator allows access to AuditStore models, most recent first
+type AuditRecordIterator interface {
+	Iterator
+	Record() (*models.AuditRecord, error)
+	All() ([]*models.AuditRecord, error)
+	SeekTimestamp(ts time.Time) bool
+}
+
+// RevisionIterator allows access to RevisionStore models
+type RevisionIterator interface {
+	Iterator
+	Revision() (*models.VASPRevision, error)
+	All() ([]*models.VASPRevision, error)
+}
@@ -1,6 +1,8 @@
 package leveldb
 
 import (
+	"time"
+
 	"github.com/rs/zerolog/log"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
@@ -25,6 +27,18 @@ type certReqIterator struct {
 	iterWrapper
 }
 
+type actionIterator struct {
+	iterWrapper
+}
+
+type auditIterator struct {
+	iterWrapper
+}
+
+type revisionIterator struct {
+	iterWrapper
+}
+
 func (i *iterWrapper) Next() bool {
 	return i.iter.Next()
 }
@@ -132,3 +146,90 @@ func (i *certReqIterator) All() (reqs []*models.CertificateRequest, err error) {
 
 	return reqs, nil
 }
+
+func (i *actionIterator) Action() (*models.PendingAction, error) {
+	a := new(models.PendingAction)
+	if err := proto.Unmarshal(i.iter.Value(), a); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceActions).Str("key", string(i.iter.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return a, nil
+}
+
+func (i *actionIterator) All() (actions []*models.PendingAction, err error) {
+	actions = make([]*models.PendingAction, 0)
+	defer i.iter.Release()
+	for i.iter.Next() {
+		a := new(models.PendingAction)
+		if err = proto.Unmarshal(i.iter.Value(), a); err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	if err = i.iter.Error(); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func (i *auditIterator) Record() (*models.AuditRecord, error) {
+	r := new(models.AuditRecord)
+	if err := proto.Unmarshal(i.iter.Value(), r); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceAudit).Str("key", string(i.iter.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return r, nil
+}
+
+func (i *auditIterator) All() (records []*models.AuditRecord, err error) {
+	records = make([]*models.AuditRecord, 0)
+	defer i.iter.Release()
+	for i.iter.Next() {
+		r := new(models.AuditRecord)
+		if err = proto.Unmarshal(i.iter.Value(), r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+
+	if err = i.iter.Error(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// SeekTimestamp positions the iterator at the most recent audit record created at or
+// before the timestamp, returning false if there is no such record.
+func (i *auditIterator) SeekTimestamp(ts time.Time) bool {
+	return i.iter.Seek(auditKey(models.AuditRecordSeekKey(ts)))
+}
+
+func (i *revisionIterator) Revision() (*models.VASPRevision, error) {
+	r := new(models.VASPRevision)
+	if err := proto.Unmarshal(i.iter.Value(), r); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceRevisions).Str("key", string(i.iter.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return r, nil
+}
+
+func (i *revisionIterator) All() (revisions []*models.VASPRevision, err error) {
+	revisions = make([]*models.VASPRevision, 0)
+	defer i.iter.Release()
+	for i.iter.Next() {
+		r := new(models.VASPRevision)
+		if err = proto.Unmarshal(i.iter.Value(), r); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, r)
+	}
+
+	if err = i.iter.Error(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -3,6 +3,7 @@ package leveldb
 import (
 	"archive/tar"
 	"compress/gzip"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -20,6 +21,7 @@ import (
 	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
 	"github.com/trisacrypto/directory/pkg/gds/store/index"
 	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 	"google.golang.org/protobuf/proto"
 )
@@ -39,7 +41,7 @@ func Open(path string) (*Store, error) {
 	// Perform a reindex if the local indices are null or empty. In the case where the
 	// store has no data, this won't be harmful - but in the case where the stored index
 	// has been corrupted, this should repair it.
-	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() {
+	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() || store.fulltext.Empty() || store.missingCertIndices() {
 		log.Info().Msg("reindexing to recover from empty indices")
 		if err = store.Reindex(); err != nil {
 			return nil, err
@@ -56,9 +58,16 @@ var (
 	keyWebsiteIndex  = []byte("index::websites")
 	keyCountryIndex  = []byte("index::countries")
 	keyCategoryIndex = []byte("index::categories")
+	keyFullTextIndex = []byte("index::fulltext")
+	keySerialIndex   = []byte("index::serials")
+	keyCertVASPIndex = []byte("index::certvasps")
+	keyExpiresIndex  = []byte("index::expirations")
 	preVASPs         = []byte("vasps::")
 	preCerts         = []byte("certs::")
 	preCertReqs      = []byte("certreqs::")
+	preActions       = []byte("actions::")
+	preAudit         = []byte("audit::")
+	preRevisions     = []byte("revisions::")
 )
 
 // Store implements store.Store for some basic LevelDB operations and simple protocol
@@ -71,6 +80,10 @@ type Store struct {
 	websites   index.SingleIndex // website/url index
 	countries  index.MultiIndex  // lookup vasps in a specific country
 	categories index.MultiIndex  // lookup vasps based on specified categories
+	fulltext   index.TextIndex   // full text search of names, websites, and addresses
+	serials    index.SingleIndex // lookup certificates by serial number
+	certVASPs  index.MultiIndex  // lookup certificates issued to a specific vasp
+	expires    index.TimeIndex   // lookup certificates that expire in a time window
 }
 
 //===========================================================================
@@ -133,8 +146,15 @@ func (s *Store) CreateVASP(v *pb.VASP) (id string, err error) {
 		return "", err
 	}
 
-	// This Put must be inside the lock to ensure the indices reflect what is in the db.
-	if err = s.db.Put(key, data, nil); err != nil {
+	// The record is written along with its first revision in a single batch, which
+	// must be inside the lock to ensure the indices reflect what is in the db.
+	batch := new(leveldb.Batch)
+	batch.Put(key, data)
+	if err = s.putRevision(batch, v); err != nil {
+		return "", err
+	}
+
+	if err = s.db.Write(batch, nil); err != nil {
 		return "", err
 	}
 
@@ -165,7 +185,8 @@ func (s *Store) RetrieveVASP(id string) (v *pb.VASP, err error) {
 }
 
 // UpdateVASP by the VASP ID (required). This method simply overwrites the
-// entire VASP record and does not update individual fields.
+// entire VASP record and does not update individual fields. If the stored record has
+// a different version than the record being updated, ErrConcurrentUpdate is returned.
 func (s *Store) UpdateVASP(v *pb.VASP) (err error) {
 	if v.Id == "" {
 		return storeerrors.ErrIncompleteRecord
@@ -178,18 +199,6 @@ func (s *Store) UpdateVASP(v *pb.VASP) (err error) {
 		return storeerrors.ErrIncompleteRecord
 	}
 
-	// Update management timestamps and record metadata
-	v.Version.Version++
-	v.LastUpdated = time.Now().Format(time.RFC3339)
-	if v.FirstListed == "" {
-		v.FirstListed = v.LastUpdated
-	}
-
-	var val []byte
-	if val, err = proto.Marshal(v); err != nil {
-		return err
-	}
-
 	// Critical section (optimizing for safety rather than speed)
 	s.Lock()
 	defer s.Unlock()
@@ -201,16 +210,43 @@ func (s *Store) UpdateVASP(v *pb.VASP) (err error) {
 		return err
 	}
 
+	// The stored record must still be the version of the record that the caller
+	// retrieved, otherwise the update would overwrite the changes made since then.
+	if o.Version.GetVersion() != v.Version.GetVersion() {
+		return storeerrors.ErrConcurrentUpdate
+	}
+
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
 	if id, ok := s.names.Find(v.CommonName); ok && id != v.Id {
 		return storeerrors.ErrDuplicateEntity
 	}
 
-	// Insert the new record
+	// Update management timestamps and record metadata
+	if v.Version == nil {
+		v.Version = &pb.Version{}
+	}
+	v.Version.Version++
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+
+	var val []byte
+	if val, err = proto.Marshal(v); err != nil {
+		return err
+	}
+
+	// Insert the new record along with a new revision
 	// This must be inside the lock so that the indices reflect what is currently in
 	// the database and there is no race condition between the retrieve and put.
-	if err = s.db.Put(key, val, nil); err != nil {
+	batch := new(leveldb.Batch)
+	batch.Put(key, val)
+	if err = s.putRevision(batch, v); err != nil {
+		return err
+	}
+
+	if err = s.db.Write(batch, nil); err != nil {
 		return err
 	}
 
@@ -244,8 +280,21 @@ func (s *Store) DeleteVASP(id string) (err error) {
 		return err
 	}
 
+	// Delete the record along with its revision history
+	batch := new(leveldb.Batch)
+	batch.Delete(key)
+
+	iter := s.db.NewIterator(util.BytesPrefix(revisionPrefix(id)), nil)
+	for iter.Next() {
+		batch.Delete(iter.Key())
+	}
+	iter.Release()
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
 	// LevelDB will not return an error if the entity does not exist
-	if err = s.db.Delete(key, nil); err != nil {
+	if err = s.db.Write(batch, nil); err != nil {
 		return err
 	}
 
@@ -270,13 +319,21 @@ func (s *Store) ListVASPs() iterator.DirectoryIterator {
 // VASP by name, a case insensitive search is performed if the query exists in
 // any of the VASP entity names. If there is not an exact match a prefix lookup is used
 // so long as the prefix > 3 characters. The search also looks up website matches by
-// parsing urls to match hostnames rather than scheme or path. Finally the query is
-// filtered by country and category.
+// parsing urls to match hostnames rather than scheme or path. A "text" query performs
+// a full text search (with typo tolerance if "fuzzy" is true) whose results are
+// returned first in order of relevance. Finally the query is filtered by country and
+// category.
 func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*pb.VASP, err error) {
 	// A set of records that match the query and need to be fetched
 	records := make(map[string]struct{})
 
 	s.RLock()
+	// Full text search results are ranked, so maintain their order
+	ranked := s.fulltext.Search(query)
+	for _, result := range ranked {
+		records[result] = struct{}{}
+	}
+
 	// Search the name index
 	for _, result := range s.names.Search(query) {
 		records[result] = struct{}{}
@@ -322,7 +379,7 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*pb.VASP, err
 	// Perform the lookup of records if there are any
 	if len(records) > 0 {
 		vasps = make([]*pb.VASP, 0, len(records))
-		for id := range records {
+		for _, id := range index.RankedOrder(ranked, records) {
 			var vasp *pb.VASP
 			if vasp, err = s.RetrieveVASP(id); err != nil {
 				if err == storeerrors.ErrEntityNotFound {
@@ -330,6 +387,12 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*pb.VASP, err
 				}
 				return nil, err
 			}
+
+			// Deleted VASPs are retained until they are purged but are not searchable
+			if models.IsArchived(vasp) {
+				continue
+			}
+
 			vasps = append(vasps, vasp)
 		}
 	}
@@ -337,6 +400,35 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*pb.VASP, err
 	return vasps, nil
 }
 
+// QueryVASPs returns a page of VASPs that match the query, ordered by relevance or by
+// name. If the query has name, website, or text terms, the indices are used to find
+// and score the matching records, otherwise all of the VASPs are filtered.
+func (s *Store) QueryVASPs(query *search.Query) (page *search.Page, err error) {
+	if !query.HasTerms() {
+		return search.Scan(query, s.ListVASPs())
+	}
+
+	s.RLock()
+	scores := search.Score(query, s.names, s.websites, s.fulltext)
+	s.RUnlock()
+
+	results := make([]*search.Result, 0, len(scores))
+	for id, score := range scores {
+		var vasp *pb.VASP
+		if vasp, err = s.RetrieveVASP(id); err != nil {
+			if err == storeerrors.ErrEntityNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		if query.Match(vasp) {
+			results = append(results, &search.Result{VASP: vasp, Score: score})
+		}
+	}
+	return search.Paginate(query, results)
+}
+
 //===========================================================================
 // CertificateStore Implementation
 //===========================================================================
@@ -366,10 +458,18 @@ func (s *Store) CreateCert(c *models.Certificate) (id string, err error) {
 		return "", err
 	}
 
+	// Critical section to ensure the indices reflect what is in the database
+	s.Lock()
+	defer s.Unlock()
+
 	if err = s.db.Put(key, data, nil); err != nil {
 		return "", err
 	}
 
+	// Update indices after successful insert
+	if err = s.insertCertIndices(c); err != nil {
+		return "", err
+	}
 	return c.Id, nil
 }
 
@@ -408,23 +508,113 @@ func (s *Store) UpdateCert(c *models.Certificate) (err error) {
 		return err
 	}
 
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	// Retrieve the original record (if any) to ensure that the indices are updated
+	// properly; this must be inside the lock so the indices reflect the database.
+	o, err := s.RetrieveCert(c.Id)
+	if err != nil && err != storeerrors.ErrEntityNotFound {
+		return err
+	}
+
 	if err = s.db.Put(key, data, nil); err != nil {
 		return err
 	}
 
+	if o != nil {
+		if err = s.removeCertIndices(o); err != nil {
+			// NOTE: if this error is triggered, admins may want to reindex the database
+			log.Error().Err(err).Msg("could not remove previous certificate indices on update: reindex required")
+		}
+	}
+	if err = s.insertCertIndices(c); err != nil {
+		return err
+	}
 	return nil
 }
 
-// DeleteCertReq removes a certificate request from the store.
+// DeleteCert removes a certificate from the store and the certificate indices.
 func (s *Store) DeleteCert(id string) (err error) {
-	// LevelDB will not return an error if the entity does not exist
 	key := certKey(id)
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	// Lookup the record in order to remove it from the indices
+	record, err := s.RetrieveCert(id)
+	if err != nil {
+		if err == storeerrors.ErrEntityNotFound {
+			return nil
+		}
+		return err
+	}
+
 	if err = s.db.Delete(key, nil); err != nil {
 		return err
 	}
+
+	if err = s.removeCertIndices(record); err != nil {
+		return err
+	}
 	return nil
 }
 
+// RetrieveCertBySerial returns the certificate with the specified hex encoded serial
+// number; colons and whitespace in the serial number are ignored.
+func (s *Store) RetrieveCertBySerial(serial string) (c *models.Certificate, err error) {
+	s.RLock()
+	id, ok := s.serials.Find(serial)
+	s.RUnlock()
+
+	if !ok {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+	return s.RetrieveCert(id)
+}
+
+// ListCertsByVASP returns all certificates issued to the specified VASP ordered by ID.
+func (s *Store) ListCertsByVASP(vaspID string) (certs []*models.Certificate, err error) {
+	s.RLock()
+	ids, _ := s.certVASPs.Find(vaspID)
+	// Copy the IDs since the index may be modified once the lock is released
+	ids = append(make([]string, 0, len(ids)), ids...)
+	s.RUnlock()
+
+	return s.retrieveCerts(ids)
+}
+
+// ListCertsByExpiration returns all certificates that expire on or after the after
+// timestamp and before the before timestamp, ordered by expiration (soonest first).
+// A zero timestamp leaves that side of the window unbounded.
+func (s *Store) ListCertsByExpiration(after, before time.Time) (certs []*models.Certificate, err error) {
+	s.RLock()
+	ids := s.expires.Range(after, before)
+	s.RUnlock()
+
+	return s.retrieveCerts(ids)
+}
+
+// retrieveCerts fetches the certificates found by an index lookup, skipping any that
+// are in the index but no longer in the database.
+func (s *Store) retrieveCerts(ids []string) (certs []*models.Certificate, err error) {
+	certs = make([]*models.Certificate, 0, len(ids))
+	for _, id := range ids {
+		var c *models.Certificate
+		if c, err = s.RetrieveCert(id); err != nil {
+			if err == storeerrors.ErrEntityNotFound {
+				log.Warn().Str("id", id).Msg("certificate index out of sync with database: reindex required")
+				continue
+			}
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, nil
+}
+
 //===========================================================================
 // CertificateRequestStore Implementation
 //===========================================================================
@@ -525,6 +715,292 @@ func (s *Store) DeleteCertReq(id string) (err error) {
 	return nil
 }
 
+//===========================================================================
+// PendingActionStore Implementation
+//===========================================================================
+
+// ListActions returns all pending actions that are currently in the store.
+func (s *Store) ListActions() iterator.PendingActionIterator {
+	return &actionIterator{
+		iterWrapper{
+			iter: s.db.NewIterator(util.BytesPrefix(preActions), nil),
+		},
+	}
+}
+
+// CreateAction and assign a new ID and return the version.
+func (s *Store) CreateAction(a *models.PendingAction) (id string, err error) {
+	if a.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create UUID for record
+	a.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	a.Created = time.Now().Format(time.RFC3339)
+	if a.Modified == "" {
+		a.Modified = a.Created
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(a); err != nil {
+		return "", err
+	}
+
+	if err = s.db.Put(actionKey(a.Id), data, nil); err != nil {
+		return "", err
+	}
+
+	return a.Id, nil
+}
+
+// RetrieveAction returns a pending action by ID.
+func (s *Store) RetrieveAction(id string) (a *models.PendingAction, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var val []byte
+	if val, err = s.db.Get(actionKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	a = new(models.PendingAction)
+	if err = proto.Unmarshal(val, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// UpdateAction can create or update a pending action. The action should be as complete
+// as possible, including an ID generated by the caller.
+func (s *Store) UpdateAction(a *models.PendingAction) (err error) {
+	if a.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	a.Modified = time.Now().Format(time.RFC3339)
+	if a.Created == "" {
+		a.Created = a.Modified
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(a); err != nil {
+		return err
+	}
+
+	if err = s.db.Put(actionKey(a.Id), data, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAction removes a pending action from the store.
+func (s *Store) DeleteAction(id string) (err error) {
+	// LevelDB will not return an error if the entity does not exist
+	if err = s.db.Delete(actionKey(id), nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+//===========================================================================
+// AuditStore Implementation
+//===========================================================================
+
+// ListAuditRecords returns all of the records in the admin audit log, most recent first.
+func (s *Store) ListAuditRecords() iterator.AuditRecordIterator {
+	return &auditIterator{
+		iterWrapper{
+			iter: s.db.NewIterator(util.BytesPrefix(preAudit), nil),
+		},
+	}
+}
+
+// CreateAuditRecord appends a record to the audit log, assigning it a new ID.
+func (s *Store) CreateAuditRecord(r *models.AuditRecord) (id string, err error) {
+	if r.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create a time ordered ID for the record
+	if r.Timestamp == "" {
+		r.Timestamp = time.Now().Format(time.RFC3339Nano)
+	}
+	r.Id = models.NewAuditRecordID(r)
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return "", err
+	}
+
+	if err = s.db.Put(auditKey(r.Id), data, nil); err != nil {
+		return "", err
+	}
+
+	return r.Id, nil
+}
+
+// RetrieveAuditRecord returns an audit record by ID.
+func (s *Store) RetrieveAuditRecord(id string) (r *models.AuditRecord, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var val []byte
+	if val, err = s.db.Get(auditKey(id), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.AuditRecord)
+	if err = proto.Unmarshal(val, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ImportVASP writes a VASP migrated from another store with its original ID, version
+// and timestamps without creating a revision. The VASP must not already exist.
+func (s *Store) ImportVASP(v *pb.VASP) (err error) {
+	if v.Id == "" || index.Normalize(v.CommonName) == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(v); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// The indices of an existing record would not be removed, so it cannot be replaced
+	if _, ok := s.names.Find(v.CommonName); ok {
+		return storeerrors.ErrDuplicateEntity
+	}
+
+	var exists bool
+	if exists, err = s.db.Has(vaspKey(v.Id), nil); err != nil {
+		return err
+	}
+	if exists {
+		return storeerrors.ErrDuplicateEntity
+	}
+
+	if err = s.db.Put(vaspKey(v.Id), data, nil); err != nil {
+		return err
+	}
+	return s.insertIndices(v)
+}
+
+// ImportAuditRecord writes an audit record migrated from another store with its
+// original ID, replacing any record with the same ID.
+func (s *Store) ImportAuditRecord(r *models.AuditRecord) (err error) {
+	if r.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	return s.db.Put(auditKey(r.Id), data, nil)
+}
+
+//===========================================================================
+// RevisionStore Implementation
+//===========================================================================
+
+// ListRevisions returns the revisions of the specified VASP from oldest to newest.
+func (s *Store) ListRevisions(vaspID string) iterator.RevisionIterator {
+	return &revisionIterator{
+		iterWrapper{
+			iter: s.db.NewIterator(util.BytesPrefix(revisionPrefix(vaspID)), nil),
+		},
+	}
+}
+
+// RetrieveRevision returns the specified revision of a VASP record.
+func (s *Store) RetrieveRevision(vaspID string, revision uint64) (r *models.VASPRevision, err error) {
+	if vaspID == "" || revision == 0 {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var val []byte
+	if val, err = s.db.Get(revisionKey(vaspID, revision), nil); err != nil {
+		if err == leveldb.ErrNotFound {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.VASPRevision)
+	if err = proto.Unmarshal(val, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ImportRevision writes a revision migrated from another store with its original
+// revision number, replacing any revision of the VASP with the same number.
+func (s *Store) ImportRevision(r *models.VASPRevision) (err error) {
+	if r.Vasp == "" || r.Revision == 0 {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	// The lock prevents the import from racing with putRevision
+	s.Lock()
+	defer s.Unlock()
+	return s.db.Put(revisionKey(r.Vasp, r.Revision), data, nil)
+}
+
+// putRevision adds the next revision of the VASP to the batch. This must be called
+// inside the lock so that concurrent updates are not assigned the same revision.
+func (s *Store) putRevision(batch *leveldb.Batch, v *pb.VASP) (err error) {
+	// Revision keys are zero padded so the most recent revision is sorted last
+	rev := &models.VASPRevision{Vasp: v.Id, Revision: 1, Created: v.LastUpdated, Record: v}
+	iter := s.db.NewIterator(util.BytesPrefix(revisionPrefix(v.Id)), nil)
+	if iter.Last() {
+		prev := new(models.VASPRevision)
+		if err = proto.Unmarshal(iter.Value(), prev); err != nil {
+			iter.Release()
+			return err
+		}
+		rev.Revision = prev.Revision + 1
+	}
+
+	iter.Release()
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(rev); err != nil {
+		return err
+	}
+
+	batch.Put(revisionKey(v.Id, rev.Revision), data)
+	return nil
+}
+
 //===========================================================================
 // Key Handlers
 //===========================================================================
@@ -553,6 +1029,27 @@ func careqKey(id string) (key []byte) {
 	return makeKey(preCertReqs, id)
 }
 
+// creates a []byte key from the pending action id using a prefix to act as a leveldb bucket
+func actionKey(id string) (key []byte) {
+	return makeKey(preActions, id)
+}
+
+// creates a []byte key from the audit record id using a prefix to act as a leveldb bucket
+func auditKey(id string) (key []byte) {
+	return makeKey(preAudit, id)
+}
+
+// creates a []byte key from the vasp id and revision number, zero padding the revision
+// so that the revisions of a vasp are sorted in order
+func revisionKey(vaspID string, revision uint64) (key []byte) {
+	return makeKey(preRevisions, fmt.Sprintf("%s:%020d", vaspID, revision))
+}
+
+// creates a []byte prefix to iterate over all of the revisions of the vasp
+func revisionPrefix(vaspID string) (prefix []byte) {
+	return makeKey(preRevisions, vaspID+":")
+}
+
 //===========================================================================
 // Indexer
 //===========================================================================
@@ -564,6 +1061,10 @@ func (s *Store) Reindex() (err error) {
 	websites := index.NewWebsiteIndex()
 	countries := index.NewCountryIndex()
 	categories := index.NewCategoryIndex()
+	fulltext := index.NewFullTextIndex()
+	serials := index.NewSerialNumberIndex()
+	certVASPs := index.NewVASPCertsIndex()
+	expires := index.NewExpirationIndex()
 
 	iter := s.db.NewIterator(util.BytesPrefix(preVASPs), nil)
 	defer iter.Release()
@@ -594,12 +1095,38 @@ func (s *Store) Reindex() (err error) {
 		for _, vaspCategory := range vasp.VaspCategories {
 			categories.Add(vaspCategory, vasp.Id)
 		}
+
+		// Update full text index
+		for _, text := range index.SearchText(vasp) {
+			fulltext.Add(text, vasp.Id)
+		}
 	}
 
 	if err = iter.Error(); err != nil {
 		return err
 	}
 
+	certs := s.db.NewIterator(util.BytesPrefix(preCerts), nil)
+	defer certs.Release()
+
+	for certs.Next() {
+		cert := new(models.Certificate)
+		if err = proto.Unmarshal(certs.Value(), cert); err != nil {
+			return err
+		}
+
+		// Update certificate indices
+		certVASPs.Add(cert.Vasp, cert.Id)
+		if cert.Details != nil {
+			serials.Overwrite(hex.EncodeToString(cert.Details.SerialNumber), cert.Id)
+			expires.Add(cert.Details.NotAfter, cert.Id)
+		}
+	}
+
+	if err = certs.Error(); err != nil {
+		return err
+	}
+
 	s.Lock()
 	if !names.Empty() {
 		s.names = names
@@ -616,6 +1143,22 @@ func (s *Store) Reindex() (err error) {
 	if !categories.Empty() {
 		s.categories = categories
 	}
+
+	if !fulltext.Empty() {
+		s.fulltext = fulltext
+	}
+
+	if !serials.Empty() {
+		s.serials = serials
+	}
+
+	if !certVASPs.Empty() {
+		s.certVASPs = certVASPs
+	}
+
+	if !expires.Empty() {
+		s.expires = expires
+	}
 	s.Unlock()
 
 	if err = s.sync(); err != nil {
@@ -627,6 +1170,10 @@ func (s *Store) Reindex() (err error) {
 		Int("websites", s.websites.Len()).
 		Int("countries", s.countries.Len()).
 		Int("categories", s.categories.Len()).
+		Int("fulltext", s.fulltext.Len()).
+		Int("serials", s.serials.Len()).
+		Int("certvasps", s.certVASPs.Len()).
+		Int("expirations", s.expires.Len()).
 		Msg("reindex complete")
 	return nil
 }
@@ -771,9 +1318,13 @@ func CopyDB(src *leveldb.DB, dst *leveldb.DB) (ncopied uint64, err error) {
 //===========================================================================
 
 func (s *Store) insertIndices(v *pb.VASP) (err error) {
-	s.names.Add(v.CommonName, v.Id)
-	for _, name := range v.Entity.Names() {
-		s.names.Add(name, v.Id)
+	// Archived VASPs do not reserve their names so that the VASP can be registered
+	// again; the names are indexed again if the archived VASP is restored.
+	if !models.IsArchived(v) {
+		s.names.Add(v.CommonName, v.Id)
+		for _, name := range v.Entity.Names() {
+			s.names.Add(name, v.Id)
+		}
 	}
 
 	s.websites.Add(v.Website, v.Id)
@@ -788,13 +1339,19 @@ func (s *Store) insertIndices(v *pb.VASP) (err error) {
 		s.categories.Add(vaspCategory, v.Id)
 	}
 
+	for _, text := range index.SearchText(v) {
+		s.fulltext.Add(text, v.Id)
+	}
 	return nil
 }
 
 func (s *Store) removeIndices(v *pb.VASP) (err error) {
-	s.names.Remove(v.CommonName)
-	for _, name := range v.Entity.Names() {
-		s.names.Remove(name)
+	// Only remove the names that have not been reassigned to another VASP, e.g. when
+	// an archived VASP has been registered again.
+	for _, name := range append([]string{v.CommonName}, v.Entity.Names()...) {
+		if id, ok := s.names.Find(name); ok && id == v.Id {
+			s.names.Remove(name)
+		}
 	}
 
 	s.websites.Remove(v.Website)
@@ -808,9 +1365,48 @@ func (s *Store) removeIndices(v *pb.VASP) (err error) {
 	for _, vaspCategory := range v.VaspCategories {
 		s.categories.Remove(vaspCategory, v.Id)
 	}
+
+	for _, text := range index.SearchText(v) {
+		s.fulltext.Remove(text, v.Id)
+	}
+	return nil
+}
+
+func (s *Store) insertCertIndices(c *models.Certificate) (err error) {
+	s.certVASPs.Add(c.Vasp, c.Id)
+	if c.Details != nil {
+		s.serials.Overwrite(hex.EncodeToString(c.Details.SerialNumber), c.Id)
+		s.expires.Add(c.Details.NotAfter, c.Id)
+	}
 	return nil
 }
 
+func (s *Store) removeCertIndices(c *models.Certificate) (err error) {
+	s.certVASPs.Remove(c.Vasp, c.Id)
+	if c.Details != nil {
+		// Only remove the serial number if it has not been reassigned to another record
+		serial := hex.EncodeToString(c.Details.SerialNumber)
+		if id, ok := s.serials.Find(serial); ok && id == c.Id {
+			s.serials.Remove(serial)
+		}
+	}
+	s.expires.Remove(c.Id)
+	return nil
+}
+
+// missingCertIndices returns true if the certificate indices are empty but there are
+// certificates in the database, e.g. if the database was created before the
+// certificate indices were added and must be reindexed.
+func (s *Store) missingCertIndices() bool {
+	if !s.serials.Empty() || !s.certVASPs.Empty() || !s.expires.Empty() {
+		return false
+	}
+
+	iter := s.db.NewIterator(util.BytesPrefix(preCerts), nil)
+	defer iter.Release()
+	return iter.Next()
+}
+
 // sync all indices with the underlying database
 func (s *Store) sync() (err error) {
 	if err = s.seqsync(); err != nil {
@@ -833,11 +1429,31 @@ func (s *Store) sync() (err error) {
 		return err
 	}
 
+	if err = s.syncfulltext(); err != nil {
+		return err
+	}
+
+	if err = s.syncserials(); err != nil {
+		return err
+	}
+
+	if err = s.synccertvasps(); err != nil {
+		return err
+	}
+
+	if err = s.syncexpires(); err != nil {
+		return err
+	}
+
 	log.Debug().
 		Int("names", s.names.Len()).
 		Int("websites", s.websites.Len()).
 		Int("countries", s.countries.Len()).
 		Int("categories", s.categories.Len()).
+		Int("fulltext", s.fulltext.Len()).
+		Int("serials", s.serials.Len()).
+		Int("certvasps", s.certVASPs.Len()).
+		Int("expirations", s.expires.Len()).
 		Msg("indices synchronized")
 	return nil
 }
@@ -1057,3 +1673,179 @@ func (s *Store) synccategories() (err error) {
 	log.Debug().Int("size", len(val)).Msg("categories index checkpointed")
 	return nil
 }
+
+// sync the full text index with the leveldb full text key
+func (s *Store) syncfulltext() (err error) {
+	var val []byte
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.fulltext == nil {
+		// Create the full text index and load from the database
+		s.fulltext = index.NewFullTextIndex()
+
+		// fetch the full text index from the database
+		if val, err = s.db.Get(keyFullTextIndex, nil); err != nil {
+			if err == leveldb.ErrNotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch full text index from database")
+			return err
+		}
+
+		if err = s.fulltext.Load(val); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	if !s.fulltext.Empty() {
+		// Put the current full text index back to the database
+		if val, err = s.fulltext.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if err = s.db.Put(keyFullTextIndex, val, nil); err != nil {
+			log.Error().Err(err).Msg("could not put full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	log.Debug().Int("size", len(val)).Msg("full text index checkpointed")
+	return nil
+}
+
+// sync the serial number index with the leveldb serial number key
+func (s *Store) syncserials() (err error) {
+	var val []byte
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.serials == nil {
+		// Create the serial number index and load from the database
+		s.serials = index.NewSerialNumberIndex()
+
+		// fetch the serial number index from the database
+		if val, err = s.db.Get(keySerialIndex, nil); err != nil {
+			if err == leveldb.ErrNotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch serial number index from database")
+			return err
+		}
+
+		if err = s.serials.Load(val); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	if !s.serials.Empty() {
+		// Put the current serial number index back to the database
+		if val, err = s.serials.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if err = s.db.Put(keySerialIndex, val, nil); err != nil {
+			log.Error().Err(err).Msg("could not put serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	log.Debug().Int("size", len(val)).Msg("serial number index checkpointed")
+	return nil
+}
+
+// sync the certificate vasps index with the leveldb certificate vasps key
+func (s *Store) synccertvasps() (err error) {
+	var val []byte
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.certVASPs == nil {
+		// Create the certificate vasps index and load from the database
+		s.certVASPs = index.NewVASPCertsIndex()
+
+		// fetch the certificate vasps index from the database
+		if val, err = s.db.Get(keyCertVASPIndex, nil); err != nil {
+			if err == leveldb.ErrNotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch certificate vasps index from database")
+			return err
+		}
+
+		if err = s.certVASPs.Load(val); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	if !s.certVASPs.Empty() {
+		// Put the current certificate vasps index back to the database
+		if val, err = s.certVASPs.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if err = s.db.Put(keyCertVASPIndex, val, nil); err != nil {
+			log.Error().Err(err).Msg("could not put certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	log.Debug().Int("size", len(val)).Msg("certificate vasps index checkpointed")
+	return nil
+}
+
+// sync the expirations index with the leveldb expirations key
+func (s *Store) syncexpires() (err error) {
+	var val []byte
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.expires == nil {
+		// Create the expirations index and load from the database
+		s.expires = index.NewExpirationIndex()
+
+		// fetch the expirations index from the database
+		if val, err = s.db.Get(keyExpiresIndex, nil); err != nil {
+			if err == leveldb.ErrNotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch expirations index from database")
+			return err
+		}
+
+		if err = s.expires.Load(val); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	if !s.expires.Empty() {
+		// Put the current expirations index back to the database
+		if val, err = s.expires.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if err = s.db.Put(keyExpiresIndex, val, nil); err != nil {
+			log.Error().Err(err).Msg("could not put expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	log.Debug().Int("size", len(val)).Msg("expirations index checkpointed")
+	return nil
+}
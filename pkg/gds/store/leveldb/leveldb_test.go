@@ -97,6 +97,20 @@ func (s *leveldbTestSuite) TestDirectoryStore() {
 	s.Equal(uint64(2), alicer.Version.Version)
 	s.Equal(alicer.VerificationStatus, pb.VerificationState_VERIFIED)
 
+	// The full text index should reflect the updated name
+	vasps, err := s.db.SearchVASPs(map[string]interface{}{"text": "alicelitecoin"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"text": "alicelitecion"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"text": "alicelitecion", "fuzzy": true})
+	s.NoError(err)
+	s.Len(vasps, 1)
+	s.Equal(id, vasps[0].Id)
+
 	// Delete the VASP
 	err = s.db.DeleteVASP(id)
 	s.NoError(err)
@@ -104,6 +118,10 @@ func (s *leveldbTestSuite) TestDirectoryStore() {
 	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
 	s.Empty(alicer)
 
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"text": "alicelitecoin"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
 	// Add a few more VASPs
 	for i := 0; i < 10; i++ {
 		vasp := &pb.VASP{
@@ -234,6 +252,100 @@ func (s *leveldbTestSuite) TestCertificateStore() {
 	s.Equal(10, niters)
 }
 
+func (s *leveldbTestSuite) TestCertificateIndices() {
+	require := s.Require()
+	vaspID := uuid.New().String()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Create certificates that expire in 10, 20, and 40 days
+	ids := make([]string, 0, 3)
+	for i, days := range []int{20, 10, 40} {
+		cert := &models.Certificate{
+			Request: uuid.New().String(),
+			Vasp:    vaspID,
+			Status:  models.CertificateState_ISSUED,
+			Details: &pb.Certificate{
+				SerialNumber: []byte{0x0a, 0x1b, byte(i)},
+				NotAfter:     now.AddDate(0, 0, days).Format(time.RFC3339),
+			},
+		}
+
+		id, err := s.db.CreateCert(cert)
+		require.NoError(err)
+		ids = append(ids, id)
+	}
+
+	// Lookup a certificate by serial number
+	cert, err := s.db.RetrieveCertBySerial("0a:1b:01")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	_, err = s.db.RetrieveCertBySerial("0A1B03")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Lookup certificates by VASP
+	certs, err := s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 3)
+
+	certs, err = s.db.ListCertsByVASP(uuid.New().String())
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	// Lookup certificates that expire in the next 30 days, soonest first
+	certs, err = s.db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 2)
+	require.Equal(ids[1], certs[0].Id)
+	require.Equal(ids[0], certs[1].Id)
+
+	// Updating a certificate should update the indices
+	cert.Vasp = uuid.New().String()
+	cert.Details.SerialNumber = []byte{0x0a, 0x1b, 0x03}
+	cert.Details.NotAfter = now.AddDate(0, 0, 60).Format(time.RFC3339)
+	require.NoError(s.db.UpdateCert(cert))
+
+	_, err = s.db.RetrieveCertBySerial("0A1B01")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	cert, err = s.db.RetrieveCertBySerial("0A1B03")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	certs, err = s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 2)
+
+	certs, err = s.db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 1)
+	require.Equal(ids[0], certs[0].Id)
+
+	// The indices should be rebuilt by a reindex
+	require.NoError(s.db.Reindex())
+	certs, err = s.db.ListCertsByExpiration(time.Time{}, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 1)
+	cert, err = s.db.RetrieveCertBySerial("0A1B00")
+	require.NoError(err)
+	require.Equal(ids[0], cert.Id)
+
+	// Deleting certificates should remove them from the indices
+	for _, id := range ids {
+		require.NoError(s.db.DeleteCert(id))
+	}
+
+	_, err = s.db.RetrieveCertBySerial("0A1B00")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	certs, err = s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	certs, err = s.db.ListCertsByExpiration(now, time.Time{})
+	require.NoError(err)
+	require.Len(certs, 0)
+}
+
 func (s *leveldbTestSuite) TestCertificateRequestStore() {
 	// Load the VASP record from testdata
 	data, err := ioutil.ReadFile("../testdata/certreq.json")
@@ -327,3 +439,208 @@ func (s *leveldbTestSuite) TestCertificateRequestStore() {
 	iter.Release()
 	s.Equal(10, niters)
 }
+
+func (s *leveldbTestSuite) TestPendingActionStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve an action that doesn't exist
+	_, err := s.db.RetrieveAction(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	action := &models.PendingAction{
+		Type:       models.PendingActionType_DELETE_VASP,
+		Vasp:       uuid.New().String(),
+		ProposedBy: "alice@example.com",
+		Expires:    time.Now().Add(72 * time.Hour).Format(time.RFC3339),
+	}
+
+	// Attempt to Create the action
+	id, err := s.db.CreateAction(action)
+	require.NoError(err)
+
+	// Attempt to Retrieve the action
+	a, err := s.db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(id, a.Id)
+	require.Equal(models.PendingActionState_ACTION_PROPOSED, a.Status)
+	require.Equal(action.Vasp, a.Vasp)
+	require.Equal(action.ProposedBy, a.ProposedBy)
+	require.NotEmpty(a.Created)
+	require.Equal(a.Modified, a.Created)
+
+	// Cannot create an action with an ID on it
+	_, err = s.db.CreateAction(&models.PendingAction{Id: uuid.New().String()})
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Sleep for a second to roll over the clock for the modified time stamp
+	time.Sleep(1 * time.Second)
+
+	// Update the action
+	a.Status = models.PendingActionState_ACTION_EXECUTED
+	a.ResolvedBy = "bob@example.com"
+	require.NoError(s.db.UpdateAction(a))
+
+	a, err = s.db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(models.PendingActionState_ACTION_EXECUTED, a.Status)
+	require.Equal("bob@example.com", a.ResolvedBy)
+	require.NotEqual(a.Modified, a.Created)
+
+	// Cannot update an action without an ID
+	require.ErrorIs(s.db.UpdateAction(&models.PendingAction{}), storeerrors.ErrIncompleteRecord)
+
+	// Add a few more actions and list them
+	for i := 0; i < 5; i++ {
+		_, err = s.db.CreateAction(&models.PendingAction{Type: models.PendingActionType_REJECT_REGISTRATION, Vasp: uuid.New().String()})
+		require.NoError(err)
+	}
+
+	actions, err := s.db.ListActions().All()
+	require.NoError(err)
+	require.Len(actions, 6)
+
+	// Delete the action
+	require.NoError(s.db.DeleteAction(id))
+	_, err = s.db.RetrieveAction(id)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+}
+
+func (s *leveldbTestSuite) TestAuditStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve a record that doesn't exist
+	_, err := s.db.RetrieveAuditRecord(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	record := &models.AuditRecord{
+		Actor:     "alice@example.com",
+		Action:    "DELETE /v2/vasps/:vaspID",
+		Target:    uuid.New().String(),
+		RequestId: uuid.New().String(),
+		Status:    200,
+		Changes: []*models.FieldChange{
+			{Path: "vasp", Before: `{"id":"42"}`},
+		},
+	}
+
+	// Attempt to Create the record
+	id, err := s.db.CreateAuditRecord(record)
+	require.NoError(err)
+	require.NotEmpty(record.Timestamp)
+
+	// Attempt to Retrieve the record
+	r, err := s.db.RetrieveAuditRecord(id)
+	require.NoError(err)
+	require.True(proto.Equal(record, r))
+
+	// Cannot append a record that already has an ID
+	_, err = s.db.CreateAuditRecord(r)
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Add a few more records recorded an hour apart and list them, most recent first
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		ts := now.Add(-time.Duration(i+1) * time.Hour).Format(time.RFC3339Nano)
+		_, err = s.db.CreateAuditRecord(&models.AuditRecord{Timestamp: ts, Actor: "bob@example.com", Action: "POST /v2/vasps/:vaspID/notes"})
+		require.NoError(err)
+	}
+
+	records, err := s.db.ListAuditRecords().All()
+	require.NoError(err)
+	require.Len(records, 6)
+	require.Equal(id, records[0].Id)
+	for i, record := range records[1:] {
+		require.Equal(now.Add(-time.Duration(i+1)*time.Hour).Format(time.RFC3339Nano), record.Timestamp)
+	}
+
+	// Seek to the records created before a timestamp
+	iter := s.db.ListAuditRecords()
+	require.True(iter.SeekTimestamp(now.Add(-150 * time.Minute)))
+	r, err = iter.Record()
+	require.NoError(err)
+	require.Equal(records[3].Id, r.Id)
+	require.True(iter.Next())
+	require.True(iter.Next())
+	require.False(iter.Next())
+	require.NoError(iter.Error())
+	iter.Release()
+}
+
+func (s *leveldbTestSuite) TestRevisionStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve a revision that doesn't exist
+	_, err := s.db.RetrieveRevision(uuid.New().String(), 1)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Creating a VASP stores the first revision
+	vasp := &pb.VASP{
+		CommonName: "revisions.example.com",
+		Website:    "https://revisions.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Revisions, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	id, err := s.db.CreateVASP(vasp)
+	require.NoError(err)
+
+	other := &pb.VASP{
+		CommonName: "other.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Other, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	_, err = s.db.CreateVASP(other)
+	require.NoError(err)
+
+	// Each update stores a new revision; an update made from a stale copy is rejected
+	// without storing a revision.
+	stale := proto.Clone(vasp).(*pb.VASP)
+	vasp.Website = "https://revisions.example.org"
+	require.NoError(s.db.UpdateVASP(vasp))
+	stale.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.ErrorIs(s.db.UpdateVASP(stale), storeerrors.ErrConcurrentUpdate)
+	require.Equal(uint64(1), stale.Version.Version, "version should not be modified on failed update")
+
+	vasp.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.NoError(s.db.UpdateVASP(vasp))
+
+	revisions, err := s.db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Len(revisions, 3)
+	for i, rev := range revisions {
+		require.Equal(id, rev.Vasp)
+		require.Equal(uint64(i+1), rev.Revision)
+		require.NotEmpty(rev.Created)
+	}
+	require.Equal("https://revisions.example.com", revisions[0].Record.Website)
+	require.Equal("https://revisions.example.org", revisions[1].Record.Website)
+	require.Equal(pb.VerificationState_PENDING_REVIEW, revisions[2].Record.VerificationStatus)
+
+	// Attempt to Retrieve a revision
+	rev, err := s.db.RetrieveRevision(id, 2)
+	require.NoError(err)
+	require.True(proto.Equal(revisions[1], rev))
+	require.True(proto.Equal(vasp, revisions[2].Record))
+
+	_, err = s.db.RetrieveRevision(id, 4)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	_, err = s.db.RetrieveRevision(id, 0)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Revisions are deleted with the VASP
+	require.NoError(s.db.DeleteVASP(id))
+	revisions, err = s.db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Empty(revisions)
+
+	revisions, err = s.db.ListRevisions(other.Id).All()
+	require.NoError(err)
+	require.Len(revisions, 1)
+	require.NoError(s.db.DeleteVASP(other.Id))
+}
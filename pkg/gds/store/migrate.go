@@ -0,0 +1,602 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Migrate copies every record in every namespace of the Store interface (VASPs and
+// their revision history, certificates, certificate requests, pending actions, and the
+// admin audit log) from the src store to the dst store, so that a directory can be
+// moved between any two storage backends (e.g. from an embedded leveldb database to
+// trtl). Record IDs are preserved. Records that already exist in the destination with
+// the same checksum are skipped, which allows a migration that was interrupted to be
+// resumed by running it again; records that exist but differ are replaced. VASPs, the
+// audit log and revisions are managed by the store itself, so the destination must be
+// an Importer to write them with their original IDs and without creating revisions
+// that do not exist in the source.
+//
+// NOTE: the store manages some metadata itself (the certificate request and pending
+// action modified timestamps); these fields are set by the destination store when
+// records are written and are excluded from checksums, as is the VASP last updated
+// timestamp so that VASPs migrated by earlier versions are not replaced. When
+// a namespace is added to the Store interface it must also be added to Migrate and
+// Checksum, otherwise it is silently dropped by store:migrate.
+func Migrate(src, dst Store) (report *MigrationReport, err error) {
+	// Check the destination before anything is written so a migration to a store that
+	// cannot import records does not leave it partially populated.
+	importer, ok := dst.(Importer)
+	if !ok {
+		return nil, errors.New("destination store cannot import vasps, audit records or revisions")
+	}
+
+	report = &MigrationReport{}
+	if err = migrateVASPs(src, dst, importer, &report.VASPs); err != nil {
+		return report, err
+	}
+
+	if err = migrateCerts(src, dst, &report.Certs); err != nil {
+		return report, err
+	}
+
+	if err = migrateCertReqs(src, dst, &report.CertReqs); err != nil {
+		return report, err
+	}
+
+	if err = migrateActions(src, dst, &report.Actions); err != nil {
+		return report, err
+	}
+
+	if err = migrateAuditRecords(src, dst, importer, &report.Audit); err != nil {
+		return report, err
+	}
+
+	// Revisions must be migrated after the VASPs since replacing a VASP in the
+	// destination store deletes its revision history.
+	if err = migrateRevisions(src, dst, importer, &report.Revisions); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// Verify compares the record counts and checksums of every namespace in the src and
+// dst stores, returning ErrMigrationMismatch if the stores do not contain the same data.
+func Verify(src, dst Store) (srcsum, dstsum *StoreChecksum, err error) {
+	if srcsum, err = Checksum(src); err != nil {
+		return nil, nil, fmt.Errorf("could not compute source checksum: %s", err)
+	}
+
+	if dstsum, err = Checksum(dst); err != nil {
+		return nil, nil, fmt.Errorf("could not compute destination checksum: %s", err)
+	}
+
+	if !srcsum.Equal(dstsum) {
+		return srcsum, dstsum, ErrMigrationMismatch
+	}
+	return srcsum, dstsum, nil
+}
+
+// ErrMigrationMismatch is returned from Verify when the stores differ.
+var ErrMigrationMismatch = errors.New("source and destination stores do not match")
+
+// MigrationReport describes the records that were handled during a migration.
+type MigrationReport struct {
+	VASPs     MigrationCount
+	Certs     MigrationCount
+	CertReqs  MigrationCount
+	Actions   MigrationCount
+	Audit     MigrationCount
+	Revisions MigrationCount
+}
+
+// MigrationCount tracks how many records of a namespace were migrated.
+type MigrationCount struct {
+	Copied   uint64 // records that were written to the destination store
+	Replaced uint64 // records that existed in the destination but were different
+	Skipped  uint64 // records that already existed in the destination unchanged
+}
+
+// Total returns the number of records from the source that were handled.
+func (c MigrationCount) Total() uint64 {
+	return c.Copied + c.Replaced + c.Skipped
+}
+
+// StoreChecksum contains the number of records and an order-independent digest of the
+// records in each namespace of a store.
+type StoreChecksum struct {
+	VASPs     NamespaceChecksum
+	Certs     NamespaceChecksum
+	CertReqs  NamespaceChecksum
+	Actions   NamespaceChecksum
+	Audit     NamespaceChecksum
+	Revisions NamespaceChecksum
+}
+
+// Equal returns true if both stores have the same counts and digests.
+func (s *StoreChecksum) Equal(o *StoreChecksum) bool {
+	return s.VASPs.Equal(o.VASPs) && s.Certs.Equal(o.Certs) && s.CertReqs.Equal(o.CertReqs) &&
+		s.Actions.Equal(o.Actions) && s.Audit.Equal(o.Audit) && s.Revisions.Equal(o.Revisions)
+}
+
+// NamespaceChecksum is the count and digest of the records in a single namespace.
+type NamespaceChecksum struct {
+	Count  uint64
+	Digest [sha256.Size]byte
+}
+
+// Equal returns true if the counts and the digests are the same.
+func (c NamespaceChecksum) Equal(o NamespaceChecksum) bool {
+	return c.Count == o.Count && bytes.Equal(c.Digest[:], o.Digest[:])
+}
+
+// String returns the count and hex encoded digest for display.
+func (c NamespaceChecksum) String() string {
+	return fmt.Sprintf("%d records (%s)", c.Count, hex.EncodeToString(c.Digest[:]))
+}
+
+// add the record hash to the digest; XOR makes the digest independent of iteration
+// order so that stores which order their keys differently can still be compared.
+func (c *NamespaceChecksum) add(id string, sum []byte) {
+	h := sha256.New()
+	h.Write([]byte(id))
+	h.Write(sum)
+
+	c.Count++
+	for i, b := range h.Sum(nil) {
+		c.Digest[i] ^= b
+	}
+}
+
+// Checksum computes the record counts and digests of all namespaces in the store.
+func Checksum(db Store) (sum *StoreChecksum, err error) {
+	sum = &StoreChecksum{}
+
+	vasps := db.ListVASPs()
+	defer vasps.Release()
+	for vasps.Next() {
+		var vasp *pb.VASP
+		if vasp, err = vasps.VASP(); err != nil {
+			return nil, err
+		}
+
+		var hash []byte
+		if hash, err = vaspChecksum(vasp); err != nil {
+			return nil, err
+		}
+		sum.VASPs.add(vasp.Id, hash)
+
+		if err = revisionsChecksum(db, vasp.Id, &sum.Revisions); err != nil {
+			return nil, err
+		}
+	}
+	if err = vasps.Error(); err != nil {
+		return nil, err
+	}
+
+	certs := db.ListCerts()
+	defer certs.Release()
+	for certs.Next() {
+		var cert *models.Certificate
+		if cert, err = certs.Cert(); err != nil {
+			return nil, err
+		}
+
+		var hash []byte
+		if hash, err = recordChecksum(cert); err != nil {
+			return nil, err
+		}
+		sum.Certs.add(cert.Id, hash)
+	}
+	if err = certs.Error(); err != nil {
+		return nil, err
+	}
+
+	reqs := db.ListCertReqs()
+	defer reqs.Release()
+	for reqs.Next() {
+		var req *models.CertificateRequest
+		if req, err = reqs.CertReq(); err != nil {
+			return nil, err
+		}
+
+		var hash []byte
+		if hash, err = certReqChecksum(req); err != nil {
+			return nil, err
+		}
+		sum.CertReqs.add(req.Id, hash)
+	}
+	if err = reqs.Error(); err != nil {
+		return nil, err
+	}
+
+	actions := db.ListActions()
+	defer actions.Release()
+	for actions.Next() {
+		var action *models.PendingAction
+		if action, err = actions.Action(); err != nil {
+			return nil, err
+		}
+
+		var hash []byte
+		if hash, err = actionChecksum(action); err != nil {
+			return nil, err
+		}
+		sum.Actions.add(action.Id, hash)
+	}
+	if err = actions.Error(); err != nil {
+		return nil, err
+	}
+
+	records := db.ListAuditRecords()
+	defer records.Release()
+	for records.Next() {
+		var record *models.AuditRecord
+		if record, err = records.Record(); err != nil {
+			return nil, err
+		}
+
+		var hash []byte
+		if hash, err = recordChecksum(record); err != nil {
+			return nil, err
+		}
+		sum.Audit.add(record.Id, hash)
+	}
+	if err = records.Error(); err != nil {
+		return nil, err
+	}
+
+	return sum, nil
+}
+
+// revisionsChecksum adds the revisions of the VASP to the revisions checksum.
+func revisionsChecksum(db Store, vaspID string, sum *NamespaceChecksum) (err error) {
+	iter := db.ListRevisions(vaspID)
+	defer iter.Release()
+	for iter.Next() {
+		var rev *models.VASPRevision
+		if rev, err = iter.Revision(); err != nil {
+			return err
+		}
+
+		var hash []byte
+		if hash, err = recordChecksum(rev); err != nil {
+			return err
+		}
+		sum.add(revisionID(rev), hash)
+	}
+	return iter.Error()
+}
+
+func migrateVASPs(src, dst Store, importer Importer, count *MigrationCount) (err error) {
+	iter := src.ListVASPs()
+	defer iter.Release()
+
+	for iter.Next() {
+		var vasp *pb.VASP
+		if vasp, err = iter.VASP(); err != nil {
+			return err
+		}
+
+		var srcsum []byte
+		if srcsum, err = vaspChecksum(vasp); err != nil {
+			return err
+		}
+
+		// Check if the record has already been migrated
+		var existing *pb.VASP
+		if existing, err = dst.RetrieveVASP(vasp.Id); err != nil && err != storeerrors.ErrEntityNotFound {
+			return fmt.Errorf("could not retrieve vasp %s from destination: %s", vasp.Id, err)
+		}
+
+		if existing != nil {
+			var dstsum []byte
+			if dstsum, err = vaspChecksum(existing); err != nil {
+				return err
+			}
+
+			if bytes.Equal(srcsum, dstsum) {
+				count.Skipped++
+				continue
+			}
+
+			// Delete and import the record rather than updating it so that the version
+			// of the record is preserved rather than incremented; the revision history
+			// is deleted with the record and imported from the source afterwards.
+			if err = dst.DeleteVASP(vasp.Id); err != nil {
+				return fmt.Errorf("could not replace vasp %s in destination: %s", vasp.Id, err)
+			}
+			count.Replaced++
+		} else {
+			count.Copied++
+		}
+
+		if err = importer.ImportVASP(vasp); err != nil {
+			return fmt.Errorf("could not write vasp %s to destination: %s", vasp.Id, err)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceVASPs).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+func migrateCerts(src, dst Store, count *MigrationCount) (err error) {
+	iter := src.ListCerts()
+	defer iter.Release()
+
+	for iter.Next() {
+		var cert *models.Certificate
+		if cert, err = iter.Cert(); err != nil {
+			return err
+		}
+
+		var existing *models.Certificate
+		if existing, err = dst.RetrieveCert(cert.Id); err != nil && err != storeerrors.ErrEntityNotFound {
+			return fmt.Errorf("could not retrieve certificate %s from destination: %s", cert.Id, err)
+		}
+
+		if existing != nil {
+			if proto.Equal(cert, existing) {
+				count.Skipped++
+				continue
+			}
+			count.Replaced++
+		} else {
+			count.Copied++
+		}
+
+		// UpdateCert creates or replaces the certificate, preserving its ID
+		if err = dst.UpdateCert(cert); err != nil {
+			return fmt.Errorf("could not write certificate %s to destination: %s", cert.Id, err)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceCerts).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+func migrateCertReqs(src, dst Store, count *MigrationCount) (err error) {
+	iter := src.ListCertReqs()
+	defer iter.Release()
+
+	for iter.Next() {
+		var req *models.CertificateRequest
+		if req, err = iter.CertReq(); err != nil {
+			return err
+		}
+
+		var srcsum []byte
+		if srcsum, err = certReqChecksum(req); err != nil {
+			return err
+		}
+
+		var existing *models.CertificateRequest
+		if existing, err = dst.RetrieveCertReq(req.Id); err != nil && err != storeerrors.ErrEntityNotFound {
+			return fmt.Errorf("could not retrieve certificate request %s from destination: %s", req.Id, err)
+		}
+
+		if existing != nil {
+			var dstsum []byte
+			if dstsum, err = certReqChecksum(existing); err != nil {
+				return err
+			}
+
+			if bytes.Equal(srcsum, dstsum) {
+				count.Skipped++
+				continue
+			}
+			count.Replaced++
+		} else {
+			count.Copied++
+		}
+
+		// UpdateCertReq creates or replaces the certificate request, preserving its ID
+		if err = dst.UpdateCertReq(req); err != nil {
+			return fmt.Errorf("could not write certificate request %s to destination: %s", req.Id, err)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceCertReqs).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+func migrateActions(src, dst Store, count *MigrationCount) (err error) {
+	iter := src.ListActions()
+	defer iter.Release()
+
+	for iter.Next() {
+		var action *models.PendingAction
+		if action, err = iter.Action(); err != nil {
+			return err
+		}
+
+		var srcsum []byte
+		if srcsum, err = actionChecksum(action); err != nil {
+			return err
+		}
+
+		var existing *models.PendingAction
+		if existing, err = dst.RetrieveAction(action.Id); err != nil && err != storeerrors.ErrEntityNotFound {
+			return fmt.Errorf("could not retrieve pending action %s from destination: %s", action.Id, err)
+		}
+
+		if existing != nil {
+			var dstsum []byte
+			if dstsum, err = actionChecksum(existing); err != nil {
+				return err
+			}
+
+			if bytes.Equal(srcsum, dstsum) {
+				count.Skipped++
+				continue
+			}
+			count.Replaced++
+		} else {
+			count.Copied++
+		}
+
+		// UpdateAction creates or replaces the pending action, preserving its ID
+		if err = dst.UpdateAction(action); err != nil {
+			return fmt.Errorf("could not write pending action %s to destination: %s", action.Id, err)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceActions).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+func migrateAuditRecords(src, dst Store, importer Importer, count *MigrationCount) (err error) {
+	iter := src.ListAuditRecords()
+	defer iter.Release()
+
+	for iter.Next() {
+		var record *models.AuditRecord
+		if record, err = iter.Record(); err != nil {
+			return err
+		}
+
+		var existing *models.AuditRecord
+		if existing, err = dst.RetrieveAuditRecord(record.Id); err != nil && err != storeerrors.ErrEntityNotFound {
+			return fmt.Errorf("could not retrieve audit record %s from destination: %s", record.Id, err)
+		}
+
+		if existing != nil {
+			if proto.Equal(record, existing) {
+				count.Skipped++
+				continue
+			}
+			count.Replaced++
+		} else {
+			count.Copied++
+		}
+
+		if err = importer.ImportAuditRecord(record); err != nil {
+			return fmt.Errorf("could not write audit record %s to destination: %s", record.Id, err)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceAudit).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+func migrateRevisions(src, dst Store, importer Importer, count *MigrationCount) (err error) {
+	vasps := src.ListVASPs()
+	defer vasps.Release()
+
+	for vasps.Next() {
+		var vasp *pb.VASP
+		if vasp, err = vasps.VASP(); err != nil {
+			return err
+		}
+
+		iter := src.ListRevisions(vasp.Id)
+		for iter.Next() {
+			var rev *models.VASPRevision
+			if rev, err = iter.Revision(); err != nil {
+				iter.Release()
+				return err
+			}
+
+			var existing *models.VASPRevision
+			if existing, err = dst.RetrieveRevision(rev.Vasp, rev.Revision); err != nil && err != storeerrors.ErrEntityNotFound {
+				iter.Release()
+				return fmt.Errorf("could not retrieve revision %s from destination: %s", revisionID(rev), err)
+			}
+
+			if existing != nil {
+				if proto.Equal(rev, existing) {
+					count.Skipped++
+					continue
+				}
+				count.Replaced++
+			} else {
+				count.Copied++
+			}
+
+			if err = importer.ImportRevision(rev); err != nil {
+				iter.Release()
+				return fmt.Errorf("could not write revision %s to destination: %s", revisionID(rev), err)
+			}
+		}
+
+		iter.Release()
+		if err = iter.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err = vasps.Error(); err != nil {
+		return err
+	}
+
+	log.Debug().Str("namespace", wire.NamespaceRevisions).Uint64("copied", count.Copied).Uint64("replaced", count.Replaced).Uint64("skipped", count.Skipped).Msg("migrated")
+	return nil
+}
+
+// vaspChecksum hashes the VASP excluding the last updated timestamp managed by the store.
+func vaspChecksum(vasp *pb.VASP) ([]byte, error) {
+	vasp = proto.Clone(vasp).(*pb.VASP)
+	vasp.LastUpdated = ""
+	return recordChecksum(vasp)
+}
+
+// certReqChecksum hashes the certificate request excluding the modified timestamp
+// managed by the store.
+func certReqChecksum(req *models.CertificateRequest) ([]byte, error) {
+	req = proto.Clone(req).(*models.CertificateRequest)
+	req.Modified = ""
+	return recordChecksum(req)
+}
+
+// actionChecksum hashes the pending action excluding the modified timestamp managed by
+// the store.
+func actionChecksum(action *models.PendingAction) ([]byte, error) {
+	action = proto.Clone(action).(*models.PendingAction)
+	action.Modified = ""
+	return recordChecksum(action)
+}
+
+// revisionID identifies a revision across all of the VASPs in the store.
+func revisionID(rev *models.VASPRevision) string {
+	return fmt.Sprintf("%s:%d", rev.Vasp, rev.Revision)
+}
+
+// recordChecksum returns the sha256 hash of the deterministic protocol buffer encoding.
+func recordChecksum(msg proto.Message) (_ []byte, err error) {
+	var data []byte
+	if data, err = (proto.MarshalOptions{Deterministic: true}).Marshal(msg); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
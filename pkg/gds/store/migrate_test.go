@@ -0,0 +1,171 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/config"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/store"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Test migrating a leveldb store to a sqlite store, including resuming the migration.
+func TestMigrate(t *testing.T) {
+	tmp := t.TempDir()
+	src, err := store.Open(config.DatabaseConfig{URL: "leveldb:///" + filepath.Join(tmp, "db")})
+	require.NoError(t, err)
+	defer src.Close()
+
+	dst, err := store.Open(config.DatabaseConfig{URL: "sqlite:///" + filepath.Join(tmp, "gds.db")})
+	require.NoError(t, err)
+	defer dst.Close()
+
+	// Populate the source store with fixtures
+	require.NoError(t, store.Load(src, filepath.Join("testdata", "vasps.csv")))
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "cert.json"))
+	require.NoError(t, err)
+	cert := &models.Certificate{}
+	require.NoError(t, protojson.Unmarshal(data, cert))
+	_, err = src.CreateCert(cert)
+	require.NoError(t, err)
+
+	data, err = ioutil.ReadFile(filepath.Join("testdata", "certreq.json"))
+	require.NoError(t, err)
+	certreq := &models.CertificateRequest{}
+	require.NoError(t, protojson.Unmarshal(data, certreq))
+	_, err = src.CreateCertReq(certreq)
+	require.NoError(t, err)
+
+	action := &models.PendingAction{Type: models.PendingActionType_DELETE_VASP, Vasp: "b5841869-105f-411c-8722-4045aad72717", ProposedBy: "admin@example.com"}
+	_, err = src.CreateAction(action)
+	require.NoError(t, err)
+
+	record := &models.AuditRecord{Actor: "admin@example.com", Action: "delete_vasp", Target: "b5841869-105f-411c-8722-4045aad72717"}
+	_, err = src.CreateAuditRecord(record)
+	require.NoError(t, err)
+
+	// The stores should not match before the migration
+	_, _, err = store.Verify(src, dst)
+	require.ErrorIs(t, err, store.ErrMigrationMismatch)
+
+	// Simulate an interrupted migration by copying a single VASP
+	vasps, err := src.ListVASPs().All()
+	require.NoError(t, err)
+	require.Len(t, vasps, 2)
+	_, err = dst.CreateVASP(vasps[0])
+	require.NoError(t, err)
+
+	report, err := store.Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, store.MigrationCount{Copied: 1, Skipped: 1}, report.VASPs)
+	require.Equal(t, store.MigrationCount{Copied: 1}, report.Certs)
+	require.Equal(t, store.MigrationCount{Copied: 1}, report.CertReqs)
+	require.Equal(t, store.MigrationCount{Copied: 1}, report.Actions)
+	require.Equal(t, store.MigrationCount{Copied: 1}, report.Audit)
+	require.Equal(t, uint64(2), report.Revisions.Total())
+
+	srcsum, dstsum, err := store.Verify(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), dstsum.VASPs.Count)
+	require.Equal(t, uint64(1), dstsum.Certs.Count)
+	require.Equal(t, uint64(1), dstsum.CertReqs.Count)
+	require.Equal(t, uint64(1), dstsum.Actions.Count)
+	require.Equal(t, uint64(1), dstsum.Audit.Count)
+	require.Equal(t, uint64(2), dstsum.Revisions.Count)
+	require.True(t, srcsum.Equal(dstsum))
+
+	// IDs should be preserved
+	for _, vasp := range vasps {
+		_, err = dst.RetrieveVASP(vasp.Id)
+		require.NoError(t, err)
+	}
+	_, err = dst.RetrieveCert(cert.Id)
+	require.NoError(t, err)
+	_, err = dst.RetrieveCertReq(certreq.Id)
+	require.NoError(t, err)
+	_, err = dst.RetrieveAction(action.Id)
+	require.NoError(t, err)
+	_, err = dst.RetrieveAuditRecord(record.Id)
+	require.NoError(t, err)
+
+	// Records that have changed in the source should be replaced on a second run
+	vasps[1].Website = "https://example.com"
+	require.NoError(t, src.UpdateVASP(vasps[1]))
+
+	report, err = store.Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, store.MigrationCount{Replaced: 1, Skipped: 1}, report.VASPs)
+	require.Equal(t, store.MigrationCount{Skipped: 1}, report.Certs)
+	require.Equal(t, store.MigrationCount{Skipped: 1}, report.CertReqs)
+	require.Equal(t, store.MigrationCount{Skipped: 1}, report.Actions)
+	require.Equal(t, store.MigrationCount{Skipped: 1}, report.Audit)
+	require.Equal(t, uint64(3), report.Revisions.Total())
+
+	// The revision history of the updated VASP should be migrated
+	revisions, err := dst.ListRevisions(vasps[1].Id).All()
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, "https://example.com", revisions[1].Record.Website)
+
+	_, _, err = store.Verify(src, dst)
+	require.NoError(t, err)
+}
+
+// Test that VASPs without a revision history in the source are migrated without
+// creating revisions in the destination so that the stores can be verified.
+func TestMigrateWithoutRevisions(t *testing.T) {
+	tmp := t.TempDir()
+	src, err := store.Open(config.DatabaseConfig{URL: "leveldb:///" + filepath.Join(tmp, "db")})
+	require.NoError(t, err)
+	defer src.Close()
+
+	dst, err := store.Open(config.DatabaseConfig{URL: "sqlite:///" + filepath.Join(tmp, "gds.db")})
+	require.NoError(t, err)
+	defer dst.Close()
+
+	// VASPs written before revisions were tracked do not have a revision history
+	vasp := &pb.VASP{
+		Id:          "b5841869-105f-411c-8722-4045aad72717",
+		CommonName:  "trisa.example.com",
+		Entity:      &ivms101.LegalPerson{CountryOfRegistration: "US"},
+		Version:     &pb.Version{Version: 4},
+		FirstListed: "2021-06-01T12:00:00Z",
+		LastUpdated: "2021-07-01T12:00:00Z",
+	}
+	require.NoError(t, src.(store.Importer).ImportVASP(vasp))
+
+	revisions, err := src.ListRevisions(vasp.Id).All()
+	require.NoError(t, err)
+	require.Len(t, revisions, 0)
+
+	report, err := store.Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, store.MigrationCount{Copied: 1}, report.VASPs)
+	require.Equal(t, uint64(0), report.Revisions.Total())
+
+	// The record should be migrated as is without any revisions
+	migrated, err := dst.RetrieveVASP(vasp.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(vasp, migrated))
+
+	revisions, err = dst.ListRevisions(vasp.Id).All()
+	require.NoError(t, err)
+	require.Len(t, revisions, 0)
+
+	_, dstsum, err := store.Verify(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), dstsum.VASPs.Count)
+	require.Equal(t, uint64(0), dstsum.Revisions.Count)
+
+	// The imported VASP should be indexed in the destination
+	_, err = dst.CreateVASP(&pb.VASP{CommonName: "trisa.example.com", Entity: &ivms101.LegalPerson{}})
+	require.ErrorIs(t, err, storeerrors.ErrDuplicateEntity)
+}
@@ -3,10 +3,12 @@ package mockdb
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/gds/store"
 	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 )
 
@@ -23,25 +25,39 @@ type MockState struct {
 	Keys  []string
 
 	// keep track of store interface calls
-	CloseInvoked           bool
-	CreateVASPInvoked      bool
-	RetrieveVASPInvoked    bool
-	UpdateVASPInvoked      bool
-	DeleteVASPInvoked      bool
-	ListVASPsInvoked       bool
-	SearchVASPsInvoked     bool
-	ListCertReqsInvoked    bool
-	CreateCertReqInvoked   bool
-	RetrieveCertReqInvoked bool
-	UpdateCertReqInvoked   bool
-	DeleteCertReqInvoked   bool
-	ListCertInvoked        bool
-	CreateCertInvoked      bool
-	RetrieveCertInvoked    bool
-	UpdateCertInvoked      bool
-	DeleteCertInvoked      bool
-	ReindexInvoked         bool
-	BackupInvoked          bool
+	CloseInvoked                 bool
+	CreateVASPInvoked            bool
+	RetrieveVASPInvoked          bool
+	UpdateVASPInvoked            bool
+	DeleteVASPInvoked            bool
+	ListVASPsInvoked             bool
+	SearchVASPsInvoked           bool
+	QueryVASPsInvoked            bool
+	ListCertReqsInvoked          bool
+	CreateCertReqInvoked         bool
+	RetrieveCertReqInvoked       bool
+	UpdateCertReqInvoked         bool
+	DeleteCertReqInvoked         bool
+	ListCertInvoked              bool
+	CreateCertInvoked            bool
+	RetrieveCertInvoked          bool
+	UpdateCertInvoked            bool
+	DeleteCertInvoked            bool
+	RetrieveCertBySerialInvoked  bool
+	ListCertsByVASPInvoked       bool
+	ListCertsByExpirationInvoked bool
+	ListActionsInvoked           bool
+	CreateActionInvoked          bool
+	RetrieveActionInvoked        bool
+	UpdateActionInvoked          bool
+	DeleteActionInvoked          bool
+	ListAuditRecordsInvoked      bool
+	CreateAuditRecordInvoked     bool
+	RetrieveAuditRecordInvoked   bool
+	ListRevisionsInvoked         bool
+	RetrieveRevisionInvoked      bool
+	ReindexInvoked               bool
+	BackupInvoked                bool
 }
 
 func GetState() *MockState {
@@ -57,25 +73,39 @@ func ResetState() {
 
 // MockDB fulfills the store interface for testing.
 type MockDB struct {
-	OnClose           func() error
-	OnCreateVASP      func(v *pb.VASP) (string, error)
-	OnRetrieveVASP    func(id string) (*pb.VASP, error)
-	OnUpdateVASP      func(v *pb.VASP) error
-	OnDeleteVASP      func(id string) error
-	OnListVASPs       func() iterator.DirectoryIterator
-	OnSearchVASPs     func(query map[string]interface{}) ([]*pb.VASP, error)
-	OnListCertReqs    func() iterator.CertificateRequestIterator
-	OnCreateCertReq   func(r *models.CertificateRequest) (string, error)
-	OnRetrieveCertReq func(id string) (*models.CertificateRequest, error)
-	OnUpdateCertReq   func(r *models.CertificateRequest) error
-	OnDeleteCertReq   func(id string) error
-	OnListCerts       func() iterator.CertificateIterator
-	OnCreateCert      func(c *models.Certificate) (string, error)
-	OnRetrieveCert    func(id string) (*models.Certificate, error)
-	OnUpdateCert      func(c *models.Certificate) error
-	OnDeleteCert      func(id string) error
-	OnReindex         func() error
-	OnBackup          func(string) error
+	OnClose                 func() error
+	OnCreateVASP            func(v *pb.VASP) (string, error)
+	OnRetrieveVASP          func(id string) (*pb.VASP, error)
+	OnUpdateVASP            func(v *pb.VASP) error
+	OnDeleteVASP            func(id string) error
+	OnListVASPs             func() iterator.DirectoryIterator
+	OnSearchVASPs           func(query map[string]interface{}) ([]*pb.VASP, error)
+	OnQueryVASPs            func(query *search.Query) (*search.Page, error)
+	OnListCertReqs          func() iterator.CertificateRequestIterator
+	OnCreateCertReq         func(r *models.CertificateRequest) (string, error)
+	OnRetrieveCertReq       func(id string) (*models.CertificateRequest, error)
+	OnUpdateCertReq         func(r *models.CertificateRequest) error
+	OnDeleteCertReq         func(id string) error
+	OnListCerts             func() iterator.CertificateIterator
+	OnCreateCert            func(c *models.Certificate) (string, error)
+	OnRetrieveCert          func(id string) (*models.Certificate, error)
+	OnUpdateCert            func(c *models.Certificate) error
+	OnDeleteCert            func(id string) error
+	OnRetrieveCertBySerial  func(serial string) (*models.Certificate, error)
+	OnListCertsByVASP       func(vaspID string) ([]*models.Certificate, error)
+	OnListCertsByExpiration func(after, before time.Time) ([]*models.Certificate, error)
+	OnListActions           func() iterator.PendingActionIterator
+	OnCreateAction          func(a *models.PendingAction) (string, error)
+	OnRetrieveAction        func(id string) (*models.PendingAction, error)
+	OnUpdateAction          func(a *models.PendingAction) error
+	OnDeleteAction          func(id string) error
+	OnListAuditRecords      func() iterator.AuditRecordIterator
+	OnCreateAuditRecord     func(r *models.AuditRecord) (string, error)
+	OnRetrieveAuditRecord   func(id string) (*models.AuditRecord, error)
+	OnListRevisions         func(vaspID string) iterator.RevisionIterator
+	OnRetrieveRevision      func(vaspID string, revision uint64) (*models.VASPRevision, error)
+	OnReindex               func() error
+	OnBackup                func(string) error
 }
 
 func GetStore() store.Store {
@@ -133,6 +163,11 @@ func (m *MockDB) SearchVASPs(query map[string]interface{}) ([]*pb.VASP, error) {
 	return m.OnSearchVASPs(query)
 }
 
+func (m *MockDB) QueryVASPs(query *search.Query) (*search.Page, error) {
+	state.QueryVASPsInvoked = true
+	return m.OnQueryVASPs(query)
+}
+
 func (m *MockDB) ListCertReqs() iterator.CertificateRequestIterator {
 	state.ListCertReqsInvoked = true
 	return m.OnListCertReqs()
@@ -183,6 +218,71 @@ func (m *MockDB) DeleteCert(id string) error {
 	return m.OnDeleteCert(id)
 }
 
+func (m *MockDB) RetrieveCertBySerial(serial string) (*models.Certificate, error) {
+	state.RetrieveCertBySerialInvoked = true
+	return m.OnRetrieveCertBySerial(serial)
+}
+
+func (m *MockDB) ListCertsByVASP(vaspID string) ([]*models.Certificate, error) {
+	state.ListCertsByVASPInvoked = true
+	return m.OnListCertsByVASP(vaspID)
+}
+
+func (m *MockDB) ListCertsByExpiration(after, before time.Time) ([]*models.Certificate, error) {
+	state.ListCertsByExpirationInvoked = true
+	return m.OnListCertsByExpiration(after, before)
+}
+
+func (m *MockDB) ListActions() iterator.PendingActionIterator {
+	state.ListActionsInvoked = true
+	return m.OnListActions()
+}
+
+func (m *MockDB) CreateAction(a *models.PendingAction) (string, error) {
+	state.CreateActionInvoked = true
+	return m.OnCreateAction(a)
+}
+
+func (m *MockDB) RetrieveAction(id string) (*models.PendingAction, error) {
+	state.RetrieveActionInvoked = true
+	return m.OnRetrieveAction(id)
+}
+
+func (m *MockDB) UpdateAction(a *models.PendingAction) error {
+	state.UpdateActionInvoked = true
+	return m.OnUpdateAction(a)
+}
+
+func (m *MockDB) DeleteAction(id string) error {
+	state.DeleteActionInvoked = true
+	return m.OnDeleteAction(id)
+}
+
+func (m *MockDB) ListAuditRecords() iterator.AuditRecordIterator {
+	state.ListAuditRecordsInvoked = true
+	return m.OnListAuditRecords()
+}
+
+func (m *MockDB) CreateAuditRecord(r *models.AuditRecord) (string, error) {
+	state.CreateAuditRecordInvoked = true
+	return m.OnCreateAuditRecord(r)
+}
+
+func (m *MockDB) RetrieveAuditRecord(id string) (*models.AuditRecord, error) {
+	state.RetrieveAuditRecordInvoked = true
+	return m.OnRetrieveAuditRecord(id)
+}
+
+func (m *MockDB) ListRevisions(vaspID string) iterator.RevisionIterator {
+	state.ListRevisionsInvoked = true
+	return m.OnListRevisions(vaspID)
+}
+
+func (m *MockDB) RetrieveRevision(vaspID string, revision uint64) (*models.VASPRevision, error) {
+	state.RetrieveRevisionInvoked = true
+	return m.OnRetrieveRevision(vaspID, revision)
+}
+
 func (m *MockDB) Reindex() error {
 	state.ReindexInvoked = true
 	return m.OnReindex()
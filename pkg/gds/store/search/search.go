@@ -0,0 +1,521 @@
+/*
+Package search implements the paginated, ranked, and filtered VASP queries that are
+shared by the directory store implementations. Stores use their indices to find the
+records that match the terms of a query and score them, then use the query to filter
+the records and to paginate the sorted results so that results are filtered, ordered
+and paginated in the same way no matter which store is used.
+*/
+package search
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/store/index"
+	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+// DefaultPageSize is used if the query does not specify a page size.
+const DefaultPageSize = 100
+
+// Scores added to a record when a name or website in the query matches the record.
+// These are higher than a typical full text match so that records that are directly
+// identified by the query are ranked first.
+const (
+	ExactTermScore  = 2.0
+	PrefixTermScore = 1.0
+)
+
+var (
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrPageSizeChanged  = errors.New("page size cannot change between requests")
+)
+
+// Order specifies how the results of a query are sorted.
+type Order uint8
+
+const (
+	// OrderRelevance sorts the results by descending score; records with the same score
+	// (e.g. all records if the query has no terms) are sorted by ID.
+	OrderRelevance Order = iota
+
+	// OrderName sorts the results alphabetically by the VASP name.
+	OrderName
+)
+
+// Query describes which VASP records to return and in what order. The Text, Names, and
+// Websites terms determine which records match and how they are scored; if there are
+// no terms then all records match. The remaining fields filter the matching records.
+// Deleted VASPs that have not yet been purged only match if Archived is set.
+type Query struct {
+	Text               string                 // full text search of names, websites, and addresses
+	Fuzzy              bool                   // allow small typos in the full text search
+	Names              []string               // exact or prefix match of VASP names
+	Websites           []string               // match of the website hostname
+	Countries          []string               // records must be in all of the countries
+	Categories         []string               // records must be in all of the business or VASP categories
+	VerificationStatus []pb.VerificationState // records must have one of the statuses
+	FirstListed        TimeRange              // records first listed in the range
+	VerifiedOn         TimeRange              // records verified in the range
+	CertExpires        TimeRange              // records whose identity certificate expires in the range
+	Archived           bool                   // only return deleted records instead of excluding them
+	OrderBy            Order                  // the sort order of the results
+	PageSize           int32                  // the maximum number of results to return
+	PageToken          string                 // the next page token from a previous query
+	Page               int32                  // alternatively to the page token, the page number (1-indexed)
+}
+
+// HasTerms returns true if the query has terms that the records must match.
+func (q *Query) HasTerms() bool {
+	return strings.TrimSpace(q.Text) != "" || len(q.Names) > 0 || len(q.Websites) > 0
+}
+
+// Match returns true if the VASP passes all of the filters of the query.
+func (q *Query) Match(vasp *pb.VASP) bool {
+	if models.IsArchived(vasp) != q.Archived {
+		return false
+	}
+
+	if len(q.VerificationStatus) > 0 {
+		found := false
+		for _, status := range q.VerificationStatus {
+			if vasp.VerificationStatus == status {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Countries) > 0 {
+		countries := Countries(vasp)
+		for _, country := range q.Countries {
+			if _, ok := countries[index.NormalizeCountry(country)]; !ok {
+				return false
+			}
+		}
+	}
+
+	if len(q.Categories) > 0 {
+		categories := Categories(vasp)
+		for _, category := range q.Categories {
+			if _, ok := categories[index.Normalize(category)]; !ok {
+				return false
+			}
+		}
+	}
+
+	if !q.FirstListed.Contains(vasp.FirstListed) {
+		return false
+	}
+
+	if !q.VerifiedOn.Contains(vasp.VerifiedOn) {
+		return false
+	}
+
+	if !q.CertExpires.IsZero() {
+		if vasp.IdentityCertificate == nil || !q.CertExpires.Contains(vasp.IdentityCertificate.NotAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Score uses the names, websites, and full text indices to find the records that match
+// the terms of the query, returning the relevance score for each matching record ID.
+// The caller must hold any locks that protect the indices.
+func Score(q *Query, names, websites index.SingleIndex, fulltext index.TextIndex) map[string]float64 {
+	scores := make(map[string]float64)
+
+	if len(q.Names) > 0 {
+		// Exact matches on the name score higher than prefix matches
+		exact := make(map[string]struct{})
+		for _, name := range q.Names {
+			if id, ok := names.Find(name); ok {
+				exact[id] = struct{}{}
+			}
+		}
+
+		for _, id := range names.Search(map[string]interface{}{"name": q.Names}) {
+			if _, ok := exact[id]; ok {
+				scores[id] += ExactTermScore
+			} else {
+				scores[id] += PrefixTermScore
+			}
+		}
+	}
+
+	if len(q.Websites) > 0 {
+		for _, id := range websites.Search(map[string]interface{}{"website": q.Websites}) {
+			scores[id] += ExactTermScore
+		}
+	}
+
+	if text := strings.TrimSpace(q.Text); text != "" {
+		for _, hit := range fulltext.Rank(text, q.Fuzzy) {
+			scores[hit.ID] += hit.Score
+		}
+	}
+	return scores
+}
+
+// Scan executes the query by iterating over all of the VASPs rather than by using the
+// store indices. If the query has terms, temporary indices are built from the VASPs
+// in order to score them. This is used by stores that do not maintain indices and by
+// indexed stores when the query has no terms and every record must be filtered anyway.
+// If the query has no terms and is ordered by relevance, the results are already in
+// the iteration order so Seek is used rather than loading and sorting every record.
+func Scan(q *Query, iter iterator.DirectoryIterator) (page *Page, err error) {
+	if !q.HasTerms() && q.OrderBy == OrderRelevance {
+		return Seek(q, iter)
+	}
+	defer iter.Release()
+
+	var (
+		names    index.SingleIndex
+		websites index.SingleIndex
+		fulltext index.TextIndex
+	)
+
+	terms := q.HasTerms()
+	if terms {
+		names = index.NewNamesIndex()
+		websites = index.NewWebsiteIndex()
+		fulltext = index.NewFullTextIndex()
+	}
+
+	vasps := make(map[string]*pb.VASP)
+	for iter.Next() {
+		var vasp *pb.VASP
+		if vasp, err = iter.VASP(); err != nil {
+			return nil, err
+		}
+
+		if !q.Match(vasp) {
+			continue
+		}
+		vasps[vasp.Id] = vasp
+
+		if terms {
+			names.Add(vasp.CommonName, vasp.Id)
+			if vasp.Entity != nil {
+				for _, name := range vasp.Entity.Names() {
+					names.Add(name, vasp.Id)
+				}
+			}
+
+			websites.Add(vasp.Website, vasp.Id)
+			for _, text := range index.SearchText(vasp) {
+				fulltext.Add(text, vasp.Id)
+			}
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*Result, 0, len(vasps))
+	if terms {
+		for id, score := range Score(q, names, websites, fulltext) {
+			results = append(results, &Result{VASP: vasps[id], Score: score})
+		}
+	} else {
+		for _, vasp := range vasps {
+			results = append(results, &Result{VASP: vasp})
+		}
+	}
+	return Paginate(q, results)
+}
+
+// Seek executes a query that has no terms and is ordered by relevance (e.g. by ID) by
+// seeking the iterator to the next VASP in the page token and filtering the records in
+// key order until the page is full, so only a single page of records is held at once.
+// The Total of the page is only computed when the query specifies a page number, since
+// the remaining records have to be filtered to count them; otherwise it is -1.
+func Seek(q *Query, iter iterator.DirectoryIterator) (page *Page, err error) {
+	defer iter.Release()
+
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	// Determine where the page starts from the page token or the page number
+	var (
+		skip   int
+		offset int32
+		ok     bool
+	)
+
+	switch {
+	case q.PageToken != "":
+		cursor := &models.PageCursor{}
+		if err = cursor.Load(q.PageToken); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+
+		if cursor.PageSize != pageSize {
+			return nil, ErrPageSizeChanged
+		}
+
+		if cursor.Offset < 0 {
+			return nil, ErrInvalidPageToken
+		}
+
+		// Seek to the next VASP if it is known, otherwise fall back to the offset.
+		offset = cursor.Offset
+		if cursor.NextVasp != "" {
+			ok = iter.SeekId(cursor.NextVasp)
+		} else {
+			skip = int(cursor.Offset)
+			ok = iter.Next()
+		}
+	case q.Page > 1:
+		skip = int(q.Page-1) * int(pageSize)
+		offset = int32(skip)
+		ok = iter.Next()
+	default:
+		ok = iter.Next()
+	}
+
+	page = &Page{
+		Results: make([]*Result, 0, pageSize),
+		Total:   -1,
+	}
+
+	// Only page numbers require the total, page tokens stop once the next VASP is found.
+	count := q.PageToken == "" && q.Page > 0
+	matches := 0
+
+	for ; ok; ok = iter.Next() {
+		var vasp *pb.VASP
+		if vasp, err = iter.VASP(); err != nil {
+			return nil, err
+		}
+
+		if !q.Match(vasp) {
+			continue
+		}
+
+		matches++
+		switch {
+		case skip > 0:
+			skip--
+		case len(page.Results) < int(pageSize):
+			page.Results = append(page.Results, &Result{VASP: vasp})
+		case page.NextPageToken == "":
+			cursor := &models.PageCursor{
+				PageSize: pageSize,
+				NextVasp: vasp.Id,
+				Offset:   offset + int32(len(page.Results)),
+			}
+
+			if page.NextPageToken, err = cursor.Dump(); err != nil {
+				return nil, err
+			}
+		}
+
+		if page.NextPageToken != "" && !count {
+			break
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	if count {
+		page.Total = matches
+	}
+	return page, nil
+}
+
+// Result is a VASP that matched the query along with its relevance score.
+type Result struct {
+	VASP  *pb.VASP
+	Score float64
+}
+
+// Page is a single page of sorted results from a query.
+type Page struct {
+	Results       []*Result
+	NextPageToken string // empty if there are no more results
+	Total         int    // the total number of results across all pages, -1 if unknown
+}
+
+// Paginate sorts all of the results that matched the query and returns the page that
+// is specified by the page token or page number of the query.
+func Paginate(q *Query, results []*Result) (page *Page, err error) {
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	Sort(results, q.OrderBy)
+
+	// Determine where the page starts from the page token or the page number
+	var start int
+	switch {
+	case q.PageToken != "":
+		cursor := &models.PageCursor{}
+		if err = cursor.Load(q.PageToken); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+
+		if cursor.PageSize != pageSize {
+			return nil, ErrPageSizeChanged
+		}
+
+		if cursor.Offset < 0 {
+			return nil, ErrInvalidPageToken
+		}
+
+		// Prefer to start from the next VASP in case records have been added or removed
+		// since the previous page, otherwise fall back to the offset.
+		start = int(cursor.Offset)
+		for i, result := range results {
+			if result.VASP.Id == cursor.NextVasp {
+				start = i
+				break
+			}
+		}
+	case q.Page > 1:
+		start = int(q.Page-1) * int(pageSize)
+	}
+
+	page = &Page{
+		Results: make([]*Result, 0, pageSize),
+		Total:   len(results),
+	}
+
+	if start >= len(results) {
+		return page, nil
+	}
+
+	end := start + int(pageSize)
+	if end > len(results) {
+		end = len(results)
+	}
+	page.Results = append(page.Results, results[start:end]...)
+
+	if end < len(results) {
+		cursor := &models.PageCursor{
+			PageSize: pageSize,
+			NextVasp: results[end].VASP.Id,
+			Offset:   int32(end),
+		}
+
+		if page.NextPageToken, err = cursor.Dump(); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// Sort the results in place by the specified order.
+func Sort(results []*Result, order Order) {
+	switch order {
+	case OrderName:
+		names := make(map[string]string, len(results))
+		for _, result := range results {
+			names[result.VASP.Id] = SortName(result.VASP)
+		}
+
+		sort.SliceStable(results, func(i, j int) bool {
+			ni, nj := names[results[i].VASP.Id], names[results[j].VASP.Id]
+			if ni == nj {
+				return results[i].VASP.Id < results[j].VASP.Id
+			}
+			return ni < nj
+		})
+	default:
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].Score == results[j].Score {
+				return results[i].VASP.Id < results[j].VASP.Id
+			}
+			return results[i].Score > results[j].Score
+		})
+	}
+}
+
+// Countries returns the normalized countries of the VASP as they are indexed by the
+// country index: the country of registration and the countries of all addresses.
+func Countries(vasp *pb.VASP) map[string]struct{} {
+	countries := make(map[string]struct{})
+	if vasp.Entity != nil {
+		if country := index.NormalizeCountry(vasp.Entity.CountryOfRegistration); country != "" {
+			countries[country] = struct{}{}
+		}
+
+		for _, addr := range vasp.Entity.GeographicAddresses {
+			if country := index.NormalizeCountry(addr.Country); country != "" {
+				countries[country] = struct{}{}
+			}
+		}
+	}
+	return countries
+}
+
+// Categories returns the normalized business and VASP categories of the VASP as they
+// are indexed by the category index.
+func Categories(vasp *pb.VASP) map[string]struct{} {
+	categories := make(map[string]struct{})
+	categories[index.Normalize(vasp.BusinessCategory.String())] = struct{}{}
+	for _, category := range vasp.VaspCategories {
+		if category = index.Normalize(category); category != "" {
+			categories[category] = struct{}{}
+		}
+	}
+	return categories
+}
+
+// SortName returns the normalized name of the VASP that results are sorted by when they
+// are ordered by name, falling back to the common name if the VASP has no legal name.
+func SortName(vasp *pb.VASP) string {
+	if name, err := vasp.Name(); err == nil && name != "" {
+		return index.Normalize(name)
+	}
+	return index.Normalize(vasp.CommonName)
+}
+
+// TimeRange filters timestamps that are after and/or before the specified times; a
+// zero time means the range is unbounded on that side.
+type TimeRange struct {
+	After  time.Time
+	Before time.Time
+}
+
+// IsZero returns true if the range is unbounded on both sides.
+func (r TimeRange) IsZero() bool {
+	return r.After.IsZero() && r.Before.IsZero()
+}
+
+// Contains returns true if the RFC3339 timestamp is in the range. If the range is
+// bounded, empty or unparseable timestamps are not contained by the range.
+func (r TimeRange) Contains(timestamp string) bool {
+	if r.IsZero() {
+		return true
+	}
+
+	ts, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return false
+	}
+
+	if !r.After.IsZero() && ts.Before(r.After) {
+		return false
+	}
+
+	if !r.Before.IsZero() && !ts.Before(r.Before) {
+		return false
+	}
+	return true
+}
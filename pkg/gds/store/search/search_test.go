@@ -0,0 +1,352 @@
+package search_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/store/leveldb"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+)
+
+func TestTimeRange(t *testing.T) {
+	jan := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		rng      search.TimeRange
+		ts       string
+		expected bool
+	}{
+		{search.TimeRange{}, "", true},
+		{search.TimeRange{}, "2022-01-15T00:00:00Z", true},
+		{search.TimeRange{After: jan}, "", false},
+		{search.TimeRange{After: jan}, "notatimestamp", false},
+		{search.TimeRange{After: jan}, "2022-01-15T00:00:00Z", true},
+		{search.TimeRange{After: jan}, "2021-12-31T23:59:59Z", false},
+		{search.TimeRange{After: jan}, "2022-01-01T00:00:00Z", true},
+		{search.TimeRange{Before: feb}, "2022-02-01T00:00:00Z", false},
+		{search.TimeRange{Before: feb}, "2022-01-31T23:59:59Z", true},
+		{search.TimeRange{After: jan, Before: feb}, "2022-01-15T00:00:00Z", true},
+		{search.TimeRange{After: jan, Before: feb}, "2022-02-15T00:00:00Z", false},
+	}
+
+	for i, tc := range tt {
+		require.Equal(t, tc.expected, tc.rng.Contains(tc.ts), "test case %d failed", i)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	vasp := &pb.VASP{
+		Entity: &ivms101.LegalPerson{
+			CountryOfRegistration: "US",
+			GeographicAddresses: []*ivms101.Address{
+				{Country: "Germany"},
+			},
+		},
+		BusinessCategory:   pb.BusinessCategoryPrivate,
+		VaspCategories:     []string{"Exchange", "P2P"},
+		VerificationStatus: pb.VerificationState_VERIFIED,
+		FirstListed:        "2022-01-15T00:00:00Z",
+		VerifiedOn:         "2022-02-15T00:00:00Z",
+		IdentityCertificate: &pb.Certificate{
+			NotAfter: "2023-02-15T00:00:00Z",
+		},
+	}
+
+	jan := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		query    *search.Query
+		expected bool
+	}{
+		{&search.Query{}, true},
+		{&search.Query{VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}}, true},
+		{&search.Query{VerificationStatus: []pb.VerificationState{pb.VerificationState_SUBMITTED, pb.VerificationState_VERIFIED}}, true},
+		{&search.Query{VerificationStatus: []pb.VerificationState{pb.VerificationState_SUBMITTED}}, false},
+		{&search.Query{Countries: []string{"us"}}, true},
+		{&search.Query{Countries: []string{"United States", "DE"}}, true},
+		{&search.Query{Countries: []string{"US", "FR"}}, false},
+		{&search.Query{Categories: []string{"PRIVATE_ORGANIZATION", "exchange"}}, true},
+		{&search.Query{Categories: []string{"Kiosk"}}, false},
+		{&search.Query{FirstListed: search.TimeRange{After: jan}}, true},
+		{&search.Query{FirstListed: search.TimeRange{Before: jan}}, false},
+		{&search.Query{VerifiedOn: search.TimeRange{After: jan, Before: mar}}, true},
+		{&search.Query{VerifiedOn: search.TimeRange{Before: jan}}, false},
+		{&search.Query{CertExpires: search.TimeRange{Before: mar}}, false},
+		{&search.Query{CertExpires: search.TimeRange{After: mar}}, true},
+		{&search.Query{Archived: true}, false},
+	}
+
+	for i, tc := range tt {
+		require.Equal(t, tc.expected, tc.query.Match(vasp), "test case %d failed", i)
+	}
+
+	// Records without an identity certificate never match a certificate expiration
+	vasp.IdentityCertificate = nil
+	require.False(t, (&search.Query{CertExpires: search.TimeRange{After: mar}}).Match(vasp))
+
+	// Deleted records only match archived queries
+	require.NoError(t, models.ArchiveVASP(vasp, "admin@example.com"))
+	require.False(t, (&search.Query{}).Match(vasp))
+	require.True(t, (&search.Query{Archived: true}).Match(vasp))
+	require.False(t, (&search.Query{Archived: true, Countries: []string{"FR"}}).Match(vasp))
+}
+
+func TestPaginate(t *testing.T) {
+	results := make([]*search.Result, 0, 25)
+	for i := 0; i < 25; i++ {
+		results = append(results, &search.Result{
+			VASP:  &pb.VASP{Id: fmt.Sprintf("%04d", i), CommonName: fmt.Sprintf("vasp%02d.example.com", 24-i)},
+			Score: float64(i % 5),
+		})
+	}
+
+	// Results are sorted by relevance then by ID
+	query := &search.Query{PageSize: 10}
+	page, err := search.Paginate(query, results)
+	require.NoError(t, err)
+	require.Equal(t, 25, page.Total)
+	require.Len(t, page.Results, 10)
+	require.NotEmpty(t, page.NextPageToken)
+	require.Equal(t, "0004", page.Results[0].VASP.Id)
+	require.Equal(t, "0009", page.Results[1].VASP.Id)
+	require.Equal(t, float64(4), page.Results[0].Score)
+
+	// Iterate over all pages
+	seen := make(map[string]struct{})
+	for {
+		for _, result := range page.Results {
+			seen[result.VASP.Id] = struct{}{}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+
+		query.PageToken = page.NextPageToken
+		page, err = search.Paginate(query, results)
+		require.NoError(t, err)
+	}
+	require.Len(t, seen, 25)
+
+	// Page size cannot be changed between requests
+	page, err = search.Paginate(&search.Query{PageSize: 10}, results)
+	require.NoError(t, err)
+	_, err = search.Paginate(&search.Query{PageSize: 5, PageToken: page.NextPageToken}, results)
+	require.ErrorIs(t, err, search.ErrPageSizeChanged)
+
+	// Invalid page tokens are rejected
+	_, err = search.Paginate(&search.Query{PageSize: 10, PageToken: "123"}, results)
+	require.ErrorIs(t, err, search.ErrInvalidPageToken)
+
+	// If the next record is removed, the offset is used to continue
+	removed := make([]*search.Result, 0, len(results))
+	for _, result := range results {
+		if result.VASP.Id != "0002" {
+			removed = append(removed, result)
+		}
+	}
+
+	cursor := &models.PageCursor{}
+	require.NoError(t, cursor.Load(page.NextPageToken))
+	require.Equal(t, "0002", cursor.NextVasp)
+
+	page, err = search.Paginate(&search.Query{PageSize: 10, PageToken: page.NextPageToken}, removed)
+	require.NoError(t, err)
+	require.Equal(t, "0007", page.Results[0].VASP.Id)
+
+	// Page numbers can be used instead of page tokens
+	page, err = search.Paginate(&search.Query{PageSize: 10, Page: 3, OrderBy: search.OrderName}, results)
+	require.NoError(t, err)
+	require.Len(t, page.Results, 5)
+	require.Empty(t, page.NextPageToken)
+	require.Equal(t, "vasp20.example.com", page.Results[0].VASP.CommonName)
+
+	page, err = search.Paginate(&search.Query{PageSize: 10, Page: 4}, results)
+	require.NoError(t, err)
+	require.Len(t, page.Results, 0)
+	require.Equal(t, 25, page.Total)
+}
+
+func TestQueryVASPs(t *testing.T) {
+	db, err := leveldb.Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	names := []string{"Charlie Bank", "Alpha Exchange", "Bravo Trading", "Delta Exchange", "Echo Wallet"}
+	for i, name := range names {
+		vasp := &pb.VASP{
+			Entity: &ivms101.LegalPerson{
+				Name: &ivms101.LegalPersonName{
+					NameIdentifiers: []*ivms101.LegalPersonNameId{
+						{LegalPersonName: name, LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+					},
+				},
+				CountryOfRegistration: "US",
+			},
+			CommonName:         fmt.Sprintf("trisa%d.example.com", i),
+			Website:            fmt.Sprintf("https://vasp%d.example.com", i),
+			VerificationStatus: pb.VerificationState_VERIFIED,
+		}
+
+		if i%2 == 1 {
+			vasp.VerificationStatus = pb.VerificationState_SUBMITTED
+			vasp.Entity.CountryOfRegistration = "DE"
+		}
+
+		_, err = db.CreateVASP(vasp)
+		require.NoError(t, err)
+	}
+
+	// The indexed query and the scan should return the same results
+	queries := []*search.Query{
+		{OrderBy: search.OrderName},
+		{OrderBy: search.OrderName, PageSize: 2},
+		{Text: "exchange", OrderBy: search.OrderName},
+		{Text: "exchnge", Fuzzy: true, OrderBy: search.OrderName},
+		{Names: []string{"Bravo Trading", "delta"}, OrderBy: search.OrderName},
+		{Websites: []string{"https://vasp4.example.com"}},
+		{Countries: []string{"DE"}, OrderBy: search.OrderName},
+		{VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}, OrderBy: search.OrderName},
+	}
+
+	for i, query := range queries {
+		indexed, err := db.QueryVASPs(query)
+		require.NoError(t, err, "query %d failed", i)
+
+		scanned, err := search.Scan(query, db.ListVASPs())
+		require.NoError(t, err, "scan %d failed", i)
+
+		require.Equal(t, scanned.Total, indexed.Total, "query %d returned a different total", i)
+		require.Len(t, indexed.Results, len(scanned.Results), "query %d returned a different number of results", i)
+		for j := range indexed.Results {
+			require.Equal(t, scanned.Results[j].VASP.Id, indexed.Results[j].VASP.Id, "query %d returned different results", i)
+		}
+	}
+
+	page, err := db.QueryVASPs(&search.Query{OrderBy: search.OrderName})
+	require.NoError(t, err)
+	require.Equal(t, 5, page.Total)
+	require.Equal(t, "trisa1.example.com", page.Results[0].VASP.CommonName)
+	require.Equal(t, "trisa4.example.com", page.Results[4].VASP.CommonName)
+
+	page, err = db.QueryVASPs(&search.Query{Text: "exchange", Countries: []string{"DE"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, page.Total)
+
+	// Exact name matches are ranked above prefix matches
+	page, err = db.QueryVASPs(&search.Query{Names: []string{"Bravo Trading", "delta"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, page.Total)
+	require.Equal(t, "trisa2.example.com", page.Results[0].VASP.CommonName)
+	require.Greater(t, page.Results[0].Score, page.Results[1].Score)
+
+	page, err = db.QueryVASPs(&search.Query{Text: "exchnge", Fuzzy: true, VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}})
+	require.NoError(t, err)
+	require.Equal(t, 0, page.Total)
+}
+
+func TestSeek(t *testing.T) {
+	db, err := leveldb.Open(t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	ids := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		vasp := &pb.VASP{
+			Id: fmt.Sprintf("%04d", i),
+			Entity: &ivms101.LegalPerson{
+				Name: &ivms101.LegalPersonName{
+					NameIdentifiers: []*ivms101.LegalPersonNameId{
+						{LegalPersonName: fmt.Sprintf("VASP %02d", i), LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+					},
+				},
+			},
+			CommonName:         fmt.Sprintf("trisa%02d.example.com", i),
+			VerificationStatus: pb.VerificationState_VERIFIED,
+		}
+
+		if i%5 == 0 {
+			vasp.VerificationStatus = pb.VerificationState_SUBMITTED
+		} else {
+			ids = append(ids, vasp.Id)
+		}
+
+		_, err = db.CreateVASP(vasp)
+		require.NoError(t, err)
+	}
+
+	// Iterate over all pages of verified VASPs in ID order with page tokens
+	query := &search.Query{VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}, PageSize: 7}
+	page, err := search.Seek(query, db.ListVASPs())
+	require.NoError(t, err)
+	require.Equal(t, -1, page.Total)
+
+	seen := make([]string, 0, len(ids))
+	for {
+		require.LessOrEqual(t, len(page.Results), 7)
+		for _, result := range page.Results {
+			seen = append(seen, result.VASP.Id)
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+
+		query.PageToken = page.NextPageToken
+		page, err = search.Seek(query, db.ListVASPs())
+		require.NoError(t, err)
+	}
+	require.Equal(t, ids, seen)
+
+	// Seek returns the same results as the sorted scan
+	for p := int32(1); p <= 4; p++ {
+		query := &search.Query{VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}, PageSize: 7, Page: p}
+		sought, err := search.Seek(query, db.ListVASPs())
+		require.NoError(t, err)
+
+		results := make([]*search.Result, 0, 25)
+		iter := db.ListVASPs()
+		for iter.Next() {
+			vasp, err := iter.VASP()
+			require.NoError(t, err)
+			if query.Match(vasp) {
+				results = append(results, &search.Result{VASP: vasp})
+			}
+		}
+		iter.Release()
+
+		paginated, err := search.Paginate(query, results)
+		require.NoError(t, err)
+		require.Equal(t, paginated.Total, sought.Total)
+		require.Equal(t, paginated.NextPageToken, sought.NextPageToken)
+		require.Len(t, sought.Results, len(paginated.Results))
+		for i := range sought.Results {
+			require.Equal(t, paginated.Results[i].VASP.Id, sought.Results[i].VASP.Id)
+		}
+	}
+
+	// Invalid page tokens and page size changes are rejected
+	_, err = search.Seek(&search.Query{PageSize: 10, PageToken: "123"}, db.ListVASPs())
+	require.ErrorIs(t, err, search.ErrInvalidPageToken)
+
+	page, err = search.Seek(&search.Query{PageSize: 10}, db.ListVASPs())
+	require.NoError(t, err)
+	_, err = search.Seek(&search.Query{PageSize: 5, PageToken: page.NextPageToken}, db.ListVASPs())
+	require.ErrorIs(t, err, search.ErrPageSizeChanged)
+
+	// If the next record is removed, iteration continues from the following record
+	cursor := &models.PageCursor{}
+	require.NoError(t, cursor.Load(page.NextPageToken))
+	require.NoError(t, db.DeleteVASP(cursor.NextVasp))
+
+	page, err = search.Seek(&search.Query{PageSize: 10, PageToken: page.NextPageToken}, db.ListVASPs())
+	require.NoError(t, err)
+	require.Equal(t, "0011", page.Results[0].VASP.Id)
+}
@@ -0,0 +1,390 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// number of rows fetched from the database on each page of iteration
+const iterPageSize = 100
+
+// row is a materialized id and serialized record from one of the tables.
+type row struct {
+	id   string
+	data []byte
+}
+
+// rowsIterator iterates over all of the rows of a table in id order. Rows are loaded in
+// pages using keyset pagination so that the iterator does not hold a read transaction
+// open while the caller is processing records, and so that Prev can be supported.
+// Iteration can be restricted to the rows where a column has a specific value.
+type rowsIterator struct {
+	db     *sql.DB
+	table  string
+	column string
+	value  interface{}
+	rows   []row
+	index  int
+	seek   string
+	done   bool
+	closed bool
+	err    error
+}
+
+type vaspIterator struct {
+	*rowsIterator
+}
+
+type certIterator struct {
+	*rowsIterator
+}
+
+type certReqIterator struct {
+	*rowsIterator
+}
+
+type actionIterator struct {
+	*rowsIterator
+}
+
+type auditIterator struct {
+	*rowsIterator
+}
+
+type revisionIterator struct {
+	*rowsIterator
+}
+
+func newRowsIterator(db *sql.DB, table string) *rowsIterator {
+	return &rowsIterator{
+		db:    db,
+		table: table,
+		index: -1,
+		rows:  make([]row, 0),
+	}
+}
+
+// newFilteredRowsIterator only iterates over the rows where the column has the value.
+// The column must not be user supplied since it is formatted into the query.
+func newFilteredRowsIterator(db *sql.DB, table, column string, value interface{}) *rowsIterator {
+	i := newRowsIterator(db, table)
+	i.column = column
+	i.value = value
+	return i
+}
+
+func (i *rowsIterator) Next() bool {
+	if i.err != nil || i.closed {
+		return false
+	}
+
+	i.index++
+	if i.index < len(i.rows) {
+		return true
+	}
+
+	if i.done {
+		i.index = len(i.rows)
+		return false
+	}
+
+	if !i.fetch() {
+		i.index = len(i.rows)
+		return false
+	}
+	return i.index < len(i.rows)
+}
+
+func (i *rowsIterator) Prev() bool {
+	i.index--
+	if i.index < 0 {
+		i.index = -1
+		return false
+	}
+	return i.index < len(i.rows)
+}
+
+func (i *rowsIterator) Error() error {
+	return i.err
+}
+
+func (i *rowsIterator) Release() {
+	i.closed = true
+	i.rows = nil
+}
+
+// seekID positions the iterator at the first row whose id is greater than or equal to
+// the specified id, returning false if there is no such row.
+func (i *rowsIterator) seekID(id string) bool {
+	i.rows = i.rows[:0]
+	i.index = -1
+	i.done = false
+	i.seek = id
+
+	if !i.fetch() {
+		return false
+	}
+
+	i.index = 0
+	return true
+}
+
+// fetch the next page of rows from the database, returning false if there are no more
+// rows or if an error occurred.
+func (i *rowsIterator) fetch() bool {
+	var (
+		err   error
+		conds []string
+		args  []interface{}
+		rows  *sql.Rows
+	)
+
+	switch {
+	case len(i.rows) > 0:
+		conds = append(conds, "id > ?")
+		args = append(args, i.rows[len(i.rows)-1].id)
+	case i.seek != "":
+		conds = append(conds, "id >= ?")
+		args = append(args, i.seek)
+	}
+
+	if i.column != "" {
+		conds = append(conds, i.column+" = ?")
+		args = append(args, i.value)
+	}
+
+	query := fmt.Sprintf(`SELECT id, data FROM %s`, i.table)
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY id LIMIT ?"
+	args = append(args, iterPageSize)
+
+	if rows, err = i.db.Query(query, args...); err != nil {
+		i.err = err
+		return false
+	}
+	defer rows.Close()
+
+	var nrows int
+	for rows.Next() {
+		var r row
+		if err = rows.Scan(&r.id, &r.data); err != nil {
+			i.err = err
+			return false
+		}
+		i.rows = append(i.rows, r)
+		nrows++
+	}
+
+	if err = rows.Err(); err != nil {
+		i.err = err
+		return false
+	}
+
+	if nrows < iterPageSize {
+		i.done = true
+	}
+	return nrows > 0
+}
+
+// current returns the row the iterator is pointing at, if any.
+func (i *rowsIterator) current() (row, bool) {
+	if i.index < 0 || i.index >= len(i.rows) {
+		return row{}, false
+	}
+	return i.rows[i.index], true
+}
+
+func (i *vaspIterator) Id() string {
+	if r, ok := i.current(); ok {
+		return r.id
+	}
+	return ""
+}
+
+func (i *vaspIterator) SeekId(vaspID string) bool {
+	return i.seekID(vaspID)
+}
+
+func (i *vaspIterator) VASP() (*pb.VASP, error) {
+	r, _ := i.current()
+	vasp := new(pb.VASP)
+	if err := proto.Unmarshal(r.data, vasp); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceVASPs).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return vasp, nil
+}
+
+func (i *vaspIterator) All() (vasps []*pb.VASP, err error) {
+	vasps = make([]*pb.VASP, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var vasp *pb.VASP
+		if vasp, err = i.VASP(); err != nil {
+			return nil, err
+		}
+		vasps = append(vasps, vasp)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return vasps, nil
+}
+
+func (i *certIterator) Cert() (*models.Certificate, error) {
+	r, _ := i.current()
+	cert := new(models.Certificate)
+	if err := proto.Unmarshal(r.data, cert); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceCerts).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (i *certIterator) All() (certs []*models.Certificate, err error) {
+	certs = make([]*models.Certificate, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var cert *models.Certificate
+		if cert, err = i.Cert(); err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+func (i *certReqIterator) CertReq() (*models.CertificateRequest, error) {
+	r, _ := i.current()
+	req := new(models.CertificateRequest)
+	if err := proto.Unmarshal(r.data, req); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceCertReqs).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return req, nil
+}
+
+func (i *certReqIterator) All() (reqs []*models.CertificateRequest, err error) {
+	reqs = make([]*models.CertificateRequest, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var req *models.CertificateRequest
+		if req, err = i.CertReq(); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (i *actionIterator) Action() (*models.PendingAction, error) {
+	r, _ := i.current()
+	action := new(models.PendingAction)
+	if err := proto.Unmarshal(r.data, action); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceActions).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return action, nil
+}
+
+func (i *actionIterator) All() (actions []*models.PendingAction, err error) {
+	actions = make([]*models.PendingAction, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var action *models.PendingAction
+		if action, err = i.Action(); err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+func (i *auditIterator) Record() (*models.AuditRecord, error) {
+	r, _ := i.current()
+	record := new(models.AuditRecord)
+	if err := proto.Unmarshal(r.data, record); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceAudit).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return record, nil
+}
+
+func (i *auditIterator) All() (records []*models.AuditRecord, err error) {
+	records = make([]*models.AuditRecord, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var record *models.AuditRecord
+		if record, err = i.Record(); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SeekTimestamp positions the iterator at the most recent audit record created at or
+// before the timestamp, returning false if there is no such record.
+func (i *auditIterator) SeekTimestamp(ts time.Time) bool {
+	return i.seekID(models.AuditRecordSeekKey(ts))
+}
+
+func (i *revisionIterator) Revision() (*models.VASPRevision, error) {
+	r, _ := i.current()
+	revision := new(models.VASPRevision)
+	if err := proto.Unmarshal(r.data, revision); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceRevisions).Str("key", r.id).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (i *revisionIterator) All() (revisions []*models.VASPRevision, err error) {
+	revisions = make([]*models.VASPRevision, 0)
+	defer i.Release()
+
+	for i.Next() {
+		var revision *models.VASPRevision
+		if revision, err = i.Revision(); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+// The schema is applied every time the database is opened; all statements must be
+// idempotent so that an existing database is not modified. Records are stored as
+// serialized protocol buffers in the data column of each table so that no information
+// is lost, the remaining columns are extracted from the record to support queries and
+// the uniqueness constraints required by the store.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS vasps (
+		id                   TEXT PRIMARY KEY,
+		common_name          TEXT NOT NULL,
+		website              TEXT NOT NULL DEFAULT '',
+		registered_directory TEXT NOT NULL DEFAULT '',
+		business_category    TEXT NOT NULL DEFAULT '',
+		verification_status  INTEGER NOT NULL DEFAULT 0,
+		verified_on          TEXT NOT NULL DEFAULT '',
+		first_listed         TEXT NOT NULL DEFAULT '',
+		last_updated         TEXT NOT NULL DEFAULT '',
+		version              INTEGER NOT NULL DEFAULT 0,
+		archived             INTEGER NOT NULL DEFAULT 0,
+		sort_name            TEXT NOT NULL DEFAULT '',
+		cert_expires         TEXT NOT NULL DEFAULT '',
+		data                 BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS vasps_website_idx ON vasps (website)`,
+	`CREATE INDEX IF NOT EXISTS vasps_verification_status_idx ON vasps (verification_status)`,
+	`CREATE INDEX IF NOT EXISTS vasps_sort_name_idx ON vasps (archived, sort_name)`,
+
+	// The text table is the full text index of the VASPs. The text of each VASP is
+	// tokenized by the index package before it is stored so that queries are tokenized
+	// the same way as by the other stores. The vocabulary table lists the indexed tokens
+	// so that fuzzy searches can find the tokens that are close to the query tokens.
+	`CREATE VIRTUAL TABLE IF NOT EXISTS vasp_text USING fts5 (vasp_id UNINDEXED, text)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS vasp_text_vocab USING fts5vocab (vasp_text, 'row')`,
+
+	// The names table acts as the names index, mapping the normalized common name and
+	// all IVMS 101 entity names of a VASP to the VASP. Names are unique across VASPs.
+	`CREATE TABLE IF NOT EXISTS vasp_names (
+		name    TEXT PRIMARY KEY,
+		vasp_id TEXT NOT NULL REFERENCES vasps (id) ON DELETE CASCADE
+	)`,
+	`CREATE INDEX IF NOT EXISTS vasp_names_vasp_idx ON vasp_names (vasp_id)`,
+
+	// The countries table maps the country of registration and all geographic address
+	// countries (normalized to ISO 3166-1 alpha-2 where possible) to the VASP.
+	`CREATE TABLE IF NOT EXISTS vasp_countries (
+		country TEXT NOT NULL,
+		vasp_id TEXT NOT NULL REFERENCES vasps (id) ON DELETE CASCADE,
+		PRIMARY KEY (country, vasp_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS vasp_countries_vasp_idx ON vasp_countries (vasp_id)`,
+
+	// The categories table maps the business category and the VASP categories to the VASP.
+	`CREATE TABLE IF NOT EXISTS vasp_categories (
+		category TEXT NOT NULL,
+		vasp_id  TEXT NOT NULL REFERENCES vasps (id) ON DELETE CASCADE,
+		PRIMARY KEY (category, vasp_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS vasp_categories_vasp_idx ON vasp_categories (vasp_id)`,
+
+	// The serial number is the normalized hex encoding of the certificate serial number
+	// and not_after is the UTC RFC3339 expiration so that it can be compared as text.
+	`CREATE TABLE IF NOT EXISTS certificates (
+		id            TEXT PRIMARY KEY,
+		request       TEXT NOT NULL DEFAULT '',
+		vasp          TEXT NOT NULL DEFAULT '',
+		status        INTEGER NOT NULL DEFAULT 0,
+		serial_number TEXT NOT NULL DEFAULT '',
+		not_after     TEXT NOT NULL DEFAULT '',
+		data          BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS certificates_vasp_idx ON certificates (vasp)`,
+	`CREATE INDEX IF NOT EXISTS certificates_serial_number_idx ON certificates (serial_number)`,
+	`CREATE INDEX IF NOT EXISTS certificates_not_after_idx ON certificates (not_after)`,
+
+	`CREATE TABLE IF NOT EXISTS certreqs (
+		id          TEXT PRIMARY KEY,
+		vasp        TEXT NOT NULL DEFAULT '',
+		common_name TEXT NOT NULL DEFAULT '',
+		status      INTEGER NOT NULL DEFAULT 0,
+		created     TEXT NOT NULL DEFAULT '',
+		modified    TEXT NOT NULL DEFAULT '',
+		data        BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS certreqs_vasp_idx ON certreqs (vasp)`,
+
+	`CREATE TABLE IF NOT EXISTS actions (
+		id       TEXT PRIMARY KEY,
+		type     INTEGER NOT NULL DEFAULT 0,
+		vasp     TEXT NOT NULL DEFAULT '',
+		status   INTEGER NOT NULL DEFAULT 0,
+		created  TEXT NOT NULL DEFAULT '',
+		modified TEXT NOT NULL DEFAULT '',
+		data     BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS actions_vasp_idx ON actions (vasp)`,
+
+	`CREATE TABLE IF NOT EXISTS audit (
+		id        TEXT PRIMARY KEY,
+		timestamp TEXT NOT NULL DEFAULT '',
+		actor     TEXT NOT NULL DEFAULT '',
+		target    TEXT NOT NULL DEFAULT '',
+		data      BLOB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS audit_target_idx ON audit (target)`,
+
+	// Revisions are keyed by the vasp id and the zero padded revision number so that
+	// iterating by id returns the revisions of a VASP in order.
+	`CREATE TABLE IF NOT EXISTS revisions (
+		id       TEXT PRIMARY KEY,
+		vasp     TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		created  TEXT NOT NULL DEFAULT '',
+		data     BLOB NOT NULL,
+		UNIQUE (vasp, revision)
+	)`,
+}
@@ -0,0 +1,307 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	"github.com/trisacrypto/directory/pkg/gds/store/index"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+)
+
+// QueryVASPs returns a page of VASPs that match the query, ordered by relevance or by
+// name. The query is executed in SQL: records are scored with the names table, the
+// website column and the full text index, then filtered, sorted and paginated by the
+// database so that only a single page of records is loaded.
+func (s *Store) QueryVASPs(query *search.Query) (page *search.Page, err error) {
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = search.DefaultPageSize
+	}
+
+	// Determine where the page starts from the page token or the page number
+	var (
+		offset int
+		next   string
+	)
+
+	switch {
+	case query.PageToken != "":
+		cursor := &models.PageCursor{}
+		if err = cursor.Load(query.PageToken); err != nil {
+			return nil, search.ErrInvalidPageToken
+		}
+
+		if cursor.PageSize != pageSize {
+			return nil, search.ErrPageSizeChanged
+		}
+
+		if cursor.Offset < 0 {
+			return nil, search.ErrInvalidPageToken
+		}
+		offset, next = int(cursor.Offset), cursor.NextVasp
+	case query.Page > 1:
+		offset = int(query.Page-1) * int(pageSize)
+	}
+
+	var results *queryBuilder
+	if results, err = s.queryResults(query); err != nil {
+		return nil, err
+	}
+
+	page = &search.Page{Results: make([]*search.Result, 0, pageSize)}
+	if err = s.db.QueryRow(results.with(`SELECT COUNT(*) FROM results`), results.bind()...).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	// Prefer to start from the next VASP in case records have been added or removed
+	// since the previous page, otherwise fall back to the offset.
+	if next != "" {
+		var position int
+		if err = s.db.QueryRow(results.with(`SELECT position FROM results WHERE id = ?`), results.bind(next)...).Scan(&position); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+		} else {
+			offset = position
+		}
+	}
+
+	// Fetch one more record than the page size to determine the next page token.
+	var rows *sql.Rows
+	if rows, err = s.db.Query(results.with(`SELECT id, data, score FROM results WHERE position >= ? ORDER BY position LIMIT ?`), results.bind(offset, pageSize+1)...); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			data  []byte
+			score float64
+		)
+
+		if err = rows.Scan(&id, &data, &score); err != nil {
+			return nil, err
+		}
+
+		if len(page.Results) == int(pageSize) {
+			cursor := &models.PageCursor{
+				PageSize: pageSize,
+				NextVasp: id,
+				Offset:   int32(offset) + pageSize,
+			}
+
+			if page.NextPageToken, err = cursor.Dump(); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		vasp := &pb.VASP{}
+		if err = proto.Unmarshal(data, vasp); err != nil {
+			return nil, err
+		}
+		page.Results = append(page.Results, &search.Result{VASP: vasp, Score: score})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// queryResults builds the common table expression of the VASPs that match the query
+// with their relevance score and their position in the sorted results.
+func (s *Store) queryResults(query *search.Query) (_ *queryBuilder, err error) {
+	q := &queryBuilder{}
+
+	// Score the records that match the terms of the query; if there are no terms every
+	// record that passes the filters matches with a zero score.
+	source := `vasps v, (SELECT 0.0 AS score) s`
+	if query.HasTerms() {
+		var match string
+		if text := strings.TrimSpace(query.Text); text != "" {
+			if match, err = s.matchText(text, query.Fuzzy); err != nil {
+				return nil, err
+			}
+		}
+
+		scores := q.scores(query, match)
+		source = `vasps v JOIN (SELECT id, SUM(score) AS score FROM (` + scores + `) GROUP BY id) s ON s.id = v.id`
+	}
+
+	// Filter the records
+	filters := []string{`v.archived = ?`}
+	q.args = append(q.args, query.Archived)
+
+	if len(query.VerificationStatus) > 0 {
+		statuses := make([]interface{}, 0, len(query.VerificationStatus))
+		for _, status := range query.VerificationStatus {
+			statuses = append(statuses, int32(status))
+		}
+		filters = append(filters, `v.verification_status IN (`+q.params(statuses...)+`)`)
+	}
+
+	for _, country := range query.Countries {
+		filters = append(filters, `v.id IN (SELECT vasp_id FROM vasp_countries WHERE country = `+q.params(index.NormalizeCountry(country))+`)`)
+	}
+
+	for _, category := range query.Categories {
+		filters = append(filters, `v.id IN (SELECT vasp_id FROM vasp_categories WHERE category = `+q.params(index.Normalize(category))+`)`)
+	}
+
+	filters = append(filters, q.timeRange(`v.first_listed`, query.FirstListed)...)
+	filters = append(filters, q.timeRange(`v.verified_on`, query.VerifiedOn)...)
+	filters = append(filters, q.timeRange(`v.cert_expires`, query.CertExpires)...)
+
+	// Sort the records in the same order as search.Sort
+	order := `s.score DESC, v.id`
+	if query.OrderBy == search.OrderName {
+		order = `v.sort_name, v.id`
+	}
+
+	q.cte = `results AS (
+		SELECT v.id, v.data, s.score, ROW_NUMBER() OVER (ORDER BY ` + order + `) - 1 AS position
+		FROM ` + source + `
+		WHERE ` + strings.Join(filters, " AND ") + `
+	)`
+	return q, nil
+}
+
+// matchText returns the FTS5 query that matches any of the tokens of the text. Tokens
+// that meet the minimum prefix length also match indexed tokens they are a prefix of.
+// If fuzzy is true, the vocabulary of the full text index is scanned for tokens that
+// are within the allowed edit distance of the query tokens. An empty string is returned
+// if the text has no tokens to search for.
+func (s *Store) matchText(text string, fuzzy bool) (match string, err error) {
+	terms := index.Tokenize(text)
+	if len(terms) == 0 {
+		return "", nil
+	}
+
+	var vocabulary []string
+	if fuzzy {
+		if vocabulary, err = s.selectIDs(`SELECT term FROM vasp_text_vocab`); err != nil {
+			return "", err
+		}
+	}
+
+	alternatives := make([]string, 0, len(terms))
+	for _, term := range terms {
+		alternatives = append(alternatives, quoteToken(term))
+		if len(term) >= searchPrefixMinLength {
+			alternatives = append(alternatives, quoteToken(term)+"*")
+		}
+
+		for _, token := range vocabulary {
+			if token != term && index.FuzzyMatch(term, token) {
+				alternatives = append(alternatives, quoteToken(token))
+			}
+		}
+	}
+	return strings.Join(alternatives, " OR "), nil
+}
+
+// quoteToken returns the token as an FTS5 string so it is not parsed as an operator.
+func quoteToken(token string) string {
+	return `"` + strings.ReplaceAll(token, `"`, `""`) + `"`
+}
+
+// queryBuilder collects the common table expression of a query along with the
+// positional arguments that it is bound to.
+type queryBuilder struct {
+	cte  string
+	args []interface{}
+}
+
+// with returns the statement prefixed by the common table expression.
+func (q *queryBuilder) with(stmt string) string {
+	return "WITH " + q.cte + " " + stmt
+}
+
+// bind returns the arguments of the common table expression followed by the arguments
+// of the statement that uses it.
+func (q *queryBuilder) bind(args ...interface{}) []interface{} {
+	bound := make([]interface{}, 0, len(q.args)+len(args))
+	bound = append(bound, q.args...)
+	return append(bound, args...)
+}
+
+// params adds the arguments to the query and returns their placeholders.
+func (q *queryBuilder) params(args ...interface{}) string {
+	q.args = append(q.args, args...)
+	return strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+}
+
+// scores returns the query that selects the scores of the records matching the names,
+// websites and full text terms of the query, scoring them the same way as search.Score.
+func (q *queryBuilder) scores(query *search.Query, match string) string {
+	selects := make([]string, 0, 4)
+
+	if len(query.Names) > 0 {
+		names := make([]interface{}, 0, len(query.Names))
+		for _, name := range query.Names {
+			names = append(names, index.Normalize(name))
+		}
+
+		// Exact matches on the name score higher than prefix matches
+		selects = append(selects, `SELECT DISTINCT vasp_id AS id, `+q.params(search.ExactTermScore)+` AS score FROM vasp_names WHERE name IN (`+q.params(names...)+`)`)
+
+		// A name is only matched by prefix if it does not match a name exactly
+		prefixes := make([]string, 0, len(names))
+		for _, name := range names {
+			if len(name.(string)) >= searchPrefixMinLength {
+				prefixes = append(prefixes, `(substr(name, 1, length(`+q.params(name)+`)) = `+q.params(name)+` AND NOT EXISTS (SELECT 1 FROM vasp_names WHERE name = `+q.params(name)+`))`)
+			}
+		}
+
+		if len(prefixes) > 0 {
+			selects = append(selects, `SELECT DISTINCT vasp_id AS id, `+q.params(search.PrefixTermScore)+` AS score FROM vasp_names
+				WHERE (`+strings.Join(prefixes, " OR ")+`) AND vasp_id NOT IN (SELECT vasp_id FROM vasp_names WHERE name IN (`+q.params(names...)+`))`)
+		}
+	}
+
+	if len(query.Websites) > 0 {
+		websites := make([]interface{}, 0, len(query.Websites))
+		for _, website := range query.Websites {
+			if website = index.NormalizeURL(website); website != "" {
+				websites = append(websites, website)
+			}
+		}
+
+		if len(websites) > 0 {
+			selects = append(selects, `SELECT id, `+q.params(search.ExactTermScore)+` AS score FROM vasps WHERE website IN (`+q.params(websites...)+`)`)
+		}
+	}
+
+	if match != "" {
+		// The rank is the negative bm25 score, with more relevant records having lower values
+		selects = append(selects, `SELECT vasp_id AS id, -rank AS score FROM vasp_text WHERE vasp_text MATCH `+q.params(match))
+	}
+
+	if len(selects) == 0 {
+		// None of the terms can match any records
+		return `SELECT NULL AS id, 0.0 AS score WHERE 0`
+	}
+	return strings.Join(selects, " UNION ALL ")
+}
+
+// timeRange returns the filters that restrict the timestamp column to the range. The
+// timestamps are compared as julian days so that they do not need to be in the same
+// time zone; empty or unparseable timestamps are not contained by a bounded range.
+func (q *queryBuilder) timeRange(column string, rng search.TimeRange) []string {
+	filters := make([]string, 0, 2)
+	if !rng.After.IsZero() {
+		filters = append(filters, `julianday(`+column+`) >= julianday(`+q.params(rng.After.UTC().Format(time.RFC3339Nano))+`)`)
+	}
+
+	if !rng.Before.IsZero() {
+		filters = append(filters, `julianday(`+column+`) < julianday(`+q.params(rng.Before.UTC().Format(time.RFC3339Nano))+`)`)
+	}
+	return filters
+}
@@ -0,0 +1,1108 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/directory/pkg/gds/store/index"
+	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
+	"github.com/trisacrypto/directory/pkg/utils"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/proto"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Open a SQLite directory Store at the specified path, creating the database and its
+// tables if they do not already exist. The pure Go sqlite driver is used so that the
+// store is available in binaries compiled with CGO_ENABLED=0.
+func Open(path string) (store *Store, err error) {
+	// Enable foreign keys so that index rows are cascade deleted with their VASP.
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path)
+
+	store = &Store{path: path}
+	if store.db, err = sql.Open("sqlite", dsn); err != nil {
+		return nil, err
+	}
+
+	// SQLite only supports a single writer, limiting the pool to a single connection
+	// serializes access and prevents "database is locked" errors.
+	store.db.SetMaxOpenConns(1)
+
+	if err = store.db.Ping(); err != nil {
+		store.db.Close()
+		return nil, err
+	}
+
+	for _, stmt := range schema {
+		if _, err = store.db.Exec(stmt); err != nil {
+			store.db.Close()
+			return nil, fmt.Errorf("could not apply sqlite schema: %s", err)
+		}
+	}
+
+	return store, nil
+}
+
+// Store implements store.Store on relational tables in an embedded SQLite database.
+// Unlike the leveldb and trtl stores, search is performed with SQL queries so the store
+// does not maintain in-memory indices and does not need to be reindexed.
+type Store struct {
+	db   *sql.DB
+	path string
+}
+
+//===========================================================================
+// Store Implementation
+//===========================================================================
+
+// Close the database, allowing no further interactions.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+//===========================================================================
+// DirectoryStore Implementation
+//===========================================================================
+
+// ListVASPs returns all of the VASPs in the database
+func (s *Store) ListVASPs() iterator.DirectoryIterator {
+	return &vaspIterator{newRowsIterator(s.db, "vasps")}
+}
+
+// SearchVASPs uses the same semantics as the leveldb and trtl stores but executes the
+// search with SQL queries. To find a VASP by name, a case insensitive search is
+// performed against the VASP common name and IVMS 101 entity names. If there is not an
+// exact match a prefix lookup is used so long as the prefix is at least 3 characters.
+// Websites are matched by hostname rather than by scheme or path. The results are then
+// filtered by country and category.
+func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*pb.VASP, err error) {
+	// A set of records that match the query and need to be fetched
+	records := make(map[string]struct{})
+
+	// Full text search results are ranked, so maintain their order
+	var ranked []string
+	if _, ok := query["text"]; ok {
+		if ranked, err = s.searchText(query); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ranked {
+			records[id] = struct{}{}
+		}
+	}
+
+	// Search the names table
+	if names, ok := index.ParseQuery("name", query, index.Normalize); ok {
+		for _, name := range names {
+			var ids []string
+			if ids, err = s.selectIDs(`SELECT vasp_id FROM vasp_names WHERE name = ?`, name); err != nil {
+				return nil, err
+			}
+
+			if len(ids) == 0 && len(name) >= searchPrefixMinLength {
+				if ids, err = s.selectIDs(`SELECT vasp_id FROM vasp_names WHERE substr(name, 1, ?) = ?`, len(name), name); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, id := range ids {
+				records[id] = struct{}{}
+			}
+		}
+	}
+
+	// Lookup by website
+	if websites, ok := index.ParseQuery("website", query, index.NormalizeURL); ok {
+		for _, website := range websites {
+			if website == "" {
+				continue
+			}
+
+			var ids []string
+			if ids, err = s.selectIDs(`SELECT id FROM vasps WHERE website = ?`, website); err != nil {
+				return nil, err
+			}
+
+			for _, id := range ids {
+				records[id] = struct{}{}
+			}
+		}
+	}
+
+	// Filter by country
+	// NOTE: if country is not in the table, no records will be returned
+	if countries, ok := index.ParseQuery("country", query, index.NormalizeCountry); ok {
+		for _, country := range countries {
+			if err = s.filterIDs(records, `SELECT vasp_id FROM vasp_countries WHERE country = ?`, country); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Filter by category
+	// NOTE: if category is not in the table, no records will be returned
+	if categories, ok := index.ParseQuery("category", query, index.Normalize); ok {
+		for _, category := range categories {
+			if err = s.filterIDs(records, `SELECT vasp_id FROM vasp_categories WHERE category = ?`, category); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Perform the lookup of records if there are any
+	if len(records) > 0 {
+		vasps = make([]*pb.VASP, 0, len(records))
+		for _, id := range index.RankedOrder(ranked, records) {
+			var vasp *pb.VASP
+			if vasp, err = s.RetrieveVASP(id); err != nil {
+				if err == storeerrors.ErrEntityNotFound {
+					continue
+				}
+				return nil, err
+			}
+
+			// Deleted VASPs are retained until they are purged but are not searchable
+			if models.IsArchived(vasp) {
+				continue
+			}
+
+			vasps = append(vasps, vasp)
+		}
+	}
+
+	return vasps, nil
+}
+
+// searchText performs a ranked full text search of the VASPs with the full text index,
+// returning the IDs of the matching VASPs in order of relevance.
+func (s *Store) searchText(query map[string]interface{}) (_ []string, err error) {
+	terms, ok := index.ParseQuery("text", query, nil)
+	if !ok {
+		return nil, nil
+	}
+
+	fuzzy, _ := query["fuzzy"].(bool)
+	var match string
+	if match, err = s.matchText(strings.Join(terms, " "), fuzzy); err != nil || match == "" {
+		return nil, err
+	}
+	return s.selectIDs(`SELECT vasp_id FROM vasp_text WHERE vasp_text MATCH ? ORDER BY rank, vasp_id`, match)
+}
+
+// CreateVASP into the directory. This method requires the VASP to have a unique
+// name. A new ID is assigned if the VASP does not have one, otherwise the ID is kept so
+// that records can be copied between stores without changing their IDs.
+func (s *Store) CreateVASP(v *pb.VASP) (id string, err error) {
+	// Create UUID for record
+	if v.Id == "" {
+		v.Id = uuid.New().String()
+	}
+
+	// Ensure a common name exists for the uniqueness constraint
+	// NOTE: other validation should have been performed in advance
+	if name := index.Normalize(v.CommonName); name == "" {
+		return "", storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+	if v.Version == nil || v.Version.Version == 0 {
+		v.Version = &pb.Version{Version: 1}
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(v); err != nil {
+		return "", err
+	}
+
+	var tx *sql.Tx
+	if tx, err = s.db.Begin(); err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	// Check the uniqueness constraints
+	// NOTE: website removed as uniqueness constraint in SC-4483
+	if err = checkUniqueName(tx, v); err != nil {
+		return "", err
+	}
+
+	if _, err = tx.Exec(insertVASPSQL, vaspArgs(v, data)...); err != nil {
+		if isConstraintError(err) {
+			return "", storeerrors.ErrDuplicateEntity
+		}
+		return "", err
+	}
+
+	if err = insertIndices(tx, v); err != nil {
+		return "", err
+	}
+
+	if err = insertRevision(tx, v); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+	return v.Id, nil
+}
+
+// RetrieveVASP record by id; returns an error if the record does not exist.
+func (s *Store) RetrieveVASP(id string) (v *pb.VASP, err error) {
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM vasps WHERE id = ?`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	v = new(pb.VASP)
+	if err = proto.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UpdateVASP by the VASP ID (required). This method simply overwrites the
+// entire VASP record and does not update individual fields. If the stored record has
+// a different version than the record being updated, ErrConcurrentUpdate is returned.
+func (s *Store) UpdateVASP(v *pb.VASP) (err error) {
+	if v.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Ensure a common name exists for the uniqueness constraint
+	// NOTE: other validation should have been performed in advance
+	if name := index.Normalize(v.CommonName); name == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var tx *sql.Tx
+	if tx, err = s.db.Begin(); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Ensure the record exists before it is updated and that the stored record is
+	// still the version of the record that the caller retrieved, otherwise the update
+	// would overwrite the changes made since then.
+	var version uint64
+	if err = tx.QueryRow(`SELECT version FROM vasps WHERE id = ?`, v.Id).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storeerrors.ErrEntityNotFound
+		}
+		return err
+	}
+	if version != v.Version.GetVersion() {
+		return storeerrors.ErrConcurrentUpdate
+	}
+
+	// Check the uniqueness constraints
+	// NOTE: website removed as uniqueness constraint in SC-4483
+	if err = checkUniqueName(tx, v); err != nil {
+		return err
+	}
+
+	// Update management timestamps and record metadata
+	if v.Version == nil {
+		v.Version = &pb.Version{}
+	}
+	v.Version.Version++
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(v); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(updateVASPSQL, vaspArgs(v, data)...); err != nil {
+		return err
+	}
+
+	// Update the index tables to match the new record, removing the old entries so that
+	// the tables correctly reflect the state of the record.
+	if err = removeIndices(tx, v.Id); err != nil {
+		return err
+	}
+	if err = insertIndices(tx, v); err != nil {
+		return err
+	}
+
+	if err = insertRevision(tx, v); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteVASP record, removing it completely from the database and index tables.
+func (s *Store) DeleteVASP(id string) (err error) {
+	var tx *sql.Tx
+	if tx, err = s.db.Begin(); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Remove the index rows explicitly in case foreign keys are not enforced.
+	if err = removeIndices(tx, id); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM revisions WHERE vasp = ?`, id); err != nil {
+		return err
+	}
+
+	// SQLite will not return an error if the entity does not exist
+	if _, err = tx.Exec(`DELETE FROM vasps WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+//===========================================================================
+// CertificateStore Implementation
+//===========================================================================
+
+// ListCert returns all certificates that are currently in the store.
+func (s *Store) ListCerts() iterator.CertificateIterator {
+	return &certIterator{newRowsIterator(s.db, "certificates")}
+}
+
+// CreateCert and assign a new ID and return the version.
+func (s *Store) CreateCert(c *models.Certificate) (id string, err error) {
+	if c.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create UUID for record
+	c.Id = uuid.New().String()
+	if err = s.UpdateCert(c); err != nil {
+		return "", err
+	}
+	return c.Id, nil
+}
+
+// RetrieveCert returns a certificate by certificate ID.
+func (s *Store) RetrieveCert(id string) (c *models.Certificate, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM certificates WHERE id = ?`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	c = new(models.Certificate)
+	if err = proto.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// UpdateCert can create or update a certificate. The certificate should be as complete
+// as possible, including an ID generated by the caller.
+func (s *Store) UpdateCert(c *models.Certificate) (err error) {
+	if c.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(c); err != nil {
+		return err
+	}
+
+	if _, err = s.db.Exec(upsertCertSQL, certArgs(c, data)...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteCert removes a certificate from the store.
+func (s *Store) DeleteCert(id string) (err error) {
+	// SQLite will not return an error if the entity does not exist
+	if _, err = s.db.Exec(`DELETE FROM certificates WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RetrieveCertBySerial returns the certificate with the specified hex encoded serial
+// number; colons and whitespace in the serial number are ignored.
+func (s *Store) RetrieveCertBySerial(serial string) (c *models.Certificate, err error) {
+	var certs []*models.Certificate
+	if certs, err = s.selectCerts(`SELECT data FROM certificates WHERE serial_number = ? AND serial_number != '' LIMIT 1`, index.NormalizeSerialNumber(serial)); err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+	return certs[0], nil
+}
+
+// ListCertsByVASP returns all certificates issued to the specified VASP ordered by ID.
+func (s *Store) ListCertsByVASP(vaspID string) ([]*models.Certificate, error) {
+	return s.selectCerts(`SELECT data FROM certificates WHERE vasp = ? ORDER BY id`, vaspID)
+}
+
+// ListCertsByExpiration returns all certificates that expire on or after the after
+// timestamp and before the before timestamp, ordered by expiration (soonest first).
+// A zero timestamp leaves that side of the window unbounded.
+func (s *Store) ListCertsByExpiration(after, before time.Time) ([]*models.Certificate, error) {
+	query := `SELECT data FROM certificates WHERE not_after != ''`
+	args := make([]interface{}, 0, 2)
+	if !after.IsZero() {
+		query += ` AND not_after >= ?`
+		args = append(args, after.UTC().Format(time.RFC3339))
+	}
+
+	if !before.IsZero() {
+		query += ` AND not_after < ?`
+		args = append(args, before.UTC().Format(time.RFC3339))
+	}
+
+	query += ` ORDER BY not_after, id`
+	return s.selectCerts(query, args...)
+}
+
+//===========================================================================
+// CertificateRequestStore Implementation
+//===========================================================================
+
+// ListCertReqs returns all certificate requests that are currently in the store.
+func (s *Store) ListCertReqs() iterator.CertificateRequestIterator {
+	return &certReqIterator{newRowsIterator(s.db, "certreqs")}
+}
+
+// CreateCertReq and assign a new ID and return the version.
+func (s *Store) CreateCertReq(r *models.CertificateRequest) (id string, err error) {
+	if r.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create UUID for record
+	r.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	r.Created = time.Now().Format(time.RFC3339)
+	if r.Modified == "" {
+		r.Modified = r.Created
+	}
+
+	if err = s.putCertReq(r); err != nil {
+		return "", err
+	}
+	return r.Id, nil
+}
+
+// RetrieveCertReq returns a certificate request by certificate request ID.
+func (s *Store) RetrieveCertReq(id string) (r *models.CertificateRequest, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM certreqs WHERE id = ?`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.CertificateRequest)
+	if err = proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateCertReq can create or update a certificate request. The request should be as
+// complete as possible, including an ID generated by the caller.
+func (s *Store) UpdateCertReq(r *models.CertificateRequest) (err error) {
+	if r.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	r.Modified = time.Now().Format(time.RFC3339)
+	if r.Created == "" {
+		r.Created = r.Modified
+	}
+
+	return s.putCertReq(r)
+}
+
+// DeleteCertReq removes a certificate request from the store.
+func (s *Store) DeleteCertReq(id string) (err error) {
+	// SQLite will not return an error if the entity does not exist
+	if _, err = s.db.Exec(`DELETE FROM certreqs WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) putCertReq(r *models.CertificateRequest) (err error) {
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	if _, err = s.db.Exec(upsertCertReqSQL, r.Id, r.Vasp, r.CommonName, int32(r.Status), r.Created, r.Modified, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+//===========================================================================
+// PendingActionStore Implementation
+//===========================================================================
+
+// ListActions returns all pending actions that are currently in the store.
+func (s *Store) ListActions() iterator.PendingActionIterator {
+	return &actionIterator{newRowsIterator(s.db, "actions")}
+}
+
+// CreateAction and assign a new ID and return the version.
+func (s *Store) CreateAction(a *models.PendingAction) (id string, err error) {
+	if a.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create UUID for record
+	a.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	a.Created = time.Now().Format(time.RFC3339)
+	if a.Modified == "" {
+		a.Modified = a.Created
+	}
+
+	if err = s.putAction(a); err != nil {
+		return "", err
+	}
+	return a.Id, nil
+}
+
+// RetrieveAction returns a pending action by ID.
+func (s *Store) RetrieveAction(id string) (a *models.PendingAction, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM actions WHERE id = ?`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	a = new(models.PendingAction)
+	if err = proto.Unmarshal(data, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// UpdateAction can create or update a pending action. The action should be as complete
+// as possible, including an ID generated by the caller.
+func (s *Store) UpdateAction(a *models.PendingAction) (err error) {
+	if a.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	a.Modified = time.Now().Format(time.RFC3339)
+	if a.Created == "" {
+		a.Created = a.Modified
+	}
+
+	return s.putAction(a)
+}
+
+// DeleteAction removes a pending action from the store.
+func (s *Store) DeleteAction(id string) (err error) {
+	// SQLite will not return an error if the entity does not exist
+	if _, err = s.db.Exec(`DELETE FROM actions WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) putAction(a *models.PendingAction) (err error) {
+	var data []byte
+	if data, err = proto.Marshal(a); err != nil {
+		return err
+	}
+
+	if _, err = s.db.Exec(upsertActionSQL, a.Id, int32(a.Type), a.Vasp, int32(a.Status), a.Created, a.Modified, data); err != nil {
+		return err
+	}
+	return nil
+}
+
+//===========================================================================
+// AuditStore Implementation
+//===========================================================================
+
+// ListAuditRecords returns all of the records in the admin audit log, most recent first.
+func (s *Store) ListAuditRecords() iterator.AuditRecordIterator {
+	return &auditIterator{newRowsIterator(s.db, "audit")}
+}
+
+// CreateAuditRecord appends a record to the audit log, assigning it a new ID.
+func (s *Store) CreateAuditRecord(r *models.AuditRecord) (id string, err error) {
+	if r.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create a time ordered ID for the record
+	if r.Timestamp == "" {
+		r.Timestamp = time.Now().Format(time.RFC3339Nano)
+	}
+	r.Id = models.NewAuditRecordID(r)
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return "", err
+	}
+
+	if _, err = s.db.Exec(insertAuditSQL, r.Id, r.Timestamp, r.Actor, r.Target, data); err != nil {
+		return "", err
+	}
+	return r.Id, nil
+}
+
+// RetrieveAuditRecord returns an audit record by ID.
+func (s *Store) RetrieveAuditRecord(id string) (r *models.AuditRecord, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM audit WHERE id = ?`, id).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.AuditRecord)
+	if err = proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ImportVASP writes a VASP migrated from another store with its original ID, version
+// and timestamps without creating a revision. The VASP must not already exist.
+func (s *Store) ImportVASP(v *pb.VASP) (err error) {
+	if v.Id == "" || index.Normalize(v.CommonName) == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(v); err != nil {
+		return err
+	}
+
+	var tx *sql.Tx
+	if tx, err = s.db.Begin(); err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = checkUniqueName(tx, v); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(insertVASPSQL, vaspArgs(v, data)...); err != nil {
+		if isConstraintError(err) {
+			return storeerrors.ErrDuplicateEntity
+		}
+		return err
+	}
+
+	if err = insertIndices(tx, v); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ImportAuditRecord writes an audit record migrated from another store with its
+// original ID, replacing any record with the same ID.
+func (s *Store) ImportAuditRecord(r *models.AuditRecord) (err error) {
+	if r.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(upsertAuditSQL, r.Id, r.Timestamp, r.Actor, r.Target, data)
+	return err
+}
+
+//===========================================================================
+// RevisionStore Implementation
+//===========================================================================
+
+// ListRevisions returns the revisions of the specified VASP from oldest to newest.
+func (s *Store) ListRevisions(vaspID string) iterator.RevisionIterator {
+	return &revisionIterator{newFilteredRowsIterator(s.db, "revisions", "vasp", vaspID)}
+}
+
+// RetrieveRevision returns the specified revision of a VASP record.
+func (s *Store) RetrieveRevision(vaspID string, revision uint64) (r *models.VASPRevision, err error) {
+	if vaspID == "" || revision == 0 {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if err = s.db.QueryRow(`SELECT data FROM revisions WHERE vasp = ? AND revision = ?`, vaspID, revision).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, err
+	}
+
+	r = new(models.VASPRevision)
+	if err = proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ImportRevision writes a revision migrated from another store with its original
+// revision number, replacing any revision of the VASP with the same number.
+func (s *Store) ImportRevision(r *models.VASPRevision) (err error) {
+	if r.Vasp == "" || r.Revision == 0 {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(upsertRevisionSQL, revisionID(r.Vasp, r.Revision), r.Vasp, r.Revision, r.Created, data)
+	return err
+}
+
+// insertRevision stores the next revision of the VASP in the same transaction as the
+// record so that the revision history always reflects what is in the database.
+func insertRevision(tx *sql.Tx, v *pb.VASP) (err error) {
+	rev := &models.VASPRevision{Vasp: v.Id, Created: v.LastUpdated, Record: v}
+	if err = tx.QueryRow(`SELECT COALESCE(MAX(revision), 0) + 1 FROM revisions WHERE vasp = ?`, v.Id).Scan(&rev.Revision); err != nil {
+		return err
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(rev); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(insertRevisionSQL, revisionID(v.Id, rev.Revision), rev.Vasp, rev.Revision, rev.Created, data); err != nil {
+		if isConstraintError(err) {
+			return storeerrors.ErrConcurrentUpdate
+		}
+		return err
+	}
+	return nil
+}
+
+// revisionID zero pads the revision number so that revisions are sorted in order.
+func revisionID(vaspID string, revision uint64) string {
+	return fmt.Sprintf("%s:%020d", vaspID, revision)
+}
+
+//===========================================================================
+// Backup
+//===========================================================================
+
+// Backup writes a consistent copy of the SQLite database to a new directory using
+// VACUUM INTO and archives it as gzip tar.
+func (s *Store) Backup(path string) (err error) {
+	// Create the directory for the copied sqlite database
+	archive := filepath.Join(path, time.Now().UTC().Format("gdsdb-200601021504"))
+	if err = os.Mkdir(archive, 0744); err != nil {
+		return fmt.Errorf("could not create archive directory: %s", err)
+	}
+
+	// Ensure the archive directory is cleaned up when the backup is complete
+	defer func() {
+		os.RemoveAll(archive)
+	}()
+
+	// VACUUM INTO creates a transactionally consistent, compacted copy of the database
+	dst := filepath.Join(archive, filepath.Base(s.path))
+	if _, err = s.db.Exec(`VACUUM INTO ?`, dst); err != nil {
+		return fmt.Errorf("could not copy sqlite database to archive: %s", err)
+	}
+	log.Info().Str("path", dst).Msg("sqlite archive complete")
+
+	if err = utils.WriteGzip(archive, archive+".tgz"); err != nil {
+		return fmt.Errorf("could not create gzip tar: %s", err)
+	}
+	return nil
+}
+
+//===========================================================================
+// Helpers
+//===========================================================================
+
+// minimum length of a name query before a prefix match is performed (matches index)
+const searchPrefixMinLength = 3
+
+const (
+	insertVASPSQL = `INSERT INTO vasps (
+		id, common_name, website, registered_directory, business_category,
+		verification_status, verified_on, first_listed, last_updated, version,
+		archived, sort_name, cert_expires, data
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	updateVASPSQL = `UPDATE vasps SET
+		common_name=?2, website=?3, registered_directory=?4, business_category=?5,
+		verification_status=?6, verified_on=?7, first_listed=?8, last_updated=?9,
+		version=?10, archived=?11, sort_name=?12, cert_expires=?13, data=?14
+	WHERE id=?1`
+
+	upsertCertSQL = `INSERT INTO certificates (id, request, vasp, status, serial_number, not_after, data)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+		request=excluded.request, vasp=excluded.vasp, status=excluded.status,
+		serial_number=excluded.serial_number, not_after=excluded.not_after, data=excluded.data`
+
+	upsertCertReqSQL = `INSERT INTO certreqs (id, vasp, common_name, status, created, modified, data)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+		vasp=excluded.vasp, common_name=excluded.common_name, status=excluded.status,
+		created=excluded.created, modified=excluded.modified, data=excluded.data`
+
+	upsertActionSQL = `INSERT INTO actions (id, type, vasp, status, created, modified, data)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (id) DO UPDATE SET
+		type=excluded.type, vasp=excluded.vasp, status=excluded.status,
+		created=excluded.created, modified=excluded.modified, data=excluded.data`
+
+	// Audit records are append-only so there is no upsert
+	insertAuditSQL = `INSERT INTO audit (id, timestamp, actor, target, data) VALUES (?, ?, ?, ?, ?)`
+
+	// Revisions are immutable so they are only upserted when imported by a migration
+	insertRevisionSQL = `INSERT INTO revisions (id, vasp, revision, created, data) VALUES (?, ?, ?, ?, ?)`
+
+	// Audit records and revisions imported by a migration replace existing records
+	upsertAuditSQL    = `INSERT OR REPLACE INTO audit (id, timestamp, actor, target, data) VALUES (?, ?, ?, ?, ?)`
+	upsertRevisionSQL = `INSERT OR REPLACE INTO revisions (id, vasp, revision, created, data) VALUES (?, ?, ?, ?, ?)`
+)
+
+// vaspArgs returns the column values of the vasps table in insert order.
+func vaspArgs(v *pb.VASP, data []byte) []interface{} {
+	return []interface{}{
+		v.Id,
+		index.Normalize(v.CommonName),
+		index.NormalizeURL(v.Website),
+		v.RegisteredDirectory,
+		v.BusinessCategory.String(),
+		int32(v.VerificationStatus),
+		v.VerifiedOn,
+		v.FirstListed,
+		v.LastUpdated,
+		int64(v.Version.Version),
+		models.IsArchived(v),
+		search.SortName(v),
+		v.IdentityCertificate.GetNotAfter(),
+		data,
+	}
+}
+
+// certArgs returns the column values of the certificates table in insert order.
+func certArgs(c *models.Certificate, data []byte) []interface{} {
+	var serial, notAfter string
+	if c.Details != nil {
+		serial = index.NormalizeSerialNumber(hex.EncodeToString(c.Details.SerialNumber))
+		if ts, err := time.Parse(time.RFC3339, c.Details.NotAfter); err == nil {
+			notAfter = ts.UTC().Format(time.RFC3339)
+		}
+	}
+	return []interface{}{c.Id, c.Request, c.Vasp, int32(c.Status), serial, notAfter, data}
+}
+
+// checkUniqueName ensures that the common name of the VASP is not used by another VASP.
+func checkUniqueName(tx *sql.Tx, v *pb.VASP) (err error) {
+	var owner string
+	if err = tx.QueryRow(`SELECT vasp_id FROM vasp_names WHERE name = ?`, index.Normalize(v.CommonName)).Scan(&owner); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	if owner != v.Id {
+		return storeerrors.ErrDuplicateEntity
+	}
+	return nil
+}
+
+// insertIndices adds the names, countries, and categories of the VASP to the lookup
+// tables. Like the in-memory indices, names that belong to another VASP are ignored
+// and archived VASPs do not reserve their names so that they can be registered again.
+func insertIndices(tx *sql.Tx, v *pb.VASP) (err error) {
+	var names []string
+	if !models.IsArchived(v) {
+		names = append([]string{v.CommonName}, v.Entity.Names()...)
+	}
+	for _, name := range names {
+		if name = index.Normalize(name); name == "" {
+			continue
+		}
+		if _, err = tx.Exec(`INSERT OR IGNORE INTO vasp_names (name, vasp_id) VALUES (?, ?)`, name, v.Id); err != nil {
+			return err
+		}
+	}
+
+	countries := []string{v.Entity.GetCountryOfRegistration()}
+	for _, addr := range v.Entity.GetGeographicAddresses() {
+		countries = append(countries, addr.Country)
+	}
+	for _, country := range countries {
+		if country = index.NormalizeCountry(country); country == "" {
+			continue
+		}
+		if _, err = tx.Exec(`INSERT OR IGNORE INTO vasp_countries (country, vasp_id) VALUES (?, ?)`, country, v.Id); err != nil {
+			return err
+		}
+	}
+
+	categories := []string{v.BusinessCategory.String()}
+	categories = append(categories, v.VaspCategories...)
+	for _, category := range categories {
+		if category = index.Normalize(category); category == "" {
+			continue
+		}
+		if _, err = tx.Exec(`INSERT OR IGNORE INTO vasp_categories (category, vasp_id) VALUES (?, ?)`, category, v.Id); err != nil {
+			return err
+		}
+	}
+
+	// Archived VASPs remain in the full text index so that they can be searched by admins
+	var tokens []string
+	for _, text := range index.SearchText(v) {
+		tokens = append(tokens, index.Tokenize(text)...)
+	}
+	if len(tokens) > 0 {
+		if _, err = tx.Exec(`INSERT INTO vasp_text (vasp_id, text) VALUES (?, ?)`, v.Id, strings.Join(tokens, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeIndices deletes all lookup table rows that refer to the specified VASP.
+func removeIndices(tx *sql.Tx, id string) (err error) {
+	for _, table := range []string{"vasp_names", "vasp_countries", "vasp_categories", "vasp_text"} {
+		if _, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE vasp_id = ?`, table), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectCerts executes a query that returns a single column of certificate data.
+func (s *Store) selectCerts(query string, args ...interface{}) (certs []*models.Certificate, err error) {
+	var rows *sql.Rows
+	if rows, err = s.db.Query(query, args...); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	certs = make([]*models.Certificate, 0)
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		c := new(models.Certificate)
+		if err = proto.Unmarshal(data, c); err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, rows.Err()
+}
+
+// selectIDs executes a query that returns a single column of record IDs.
+func (s *Store) selectIDs(query string, args ...interface{}) (ids []string, err error) {
+	var rows *sql.Rows
+	if rows, err = s.db.Query(query, args...); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// filterIDs removes any records from the set that are not returned by the query.
+func (s *Store) filterIDs(records map[string]struct{}, query string, args ...interface{}) (err error) {
+	var ids []string
+	if ids, err = s.selectIDs(query, args...); err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		found[id] = struct{}{}
+	}
+
+	for record := range records {
+		if _, ok := found[record]; !ok {
+			// NOTE: safe to remove during map iteration
+			delete(records, record)
+		}
+	}
+	return nil
+}
+
+// isConstraintError returns true if the error is a SQLite constraint violation.
+func isConstraintError(err error) bool {
+	var serr *sqlite.Error
+	if errors.As(err, &serr) {
+		// The primary result code is in the least significant byte of extended codes
+		return serr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT
+	}
+	return strings.Contains(err.Error(), "constraint failed")
+}
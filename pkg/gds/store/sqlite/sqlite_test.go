@@ -0,0 +1,865 @@
+package sqlite
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
+	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
+	"github.com/trisacrypto/directory/pkg/utils/logger"
+	"github.com/trisacrypto/trisa/pkg/ivms101"
+	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type sqliteTestSuite struct {
+	suite.Suite
+	path string
+	db   *Store
+}
+
+func (s *sqliteTestSuite) SetupSuite() {
+	// Discard logging from the application to focus on test logs
+	// NOTE: ConsoleLog MUST be false otherwise this will be overriden
+	logger.Discard()
+
+	path, err := ioutil.TempDir("", "gdssqlitestore-*")
+	s.NoError(err)
+
+	// Open the database in a temp directory
+	s.path = path
+	s.db, err = Open(filepath.Join(path, "gds.db"))
+	s.NoError(err)
+}
+
+func (s *sqliteTestSuite) TearDownSuite() {
+	// Delete the temp directory when done
+	s.NoError(s.db.Close())
+	err := os.RemoveAll(s.path)
+	s.NoError(err)
+	logger.ResetLogger()
+}
+
+func TestSQLite(t *testing.T) {
+	suite.Run(t, new(sqliteTestSuite))
+}
+
+func (s *sqliteTestSuite) TestDirectoryStore() {
+	// Load the VASP record from testdata
+	data, err := ioutil.ReadFile("../testdata/vasp.json")
+	s.NoError(err)
+
+	alice := &pb.VASP{}
+	err = protojson.Unmarshal(data, alice)
+	s.NoError(err)
+
+	// Validate the VASP record loaded correctly and is partial
+	s.NotEmpty(alice.CommonName)
+	s.NotEmpty(alice.TrisaEndpoint)
+	s.NoError(alice.Validate(true))
+	s.Empty(alice.Id)
+
+	// Attempt to Create the VASP
+	id, err := s.db.CreateVASP(alice)
+	s.NoError(err)
+	s.NotEmpty(id)
+
+	// Attempt to Retrieve the VASP
+	alicer, err := s.db.RetrieveVASP(id)
+	s.NoError(err)
+	s.Equal(id, alicer.Id)
+	s.Equal(alicer.FirstListed, alicer.LastUpdated)
+	s.NotEmpty(alicer.LastUpdated)
+	s.NotEmpty(alicer.Version)
+	s.Equal(uint64(1), alicer.Version.Version)
+
+	// Ensure the modification time rolls over to the next second for comparison
+	time.Sleep(1 * time.Second)
+
+	// Update the VASP
+	alicer.Entity.Name.NameIdentifiers[0].LegalPersonName = "AliceLiteCoin, LLC"
+	alicer.VerificationStatus = pb.VerificationState_VERIFIED
+	alicer.VerifiedOn = "2021-06-30T10:40:40Z"
+	err = s.db.UpdateVASP(alicer)
+	s.NoError(err)
+
+	alicer, err = s.db.RetrieveVASP(id)
+	s.NoError(err)
+	s.Equal(id, alicer.Id)
+	s.NotEmpty(alicer.LastUpdated)
+	s.NotEqual(alicer.FirstListed, alicer.LastUpdated)
+	s.NotEmpty(alicer.Version)
+	s.Equal(uint64(2), alicer.Version.Version)
+	s.Equal(alicer.VerificationStatus, pb.VerificationState_VERIFIED)
+
+	// Delete the VASP
+	err = s.db.DeleteVASP(id)
+	s.NoError(err)
+	alicer, err = s.db.RetrieveVASP(id)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	s.Empty(alicer)
+
+	// Add a few more VASPs
+	for i := 0; i < 10; i++ {
+		vasp := &pb.VASP{
+			Entity: &ivms101.LegalPerson{
+				Name: &ivms101.LegalPersonName{
+					NameIdentifiers: []*ivms101.LegalPersonNameId{
+						{
+							LegalPersonName:               fmt.Sprintf("Test %d", i+1),
+							LegalPersonNameIdentifierType: ivms101.LegalPersonLegal,
+						},
+					},
+				},
+			},
+			CommonName: fmt.Sprintf("trisa%d.test.net", i+1),
+		}
+		_, err := s.db.CreateVASP(vasp)
+		s.NoError(err)
+	}
+
+	// Test listing all of the VASPs
+	reqs, err := s.db.ListVASPs().All()
+	s.NoError(err)
+	s.Len(reqs, 10)
+
+	// Test iterating over all the VASPs
+	var niters int
+	iter := s.db.ListVASPs()
+	for iter.Next() {
+		s.NotEmpty(iter.VASP())
+		niters++
+	}
+	s.NoError(iter.Error())
+	iter.Release()
+	s.Equal(10, niters)
+
+	// Test seeking to a VASP in the middle of the table
+	vasps, err := s.db.ListVASPs().All()
+	s.NoError(err)
+	iter = s.db.ListVASPs()
+	s.True(iter.SeekId(vasps[5].Id))
+	s.Equal(vasps[5].Id, iter.Id())
+	iter.Prev()
+
+	niters = 0
+	for iter.Next() {
+		niters++
+	}
+	s.NoError(iter.Error())
+	iter.Release()
+	s.Equal(5, niters)
+
+	// Cleanup the VASPs for the next tests
+	for _, vasp := range vasps {
+		s.NoError(s.db.DeleteVASP(vasp.Id))
+	}
+}
+
+func (s *sqliteTestSuite) TestSearchVASPs() {
+	// Load the VASP record from testdata
+	data, err := ioutil.ReadFile("../testdata/vasp.json")
+	s.NoError(err)
+
+	alice := &pb.VASP{}
+	err = protojson.Unmarshal(data, alice)
+	s.NoError(err)
+
+	id, err := s.db.CreateVASP(alice)
+	s.NoError(err)
+	defer s.db.DeleteVASP(id)
+
+	// A second VASP with the same common name violates the uniqueness constraint
+	_, err = s.db.CreateVASP(&pb.VASP{CommonName: alice.CommonName})
+	s.ErrorIs(err, storeerrors.ErrDuplicateEntity)
+
+	// Search by exact name, name prefix, and website
+	for _, query := range []map[string]interface{}{
+		{"name": alice.CommonName},
+		{"name": alice.Entity.Names()[0]},
+		{"name": alice.CommonName[:4]},
+		{"website": alice.Website},
+		{"name": []string{"unknown", alice.CommonName}},
+	} {
+		vasps, err := s.db.SearchVASPs(query)
+		s.NoError(err)
+		s.Len(vasps, 1, "query %v", query)
+		s.Equal(id, vasps[0].Id)
+	}
+
+	// Full text search tolerates typos if fuzzy matching is requested
+	vasps, err := s.db.SearchVASPs(map[string]interface{}{"text": "alicecoin"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"text": "alicecion"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"text": "alicecion", "fuzzy": true})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	// Filter by country and category
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": alice.CommonName, "country": alice.Entity.CountryOfRegistration})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": alice.CommonName, "category": alice.BusinessCategory.String()})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": alice.CommonName, "country": "ZZ"})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	// Prefixes shorter than 3 characters are not matched
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": alice.CommonName[:2]})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	// Updating the VASP should update the lookup tables
+	alice, err = s.db.RetrieveVASP(id)
+	s.NoError(err)
+	commonName := alice.CommonName
+	alice.CommonName = "bob.example.com"
+	s.NoError(s.db.UpdateVASP(alice))
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": "bob.example.com"})
+	s.NoError(err)
+	s.Len(vasps, 1)
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": commonName})
+	s.NoError(err)
+	s.Len(vasps, 0)
+
+	// Archived VASPs do not reserve their names so the VASP can be registered again
+	s.NoError(models.ArchiveVASP(alice, "admin@example.com"))
+	s.NoError(s.db.UpdateVASP(alice))
+
+	duplicate, err := s.db.CreateVASP(&pb.VASP{CommonName: alice.CommonName, Entity: alice.Entity})
+	s.NoError(err)
+
+	// The archived VASP cannot be restored while its name is in use
+	s.NoError(models.RestoreVASP(alice, "admin@example.com"))
+	s.ErrorIs(s.db.UpdateVASP(alice), storeerrors.ErrDuplicateEntity)
+
+	s.NoError(s.db.DeleteVASP(duplicate))
+	alice, err = s.db.RetrieveVASP(id)
+	s.NoError(err)
+	s.NoError(models.RestoreVASP(alice, "admin@example.com"))
+	s.NoError(s.db.UpdateVASP(alice))
+
+	vasps, err = s.db.SearchVASPs(map[string]interface{}{"name": alice.CommonName})
+	s.NoError(err)
+	s.Len(vasps, 1)
+}
+
+func (s *sqliteTestSuite) TestQueryVASPs() {
+	require := s.Require()
+
+	names := []string{"Charlie Bank", "Alpha Exchange", "Bravo Trading", "Delta Exchange", "Echo Wallet"}
+	for i, name := range names {
+		vasp := &pb.VASP{
+			Entity: &ivms101.LegalPerson{
+				Name: &ivms101.LegalPersonName{
+					NameIdentifiers: []*ivms101.LegalPersonNameId{
+						{LegalPersonName: name, LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+					},
+				},
+				CountryOfRegistration: "US",
+			},
+			CommonName:         fmt.Sprintf("trisa%d.example.com", i),
+			Website:            fmt.Sprintf("https://vasp%d.example.com", i),
+			VerificationStatus: pb.VerificationState_VERIFIED,
+			VerifiedOn:         time.Date(2022, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		}
+
+		if i%2 == 1 {
+			vasp.VerificationStatus = pb.VerificationState_SUBMITTED
+			vasp.Entity.CountryOfRegistration = "DE"
+		}
+
+		id, err := s.db.CreateVASP(vasp)
+		require.NoError(err)
+		defer s.db.DeleteVASP(id)
+	}
+
+	// Archive the Echo Wallet so that it is only returned by archived queries
+	page, err := s.db.QueryVASPs(&search.Query{Names: []string{"Echo Wallet"}})
+	require.NoError(err)
+	require.Len(page.Results, 1)
+	echo := page.Results[0].VASP
+	require.NoError(models.ArchiveVASP(echo, "admin@example.com"))
+	require.NoError(s.db.UpdateVASP(echo))
+
+	// The SQL query and the scan should return the same results; text queries are
+	// ordered by name since the full text index ranks records differently.
+	queries := []*search.Query{
+		{},
+		{PageSize: 2},
+		{OrderBy: search.OrderName},
+		{OrderBy: search.OrderName, PageSize: 2, Page: 2},
+		{Text: "exchange", OrderBy: search.OrderName},
+		{Text: "exch", OrderBy: search.OrderName},
+		{Text: "exchnge", Fuzzy: true, OrderBy: search.OrderName},
+		{Names: []string{"Bravo Trading", "delta"}},
+		{Websites: []string{"https://vasp4.example.com"}},
+		{Websites: []string{"https://vasp3.example.com"}, Names: []string{"charlie"}},
+		{Countries: []string{"DE"}, OrderBy: search.OrderName},
+		{Categories: []string{"UNKNOWN_ENTITY"}},
+		{VerificationStatus: []pb.VerificationState{pb.VerificationState_VERIFIED}, OrderBy: search.OrderName},
+		{VerifiedOn: search.TimeRange{After: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC), Before: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)}},
+		{Archived: true},
+		{Archived: true, Text: "wallet"},
+	}
+
+	for i, query := range queries {
+		queried, err := s.db.QueryVASPs(query)
+		require.NoError(err, "query %d failed", i)
+
+		scanned, err := search.Scan(query, s.db.ListVASPs())
+		require.NoError(err, "scan %d failed", i)
+
+		if scanned.Total >= 0 {
+			require.Equal(scanned.Total, queried.Total, "query %d returned a different total", i)
+		}
+		require.Len(queried.Results, len(scanned.Results), "query %d returned a different number of results", i)
+		require.Equal(scanned.NextPageToken == "", queried.NextPageToken == "", "query %d returned a different next page", i)
+		for j := range queried.Results {
+			require.Equal(scanned.Results[j].VASP.Id, queried.Results[j].VASP.Id, "query %d returned different results", i)
+		}
+	}
+
+	// Exact name matches are ranked above prefix matches
+	page, err = s.db.QueryVASPs(&search.Query{Names: []string{"Bravo Trading", "delta"}})
+	require.NoError(err)
+	require.Equal(2, page.Total)
+	require.Equal("trisa2.example.com", page.Results[0].VASP.CommonName)
+	require.Greater(page.Results[0].Score, page.Results[1].Score)
+
+	// Full text matches are ranked by relevance
+	page, err = s.db.QueryVASPs(&search.Query{Text: "delta exchange"})
+	require.NoError(err)
+	require.Equal(2, page.Total)
+	require.Equal("trisa3.example.com", page.Results[0].VASP.CommonName)
+
+	// Iterate over all pages with page tokens
+	query := &search.Query{OrderBy: search.OrderName, PageSize: 3}
+	page, err = s.db.QueryVASPs(query)
+	require.NoError(err)
+	require.Equal(4, page.Total)
+	require.Len(page.Results, 3)
+	require.NotEmpty(page.NextPageToken)
+
+	query.PageToken = page.NextPageToken
+	page, err = s.db.QueryVASPs(query)
+	require.NoError(err)
+	require.Len(page.Results, 1)
+	require.Empty(page.NextPageToken)
+	require.Equal("trisa3.example.com", page.Results[0].VASP.CommonName)
+
+	// Page size cannot change between requests and invalid tokens are rejected
+	_, err = s.db.QueryVASPs(&search.Query{PageSize: 2, PageToken: query.PageToken})
+	require.ErrorIs(err, search.ErrPageSizeChanged)
+	_, err = s.db.QueryVASPs(&search.Query{PageToken: "123"})
+	require.ErrorIs(err, search.ErrInvalidPageToken)
+}
+
+func (s *sqliteTestSuite) TestBackup() {
+	path, err := ioutil.TempDir("", "gdssqlitebackup-*")
+	s.NoError(err)
+	defer os.RemoveAll(path)
+
+	s.NoError(s.db.Backup(path))
+
+	archives, err := filepath.Glob(filepath.Join(path, "gdsdb-*.tgz"))
+	s.NoError(err)
+	s.Len(archives, 1)
+}
+
+func (s *sqliteTestSuite) TestCertificateStore() {
+	// Load the VASP record from testdata
+	data, err := ioutil.ReadFile("../testdata/cert.json")
+	s.NoError(err)
+
+	cert := &models.Certificate{}
+	err = protojson.Unmarshal(data, cert)
+	s.NoError(err)
+
+	// Verify the certificate is loaded correctly
+	s.Empty(cert.Id)
+	s.NotEmpty(cert.Request)
+	s.NotEmpty(cert.Vasp)
+	s.Equal(models.CertificateState_ISSUED, cert.Status)
+	s.NotEmpty(cert.Details)
+	s.NotEmpty(cert.Details.NotBefore)
+	s.NotEmpty(cert.Details.NotAfter)
+
+	// Attempt to Create the Cert
+	id, err := s.db.CreateCert(cert)
+	s.NoError(err)
+
+	// Attempt to Retrieve the Cert
+	crr, err := s.db.RetrieveCert(id)
+	s.NoError(err)
+	s.Equal(id, crr.Id)
+	s.Equal(cert.Request, crr.Request)
+	s.Equal(cert.Vasp, crr.Vasp)
+	s.Equal(cert.Status, crr.Status)
+	s.True(proto.Equal(cert.Details, crr.Details))
+
+	// Attempt to save a certificate with an ID on it
+	icrr := &models.Certificate{
+		Id:      uuid.New().String(),
+		Request: crr.Request,
+		Vasp:    crr.Vasp,
+		Status:  models.CertificateState_ISSUED,
+		Details: crr.Details,
+	}
+	_, err = s.db.CreateCert(icrr)
+	s.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Update the Cert
+	crr.Status = models.CertificateState_REVOKED
+	err = s.db.UpdateCert(crr)
+	s.NoError(err)
+
+	crr, err = s.db.RetrieveCert(id)
+	s.NoError(err)
+	s.Equal(id, crr.Id)
+	s.Equal(models.CertificateState_REVOKED, crr.Status)
+
+	// Attempt to update a certificate with no Id on it
+	cert.Id = ""
+	s.ErrorIs(s.db.UpdateCert(cert), storeerrors.ErrIncompleteRecord)
+
+	// Delete the Cert
+	err = s.db.DeleteCert(id)
+	s.NoError(err)
+	crr, err = s.db.RetrieveCert(id)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	s.Empty(crr)
+
+	// Add a few more certificates
+	for i := 0; i < 10; i++ {
+		crr := &models.Certificate{
+			Request: uuid.New().String(),
+			Vasp:    uuid.New().String(),
+			Status:  models.CertificateState_ISSUED,
+			Details: &pb.Certificate{
+				SerialNumber: []byte(uuid.New().String()),
+			},
+		}
+		_, err := s.db.CreateCert(crr)
+		s.NoError(err)
+	}
+
+	// Test listing all of the certificates
+	certs, err := s.db.ListCerts().All()
+	s.NoError(err)
+	s.Len(certs, 10)
+
+	// Test iterating over all the certificates
+	var niters int
+	iter := s.db.ListCerts()
+	for iter.Next() {
+		s.NotEmpty(iter.Cert())
+		niters++
+	}
+	s.NoError(iter.Error())
+	iter.Release()
+	s.Equal(10, niters)
+}
+
+func (s *sqliteTestSuite) TestCertificateIndices() {
+	require := s.Require()
+	vaspID := uuid.New().String()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Create certificates that expire in 10, 20, and 40 days
+	ids := make([]string, 0, 3)
+	for i, days := range []int{20, 10, 40} {
+		cert := &models.Certificate{
+			Request: uuid.New().String(),
+			Vasp:    vaspID,
+			Status:  models.CertificateState_ISSUED,
+			Details: &pb.Certificate{
+				SerialNumber: []byte{0x0a, 0x1b, byte(i)},
+				NotAfter:     now.AddDate(0, 0, days).Format(time.RFC3339),
+			},
+		}
+
+		id, err := s.db.CreateCert(cert)
+		require.NoError(err)
+		ids = append(ids, id)
+	}
+
+	// Lookup a certificate by serial number
+	cert, err := s.db.RetrieveCertBySerial("0a:1b:01")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	_, err = s.db.RetrieveCertBySerial("0A1B03")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Lookup certificates by VASP
+	certs, err := s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 3)
+
+	certs, err = s.db.ListCertsByVASP(uuid.New().String())
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	// Lookup certificates that expire in the next 30 days, soonest first
+	certs, err = s.db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 2)
+	require.Equal(ids[1], certs[0].Id)
+	require.Equal(ids[0], certs[1].Id)
+
+	// Updating a certificate should update the indices
+	cert.Vasp = uuid.New().String()
+	cert.Details.SerialNumber = []byte{0x0a, 0x1b, 0x03}
+	cert.Details.NotAfter = now.AddDate(0, 0, 60).Format(time.RFC3339)
+	require.NoError(s.db.UpdateCert(cert))
+
+	_, err = s.db.RetrieveCertBySerial("0A1B01")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	cert, err = s.db.RetrieveCertBySerial("0A1B03")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	certs, err = s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 2)
+
+	certs, err = s.db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 1)
+	require.Equal(ids[0], certs[0].Id)
+
+	// Deleting certificates should remove them from the indices
+	for _, id := range ids {
+		require.NoError(s.db.DeleteCert(id))
+	}
+
+	_, err = s.db.RetrieveCertBySerial("0A1B00")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	certs, err = s.db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	certs, err = s.db.ListCertsByExpiration(now, time.Time{})
+	require.NoError(err)
+	require.Len(certs, 0)
+}
+
+func (s *sqliteTestSuite) TestCertificateRequestStore() {
+	// Load the VASP record from testdata
+	data, err := ioutil.ReadFile("../testdata/certreq.json")
+	s.NoError(err)
+
+	certreq := &models.CertificateRequest{}
+	err = protojson.Unmarshal(data, certreq)
+	s.NoError(err)
+
+	// Verify the certificate request is loaded correctly
+	s.Empty(certreq.Id)
+	s.NotEmpty(certreq.Vasp)
+	s.NotEmpty(certreq.CommonName)
+	s.Equal(models.CertificateRequestState_INITIALIZED, certreq.Status)
+	s.Empty(certreq.Created)
+	s.Empty(certreq.Modified)
+
+	// Attempt to Create the CertReq
+	id, err := s.db.CreateCertReq(certreq)
+	s.NoError(err)
+
+	// Attempt to Retrieve the CertReq
+	crr, err := s.db.RetrieveCertReq(id)
+	s.NoError(err)
+	s.Equal(id, crr.Id)
+	s.NotEmpty(crr.Created)
+	s.Equal(crr.Modified, crr.Created)
+	s.Equal(certreq.Vasp, crr.Vasp)
+	s.Equal(certreq.CommonName, crr.CommonName)
+
+	// Attempt to save a certificate request with an ID on it
+	icrr := &models.CertificateRequest{
+		Id:         uuid.New().String(),
+		Vasp:       crr.Vasp,
+		CommonName: crr.CommonName,
+		Status:     models.CertificateRequestState_INITIALIZED,
+	}
+	_, err = s.db.CreateCertReq(icrr)
+	s.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Sleep for a second to roll over the clock for the modified time stamp
+	time.Sleep(1 * time.Second)
+
+	// Update the CertReq
+	crr.Status = models.CertificateRequestState_COMPLETED
+	err = s.db.UpdateCertReq(crr)
+	s.NoError(err)
+
+	crr, err = s.db.RetrieveCertReq(id)
+	s.NoError(err)
+	s.Equal(id, crr.Id)
+	s.Equal(models.CertificateRequestState_COMPLETED, crr.Status)
+	s.NotEmpty(crr.Modified)
+	s.NotEqual(crr.Modified, crr.Created)
+
+	// Attempt to update a certificate request with no Id on it
+	certreq.Id = ""
+	s.ErrorIs(s.db.UpdateCertReq(certreq), storeerrors.ErrIncompleteRecord)
+
+	// Delete the CertReq
+	err = s.db.DeleteCertReq(id)
+	s.NoError(err)
+	crr, err = s.db.RetrieveCertReq(id)
+	s.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	s.Empty(crr)
+
+	// Add a few more certificate requests
+	for i := 0; i < 10; i++ {
+		crr := &models.CertificateRequest{
+			Vasp:       uuid.New().String(),
+			CommonName: fmt.Sprintf("trisa%d.example.com", i+1),
+			Status:     models.CertificateRequestState_COMPLETED,
+		}
+		_, err := s.db.CreateCertReq(crr)
+		s.NoError(err)
+	}
+
+	// Test listing all of the certificates
+	reqs, err := s.db.ListCertReqs().All()
+	s.NoError(err)
+	s.Len(reqs, 10)
+
+	// Test iterating over all the certificates
+	var niters int
+	iter := s.db.ListCertReqs()
+	for iter.Next() {
+		s.NotEmpty(iter.CertReq())
+		niters++
+	}
+	s.NoError(iter.Error())
+	iter.Release()
+	s.Equal(10, niters)
+}
+
+func (s *sqliteTestSuite) TestPendingActionStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve an action that doesn't exist
+	_, err := s.db.RetrieveAction(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	action := &models.PendingAction{
+		Type:       models.PendingActionType_DELETE_VASP,
+		Vasp:       uuid.New().String(),
+		ProposedBy: "alice@example.com",
+		Expires:    time.Now().Add(72 * time.Hour).Format(time.RFC3339),
+	}
+
+	// Attempt to Create the action
+	id, err := s.db.CreateAction(action)
+	require.NoError(err)
+
+	// Attempt to Retrieve the action
+	a, err := s.db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(id, a.Id)
+	require.Equal(models.PendingActionState_ACTION_PROPOSED, a.Status)
+	require.Equal(action.Vasp, a.Vasp)
+	require.Equal(action.ProposedBy, a.ProposedBy)
+	require.NotEmpty(a.Created)
+	require.Equal(a.Modified, a.Created)
+
+	// Cannot create an action with an ID on it
+	_, err = s.db.CreateAction(&models.PendingAction{Id: uuid.New().String()})
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Sleep for a second to roll over the clock for the modified time stamp
+	time.Sleep(1 * time.Second)
+
+	// Update the action
+	a.Status = models.PendingActionState_ACTION_EXECUTED
+	a.ResolvedBy = "bob@example.com"
+	require.NoError(s.db.UpdateAction(a))
+
+	a, err = s.db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(models.PendingActionState_ACTION_EXECUTED, a.Status)
+	require.Equal("bob@example.com", a.ResolvedBy)
+	require.NotEqual(a.Modified, a.Created)
+
+	// Cannot update an action without an ID
+	require.ErrorIs(s.db.UpdateAction(&models.PendingAction{}), storeerrors.ErrIncompleteRecord)
+
+	// Add a few more actions and list them
+	for i := 0; i < 5; i++ {
+		_, err = s.db.CreateAction(&models.PendingAction{Type: models.PendingActionType_REJECT_REGISTRATION, Vasp: uuid.New().String()})
+		require.NoError(err)
+	}
+
+	actions, err := s.db.ListActions().All()
+	require.NoError(err)
+	require.Len(actions, 6)
+
+	// Delete the action
+	require.NoError(s.db.DeleteAction(id))
+	_, err = s.db.RetrieveAction(id)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+}
+
+func (s *sqliteTestSuite) TestAuditStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve a record that doesn't exist
+	_, err := s.db.RetrieveAuditRecord(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	record := &models.AuditRecord{
+		Actor:     "alice@example.com",
+		Action:    "DELETE /v2/vasps/:vaspID",
+		Target:    uuid.New().String(),
+		RequestId: uuid.New().String(),
+		Status:    200,
+		Changes: []*models.FieldChange{
+			{Path: "vasp", Before: `{"id":"42"}`},
+		},
+	}
+
+	// Attempt to Create the record
+	id, err := s.db.CreateAuditRecord(record)
+	require.NoError(err)
+	require.NotEmpty(record.Timestamp)
+
+	// Attempt to Retrieve the record
+	r, err := s.db.RetrieveAuditRecord(id)
+	require.NoError(err)
+	require.True(proto.Equal(record, r))
+
+	// Cannot append a record that already has an ID
+	_, err = s.db.CreateAuditRecord(r)
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Add a few more records recorded an hour apart and list them, most recent first
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		ts := now.Add(-time.Duration(i+1) * time.Hour).Format(time.RFC3339Nano)
+		_, err = s.db.CreateAuditRecord(&models.AuditRecord{Timestamp: ts, Actor: "bob@example.com", Action: "POST /v2/vasps/:vaspID/notes"})
+		require.NoError(err)
+	}
+
+	records, err := s.db.ListAuditRecords().All()
+	require.NoError(err)
+	require.Len(records, 6)
+	require.Equal(id, records[0].Id)
+	for i, record := range records[1:] {
+		require.Equal(now.Add(-time.Duration(i+1)*time.Hour).Format(time.RFC3339Nano), record.Timestamp)
+	}
+
+	// Seek to the records created before a timestamp
+	iter := s.db.ListAuditRecords()
+	require.True(iter.SeekTimestamp(now.Add(-150 * time.Minute)))
+	r, err = iter.Record()
+	require.NoError(err)
+	require.Equal(records[3].Id, r.Id)
+	require.True(iter.Next())
+	require.True(iter.Next())
+	require.False(iter.Next())
+	require.NoError(iter.Error())
+	iter.Release()
+}
+
+func (s *sqliteTestSuite) TestRevisionStore() {
+	require := s.Require()
+	// Should get a not found error trying to retrieve a revision that doesn't exist
+	_, err := s.db.RetrieveRevision(uuid.New().String(), 1)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Creating a VASP stores the first revision
+	vasp := &pb.VASP{
+		CommonName: "revisions.example.com",
+		Website:    "https://revisions.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Revisions, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	id, err := s.db.CreateVASP(vasp)
+	require.NoError(err)
+
+	other := &pb.VASP{
+		CommonName: "other.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Other, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	_, err = s.db.CreateVASP(other)
+	require.NoError(err)
+
+	// Each update stores a new revision; an update made from a stale copy is rejected
+	// without storing a revision.
+	stale := proto.Clone(vasp).(*pb.VASP)
+	vasp.Website = "https://revisions.example.org"
+	require.NoError(s.db.UpdateVASP(vasp))
+	stale.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.ErrorIs(s.db.UpdateVASP(stale), storeerrors.ErrConcurrentUpdate)
+	require.Equal(uint64(1), stale.Version.Version, "version should not be modified on failed update")
+
+	vasp.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.NoError(s.db.UpdateVASP(vasp))
+
+	revisions, err := s.db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Len(revisions, 3)
+	for i, rev := range revisions {
+		require.Equal(id, rev.Vasp)
+		require.Equal(uint64(i+1), rev.Revision)
+		require.NotEmpty(rev.Created)
+	}
+	require.Equal("https://revisions.example.com", revisions[0].Record.Website)
+	require.Equal("https://revisions.example.org", revisions[1].Record.Website)
+	require.Equal(pb.VerificationState_PENDING_REVIEW, revisions[2].Record.VerificationStatus)
+
+	// Attempt to Retrieve a revision
+	rev, err := s.db.RetrieveRevision(id, 2)
+	require.NoError(err)
+	require.True(proto.Equal(revisions[1], rev))
+	require.True(proto.Equal(vasp, revisions[2].Record))
+
+	_, err = s.db.RetrieveRevision(id, 4)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	_, err = s.db.RetrieveRevision(id, 0)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Revisions are deleted with the VASP
+	require.NoError(s.db.DeleteVASP(id))
+	revisions, err = s.db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Empty(revisions)
+
+	revisions, err = s.db.ListRevisions(other.Id).All()
+	require.NoError(err)
+	require.Len(revisions, 1)
+	require.NoError(s.db.DeleteVASP(other.Id))
+}
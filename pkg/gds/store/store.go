@@ -1,7 +1,8 @@
 /*
 Package store provides an interface to multiple types of embedded storage across
-multiple objects. Unlike a SQL interface, the TRISA directory service relies on document
-databases or key/value stores such as leveldb or trtl. It also manages multiple
+multiple objects. The TRISA directory service primarily relies on document databases or
+key/value stores such as leveldb or trtl, though an embedded SQLite store is also
+available for reporting and local development. It also manages multiple
 namespaces (object types) - VASP records, CertificateRequests, Peers, etc. In general an
 object store interface provides accesses to the objects, with one interface per
 namespace as follows:
@@ -26,12 +27,15 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/gds/config"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
 	"github.com/trisacrypto/directory/pkg/gds/store/leveldb"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
+	"github.com/trisacrypto/directory/pkg/gds/store/sqlite"
 	"github.com/trisacrypto/directory/pkg/gds/store/trtl"
 	pb "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
 )
@@ -55,6 +59,10 @@ func Open(conf config.DatabaseConfig) (s Store, err error) {
 		if s, err = trtl.Open(conf); err != nil {
 			return nil, err
 		}
+	case "sqlite", "sqlite3":
+		if s, err = sqlite.Open(dsn.Path); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unhandled database scheme %q", dsn.Scheme)
 	}
@@ -84,12 +92,19 @@ type Store interface {
 	DirectoryStore
 	CertificateStore
 	CertificateRequestStore
+	PendingActionStore
+	AuditStore
+	RevisionStore
 }
 
 // DirectoryStore describes how the service interacts with VASP identity records.
+// UpdateVASP only stores the record if the stored record still has the version of the
+// record being updated, otherwise it returns ErrConcurrentUpdate so that concurrent
+// updates to the same record are not lost.
 type DirectoryStore interface {
 	ListVASPs() iterator.DirectoryIterator
 	SearchVASPs(query map[string]interface{}) ([]*pb.VASP, error)
+	QueryVASPs(query *search.Query) (*search.Page, error)
 	CreateVASP(v *pb.VASP) (string, error)
 	RetrieveVASP(id string) (*pb.VASP, error)
 	UpdateVASP(v *pb.VASP) error
@@ -105,6 +120,33 @@ type CertificateRequestStore interface {
 	DeleteCertReq(id string) error
 }
 
+// PendingActionStore describes how the service interacts with administrative actions
+// that are awaiting approval.
+type PendingActionStore interface {
+	ListActions() iterator.PendingActionIterator
+	CreateAction(a *models.PendingAction) (string, error)
+	RetrieveAction(id string) (*models.PendingAction, error)
+	UpdateAction(a *models.PendingAction) error
+	DeleteAction(id string) error
+}
+
+// AuditStore describes how the service interacts with the admin audit log. The log is
+// append-only so audit records cannot be updated or deleted once they are created.
+// Audit records are listed most recent first so that the log can be paged by time.
+type AuditStore interface {
+	ListAuditRecords() iterator.AuditRecordIterator
+	CreateAuditRecord(r *models.AuditRecord) (string, error)
+	RetrieveAuditRecord(id string) (*models.AuditRecord, error)
+}
+
+// RevisionStore describes how the service accesses the revision history of VASP
+// records. Revisions are stored by the DirectoryStore when a VASP is created or updated
+// and are deleted with the VASP, so they are read-only to the service.
+type RevisionStore interface {
+	ListRevisions(vaspID string) iterator.RevisionIterator
+	RetrieveRevision(vaspID string, revision uint64) (*models.VASPRevision, error)
+}
+
 // CertificateStore describes how the service interacts with Certificate records.
 type CertificateStore interface {
 	ListCerts() iterator.CertificateIterator
@@ -112,6 +154,9 @@ type CertificateStore interface {
 	RetrieveCert(id string) (*models.Certificate, error)
 	UpdateCert(c *models.Certificate) error
 	DeleteCert(id string) error
+	RetrieveCertBySerial(serial string) (*models.Certificate, error)
+	ListCertsByVASP(vaspID string) ([]*models.Certificate, error)
+	ListCertsByExpiration(after, before time.Time) ([]*models.Certificate, error)
 }
 
 // Indexer allows external methods to access the index function of the store if it has
@@ -121,6 +166,18 @@ type Indexer interface {
 	Reindex() error
 }
 
+// Importer allows records that are managed by the store itself, the admin audit log and
+// the revision history of VASPs, to be written with their original IDs when records are
+// migrated from another store. Existing records with the same ID are replaced. VASPs are
+// imported with their original version and timestamps and without creating a revision,
+// so that the revision history is exactly the history imported from the other store;
+// the VASP must not already exist in the store.
+type Importer interface {
+	ImportVASP(v *pb.VASP) error
+	ImportAuditRecord(r *models.AuditRecord) error
+	ImportRevision(r *models.VASPRevision) error
+}
+
 // Backup means that the Store can be backed up to a compressed location on disk,
 // optionally with encryption if its required.
 type Backup interface {
@@ -14,6 +14,8 @@ func TestDSNParsing(t *testing.T) {
 	}{
 		{"leveldb:///fixtures/db", &store.DSN{"leveldb", "fixtures/db"}},
 		{"leveldb:////data/db", &store.DSN{"leveldb", "/data/db"}},
+		{"sqlite:///fixtures/gds.db", &store.DSN{"sqlite", "fixtures/gds.db"}},
+		{"sqlite:////data/gds.db", &store.DSN{"sqlite", "/data/gds.db"}},
 	}
 
 	for _, tc := range cases {
@@ -2,10 +2,12 @@ package trtl
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
 
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/gds/models/v1"
 	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
 	"github.com/trisacrypto/directory/pkg/gds/store/index"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
@@ -27,6 +29,10 @@ func (s *Store) Reindex() (err error) {
 	websites := index.NewWebsiteIndex()
 	countries := index.NewCountryIndex()
 	categories := index.NewCategoryIndex()
+	fulltext := index.NewFullTextIndex()
+	serials := index.NewSerialNumberIndex()
+	certVASPs := index.NewVASPCertsIndex()
+	expires := index.NewExpirationIndex()
 
 	ctx, cancel := withContext(context.Background())
 	defer cancel()
@@ -70,12 +76,47 @@ func (s *Store) Reindex() (err error) {
 		for _, vaspCategory := range vasp.VaspCategories {
 			categories.Add(vaspCategory, vasp.Id)
 		}
+
+		// Update full text index
+		for _, text := range index.SearchText(vasp) {
+			fulltext.Add(text, vasp.Id)
+		}
 	}
 
 	if err = cursor.CloseSend(); err != nil {
 		log.Error().Err(err).Msg("could not close the trtl cursor in reindex")
 	}
 
+	if cursor, err = s.client.Cursor(ctx, &pb.CursorRequest{Namespace: wire.NamespaceCerts}); err != nil {
+		return err
+	}
+
+	for {
+		var pair *pb.KVPair
+		if pair, err = cursor.Recv(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		cert := &models.Certificate{}
+		if err = proto.Unmarshal(pair.Value, cert); err != nil {
+			return err
+		}
+
+		// Update certificate indices
+		certVASPs.Add(cert.Vasp, cert.Id)
+		if cert.Details != nil {
+			serials.Overwrite(hex.EncodeToString(cert.Details.SerialNumber), cert.Id)
+			expires.Add(cert.Details.NotAfter, cert.Id)
+		}
+	}
+
+	if err = cursor.CloseSend(); err != nil {
+		log.Error().Err(err).Msg("could not close the trtl certificates cursor in reindex")
+	}
+
 	// Critical section
 	s.Lock()
 	if !names.Empty() {
@@ -93,6 +134,22 @@ func (s *Store) Reindex() (err error) {
 	if !categories.Empty() {
 		s.categories = categories
 	}
+
+	if !fulltext.Empty() {
+		s.fulltext = fulltext
+	}
+
+	if !serials.Empty() {
+		s.serials = serials
+	}
+
+	if !certVASPs.Empty() {
+		s.certVASPs = certVASPs
+	}
+
+	if !expires.Empty() {
+		s.expires = expires
+	}
 	s.Unlock()
 
 	if err = s.sync(); err != nil {
@@ -104,6 +161,10 @@ func (s *Store) Reindex() (err error) {
 		Int("websites", s.websites.Len()).
 		Int("countries", s.countries.Len()).
 		Int("categories", s.categories.Len()).
+		Int("fulltext", s.fulltext.Len()).
+		Int("serials", s.serials.Len()).
+		Int("certvasps", s.certVASPs.Len()).
+		Int("expirations", s.expires.Len()).
 		Msg("reindex complete")
 	return nil
 }
@@ -113,9 +174,13 @@ func (s *Store) Reindex() (err error) {
 //===========================================================================
 
 func (s *Store) insertIndices(v *gds.VASP) error {
-	s.names.Add(v.CommonName, v.Id)
-	for _, name := range v.Entity.Names() {
-		s.names.Add(name, v.Id)
+	// Archived VASPs do not reserve their names so that the VASP can be registered
+	// again; the names are indexed again if the archived VASP is restored.
+	if !models.IsArchived(v) {
+		s.names.Add(v.CommonName, v.Id)
+		for _, name := range v.Entity.Names() {
+			s.names.Add(name, v.Id)
+		}
 	}
 
 	s.websites.Add(v.Website, v.Id)
@@ -130,13 +195,19 @@ func (s *Store) insertIndices(v *gds.VASP) error {
 		s.categories.Add(vaspCategory, v.Id)
 	}
 
+	for _, text := range index.SearchText(v) {
+		s.fulltext.Add(text, v.Id)
+	}
 	return nil
 }
 
 func (s *Store) removeIndices(v *gds.VASP) error {
-	s.names.Remove(v.CommonName)
-	for _, name := range v.Entity.Names() {
-		s.names.Remove(name)
+	// Only remove the names that have not been reassigned to another VASP, e.g. when
+	// an archived VASP has been registered again.
+	for _, name := range append([]string{v.CommonName}, v.Entity.Names()...) {
+		if id, ok := s.names.Find(name); ok && id == v.Id {
+			s.names.Remove(name)
+		}
 	}
 
 	s.websites.Remove(v.Website)
@@ -150,15 +221,57 @@ func (s *Store) removeIndices(v *gds.VASP) error {
 	for _, vaspCategory := range v.VaspCategories {
 		s.categories.Remove(vaspCategory, v.Id)
 	}
+
+	for _, text := range index.SearchText(v) {
+		s.fulltext.Remove(text, v.Id)
+	}
+	return nil
+}
+
+func (s *Store) insertCertIndices(c *models.Certificate) error {
+	s.certVASPs.Add(c.Vasp, c.Id)
+	if c.Details != nil {
+		s.serials.Overwrite(hex.EncodeToString(c.Details.SerialNumber), c.Id)
+		s.expires.Add(c.Details.NotAfter, c.Id)
+	}
 	return nil
 }
 
+func (s *Store) removeCertIndices(c *models.Certificate) error {
+	s.certVASPs.Remove(c.Vasp, c.Id)
+	if c.Details != nil {
+		// Only remove the serial number if it has not been reassigned to another record
+		serial := hex.EncodeToString(c.Details.SerialNumber)
+		if id, ok := s.serials.Find(serial); ok && id == c.Id {
+			s.serials.Remove(serial)
+		}
+	}
+	s.expires.Remove(c.Id)
+	return nil
+}
+
+// missingCertIndices returns true if the certificate indices are empty but there are
+// certificates in the database, e.g. if the indices were never synchronized to trtl.
+func (s *Store) missingCertIndices() bool {
+	if !s.serials.Empty() || !s.certVASPs.Empty() || !s.expires.Empty() {
+		return false
+	}
+
+	iter := s.ListCerts()
+	defer iter.Release()
+	return iter.Next()
+}
+
 // keys and prefixes for leveldb buckets and indices
 var (
 	keyNameIndex     = []byte("names")
 	keyWebsiteIndex  = []byte("websites")
 	keyCountryIndex  = []byte("countries")
 	keyCategoryIndex = []byte("categories")
+	keyFullTextIndex = []byte("fulltext")
+	keySerialIndex   = []byte("serials")
+	keyCertVASPIndex = []byte("certvasps")
+	keyExpiresIndex  = []byte("expirations")
 )
 
 // Sync exposes the index synchronization functionality to tests, allowing them to sync
@@ -173,6 +286,14 @@ func (s *Store) Sync(index string) error {
 		return s.synccountries()
 	case "category", "categories":
 		return s.synccategories()
+	case "fulltext":
+		return s.syncfulltext()
+	case "serial", "serials":
+		return s.syncserials()
+	case "certvasps":
+		return s.synccertvasps()
+	case "expires", "expirations":
+		return s.syncexpires()
 	case "", "all":
 		return s.sync()
 	default:
@@ -197,11 +318,31 @@ func (s *Store) sync() (err error) {
 		return err
 	}
 
+	if err = s.syncfulltext(); err != nil {
+		return err
+	}
+
+	if err = s.syncserials(); err != nil {
+		return err
+	}
+
+	if err = s.synccertvasps(); err != nil {
+		return err
+	}
+
+	if err = s.syncexpires(); err != nil {
+		return err
+	}
+
 	log.Debug().
 		Int("names", s.names.Len()).
 		Int("websites", s.websites.Len()).
 		Int("countries", s.countries.Len()).
 		Int("categories", s.categories.Len()).
+		Int("fulltext", s.fulltext.Len()).
+		Int("serials", s.serials.Len()).
+		Int("certvasps", s.certVASPs.Len()).
+		Int("expirations", s.expires.Len()).
 		Msg("indices synchronized")
 	return nil
 }
@@ -402,6 +543,202 @@ func (s *Store) synccategories() (err error) {
 	return nil
 }
 
+func (s *Store) syncfulltext() (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.fulltext == nil {
+		// Create the index to load it from disk
+		s.fulltext = index.NewFullTextIndex()
+
+		// Fetch the data from the database
+		var rep *pb.GetReply
+		if rep, err = s.client.Get(ctx, &pb.GetRequest{Key: keyFullTextIndex, Namespace: wire.NamespaceIndices}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch full text index from database")
+			return err
+		}
+
+		if err = s.fulltext.Load(rep.Value); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	// Put the current full text index back to the database
+	if !s.fulltext.Empty() {
+		var value []byte
+		if value, err = s.fulltext.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if rep, err := s.client.Put(ctx, &pb.PutRequest{Key: keyFullTextIndex, Value: value, Namespace: wire.NamespaceIndices}); err != nil || !rep.Success {
+			if err == nil {
+				err = storeerrors.ErrProtocol
+			}
+			log.Error().Err(err).Msg("could not put full text index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		log.Debug().Int("size", len(value)).Msg("full text index checkpointed")
+	}
+	return nil
+}
+
+func (s *Store) syncserials() (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.serials == nil {
+		// Create the index to load it from disk
+		s.serials = index.NewSerialNumberIndex()
+
+		// Fetch the data from the database
+		var rep *pb.GetReply
+		if rep, err = s.client.Get(ctx, &pb.GetRequest{Key: keySerialIndex, Namespace: wire.NamespaceIndices}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch serial number index from database")
+			return err
+		}
+
+		if err = s.serials.Load(rep.Value); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	// Put the current serial number index back to the database
+	if !s.serials.Empty() {
+		var value []byte
+		if value, err = s.serials.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if rep, err := s.client.Put(ctx, &pb.PutRequest{Key: keySerialIndex, Value: value, Namespace: wire.NamespaceIndices}); err != nil || !rep.Success {
+			if err == nil {
+				err = storeerrors.ErrProtocol
+			}
+			log.Error().Err(err).Msg("could not put serial number index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		log.Debug().Int("size", len(value)).Msg("serial number index checkpointed")
+	}
+	return nil
+}
+
+func (s *Store) synccertvasps() (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.certVASPs == nil {
+		// Create the index to load it from disk
+		s.certVASPs = index.NewVASPCertsIndex()
+
+		// Fetch the data from the database
+		var rep *pb.GetReply
+		if rep, err = s.client.Get(ctx, &pb.GetRequest{Key: keyCertVASPIndex, Namespace: wire.NamespaceIndices}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch certificate vasps index from database")
+			return err
+		}
+
+		if err = s.certVASPs.Load(rep.Value); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	// Put the current certificate vasps index back to the database
+	if !s.certVASPs.Empty() {
+		var value []byte
+		if value, err = s.certVASPs.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if rep, err := s.client.Put(ctx, &pb.PutRequest{Key: keyCertVASPIndex, Value: value, Namespace: wire.NamespaceIndices}); err != nil || !rep.Success {
+			if err == nil {
+				err = storeerrors.ErrProtocol
+			}
+			log.Error().Err(err).Msg("could not put certificate vasps index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		log.Debug().Int("size", len(value)).Msg("certificate vasps index checkpointed")
+	}
+	return nil
+}
+
+func (s *Store) syncexpires() (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	if s.expires == nil {
+		// Create the index to load it from disk
+		s.expires = index.NewExpirationIndex()
+
+		// Fetch the data from the database
+		var rep *pb.GetReply
+		if rep, err = s.client.Get(ctx, &pb.GetRequest{Key: keyExpiresIndex, Namespace: wire.NamespaceIndices}); err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			log.Error().Err(err).Msg("could not fetch expirations index from database")
+			return err
+		}
+
+		if err = s.expires.Load(rep.Value); err != nil {
+			log.Error().Err(err).Msg("could not unmarshal expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+	}
+
+	// Put the current expirations index back to the database
+	if !s.expires.Empty() {
+		var value []byte
+		if value, err = s.expires.Dump(); err != nil {
+			log.Error().Err(err).Msg("could not marshal expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		if rep, err := s.client.Put(ctx, &pb.PutRequest{Key: keyExpiresIndex, Value: value, Namespace: wire.NamespaceIndices}); err != nil || !rep.Success {
+			if err == nil {
+				err = storeerrors.ErrProtocol
+			}
+			log.Error().Err(err).Msg("could not put expirations index")
+			return storeerrors.ErrCorruptedIndex
+		}
+
+		log.Debug().Int("size", len(value)).Msg("expirations index checkpointed")
+	}
+	return nil
+}
+
 // GetNamesIndex for testing
 func (s *Store) GetNamesIndex() index.SingleIndex {
 	return s.names
@@ -422,13 +759,33 @@ func (s *Store) GetCategoriesIndex() index.MultiIndex {
 	return s.categories
 }
 
+// GetFullTextIndex for testing
+func (s *Store) GetFullTextIndex() index.TextIndex {
+	return s.fulltext
+}
+
+// GetSerialsIndex for testing
+func (s *Store) GetSerialsIndex() index.SingleIndex {
+	return s.serials
+}
+
+// GetCertVASPsIndex for testing
+func (s *Store) GetCertVASPsIndex() index.MultiIndex {
+	return s.certVASPs
+}
+
+// GetExpirationsIndex for testing
+func (s *Store) GetExpirationsIndex() index.TimeIndex {
+	return s.expires
+}
+
 // DeleteIndices for testing
 // TODO: remove this function in favor of SC-3653
 func (s *Store) DeleteIndices() (err error) {
 	ctx, cancel := withContext(context.Background())
 	defer cancel()
 
-	keys := [][]byte{keyNameIndex, keyWebsiteIndex, keyCategoryIndex, keyCountryIndex}
+	keys := [][]byte{keyNameIndex, keyWebsiteIndex, keyCategoryIndex, keyCountryIndex, keyFullTextIndex, keySerialIndex, keyCertVASPIndex, keyExpiresIndex}
 	for _, key := range keys {
 		if _, err := s.client.Delete(ctx, &pb.DeleteRequest{Key: key, Namespace: wire.NamespaceIndices}); err != nil {
 			log.Debug().Err(err).Msg("could not delete index")
@@ -19,6 +19,7 @@ func (s *trtlStoreTestSuite) TestIndexSync() {
 	require.True(db.GetWebsitesIndex().Empty(), "website index not empty, have fixtures changed?")
 	require.True(db.GetCountriesIndex().Empty(), "country index not empty, have fixtures changed?")
 	require.True(db.GetCategoriesIndex().Empty(), "category index not empty, have fixtures changed?")
+	require.True(db.GetFullTextIndex().Empty(), "full text index not empty, have fixtures changed?")
 
 	// Create a bunch of records
 	err = createVASPs(db, 100, 1)
@@ -27,6 +28,7 @@ func (s *trtlStoreTestSuite) TestIndexSync() {
 	require.Equal(100, db.GetWebsitesIndex().Len(), "website index has an unexpected length")
 	require.Equal(7, db.GetCountriesIndex().Len(), "countries index has an unexpected length")
 	require.Equal(3, db.GetCategoriesIndex().Len(), "categories index has an unexpected length")
+	require.Equal(303, db.GetFullTextIndex().Len(), "full text index has an unexpected length")
 
 	// Sync the indices to disk
 	// NOTE: this should also test any conflicts with reserved namespaces in trtl
@@ -40,6 +42,7 @@ func (s *trtlStoreTestSuite) TestIndexSync() {
 	require.NoError(deleteVASPs(db), "could not delete vasps during index test")
 	require.True(db.GetNamesIndex().Empty(), "name index not empty after delete")
 	require.True(db.GetWebsitesIndex().Empty(), "website index not empty after delete")
+	require.True(db.GetFullTextIndex().Empty(), "full text index not empty after delete")
 
 	// TODO: multi-index has empty arrays but still contains country/categories
 	// require.True(db.GetCountriesIndex().Empty(), "country index not empty after delete")
@@ -70,5 +73,25 @@ func (s *trtlStoreTestSuite) TestSearch() {
 	require.NoError(err, "could not search vasps with query")
 	require.Len(vasps, 1, "no vasps returned from search")
 	require.Equal("trisa0003.test.net", vasps[0].CommonName)
+
+	// Test a full text search, the most relevant result should be returned first
+	vasps, err = db.SearchVASPs(map[string]interface{}{"text": "vasp 0050"})
+	require.NoError(err, "could not search vasps with text query")
+	require.Len(vasps, 100, "expected all vasps to match the text query")
+	require.Equal("trisa0080.test.net", vasps[0].CommonName)
+
+	// Typos are only matched when fuzzy search is enabled
+	vasps, err = db.SearchVASPs(map[string]interface{}{"text": "tisa0080"})
+	require.NoError(err, "could not search vasps with text query")
+	require.Len(vasps, 0, "expected no results without fuzzy search")
+
+	vasps, err = db.SearchVASPs(map[string]interface{}{"text": "tisa0080", "fuzzy": true, "country": "CC"})
+	require.NoError(err, "could not search vasps with fuzzy text query")
+	require.NotEmpty(vasps, "expected results from fuzzy search")
+	require.Equal("trisa0080.test.net", vasps[0].CommonName)
+	for _, vasp := range vasps {
+		require.Equal("CC", vasp.Entity.CountryOfRegistration, "expected results to be filtered by country")
+	}
+
 	require.NoError(deleteVASPs(db), "could not delete vasps after search test")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/gds/models/v1"
@@ -26,6 +27,18 @@ type certReqIterator struct {
 	trtlIterator
 }
 
+type actionIterator struct {
+	trtlIterator
+}
+
+type auditIterator struct {
+	trtlIterator
+}
+
+type revisionIterator struct {
+	trtlIterator
+}
+
 // trtlIterator is an interface that is implemented by both the trtlBatchIterator and
 // trtlStreamingIterator to iterate over values in the trtl store. The general workflow
 // is to instantiate the iterator with either NewTrtlBatchIterator or
@@ -181,6 +194,7 @@ type trtlStreamingIterator struct {
 	next      *trtlpb.KVPair
 	eof       bool
 	namespace string
+	prefix    []byte
 	err       error
 }
 
@@ -191,12 +205,23 @@ func NewTrtlStreamingIterator(client trtlpb.TrtlClient, namespace string) *trtlS
 	}
 }
 
+// NewTrtlPrefixIterator returns a streaming iterator over the keys in the namespace
+// that start with the specified prefix.
+func NewTrtlPrefixIterator(client trtlpb.TrtlClient, namespace string, prefix []byte) *trtlStreamingIterator {
+	return &trtlStreamingIterator{
+		client:    client,
+		namespace: namespace,
+		prefix:    prefix,
+	}
+}
+
 func (i *trtlStreamingIterator) Next() bool {
 	if i.cursor == nil {
 		var ctx context.Context
 		ctx, i.cancel = withContext(context.Background())
 		request := &trtlpb.CursorRequest{
 			Namespace: i.namespace,
+			Prefix:    i.prefix,
 		}
 		i.cursor, i.err = i.client.Cursor(ctx, request)
 	}
@@ -257,6 +282,7 @@ func (i *trtlStreamingIterator) Seek(key []byte) bool {
 	ctx, i.cancel = withContext(context.Background())
 	request := &trtlpb.CursorRequest{
 		Namespace: i.namespace,
+		Prefix:    i.prefix,
 		SeekKey:   key,
 	}
 	i.cursor, i.err = i.client.Cursor(ctx, request)
@@ -289,7 +315,11 @@ func (i *trtlStreamingIterator) Release() {
 	if i.cursor != nil {
 		i.cursor.CloseSend()
 	}
-	i.cancel()
+
+	// The context is not created if the iterator is released before it is started.
+	if i.cancel != nil {
+		i.cancel()
+	}
 }
 
 func (i *vaspIterator) VASP() (*pb.VASP, error) {
@@ -380,3 +410,90 @@ func (i *certReqIterator) All() (reqs []*models.CertificateRequest, err error) {
 
 	return reqs, nil
 }
+
+func (i *actionIterator) Action() (*models.PendingAction, error) {
+	a := new(models.PendingAction)
+	if err := proto.Unmarshal(i.Value(), a); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceActions).Str("key", string(i.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return a, nil
+}
+
+func (i *actionIterator) All() (actions []*models.PendingAction, err error) {
+	actions = make([]*models.PendingAction, 0)
+	defer i.Release()
+	for i.Next() {
+		a := new(models.PendingAction)
+		if err = proto.Unmarshal(i.Value(), a); err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func (i *auditIterator) Record() (*models.AuditRecord, error) {
+	r := new(models.AuditRecord)
+	if err := proto.Unmarshal(i.Value(), r); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceAudit).Str("key", string(i.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return r, nil
+}
+
+func (i *auditIterator) All() (records []*models.AuditRecord, err error) {
+	records = make([]*models.AuditRecord, 0)
+	defer i.Release()
+	for i.Next() {
+		r := new(models.AuditRecord)
+		if err = proto.Unmarshal(i.Value(), r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// SeekTimestamp positions the iterator at the most recent audit record created at or
+// before the timestamp, returning false if there is no such record.
+func (i *auditIterator) SeekTimestamp(ts time.Time) bool {
+	return i.Seek([]byte(models.AuditRecordSeekKey(ts)))
+}
+
+func (i *revisionIterator) Revision() (*models.VASPRevision, error) {
+	r := new(models.VASPRevision)
+	if err := proto.Unmarshal(i.Value(), r); err != nil {
+		log.Error().Err(err).Str("type", wire.NamespaceRevisions).Str("key", string(i.Key())).Msg("corrupted data encountered")
+		return nil, err
+	}
+	return r, nil
+}
+
+func (i *revisionIterator) All() (revisions []*models.VASPRevision, err error) {
+	revisions = make([]*models.VASPRevision, 0)
+	defer i.Release()
+	for i.Next() {
+		r := new(models.VASPRevision)
+		if err = proto.Unmarshal(i.Value(), r); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, r)
+	}
+
+	if err = i.Error(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -402,3 +402,11 @@ func (s *trtlErrorClient) Sync(context.Context, ...grpc.CallOption) (pb.Trtl_Syn
 func (s *trtlErrorClient) Status(context.Context, *pb.HealthCheck, ...grpc.CallOption) (*pb.ServerStatus, error) {
 	return nil, status.Error(codes.Unavailable, "trtl is down")
 }
+
+func (s *trtlErrorClient) Compact(context.Context, *pb.CompactRequest, ...grpc.CallOption) (*pb.CompactReply, error) {
+	return nil, status.Error(codes.Unavailable, "trtl is down")
+}
+
+func (s *trtlErrorClient) Watch(context.Context, *pb.WatchRequest, ...grpc.CallOption) (pb.Trtl_WatchClient, error) {
+	return nil, status.Error(codes.Unavailable, "trtl is down")
+}
@@ -2,6 +2,9 @@ package trtl
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	storeerrors "github.com/trisacrypto/directory/pkg/gds/store/errors"
 	"github.com/trisacrypto/directory/pkg/gds/store/index"
 	"github.com/trisacrypto/directory/pkg/gds/store/iterator"
+	"github.com/trisacrypto/directory/pkg/gds/store/search"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
@@ -36,13 +40,18 @@ func Open(conf config.DatabaseConfig) (store *Store, err error) {
 	// Perform a reindex if the local indices are null or empty. In the case where the
 	// store has no data, this won't be harmful - but in the case where the stored index
 	// has been corrupted, this should repair it.
-	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() {
+	if store.names.Empty() || store.websites.Empty() || store.countries.Empty() || store.categories.Empty() || store.fulltext.Empty() || store.missingCertIndices() {
 		log.Info().Msg("reindexing to recover from empty indices")
 		if err = store.Reindex(); err != nil {
 			return nil, err
 		}
 	}
 
+	// Keep the indices up to date with the changes made by other GDS replicas.
+	var ctx context.Context
+	ctx, store.stopWatch = context.WithCancel(context.Background())
+	go store.watch(ctx)
+
 	// Run background go routine to periodically checkpoint index to disk.
 	// NOTE: the leveldb store does this in the backup go routine.
 	// TODO: configure (enable/disable) this functionality and shutdown
@@ -68,6 +77,12 @@ type Store struct {
 	websites   index.SingleIndex // website/url index
 	countries  index.MultiIndex  // lookup vasps in a specific country
 	categories index.MultiIndex  // lookup vasps based on specified categories
+	fulltext   index.TextIndex   // full text search of names, websites, and addresses
+	serials    index.SingleIndex // lookup certificates by serial number
+	certVASPs  index.MultiIndex  // lookup certificates issued to a specific vasp
+	expires    index.TimeIndex   // lookup certificates that expire in a time window
+	cursor     string            // the cursor of the last change applied from the vasps watch
+	stopWatch  context.CancelFunc
 }
 
 func withContext(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -81,6 +96,10 @@ func withContext(ctx context.Context) (context.Context, context.CancelFunc) {
 
 // Close the connection to the database.
 func (s *Store) Close() error {
+	if s.stopWatch != nil {
+		s.stopWatch()
+	}
+
 	defer s.conn.Close()
 	if err := s.sync(); err != nil {
 		return err
@@ -102,13 +121,21 @@ func (s *Store) ListVASPs() iterator.DirectoryIterator {
 // SearchVASPs is intended to specifically identify a VASP (rather than as a browsing
 // functionality). As such it is primarily a filtering search rather than an inclusive
 // search. The query can contain a one or more name or website terms. Names are prefixed
-// matched to the index and websites are hostname matched. The query can contain one or
-// more country and category filters as well, which reduce the number of search results.
+// matched to the index and websites are hostname matched. A "text" term is matched
+// against the full text index (tolerating typos if "fuzzy" is true) and these results
+// are returned first, ordered by relevance. The query can contain one or more country
+// and category filters as well, which reduce the number of search results.
 func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*gds.VASP, err error) {
 	// A set of records that match the query and need to be fetched
 	records := make(map[string]struct{})
 
 	s.RLock()
+	// Full text search results are ranked, so maintain their order
+	ranked := s.fulltext.Search(query)
+	for _, result := range ranked {
+		records[result] = struct{}{}
+	}
+
 	// Search the name index
 	for _, result := range s.names.Search(query) {
 		records[result] = struct{}{}
@@ -153,7 +180,7 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*gds.VASP, er
 	// Perform the lookup of records if there are any
 	if len(records) > 0 {
 		vasps = make([]*gds.VASP, 0, len(records))
-		for id := range records {
+		for _, id := range index.RankedOrder(ranked, records) {
 			var vasp *gds.VASP
 			if vasp, err = s.RetrieveVASP(id); err != nil {
 				if err == storeerrors.ErrEntityNotFound {
@@ -161,6 +188,12 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*gds.VASP, er
 				}
 				return nil, err
 			}
+
+			// Deleted VASPs are retained until they are purged but are not searchable
+			if models.IsArchived(vasp) {
+				continue
+			}
+
 			vasps = append(vasps, vasp)
 		}
 	}
@@ -168,6 +201,35 @@ func (s *Store) SearchVASPs(query map[string]interface{}) (vasps []*gds.VASP, er
 	return vasps, nil
 }
 
+// QueryVASPs returns a page of VASPs that match the query, ordered by relevance or by
+// name. If the query has name, website, or text terms, the indices are used to find
+// and score the matching records, otherwise all of the VASPs are filtered.
+func (s *Store) QueryVASPs(query *search.Query) (page *search.Page, err error) {
+	if !query.HasTerms() {
+		return search.Scan(query, s.ListVASPs())
+	}
+
+	s.RLock()
+	scores := search.Score(query, s.names, s.websites, s.fulltext)
+	s.RUnlock()
+
+	results := make([]*search.Result, 0, len(scores))
+	for id, score := range scores {
+		var vasp *gds.VASP
+		if vasp, err = s.RetrieveVASP(id); err != nil {
+			if err == storeerrors.ErrEntityNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		if query.Match(vasp) {
+			results = append(results, &search.Result{VASP: vasp, Score: score})
+		}
+	}
+	return search.Paginate(query, results)
+}
+
 // CreateVASP into the directory. This method requires the VASP to have a unique
 // name and ignores any ID fields that are set on the VASP, instead assigning new IDs.
 func (s *Store) CreateVASP(v *gds.VASP) (id string, err error) {
@@ -209,17 +271,19 @@ func (s *Store) CreateVASP(v *gds.VASP) (id string, err error) {
 		return "", err
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     data,
-		Namespace: wire.NamespaceVASPs,
+	var revision *pb.PutRequest
+	if revision, err = s.revisionPut(v); err != nil {
+		return "", err
 	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
-		}
+
+	// The record must not already exist so that a record created by another GDS
+	// replica with the same ID is not overwritten. The record and its first revision
+	// are written in a single atomic batch so that neither is stored without the other.
+	puts := []*batchPut{
+		{request: &pb.PutRequest{Key: key, Value: data, Namespace: wire.NamespaceVASPs, Options: &pb.Options{IfAbsent: true}}, conflict: storeerrors.ErrDuplicateEntity},
+		{request: revision, conflict: storeerrors.ErrConcurrentUpdate},
+	}
+	if err = s.batch(puts); err != nil {
 		return "", err
 	}
 
@@ -232,32 +296,29 @@ func (s *Store) CreateVASP(v *gds.VASP) (id string, err error) {
 
 // RetrieveVASP record by id. Returns ErrEntityNotFound if the record does not exist.
 func (s *Store) RetrieveVASP(id string) (v *gds.VASP, err error) {
-	key := []byte(id)
+	v, _, err = s.retrieveVASP(id)
+	return v, err
+}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.GetRequest{
-		Key:       key,
-		Namespace: wire.NamespaceVASPs,
-	}
-	var reply *pb.GetReply
-	if reply, err = s.client.Get(ctx, request); err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, storeerrors.ErrEntityNotFound
-		}
-		return nil, err
+// retrieveVASP returns the VASP record along with the trtl version of the record so
+// that the record can be updated or deleted with a compare-and-swap.
+func (s *Store) retrieveVASP(id string) (v *gds.VASP, version *pb.Version, err error) {
+	var data []byte
+	if data, version, err = s.get(wire.NamespaceVASPs, []byte(id)); err != nil {
+		return nil, nil, err
 	}
 
 	v = new(gds.VASP)
-	if err = proto.Unmarshal(reply.Value, v); err != nil {
-		return nil, err
+	if err = proto.Unmarshal(data, v); err != nil {
+		return nil, nil, err
 	}
 
-	return v, nil
+	return v, version, nil
 }
 
 // UpdateVASP by the VASP ID (required). This method simply overwrites the
-// entire VASP record and does not update individual fields.
+// entire VASP record and does not update individual fields. If the stored record has
+// a different version than the record being updated, ErrConcurrentUpdate is returned.
 func (s *Store) UpdateVASP(v *gds.VASP) (err error) {
 	if v.Id == "" {
 		return storeerrors.ErrIncompleteRecord
@@ -269,18 +330,6 @@ func (s *Store) UpdateVASP(v *gds.VASP) (err error) {
 		return storeerrors.ErrIncompleteRecord
 	}
 
-	// Update management timestamps and record metadata
-	v.Version.Version++
-	v.LastUpdated = time.Now().Format(time.RFC3339)
-	if v.FirstListed == "" {
-		v.FirstListed = v.LastUpdated
-	}
-
-	var val []byte
-	if val, err = proto.Marshal(v); err != nil {
-		return err
-	}
-
 	// Critical section (optimizing for safety rather than speed)
 	s.Lock()
 	defer s.Unlock()
@@ -288,33 +337,52 @@ func (s *Store) UpdateVASP(v *gds.VASP) (err error) {
 	// Retrieve the original record to ensure that the indices are updated properly
 	// This must be inside the lock so that the database indices are consistent.
 	// NOTE: the lock doesn't prevent concurrent writes from multiple GDS instances,
-	// just from this instance ... we need trtl transactions to guarantee this (or
-	// indices managed by trtl itself, since this is the only reason we're doing a Get).
-	o, err := s.RetrieveVASP(v.Id)
+	// so the record is only stored if trtl still has the version that was retrieved.
+	o, version, err := s.retrieveVASP(v.Id)
 	if err != nil {
 		return err
 	}
 
+	// The stored record must still be the version of the record that the caller
+	// retrieved, otherwise the update would overwrite the changes made since then.
+	if o.Version.GetVersion() != v.Version.GetVersion() {
+		return storeerrors.ErrConcurrentUpdate
+	}
+
 	// Check the uniqueness constraints
 	// NOTE: website removed as uniqueness constraint in SC-4483
 	if id, ok := s.names.Find(v.CommonName); ok && id != v.Id {
 		return storeerrors.ErrDuplicateEntity
 	}
 
-	// Update the VASP record
+	// Update management timestamps and record metadata
+	v.Version.Version++
+	v.LastUpdated = time.Now().Format(time.RFC3339)
+	if v.FirstListed == "" {
+		v.FirstListed = v.LastUpdated
+	}
+
+	var val []byte
+	if val, err = proto.Marshal(v); err != nil {
+		v.Version.Version--
+		return err
+	}
+
+	var revision *pb.PutRequest
+	if revision, err = s.revisionPut(v); err != nil {
+		v.Version.Version--
+		return err
+	}
+
+	// Update the VASP record and store its revision in a single atomic batch
 	// This must be inside the lock so that there is no race condition between the index
 	// and the stored index inside of the database.
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     val,
-		Namespace: wire.NamespaceVASPs,
+	puts := []*batchPut{
+		{request: &pb.PutRequest{Key: key, Value: val, Namespace: wire.NamespaceVASPs, Options: &pb.Options{ExpectedVersion: version}}, conflict: storeerrors.ErrConcurrentUpdate},
+		{request: revision, conflict: storeerrors.ErrConcurrentUpdate},
 	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
-		}
+	if err = s.batch(puts); err != nil {
+		v.Version.Version--
 		return err
 	}
 
@@ -341,9 +409,9 @@ func (s *Store) DeleteVASP(id string) error {
 
 	// Lookup the record in order to remove in order to remove data from the indices.
 	// This must be inside the lock to ensure indices are updated correctly with what
-	// is on disk. However, this doesn't prevent a concurrency issue with another
-	// GDS replica interacting with trtl.
-	o, err := s.RetrieveVASP(id)
+	// is on disk. The record is only deleted if it was not modified by another GDS
+	// replica after it was retrieved.
+	o, version, err := s.retrieveVASP(id)
 	if err != nil {
 		if err == storeerrors.ErrEntityNotFound {
 			return nil
@@ -351,16 +419,11 @@ func (s *Store) DeleteVASP(id string) error {
 		return err
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.DeleteRequest{
-		Key:       key,
-		Namespace: wire.NamespaceVASPs,
+	if err = s.delete(wire.NamespaceVASPs, key, version); err != nil {
+		return err
 	}
-	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
-		}
+
+	if err = s.deleteRevisions(id); err != nil {
 		return err
 	}
 
@@ -398,49 +461,45 @@ func (s *Store) CreateCert(c *models.Certificate) (id string, err error) {
 		return "", err
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     data,
-		Namespace: wire.NamespaceCerts,
-	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
-		}
+	// Critical section to ensure the indices reflect what is in the database
+	s.Lock()
+	defer s.Unlock()
+
+	if err = s.put(wire.NamespaceCerts, key, data, &pb.Options{IfAbsent: true}, storeerrors.ErrDuplicateEntity); err != nil {
 		return "", err
 	}
 
+	// Update indices after successful insert
+	if err = s.insertCertIndices(c); err != nil {
+		return "", err
+	}
 	return c.Id, nil
 }
 
 // RetrieveCert returns a certificate by certificate ID.
 func (s *Store) RetrieveCert(id string) (c *models.Certificate, err error) {
+	c, _, err = s.retrieveCert(id)
+	return c, err
+}
+
+// retrieveCert returns the certificate along with the trtl version of the record so
+// that the record can be updated or deleted with a compare-and-swap.
+func (s *Store) retrieveCert(id string) (c *models.Certificate, version *pb.Version, err error) {
 	if id == "" {
-		return nil, storeerrors.ErrEntityNotFound
+		return nil, nil, storeerrors.ErrEntityNotFound
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.GetRequest{
-		Key:       []byte(id),
-		Namespace: wire.NamespaceCerts,
-	}
-	var reply *pb.GetReply
-	if reply, err = s.client.Get(ctx, request); err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, storeerrors.ErrEntityNotFound
-		}
-		return nil, err
+	var data []byte
+	if data, version, err = s.get(wire.NamespaceCerts, []byte(id)); err != nil {
+		return nil, nil, err
 	}
 
 	c = new(models.Certificate)
-	if err = proto.Unmarshal(reply.Value, c); err != nil {
-		return nil, err
+	if err = proto.Unmarshal(data, c); err != nil {
+		return nil, nil, err
 	}
 
-	return c, nil
+	return c, version, nil
 }
 
 // UpdateCert can create or update a certificate. The certificate should be as
@@ -456,39 +515,116 @@ func (s *Store) UpdateCert(c *models.Certificate) (err error) {
 		return err
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     data,
-		Namespace: wire.NamespaceCerts,
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	// Retrieve the original record (if any) so that the indices are updated correctly.
+	// The certificate is only stored if another GDS replica has not created or modified
+	// it since it was retrieved.
+	o, version, err := s.retrieveCert(c.Id)
+	if err != nil && err != storeerrors.ErrEntityNotFound {
+		return err
 	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
+
+	opts := &pb.Options{ExpectedVersion: version}
+	if o == nil {
+		opts = &pb.Options{IfAbsent: true}
+	}
+
+	if err = s.put(wire.NamespaceCerts, key, data, opts, storeerrors.ErrConcurrentUpdate); err != nil {
+		return err
+	}
+
+	if o != nil {
+		if err = s.removeCertIndices(o); err != nil {
+			// NOTE: if this error is triggered, admins may want to reindex the database
+			log.Error().Err(err).Msg("could not remove previous certificate indices on update: reindex required")
 		}
+	}
+	if err = s.insertCertIndices(c); err != nil {
 		return err
 	}
 	return nil
 }
 
-// DeleteCert removes a certificate from the store.
+// DeleteCert removes a certificate from the store and the certificate indices.
 func (s *Store) DeleteCert(id string) (err error) {
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.DeleteRequest{
-		Key:       []byte(id),
-		Namespace: wire.NamespaceCerts,
-	}
-	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
+	// Critical section (optimizing for safety rather than speed)
+	s.Lock()
+	defer s.Unlock()
+
+	// Lookup the record in order to remove it from the indices
+	o, version, err := s.retrieveCert(id)
+	if err != nil {
+		if err == storeerrors.ErrEntityNotFound {
+			return nil
 		}
 		return err
 	}
+
+	if err = s.delete(wire.NamespaceCerts, []byte(id), version); err != nil {
+		return err
+	}
+
+	if err = s.removeCertIndices(o); err != nil {
+		return err
+	}
 	return nil
 }
 
+// RetrieveCertBySerial returns the certificate with the specified hex encoded serial
+// number; colons and whitespace in the serial number are ignored.
+func (s *Store) RetrieveCertBySerial(serial string) (c *models.Certificate, err error) {
+	s.RLock()
+	id, ok := s.serials.Find(serial)
+	s.RUnlock()
+
+	if !ok {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+	return s.RetrieveCert(id)
+}
+
+// ListCertsByVASP returns all certificates issued to the specified VASP ordered by ID.
+func (s *Store) ListCertsByVASP(vaspID string) (certs []*models.Certificate, err error) {
+	s.RLock()
+	ids, _ := s.certVASPs.Find(vaspID)
+	ids = append(make([]string, 0, len(ids)), ids...)
+	s.RUnlock()
+
+	return s.retrieveCerts(ids)
+}
+
+// ListCertsByExpiration returns all certificates that expire on or after the after
+// timestamp and before the before timestamp, ordered by expiration (soonest first).
+// A zero timestamp leaves that side of the window unbounded.
+func (s *Store) ListCertsByExpiration(after, before time.Time) (certs []*models.Certificate, err error) {
+	s.RLock()
+	ids := s.expires.Range(after, before)
+	s.RUnlock()
+
+	return s.retrieveCerts(ids)
+}
+
+// retrieveCerts fetches the certificates found by an index lookup. Certificates that
+// are indexed but have been deleted (e.g. by another replica) are skipped.
+func (s *Store) retrieveCerts(ids []string) (certs []*models.Certificate, err error) {
+	certs = make([]*models.Certificate, 0, len(ids))
+	for _, id := range ids {
+		var c *models.Certificate
+		if c, err = s.RetrieveCert(id); err != nil {
+			if err == storeerrors.ErrEntityNotFound {
+				log.Warn().Str("id", id).Msg("certificate index out of sync with database: reindex required")
+				continue
+			}
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, nil
+}
+
 //===========================================================================
 // CertificateRequestStore Implementation
 //===========================================================================
@@ -523,17 +659,7 @@ func (s *Store) CreateCertReq(r *models.CertificateRequest) (id string, err erro
 		return "", err
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     data,
-		Namespace: wire.NamespaceCertReqs,
-	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
-		if err == nil {
-			err = storeerrors.ErrProtocol
-		}
+	if err = s.put(wire.NamespaceCertReqs, key, data, &pb.Options{IfAbsent: true}, storeerrors.ErrDuplicateEntity); err != nil {
 		return "", err
 	}
 
@@ -546,22 +672,13 @@ func (s *Store) RetrieveCertReq(id string) (r *models.CertificateRequest, err er
 		return nil, storeerrors.ErrEntityNotFound
 	}
 
-	ctx, cancel := withContext(context.Background())
-	defer cancel()
-	request := &pb.GetRequest{
-		Key:       []byte(id),
-		Namespace: wire.NamespaceCertReqs,
-	}
-	var reply *pb.GetReply
-	if reply, err = s.client.Get(ctx, request); err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil, storeerrors.ErrEntityNotFound
-		}
+	var data []byte
+	if data, _, err = s.get(wire.NamespaceCertReqs, []byte(id)); err != nil {
 		return nil, err
 	}
 
 	r = new(models.CertificateRequest)
-	if err = proto.Unmarshal(reply.Value, r); err != nil {
+	if err = proto.Unmarshal(data, r); err != nil {
 		return nil, err
 	}
 
@@ -569,32 +686,66 @@ func (s *Store) RetrieveCertReq(id string) (r *models.CertificateRequest, err er
 }
 
 // UpdateCertReq can create or update a certificate request. The request should be as
-// complete as possible, including an ID generated by the caller.
+// complete as possible, including an ID generated by the caller. If the stored request
+// was modified after the caller retrieved it, ErrConcurrentUpdate is returned.
 func (s *Store) UpdateCertReq(r *models.CertificateRequest) (err error) {
 	if r.Id == "" {
 		return storeerrors.ErrIncompleteRecord
 	}
 
+	// Retrieve the original request (if any) so that the request is only stored if it
+	// has not been created or modified by another request or GDS replica since the
+	// caller retrieved it.
+	var (
+		data    []byte
+		version *pb.Version
+	)
+	key := []byte(r.Id)
+	opts := &pb.Options{IfAbsent: true}
+	if data, version, err = s.get(wire.NamespaceCertReqs, key); err != nil {
+		if err != storeerrors.ErrEntityNotFound {
+			return err
+		}
+	} else {
+		o := new(models.CertificateRequest)
+		if err = proto.Unmarshal(data, o); err != nil {
+			return err
+		}
+
+		if o.Modified != r.Modified {
+			return storeerrors.ErrConcurrentUpdate
+		}
+		opts = &pb.Options{ExpectedVersion: version}
+	}
+
 	// Update management timestamps and record metadata
-	r.Modified = time.Now().Format(time.RFC3339)
+	modified := r.Modified
+	r.Modified = time.Now().Format(time.RFC3339Nano)
 	if r.Created == "" {
 		r.Created = r.Modified
 	}
 
-	var data []byte
-	key := []byte(r.Id)
 	if data, err = proto.Marshal(r); err != nil {
+		r.Modified = modified
 		return err
 	}
 
+	if err = s.put(wire.NamespaceCertReqs, key, data, opts, storeerrors.ErrConcurrentUpdate); err != nil {
+		r.Modified = modified
+		return err
+	}
+	return nil
+}
+
+// DeleteCertReq removes a certificate request from the store.
+func (s *Store) DeleteCertReq(id string) (err error) {
 	ctx, cancel := withContext(context.Background())
 	defer cancel()
-	request := &pb.PutRequest{
-		Key:       key,
-		Value:     data,
+	request := &pb.DeleteRequest{
+		Key:       []byte(id),
 		Namespace: wire.NamespaceCertReqs,
 	}
-	if reply, err := s.client.Put(ctx, request); err != nil || !reply.Success {
+	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
 		if err == nil {
 			err = storeerrors.ErrProtocol
 		}
@@ -603,13 +754,91 @@ func (s *Store) UpdateCertReq(r *models.CertificateRequest) (err error) {
 	return nil
 }
 
-// DeleteCertReq removes a certificate request from the store.
-func (s *Store) DeleteCertReq(id string) (err error) {
+//===========================================================================
+// PendingActionStore Implementation
+//===========================================================================
+
+// ListActions returns all pending actions that are currently in the store.
+func (s *Store) ListActions() iterator.PendingActionIterator {
+	return &actionIterator{
+		NewTrtlStreamingIterator(s.client, wire.NamespaceActions),
+	}
+}
+
+// CreateAction and assign a new ID and return the version.
+func (s *Store) CreateAction(a *models.PendingAction) (id string, err error) {
+	if a.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create UUID for record
+	a.Id = uuid.New().String()
+
+	// Update management timestamps and record metadata
+	a.Created = time.Now().Format(time.RFC3339)
+	if a.Modified == "" {
+		a.Modified = a.Created
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(a); err != nil {
+		return "", err
+	}
+
+	if err = s.put(wire.NamespaceActions, []byte(a.Id), data, &pb.Options{IfAbsent: true}, storeerrors.ErrDuplicateEntity); err != nil {
+		return "", err
+	}
+
+	return a.Id, nil
+}
+
+// RetrieveAction returns a pending action by ID.
+func (s *Store) RetrieveAction(id string) (a *models.PendingAction, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if data, _, err = s.get(wire.NamespaceActions, []byte(id)); err != nil {
+		return nil, err
+	}
+
+	a = new(models.PendingAction)
+	if err = proto.Unmarshal(data, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// UpdateAction can create or update a pending action. The action should be as
+// complete as possible, including an ID generated by the caller.
+func (s *Store) UpdateAction(a *models.PendingAction) (err error) {
+	if a.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	// Update management timestamps and record metadata
+	a.Modified = time.Now().Format(time.RFC3339)
+	if a.Created == "" {
+		a.Created = a.Modified
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(a); err != nil {
+		return err
+	}
+
+	return s.put(wire.NamespaceActions, []byte(a.Id), data, nil, storeerrors.ErrConcurrentUpdate)
+}
+
+// DeleteAction removes a pending action from the store.
+func (s *Store) DeleteAction(id string) (err error) {
 	ctx, cancel := withContext(context.Background())
 	defer cancel()
 	request := &pb.DeleteRequest{
 		Key:       []byte(id),
-		Namespace: wire.NamespaceCertReqs,
+		Namespace: wire.NamespaceActions,
 	}
 	if reply, err := s.client.Delete(ctx, request); err != nil || !reply.Success {
 		if err == nil {
@@ -619,3 +848,332 @@ func (s *Store) DeleteCertReq(id string) (err error) {
 	}
 	return nil
 }
+
+//===========================================================================
+// AuditStore Implementation
+//===========================================================================
+
+// ListAuditRecords returns all of the records in the admin audit log, most recent first.
+func (s *Store) ListAuditRecords() iterator.AuditRecordIterator {
+	return &auditIterator{
+		NewTrtlStreamingIterator(s.client, wire.NamespaceAudit),
+	}
+}
+
+// CreateAuditRecord appends a record to the audit log, assigning it a new ID.
+func (s *Store) CreateAuditRecord(r *models.AuditRecord) (id string, err error) {
+	if r.Id != "" {
+		return "", storeerrors.ErrIDAlreadySet
+	}
+
+	// Create a time ordered ID for the record
+	if r.Timestamp == "" {
+		r.Timestamp = time.Now().Format(time.RFC3339Nano)
+	}
+	r.Id = models.NewAuditRecordID(r)
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return "", err
+	}
+
+	if err = s.put(wire.NamespaceAudit, []byte(r.Id), data, &pb.Options{IfAbsent: true}, storeerrors.ErrDuplicateEntity); err != nil {
+		return "", err
+	}
+
+	return r.Id, nil
+}
+
+// RetrieveAuditRecord returns an audit record by ID.
+func (s *Store) RetrieveAuditRecord(id string) (r *models.AuditRecord, err error) {
+	if id == "" {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if data, _, err = s.get(wire.NamespaceAudit, []byte(id)); err != nil {
+		return nil, err
+	}
+
+	r = new(models.AuditRecord)
+	if err = proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ImportVASP writes a VASP migrated from another store with its original ID, version
+// and timestamps without creating a revision. The VASP must not already exist.
+func (s *Store) ImportVASP(v *gds.VASP) (err error) {
+	if v.Id == "" || index.Normalize(v.CommonName) == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(v); err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.names.Find(v.CommonName); ok {
+		return storeerrors.ErrDuplicateEntity
+	}
+
+	if err = s.put(wire.NamespaceVASPs, []byte(v.Id), data, &pb.Options{IfAbsent: true}, storeerrors.ErrDuplicateEntity); err != nil {
+		return err
+	}
+	return s.insertIndices(v)
+}
+
+// ImportAuditRecord writes an audit record migrated from another store with its
+// original ID, replacing any record with the same ID.
+func (s *Store) ImportAuditRecord(r *models.AuditRecord) (err error) {
+	if r.Id == "" {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	return s.put(wire.NamespaceAudit, []byte(r.Id), data, nil, storeerrors.ErrConcurrentUpdate)
+}
+
+//===========================================================================
+// RevisionStore Implementation
+//===========================================================================
+
+// ListRevisions returns the revisions of the specified VASP from oldest to newest.
+func (s *Store) ListRevisions(vaspID string) iterator.RevisionIterator {
+	return &revisionIterator{
+		NewTrtlPrefixIterator(s.client, wire.NamespaceRevisions, revisionPrefix(vaspID)),
+	}
+}
+
+// RetrieveRevision returns the specified revision of a VASP record.
+func (s *Store) RetrieveRevision(vaspID string, revision uint64) (r *models.VASPRevision, err error) {
+	if vaspID == "" || revision == 0 {
+		return nil, storeerrors.ErrEntityNotFound
+	}
+
+	var data []byte
+	if data, _, err = s.get(wire.NamespaceRevisions, revisionKey(vaspID, revision)); err != nil {
+		return nil, err
+	}
+
+	r = new(models.VASPRevision)
+	if err = proto.Unmarshal(data, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ImportRevision writes a revision migrated from another store with its original
+// revision number, replacing any revision of the VASP with the same number.
+func (s *Store) ImportRevision(r *models.VASPRevision) (err error) {
+	if r.Vasp == "" || r.Revision == 0 {
+		return storeerrors.ErrIncompleteRecord
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(r); err != nil {
+		return err
+	}
+
+	return s.put(wire.NamespaceRevisions, revisionKey(r.Vasp, r.Revision), data, nil, storeerrors.ErrConcurrentUpdate)
+}
+
+// revisionPut returns the request that stores the next revision of the VASP, which
+// must be written in the same batch as the record. This must be called inside the lock
+// so that concurrent updates from this replica are not assigned the same revision; the
+// revision is only stored if it does not exist so that a revision stored by another
+// GDS replica is never overwritten.
+func (s *Store) revisionPut(v *gds.VASP) (_ *pb.PutRequest, err error) {
+	rev := &models.VASPRevision{Vasp: v.Id, Revision: 1, Created: v.LastUpdated, Record: v}
+
+	var revisions []*models.VASPRevision
+	if revisions, err = s.ListRevisions(v.Id).All(); err != nil {
+		return nil, err
+	}
+	for _, prev := range revisions {
+		if prev.Revision >= rev.Revision {
+			rev.Revision = prev.Revision + 1
+		}
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(rev); err != nil {
+		return nil, err
+	}
+
+	return &pb.PutRequest{
+		Key:       revisionKey(v.Id, rev.Revision),
+		Value:     data,
+		Namespace: wire.NamespaceRevisions,
+		Options:   &pb.Options{IfAbsent: true},
+	}, nil
+}
+
+// deleteRevisions removes the entire revision history of the VASP.
+func (s *Store) deleteRevisions(vaspID string) (err error) {
+	// Collect the keys first so the cursor is not open while the keys are deleted
+	keys := make([][]byte, 0)
+	iter := NewTrtlPrefixIterator(s.client, wire.NamespaceRevisions, revisionPrefix(vaspID))
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+
+	if err = iter.Error(); err != nil {
+		iter.Release()
+		return err
+	}
+	iter.Release()
+
+	for _, key := range keys {
+		if err = s.delete(wire.NamespaceRevisions, key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revisionKey zero pads the revision number so that revisions are sorted in order.
+func revisionKey(vaspID string, revision uint64) []byte {
+	return []byte(fmt.Sprintf("%s:%020d", vaspID, revision))
+}
+
+// revisionPrefix is the prefix of all the revision keys of the VASP.
+func revisionPrefix(vaspID string) []byte {
+	return []byte(vaspID + ":")
+}
+
+//===========================================================================
+// Trtl Helpers
+//===========================================================================
+
+// get the value of the key in the namespace along with the trtl version of the object,
+// returning ErrEntityNotFound if the key does not exist.
+func (s *Store) get(namespace string, key []byte) (value []byte, version *pb.Version, err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+	request := &pb.GetRequest{
+		Key:       key,
+		Namespace: namespace,
+		Options:   &pb.Options{ReturnMeta: true},
+	}
+
+	var reply *pb.GetReply
+	if reply, err = s.client.Get(ctx, request); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, storeerrors.ErrEntityNotFound
+		}
+		return nil, nil, err
+	}
+	return reply.Value, reply.Meta.GetVersion(), nil
+}
+
+// put the value to the key in the namespace if the write preconditions in the options
+// are met, otherwise the conflict error is returned.
+func (s *Store) put(namespace string, key, value []byte, opts *pb.Options, conflict error) (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+	request := &pb.PutRequest{
+		Key:       key,
+		Value:     value,
+		Namespace: namespace,
+		Options:   opts,
+	}
+
+	var reply *pb.PutReply
+	if reply, err = s.client.Put(ctx, request); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return conflict
+		}
+		return err
+	}
+
+	if !reply.Success {
+		return storeerrors.ErrProtocol
+	}
+	return nil
+}
+
+// batchPut is a put request written in an atomic batch along with the error returned if
+// the preconditions of the request are not met.
+type batchPut struct {
+	request  *pb.PutRequest
+	conflict error
+}
+
+// batch writes the puts to trtl in a single atomic batch so that either all or none of
+// the values are stored. If the preconditions of any of the puts are not met, nothing
+// is written and the conflict error of that put is returned.
+func (s *Store) batch(puts []*batchPut) (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+
+	var stream pb.Trtl_BatchClient
+	if stream, err = s.client.Batch(ctx); err != nil {
+		return err
+	}
+
+	for i, put := range puts {
+		req := &pb.BatchRequest{Id: int64(i), Atomic: i == 0, Request: &pb.BatchRequest_Put{Put: put.request}}
+		if err = stream.Send(req); err != nil {
+			// The server closed the stream, the reason is returned by CloseAndRecv
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+
+	var reply *pb.BatchReply
+	if reply, err = stream.CloseAndRecv(); err != nil {
+		return err
+	}
+
+	if !reply.Committed {
+		// Only a failed precondition aborts a batch with a single error; otherwise the
+		// batch contained invalid requests.
+		if len(reply.Errors) == 1 && reply.Errors[0].Id < int64(len(puts)) && strings.Contains(reply.Errors[0].Error, codes.FailedPrecondition.String()) {
+			return puts[reply.Errors[0].Id].conflict
+		}
+
+		if len(reply.Errors) > 0 {
+			return fmt.Errorf("could not apply batch: %s", reply.Errors[0].Error)
+		}
+		return storeerrors.ErrProtocol
+	}
+	return nil
+}
+
+// delete the key from the namespace if the object still has the specified version,
+// otherwise ErrConcurrentUpdate is returned.
+func (s *Store) delete(namespace string, key []byte, version *pb.Version) (err error) {
+	ctx, cancel := withContext(context.Background())
+	defer cancel()
+	request := &pb.DeleteRequest{
+		Key:       key,
+		Namespace: namespace,
+		Options:   &pb.Options{ExpectedVersion: version},
+	}
+
+	var reply *pb.DeleteReply
+	if reply, err = s.client.Delete(ctx, request); err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return storeerrors.ErrConcurrentUpdate
+		}
+		return err
+	}
+
+	if !reply.Success {
+		return storeerrors.ErrProtocol
+	}
+	return nil
+}
@@ -159,6 +159,14 @@ func (s *trtlStoreTestSuite) TestDirectoryStore() {
 	require.EqualError(err, storeerrors.ErrDuplicateEntity.Error())
 	require.Empty(id2)
 
+	// Should not be able to overwrite an existing VASP record on create, even when the
+	// uniqueness constraints are not violated (e.g. a record created by another replica)
+	bob := proto.Clone(alice).(*pb.VASP)
+	bob.CommonName = "bob.example.com"
+	id2, err = db.CreateVASP(bob)
+	require.EqualError(err, storeerrors.ErrDuplicateEntity.Error())
+	require.Empty(id2)
+
 	// Attempt to Retrieve the VASP
 	alicer, err := db.RetrieveVASP(id)
 	require.NoError(err)
@@ -404,6 +412,108 @@ func (s *trtlStoreTestSuite) TestCertificateStore() {
 	require.Len(certs, 110)
 }
 
+func (s *trtlStoreTestSuite) TestCertificateIndices() {
+	require := s.Require()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	vaspID := uuid.New().String()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// Create certificates that expire in 10, 20, and 40 days
+	ids := make([]string, 0, 3)
+	for i, days := range []int{20, 10, 40} {
+		cert := &models.Certificate{
+			Request: uuid.New().String(),
+			Vasp:    vaspID,
+			Status:  models.CertificateState_ISSUED,
+			Details: &pb.Certificate{
+				SerialNumber: []byte{0x0a, 0x1b, byte(i)},
+				NotAfter:     now.AddDate(0, 0, days).Format(time.RFC3339),
+			},
+		}
+
+		id, err := db.CreateCert(cert)
+		require.NoError(err)
+		ids = append(ids, id)
+	}
+
+	// Lookup a certificate by serial number
+	cert, err := db.RetrieveCertBySerial("0a:1b:01")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	_, err = db.RetrieveCertBySerial("0A1B03")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Lookup certificates by VASP
+	certs, err := db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 3)
+
+	certs, err = db.ListCertsByVASP(uuid.New().String())
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	// Lookup certificates that expire in the next 30 days, soonest first
+	certs, err = db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 2)
+	require.Equal(ids[1], certs[0].Id)
+	require.Equal(ids[0], certs[1].Id)
+
+	// Updating a certificate should update the indices
+	cert.Vasp = uuid.New().String()
+	cert.Details.SerialNumber = []byte{0x0a, 0x1b, 0x03}
+	cert.Details.NotAfter = now.AddDate(0, 0, 60).Format(time.RFC3339)
+	require.NoError(db.UpdateCert(cert))
+
+	_, err = db.RetrieveCertBySerial("0A1B01")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	cert, err = db.RetrieveCertBySerial("0A1B03")
+	require.NoError(err)
+	require.Equal(ids[1], cert.Id)
+
+	certs, err = db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 2)
+
+	certs, err = db.ListCertsByExpiration(now, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 1)
+	require.Equal(ids[0], certs[0].Id)
+
+	// The indices should be rebuilt by a reindex
+	require.NoError(db.Reindex())
+	certs, err = db.ListCertsByExpiration(time.Time{}, now.AddDate(0, 0, 30))
+	require.NoError(err)
+	require.Len(certs, 1)
+	cert, err = db.RetrieveCertBySerial("0A1B00")
+	require.NoError(err)
+	require.Equal(ids[0], cert.Id)
+
+	// Deleting certificates should remove them from the indices
+	for _, id := range ids {
+		require.NoError(db.DeleteCert(id))
+	}
+
+	_, err = db.RetrieveCertBySerial("0A1B00")
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	certs, err = db.ListCertsByVASP(vaspID)
+	require.NoError(err)
+	require.Len(certs, 0)
+
+	certs, err = db.ListCertsByExpiration(now, time.Time{})
+	require.NoError(err)
+	require.Len(certs, 0)
+}
+
 func (s *trtlStoreTestSuite) TestCertificateRequestStore() {
 	require := s.Require()
 
@@ -477,6 +587,20 @@ func (s *trtlStoreTestSuite) TestCertificateRequestStore() {
 	s.NotEmpty(crr.Modified)
 	s.NotEqual(crr.Modified, crr.Created)
 
+	// Updating a stale copy of the certificate request should fail without modifying it
+	stale := proto.Clone(crr).(*models.CertificateRequest)
+	crr.Status = models.CertificateRequestState_CR_ERRORED
+	s.NoError(db.UpdateCertReq(crr))
+
+	modified := stale.Modified
+	stale.Status = models.CertificateRequestState_CR_REJECTED
+	s.ErrorIs(db.UpdateCertReq(stale), storeerrors.ErrConcurrentUpdate)
+	s.Equal(modified, stale.Modified, "modified timestamp should be restored on failed update")
+
+	crr, err = db.RetrieveCertReq(id)
+	s.NoError(err)
+	s.Equal(models.CertificateRequestState_CR_ERRORED, crr.Status)
+
 	// Attempt to update a certificate request with no Id on it
 	certreq.Id = ""
 	s.ErrorIs(db.UpdateCertReq(certreq), storeerrors.ErrIncompleteRecord)
@@ -543,6 +667,78 @@ func (s *trtlStoreTestSuite) TestCertificateRequestStore() {
 	require.Len(reqs, 110)
 }
 
+func (s *trtlStoreTestSuite) TestPendingActionStore() {
+	require := s.Require()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Should get a not found error trying to retrieve an action that doesn't exist
+	_, err = db.RetrieveAction(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	action := &models.PendingAction{
+		Type:       models.PendingActionType_DELETE_VASP,
+		Vasp:       uuid.New().String(),
+		ProposedBy: "alice@example.com",
+		Expires:    time.Now().Add(72 * time.Hour).Format(time.RFC3339),
+	}
+
+	// Attempt to Create the action
+	id, err := db.CreateAction(action)
+	require.NoError(err)
+
+	// Attempt to Retrieve the action
+	a, err := db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(id, a.Id)
+	require.Equal(models.PendingActionState_ACTION_PROPOSED, a.Status)
+	require.Equal(action.Vasp, a.Vasp)
+	require.Equal(action.ProposedBy, a.ProposedBy)
+	require.NotEmpty(a.Created)
+	require.Equal(a.Modified, a.Created)
+
+	// Cannot create an action with an ID on it
+	_, err = db.CreateAction(&models.PendingAction{Id: uuid.New().String()})
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Sleep for a second to roll over the clock for the modified time stamp
+	time.Sleep(1 * time.Second)
+
+	// Update the action
+	a.Status = models.PendingActionState_ACTION_EXECUTED
+	a.ResolvedBy = "bob@example.com"
+	require.NoError(db.UpdateAction(a))
+
+	a, err = db.RetrieveAction(id)
+	require.NoError(err)
+	require.Equal(models.PendingActionState_ACTION_EXECUTED, a.Status)
+	require.Equal("bob@example.com", a.ResolvedBy)
+	require.NotEqual(a.Modified, a.Created)
+
+	// Cannot update an action without an ID
+	require.ErrorIs(db.UpdateAction(&models.PendingAction{}), storeerrors.ErrIncompleteRecord)
+
+	// Add a few more actions and list them
+	for i := 0; i < 5; i++ {
+		_, err = db.CreateAction(&models.PendingAction{Type: models.PendingActionType_REJECT_REGISTRATION, Vasp: uuid.New().String()})
+		require.NoError(err)
+	}
+
+	actions, err := db.ListActions().All()
+	require.NoError(err)
+	require.Len(actions, 6)
+
+	// Delete the action
+	require.NoError(db.DeleteAction(id))
+	_, err = db.RetrieveAction(id)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+}
+
 func createVASPs(db *store.Store, num, startIndex int) error {
 	countries := []string{"TV", "KY", "CC", "LT", "EH", "SC", "NU"}
 	bcats := []pb.BusinessCategory{pb.BusinessCategoryBusiness, pb.BusinessCategoryNonCommercial, pb.BusinessCategoryPrivate}
@@ -600,3 +796,228 @@ func deleteVASPs(db *store.Store) error {
 	db.DeleteIndices()
 	return nil
 }
+
+// Test that the indices of a store are kept up to date with the changes made to the
+// VASPs by another store connected to the same trtl database.
+func (s *trtlStoreTestSuite) TestWatchIndices() {
+	require := s.Require()
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	writer, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	watcher, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Watch(ctx)
+
+	// Helper to lookup the names of a VASP in the watcher's index
+	names := func(id string) []string {
+		watcher.RLock()
+		defer watcher.RUnlock()
+		names, _ := watcher.GetNamesIndex().Reverse(id)
+		return names
+	}
+
+	// The VASP is created after the watcher has loaded its indices
+	require.NoError(createVASPs(writer, 1, 1))
+	vasps, err := writer.ListVASPs().All()
+	require.NoError(err)
+	require.Len(vasps, 1)
+	vasp := vasps[0]
+
+	// The watch stream may not have been established when the VASP was created, so
+	// keep writing the VASP until the change is received by the watcher.
+	require.Eventually(func() bool {
+		require.NoError(writer.UpdateVASP(vasp))
+		return len(names(vasp.Id)) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Contains(names(vasp.Id), "trisa0001.test.net")
+
+	// Renaming the VASP should replace the previous names in the index
+	vasp, err = writer.RetrieveVASP(vasp.Id)
+	require.NoError(err)
+	vasp.CommonName = "renamed.test.net"
+	require.NoError(writer.UpdateVASP(vasp))
+	require.Eventually(func() bool {
+		return contains(names(vasp.Id), "renamed.test.net")
+	}, 5*time.Second, 100*time.Millisecond)
+	require.NotContains(names(vasp.Id), "trisa0001.test.net")
+
+	// Deleting the VASP should remove it from the index
+	require.NoError(writer.DeleteVASP(vasp.Id))
+	require.Eventually(func() bool {
+		return len(names(vasp.Id)) == 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	require.NoError(deleteVASPs(writer), "could not cleanup database")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *trtlStoreTestSuite) TestAuditStore() {
+	require := s.Require()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Should get a not found error trying to retrieve a record that doesn't exist
+	_, err = db.RetrieveAuditRecord(uuid.New().String())
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	record := &models.AuditRecord{
+		Actor:     "alice@example.com",
+		Action:    "DELETE /v2/vasps/:vaspID",
+		Target:    uuid.New().String(),
+		RequestId: uuid.New().String(),
+		Status:    200,
+		Changes: []*models.FieldChange{
+			{Path: "vasp", Before: `{"id":"42"}`},
+		},
+	}
+
+	// Attempt to Create the record
+	id, err := db.CreateAuditRecord(record)
+	require.NoError(err)
+	require.NotEmpty(record.Timestamp)
+
+	// Attempt to Retrieve the record
+	r, err := db.RetrieveAuditRecord(id)
+	require.NoError(err)
+	require.True(proto.Equal(record, r))
+
+	// Cannot append a record that already has an ID
+	_, err = db.CreateAuditRecord(r)
+	require.ErrorIs(err, storeerrors.ErrIDAlreadySet)
+
+	// Add a few more records recorded an hour apart and list them, most recent first
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		ts := now.Add(-time.Duration(i+1) * time.Hour).Format(time.RFC3339Nano)
+		_, err = db.CreateAuditRecord(&models.AuditRecord{Timestamp: ts, Actor: "bob@example.com", Action: "POST /v2/vasps/:vaspID/notes"})
+		require.NoError(err)
+	}
+
+	records, err := db.ListAuditRecords().All()
+	require.NoError(err)
+	require.Len(records, 6)
+	require.Equal(id, records[0].Id)
+	for i, record := range records[1:] {
+		require.Equal(now.Add(-time.Duration(i+1)*time.Hour).Format(time.RFC3339Nano), record.Timestamp)
+	}
+
+	// Seek to the records created before a timestamp
+	iter := db.ListAuditRecords()
+	require.True(iter.SeekTimestamp(now.Add(-150 * time.Minute)))
+	r, err = iter.Record()
+	require.NoError(err)
+	require.Equal(records[3].Id, r.Id)
+	require.True(iter.Next())
+	require.True(iter.Next())
+	require.False(iter.Next())
+	require.NoError(iter.Error())
+	iter.Release()
+}
+
+func (s *trtlStoreTestSuite) TestRevisionStore() {
+	require := s.Require()
+
+	// Inject bufconn connection into the store
+	require.NoError(s.grpc.Connect(context.Background()))
+	defer s.grpc.Close()
+
+	db, err := store.NewMock(s.grpc.Conn)
+	require.NoError(err)
+
+	// Should get a not found error trying to retrieve a revision that doesn't exist
+	_, err = db.RetrieveRevision(uuid.New().String(), 1)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Creating a VASP stores the first revision
+	vasp := &pb.VASP{
+		CommonName: "revisions.example.com",
+		Website:    "https://revisions.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Revisions, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	id, err := db.CreateVASP(vasp)
+	require.NoError(err)
+
+	other := &pb.VASP{
+		CommonName: "other.example.com",
+		Entity: &ivms101.LegalPerson{
+			Name: &ivms101.LegalPersonName{
+				NameIdentifiers: []*ivms101.LegalPersonNameId{
+					{LegalPersonName: "Other, Inc.", LegalPersonNameIdentifierType: ivms101.LegalPersonLegal},
+				},
+			},
+		},
+	}
+	_, err = db.CreateVASP(other)
+	require.NoError(err)
+
+	// Each update stores a new revision; an update made from a stale copy is rejected
+	// without storing a revision.
+	stale := proto.Clone(vasp).(*pb.VASP)
+	vasp.Website = "https://revisions.example.org"
+	require.NoError(db.UpdateVASP(vasp))
+	stale.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.ErrorIs(db.UpdateVASP(stale), storeerrors.ErrConcurrentUpdate)
+	require.Equal(uint64(1), stale.Version.Version, "version should be restored on failed update")
+
+	vasp.VerificationStatus = pb.VerificationState_PENDING_REVIEW
+	require.NoError(db.UpdateVASP(vasp))
+
+	revisions, err := db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Len(revisions, 3)
+	for i, rev := range revisions {
+		require.Equal(id, rev.Vasp)
+		require.Equal(uint64(i+1), rev.Revision)
+		require.NotEmpty(rev.Created)
+	}
+	require.Equal("https://revisions.example.com", revisions[0].Record.Website)
+	require.Equal("https://revisions.example.org", revisions[1].Record.Website)
+	require.Equal(pb.VerificationState_PENDING_REVIEW, revisions[2].Record.VerificationStatus)
+
+	// Attempt to Retrieve a revision
+	rev, err := db.RetrieveRevision(id, 2)
+	require.NoError(err)
+	require.True(proto.Equal(revisions[1], rev))
+	require.True(proto.Equal(vasp, revisions[2].Record))
+
+	_, err = db.RetrieveRevision(id, 4)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+	_, err = db.RetrieveRevision(id, 0)
+	require.ErrorIs(err, storeerrors.ErrEntityNotFound)
+
+	// Revisions are deleted with the VASP
+	require.NoError(db.DeleteVASP(id))
+	revisions, err = db.ListRevisions(id).All()
+	require.NoError(err)
+	require.Empty(revisions)
+
+	revisions, err = db.ListRevisions(other.Id).All()
+	require.NoError(err)
+	require.Len(revisions, 1)
+	require.NoError(db.DeleteVASP(other.Id))
+}
@@ -0,0 +1,140 @@
+package trtl
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	gds "github.com/trisacrypto/trisa/pkg/trisa/gds/models/v1beta1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// How long to wait before reconnecting to trtl if the watch stream is interrupted.
+const watchRetryInterval = 5 * time.Second
+
+//===========================================================================
+// Change Feed
+//===========================================================================
+
+// Watch the VASPs namespace for changes and apply them to the name, website, country,
+// category, and full text indices so that the indices reflect the records written by
+// other GDS replicas (directly or through trtl anti-entropy). Watch blocks until the
+// context is canceled or the stream is interrupted. The cursor of the last event is
+// retained so that a subsequent call resumes without missing changes; if trtl cannot
+// resume from the cursor or a change cannot be applied, the store is reindexed so that
+// the indices do not diverge from the replicated records.
+//
+// NOTE: Watch should only be called from one go routine at a time.
+func (s *Store) Watch(ctx context.Context) (err error) {
+	var stream pb.Trtl_WatchClient
+	if stream, err = s.client.Watch(ctx, &pb.WatchRequest{Namespace: wire.NamespaceVASPs, Cursor: s.cursor}); err != nil {
+		return err
+	}
+
+	for {
+		var event *pb.WatchEvent
+		if event, err = stream.Recv(); err != nil {
+			if ctx.Err() != nil || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+
+		if err = s.applyEvent(event); err != nil {
+			log.Error().Err(err).Str("type", event.Type.String()).Str("key", string(event.Key)).Msg("could not apply change to indices, reindexing")
+			if err = s.Reindex(); err != nil {
+				// NOTE: if this error is triggered, admins may want to reindex the database.
+				log.Error().Err(err).Msg("could not reindex after watch event: reindex required")
+			}
+		}
+		s.cursor = event.Cursor
+	}
+}
+
+// watch runs Watch in a loop, reconnecting if the stream is interrupted, until the
+// context is canceled.
+func (s *Store) watch(ctx context.Context) {
+	for {
+		if err := s.Watch(ctx); err != nil {
+			log.Warn().Err(err).Msg("vasps watch stream interrupted")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
+
+// Apply a change to a VASP record to the indices.
+func (s *Store) applyEvent(event *pb.WatchEvent) (err error) {
+	switch event.Type {
+	case pb.WatchEvent_PUT:
+		vasp := &gds.VASP{}
+		if err = proto.Unmarshal(event.Value, vasp); err != nil {
+			return err
+		}
+
+		s.Lock()
+		err = s.refreshIndices(string(event.Key), vasp)
+		s.Unlock()
+		return err
+	case pb.WatchEvent_DELETE:
+		s.Lock()
+		err = s.refreshIndices(string(event.Key), nil)
+		s.Unlock()
+		return err
+	case pb.WatchEvent_RESYNC:
+		log.Info().Msg("vasps watch could not be resumed, reindexing")
+		return s.Reindex()
+	}
+	return nil
+}
+
+// Replace the index entries of the VASP with the entries of the updated record, or
+// remove them if the record was deleted. Unlike removeIndices, the previous entries are
+// found by reverse lookup since the previous version of the record is not available.
+// The caller must hold the lock.
+func (s *Store) refreshIndices(id string, v *gds.VASP) error {
+	if names, ok := s.names.Reverse(id); ok {
+		for _, name := range names {
+			s.names.Remove(name)
+		}
+	}
+
+	if websites, ok := s.websites.Reverse(id); ok {
+		for _, website := range websites {
+			s.websites.Remove(website)
+		}
+	}
+
+	if countries, ok := s.countries.Reverse(id); ok {
+		for _, country := range countries {
+			s.countries.Remove(country, id)
+		}
+	}
+
+	if categories, ok := s.categories.Reverse(id); ok {
+		for _, category := range categories {
+			s.categories.Remove(category, id)
+		}
+	}
+
+	if tokens, ok := s.fulltext.Reverse(id); ok {
+		for _, token := range tokens {
+			// Tokens are counted for each time they appear in the record
+			for s.fulltext.Remove(token, id) {
+			}
+		}
+	}
+
+	if v != nil {
+		return s.insertIndices(v)
+	}
+	return nil
+}
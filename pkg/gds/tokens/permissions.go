@@ -0,0 +1,56 @@
+package tokens
+
+import "fmt"
+
+// Permissions are included in the claims of access tokens and are required by the
+// admin API endpoints to perform specific actions.
+const (
+	ReadPermission   = "read"   // view summaries, VASP records, certificates, and notes
+	ReviewPermission = "review" // review registrations, resend emails, and manage notes
+	UpdatePermission = "update" // modify VASP records and contacts
+	DeletePermission = "delete" // delete VASP records and revoke certificates
+)
+
+// Roles are assigned to admin users in the GDS configuration and determine the
+// permissions that are included in their access tokens when they authenticate.
+const (
+	ReadOnlyRole  = "readonly"
+	ReviewerRole  = "reviewer"
+	SuperuserRole = "superuser"
+)
+
+var rolePermissions = map[string][]string{
+	ReadOnlyRole:  {ReadPermission},
+	ReviewerRole:  {ReadPermission, ReviewPermission},
+	SuperuserRole: {ReadPermission, ReviewPermission, UpdatePermission, DeletePermission},
+}
+
+// RolePermissions returns the permissions granted to the specified role or an error if
+// the role is not defined.
+func RolePermissions(role string) (_ []string, err error) {
+	permissions, ok := rolePermissions[role]
+	if !ok {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+
+	// Return a copy so that the role definitions cannot be modified by the caller.
+	return append([]string(nil), permissions...), nil
+}
+
+// HasPermission returns true if the claims include all of the specified permissions.
+func (c *Claims) HasPermission(permissions ...string) bool {
+	for _, required := range permissions {
+		granted := false
+		for _, permission := range c.Permissions {
+			if permission == required {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			return false
+		}
+	}
+	return true
+}
@@ -53,6 +53,9 @@ type Claims struct {
 	Email   string `json:"email,omitempty"`
 	Name    string `json:"name,omitempty"`
 	Picture string `json:"picture,omitempty"`
+
+	// Permissions granted to the user by their role, required to access admin routes.
+	Permissions []string `json:"permissions,omitempty"`
 }
 
 // New creates a TokenManager with the specified keys which should be a mapping of KSUID
@@ -181,6 +184,7 @@ func (tm *TokenManager) CreateAccessToken(creds interface{}) (_ *jwt.Token, err
 		claims.Name = t.Name
 		claims.Picture = t.Picture
 		claims.Subject = t.Subject
+		claims.Permissions = t.Permissions
 	default:
 		return nil, fmt.Errorf("cannot create access token from %T", t)
 	}
@@ -276,6 +280,31 @@ func (c *Claims) extractClaims(o map[string]interface{}) (err error) {
 		c.extractClaim(key, o)
 	}
 
+	return c.extractPermissions(o)
+}
+
+// Permissions are optional but if specified must be a list of strings.
+func (c *Claims) extractPermissions(o map[string]interface{}) error {
+	val, ok := o["permissions"]
+	if !ok || val == nil {
+		return nil
+	}
+
+	switch t := val.(type) {
+	case []string:
+		c.Permissions = t
+	case []interface{}:
+		c.Permissions = make([]string, 0, len(t))
+		for _, item := range t {
+			permission, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("claim %q contains %T, expected string", "permissions", item)
+			}
+			c.Permissions = append(c.Permissions, permission)
+		}
+	default:
+		return fmt.Errorf("claim %q is not a list of strings", "permissions")
+	}
 	return nil
 }
 
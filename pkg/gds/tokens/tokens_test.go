@@ -349,6 +349,49 @@ func (s *TokenTestSuite) TestParseExpiredToken() {
 }
 
 // Execute suite as a go test.
+func (s *TokenTestSuite) TestPermissions() {
+	require := s.Require()
+	tm, err := tokens.New(s.testdata, "http://localhost:3000")
+	require.NoError(err, "could not initialize token manager")
+
+	permissions, err := tokens.RolePermissions(tokens.ReviewerRole)
+	require.NoError(err, "could not get reviewer permissions")
+	require.Equal([]string{tokens.ReadPermission, tokens.ReviewPermission}, permissions)
+
+	_, err = tokens.RolePermissions("janitor")
+	require.EqualError(err, `unknown role "janitor"`)
+
+	// Permissions should be extracted from the credentials
+	creds := map[string]interface{}{
+		"hd":          "rotational.io",
+		"email":       "kate@rotational.io",
+		"permissions": []interface{}{tokens.ReadPermission, tokens.ReviewPermission},
+	}
+
+	accessToken, err := tm.CreateAccessToken(creds)
+	require.NoError(err, "could not create access token")
+	claims := accessToken.Claims.(*tokens.Claims)
+	require.True(claims.HasPermission(tokens.ReadPermission))
+	require.True(claims.HasPermission(tokens.ReadPermission, tokens.ReviewPermission))
+	require.False(claims.HasPermission(tokens.ReadPermission, tokens.DeletePermission))
+
+	// Permissions should be retained when the token is verified and reissued
+	tks, err := tm.Sign(accessToken)
+	require.NoError(err, "could not sign access token")
+	claims, err = tm.Verify(tks)
+	require.NoError(err, "could not verify access token")
+	require.Equal(permissions, claims.Permissions)
+
+	accessToken, err = tm.CreateAccessToken(claims)
+	require.NoError(err, "could not reissue access token")
+	require.Equal(permissions, accessToken.Claims.(*tokens.Claims).Permissions)
+
+	// Permissions must be strings
+	creds["permissions"] = []interface{}{tokens.ReadPermission, 42}
+	_, err = tm.CreateAccessToken(creds)
+	require.Error(err, "should not be able to create a token with invalid permissions")
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, new(TokenTestSuite))
 }
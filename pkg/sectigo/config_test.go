@@ -18,3 +18,20 @@ func TestConfigValidation(t *testing.T) {
 	conf.Profile = sectigo.AllProfiles[0]
 	require.NoError(t, conf.Validate())
 }
+
+func TestProfileID(t *testing.T) {
+	for _, profile := range []string{sectigo.ProfileCipherTraceEE, sectigo.ProfileIDCipherTraceEE} {
+		id, err := sectigo.ProfileID(profile)
+		require.NoError(t, err)
+		require.Equal(t, 17, id)
+	}
+
+	for _, profile := range []string{sectigo.ProfileCipherTraceEndEntityCertificate, sectigo.ProfileIDCipherTraceEndEntityCertificate} {
+		id, err := sectigo.ProfileID(profile)
+		require.NoError(t, err)
+		require.Equal(t, 85, id)
+	}
+
+	_, err := sectigo.ProfileID("invalid profile")
+	require.EqualError(t, err, `"invalid profile" is not a valid Sectigo profile name or ID`)
+}
@@ -16,6 +16,7 @@ import (
 	"net/textproto"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -34,6 +35,22 @@ var AllProfiles = [4]string{
 	ProfileCipherTraceEndEntityCertificate, ProfileIDCipherTraceEndEntityCertificate,
 }
 
+// ProfileID returns the numeric ID of a profile that is specified either by name or by
+// ID, e.g. for endpoints such as certificate revocation that require the profile ID.
+func ProfileID(profile string) (id int, err error) {
+	switch profile {
+	case ProfileCipherTraceEE:
+		profile = ProfileIDCipherTraceEE
+	case ProfileCipherTraceEndEntityCertificate:
+		profile = ProfileIDCipherTraceEndEntityCertificate
+	}
+
+	if id, err = strconv.Atoi(profile); err != nil {
+		return 0, fmt.Errorf("%q is not a valid Sectigo profile name or ID", profile)
+	}
+	return id, nil
+}
+
 // Sectigo provides authenticated http requests to the Sectigo IoT Manager 20.7 REST API.
 // See documentation at: https://support.sectigo.com/Com_KnowledgeDetailPage?Id=kA01N000000bvCJ
 //
@@ -40,11 +40,14 @@ func (s *trtlTestSuite) TestBackupManager() {
 		backup.Run()
 	}()
 
-	// Wait for the backup manager to run through its loop. The shutdown check is at
-	// the beginning so there is a timing window here.
-	time.Sleep(conf.Interval * 3)
+	// Wait for the backup manager to create an archive rather than sleeping, otherwise
+	// the stop signal may be received before the first tick on a busy machine.
+	require.Eventually(func() bool {
+		archives, err := filepath.Glob(filepath.Join(backupDir, "trtldb-*.tgz"))
+		return err == nil && len(archives) > 0
+	}, 5*time.Second, conf.Interval, "no backup was created")
 
-	// Make sure that the backup manager is stopped
+	// Shutdown blocks until the current backup and cleanup are complete
 	require.NoError(backup.Shutdown())
 	wg.Wait()
 
@@ -0,0 +1,265 @@
+package trtl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	engine "github.com/rotationalio/honu/engines"
+	honuldb "github.com/rotationalio/honu/engines/leveldb"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// honu's leveldb engine stores namespaced keys as namespace::key.
+var nssep = []byte("::")
+
+// batchError identifies the operation of an atomic batch that caused it to be aborted.
+type batchError struct {
+	id  int64
+	err error
+}
+
+func (e *batchError) Error() string {
+	return e.err.Error()
+}
+
+// atomicBatch receives the rest of a Batch stream whose first request is marked atomic
+// and applies all of the operations to the database in a single leveldb write batch.
+// If any operation is invalid or its preconditions are not met, none of the operations
+// are applied and the reply lists the operation that caused the batch to be aborted.
+// Preconditions are evaluated in order, against the state of the object after the
+// previous operations of the batch. Keys that are modified more than once are only
+// versioned once, with the final value (or tombstone) of the key. Because the batch is
+// held in memory until it is applied, batches larger than maxAtomicBatchSize are
+// rejected.
+func (h *TrtlService) atomicBatch(stream pb.Trtl_BatchServer, first *pb.BatchRequest) (err error) {
+	requests := []*pb.BatchRequest{first}
+	for {
+		var in *pb.BatchRequest
+		if in, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if len(requests) >= maxAtomicBatchSize {
+			log.Debug().Int("max_size", maxAtomicBatchSize).Msg("atomic batch is too large")
+			return status.Errorf(codes.ResourceExhausted, "atomic batch cannot contain more than %d operations", maxAtomicBatchSize)
+		}
+		requests = append(requests, in)
+	}
+
+	out := &pb.BatchReply{Operations: int64(len(requests))}
+	for _, in := range requests {
+		if err = validateBatchRequest(in); err != nil {
+			out.Errors = append(out.Errors, &pb.BatchReply_Error{Id: in.Id, Error: err.Error()})
+		}
+	}
+
+	if len(out.Errors) > 0 {
+		log.Debug().Int("errors", len(out.Errors)).Msg("atomic batch contains invalid operations")
+		out.Failed = out.Operations
+		return stream.SendAndClose(out)
+	}
+
+	var objects []*object.Object
+	if objects, err = h.commit(requests); err != nil {
+		var berr *batchError
+		if errors.As(err, &berr) {
+			log.Debug().Err(berr.err).Int64("id", berr.id).Msg("atomic batch aborted")
+			out.Failed = out.Operations
+			out.Errors = append(out.Errors, &pb.BatchReply_Error{Id: berr.id, Error: berr.Error()})
+			return stream.SendAndClose(out)
+		}
+
+		log.Error().Err(err).Msg("could not apply atomic batch")
+		return status.Error(codes.Internal, "could not apply atomic batch")
+	}
+
+	for _, obj := range objects {
+		h.feed.Publish(obj)
+		h.parent.replica.Observe(obj)
+		if obj.Tombstone() {
+			prom.PmDels.WithLabelValues(obj.Namespace).Inc()
+		} else {
+			prom.PmPuts.WithLabelValues(obj.Namespace).Inc()
+		}
+	}
+
+	out.Successful = out.Operations
+	out.Committed = true
+	log.Debug().Int64("operations", out.Operations).Int("keys", len(objects)).Msg("atomic batch committed")
+	return stream.SendAndClose(out)
+}
+
+// commit applies the operations of an atomic batch and returns the objects that were
+// written. The engine write transaction is held while the batch is prepared so that no
+// other writes (including anti-entropy repairs) are interleaved with the batch.
+func (h *TrtlService) commit(requests []*pb.BatchRequest) (_ []*object.Object, err error) {
+	ldb, ok := h.db.Engine().(*honuldb.LevelDBEngine)
+	if !ok {
+		return nil, fmt.Errorf("atomic batches are not supported by the %s engine", h.db.Engine().Engine())
+	}
+
+	h.writes.Lock()
+	defer h.writes.Unlock()
+
+	var tx engine.Transaction
+	if tx, err = ldb.Begin(false); err != nil {
+		return nil, err
+	}
+	defer tx.Finish()
+
+	// The objects modified by the batch, indexed by namespaced key, and the order in
+	// which they were first modified.
+	modified := make(map[string]*object.Object)
+	order := make([]string, 0, len(requests))
+
+	for _, in := range requests {
+		key, namespace, opts := batchTarget(in)
+		if namespace == "" {
+			namespace = options.NamespaceDefault
+		}
+		ref := string(bytes.Join([][]byte{[]byte(namespace), key}, nssep))
+
+		current, seen := modified[ref]
+		if !seen {
+			if current, err = getObject(tx, key, namespace); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = preconditionsMet(key, current, opts); err != nil {
+			return nil, &batchError{id: in.Id, err: err}
+		}
+
+		switch req := in.Request.(type) {
+		case *pb.BatchRequest_Put:
+			if current == nil {
+				current = &object.Object{Key: key, Namespace: namespace}
+			}
+
+			if !seen {
+				if err = h.vm.Update(current); err != nil {
+					return nil, err
+				}
+			} else {
+				current.Version.Tombstone = false
+			}
+			current.Data = req.Put.Value
+
+		case *pb.BatchRequest_Delete:
+			if current == nil || current.Tombstone() {
+				return nil, &batchError{id: in.Id, err: status.Error(codes.NotFound, engine.ErrNotFound.Error())}
+			}
+
+			if !seen {
+				if err = h.vm.Delete(current); err != nil {
+					return nil, err
+				}
+			} else {
+				current.Version.Tombstone = true
+			}
+			current.Data = nil
+		}
+
+		if !seen {
+			modified[ref] = current
+			order = append(order, ref)
+		}
+	}
+
+	batch := new(leveldb.Batch)
+	objects := make([]*object.Object, 0, len(order))
+	for _, ref := range order {
+		var data []byte
+		if data, err = proto.Marshal(modified[ref]); err != nil {
+			return nil, err
+		}
+		batch.Put([]byte(ref), data)
+		objects = append(objects, modified[ref])
+	}
+
+	if err = ldb.DB().Write(batch, nil); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// validateBatchRequest performs the same validation as Put and Delete on an operation
+// of an atomic batch before any of the operations are applied.
+func validateBatchRequest(in *pb.BatchRequest) error {
+	switch req := in.Request.(type) {
+	case *pb.BatchRequest_Put:
+		if req.Put == nil {
+			return status.Error(codes.InvalidArgument, "missing request field")
+		}
+		if len(req.Put.Value) == 0 {
+			return status.Error(codes.InvalidArgument, "value must be provided in Put request")
+		}
+	case *pb.BatchRequest_Delete:
+		if req.Delete == nil {
+			return status.Error(codes.InvalidArgument, "missing request field")
+		}
+	case nil:
+		return status.Error(codes.InvalidArgument, "missing request field")
+	default:
+		return status.Error(codes.InvalidArgument, "unknown request type")
+	}
+
+	key, namespace, opts := batchTarget(in)
+	if _, found := reservedNamespaces[namespace]; found {
+		return status.Error(codes.PermissionDenied, "cannot use reserved namespace")
+	}
+	if len(key) == 0 {
+		return status.Error(codes.InvalidArgument, "key must be provided in batch request")
+	}
+	if err := validatePreconditions(opts); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// batchTarget returns the key, namespace, and options of a Put or Delete operation.
+func batchTarget(in *pb.BatchRequest) (key []byte, namespace string, opts *pb.Options) {
+	switch req := in.Request.(type) {
+	case *pb.BatchRequest_Put:
+		return req.Put.Key, req.Put.Namespace, req.Put.Options
+	case *pb.BatchRequest_Delete:
+		return req.Delete.Key, req.Delete.Namespace, req.Delete.Options
+	}
+	return nil, "", nil
+}
+
+// getObject returns the current version of the object, including tombstones, or nil if
+// the object does not exist.
+func getObject(tx engine.Transaction, key []byte, namespace string) (_ *object.Object, err error) {
+	var cfg *options.Options
+	if cfg, err = options.New(options.WithNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if data, err = tx.Get(key, cfg); err != nil {
+		if errors.Is(err, engine.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	obj := &object.Object{}
+	if err = proto.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
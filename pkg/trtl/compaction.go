@@ -0,0 +1,255 @@
+package trtl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rotationalio/honu"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	nspb "github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"google.golang.org/protobuf/proto"
+)
+
+// CompactionManager runs as an independent service which periodically removes the
+// tombstones left by deletes from the replicated namespaces and updates the object and
+// tombstone gauges for each namespace. A tombstone must be kept until every peer has
+// received it, otherwise anti-entropy with a peer that still has an earlier version of
+// the object would resurrect it. Because honu versions are not timestamped, the manager
+// records when it first observes each tombstone; the tombstone is removed once it has
+// been observed for longer than the configured horizon and the local replica has since
+// synchronized the namespace with every known peer that the namespace replicates to.
+//
+// Observations are kept in memory, so the horizon of every tombstone restarts when the
+// process restarts. This can only delay compaction, never remove a tombstone early.
+type CompactionManager struct {
+	sync.Mutex
+	conf    config.CompactionConfig
+	db      *honu.DB
+	replica *replica.Service
+	seen    map[string]map[string]*observation
+	stop    chan struct{}
+}
+
+// observation records when the compaction manager first saw a tombstone version.
+type observation struct {
+	pid     uint64
+	version uint64
+	seen    time.Time
+}
+
+func NewCompactionManager(conf config.CompactionConfig, db *honu.DB, replica *replica.Service) (*CompactionManager, error) {
+	return &CompactionManager{
+		conf:    conf,
+		db:      db,
+		replica: replica,
+		seen:    make(map[string]map[string]*observation),
+		stop:    make(chan struct{}),
+	}, nil
+}
+
+// Runs the main CompactionManager routine which compacts the replicated namespaces when
+// it starts (so that tombstones are observed as early as possible) and then periodically
+// at the configured interval.
+func (m *CompactionManager) Run() {
+	if !m.conf.Enabled {
+		log.Warn().Msg("trtl compaction disabled")
+		return
+	}
+
+	ticker := time.NewTicker(m.conf.Interval)
+	log.Info().Dur("interval", m.conf.Interval).Dur("horizon", m.conf.Horizon).Msg("trtl compaction manager started")
+
+	for {
+		start := time.Now()
+		if stats, err := m.Compact(nil, false); err != nil {
+			log.Error().Err(err).Msg("could not compact database")
+		} else {
+			var compacted uint64
+			for _, ns := range stats {
+				compacted += ns.Compacted
+			}
+			log.Info().Uint64("compacted", compacted).Dur("duration", time.Since(start)).Msg("trtl compaction complete")
+		}
+
+		// Wait for next tick or a stop message
+		select {
+		case <-m.stop:
+			log.Warn().Msg("trtl compaction manager received stop signal")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *CompactionManager) Shutdown() error {
+	if m.stop != nil {
+		// Should block until the current compaction completes
+		m.stop <- struct{}{}
+
+		// Close the channel and set it to nil so that multiple shutdown calls don't block.
+		close(m.stop)
+		m.stop = nil
+	}
+	return nil
+}
+
+// Compact the specified namespaces (or all replicated namespaces if none are specified),
+// removing the tombstones that have expired and have been seen by all peers. If dryRun
+// is true the tombstones that would be removed are counted but not removed. Only one
+// compaction runs at a time.
+func (m *CompactionManager) Compact(namespaces []string, dryRun bool) (stats []*pb.CompactionStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(namespaces) == 0 {
+		var policies []*nspb.Policy
+		if policies, err = m.replica.Registry().Replicated(); err != nil {
+			return nil, fmt.Errorf("could not load replication policies: %w", err)
+		}
+
+		namespaces = make([]string, 0, len(policies))
+		for _, policy := range policies {
+			namespaces = append(namespaces, policy.Namespace)
+		}
+	}
+
+	stats = make([]*pb.CompactionStats, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		// Determine which tombstones every peer has received before scanning the
+		// namespace; tombstones observed after this time may not have been sent to all
+		// peers.
+		var synced time.Time
+		if synced, err = m.replica.PeersSyncedAt(namespace); err != nil {
+			return nil, fmt.Errorf("could not determine peer synchronization: %w", err)
+		}
+
+		var ns *pb.CompactionStats
+		if ns, err = m.compact(namespace, synced, dryRun); err != nil {
+			return nil, fmt.Errorf("could not compact namespace %s: %w", namespace, err)
+		}
+		stats = append(stats, ns)
+	}
+	return stats, nil
+}
+
+func (m *CompactionManager) compact(namespace string, synced time.Time, dryRun bool) (stats *pb.CompactionStats, err error) {
+	now := time.Now()
+	stats = &pb.CompactionStats{Namespace: namespace}
+
+	// Replace the observations for the namespace with the tombstones currently in the
+	// database so that overwritten and removed tombstones are forgotten.
+	prev := m.seen[namespace]
+	seen := make(map[string]*observation)
+	expired := make([]*object.Object, 0)
+
+	iter, err := m.db.Iter(nil, options.WithNamespace(namespace), options.WithTombstones())
+	if err != nil {
+		return nil, err
+	}
+
+	for iter.Next() {
+		var obj *object.Object
+		if obj, err = iter.Object(); err != nil {
+			iter.Release()
+			return nil, fmt.Errorf("could not unmarshal honu metadata: %w", err)
+		}
+
+		if !obj.Tombstone() {
+			stats.Objects++
+			continue
+		}
+		stats.Tombstones++
+
+		key := string(obj.Key)
+		obs, ok := prev[key]
+		if !ok || obs.pid != obj.Version.Pid || obs.version != obj.Version.Version {
+			obs = &observation{pid: obj.Version.Pid, version: obj.Version.Version, seen: now}
+		}
+		seen[key] = obs
+
+		if now.Sub(obs.seen) >= m.conf.Horizon && !obs.seen.After(synced) {
+			expired = append(expired, obj)
+		}
+	}
+
+	err = iter.Error()
+	iter.Release()
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the expired tombstones after the iterator is released
+	for _, obj := range expired {
+		if !dryRun {
+			var removed bool
+			if removed, err = m.remove(namespace, obj); err != nil {
+				return nil, err
+			}
+
+			if !removed {
+				continue
+			}
+			delete(seen, string(obj.Key))
+		}
+		stats.Compacted++
+		stats.Tombstones--
+	}
+	m.seen[namespace] = seen
+
+	prom.PmObjects.WithLabelValues(namespace).Set(float64(stats.Objects))
+	if dryRun {
+		prom.PmTombstones.WithLabelValues(namespace).Set(float64(stats.Tombstones + stats.Compacted))
+	} else {
+		prom.PmTombstones.WithLabelValues(namespace).Set(float64(stats.Tombstones))
+		prom.PmCompacted.WithLabelValues(namespace).Add(float64(stats.Compacted))
+	}
+	return stats, nil
+}
+
+// Permanently remove the tombstone from the underlying engine. The object is checked in
+// a write transaction so that the tombstone is not removed if it has been overwritten
+// by a Put or by anti-entropy since it was observed.
+func (m *CompactionManager) remove(namespace string, tombstone *object.Object) (_ bool, err error) {
+	var cfg *options.Options
+	if cfg, err = options.New(options.WithNamespace(namespace)); err != nil {
+		return false, err
+	}
+
+	var tx engine.Transaction
+	if tx, err = m.db.Engine().Begin(false); err != nil {
+		return false, err
+	}
+	defer tx.Finish()
+
+	var data []byte
+	if data, err = tx.Get(tombstone.Key, cfg); err != nil {
+		if errors.Is(err, engine.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	current := &object.Object{}
+	if err = proto.Unmarshal(data, current); err != nil {
+		return false, fmt.Errorf("could not unmarshal honu metadata: %w", err)
+	}
+
+	if !current.Tombstone() || !current.Version.Equal(tombstone.Version) {
+		return false, nil
+	}
+
+	if err = tx.Delete(tombstone.Key, cfg); err != nil {
+		return false, err
+	}
+	m.replica.Compacted(tombstone)
+	return true, nil
+}
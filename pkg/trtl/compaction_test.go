@@ -0,0 +1,113 @@
+package trtl_test
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/options"
+	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// Test that tombstones are only removed once they have been observed by a previous
+// compaction and have been seen by all peers.
+func (s *trtlTestSuite) TestCompact() {
+	// Ensure the fixtures are restored after the database is modified
+	defer s.reset()
+	require := s.Require()
+	ctx := context.Background()
+	db := s.trtl.GetDB()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	for _, key := range []string{"alpha", "bravo", "charlie"} {
+		_, err := client.Put(ctx, &pb.PutRequest{Namespace: "vasps", Key: []byte(key), Value: []byte(key)})
+		require.NoError(err)
+	}
+
+	_, err := client.Delete(ctx, &pb.DeleteRequest{Namespace: "vasps", Key: []byte("alpha")})
+	require.NoError(err)
+
+	// Only replicated namespaces can be compacted
+	_, err = client.Compact(ctx, &pb.CompactRequest{Namespace: "people"})
+	s.StatusError(err, codes.InvalidArgument, "only replicated namespaces can be compacted")
+
+	// The first compaction observes the tombstone but cannot remove it
+	rep, err := client.Compact(ctx, &pb.CompactRequest{Namespace: "vasps", DryRun: true})
+	require.NoError(err)
+	require.Len(rep.Namespaces, 1)
+	require.Equal(&pb.CompactionStats{Namespace: "vasps", Objects: 2, Tombstones: 1}, rep.Namespaces[0])
+	require.NotEmpty(rep.Duration)
+
+	// A dry run reports the tombstone without removing it
+	rep, err = client.Compact(ctx, &pb.CompactRequest{Namespace: "vasps", DryRun: true})
+	require.NoError(err)
+	require.Equal(&pb.CompactionStats{Namespace: "vasps", Objects: 2, Compacted: 1}, rep.Namespaces[0])
+
+	obj, err := db.Object([]byte("alpha"), options.WithNamespace("vasps"))
+	require.NoError(err)
+	require.True(obj.Tombstone())
+	require.Equal(float64(1), testutil.ToFloat64(prom.PmTombstones.WithLabelValues("vasps")))
+
+	// Compacting all namespaces removes the tombstone
+	rep, err = client.Compact(ctx, &pb.CompactRequest{})
+	require.NoError(err)
+	require.Len(rep.Namespaces, 8)
+	require.Equal(&pb.CompactionStats{Namespace: "vasps", Objects: 2, Compacted: 1}, rep.Namespaces[7])
+
+	_, err = db.Object([]byte("alpha"), options.WithNamespace("vasps"))
+	require.ErrorIs(err, engine.ErrNotFound)
+	require.Equal(float64(2), testutil.ToFloat64(prom.PmObjects.WithLabelValues("vasps")))
+	require.Equal(float64(0), testutil.ToFloat64(prom.PmTombstones.WithLabelValues("vasps")))
+
+	// Tombstones are not removed until the local replica has synchronized with every
+	// remote peer, which never happens in this test
+	_, err = peers.NewPeerManagementClient(s.grpc.Conn).AddPeers(ctx, &peers.Peer{
+		Id:     42,
+		Addr:   "remote.trtl.dev:443",
+		Name:   "remote",
+		Region: "tauceti",
+	})
+	require.NoError(err)
+
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Namespace: "vasps", Key: []byte("bravo")})
+	require.NoError(err)
+
+	for i := 0; i < 2; i++ {
+		rep, err = client.Compact(ctx, &pb.CompactRequest{Namespace: "vasps"})
+		require.NoError(err)
+		require.Equal(&pb.CompactionStats{Namespace: "vasps", Objects: 1, Tombstones: 1}, rep.Namespaces[0])
+	}
+
+	obj, err = db.Object([]byte("bravo"), options.WithNamespace("vasps"))
+	require.NoError(err)
+	require.True(obj.Tombstone())
+
+	// Peers in regions that the namespace is not replicated to do not need to receive
+	// the tombstone before it is removed
+	_, err = namespaces.NewNamespaceManagementClient(s.grpc.Conn).SetPolicy(ctx, &namespaces.Policy{
+		Namespace:  "vasps",
+		Replicated: true,
+		Regions:    []string{s.conf.Replica.Region},
+	})
+	require.NoError(err)
+
+	_, err = peers.NewPeerManagementClient(s.grpc.Conn).AddPeers(ctx, &peers.Peer{
+		Id:     42,
+		Addr:   "remote.trtl.dev:443",
+		Name:   "remote",
+		Region: "sirius",
+	})
+	require.NoError(err)
+
+	rep, err = client.Compact(ctx, &pb.CompactRequest{Namespace: "vasps"})
+	require.NoError(err)
+	require.Equal(&pb.CompactionStats{Namespace: "vasps", Objects: 1, Compacted: 1}, rep.Namespaces[0])
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -29,6 +30,8 @@ type Config struct {
 	ReplicaStrategy ReplicaStrategyConfig `split_words:"true"`
 	MTLS            MTLSConfig            `split_words:"true"`
 	Backup          BackupConfig          `split_words:"true"`
+	Compaction      CompactionConfig      `split_words:"true"`
+	Membership      MembershipConfig      `split_words:"true"`
 	Sentry          sentry.Config         `split_words:"true"`
 	processed       bool
 }
@@ -48,10 +51,33 @@ type ReplicaConfig struct {
 }
 
 type ReplicaStrategyConfig struct {
-	HostnamePID bool   `split_words:"true" default:"false"` // Set to true to use HostnamePID
-	Hostname    string `envconfig:"TRTL_REPLICA_HOSTNAME"`  // configure the hostname from the environment
-	FilePID     string `split_words:"true"`                 // Set to the PID filename path to use FilePID
-	JSONConfig  string `split_words:"true"`                 // Set to the config map path to use JSONConfig
+	HostnamePID   bool          `split_words:"true" default:"false"`  // Set to true to use HostnamePID
+	Hostname      string        `envconfig:"TRTL_REPLICA_HOSTNAME"`   // configure the hostname from the environment
+	FilePID       string        `split_words:"true"`                  // Set to the PID filename path to use FilePID
+	JSONConfig    string        `split_words:"true"`                  // Set to the config map path to use JSONConfig
+	PeerSelection string        `split_words:"true" default:"random"` // How to select peers for anti-entropy: random, region, least-recent, or backoff
+	PeerBackoff   time.Duration `split_words:"true" default:"1m"`     // The initial backoff after a failed sync when using backoff selection
+}
+
+// Peer selection strategies for choosing a remote peer to perform anti-entropy with.
+const (
+	PeerSelectionRandom      = "random"
+	PeerSelectionRegion      = "region"
+	PeerSelectionLeastRecent = "least-recent"
+	PeerSelectionBackoff     = "backoff"
+)
+
+// MembershipConfig enables peer discovery and failure detection. Each replica writes
+// heartbeats to its own peer record, which are propagated by anti-entropy, and removes
+// peers that have been dead for longer than the grace period.
+type MembershipConfig struct {
+	Enabled           bool          `split_words:"true" default:"false"`
+	AdvertiseAddr     string        `split_words:"true" required:"false"` // The address remote peers use to connect to this replica
+	Seeds             []string      `split_words:"true" required:"false"` // Peer addresses used to join the network if no peers are known
+	HeartbeatInterval time.Duration `split_words:"true" default:"30s"`
+	SuspectTimeout    time.Duration `split_words:"true" default:"5m"`  // Peers without a heartbeat for this long are suspect
+	DeadTimeout       time.Duration `split_words:"true" default:"15m"` // Peers without a heartbeat for this long are dead
+	GracePeriod       time.Duration `split_words:"true" default:"24h"` // How long dead peers are kept before they are removed
 }
 
 type MTLSConfig struct {
@@ -71,6 +97,12 @@ type BackupConfig struct {
 	Keep     int           `split_words:"true" default:"1"`
 }
 
+type CompactionConfig struct {
+	Enabled  bool          `split_words:"true" default:"false"`
+	Interval time.Duration `split_words:"true" default:"1h"`   // How often the compaction manager removes expired tombstones
+	Horizon  time.Duration `split_words:"true" default:"168h"` // How long a tombstone must be kept after it is first observed
+}
+
 // New creates a new Config object, loading environment variables and defaults.
 func New() (_ Config, err error) {
 	var conf Config
@@ -111,11 +143,17 @@ func (c Config) Mark() (Config, error) {
 
 // GetHonuConfig converts ReplicaConfig into honu's struct of the same name.
 func (c Config) GetHonuConfig() honuconfig.Option {
-	return honuconfig.WithReplica(honuconfig.ReplicaConfig{
+	return honuconfig.WithReplica(c.GetHonuReplicaConfig())
+}
+
+// GetHonuReplicaConfig returns the honu replica configuration, e.g. to create a version
+// manager that versions objects the same way as the honu database.
+func (c Config) GetHonuReplicaConfig() honuconfig.ReplicaConfig {
+	return honuconfig.ReplicaConfig{
 		PID:    c.Replica.PID,
 		Region: c.Replica.Region,
 		Name:   c.Replica.Name,
-	})
+	}
 }
 
 func (c Config) Validate() (err error) {
@@ -123,9 +161,30 @@ func (c Config) Validate() (err error) {
 	if err = c.Replica.Validate(); err != nil {
 		return err
 	}
+	if err = c.ReplicaStrategy.Validate(); err != nil {
+		return err
+	}
 	if err = c.MTLS.Validate(); err != nil {
 		return err
 	}
+	if err = c.Compaction.Validate(); err != nil {
+		return err
+	}
+	if err = c.Membership.Validate(); err != nil {
+		return err
+	}
+
+	if c.Membership.Enabled {
+		if !c.Replica.Enabled {
+			return errors.New("invalid configuration: membership requires an enabled replica")
+		}
+
+		// Tombstones must outlive dead peers, otherwise a dead peer that recovers after
+		// its tombstones were compacted would resurrect deleted objects.
+		if c.Compaction.Enabled && c.Compaction.Horizon < c.Membership.DeadTimeout+c.Membership.GracePeriod {
+			return errors.New("invalid configuration: compaction horizon must exceed the membership dead timeout and grace period")
+		}
+	}
 	return nil
 }
 
@@ -146,6 +205,53 @@ func (c *ReplicaConfig) Validate() error {
 	return nil
 }
 
+func (c *ReplicaStrategyConfig) Validate() error {
+	switch c.PeerSelection {
+	case "", PeerSelectionRandom, PeerSelectionRegion, PeerSelectionLeastRecent:
+	case PeerSelectionBackoff:
+		if c.PeerBackoff <= 0 {
+			return errors.New("invalid configuration: specify a positive peer backoff for backoff peer selection")
+		}
+	default:
+		return fmt.Errorf("invalid configuration: unknown peer selection strategy %q", c.PeerSelection)
+	}
+	return nil
+}
+
+func (c *CompactionConfig) Validate() error {
+	if c.Horizon < 0 {
+		return errors.New("invalid configuration: compaction horizon cannot be negative")
+	}
+
+	if c.Enabled && c.Interval <= 0 {
+		return errors.New("invalid configuration: specify a positive compaction interval")
+	}
+	return nil
+}
+
+func (c *MembershipConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.AdvertiseAddr == "" {
+		return errors.New("invalid configuration: membership requires an advertise address")
+	}
+
+	if c.HeartbeatInterval <= 0 {
+		return errors.New("invalid configuration: specify a positive heartbeat interval")
+	}
+
+	if c.SuspectTimeout <= c.HeartbeatInterval || c.DeadTimeout <= c.SuspectTimeout {
+		return errors.New("invalid configuration: membership timeouts must increase from the heartbeat interval to the suspect and dead timeouts")
+	}
+
+	if c.GracePeriod < 0 {
+		return errors.New("invalid configuration: membership grace period cannot be negative")
+	}
+	return nil
+}
+
 // Strategies extracts the replica configuration strategies from the configuration
 func (c *ReplicaStrategyConfig) Strategies() (strategies []ReplicaStrategy) {
 	strategies = make([]ReplicaStrategy, 0)
@@ -11,33 +11,40 @@ import (
 )
 
 var testEnv = map[string]string{
-	"TRTL_MAINTENANCE":              "true",
-	"TRTL_BIND_ADDR":                ":445",
-	"TRTL_METRICS_ADDR":             ":9090",
-	"TRTL_METRICS_ENABLED":          "true",
-	"TRTL_LOG_LEVEL":                "debug",
-	"TRTL_CONSOLE_LOG":              "true",
-	"TRTL_DATABASE_URL":             "leveldb:///fixtures/db",
-	"TRTL_DATABASE_REINDEX_ON_BOOT": "true",
-	"TRTL_REPLICA_ENABLED":          "true",
-	"TRTL_REPLICA_PID":              "8",
-	"TRTL_REPLICA_NAME":             "mitchell",
-	"TRTL_REPLICA_REGION":           "us-east-1c",
-	"TRTL_REPLICA_GOSSIP_INTERVAL":  "30m",
-	"TRTL_REPLICA_GOSSIP_SIGMA":     "3m",
-	"TRTL_INSECURE":                 "true",
-	"TRTL_MTLS_CHAIN_PATH":          "fixtures/certs/chain.pem",
-	"TRTL_MTLS_CERT_PATH":           "fixtures/certs/cert.pem",
-	"TRTL_BACKUP_ENABLED":           "true",
-	"TRTL_BACKUP_INTERVAL":          "1h",
-	"TRTL_BACKUP_STORAGE":           "fixtures/backups",
-	"TRTL_BACKUP_KEEP":              "7",
-	"TRTL_SENTRY_DSN":               "https://something.ingest.sentry.io",
-	"TRTL_SENTRY_ENVIRONMENT":       "test",
-	"TRTL_SENTRY_RELEASE":           "1.4",
-	"TRTL_SENTRY_DEBUG":             "true",
-	"TRTL_SENTRY_TRACK_PERFORMANCE": "true",
-	"TRTL_SENTRY_SAMPLE_RATE":       "0.2",
+	"TRTL_MAINTENANCE":               "true",
+	"TRTL_BIND_ADDR":                 ":445",
+	"TRTL_METRICS_ADDR":              ":9090",
+	"TRTL_METRICS_ENABLED":           "true",
+	"TRTL_LOG_LEVEL":                 "debug",
+	"TRTL_CONSOLE_LOG":               "true",
+	"TRTL_DATABASE_URL":              "leveldb:///fixtures/db",
+	"TRTL_DATABASE_REINDEX_ON_BOOT":  "true",
+	"TRTL_REPLICA_ENABLED":           "true",
+	"TRTL_REPLICA_PID":               "8",
+	"TRTL_REPLICA_NAME":              "mitchell",
+	"TRTL_REPLICA_REGION":            "us-east-1c",
+	"TRTL_REPLICA_GOSSIP_INTERVAL":   "30m",
+	"TRTL_REPLICA_GOSSIP_SIGMA":      "3m",
+	"TRTL_INSECURE":                  "true",
+	"TRTL_MTLS_CHAIN_PATH":           "fixtures/certs/chain.pem",
+	"TRTL_MTLS_CERT_PATH":            "fixtures/certs/cert.pem",
+	"TRTL_BACKUP_ENABLED":            "true",
+	"TRTL_BACKUP_INTERVAL":           "1h",
+	"TRTL_BACKUP_STORAGE":            "fixtures/backups",
+	"TRTL_BACKUP_KEEP":               "7",
+	"TRTL_COMPACTION_ENABLED":        "true",
+	"TRTL_COMPACTION_INTERVAL":       "6h",
+	"TRTL_COMPACTION_HORIZON":        "72h",
+	"TRTL_MEMBERSHIP_ENABLED":        "true",
+	"TRTL_MEMBERSHIP_ADVERTISE_ADDR": "mitchell.trtl.dev:4436",
+	"TRTL_MEMBERSHIP_SEEDS":          "alpha.trtl.dev:4436,bravo.trtl.dev:4436",
+	"TRTL_MEMBERSHIP_GRACE_PERIOD":   "48h",
+	"TRTL_SENTRY_DSN":                "https://something.ingest.sentry.io",
+	"TRTL_SENTRY_ENVIRONMENT":        "test",
+	"TRTL_SENTRY_RELEASE":            "1.4",
+	"TRTL_SENTRY_DEBUG":              "true",
+	"TRTL_SENTRY_TRACK_PERFORMANCE":  "true",
+	"TRTL_SENTRY_SAMPLE_RATE":        "0.2",
 }
 
 var strategyEnv = map[string]string{
@@ -87,6 +94,16 @@ func TestConfig(t *testing.T) {
 	require.Equal(t, 1*time.Hour, conf.Backup.Interval)
 	require.Equal(t, testEnv["TRTL_BACKUP_STORAGE"], conf.Backup.Storage)
 	require.Equal(t, 7, conf.Backup.Keep)
+	require.True(t, conf.Compaction.Enabled)
+	require.Equal(t, 6*time.Hour, conf.Compaction.Interval)
+	require.Equal(t, 72*time.Hour, conf.Compaction.Horizon)
+	require.True(t, conf.Membership.Enabled)
+	require.Equal(t, testEnv["TRTL_MEMBERSHIP_ADVERTISE_ADDR"], conf.Membership.AdvertiseAddr)
+	require.Equal(t, []string{"alpha.trtl.dev:4436", "bravo.trtl.dev:4436"}, conf.Membership.Seeds)
+	require.Equal(t, 30*time.Second, conf.Membership.HeartbeatInterval)
+	require.Equal(t, 5*time.Minute, conf.Membership.SuspectTimeout)
+	require.Equal(t, 15*time.Minute, conf.Membership.DeadTimeout)
+	require.Equal(t, 48*time.Hour, conf.Membership.GracePeriod)
 	require.Equal(t, testEnv["TRTL_SENTRY_DSN"], conf.Sentry.DSN)
 	require.Equal(t, testEnv["TRTL_SENTRY_ENVIRONMENT"], conf.Sentry.Environment)
 	require.Equal(t, testEnv["TRTL_SENTRY_RELEASE"], conf.Sentry.Release)
@@ -198,6 +215,51 @@ func TestValidateMTLSConfig(t *testing.T) {
 	require.NoError(t, conf.Validate())
 }
 
+func TestValidateCompactionConfig(t *testing.T) {
+	// The interval is only required when compaction is enabled
+	conf := &config.CompactionConfig{Horizon: time.Hour}
+	require.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: specify a positive compaction interval")
+
+	conf.Interval = time.Minute
+	require.NoError(t, conf.Validate())
+
+	// A zero horizon compacts tombstones as soon as all peers have seen them
+	conf.Horizon = 0
+	require.NoError(t, conf.Validate())
+
+	conf.Horizon = -time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: compaction horizon cannot be negative")
+}
+
+func TestValidateMembershipConfig(t *testing.T) {
+	// Membership is not validated when it is disabled
+	conf := &config.MembershipConfig{}
+	require.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	require.EqualError(t, conf.Validate(), "invalid configuration: membership requires an advertise address")
+
+	conf.AdvertiseAddr = "localhost:4436"
+	require.EqualError(t, conf.Validate(), "invalid configuration: specify a positive heartbeat interval")
+
+	conf.HeartbeatInterval = time.Minute
+	conf.SuspectTimeout = time.Minute
+	conf.DeadTimeout = time.Hour
+	require.Error(t, conf.Validate(), "suspect timeout must be longer than the heartbeat interval")
+
+	conf.SuspectTimeout = 2 * time.Hour
+	require.Error(t, conf.Validate(), "dead timeout must be longer than the suspect timeout")
+
+	conf.SuspectTimeout = 5 * time.Minute
+	require.NoError(t, conf.Validate())
+
+	conf.GracePeriod = -time.Hour
+	require.EqualError(t, conf.Validate(), "invalid configuration: membership grace period cannot be negative")
+}
+
 func TestKubernetesStatefulSetStrategy(t *testing.T) {
 	// Set required environment variables and cleanup after
 	prevEnv := curEnv()
@@ -24,3 +24,23 @@ func TestPageCursor(t *testing.T) {
 	require.Equal(t, cursor.PageSize, other.PageSize)
 	require.Equal(t, cursor.NextKey, other.NextKey)
 }
+
+func TestWatchCursor(t *testing.T) {
+	cursor := &internal.WatchCursor{
+		Epoch:    1665000000000000000,
+		Sequence: 42,
+	}
+
+	// Testing dumping a cursor
+	token, err := cursor.Dump()
+	require.NoError(t, err, "could not dump watch cursor")
+
+	// Test loading a cursor
+	other := &internal.WatchCursor{}
+	require.NoError(t, other.Load(token), "could not load watch cursor")
+	require.Equal(t, cursor.Epoch, other.Epoch)
+	require.Equal(t, cursor.Sequence, other.Sequence)
+
+	// Test loading an invalid cursor
+	require.Error(t, other.Load("not a cursor!"))
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Load a WatchCursor from the cursor string of a watch event.
+func (wc *WatchCursor) Load(cursor string) (err error) {
+	var data []byte
+	if data, err = base64.RawURLEncoding.DecodeString(cursor); err != nil {
+		return fmt.Errorf("could not decode watch cursor: %s", err)
+	}
+
+	if err = proto.Unmarshal(data, wc); err != nil {
+		return fmt.Errorf("could not unmarshal watch cursor: %s", err)
+	}
+	return nil
+}
+
+// Dump a WatchCursor into the cursor string of a watch event.
+func (wc *WatchCursor) Dump() (cursor string, err error) {
+	var data []byte
+	if data, err = proto.Marshal(wc); err != nil {
+		return "", fmt.Errorf("could not marshal watch cursor: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: trtl/internal/watch.proto
+
+package internal
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Implements a protocol buffer struct for resuming a Watch stream. This struct will be
+// marshaled into a url-safe base64 encoded string and sent to the user as the cursor of
+// each watch event. The epoch identifies the process that assigned the sequence number,
+// since sequences are only kept in memory and restart when the replica is restarted.
+type WatchCursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch    int64  `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`       // the timestamp the change feed was started, in nanoseconds
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"` // the sequence number of the event in the change feed
+}
+
+func (x *WatchCursor) Reset() {
+	*x = WatchCursor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_internal_watch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchCursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCursor) ProtoMessage() {}
+
+func (x *WatchCursor) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_internal_watch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCursor.ProtoReflect.Descriptor instead.
+func (*WatchCursor) Descriptor() ([]byte, []int) {
+	return file_trtl_internal_watch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchCursor) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *WatchCursor) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_trtl_internal_watch_proto protoreflect.FileDescriptor
+
+var file_trtl_internal_watch_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x72, 0x74,
+	0x6c, 0x2e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x22, 0x3f, 0x0a, 0x0b, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x34, 0x5a, 0x32, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_trtl_internal_watch_proto_rawDescOnce sync.Once
+	file_trtl_internal_watch_proto_rawDescData = file_trtl_internal_watch_proto_rawDesc
+)
+
+func file_trtl_internal_watch_proto_rawDescGZIP() []byte {
+	file_trtl_internal_watch_proto_rawDescOnce.Do(func() {
+		file_trtl_internal_watch_proto_rawDescData = protoimpl.X.CompressGZIP(file_trtl_internal_watch_proto_rawDescData)
+	})
+	return file_trtl_internal_watch_proto_rawDescData
+}
+
+var file_trtl_internal_watch_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_trtl_internal_watch_proto_goTypes = []interface{}{
+	(*WatchCursor)(nil), // 0: trtl.internal.WatchCursor
+}
+var file_trtl_internal_watch_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_trtl_internal_watch_proto_init() }
+func file_trtl_internal_watch_proto_init() {
+	if File_trtl_internal_watch_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_trtl_internal_watch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchCursor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_trtl_internal_watch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_trtl_internal_watch_proto_goTypes,
+		DependencyIndexes: file_trtl_internal_watch_proto_depIdxs,
+		MessageInfos:      file_trtl_internal_watch_proto_msgTypes,
+	}.Build()
+	File_trtl_internal_watch_proto = out.File
+	file_trtl_internal_watch_proto_rawDesc = nil
+	file_trtl_internal_watch_proto_goTypes = nil
+	file_trtl_internal_watch_proto_depIdxs = nil
+}
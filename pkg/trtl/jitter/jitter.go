@@ -53,6 +53,12 @@ func (t *Ticker) loop(c chan<- time.Time) {
 }
 
 func (j *Ticker) calcDelay() time.Duration {
-	s := rand.NormFloat64() * float64(j.Sigma)
-	return j.Interval + time.Duration(s)
+	return Delay(j.Interval, j.Sigma)
+}
+
+// Delay returns a random duration normally distributed around the interval with the
+// specified standard deviation, e.g. the delay between two ticks of a Ticker.
+func Delay(interval, sigma time.Duration) time.Duration {
+	s := rand.NormFloat64() * float64(sigma)
+	return interval + time.Duration(s)
 }
@@ -0,0 +1,207 @@
+package trtl
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rotationalio/honu"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"google.golang.org/protobuf/proto"
+)
+
+// MembershipManager runs as an independent service which maintains the membership of
+// the trtl network through the replicated peers namespace. On every heartbeat interval
+// the manager writes a heartbeat to the peer record of the local replica, which is
+// propagated to the rest of the network by anti-entropy, and removes the records of
+// peers that have not written a heartbeat for longer than the dead timeout plus the
+// grace period; the removal is also propagated by anti-entropy. The liveness of peers
+// is computed from their heartbeats when they are returned by GetPeers.
+//
+// If the local replica does not know about any live remote peers (e.g. it is joining
+// the network for the first time) it performs anti-entropy with the configured seeds
+// until one succeeds. This exchanges the peers namespace with the seed, so the local
+// replica discovers the rest of the network and the seed learns about the new replica.
+type MembershipManager struct {
+	conf    config.MembershipConfig
+	local   config.ReplicaConfig
+	db      *honu.DB
+	replica *replica.Service
+	stop    chan struct{}
+}
+
+func NewMembershipManager(conf config.MembershipConfig, local config.ReplicaConfig, db *honu.DB, replica *replica.Service) (*MembershipManager, error) {
+	return &MembershipManager{
+		conf:    conf,
+		local:   local,
+		db:      db,
+		replica: replica,
+		stop:    make(chan struct{}),
+	}, nil
+}
+
+// Runs the main MembershipManager routine which writes a heartbeat, removes dead peers,
+// and joins the network if necessary when it starts and at every heartbeat interval.
+func (m *MembershipManager) Run() {
+	if !m.conf.Enabled {
+		log.Warn().Msg("trtl membership disabled")
+		return
+	}
+
+	ticker := time.NewTicker(m.conf.HeartbeatInterval)
+	log.Info().Dur("interval", m.conf.HeartbeatInterval).Str("addr", m.conf.AdvertiseAddr).Msg("trtl membership manager started")
+
+	for {
+		if err := m.heartbeat(); err != nil {
+			log.Error().Err(err).Msg("could not write membership heartbeat")
+		}
+
+		members, err := m.members()
+		if err != nil {
+			log.Error().Err(err).Msg("could not load peers for membership")
+		} else {
+			members = m.reap(members)
+			if len(members) == 0 {
+				m.join()
+			}
+		}
+
+		// Wait for next tick or a stop message
+		select {
+		case <-m.stop:
+			log.Warn().Msg("trtl membership manager received stop signal")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *MembershipManager) Shutdown() error {
+	if m.stop != nil {
+		m.stop <- struct{}{}
+
+		// Close the channel and set it to nil so that multiple shutdown calls don't block.
+		close(m.stop)
+		m.stop = nil
+	}
+	return nil
+}
+
+// Write a heartbeat to the peer record of the local replica, creating the record if the
+// replica has not joined the network yet or was removed while it was unavailable.
+func (m *MembershipManager) heartbeat() (err error) {
+	self := &peers.Peer{Id: m.local.PID}
+	key := []byte(self.Key())
+
+	var data []byte
+	if data, err = m.db.Get(key, options.WithNamespace(NamespacePeers)); err != nil {
+		if !errors.Is(err, engine.ErrNotFound) {
+			return err
+		}
+	} else {
+		if err = proto.Unmarshal(data, self); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	if self.Addr != m.conf.AdvertiseAddr || self.Name != m.local.Name || self.Region != m.local.Region {
+		self.Addr = m.conf.AdvertiseAddr
+		self.Name = m.local.Name
+		self.Region = m.local.Region
+		self.Modified = now
+	}
+
+	if self.Created == "" {
+		self.Created = now
+	}
+	self.LastHeartbeat = now
+
+	if data, err = proto.Marshal(self); err != nil {
+		return err
+	}
+
+	var obj *object.Object
+	if obj, err = m.db.Put(key, data, options.WithNamespace(NamespacePeers)); err != nil {
+		return err
+	}
+	m.replica.Observe(obj)
+	return nil
+}
+
+// Load the remote peers from the database, excluding the local replica.
+func (m *MembershipManager) members() (members []*peers.Peer, err error) {
+	iter, err := m.db.Iter(nil, options.WithNamespace(NamespacePeers))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		peer := new(peers.Peer)
+		if err = proto.Unmarshal(iter.Value(), peer); err != nil {
+			log.Warn().Err(err).Str("key", string(iter.Key())).Msg("could not unmarshal peer")
+			continue
+		}
+
+		if peer.Id != m.local.PID {
+			members = append(members, peer)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Remove the peers that have been dead for longer than the grace period, returning the
+// remaining peers that are not dead. Peers that have never written a heartbeat (e.g.
+// peers that were added manually) are never removed.
+func (m *MembershipManager) reap(members []*peers.Peer) (live []*peers.Peer) {
+	now := time.Now()
+	live = make([]*peers.Peer, 0, len(members))
+	for _, peer := range members {
+		if peer.Liveness(now, m.conf.SuspectTimeout, m.conf.DeadTimeout) != peers.PeerState_DEAD {
+			live = append(live, peer)
+			continue
+		}
+
+		if now.Sub(peer.Heartbeat()) <= m.conf.DeadTimeout+m.conf.GracePeriod {
+			continue
+		}
+
+		obj, err := m.db.Delete([]byte(peer.Key()), options.WithNamespace(NamespacePeers))
+		if err != nil {
+			log.Error().Err(err).Uint64("pid", peer.Id).Msg("could not remove dead peer")
+			continue
+		}
+		m.replica.Observe(obj)
+		log.Info().Uint64("pid", peer.Id).Str("name", peer.Name).Str("last_heartbeat", peer.LastHeartbeat).Msg("removed dead peer")
+	}
+	return live
+}
+
+// Join the network by performing anti-entropy with the first seed that responds.
+func (m *MembershipManager) join() {
+	if len(m.conf.Seeds) == 0 {
+		log.Debug().Msg("no live peers or seeds available to join the network")
+		return
+	}
+
+	for _, seed := range m.conf.Seeds {
+		logctx := log.With().Str("seed", seed).Str("service", "membership").Logger()
+		if err := m.replica.AntiEntropySync(&peers.Peer{Addr: seed, Name: seed}, logctx); err != nil {
+			logctx.Warn().Err(err).Msg("could not join network through seed")
+			continue
+		}
+
+		logctx.Info().Msg("joined network through seed")
+		return
+	}
+}
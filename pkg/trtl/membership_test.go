@@ -0,0 +1,126 @@
+package trtl_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/options"
+	replication "github.com/rotationalio/honu/replica"
+	"github.com/trisacrypto/directory/pkg/trtl"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Test that the membership manager writes heartbeats, removes dead peers, and joins
+// the network through a seed when it does not know of any live peers.
+func (s *trtlTestSuite) TestMembershipManager() {
+	// Ensure the fixtures are restored after the database is modified
+	defer s.reset()
+	require := s.Require()
+	db := s.trtl.GetDB()
+
+	conf := *s.conf
+	conf.Replica.Enabled = true
+	conf.Replica.GossipInterval = time.Minute
+	conf.Replica.GossipSigma = time.Second
+
+	// Create a seed replica that knows about itself and another live peer
+	tmp, err := ioutil.TempDir("testdata", "seeddb-*")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	seeddb, err := honu.Open("leveldb:///" + tmp)
+	require.NoError(err)
+	defer seeddb.Close()
+
+	now := time.Now()
+	s.putPeer(seeddb, &peers.Peer{Id: 2, Name: "seed", LastHeartbeat: now.Format(time.RFC3339)})
+	s.putPeer(seeddb, &peers.Peer{Id: 3, Name: "charlie", LastHeartbeat: now.Add(-10 * time.Minute).Format(time.RFC3339)})
+
+	seedconf := conf
+	seedconf.Replica.PID = 2
+	seedconf.Replica.Name = "seed"
+	seed, err := replica.New(seedconf, seeddb, []string{trtl.NamespacePeers})
+	require.NoError(err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+
+	srv := grpc.NewServer()
+	replication.RegisterReplicationServer(srv, seed)
+	merkle.RegisterMerkleServer(srv, seed)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	// The local replica only knows about a peer that has been dead for days
+	s.putPeer(db, &peers.Peer{Id: 9, Name: "zombie", LastHeartbeat: now.Add(-72 * time.Hour).Format(time.RFC3339)})
+
+	conf.Membership = config.MembershipConfig{
+		Enabled:           true,
+		AdvertiseAddr:     "localhost:4436",
+		Seeds:             []string{lis.Addr().String()},
+		HeartbeatInterval: 10 * time.Millisecond,
+		SuspectTimeout:    5 * time.Minute,
+		DeadTimeout:       15 * time.Minute,
+		GracePeriod:       24 * time.Hour,
+	}
+	require.NoError(conf.Validate())
+
+	local, err := replica.New(conf, db, []string{trtl.NamespacePeers})
+	require.NoError(err)
+
+	membership, err := trtl.NewMembershipManager(conf.Membership, conf.Replica, db, local)
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		membership.Run()
+	}()
+
+	// The seed should learn about the local replica and the local replica should
+	// discover the rest of the network from the seed.
+	require.Eventually(func() bool {
+		_, err := seeddb.Get([]byte(fmt.Sprintf("%04x", conf.Replica.PID)), options.WithNamespace(trtl.NamespacePeers))
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond, "seed did not learn about the local replica")
+
+	require.NoError(membership.Shutdown())
+	wg.Wait()
+
+	for _, pid := range []uint64{2, 3} {
+		_, err := db.Get([]byte(fmt.Sprintf("%04x", pid)), options.WithNamespace(trtl.NamespacePeers))
+		require.NoError(err, "peer %d was not discovered", pid)
+	}
+
+	// The dead peer should be removed after its grace period
+	_, err = db.Get([]byte(fmt.Sprintf("%04x", 9)), options.WithNamespace(trtl.NamespacePeers))
+	require.Error(err, "dead peer was not removed")
+
+	// The local replica should have written a heartbeat with its advertised address
+	data, err := db.Get([]byte(fmt.Sprintf("%04x", conf.Replica.PID)), options.WithNamespace(trtl.NamespacePeers))
+	require.NoError(err)
+	self := &peers.Peer{}
+	require.NoError(proto.Unmarshal(data, self))
+	require.Equal(conf.Membership.AdvertiseAddr, self.Addr)
+	require.Equal(conf.Replica.Name, self.Name)
+	require.Equal(peers.PeerState_ALIVE, self.Liveness(time.Now(), conf.Membership.SuspectTimeout, conf.Membership.DeadTimeout))
+	require.NotEmpty(self.Created)
+}
+
+func (s *trtlTestSuite) putPeer(db *honu.DB, peer *peers.Peer) {
+	data, err := proto.Marshal(peer)
+	s.Require().NoError(err)
+	_, err = db.Put([]byte(peer.Key()), data, options.WithNamespace(trtl.NamespacePeers))
+	s.Require().NoError(err)
+}
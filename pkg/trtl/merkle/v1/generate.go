@@ -0,0 +1,3 @@
+package merkle
+
+//go:generate protoc -I=$GOPATH/src/github.com/trisacrypto/directory/proto --go_out=. --go_opt=module=github.com/trisacrypto/directory/pkg/trtl/merkle/v1 --go-grpc_out=. --go-grpc_opt=module=github.com/trisacrypto/directory/pkg/trtl/merkle/v1 trtl/merkle/v1/merkle.proto
@@ -0,0 +1,137 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: trtl/merkle/v1/merkle.proto
+
+package merkle
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MerkleClient is the client API for Merkle service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MerkleClient interface {
+	Compare(ctx context.Context, opts ...grpc.CallOption) (Merkle_CompareClient, error)
+}
+
+type merkleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMerkleClient(cc grpc.ClientConnInterface) MerkleClient {
+	return &merkleClient{cc}
+}
+
+func (c *merkleClient) Compare(ctx context.Context, opts ...grpc.CallOption) (Merkle_CompareClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Merkle_ServiceDesc.Streams[0], "/trtl.merkle.v1.Merkle/Compare", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &merkleCompareClient{stream}
+	return x, nil
+}
+
+type Merkle_CompareClient interface {
+	Send(*DigestRequest) error
+	Recv() (*DigestReply, error)
+	grpc.ClientStream
+}
+
+type merkleCompareClient struct {
+	grpc.ClientStream
+}
+
+func (x *merkleCompareClient) Send(m *DigestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *merkleCompareClient) Recv() (*DigestReply, error) {
+	m := new(DigestReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MerkleServer is the server API for Merkle service.
+// All implementations must embed UnimplementedMerkleServer
+// for forward compatibility
+type MerkleServer interface {
+	Compare(Merkle_CompareServer) error
+	mustEmbedUnimplementedMerkleServer()
+}
+
+// UnimplementedMerkleServer must be embedded to have forward compatible implementations.
+type UnimplementedMerkleServer struct {
+}
+
+func (UnimplementedMerkleServer) Compare(Merkle_CompareServer) error {
+	return status.Errorf(codes.Unimplemented, "method Compare not implemented")
+}
+func (UnimplementedMerkleServer) mustEmbedUnimplementedMerkleServer() {}
+
+// UnsafeMerkleServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MerkleServer will
+// result in compilation errors.
+type UnsafeMerkleServer interface {
+	mustEmbedUnimplementedMerkleServer()
+}
+
+func RegisterMerkleServer(s grpc.ServiceRegistrar, srv MerkleServer) {
+	s.RegisterService(&Merkle_ServiceDesc, srv)
+}
+
+func _Merkle_Compare_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MerkleServer).Compare(&merkleCompareServer{stream})
+}
+
+type Merkle_CompareServer interface {
+	Send(*DigestReply) error
+	Recv() (*DigestRequest, error)
+	grpc.ServerStream
+}
+
+type merkleCompareServer struct {
+	grpc.ServerStream
+}
+
+func (x *merkleCompareServer) Send(m *DigestReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *merkleCompareServer) Recv() (*DigestRequest, error) {
+	m := new(DigestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Merkle_ServiceDesc is the grpc.ServiceDesc for Merkle service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Merkle_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trtl.merkle.v1.Merkle",
+	HandlerType: (*MerkleServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Compare",
+			Handler:       _Merkle_Compare_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "trtl/merkle/v1/merkle.proto",
+}
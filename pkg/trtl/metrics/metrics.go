@@ -18,8 +18,9 @@ var (
 	PmDels       *prometheus.CounterVec   // count of trtl Deletes per namespace
 	PmIters      *prometheus.CounterVec   // count of trtl Iters per namespace
 	PmRPCLatency *prometheus.HistogramVec // the time it is taking for successful RPC calls to complete, labeled by RPC type, success, and failure
-	// PmObjects    *prometheus.CounterVec   // count of objects being managed by trtl, by namespace
-	// PmTombstones *prometheus.CounterVec   // count of tombstones per namespace; increases on delete, decrease on overwrite of tombstone
+	PmObjects    *prometheus.GaugeVec     // count of objects being managed by trtl, by namespace, updated by compaction
+	PmTombstones *prometheus.GaugeVec     // count of tombstones per namespace, updated by compaction
+	PmCompacted  *prometheus.CounterVec   // count of tombstones permanently removed by compaction, by namespace
 
 	// Anti-Entropy Metrics
 	PmAESyncs         *prometheus.CounterVec   // count of anti entropy sessions per peer, per region, and by perspective (initiator/remote)
@@ -111,17 +112,23 @@ func initMetrics() {
 		Buckets:   prometheus.ExponentialBuckets(5, 2, 12),
 	}, []string{"call"})
 
-	// PmObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
-	// 	Namespace: PmNamespace,
-	// 	Name:      "objects",
-	// 	Help:      "the count of trtl objects, labeled by namespace",
-	// }, []string{"namespace"})
+	PmObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: PmNamespace,
+		Name:      "objects",
+		Help:      "the count of trtl objects, labeled by namespace",
+	}, []string{"namespace"})
 
-	// PmTombstones = prometheus.NewCounterVec(prometheus.CounterOpts{
-	// 	Namespace: PmNamespace,
-	// 	Name:      "tombstones",
-	// 	Help:      "the count of tombstones, labeled by namespace",
-	// }, []string{"namespace"})
+	PmTombstones = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: PmNamespace,
+		Name:      "tombstones",
+		Help:      "the count of tombstones, labeled by namespace",
+	}, []string{"namespace"})
+
+	PmCompacted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: PmNamespace,
+		Name:      "compacted",
+		Help:      "count of tombstones removed by compaction, labeled by namespace",
+	}, []string{"namespace"})
 
 	PmAESyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: PmNamespace,
@@ -205,14 +212,18 @@ func registerMetrics() error {
 		log.Debug().Err(err).Msg("unable to register PmLatency")
 		return err
 	}
-	// if err := prometheus.Register(PmObjects); err != nil {
-	// 	log.Debug().Err(err).Msg("unable to register PmObjects")
-	// 	return err
-	// }
-	// if err := prometheus.Register(PmTombstones); err != nil {
-	// 	log.Debug().Err(err).Msg("unable to register PmTombstones")
-	// 	return err
-	// }
+	if err := prometheus.Register(PmObjects); err != nil {
+		log.Debug().Err(err).Msg("unable to register PmObjects")
+		return err
+	}
+	if err := prometheus.Register(PmTombstones); err != nil {
+		log.Debug().Err(err).Msg("unable to register PmTombstones")
+		return err
+	}
+	if err := prometheus.Register(PmCompacted); err != nil {
+		log.Debug().Err(err).Msg("unable to register PmCompacted")
+		return err
+	}
 
 	if err := prometheus.Register(PmAESyncs); err != nil {
 		log.Debug().Err(err).Msg("unable to register PmAESyncs")
@@ -1,28 +1,48 @@
 package trtl
 
-import "github.com/trisacrypto/directory/pkg/utils/wire"
+import (
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+)
 
 const (
-	NamespacePeers    = wire.NamespaceReplicas
-	NamespaceIndex    = wire.NamespaceIndices
-	NamespaceDefault  = "default"
-	NamespaceSequence = wire.NamespaceSequence
-	NamespaceVASPs    = wire.NamespaceVASPs
-	NamespaceCertReqs = wire.NamespaceCertReqs
+	NamespacePeers     = wire.NamespaceReplicas
+	NamespaceIndex     = wire.NamespaceIndices
+	NamespaceDefault   = "default"
+	NamespaceSequence  = wire.NamespaceSequence
+	NamespaceVASPs     = wire.NamespaceVASPs
+	NamespaceCertReqs  = wire.NamespaceCertReqs
+	NamespaceActions   = wire.NamespaceActions
+	NamespaceAudit     = wire.NamespaceAudit
+	NamespaceRevisions = wire.NamespaceRevisions
+	NamespacePolicies  = replica.NamespacePolicies
+	NamespaceWatch     = "watch"
 )
 
 // Reserved namespaces that cannot be used by the caller since they are in use by trtl.
 // If necessary this can be moved to configuration in the future.
 var reservedNamespaces = map[string]struct{}{
 	NamespacePeers:    {},
+	NamespacePolicies: {},
 	NamespaceSequence: {},
+	NamespaceWatch:    {},
 	NamespaceDefault:  {}, // if the user does not specify a namespace
 
 	// TODO: add index namespace back to reserved namespaces when trtl does indexing.
 	// NamespaceIndex:    {},
 }
 
-// Replicated namespaces are the namespaces that are used in anti-entropy by default.
+// Reserved returns true if the namespace is in use by trtl and cannot be accessed
+// through the trtl API, e.g. to skip the namespace when restoring a backup.
+func Reserved(namespace string) bool {
+	_, ok := reservedNamespaces[namespace]
+	return ok
+}
+
+// Replicated namespaces are the namespaces that are used in anti-entropy by default,
+// unless their replication policies are modified with the NamespaceManagement service.
+// The peers and policies namespaces are always replicated.
 var replicatedNamespaces = []string{
-	NamespaceVASPs, NamespaceCertReqs, NamespacePeers, NamespaceDefault,
+	NamespaceVASPs, NamespaceCertReqs, NamespaceActions, NamespaceAudit, NamespaceRevisions,
+	NamespacePeers, NamespaceDefault,
 }
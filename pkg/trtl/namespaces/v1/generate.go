@@ -0,0 +1,3 @@
+package namespaces
+
+//go:generate protoc -I=$GOPATH/src/github.com/trisacrypto/directory/proto --go_out=. --go_opt=module=github.com/trisacrypto/directory/pkg/trtl/namespaces/v1 --go-grpc_out=. --go-grpc_opt=module=github.com/trisacrypto/directory/pkg/trtl/namespaces/v1 trtl/namespaces/v1/namespaces.proto
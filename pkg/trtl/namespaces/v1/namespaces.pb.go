@@ -0,0 +1,394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: trtl/namespaces/v1/namespaces.proto
+
+package namespaces
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Policy describes how a namespace is replicated by anti-entropy. Policies are stored in
+// a replicated namespace so that every replica in the network applies the same policy;
+// namespaces without a stored policy use the built-in policy of the replica, if any.
+type Policy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace  string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`    // the name of the namespace the policy applies to
+	Replicated bool   `protobuf:"varint,2,opt,name=replicated,proto3" json:"replicated,omitempty"` // if false the namespace is never exchanged with peers
+	// the regions that the namespace is replicated in; objects are only exchanged
+	// between replicas that are both in one of these regions. If empty, the namespace
+	// is replicated in all regions.
+	Regions []string `protobuf:"bytes,3,rep,name=regions,proto3" json:"regions,omitempty"`
+	// if true, objects are only exchanged with peers in the same region as the replica,
+	// e.g. the namespace is replicated within each region but not between regions.
+	SameRegion bool `protobuf:"varint,4,opt,name=same_region,json=sameRegion,proto3" json:"same_region,omitempty"`
+	// the mean and standard deviation of the interval between anti-entropy sessions
+	// for the namespace as parseable durations (e.g. 10m); if empty the gossip interval
+	// and sigma of the replica configuration are used.
+	GossipInterval string `protobuf:"bytes,5,opt,name=gossip_interval,json=gossipInterval,proto3" json:"gossip_interval,omitempty"`
+	GossipSigma    string `protobuf:"bytes,6,opt,name=gossip_sigma,json=gossipSigma,proto3" json:"gossip_sigma,omitempty"`
+	// if true, the policy is built into trtl and cannot be modified (returned by
+	// GetPolicies and not stored).
+	Builtin bool `protobuf:"varint,8,opt,name=builtin,proto3" json:"builtin,omitempty"`
+	// Logging information timestamps
+	Created  string `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"`
+	Modified string `protobuf:"bytes,10,opt,name=modified,proto3" json:"modified,omitempty"`
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_trtl_namespaces_v1_namespaces_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Policy) GetReplicated() bool {
+	if x != nil {
+		return x.Replicated
+	}
+	return false
+}
+
+func (x *Policy) GetRegions() []string {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *Policy) GetSameRegion() bool {
+	if x != nil {
+		return x.SameRegion
+	}
+	return false
+}
+
+func (x *Policy) GetGossipInterval() string {
+	if x != nil {
+		return x.GossipInterval
+	}
+	return ""
+}
+
+func (x *Policy) GetGossipSigma() string {
+	if x != nil {
+		return x.GossipSigma
+	}
+	return ""
+}
+
+func (x *Policy) GetBuiltin() bool {
+	if x != nil {
+		return x.Builtin
+	}
+	return false
+}
+
+func (x *Policy) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Policy) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+// Used to filter the policies that are returned. If no namespaces are specified then
+// the policies of all namespaces known to the replica are returned.
+type PolicyFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespaces []string `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+}
+
+func (x *PolicyFilter) Reset() {
+	*x = PolicyFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyFilter) ProtoMessage() {}
+
+func (x *PolicyFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyFilter.ProtoReflect.Descriptor instead.
+func (*PolicyFilter) Descriptor() ([]byte, []int) {
+	return file_trtl_namespaces_v1_namespaces_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PolicyFilter) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// Returns the effective policies of the namespaces known to the replica.
+type PolicyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policies []*Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+}
+
+func (x *PolicyList) Reset() {
+	*x = PolicyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PolicyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolicyList) ProtoMessage() {}
+
+func (x *PolicyList) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_namespaces_v1_namespaces_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolicyList.ProtoReflect.Descriptor instead.
+func (*PolicyList) Descriptor() ([]byte, []int) {
+	return file_trtl_namespaces_v1_namespaces_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PolicyList) GetPolicies() []*Policy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+var File_trtl_namespaces_v1_namespaces_proto protoreflect.FileDescriptor
+
+var file_trtl_namespaces_v1_namespaces_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x2f, 0x76, 0x31, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x9d, 0x02, 0x0a, 0x06, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a,
+	0x0f, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x5f, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x53, 0x69, 0x67, 0x6d, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69,
+	0x6c, 0x74, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x74, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x0c, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x22, 0x44, 0x0a, 0x0a, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x32,
+	0xf5, 0x01, 0x0a, 0x13, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x4d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x51, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x1e, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x09, 0x53, 0x65,
+	0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x08, 0x52, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1a, 0x2e,
+	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x00, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x6f, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x74, 0x72, 0x74, 0x6c, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x2f,
+	0x76, 0x31, 0x3b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_trtl_namespaces_v1_namespaces_proto_rawDescOnce sync.Once
+	file_trtl_namespaces_v1_namespaces_proto_rawDescData = file_trtl_namespaces_v1_namespaces_proto_rawDesc
+)
+
+func file_trtl_namespaces_v1_namespaces_proto_rawDescGZIP() []byte {
+	file_trtl_namespaces_v1_namespaces_proto_rawDescOnce.Do(func() {
+		file_trtl_namespaces_v1_namespaces_proto_rawDescData = protoimpl.X.CompressGZIP(file_trtl_namespaces_v1_namespaces_proto_rawDescData)
+	})
+	return file_trtl_namespaces_v1_namespaces_proto_rawDescData
+}
+
+var file_trtl_namespaces_v1_namespaces_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_trtl_namespaces_v1_namespaces_proto_goTypes = []interface{}{
+	(*Policy)(nil),       // 0: trtl.namespaces.v1.Policy
+	(*PolicyFilter)(nil), // 1: trtl.namespaces.v1.PolicyFilter
+	(*PolicyList)(nil),   // 2: trtl.namespaces.v1.PolicyList
+}
+var file_trtl_namespaces_v1_namespaces_proto_depIdxs = []int32{
+	0, // 0: trtl.namespaces.v1.PolicyList.policies:type_name -> trtl.namespaces.v1.Policy
+	1, // 1: trtl.namespaces.v1.NamespaceManagement.GetPolicies:input_type -> trtl.namespaces.v1.PolicyFilter
+	0, // 2: trtl.namespaces.v1.NamespaceManagement.SetPolicy:input_type -> trtl.namespaces.v1.Policy
+	0, // 3: trtl.namespaces.v1.NamespaceManagement.RmPolicy:input_type -> trtl.namespaces.v1.Policy
+	2, // 4: trtl.namespaces.v1.NamespaceManagement.GetPolicies:output_type -> trtl.namespaces.v1.PolicyList
+	0, // 5: trtl.namespaces.v1.NamespaceManagement.SetPolicy:output_type -> trtl.namespaces.v1.Policy
+	0, // 6: trtl.namespaces.v1.NamespaceManagement.RmPolicy:output_type -> trtl.namespaces.v1.Policy
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_trtl_namespaces_v1_namespaces_proto_init() }
+func file_trtl_namespaces_v1_namespaces_proto_init() {
+	if File_trtl_namespaces_v1_namespaces_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_trtl_namespaces_v1_namespaces_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Policy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trtl_namespaces_v1_namespaces_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trtl_namespaces_v1_namespaces_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_trtl_namespaces_v1_namespaces_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_trtl_namespaces_v1_namespaces_proto_goTypes,
+		DependencyIndexes: file_trtl_namespaces_v1_namespaces_proto_depIdxs,
+		MessageInfos:      file_trtl_namespaces_v1_namespaces_proto_msgTypes,
+	}.Build()
+	File_trtl_namespaces_v1_namespaces_proto = out.File
+	file_trtl_namespaces_v1_namespaces_proto_rawDesc = nil
+	file_trtl_namespaces_v1_namespaces_proto_goTypes = nil
+	file_trtl_namespaces_v1_namespaces_proto_depIdxs = nil
+}
@@ -0,0 +1,177 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: trtl/namespaces/v1/namespaces.proto
+
+package namespaces
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NamespaceManagementClient is the client API for NamespaceManagement service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NamespaceManagementClient interface {
+	GetPolicies(ctx context.Context, in *PolicyFilter, opts ...grpc.CallOption) (*PolicyList, error)
+	SetPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error)
+	RmPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error)
+}
+
+type namespaceManagementClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNamespaceManagementClient(cc grpc.ClientConnInterface) NamespaceManagementClient {
+	return &namespaceManagementClient{cc}
+}
+
+func (c *namespaceManagementClient) GetPolicies(ctx context.Context, in *PolicyFilter, opts ...grpc.CallOption) (*PolicyList, error) {
+	out := new(PolicyList)
+	err := c.cc.Invoke(ctx, "/trtl.namespaces.v1.NamespaceManagement/GetPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceManagementClient) SetPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error) {
+	out := new(Policy)
+	err := c.cc.Invoke(ctx, "/trtl.namespaces.v1.NamespaceManagement/SetPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namespaceManagementClient) RmPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error) {
+	out := new(Policy)
+	err := c.cc.Invoke(ctx, "/trtl.namespaces.v1.NamespaceManagement/RmPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NamespaceManagementServer is the server API for NamespaceManagement service.
+// All implementations must embed UnimplementedNamespaceManagementServer
+// for forward compatibility
+type NamespaceManagementServer interface {
+	GetPolicies(context.Context, *PolicyFilter) (*PolicyList, error)
+	SetPolicy(context.Context, *Policy) (*Policy, error)
+	RmPolicy(context.Context, *Policy) (*Policy, error)
+	mustEmbedUnimplementedNamespaceManagementServer()
+}
+
+// UnimplementedNamespaceManagementServer must be embedded to have forward compatible implementations.
+type UnimplementedNamespaceManagementServer struct {
+}
+
+func (UnimplementedNamespaceManagementServer) GetPolicies(context.Context, *PolicyFilter) (*PolicyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolicies not implemented")
+}
+func (UnimplementedNamespaceManagementServer) SetPolicy(context.Context, *Policy) (*Policy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPolicy not implemented")
+}
+func (UnimplementedNamespaceManagementServer) RmPolicy(context.Context, *Policy) (*Policy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RmPolicy not implemented")
+}
+func (UnimplementedNamespaceManagementServer) mustEmbedUnimplementedNamespaceManagementServer() {}
+
+// UnsafeNamespaceManagementServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NamespaceManagementServer will
+// result in compilation errors.
+type UnsafeNamespaceManagementServer interface {
+	mustEmbedUnimplementedNamespaceManagementServer()
+}
+
+func RegisterNamespaceManagementServer(s grpc.ServiceRegistrar, srv NamespaceManagementServer) {
+	s.RegisterService(&NamespaceManagement_ServiceDesc, srv)
+}
+
+func _NamespaceManagement_GetPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceManagementServer).GetPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trtl.namespaces.v1.NamespaceManagement/GetPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceManagementServer).GetPolicies(ctx, req.(*PolicyFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NamespaceManagement_SetPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Policy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceManagementServer).SetPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trtl.namespaces.v1.NamespaceManagement/SetPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceManagementServer).SetPolicy(ctx, req.(*Policy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NamespaceManagement_RmPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Policy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamespaceManagementServer).RmPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trtl.namespaces.v1.NamespaceManagement/RmPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamespaceManagementServer).RmPolicy(ctx, req.(*Policy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NamespaceManagement_ServiceDesc is the grpc.ServiceDesc for NamespaceManagement service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NamespaceManagement_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trtl.namespaces.v1.NamespaceManagement",
+	HandlerType: (*NamespaceManagementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPolicies",
+			Handler:    _NamespaceManagement_GetPolicies_Handler,
+		},
+		{
+			MethodName: "SetPolicy",
+			Handler:    _NamespaceManagement_SetPolicy_Handler,
+		},
+		{
+			MethodName: "RmPolicy",
+			Handler:    _NamespaceManagement_RmPolicy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "trtl/namespaces/v1/namespaces.proto",
+}
@@ -0,0 +1,87 @@
+package namespaces
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Validate the policy, ensuring that it specifies a namespace and that its gossip
+// cadence can be parsed. The interval must be positive and greater than twice the
+// sigma so that sessions do not span multiple intervals.
+func (p *Policy) Validate() (err error) {
+	if p.Namespace == "" {
+		return errors.New("a namespace is required")
+	}
+
+	var interval, sigma time.Duration
+	if p.GossipInterval != "" {
+		if interval, err = time.ParseDuration(p.GossipInterval); err != nil {
+			return fmt.Errorf("could not parse gossip interval: %w", err)
+		}
+
+		if interval <= 0 {
+			return errors.New("gossip interval must be positive")
+		}
+	}
+
+	if p.GossipSigma != "" {
+		if sigma, err = time.ParseDuration(p.GossipSigma); err != nil {
+			return fmt.Errorf("could not parse gossip sigma: %w", err)
+		}
+
+		if sigma < 0 {
+			return errors.New("gossip sigma cannot be negative")
+		}
+	}
+
+	if interval > 0 && interval <= 2*sigma {
+		return errors.New("gossip interval must be greater than twice the gossip sigma")
+	}
+	return nil
+}
+
+// Cadence returns the mean and standard deviation of the interval between anti-entropy
+// sessions for the namespace, using the specified defaults if they are not set (or
+// cannot be parsed) on the policy.
+func (p *Policy) Cadence(interval, sigma time.Duration) (time.Duration, time.Duration) {
+	if d, err := time.ParseDuration(p.GossipInterval); err == nil && d > 0 {
+		interval = d
+	}
+
+	if d, err := time.ParseDuration(p.GossipSigma); err == nil && d >= 0 {
+		sigma = d
+	}
+	return interval, sigma
+}
+
+// Allows returns true if objects in the namespace can be exchanged between a replica
+// in the local region and a peer in the remote region.
+func (p *Policy) Allows(local, remote string) bool {
+	if !p.Replicated {
+		return false
+	}
+
+	if p.SameRegion && local != remote {
+		return false
+	}
+
+	if len(p.Regions) > 0 {
+		return p.InRegion(local) && p.InRegion(remote)
+	}
+	return true
+}
+
+// InRegion returns true if the namespace is replicated in the specified region.
+func (p *Policy) InRegion(region string) bool {
+	if len(p.Regions) == 0 {
+		return true
+	}
+
+	for _, r := range p.Regions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package namespaces_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	testCases := []struct {
+		policy *namespaces.Policy
+		err    string
+	}{
+		{&namespaces.Policy{}, "a namespace is required"},
+		{&namespaces.Policy{Namespace: "vasps", GossipInterval: "often"}, "could not parse gossip interval"},
+		{&namespaces.Policy{Namespace: "vasps", GossipInterval: "-1m"}, "gossip interval must be positive"},
+		{&namespaces.Policy{Namespace: "vasps", GossipSigma: "sometimes"}, "could not parse gossip sigma"},
+		{&namespaces.Policy{Namespace: "vasps", GossipSigma: "-1s"}, "gossip sigma cannot be negative"},
+		{&namespaces.Policy{Namespace: "vasps", GossipInterval: "1m", GossipSigma: "30s"}, "gossip interval must be greater than twice the gossip sigma"},
+		{&namespaces.Policy{Namespace: "vasps"}, ""},
+		{&namespaces.Policy{Namespace: "vasps", Replicated: true, GossipInterval: "10m", GossipSigma: "1m"}, ""},
+	}
+
+	for _, tc := range testCases {
+		err := tc.policy.Validate()
+		if tc.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tc.err)
+		}
+	}
+}
+
+func TestPolicyCadence(t *testing.T) {
+	policy := &namespaces.Policy{Namespace: "vasps"}
+	interval, sigma := policy.Cadence(time.Minute, 5*time.Second)
+	require.Equal(t, time.Minute, interval)
+	require.Equal(t, 5*time.Second, sigma)
+
+	policy.GossipInterval = "1h"
+	policy.GossipSigma = "5m"
+	interval, sigma = policy.Cadence(time.Minute, 5*time.Second)
+	require.Equal(t, time.Hour, interval)
+	require.Equal(t, 5*time.Minute, sigma)
+}
+
+func TestPolicyAllows(t *testing.T) {
+	testCases := []struct {
+		policy        *namespaces.Policy
+		local, remote string
+		expected      bool
+	}{
+		{&namespaces.Policy{}, "us", "us", false},
+		{&namespaces.Policy{Replicated: true}, "us", "eu", true},
+		{&namespaces.Policy{Replicated: true}, "us", "", true},
+		{&namespaces.Policy{Replicated: true, SameRegion: true}, "us", "us", true},
+		{&namespaces.Policy{Replicated: true, SameRegion: true}, "us", "eu", false},
+		{&namespaces.Policy{Replicated: true, Regions: []string{"us", "eu"}}, "us", "eu", true},
+		{&namespaces.Policy{Replicated: true, Regions: []string{"us", "eu"}}, "us", "sg", false},
+		{&namespaces.Policy{Replicated: true, Regions: []string{"us", "eu"}}, "sg", "us", false},
+		{&namespaces.Policy{Replicated: true, Regions: []string{"us", "eu"}, SameRegion: true}, "eu", "eu", true},
+		{&namespaces.Policy{Replicated: true, Regions: []string{"us", "eu"}, SameRegion: true}, "sg", "sg", false},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, tc.policy.Allows(tc.local, tc.remote), "test case %d", i)
+	}
+}
@@ -35,9 +35,15 @@ type TrtlClient interface {
 	// Cursor is a server-side streaming request to iterate in a memory safe fashion.
 	Cursor(ctx context.Context, in *CursorRequest, opts ...grpc.CallOption) (Trtl_CursorClient, error)
 	// Sync is a bi-directional streaming mechanism to issue access requests synchronously.
+	// Requests for the same key are handled in order; an Iter request is handled after
+	// all of the Put and Delete requests received before it on the stream have completed.
 	Sync(ctx context.Context, opts ...grpc.CallOption) (Trtl_SyncClient, error)
 	// This RPC servers as a health check for clients to make sure the server is online.
 	Status(ctx context.Context, in *HealthCheck, opts ...grpc.CallOption) (*ServerStatus, error)
+	// Compact permanently removes expired tombstones that have been seen by all peers.
+	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactReply, error)
+	// Watch is a server-side streaming request to receive changes to a namespace as they happen.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Trtl_WatchClient, error)
 }
 
 type trtlClient struct {
@@ -190,6 +196,47 @@ func (c *trtlClient) Status(ctx context.Context, in *HealthCheck, opts ...grpc.C
 	return out, nil
 }
 
+func (c *trtlClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactReply, error) {
+	out := new(CompactReply)
+	err := c.cc.Invoke(ctx, "/trtl.v1.Trtl/Compact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trtlClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Trtl_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Trtl_ServiceDesc.Streams[3], "/trtl.v1.Trtl/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trtlWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Trtl_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type trtlWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *trtlWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TrtlServer is the server API for Trtl service.
 // All implementations must embed UnimplementedTrtlServer
 // for forward compatibility
@@ -207,9 +254,15 @@ type TrtlServer interface {
 	// Cursor is a server-side streaming request to iterate in a memory safe fashion.
 	Cursor(*CursorRequest, Trtl_CursorServer) error
 	// Sync is a bi-directional streaming mechanism to issue access requests synchronously.
+	// Requests for the same key are handled in order; an Iter request is handled after
+	// all of the Put and Delete requests received before it on the stream have completed.
 	Sync(Trtl_SyncServer) error
 	// This RPC servers as a health check for clients to make sure the server is online.
 	Status(context.Context, *HealthCheck) (*ServerStatus, error)
+	// Compact permanently removes expired tombstones that have been seen by all peers.
+	Compact(context.Context, *CompactRequest) (*CompactReply, error)
+	// Watch is a server-side streaming request to receive changes to a namespace as they happen.
+	Watch(*WatchRequest, Trtl_WatchServer) error
 	mustEmbedUnimplementedTrtlServer()
 }
 
@@ -241,6 +294,12 @@ func (UnimplementedTrtlServer) Sync(Trtl_SyncServer) error {
 func (UnimplementedTrtlServer) Status(context.Context, *HealthCheck) (*ServerStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedTrtlServer) Compact(context.Context, *CompactRequest) (*CompactReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
+}
+func (UnimplementedTrtlServer) Watch(*WatchRequest, Trtl_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedTrtlServer) mustEmbedUnimplementedTrtlServer() {}
 
 // UnsafeTrtlServer may be embedded to opt out of forward compatibility for this service.
@@ -417,6 +476,45 @@ func _Trtl_Status_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Trtl_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrtlServer).Compact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trtl.v1.Trtl/Compact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrtlServer).Compact(ctx, req.(*CompactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Trtl_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrtlServer).Watch(m, &trtlWatchServer{stream})
+}
+
+type Trtl_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type trtlWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *trtlWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Trtl_ServiceDesc is the grpc.ServiceDesc for Trtl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -444,6 +542,10 @@ var Trtl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Trtl_Status_Handler,
 		},
+		{
+			MethodName: "Compact",
+			Handler:    _Trtl_Compact_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -462,6 +564,11 @@ var Trtl_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Trtl_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "trtl/v1/trtl.proto",
 }
@@ -55,7 +55,7 @@ func (p *PeerService) AddPeers(ctx context.Context, in *peers.Peer) (out *peers.
 			log.Error().Err(err).Msg("could not access database for peers lookup")
 			return nil, status.Error(codes.FailedPrecondition, "could not get peer from database")
 		}
-
+	} else {
 		current := new(peers.Peer)
 		if err = proto.Unmarshal(data, current); err != nil {
 			log.Error().Err(err).Str("key", key).Msg("could not unmarshal peer from database")
@@ -65,8 +65,15 @@ func (p *PeerService) AddPeers(ctx context.Context, in *peers.Peer) (out *peers.
 		// Update the incoming peer with data from the previous peer
 		// TODO: merge empty fields from current peer into incoming peer then validate.
 		in.Created = current.Created
+		if in.LastHeartbeat == "" {
+			in.LastHeartbeat = current.LastHeartbeat
+		}
 	}
 
+	// Sync stats and liveness are computed by each replica and are not stored with the peer
+	in.Sync = nil
+	in.State = peers.PeerState_UNKNOWN
+
 	// TODO: validate other Peer fields
 	in.Modified = time.Now().Format(time.RFC3339)
 	if in.Created == "" {
@@ -80,10 +87,12 @@ func (p *PeerService) AddPeers(ctx context.Context, in *peers.Peer) (out *peers.
 		return nil, status.Error(codes.FailedPrecondition, "could not marshal peer protocol buffers")
 	}
 
-	if _, err = p.db.Put([]byte(key), value, options.WithNamespace(NamespacePeers)); err != nil {
+	var obj *object.Object
+	if obj, err = p.db.Put([]byte(key), value, options.WithNamespace(NamespacePeers)); err != nil {
 		log.Error().Err(err).Msg("could not put peer to database")
 		return nil, status.Error(codes.FailedPrecondition, "could not insert peer into database")
 	}
+	p.parent.replica.Observe(obj)
 
 	// Assuming we don't need all the Peer details in this case
 	ftr := &peers.PeersFilter{
@@ -98,10 +107,12 @@ func (p *PeerService) AddPeers(ctx context.Context, in *peers.Peer) (out *peers.
 }
 
 func (p *PeerService) RmPeers(ctx context.Context, in *peers.Peer) (out *peers.PeersStatus, err error) {
-	if _, err = p.db.Delete([]byte(in.Key()), options.WithNamespace(NamespacePeers)); err != nil {
+	var obj *object.Object
+	if obj, err = p.db.Delete([]byte(in.Key()), options.WithNamespace(NamespacePeers)); err != nil {
 		log.Error().Err(err).Msg("unable to remove peer")
 		return nil, status.Error(codes.InvalidArgument, "invalid peer; could not be removed")
 	}
+	p.parent.replica.Observe(obj)
 
 	// Assuming we don't need all the Peer details in this case
 	ftr := &peers.PeersFilter{
@@ -128,6 +139,7 @@ func (p *PeerService) peerStatus(ctx context.Context, in *peers.PeersFilter) (ou
 
 	// Iterate over all the peers (necessary for both list and status-only)
 	// TODO: filter self from the list?
+	now := time.Now()
 	ps, err := p.db.Iter(nil, options.WithNamespace(NamespacePeers))
 	if err != nil {
 		return nil, err
@@ -156,6 +168,13 @@ func (p *PeerService) peerStatus(ctx context.Context, in *peers.PeersFilter) (ou
 		out.Status.NetworkSize++
 		out.Status.Regions[peer.Region]++
 
+		// Add the anti-entropy statistics this replica has recorded for the peer and
+		// its liveness if membership is enabled.
+		peer.Sync = p.parent.replica.SyncStats(peer.Id)
+		if membership := p.parent.conf.Membership; membership.Enabled {
+			peer.State = peer.Liveness(now, membership.SuspectTimeout, membership.DeadTimeout)
+		}
+
 		// If it's not a status only, get the details for each Peer
 		if !in.StatusOnly {
 			// If we've been asked to filter by region
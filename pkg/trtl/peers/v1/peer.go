@@ -2,6 +2,7 @@ package peers
 
 import (
 	"fmt"
+	"time"
 )
 
 func (p *Peer) Key() string {
@@ -10,3 +11,36 @@ func (p *Peer) Key() string {
 	}
 	return fmt.Sprintf("%04x", p.Id)
 }
+
+// Heartbeat returns the time of the most recent heartbeat written by the peer or the
+// zero time if the peer has not written a heartbeat.
+func (p *Peer) Heartbeat() time.Time {
+	if p.LastHeartbeat == "" {
+		return time.Time{}
+	}
+
+	ts, err := time.Parse(time.RFC3339, p.LastHeartbeat)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
+
+// Liveness computes the state of the peer from the age of its most recent heartbeat.
+// Heartbeats are timestamped by the peer, so the timeouts should be much larger than
+// the clock skew between peers and the time it takes heartbeats to propagate.
+func (p *Peer) Liveness(now time.Time, suspect, dead time.Duration) PeerState {
+	heartbeat := p.Heartbeat()
+	if heartbeat.IsZero() {
+		return PeerState_UNKNOWN
+	}
+
+	switch age := now.Sub(heartbeat); {
+	case age <= suspect:
+		return PeerState_ALIVE
+	case age <= dead:
+		return PeerState_SUSPECT
+	default:
+		return PeerState_DEAD
+	}
+}
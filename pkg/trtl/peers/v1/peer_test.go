@@ -0,0 +1,34 @@
+package peers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+)
+
+func TestLiveness(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		heartbeat string
+		expected  peers.PeerState
+	}{
+		{"", peers.PeerState_UNKNOWN},
+		{"not a timestamp", peers.PeerState_UNKNOWN},
+		{now.Add(time.Minute).Format(time.RFC3339), peers.PeerState_ALIVE},
+		{now.Add(-1 * time.Minute).Format(time.RFC3339), peers.PeerState_ALIVE},
+		{now.Add(-10 * time.Minute).Format(time.RFC3339), peers.PeerState_SUSPECT},
+		{now.Add(-1 * time.Hour).Format(time.RFC3339), peers.PeerState_DEAD},
+	}
+
+	for _, tc := range testCases {
+		peer := &peers.Peer{Id: 1, LastHeartbeat: tc.heartbeat}
+		require.Equal(t, tc.expected, peer.Liveness(now, 5*time.Minute, 15*time.Minute), "heartbeat %q", tc.heartbeat)
+	}
+
+	peer := &peers.Peer{Id: 1}
+	require.True(t, peer.Heartbeat().IsZero())
+	peer.LastHeartbeat = "2022-08-01T12:00:00Z"
+	require.Equal(t, time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC), peer.Heartbeat())
+}
@@ -20,6 +20,60 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PeerState describes the liveness of a peer that participates in membership. Peers
+// that have not sent a heartbeat (e.g. peers that were added manually) are UNKNOWN.
+type PeerState int32
+
+const (
+	PeerState_UNKNOWN PeerState = 0
+	PeerState_ALIVE   PeerState = 1 // a heartbeat was received within the suspect timeout
+	PeerState_SUSPECT PeerState = 2 // a heartbeat was received within the dead timeout
+	PeerState_DEAD    PeerState = 3 // no heartbeat within the dead timeout; removed after a grace period
+)
+
+// Enum value maps for PeerState.
+var (
+	PeerState_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "ALIVE",
+		2: "SUSPECT",
+		3: "DEAD",
+	}
+	PeerState_value = map[string]int32{
+		"UNKNOWN": 0,
+		"ALIVE":   1,
+		"SUSPECT": 2,
+		"DEAD":    3,
+	}
+)
+
+func (x PeerState) Enum() *PeerState {
+	p := new(PeerState)
+	*p = x
+	return p
+}
+
+func (x PeerState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeerState) Descriptor() protoreflect.EnumDescriptor {
+	return file_trtl_peers_v1_peers_proto_enumTypes[0].Descriptor()
+}
+
+func (PeerState) Type() protoreflect.EnumType {
+	return &file_trtl_peers_v1_peers_proto_enumTypes[0]
+}
+
+func (x PeerState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeerState.Descriptor instead.
+func (PeerState) EnumDescriptor() ([]byte, []int) {
+	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{0}
+}
+
 // Peer contains metadata about how to connect to remote peers in the directory service
 // network. This message services as a data-transfer and exchange mechanism for dynamic
 // networks with changing membership.
@@ -35,9 +89,18 @@ type Peer struct {
 	// Logging information timestamps
 	Created  string `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"`
 	Modified string `protobuf:"bytes,10,opt,name=modified,proto3" json:"modified,omitempty"`
+	// the timestamp of the most recent heartbeat written by the peer, propagated to the
+	// other peers by anti-entropy; empty if the peer does not participate in membership.
+	LastHeartbeat string `protobuf:"bytes,11,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`
 	// extra information that might be relevant to process-specific functions; e.g. for
 	// specific clouds or data that's been parsed (optional).
 	Extra map[string]string `protobuf:"bytes,14,rep,name=extra,proto3" json:"extra,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// anti-entropy statistics recorded by the replica that returned the peer; these are
+	// populated by GetPeers and are not stored with the peer.
+	Sync *SyncStats `protobuf:"bytes,15,opt,name=sync,proto3" json:"sync,omitempty"`
+	// the liveness of the peer computed from its last heartbeat by the replica that
+	// returned the peer; this is populated by GetPeers and is not stored with the peer.
+	State PeerState `protobuf:"varint,16,opt,name=state,proto3,enum=trtl.peers.v1.PeerState" json:"state,omitempty"`
 }
 
 func (x *Peer) Reset() {
@@ -114,6 +177,13 @@ func (x *Peer) GetModified() string {
 	return ""
 }
 
+func (x *Peer) GetLastHeartbeat() string {
+	if x != nil {
+		return x.LastHeartbeat
+	}
+	return ""
+}
+
 func (x *Peer) GetExtra() map[string]string {
 	if x != nil {
 		return x.Extra
@@ -121,6 +191,117 @@ func (x *Peer) GetExtra() map[string]string {
 	return nil
 }
 
+func (x *Peer) GetSync() *SyncStats {
+	if x != nil {
+		return x.Sync
+	}
+	return nil
+}
+
+func (x *Peer) GetState() PeerState {
+	if x != nil {
+		return x.State
+	}
+	return PeerState_UNKNOWN
+}
+
+// SyncStats describes the anti-entropy sessions that a replica has initiated with a
+// remote peer since the replica was started.
+type SyncStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Syncs               uint64 `protobuf:"varint,1,opt,name=syncs,proto3" json:"syncs,omitempty"`                                                        // the number of sessions initiated with the peer
+	Failures            uint64 `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`                                                  // the number of sessions that failed
+	ConsecutiveFailures uint64 `protobuf:"varint,3,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"` // the number of failures since the last successful session
+	LastSync            string `protobuf:"bytes,4,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`                                   // the timestamp of the most recent session
+	LastSuccess         string `protobuf:"bytes,5,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`                          // the timestamp of the most recent successful session
+	LastFailure         string `protobuf:"bytes,6,opt,name=last_failure,json=lastFailure,proto3" json:"last_failure,omitempty"`                          // the timestamp of the most recent failed session
+	LastError           string `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`                                // the error of the most recent failed session
+}
+
+func (x *SyncStats) Reset() {
+	*x = SyncStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trtl_peers_v1_peers_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncStats) ProtoMessage() {}
+
+func (x *SyncStats) ProtoReflect() protoreflect.Message {
+	mi := &file_trtl_peers_v1_peers_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncStats.ProtoReflect.Descriptor instead.
+func (*SyncStats) Descriptor() ([]byte, []int) {
+	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SyncStats) GetSyncs() uint64 {
+	if x != nil {
+		return x.Syncs
+	}
+	return 0
+}
+
+func (x *SyncStats) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *SyncStats) GetConsecutiveFailures() uint64 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *SyncStats) GetLastSync() string {
+	if x != nil {
+		return x.LastSync
+	}
+	return ""
+}
+
+func (x *SyncStats) GetLastSuccess() string {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return ""
+}
+
+func (x *SyncStats) GetLastFailure() string {
+	if x != nil {
+		return x.LastFailure
+	}
+	return ""
+}
+
+func (x *SyncStats) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
 // Used to filter the peers that are returned. If no filters are specified then all
 // known peers on the remote replica are returned.
 type PeersFilter struct {
@@ -135,7 +316,7 @@ type PeersFilter struct {
 func (x *PeersFilter) Reset() {
 	*x = PeersFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_peers_v1_peers_proto_msgTypes[1]
+		mi := &file_trtl_peers_v1_peers_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -148,7 +329,7 @@ func (x *PeersFilter) String() string {
 func (*PeersFilter) ProtoMessage() {}
 
 func (x *PeersFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_peers_v1_peers_proto_msgTypes[1]
+	mi := &file_trtl_peers_v1_peers_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +342,7 @@ func (x *PeersFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeersFilter.ProtoReflect.Descriptor instead.
 func (*PeersFilter) Descriptor() ([]byte, []int) {
-	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{1}
+	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PeersFilter) GetRegion() []string {
@@ -191,7 +372,7 @@ type PeersList struct {
 func (x *PeersList) Reset() {
 	*x = PeersList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_peers_v1_peers_proto_msgTypes[2]
+		mi := &file_trtl_peers_v1_peers_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -204,7 +385,7 @@ func (x *PeersList) String() string {
 func (*PeersList) ProtoMessage() {}
 
 func (x *PeersList) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_peers_v1_peers_proto_msgTypes[2]
+	mi := &file_trtl_peers_v1_peers_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -217,7 +398,7 @@ func (x *PeersList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeersList.ProtoReflect.Descriptor instead.
 func (*PeersList) Descriptor() ([]byte, []int) {
-	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{2}
+	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PeersList) GetPeers() []*Peer {
@@ -248,7 +429,7 @@ type PeersStatus struct {
 func (x *PeersStatus) Reset() {
 	*x = PeersStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trtl_peers_v1_peers_proto_msgTypes[3]
+		mi := &file_trtl_peers_v1_peers_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -261,7 +442,7 @@ func (x *PeersStatus) String() string {
 func (*PeersStatus) ProtoMessage() {}
 
 func (x *PeersStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_trtl_peers_v1_peers_proto_msgTypes[3]
+	mi := &file_trtl_peers_v1_peers_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,7 +455,7 @@ func (x *PeersStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PeersStatus.ProtoReflect.Descriptor instead.
 func (*PeersStatus) Descriptor() ([]byte, []int) {
-	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{3}
+	return file_trtl_peers_v1_peers_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PeersStatus) GetNetworkSize() int64 {
@@ -303,7 +484,7 @@ var File_trtl_peers_v1_peers_proto protoreflect.FileDescriptor
 var file_trtl_peers_v1_peers_proto_rawDesc = []byte{
 	0x0a, 0x19, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x2f,
 	0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x74, 0x72, 0x74,
-	0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x22, 0xfc, 0x01, 0x0a, 0x04, 0x50,
+	0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x81, 0x03, 0x0a, 0x04, 0x50,
 	0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
 	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
@@ -312,57 +493,84 @@ var file_trtl_peers_v1_peers_proto_rawDesc = []byte{
 	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x09,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a,
 	0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x05, 0x65, 0x78, 0x74,
-	0x72, 0x61, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
-	0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x2e, 0x45, 0x78,
-	0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x1a,
-	0x38, 0x0a, 0x0a, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x0b, 0x50, 0x65, 0x65,
-	0x72, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
-	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4f, 0x6e, 0x6c,
-	0x79, 0x22, 0x6a, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x29,
-	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c,
-	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe2, 0x01,
-	0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x69, 0x7a, 0x65,
-	0x12, 0x41, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x27, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x52, 0x65,
-	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63,
-	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x32, 0xd1, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x50, 0x65, 0x65, 0x72,
-	0x73, 0x12, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x18, 0x2e,
-	0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x08, 0x41, 0x64, 0x64,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65,
-	0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x74,
-	0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x52, 0x6d, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e,
-	0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
-	0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74,
-	0x72, 0x74, 0x6c, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x65, 0x65,
-	0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x12, 0x34, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04,
+	0x73, 0x79, 0x6e, 0x63, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x45, 0x78, 0x74, 0x72, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf2,
+	0x01, 0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x79, 0x6e, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x79, 0x6e,
+	0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x31,
+	0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x63, 0x6f,
+	0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x21,
+	0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x46, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x6a, 0x0a, 0x09, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x41, 0x0a, 0x07, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x74, 0x72,
+	0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a,
+	0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x3a, 0x0a, 0x09,
+	0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4c, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x53, 0x50, 0x45, 0x43, 0x54, 0x10, 0x02, 0x12, 0x08,
+	0x0a, 0x04, 0x44, 0x45, 0x41, 0x44, 0x10, 0x03, 0x32, 0xd1, 0x01, 0x0a, 0x0e, 0x50, 0x65, 0x65,
+	0x72, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x1a, 0x18, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x72,
+	0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x1a, 0x1a, 0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x3c,
+	0x0a, 0x07, 0x52, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x13, 0x2e, 0x74, 0x72, 0x74, 0x6c,
+	0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x1a, 0x1a,
+	0x2e, 0x74, 0x72, 0x74, 0x6c, 0x2e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x42, 0x3a, 0x5a, 0x38,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x69, 0x73, 0x61,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x74, 0x72, 0x74, 0x6c, 0x2f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x2f,
+	0x76, 0x31, 0x3b, 0x70, 0x65, 0x65, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -377,31 +585,36 @@ func file_trtl_peers_v1_peers_proto_rawDescGZIP() []byte {
 	return file_trtl_peers_v1_peers_proto_rawDescData
 }
 
-var file_trtl_peers_v1_peers_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_trtl_peers_v1_peers_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_trtl_peers_v1_peers_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_trtl_peers_v1_peers_proto_goTypes = []interface{}{
-	(*Peer)(nil),        // 0: trtl.peers.v1.Peer
-	(*PeersFilter)(nil), // 1: trtl.peers.v1.PeersFilter
-	(*PeersList)(nil),   // 2: trtl.peers.v1.PeersList
-	(*PeersStatus)(nil), // 3: trtl.peers.v1.PeersStatus
-	nil,                 // 4: trtl.peers.v1.Peer.ExtraEntry
-	nil,                 // 5: trtl.peers.v1.PeersStatus.RegionsEntry
+	(PeerState)(0),      // 0: trtl.peers.v1.PeerState
+	(*Peer)(nil),        // 1: trtl.peers.v1.Peer
+	(*SyncStats)(nil),   // 2: trtl.peers.v1.SyncStats
+	(*PeersFilter)(nil), // 3: trtl.peers.v1.PeersFilter
+	(*PeersList)(nil),   // 4: trtl.peers.v1.PeersList
+	(*PeersStatus)(nil), // 5: trtl.peers.v1.PeersStatus
+	nil,                 // 6: trtl.peers.v1.Peer.ExtraEntry
+	nil,                 // 7: trtl.peers.v1.PeersStatus.RegionsEntry
 }
 var file_trtl_peers_v1_peers_proto_depIdxs = []int32{
-	4, // 0: trtl.peers.v1.Peer.extra:type_name -> trtl.peers.v1.Peer.ExtraEntry
-	0, // 1: trtl.peers.v1.PeersList.peers:type_name -> trtl.peers.v1.Peer
-	3, // 2: trtl.peers.v1.PeersList.status:type_name -> trtl.peers.v1.PeersStatus
-	5, // 3: trtl.peers.v1.PeersStatus.regions:type_name -> trtl.peers.v1.PeersStatus.RegionsEntry
-	1, // 4: trtl.peers.v1.PeerManagement.GetPeers:input_type -> trtl.peers.v1.PeersFilter
-	0, // 5: trtl.peers.v1.PeerManagement.AddPeers:input_type -> trtl.peers.v1.Peer
-	0, // 6: trtl.peers.v1.PeerManagement.RmPeers:input_type -> trtl.peers.v1.Peer
-	2, // 7: trtl.peers.v1.PeerManagement.GetPeers:output_type -> trtl.peers.v1.PeersList
-	3, // 8: trtl.peers.v1.PeerManagement.AddPeers:output_type -> trtl.peers.v1.PeersStatus
-	3, // 9: trtl.peers.v1.PeerManagement.RmPeers:output_type -> trtl.peers.v1.PeersStatus
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 0: trtl.peers.v1.Peer.extra:type_name -> trtl.peers.v1.Peer.ExtraEntry
+	2, // 1: trtl.peers.v1.Peer.sync:type_name -> trtl.peers.v1.SyncStats
+	0, // 2: trtl.peers.v1.Peer.state:type_name -> trtl.peers.v1.PeerState
+	1, // 3: trtl.peers.v1.PeersList.peers:type_name -> trtl.peers.v1.Peer
+	5, // 4: trtl.peers.v1.PeersList.status:type_name -> trtl.peers.v1.PeersStatus
+	7, // 5: trtl.peers.v1.PeersStatus.regions:type_name -> trtl.peers.v1.PeersStatus.RegionsEntry
+	3, // 6: trtl.peers.v1.PeerManagement.GetPeers:input_type -> trtl.peers.v1.PeersFilter
+	1, // 7: trtl.peers.v1.PeerManagement.AddPeers:input_type -> trtl.peers.v1.Peer
+	1, // 8: trtl.peers.v1.PeerManagement.RmPeers:input_type -> trtl.peers.v1.Peer
+	4, // 9: trtl.peers.v1.PeerManagement.GetPeers:output_type -> trtl.peers.v1.PeersList
+	5, // 10: trtl.peers.v1.PeerManagement.AddPeers:output_type -> trtl.peers.v1.PeersStatus
+	5, // 11: trtl.peers.v1.PeerManagement.RmPeers:output_type -> trtl.peers.v1.PeersStatus
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_trtl_peers_v1_peers_proto_init() }
@@ -423,7 +636,7 @@ func file_trtl_peers_v1_peers_proto_init() {
 			}
 		}
 		file_trtl_peers_v1_peers_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeersFilter); i {
+			switch v := v.(*SyncStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -435,7 +648,7 @@ func file_trtl_peers_v1_peers_proto_init() {
 			}
 		}
 		file_trtl_peers_v1_peers_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PeersList); i {
+			switch v := v.(*PeersFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -447,6 +660,18 @@ func file_trtl_peers_v1_peers_proto_init() {
 			}
 		}
 		file_trtl_peers_v1_peers_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trtl_peers_v1_peers_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeersStatus); i {
 			case 0:
 				return &v.state
@@ -464,13 +689,14 @@ func file_trtl_peers_v1_peers_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_trtl_peers_v1_peers_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_trtl_peers_v1_peers_proto_goTypes,
 		DependencyIndexes: file_trtl_peers_v1_peers_proto_depIdxs,
+		EnumInfos:         file_trtl_peers_v1_peers_proto_enumTypes,
 		MessageInfos:      file_trtl_peers_v1_peers_proto_msgTypes,
 	}.Build()
 	File_trtl_peers_v1_peers_proto = out.File
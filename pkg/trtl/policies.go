@@ -0,0 +1,97 @@
+package trtl
+
+import (
+	context "context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// A PolicyService implements the RPCs for managing the replication policies of the
+// namespaces. Policies are stored in the replicated policies namespace, so changes are
+// propagated to the rest of the network by anti-entropy.
+type PolicyService struct {
+	namespaces.UnimplementedNamespaceManagementServer
+	parent   *Server
+	registry *replica.Registry
+}
+
+func NewPolicyService(s *Server) (*PolicyService, error) {
+	return &PolicyService{
+		parent:   s,
+		registry: s.replica.Registry(),
+	}, nil
+}
+
+// GetPolicies returns the effective replication policies of the requested namespaces,
+// or of all namespaces that have a built-in or stored policy if none are requested.
+func (p *PolicyService) GetPolicies(ctx context.Context, in *namespaces.PolicyFilter) (out *namespaces.PolicyList, err error) {
+	out = &namespaces.PolicyList{}
+	if len(in.Namespaces) == 0 {
+		if out.Policies, err = p.registry.Policies(); err != nil {
+			log.Error().Err(err).Msg("could not load replication policies")
+			return nil, status.Error(codes.Internal, "could not load replication policies")
+		}
+		return out, nil
+	}
+
+	out.Policies = make([]*namespaces.Policy, 0, len(in.Namespaces))
+	for _, namespace := range in.Namespaces {
+		var policy *namespaces.Policy
+		if policy, err = p.registry.Policy(namespace); err != nil {
+			log.Error().Err(err).Str("namespace", namespace).Msg("could not load replication policy")
+			return nil, status.Error(codes.Internal, "could not load replication policy")
+		}
+		out.Policies = append(out.Policies, policy)
+	}
+	return out, nil
+}
+
+// SetPolicy creates or replaces the replication policy of a namespace.
+func (p *PolicyService) SetPolicy(ctx context.Context, in *namespaces.Policy) (out *namespaces.Policy, err error) {
+	if err = in.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if out, err = p.registry.Set(in); err != nil {
+		if errors.Is(err, replica.ErrBuiltinPolicy) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		log.Error().Err(err).Str("namespace", in.Namespace).Msg("could not store replication policy")
+		return nil, status.Error(codes.Internal, "could not store replication policy")
+	}
+
+	log.Info().
+		Str("namespace", out.Namespace).
+		Bool("replicated", out.Replicated).
+		Strs("regions", out.Regions).
+		Bool("same_region", out.SameRegion).
+		Msg("replication policy updated")
+	return out, nil
+}
+
+// RmPolicy removes the stored replication policy of a namespace, reverting it to its
+// default policy, and returns the effective policy of the namespace.
+func (p *PolicyService) RmPolicy(ctx context.Context, in *namespaces.Policy) (out *namespaces.Policy, err error) {
+	if in.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "a namespace is required")
+	}
+
+	if out, err = p.registry.Delete(in.Namespace); err != nil {
+		switch {
+		case errors.Is(err, replica.ErrBuiltinPolicy):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, replica.ErrPolicyNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		log.Error().Err(err).Str("namespace", in.Namespace).Msg("could not remove replication policy")
+		return nil, status.Error(codes.Internal, "could not remove replication policy")
+	}
+
+	log.Info().Str("namespace", in.Namespace).Msg("replication policy removed")
+	return out, nil
+}
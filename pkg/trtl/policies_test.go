@@ -0,0 +1,85 @@
+package trtl_test
+
+import (
+	"context"
+
+	"github.com/trisacrypto/directory/pkg/trtl"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"google.golang.org/grpc/codes"
+)
+
+// Test that the replication policies of namespaces can be managed at runtime.
+func (s *trtlTestSuite) TestPolicies() {
+	// Ensure the fixtures are restored after the database is modified
+	defer s.reset()
+	require := s.Require()
+	ctx := context.Background()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := namespaces.NewNamespaceManagementClient(s.grpc.Conn)
+
+	// The default policies replicate the built-in namespaces to all regions
+	out, err := client.GetPolicies(ctx, &namespaces.PolicyFilter{})
+	require.NoError(err)
+	require.Len(out.Policies, 8)
+	for _, policy := range out.Policies {
+		require.True(policy.Replicated, "namespace %s should be replicated", policy.Namespace)
+		require.Empty(policy.Regions)
+	}
+
+	// Namespaces without a policy are not replicated
+	out, err = client.GetPolicies(ctx, &namespaces.PolicyFilter{Namespaces: []string{"organizations", trtl.NamespacePeers}})
+	require.NoError(err)
+	require.Len(out.Policies, 2)
+	require.Equal(&namespaces.Policy{Namespace: "organizations"}, out.Policies[0])
+	require.True(out.Policies[1].Builtin)
+
+	// Policies must be valid and built-in policies cannot be modified
+	_, err = client.SetPolicy(ctx, &namespaces.Policy{Namespace: "organizations", GossipInterval: "often"})
+	s.StatusError(err, codes.InvalidArgument, `could not parse gossip interval: time: invalid duration "often"`)
+
+	_, err = client.SetPolicy(ctx, &namespaces.Policy{Namespace: trtl.NamespacePolicies})
+	s.StatusError(err, codes.FailedPrecondition, "the replication policy of the namespace is built in and cannot be modified")
+
+	// Replicate organizations within the local region with its own cadence
+	policy, err := client.SetPolicy(ctx, &namespaces.Policy{
+		Namespace:      "organizations",
+		Replicated:     true,
+		SameRegion:     true,
+		GossipInterval: "5m",
+		GossipSigma:    "30s",
+	})
+	require.NoError(err)
+	require.NotEmpty(policy.Created)
+	require.Equal(policy.Created, policy.Modified)
+
+	out, err = client.GetPolicies(ctx, &namespaces.PolicyFilter{})
+	require.NoError(err)
+	require.Len(out.Policies, 9)
+
+	// Stop replicating the VASPs namespace
+	_, err = client.SetPolicy(ctx, &namespaces.Policy{Namespace: trtl.NamespaceVASPs})
+	require.NoError(err)
+
+	out, err = client.GetPolicies(ctx, &namespaces.PolicyFilter{Namespaces: []string{trtl.NamespaceVASPs}})
+	require.NoError(err)
+	require.False(out.Policies[0].Replicated)
+
+	// Removing the policy reverts the namespace to its default policy
+	policy, err = client.RmPolicy(ctx, &namespaces.Policy{Namespace: trtl.NamespaceVASPs})
+	require.NoError(err)
+	require.True(policy.Replicated)
+	require.Empty(policy.Created)
+
+	policy, err = client.RmPolicy(ctx, &namespaces.Policy{Namespace: "organizations"})
+	require.NoError(err)
+	require.False(policy.Replicated)
+
+	_, err = client.RmPolicy(ctx, &namespaces.Policy{Namespace: "organizations"})
+	s.StatusError(err, codes.NotFound, "the namespace does not have a stored replication policy")
+
+	_, err = client.RmPolicy(ctx, &namespaces.Policy{Namespace: trtl.NamespacePeers})
+	s.StatusError(err, codes.FailedPrecondition, "the replication policy of the namespace is built in and cannot be modified")
+}
@@ -0,0 +1,172 @@
+package replica
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rotationalio/honu"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// BootstrapStats describes the objects that were loaded from a snapshot.
+type BootstrapStats struct {
+	Manifest *snapshot.Manifest // The manifest of the snapshot sent by the peer
+	Updated  uint64             // The number of objects written to the local database
+	Skipped  uint64             // The number of objects that were already up to date
+}
+
+// Bootstrap seeds the local database with a snapshot streamed from a peer, usually
+// before the replica is started for the first time. Objects are written with their
+// version metadata intact and are only written if they are later than the local
+// version, so bootstrapping a database that already contains data is safe; it is not
+// necessary to bootstrap a database more than once since anti-entropy will exchange
+// any later changes. If the snapshot does not match its manifest an error is returned
+// after all of the objects that were received have been written.
+func Bootstrap(ctx context.Context, db *honu.DB, client snapshot.SnapshotClient, in *snapshot.SnapshotRequest) (stats *BootstrapStats, err error) {
+	var stream snapshot.Snapshot_TransferClient
+	if stream, err = client.Transfer(ctx, in); err != nil {
+		return nil, fmt.Errorf("could not start snapshot transfer: %w", err)
+	}
+
+	// Receive the chunks in a go routine, writing them to a pipe that is read by the
+	// decompressor; the manifest is returned when the stream is complete.
+	pr, pw := io.Pipe()
+	manifests := make(chan *snapshot.Manifest, 1)
+	go func() {
+		defer close(manifests)
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = errors.New("snapshot stream ended without a manifest")
+				}
+				pw.CloseWithError(err)
+				return
+			}
+
+			if _, err = pw.Write(chunk.Data); err != nil {
+				return
+			}
+
+			if chunk.Manifest != nil {
+				manifests <- chunk.Manifest
+				pw.Close()
+				return
+			}
+		}
+	}()
+
+	// Ensure the receiver go routine exits if the snapshot cannot be read
+	defer pr.Close()
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(pr); err != nil {
+		return nil, fmt.Errorf("could not decompress snapshot: %w", err)
+	}
+
+	stats = &BootstrapStats{}
+	checksum := sha256.New()
+	r := bufio.NewReader(io.TeeReader(gz, checksum))
+	namespaces := make(map[string]uint64)
+
+	for {
+		var obj *object.Object
+		if obj, err = readObject(r); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return stats, fmt.Errorf("could not read snapshot: %w", err)
+		}
+		namespaces[obj.Namespace]++
+
+		var updated bool
+		if updated, err = bootstrapObject(db, obj); err != nil {
+			return stats, fmt.Errorf("could not write %s object %s: %w", obj.Namespace, b64e(obj.Key), err)
+		}
+
+		if updated {
+			stats.Updated++
+		} else {
+			stats.Skipped++
+		}
+	}
+
+	// Verify the snapshot that was received against the manifest
+	if stats.Manifest = <-manifests; stats.Manifest == nil {
+		return stats, errors.New("snapshot stream ended without a manifest")
+	}
+
+	if !bytes.Equal(checksum.Sum(nil), stats.Manifest.Checksum) {
+		return stats, errors.New("snapshot checksum does not match manifest")
+	}
+
+	if stats.Updated+stats.Skipped != stats.Manifest.Objects {
+		return stats, fmt.Errorf("received %d objects but manifest has %d objects", stats.Updated+stats.Skipped, stats.Manifest.Objects)
+	}
+
+	for namespace, count := range stats.Manifest.Namespaces {
+		if namespaces[namespace] != count {
+			return stats, fmt.Errorf("received %d objects in namespace %q but manifest has %d objects", namespaces[namespace], namespace, count)
+		}
+	}
+
+	log.Info().
+		Uint64("updated", stats.Updated).
+		Uint64("skipped", stats.Skipped).
+		Str("peer", stats.Manifest.Name).
+		Str("created", stats.Manifest.Created).
+		Msg("bootstrapped database from snapshot")
+	return stats, nil
+}
+
+// Write the object if it is later than the local version of the object, returning
+// false if the local version is already up to date.
+func bootstrapObject(db *honu.DB, obj *object.Object) (_ bool, err error) {
+	var local *object.Object
+	if local, err = db.Object(obj.Key, options.WithNamespace(obj.Namespace)); err != nil {
+		if !errors.Is(err, engine.ErrNotFound) {
+			return false, err
+		}
+	} else if !obj.Version.IsLater(local.Version) {
+		return false, nil
+	}
+
+	if _, err = db.Update(obj, options.WithNamespace(obj.Namespace)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Read the next length prefixed object from the snapshot.
+func readObject(r *bufio.Reader) (_ *object.Object, err error) {
+	var size uint64
+	if size, err = binary.ReadUvarint(r); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, size)
+	if _, err = io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	obj := &object.Object{}
+	if err = proto.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -28,8 +28,14 @@ acts as the client when connecting to the remote peer. The AntiEntropy routine s
 for a jittered interval -- a random amount of time normally distributed by a mean and
 standard deviation duration -- to ensure that anti-entropy sessions are not all
 happening concurrently. After this interval has passed, the initiator will select a
-random peer in the network, and will open up a Gossip stream to it. It then begins two
-phases in three go routines to start the anti-entropy session. We refer to these phases
+peer in the network, and will open up a Gossip stream to it. Each namespace is
+scheduled with its own interval, as described in Replication Policies below. Peers are selected
+uniformly at random by default, but the peer selection strategy can be configured to
+prefer peers in the same region, the peer that was least recently synchronized with,
+or to back off from peers whose most recent session failed; the outcome of each
+session is recorded for these strategies and is reported by GetPeers. When membership
+is enabled, peers whose heartbeats have expired are not selected. The initiator
+then begins two phases in three go routines to start the anti-entropy session. We refer to these phases
 as the initiator phase 1 and phase 2 respectively.
 
 The Gossip stream is handled by the remote node in the Gossip method - the gRPC handler
@@ -105,5 +111,49 @@ care with synchronization -- this is where most of the bugs and safety issues ar
 
 At the end of an anti-entropy session both the initiator and the remote will have
 identical underlying databases until the next access!
+
+# Merkle Digests
+
+Before opening the Gossip stream, the initiator compares merkle trees with the remote
+using the Merkle Compare RPC. Each replicated namespace is summarized by a tree whose
+leaves are ranges of hashed keys; the digest of a leaf is the hash of the keys and
+versions of its objects and the digest of every other node is the hash of its children.
+The initiator requests the root digests first and only descends into the nodes whose
+digests differ from its own, finding the divergent leaves in a handful of round trips.
+The divergent leaves are sent to the remote in the metadata of the Gossip stream, and
+both phase 1 on the initiator and phase 2 on the remote skip objects in leaves that are
+identical, so the number of messages exchanged is proportional to the divergence of
+the replicas rather than the size of the database. If the trees are identical the
+session ends without gossip, and if the remote cannot compare trees all objects are
+exchanged as before.
+
+# Replication Policies
+
+Which namespaces are replicated, and where, is determined by the replication policy of
+each namespace, managed by the Registry. A policy specifies whether the namespace is
+replicated, the regions it is replicated in (or only within the region of each
+replica), and the mean and standard deviation of the interval between its sessions.
+Policies are stored in the policies namespace, which is itself replicated, so a policy
+set on one replica is applied by the rest of the network once it has propagated. The
+peers and policies namespaces are always replicated to every region.
+
+AntiEntropy schedules each replicated namespace independently. When namespaces are due,
+it selects a peer that at least one of them can be exchanged with and the session only
+exchanges the namespaces that are due and whose policies allow the region of the peer.
+The initiator sends its region and the namespaces of the session in the metadata of the
+Compare and Gossip streams; the remote intersects them with the namespaces its own
+policies allow it to exchange with the region of the initiator and ignores objects in
+any other namespace, so a replica never accepts objects it does not replicate.
+
+# Bootstrapping
+
+A new replica starts with an empty database, and converging by anti-entropy alone is
+slow for a large directory since every session iterates over every object. Instead, a
+new replica can be bootstrapped with the Snapshot Transfer RPC before it is started. The
+peer takes a leveldb snapshot and streams every object in the namespaces that its
+policies replicate to the region of the new replica as a gzip compressed sequence of
+length prefixed honu objects, followed by a manifest with the number of objects and a
+checksum. The objects are written with their versions intact, so the new replica only
+has to exchange the changes made since the snapshot.
 */
 package replica
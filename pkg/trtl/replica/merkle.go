@@ -0,0 +1,521 @@
+package replica
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/iterator"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// The shape of the merkle tree that summarizes each namespace; a fanout of 16 and a
+	// depth of 3 divides the hashed key space into 4096 leaves, which can be compared
+	// in 4 round trips.
+	merkleFanout = 16
+	merkleDepth  = 3
+
+	// The metadata key that the initiator uses to send the divergent leaves to the
+	// remote on the gossip stream. The -bin suffix ensures gRPC encodes the value.
+	divergenceKey = "trtl-divergence-bin"
+)
+
+var (
+	// The number of leaves in the tree and the level order index of the first leaf.
+	merkleLeaves    = pow(merkleFanout, merkleDepth)
+	merkleFirstLeaf = (merkleLeaves - 1) / (merkleFanout - 1)
+	merkleNodes     = merkleFirstLeaf + merkleLeaves
+)
+
+// merkleTree summarizes the objects in a namespace so that two replicas can find the
+// objects that differ without exchanging every version. Keys are hashed into leaves and
+// each leaf digest combines the hashes of the keys and versions of its objects with xor
+// so that the leaf can be updated as objects are written without rescanning the leaf;
+// the digest of each internal node is the hash of its children's digests. Nodes are
+// stored in level order so that the root is at index 0 and the children of node i are
+// at i*fanout+1 through i*fanout+fanout.
+type merkleTree struct {
+	digests  [][]byte
+	objects  []uint64
+	versions map[string]version
+}
+
+// version is the scalar and process ID of the version of an object in the tree.
+type version struct {
+	pid     uint64
+	version uint64
+}
+
+func (v version) Version() *object.Version {
+	return &object.Version{Pid: v.pid, Version: v.version}
+}
+
+func newMerkleTree() *merkleTree {
+	return &merkleTree{
+		digests:  make([][]byte, merkleNodes),
+		objects:  make([]uint64, merkleNodes),
+		versions: make(map[string]version),
+	}
+}
+
+// Build a merkle tree by iterating over all of the objects in the namespace, including
+// tombstones, since tombstones are also exchanged during anti-entropy.
+func buildMerkleTree(db *honu.DB, namespace string) (tree *merkleTree, err error) {
+	tree = newMerkleTree()
+
+	var iter iterator.Iterator
+	if iter, err = db.Iter(nil, options.WithNamespace(namespace), options.WithTombstones()); err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		var obj *object.Object
+		if obj, err = iter.Object(); err != nil {
+			return nil, fmt.Errorf("could not unmarshal honu metadata for %s: %w", b64e(iter.Key()), err)
+		}
+		tree.add(obj.Key, obj.Version)
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	// Compute the internal nodes from the bottom of the tree up to the root.
+	for node := int(merkleFirstLeaf) - 1; node >= 0; node-- {
+		tree.rehash(uint32(node))
+	}
+	return tree, nil
+}
+
+// Update the tree with the version of an object that was written to the namespace. The
+// version is ignored if the tree already contains a later version of the object so that
+// concurrent writers can update the tree in any order.
+func (t *merkleTree) Update(obj *object.Object) {
+	if current, ok := t.versions[string(obj.Key)]; ok {
+		if !obj.Version.IsLater(current.Version()) {
+			return
+		}
+		t.remove(obj.Key, current)
+	}
+
+	leaf := t.add(obj.Key, obj.Version)
+	t.rehashPath(leaf)
+}
+
+// Remove the object from the tree if the tree contains the specified version of the
+// object, e.g. when a tombstone is compacted.
+func (t *merkleTree) Remove(obj *object.Object) {
+	current, ok := t.versions[string(obj.Key)]
+	if !ok || !obj.Version.Equal(current.Version()) {
+		return
+	}
+
+	leaf := t.remove(obj.Key, current)
+	t.rehashPath(leaf)
+}
+
+// Snapshot returns a copy of the digests of the tree that is not modified by writes.
+func (t *merkleTree) Snapshot() *merkleTree {
+	snap := &merkleTree{
+		digests: make([][]byte, merkleNodes),
+		objects: make([]uint64, merkleNodes),
+	}
+	copy(snap.digests, t.digests)
+	copy(snap.objects, t.objects)
+	return snap
+}
+
+// Digest returns the digest of the node, which is nil if there are no objects in the
+// key range of the node or if the node is not in the tree.
+func (t *merkleTree) Digest(node uint32) *merkle.Digest {
+	if node >= merkleNodes {
+		return &merkle.Digest{Node: node}
+	}
+	return &merkle.Digest{Node: node, Hash: t.digests[node], Objects: t.objects[node]}
+}
+
+// Add the version of the key to its leaf, returning the level order index of the leaf.
+// The internal nodes must be rehashed after the leaf is modified.
+func (t *merkleTree) add(key []byte, vers *object.Version) uint32 {
+	node := merkleFirstLeaf + merkleLeaf(key)
+	t.versions[string(key)] = version{pid: vers.Pid, version: vers.Version}
+	t.objects[node]++
+	t.digests[node] = xorDigest(t.digests[node], hashVersion(key, vers.Pid, vers.Version))
+	return node
+}
+
+// Remove the version of the key from its leaf, returning the level order index of the
+// leaf. The internal nodes must be rehashed after the leaf is modified.
+func (t *merkleTree) remove(key []byte, vers version) uint32 {
+	node := merkleFirstLeaf + merkleLeaf(key)
+	delete(t.versions, string(key))
+	t.objects[node]--
+	if t.objects[node] == 0 {
+		t.digests[node] = nil
+	} else {
+		t.digests[node] = xorDigest(t.digests[node], hashVersion(key, vers.pid, vers.version))
+	}
+	return node
+}
+
+// Rehash the ancestors of the node up to the root of the tree.
+func (t *merkleTree) rehashPath(node uint32) {
+	for node > 0 {
+		node = (node - 1) / merkleFanout
+		t.rehash(node)
+	}
+}
+
+// Compute the digest and object count of an internal node from its children.
+func (t *merkleTree) rehash(node uint32) {
+	empty := make([]byte, sha256.Size)
+	h := sha256.New()
+	t.objects[node] = 0
+	for _, child := range merkleChildren(node) {
+		t.objects[node] += t.objects[child]
+		if digest := t.digests[child]; digest != nil {
+			h.Write(digest)
+		} else {
+			h.Write(empty)
+		}
+	}
+
+	if t.objects[node] > 0 {
+		t.digests[node] = h.Sum(nil)
+	} else {
+		t.digests[node] = nil
+	}
+}
+
+// Hash the key and version of an object. The key is length prefixed so that adjacent
+// fields cannot produce the same digest.
+func hashVersion(key []byte, pid, version uint64) []byte {
+	h := sha256.New()
+	buf := make([]byte, binary.MaxVarintLen64)
+	h.Write(buf[:binary.PutUvarint(buf, uint64(len(key)))])
+	h.Write(key)
+	h.Write(buf[:binary.PutUvarint(buf, pid)])
+	h.Write(buf[:binary.PutUvarint(buf, version)])
+	return h.Sum(nil)
+}
+
+// Returns a new digest that is the xor of the digest with the hash; a nil digest is
+// treated as all zeros.
+func xorDigest(digest, hash []byte) []byte {
+	out := make([]byte, sha256.Size)
+	copy(out, digest)
+	for i := range out {
+		out[i] ^= hash[i]
+	}
+	return out
+}
+
+// merkleTrees maintains the merkle tree of each namespace in memory. Each tree is built
+// from a full scan of its namespace the first time it is compared and is then updated
+// as objects are written to the namespace, so anti-entropy sessions do not need to scan
+// the database to compute digests.
+type merkleTrees struct {
+	sync.Mutex
+	db    *honu.DB
+	trees map[string]*merkleTree
+}
+
+func newMerkleTrees(db *honu.DB) *merkleTrees {
+	return &merkleTrees{db: db, trees: make(map[string]*merkleTree)}
+}
+
+// Snapshot returns a copy of the tree of the namespace, building the tree if necessary.
+func (m *merkleTrees) Snapshot(namespace string) (_ *merkleTree, err error) {
+	m.Lock()
+	defer m.Unlock()
+
+	tree, ok := m.trees[namespace]
+	if !ok {
+		// The lock is held while the tree is built so that writes to the namespace that
+		// happen during the scan are applied to the tree once it is complete.
+		if tree, err = buildMerkleTree(m.db, namespace); err != nil {
+			return nil, err
+		}
+		m.trees[namespace] = tree
+	}
+	return tree.Snapshot(), nil
+}
+
+// Update the tree of the object's namespace if the tree has been built. Update is a
+// no-op on nil trees so that writers do not need to check if replication is enabled.
+func (m *merkleTrees) Update(obj *object.Object) {
+	if m == nil || obj == nil {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if tree, ok := m.trees[obj.Namespace]; ok {
+		tree.Update(obj)
+	}
+}
+
+// Remove the object from the tree of its namespace if the tree has been built.
+func (m *merkleTrees) Remove(obj *object.Object) {
+	if m == nil || obj == nil {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if tree, ok := m.trees[obj.Namespace]; ok {
+		tree.Remove(obj)
+	}
+}
+
+// Returns the leaf (not the level order index) that the key is hashed into.
+func merkleLeaf(key []byte) uint32 {
+	h := fnv.New64a()
+	h.Write(key)
+	return uint32(h.Sum64() % uint64(merkleLeaves))
+}
+
+func merkleChildren(node uint32) []uint32 {
+	children := make([]uint32, 0, merkleFanout)
+	for i := uint32(1); i <= merkleFanout; i++ {
+		children = append(children, node*merkleFanout+i)
+	}
+	return children
+}
+
+func pow(base, exp uint32) uint32 {
+	n := uint32(1)
+	for i := uint32(0); i < exp; i++ {
+		n *= base
+	}
+	return n
+}
+
+//===========================================================================
+// Merkle (server-side) Methods
+//===========================================================================
+
+// Compare responds to digest requests from an initiator that is preparing for an
+// anti-entropy session. The tree for each namespace is copied on the first request for
+// that namespace and the copy is used for the remainder of the stream so that the
+// initiator descends through a consistent tree.
+func (r *Service) Compare(stream merkle.Merkle_CompareServer) (err error) {
+	logctx := log.With().Str("service", "merkle").Logger()
+
+	// Do not participate in anti-entropy if it is not enabled on this replica.
+	if !r.conf.Enabled {
+		logctx.Debug().Msg("rejecting compare request: anti-entropy not enabled")
+		return status.Error(codes.FailedPrecondition, "anti-entropy not enabled on remote")
+	}
+
+	// Only compare the namespaces that can be exchanged with the initiator
+	var namespaces []string
+	if namespaces, err = r.remoteNamespaces(stream.Context()); err != nil {
+		logctx.Error().Err(err).Msg("could not load replication policies")
+		return status.Error(codes.Internal, "could not load replication policies")
+	}
+
+	trees := make(map[string]*merkleTree)
+	for {
+		var in *merkle.DigestRequest
+		if in, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			logctx.Warn().Err(err).Msg("compare aborted early with error")
+			return err
+		}
+
+		if in.Fanout != merkleFanout || in.Depth != merkleDepth {
+			return status.Errorf(codes.InvalidArgument, "tree shape does not match remote (fanout %d, depth %d)", merkleFanout, merkleDepth)
+		}
+
+		if !contains(namespaces, in.Namespace) {
+			return status.Errorf(codes.InvalidArgument, "namespace %q is not replicated", in.Namespace)
+		}
+
+		tree, ok := trees[in.Namespace]
+		if !ok {
+			if tree, err = r.trees.Snapshot(in.Namespace); err != nil {
+				logctx.Error().Err(err).Str("namespace", in.Namespace).Msg("could not build merkle tree")
+				return status.Error(codes.Internal, "could not compute namespace digests")
+			}
+			trees[in.Namespace] = tree
+		}
+
+		out := &merkle.DigestReply{
+			Namespace: in.Namespace,
+			Digests:   make([]*merkle.Digest, 0, len(in.Nodes)),
+		}
+		for _, node := range in.Nodes {
+			out.Digests = append(out.Digests, tree.Digest(node))
+		}
+
+		if err = stream.Send(out); err != nil {
+			logctx.Warn().Err(err).Msg("could not send digests")
+			return err
+		}
+	}
+}
+
+//===========================================================================
+// Merkle (initiator/client-side) Methods
+//===========================================================================
+
+// divergence maps each replicated namespace to a bitmap of the leaves of its tree that
+// differ between the initiator and the remote. A nil divergence means that the trees
+// could not be compared, so every object must be exchanged. Namespaces that are not in
+// a non-nil divergence are identical on both replicas.
+type divergence map[string][]byte
+
+// Diverged returns true if the object must be exchanged during anti-entropy.
+func (d divergence) Diverged(namespace string, key []byte) bool {
+	if d == nil {
+		return true
+	}
+
+	leaves, ok := d[namespace]
+	if !ok {
+		return false
+	}
+
+	leaf := merkleLeaf(key)
+	return int(leaf/8) < len(leaves) && leaves[leaf/8]&(1<<(leaf%8)) != 0
+}
+
+func (d divergence) add(namespace string, leaf uint32) {
+	if _, ok := d[namespace]; !ok {
+		d[namespace] = make([]byte, (merkleLeaves+7)/8)
+	}
+	d[namespace][leaf/8] |= 1 << (leaf % 8)
+}
+
+// Leaves returns the total number of divergent leaves for logging.
+func (d divergence) Leaves() (n int) {
+	for _, leaves := range d {
+		for _, b := range leaves {
+			for ; b > 0; b &= b - 1 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Attach the divergence to the outgoing context so that the remote only pushes the
+// objects in divergent leaves. If the divergence is nil the context is not modified.
+func (d divergence) AppendToOutgoingContext(ctx context.Context) (_ context.Context, err error) {
+	if d == nil {
+		return ctx, nil
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(&merkle.Divergence{Leaves: d}); err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx, divergenceKey, string(data)), nil
+}
+
+// Read the divergence sent by the initiator from the incoming gossip context; if the
+// initiator did not compare trees then nil is returned so that all objects are pushed.
+func divergenceFromContext(ctx context.Context) (_ divergence, err error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(divergenceKey)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	msg := &merkle.Divergence{}
+	if err = proto.Unmarshal([]byte(values[0]), msg); err != nil {
+		return nil, err
+	}
+
+	if msg.Leaves == nil {
+		return divergence{}, nil
+	}
+	return divergence(msg.Leaves), nil
+}
+
+// compare the local trees with the remote's trees, descending from the root of each
+// namespace into the nodes whose digests differ to find the divergent leaves. If the
+// remote does not support comparisons an error is returned and the caller should fall
+// back to exchanging all versions.
+func (r *Service) compare(ctx context.Context, cc *grpc.ClientConn, namespaces []string, log zerolog.Logger) (diverged divergence, err error) {
+	var stream merkle.Merkle_CompareClient
+	if stream, err = merkle.NewMerkleClient(cc).Compare(ctx); err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	diverged = make(divergence)
+	for _, namespace := range namespaces {
+		var tree *merkleTree
+		if tree, err = r.trees.Snapshot(namespace); err != nil {
+			return nil, fmt.Errorf("could not build merkle tree for %s: %w", namespace, err)
+		}
+
+		// Descend one level of the tree per round trip
+		nodes := []uint32{0}
+		for len(nodes) > 0 {
+			req := &merkle.DigestRequest{
+				Namespace: namespace,
+				Nodes:     nodes,
+				Fanout:    merkleFanout,
+				Depth:     merkleDepth,
+			}
+			if err = stream.Send(req); err != nil {
+				return nil, err
+			}
+
+			var rep *merkle.DigestReply
+			if rep, err = stream.Recv(); err != nil {
+				return nil, err
+			}
+
+			if rep.Namespace != namespace || len(rep.Digests) != len(nodes) {
+				return nil, errors.New("remote replied with unexpected digests")
+			}
+
+			nodes = nil
+			for _, remote := range rep.Digests {
+				if bytes.Equal(tree.Digest(remote.Node).Hash, remote.Hash) {
+					continue
+				}
+
+				if remote.Node >= merkleFirstLeaf {
+					diverged.add(namespace, remote.Node-merkleFirstLeaf)
+				} else {
+					nodes = append(nodes, merkleChildren(remote.Node)...)
+				}
+			}
+		}
+	}
+
+	log.Debug().
+		Int("leaves", diverged.Leaves()).
+		Int("namespaces", len(diverged)).
+		Msg("compared merkle trees with remote peer")
+	return diverged, nil
+}
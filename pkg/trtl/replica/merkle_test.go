@@ -0,0 +1,351 @@
+package replica
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rotationalio/honu/replica"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
+	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/utils/bufconn"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMerkleTree(t *testing.T) {
+	require.Equal(t, uint32(4096), merkleLeaves)
+	require.Equal(t, uint32(273), merkleFirstLeaf)
+	require.Equal(t, uint32(4369), merkleNodes)
+	require.Equal(t, uint32(1), merkleChildren(0)[0])
+	require.Equal(t, merkleNodes-1, merkleChildren(merkleFirstLeaf - 1)[merkleFanout-1])
+
+	// Identical objects should produce identical trees on different replicas
+	alpha, bravo := openDB(t), openDB(t)
+	putObjects(t, alpha, "vasps", 100)
+	copyObjects(t, alpha, bravo, "vasps")
+
+	ta, err := buildMerkleTree(alpha, "vasps")
+	require.NoError(t, err)
+	tb, err := buildMerkleTree(bravo, "vasps")
+	require.NoError(t, err)
+	require.Equal(t, ta, tb)
+	require.Equal(t, uint64(100), ta.Digest(0).Objects)
+	require.Len(t, ta.Digest(0).Hash, 32)
+
+	// An empty namespace has an empty root
+	empty, err := buildMerkleTree(alpha, "certreqs")
+	require.NoError(t, err)
+	require.Nil(t, empty.Digest(0).Hash)
+	require.Zero(t, empty.Digest(0).Objects)
+
+	// Modifying an object changes the digest of its leaf and the root but not the
+	// digests of the other leaves
+	_, err = bravo.Put([]byte("vasp042"), []byte("modified"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	tb, err = buildMerkleTree(bravo, "vasps")
+	require.NoError(t, err)
+	require.NotEqual(t, ta.Digest(0).Hash, tb.Digest(0).Hash)
+
+	leaf := merkleFirstLeaf + merkleLeaf([]byte("vasp042"))
+	for node := merkleFirstLeaf; node < merkleNodes; node++ {
+		if node == leaf {
+			require.NotEqual(t, ta.Digest(node).Hash, tb.Digest(node).Hash)
+		} else {
+			require.Equal(t, ta.Digest(node).Hash, tb.Digest(node).Hash)
+		}
+	}
+}
+
+// Test that a tree that is updated as objects are written matches a tree that is built
+// from a full scan of the namespace.
+func TestMerkleTreeUpdate(t *testing.T) {
+	db := openDB(t)
+	putObjects(t, db, "vasps", 100)
+
+	tree, err := buildMerkleTree(db, "vasps")
+	require.NoError(t, err)
+
+	// Modify, delete, and create objects
+	modified, err := db.Put([]byte("vasp007"), []byte("modified"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	tree.Update(modified)
+
+	tombstone, err := db.Delete([]byte("vasp042"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	tree.Update(tombstone)
+
+	for i := 100; i < 120; i++ {
+		obj, err := db.Put([]byte(fmt.Sprintf("vasp%03d", i)), []byte("created"), options.WithNamespace("vasps"))
+		require.NoError(t, err)
+		tree.Update(obj)
+	}
+
+	// Earlier versions should not modify the tree if they are observed out of order
+	tree.Update(&object.Object{Key: modified.Key, Namespace: "vasps", Version: modified.Version.Parent})
+
+	expected, err := buildMerkleTree(db, "vasps")
+	require.NoError(t, err)
+	require.Equal(t, expected, tree)
+	require.Equal(t, uint64(120), tree.Digest(0).Objects)
+
+	// Snapshots should not be modified by later updates
+	snap := tree.Snapshot()
+	require.Equal(t, expected.Digest(0), snap.Digest(0))
+
+	// Removing the compacted tombstone should match a tree without the tombstone
+	tree.Remove(tombstone)
+	require.Equal(t, uint64(119), tree.Digest(0).Objects)
+	require.Equal(t, uint64(120), snap.Digest(0).Objects)
+
+	other := openDB(t)
+	iter, err := db.Iter(nil, options.WithNamespace("vasps"), options.WithTombstones())
+	require.NoError(t, err)
+	for iter.Next() {
+		obj, err := iter.Object()
+		require.NoError(t, err)
+		if obj.Tombstone() {
+			continue
+		}
+		_, err = other.Update(obj, options.WithNamespace("vasps"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, iter.Error())
+	iter.Release()
+
+	expected, err = buildMerkleTree(other, "vasps")
+	require.NoError(t, err)
+	require.Equal(t, expected, tree)
+
+	// Removing the last object in a leaf should empty the leaf
+	empty := newMerkleTree()
+	obj, err := db.Put([]byte("alone"), []byte("data"), options.WithNamespace("certreqs"))
+	require.NoError(t, err)
+	empty.Update(obj)
+	empty.Remove(obj)
+	require.Equal(t, newMerkleTree(), empty)
+}
+
+func TestMerkleCompare(t *testing.T) {
+	local, remote := openDB(t), openDB(t)
+	for _, namespace := range []string{"vasps", "certreqs"} {
+		putObjects(t, local, namespace, 200)
+		copyObjects(t, local, remote, namespace)
+	}
+
+	// Serve the merkle service from the remote
+	svc := newTestService(t, remote, 2)
+	client := serveMerkle(t, svc)
+
+	// Identical replicas should have no divergence
+	initiator := newTestService(t, local, 1)
+	diverged, err := initiator.compare(context.Background(), client, []string{"vasps", "certreqs"}, log.Logger)
+	require.NoError(t, err)
+	require.NotNil(t, diverged)
+	require.Empty(t, diverged)
+
+	// Modify, delete, and create objects on both replicas; the writes are observed so
+	// that the trees maintained by the services are updated.
+	obj, err := local.Put([]byte("vasp007"), []byte("modified"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	initiator.Observe(obj)
+	obj, err = remote.Delete([]byte("vasp123"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	svc.Observe(obj)
+	obj, err = remote.Put([]byte("new"), []byte("created"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	svc.Observe(obj)
+
+	diverged, err = initiator.compare(context.Background(), client, []string{"vasps", "certreqs"}, log.Logger)
+	require.NoError(t, err)
+	require.Len(t, diverged, 1, "only the vasps namespace should have diverged")
+	require.Equal(t, 3, diverged.Leaves())
+
+	for i := 0; i < 200; i++ {
+		key := []byte(fmt.Sprintf("vasp%03d", i))
+		expected := merkleLeaf(key) == merkleLeaf([]byte("vasp007")) || merkleLeaf(key) == merkleLeaf([]byte("vasp123")) || merkleLeaf(key) == merkleLeaf([]byte("new"))
+		require.Equal(t, expected, diverged.Diverged("vasps", key))
+		require.False(t, diverged.Diverged("certreqs", key))
+	}
+	require.True(t, diverged.Diverged("vasps", []byte("new")))
+
+	// The divergence should be sent to the remote in the gossip metadata
+	ctx, err := diverged.AppendToOutgoingContext(context.Background())
+	require.NoError(t, err)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	parsed, err := divergenceFromContext(metadata.NewIncomingContext(ctx, md))
+	require.NoError(t, err)
+	require.Equal(t, diverged, parsed)
+
+	// A nil divergence means that all objects have diverged
+	var all divergence
+	require.True(t, all.Diverged("certreqs", []byte("vasp001")))
+	ctx, err = all.AppendToOutgoingContext(context.Background())
+	require.NoError(t, err)
+	md, _ = metadata.FromOutgoingContext(ctx)
+	parsed, err = divergenceFromContext(metadata.NewIncomingContext(ctx, md))
+	require.NoError(t, err)
+	require.Nil(t, parsed)
+
+	// Remotes that do not replicate the namespace cannot compare trees
+	_, err = initiator.compare(context.Background(), client, []string{"vasps", "index"}, log.Logger)
+	require.Error(t, err)
+
+	// Remotes do not compare namespaces that their policies do not replicate to the
+	// region of the initiator
+	_, err = svc.Registry().Set(&namespaces.Policy{Namespace: "vasps", Replicated: true, Regions: []string{"us-east-1"}})
+	require.NoError(t, err)
+
+	ctx = appendSessionToOutgoingContext(context.Background(), "eu-west-1", []string{"vasps"})
+	_, err = initiator.compare(ctx, client, []string{"vasps"}, log.Logger)
+	require.Error(t, err)
+
+	ctx = appendSessionToOutgoingContext(context.Background(), "us-east-1", []string{"vasps"})
+	_, err = initiator.compare(ctx, client, []string{"vasps"}, log.Logger)
+	require.NoError(t, err)
+}
+
+// Test that an anti-entropy session only exchanges divergent objects and that both
+// replicas are synchronized at the end of the session.
+func TestAntiEntropyMerkle(t *testing.T) {
+	local, remote := openDB(t), openDB(t)
+	for _, namespace := range []string{"vasps", "certreqs"} {
+		putObjects(t, local, namespace, 200)
+		copyObjects(t, local, remote, namespace)
+	}
+
+	_, err := local.Put([]byte("vasp007"), []byte("modified"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	_, err = remote.Delete([]byte("vasp123"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	_, err = remote.Put([]byte("new"), []byte("created"), options.WithNamespace("certreqs"))
+	require.NoError(t, err)
+
+	// Metrics must be initialized before anti-entropy is run
+	_, err = prom.New()
+	require.NoError(t, err)
+
+	// Serve gossip and merkle comparisons from the remote on a local port
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	svc := newTestService(t, remote, 2)
+	replica.RegisterReplicationServer(srv, svc)
+	merkle.RegisterMerkleServer(srv, svc)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	peer := &peers.Peer{Id: 2, Addr: lis.Addr().String(), Name: "remote"}
+	initiator := newTestService(t, local, 1)
+	require.NoError(t, initiator.AntiEntropySync(peer, log.Logger))
+
+	// The remote may still be applying repairs after the initiator closes the stream
+	require.Eventually(t, func() bool {
+		for _, namespace := range []string{"vasps", "certreqs"} {
+			lt, err := buildMerkleTree(local, namespace)
+			require.NoError(t, err)
+			rt, err := buildMerkleTree(remote, namespace)
+			require.NoError(t, err)
+			if !reflect.DeepEqual(lt, rt) {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 50*time.Millisecond, "replicas were not synchronized")
+
+	val, err := remote.Get([]byte("vasp007"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("modified"), val)
+
+	val, err = local.Get([]byte("new"), options.WithNamespace("certreqs"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("created"), val)
+
+	obj, err := local.Object([]byte("vasp123"), options.WithNamespace("vasps"))
+	require.NoError(t, err)
+	require.True(t, obj.Tombstone())
+
+	// A session between synchronized replicas should not need to gossip
+	require.NoError(t, initiator.AntiEntropySync(peer, log.Logger))
+}
+
+func openDB(t *testing.T) *honu.DB {
+	tmp, err := ioutil.TempDir("testdata", "*-db")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmp) })
+
+	db, err := honu.Open("leveldb:///" + tmp)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func putObjects(t *testing.T, db *honu.DB, namespace string, n int) {
+	for i := 0; i < n; i++ {
+		_, err := db.Put([]byte(fmt.Sprintf("vasp%03d", i)), []byte(fmt.Sprintf("%s data %d", namespace, i)), options.WithNamespace(namespace))
+		require.NoError(t, err)
+	}
+}
+
+// Copy objects with their versions intact as though they had been replicated.
+func copyObjects(t *testing.T, src, dst *honu.DB, namespace string) {
+	iter, err := src.Iter(nil, options.WithNamespace(namespace), options.WithTombstones())
+	require.NoError(t, err)
+	defer iter.Release()
+
+	for iter.Next() {
+		obj, err := iter.Object()
+		require.NoError(t, err)
+		_, err = dst.Update(obj, options.WithNamespace(namespace))
+		require.NoError(t, err)
+	}
+	require.NoError(t, iter.Error())
+}
+
+func newTestService(t *testing.T, db *honu.DB, pid uint64) *Service {
+	conf := config.Config{
+		Replica: config.ReplicaConfig{
+			Enabled:        true,
+			PID:            pid,
+			Region:         "us-east-1",
+			Name:           fmt.Sprintf("replica%d", pid),
+			GossipInterval: time.Minute,
+			GossipSigma:    time.Second,
+		},
+		MTLS: config.MTLSConfig{Insecure: true},
+	}
+
+	svc, err := New(conf, db, []string{"vasps", "certreqs"})
+	require.NoError(t, err)
+	return svc
+}
+
+func serveMerkle(t *testing.T, svc *Service) *grpc.ClientConn {
+	srv := grpc.NewServer()
+	merkle.RegisterMerkleServer(srv, svc)
+
+	sock := bufconn.New(1024*1024, "")
+	go srv.Serve(sock.Listener)
+	require.NoError(t, sock.Connect(context.Background()))
+
+	t.Cleanup(func() {
+		sock.Close()
+		srv.GracefulStop()
+		sock.Release()
+	})
+	return sock.Conn
+}
@@ -0,0 +1,210 @@
+package replica
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rotationalio/honu"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	"google.golang.org/protobuf/proto"
+)
+
+// NamespacePolicies is the namespace that the replication policies are stored in.
+const NamespacePolicies = "policies"
+
+var (
+	ErrBuiltinPolicy  = errors.New("the replication policy of the namespace is built in and cannot be modified")
+	ErrPolicyNotFound = errors.New("the namespace does not have a stored replication policy")
+)
+
+// Registry manages the replication policies of the namespaces. Policies are stored in
+// the policies namespace, which is replicated by anti-entropy so that every replica in
+// the network eventually applies the same policies. Namespaces without a stored policy
+// use the default policy they were registered with (replicated to every region at the
+// cadence of the replica configuration), or are not replicated if they do not have one.
+//
+// The peers and policies namespaces must be replicated to every region for membership
+// and for the policies themselves to propagate, so their policies cannot be modified.
+// Policies are loaded from the database on every access so that changes received by
+// anti-entropy are applied without coordination.
+type Registry struct {
+	db       *honu.DB
+	defaults map[string]*namespaces.Policy
+	trees    *merkleTrees
+}
+
+// NewRegistry creates a registry whose default policies replicate the specified
+// namespaces to every region.
+func NewRegistry(db *honu.DB, replicated []string) *Registry {
+	reg := &Registry{
+		db:       db,
+		defaults: make(map[string]*namespaces.Policy, len(replicated)+2),
+	}
+
+	for _, namespace := range replicated {
+		reg.defaults[namespace] = &namespaces.Policy{Namespace: namespace, Replicated: true}
+	}
+
+	for _, namespace := range []string{wire.NamespaceReplicas, NamespacePolicies} {
+		reg.defaults[namespace] = &namespaces.Policy{Namespace: namespace, Replicated: true, Builtin: true}
+	}
+	return reg
+}
+
+// Policy returns the effective replication policy of the namespace.
+func (g *Registry) Policy(namespace string) (policy *namespaces.Policy, err error) {
+	if policy, ok := g.defaults[namespace]; ok && policy.Builtin {
+		return proto.Clone(policy).(*namespaces.Policy), nil
+	}
+
+	if policy, err = g.stored(namespace); err != nil {
+		if !errors.Is(err, ErrPolicyNotFound) {
+			return nil, err
+		}
+
+		if policy, ok := g.defaults[namespace]; ok {
+			return proto.Clone(policy).(*namespaces.Policy), nil
+		}
+		return &namespaces.Policy{Namespace: namespace}, nil
+	}
+	return policy, nil
+}
+
+// Policies returns the effective replication policies of all namespaces that have a
+// default or a stored policy, sorted by namespace.
+func (g *Registry) Policies() (policies []*namespaces.Policy, err error) {
+	merged := make(map[string]*namespaces.Policy, len(g.defaults))
+	for namespace, policy := range g.defaults {
+		merged[namespace] = proto.Clone(policy).(*namespaces.Policy)
+	}
+
+	iter, err := g.db.Iter(nil, options.WithNamespace(NamespacePolicies))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		policy := new(namespaces.Policy)
+		if err = proto.Unmarshal(iter.Value(), policy); err != nil {
+			log.Warn().Err(err).Str("key", string(iter.Key())).Msg("could not unmarshal replication policy")
+			continue
+		}
+
+		if current, ok := merged[policy.Namespace]; ok && current.Builtin {
+			continue
+		}
+		merged[policy.Namespace] = policy
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+
+	policies = make([]*namespaces.Policy, 0, len(merged))
+	for _, policy := range merged {
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Namespace < policies[j].Namespace })
+	return policies, nil
+}
+
+// Replicated returns the policies of the namespaces that are replicated, sorted by
+// namespace.
+func (g *Registry) Replicated() (replicated []*namespaces.Policy, err error) {
+	var policies []*namespaces.Policy
+	if policies, err = g.Policies(); err != nil {
+		return nil, err
+	}
+
+	replicated = make([]*namespaces.Policy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Replicated {
+			replicated = append(replicated, policy)
+		}
+	}
+	return replicated, nil
+}
+
+// Set stores the replication policy of a namespace, returning the stored policy.
+func (g *Registry) Set(policy *namespaces.Policy) (_ *namespaces.Policy, err error) {
+	if err = policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if current, ok := g.defaults[policy.Namespace]; ok && current.Builtin {
+		return nil, ErrBuiltinPolicy
+	}
+
+	policy = proto.Clone(policy).(*namespaces.Policy)
+	policy.Builtin = false
+	policy.Created = ""
+	policy.Modified = time.Now().Format(time.RFC3339)
+
+	var prev *namespaces.Policy
+	if prev, err = g.stored(policy.Namespace); err != nil && !errors.Is(err, ErrPolicyNotFound) {
+		return nil, err
+	}
+
+	if prev != nil {
+		policy.Created = prev.Created
+	}
+
+	if policy.Created == "" {
+		policy.Created = policy.Modified
+	}
+
+	var data []byte
+	if data, err = proto.Marshal(policy); err != nil {
+		return nil, err
+	}
+
+	var obj *object.Object
+	if obj, err = g.db.Put([]byte(policy.Namespace), data, options.WithNamespace(NamespacePolicies)); err != nil {
+		return nil, err
+	}
+	g.trees.Update(obj)
+	return policy, nil
+}
+
+// Delete the stored replication policy of a namespace so that its default policy is
+// used, returning the effective policy of the namespace after the deletion.
+func (g *Registry) Delete(namespace string) (_ *namespaces.Policy, err error) {
+	if current, ok := g.defaults[namespace]; ok && current.Builtin {
+		return nil, ErrBuiltinPolicy
+	}
+
+	if _, err = g.stored(namespace); err != nil {
+		return nil, err
+	}
+
+	var obj *object.Object
+	if obj, err = g.db.Delete([]byte(namespace), options.WithNamespace(NamespacePolicies)); err != nil {
+		return nil, err
+	}
+	g.trees.Update(obj)
+	return g.Policy(namespace)
+}
+
+// Load the policy stored for the namespace, returning ErrPolicyNotFound if it has none.
+func (g *Registry) stored(namespace string) (policy *namespaces.Policy, err error) {
+	var data []byte
+	if data, err = g.db.Get([]byte(namespace), options.WithNamespace(NamespacePolicies)); err != nil {
+		if errors.Is(err, engine.ErrNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, err
+	}
+
+	policy = new(namespaces.Policy)
+	if err = proto.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,73 @@
+package replica_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+)
+
+func TestRegistry(t *testing.T) {
+	db := createDB(t, nil)
+	registry := replica.NewRegistry(db, []string{wire.NamespaceVASPs, wire.NamespaceCertReqs})
+
+	// The default policies replicate the namespaces to all regions
+	policies, err := registry.Replicated()
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+	expected := []string{wire.NamespaceCertReqs, wire.NamespaceReplicas, replica.NamespacePolicies, wire.NamespaceVASPs}
+	for i, policy := range policies {
+		require.Equal(t, expected[i], policy.Namespace)
+		require.True(t, policy.Allows("us-east-1", "eu-west-1"))
+	}
+
+	// Namespaces without a default or stored policy are not replicated
+	policy, err := registry.Policy("organizations")
+	require.NoError(t, err)
+	require.False(t, policy.Replicated)
+
+	// Storing a policy replicates the namespace
+	policy, err = registry.Set(&namespaces.Policy{Namespace: "organizations", Replicated: true, Regions: []string{"us-east-1"}, Builtin: true})
+	require.NoError(t, err)
+	require.False(t, policy.Builtin)
+	require.NotEmpty(t, policy.Created)
+
+	policy, err = registry.Policy("organizations")
+	require.NoError(t, err)
+	require.True(t, policy.Replicated)
+	require.Equal(t, []string{"us-east-1"}, policy.Regions)
+
+	policies, err = registry.Replicated()
+	require.NoError(t, err)
+	require.Len(t, policies, 5)
+
+	// Stored policies override the default policies
+	_, err = registry.Set(&namespaces.Policy{Namespace: wire.NamespaceVASPs})
+	require.NoError(t, err)
+
+	policies, err = registry.Replicated()
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+
+	policy, err = registry.Delete(wire.NamespaceVASPs)
+	require.NoError(t, err)
+	require.True(t, policy.Replicated, "the default policy should be restored")
+
+	_, err = registry.Delete(wire.NamespaceVASPs)
+	require.ErrorIs(t, err, replica.ErrPolicyNotFound)
+
+	// The policies of the peers and policies namespaces cannot be modified
+	for _, namespace := range []string{wire.NamespaceReplicas, replica.NamespacePolicies} {
+		_, err = registry.Set(&namespaces.Policy{Namespace: namespace})
+		require.ErrorIs(t, err, replica.ErrBuiltinPolicy)
+
+		_, err = registry.Delete(namespace)
+		require.ErrorIs(t, err, replica.ErrBuiltinPolicy)
+	}
+
+	// Invalid policies cannot be stored
+	_, err = registry.Set(&namespaces.Policy{Namespace: "organizations", GossipInterval: "1s", GossipSigma: "1s"})
+	require.Error(t, err)
+}
@@ -15,7 +15,11 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
 	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -23,34 +27,85 @@ import (
 // Service manages anti-entropy replication between peers. It has two primary functions:
 // an Anti-Entropy routine that periodically selects another peer to initiate
 // anti-entropy with and a Gossip server method that responds to anti-entropy requests.
-// The Service implements bi-lateral anti-entropy to increase consistency.
+// The Service implements bi-lateral anti-entropy to increase consistency. The Service
+// also transfers snapshots of the replicated namespaces to bootstrap new replicas.
 // NOTE: the name of this struct follows the convention of the other trtl services.
 type Service struct {
 	sync.RWMutex
 	replica.UnimplementedReplicationServer
-	conf                 config.ReplicaConfig
-	mtls                 config.MTLSConfig
-	db                   *honu.DB
-	aestop               chan struct{}
-	synchronized         time.Time
-	replicatedNamespaces []string
+	snapshot.UnimplementedSnapshotServer
+	merkle.UnimplementedMerkleServer
+	conf         config.ReplicaConfig
+	mtls         config.MTLSConfig
+	membership   config.MembershipConfig
+	db           *honu.DB
+	aestop       chan struct{}
+	aedone       chan struct{}
+	synchronized time.Time
+	registry     *Registry
+	selector     PeerSelector
+	stats        *SyncStats
+	feed         *watch.Feed
+	trees        *merkleTrees
 }
 
 // New creates a new replica.Service that is completely decoupled from the trtl.Server.
 // This breaks the pattern of the PeersService, MetricsService, and TrtlService but
-// allows replication to be completely encapsulated in a single package.
+// allows replication to be completely encapsulated in a single package. The replicated
+// namespaces are replicated to every region unless their policies are modified.
 func New(conf config.Config, db *honu.DB, replicatedNamespaces []string) (*Service, error) {
 	if err := conf.Validate(); err != nil {
 		return nil, err
 	}
 
-	return &Service{
-		conf:                 conf.Replica,
-		mtls:                 conf.MTLS,
-		db:                   db,
-		aestop:               make(chan struct{}),
-		replicatedNamespaces: replicatedNamespaces,
-	}, nil
+	r := &Service{
+		conf:       conf.Replica,
+		mtls:       conf.MTLS,
+		membership: conf.Membership,
+		db:         db,
+		aestop:     make(chan struct{}),
+		registry:   NewRegistry(db, replicatedNamespaces),
+		stats:      NewSyncStats(),
+		trees:      newMerkleTrees(db),
+	}
+	r.registry.trees = r.trees
+
+	var err error
+	if r.selector, err = NewPeerSelector(conf.ReplicaStrategy, conf.Replica.Region, r.stats); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetFeed publishes the objects that are repaired by anti-entropy to the change feed so
+// that watchers are notified of changes made on other replicas.
+func (r *Service) SetFeed(feed *watch.Feed) {
+	r.feed = feed
+}
+
+// Observe updates the merkle tree of the object's namespace after the object is written
+// to the local database outside of anti-entropy, e.g. by a Put or Delete from a client.
+// Every write to a replicated namespace must be observed, otherwise the digests of the
+// namespace will not match its peers until the replica is restarted.
+func (r *Service) Observe(obj *object.Object) {
+	if r == nil {
+		return
+	}
+	r.trees.Update(obj)
+}
+
+// Compacted removes a tombstone that was permanently deleted from the local database
+// from the merkle tree of its namespace.
+func (r *Service) Compacted(tombstone *object.Object) {
+	if r == nil {
+		return
+	}
+	r.trees.Remove(tombstone)
+}
+
+// Registry returns the replication policies of the namespaces.
+func (r *Service) Registry() *Registry {
+	return r.registry
 }
 
 // Shutdown the replica server (stops the anti-entropy go-routine)
@@ -59,6 +114,12 @@ func (r *Service) Shutdown() error {
 	// not enabled, otherwise Shutdown() will block forever and cause a deadlock.
 	if r.conf.Enabled && r.aestop != nil {
 		r.aestop <- struct{}{}
+
+		// Wait for an in-progress anti-entropy session to finish so that no more
+		// repairs are written to the database after the service is shut down.
+		if r.aedone != nil {
+			<-r.aedone
+		}
 	}
 	return nil
 }
@@ -90,6 +151,22 @@ func (r *Service) Gossip(stream replica.Replication_GossipServer) (err error) {
 		return status.Error(codes.FailedPrecondition, "anti-entropy not enabled on remote")
 	}
 
+	// Determine which objects to push back to the initiator in phase 2; if the
+	// initiator did not compare merkle trees then all objects are pushed.
+	var diverged divergence
+	if diverged, err = divergenceFromContext(stream.Context()); err != nil {
+		logctx.Warn().Err(err).Msg("could not parse merkle divergence from initiator")
+		return status.Error(codes.InvalidArgument, "could not parse merkle divergence")
+	}
+
+	// Only exchange the namespaces requested by the initiator that the local policies
+	// allow to be replicated with the initiator.
+	var namespaces []string
+	if namespaces, err = r.remoteNamespaces(stream.Context()); err != nil {
+		logctx.Error().Err(err).Msg("could not load replication policies")
+		return status.Error(codes.Internal, "could not load replication policies")
+	}
+
 	// Update prometheus metrics
 	prom.PmAESyncs.WithLabelValues(r.conf.Name, r.conf.Region, "remote").Inc()
 
@@ -103,7 +180,7 @@ func (r *Service) Gossip(stream replica.Replication_GossipServer) (err error) {
 	// Start phase 1: receive object version vectors from the initiator. This go routine
 	// will kick off phase 2: sending unchecked versions back to the initiator.
 	wg.Add(1)
-	go r.remotePhase1(stream.Context(), wg, logctx, stream, sender)
+	go r.remotePhase1(stream.Context(), wg, logctx, stream, sender, namespaces, diverged)
 
 	// Wait for all go routines to finish
 	wg.Wait()
@@ -124,7 +201,7 @@ func (r *Service) Gossip(stream replica.Replication_GossipServer) (err error) {
 // send any messages. Receipt of the COMPLETE message from the initiator also kicks off
 // the remotePhase2 go routine, ensuring it only runs once. This phase ends when the
 // initiator closes the stream with CloseSend, ending gossip.
-func (r *Service) remotePhase1(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, stream replica.Replication_GossipServer, sender *streamSender) {
+func (r *Service) remotePhase1(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, stream replica.Replication_GossipServer, sender *streamSender, namespaces []string, diverged divergence) {
 	defer wg.Done()
 	log.Trace().Msg("starting phase 1")
 
@@ -193,6 +270,13 @@ gossip:
 			return
 		}
 
+		// Ignore objects in namespaces that are not exchanged in this session so that
+		// the initiator cannot modify namespaces the remote does not replicate with it.
+		if (sync.Status == replica.Sync_CHECK || sync.Status == replica.Sync_REPAIR) && !contains(namespaces, sync.GetObject().GetNamespace()) {
+			log.Debug().Str("namespace", sync.GetObject().GetNamespace()).Msg("ignoring object in namespace that is not replicated with initiator")
+			continue gossip
+		}
+
 		// Handle the messages coming from the initiating replica
 		switch sync.Status {
 		case replica.Sync_CHECK:
@@ -302,6 +386,8 @@ gossip:
 					Msg("could not update object from initiating replica")
 				continue gossip
 			}
+			r.feed.Publish(sync.Object)
+			r.trees.Update(sync.Object)
 			atomic.AddUint64(&repairs, 1)
 
 			// Log update type in prometheus metrics.
@@ -331,7 +417,7 @@ gossip:
 			phase3 = true
 			once.Do(func() {
 				wg.Add(1)
-				go r.remotePhase2(ctx, wg, log, &seen, sender, &updates)
+				go r.remotePhase2(ctx, wg, log, &seen, namespaces, diverged, sender, &updates)
 			})
 
 		default:
@@ -344,12 +430,13 @@ gossip:
 // objects in its local database to check if there are any objects on the remote whose
 // version vectors weren't seen during initiatorPhase1 - if so it means there is an
 // object on the remote that the initiator hasn't seen before, so the remote sends a
-// REPAIR message, pushing the object back. At the end of this go routine the remote
+// REPAIR message, pushing the object back. Only objects in the merkle leaves that the
+// initiator found to be divergent are considered. At the end of this go routine the remote
 // sends a COMPLETE message, notifying the initiator that all phases of anti-entropy
 // gossip are complete which allows the initiator to close the stream when ready.
 // This go routine closes the sender channel when the phase is over because no more
 // messages should be sent from the remote.
-func (r *Service) remotePhase2(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, seen *nsmap, sender *streamSender, updates *uint64) {
+func (r *Service) remotePhase2(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, seen *nsmap, namespaces []string, diverged divergence, sender *streamSender, updates *uint64) {
 	// Start a timer to track latency
 	start := time.Now()
 
@@ -361,9 +448,9 @@ func (r *Service) remotePhase2(ctx context.Context, wg *sync.WaitGroup, log zero
 	// deadlocks if this phase ends prematurely (e.g. the timeout expires).
 	defer sender.Close()
 
-	// Loop over all objects in all namespaces and determine what to push back
+	// Loop over all objects in the session namespaces and determine what to push back
 namespaces:
-	for _, namespace := range r.replicatedNamespaces {
+	for _, namespace := range namespaces {
 		iter, err := r.db.Iter(nil, options.WithNamespace(namespace), options.WithTombstones())
 		if err != nil {
 			log.Error().Err(err).Str("namespace", namespace).Msg("could not iterate over namespace")
@@ -387,6 +474,12 @@ namespaces:
 				continue objects
 			}
 
+			// Objects in merkle leaves that have not diverged are already on the
+			// initiator, so they do not need to be pushed back.
+			if !diverged.Diverged(namespace, iter.Key()) {
+				continue objects
+			}
+
 			// At this point, the remote has an object the initiator hasn't seen, send
 			// a repair message to push the object back to the initiator (includes data).
 			obj, err := iter.Object()
@@ -444,6 +537,12 @@ func (r *Service) LastSynchronization() string {
 	return r.synchronized.Format(time.RFC3339)
 }
 
+// SyncStats returns the statistics of the anti-entropy sessions this replica has
+// initiated with the specified peer, or nil if it has not initiated any sessions.
+func (r *Service) SyncStats(pid uint64) *peers.SyncStats {
+	return r.stats.Get(pid)
+}
+
 // Helper function to set the synchronized timestamp to now in a thread-safe manner
 func (r *Service) synchronizedNow() {
 	r.Lock()
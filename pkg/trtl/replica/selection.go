@@ -0,0 +1,234 @@
+package replica
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+)
+
+const (
+	// The probability that the region selector chooses a peer in the local region when
+	// there are peers in other regions; peers in other regions must still be selected
+	// occasionally so that changes propagate between regions.
+	regionPreference = 0.8
+
+	// The maximum backoff of a failing peer is the initial backoff times 2^maxBackoff.
+	maxBackoff = 6
+)
+
+// PeerSelector chooses the remote peer to initiate anti-entropy with. The candidates
+// never include the local replica and are never empty. If no candidate should be
+// selected (e.g. they are all backing off) nil is returned.
+type PeerSelector interface {
+	Select(candidates []*peers.Peer) *peers.Peer
+}
+
+// NewPeerSelector creates the peer selection strategy specified by the configuration.
+// The sync stats are used by strategies that select peers based on their history.
+func NewPeerSelector(conf config.ReplicaStrategyConfig, region string, stats *SyncStats) (PeerSelector, error) {
+	switch conf.PeerSelection {
+	case "", config.PeerSelectionRandom:
+		return RandomSelector{}, nil
+	case config.PeerSelectionRegion:
+		return &RegionSelector{Region: region}, nil
+	case config.PeerSelectionLeastRecent:
+		return &LeastRecentSelector{Stats: stats}, nil
+	case config.PeerSelectionBackoff:
+		return &BackoffSelector{Stats: stats, Backoff: conf.PeerBackoff}, nil
+	default:
+		return nil, fmt.Errorf("unknown peer selection strategy %q", conf.PeerSelection)
+	}
+}
+
+// RandomSelector selects peers uniformly at random.
+type RandomSelector struct{}
+
+func (RandomSelector) Select(candidates []*peers.Peer) *peers.Peer {
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// RegionSelector prefers peers in the same region as the local replica, which are
+// usually cheaper and faster to synchronize with.
+type RegionSelector struct {
+	Region string
+}
+
+func (s *RegionSelector) Select(candidates []*peers.Peer) *peers.Peer {
+	local := make([]*peers.Peer, 0, len(candidates))
+	for _, peer := range candidates {
+		if peer.Region == s.Region {
+			local = append(local, peer)
+		}
+	}
+
+	if len(local) > 0 && (len(local) == len(candidates) || rand.Float64() < regionPreference) {
+		return local[rand.Intn(len(local))]
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// LeastRecentSelector selects the peer that the local replica has gone the longest
+// without synchronizing with, preferring peers it has never synchronized with.
+type LeastRecentSelector struct {
+	Stats *SyncStats
+}
+
+func (s *LeastRecentSelector) Select(candidates []*peers.Peer) (selected *peers.Peer) {
+	var oldest time.Time
+	for _, i := range rand.Perm(len(candidates)) {
+		peer := candidates[i]
+		lastSync := s.Stats.LastSync(peer.Id)
+		if selected == nil || lastSync.Before(oldest) {
+			selected, oldest = peer, lastSync
+		}
+	}
+	return selected
+}
+
+// BackoffSelector selects peers uniformly at random, skipping peers whose most recent
+// sync failed until their backoff has expired. The backoff doubles with each
+// consecutive failure so that unhealthy peers are retried less frequently.
+type BackoffSelector struct {
+	Stats   *SyncStats
+	Backoff time.Duration
+}
+
+func (s *BackoffSelector) Select(candidates []*peers.Peer) *peers.Peer {
+	now := time.Now()
+	healthy := make([]*peers.Peer, 0, len(candidates))
+	for _, peer := range candidates {
+		if retry := s.Stats.RetryAt(peer.Id, s.Backoff); !now.Before(retry) {
+			healthy = append(healthy, peer)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// SyncStats records the outcome of the anti-entropy sessions that the local replica
+// initiates with each remote peer. It is safe for concurrent use.
+type SyncStats struct {
+	sync.RWMutex
+	peers map[uint64]*peerStats
+}
+
+type peerStats struct {
+	syncs       uint64
+	failures    uint64
+	consecutive uint64
+	lastSync    time.Time
+	lastSuccess time.Time
+	lastFailure time.Time
+	syncedAt    map[string]time.Time
+	lastError   string
+}
+
+func NewSyncStats() *SyncStats {
+	return &SyncStats{peers: make(map[uint64]*peerStats)}
+}
+
+// Record the outcome of an anti-entropy session with the peer that was started at the
+// specified time and exchanged the specified namespaces.
+func (s *SyncStats) Record(pid uint64, started time.Time, namespaces []string, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	stats, ok := s.peers[pid]
+	if !ok {
+		stats = &peerStats{syncedAt: make(map[string]time.Time)}
+		s.peers[pid] = stats
+	}
+
+	stats.syncs++
+	stats.lastSync = time.Now()
+	if err != nil {
+		stats.failures++
+		stats.consecutive++
+		stats.lastFailure = stats.lastSync
+		stats.lastError = err.Error()
+	} else {
+		stats.consecutive = 0
+		stats.lastSuccess = stats.lastSync
+		for _, namespace := range namespaces {
+			stats.syncedAt[namespace] = started
+		}
+	}
+}
+
+// LastSync returns the time of the most recent session with the peer or the zero
+// time if the local replica has not initiated a session with the peer.
+func (s *SyncStats) LastSync(pid uint64) time.Time {
+	s.RLock()
+	defer s.RUnlock()
+	if stats, ok := s.peers[pid]; ok {
+		return stats.lastSync
+	}
+	return time.Time{}
+}
+
+// SyncedAt returns the start time of the most recent successful session with the peer
+// that exchanged the namespace, which means that all versions in the namespace stored
+// locally before this time have been exchanged with the peer. The zero time is returned
+// if no session with the peer has exchanged the namespace.
+func (s *SyncStats) SyncedAt(pid uint64, namespace string) time.Time {
+	s.RLock()
+	defer s.RUnlock()
+	if stats, ok := s.peers[pid]; ok {
+		return stats.syncedAt[namespace]
+	}
+	return time.Time{}
+}
+
+// RetryAt returns the time after which a peer whose most recent session failed may
+// be selected again. If the most recent session succeeded the zero time is returned.
+func (s *SyncStats) RetryAt(pid uint64, backoff time.Duration) time.Time {
+	s.RLock()
+	defer s.RUnlock()
+
+	stats, ok := s.peers[pid]
+	if !ok || stats.consecutive == 0 {
+		return time.Time{}
+	}
+
+	exp := stats.consecutive - 1
+	if exp > maxBackoff {
+		exp = maxBackoff
+	}
+	return stats.lastFailure.Add(backoff * time.Duration(1<<exp))
+}
+
+// Get the sync stats of the peer for reporting, returns nil if the local replica has
+// not initiated a session with the peer.
+func (s *SyncStats) Get(pid uint64) *peers.SyncStats {
+	s.RLock()
+	defer s.RUnlock()
+
+	stats, ok := s.peers[pid]
+	if !ok {
+		return nil
+	}
+
+	return &peers.SyncStats{
+		Syncs:               stats.syncs,
+		Failures:            stats.failures,
+		ConsecutiveFailures: stats.consecutive,
+		LastSync:            formatTime(stats.lastSync),
+		LastSuccess:         formatTime(stats.lastSuccess),
+		LastFailure:         formatTime(stats.lastFailure),
+		LastError:           stats.lastError,
+	}
+}
+
+func formatTime(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
@@ -0,0 +1,144 @@
+package replica_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+)
+
+func TestNewPeerSelector(t *testing.T) {
+	stats := replica.NewSyncStats()
+	testCases := []struct {
+		selection string
+		expected  replica.PeerSelector
+	}{
+		{"", replica.RandomSelector{}},
+		{config.PeerSelectionRandom, replica.RandomSelector{}},
+		{config.PeerSelectionRegion, &replica.RegionSelector{Region: "us-east-1"}},
+		{config.PeerSelectionLeastRecent, &replica.LeastRecentSelector{Stats: stats}},
+		{config.PeerSelectionBackoff, &replica.BackoffSelector{Stats: stats, Backoff: time.Minute}},
+	}
+
+	for _, tc := range testCases {
+		conf := config.ReplicaStrategyConfig{PeerSelection: tc.selection, PeerBackoff: time.Minute}
+		require.NoError(t, conf.Validate())
+		selector, err := replica.NewPeerSelector(conf, "us-east-1", stats)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, selector)
+	}
+
+	conf := config.ReplicaStrategyConfig{PeerSelection: "fastest"}
+	require.Error(t, conf.Validate())
+	_, err := replica.NewPeerSelector(conf, "us-east-1", stats)
+	require.Error(t, err)
+
+	conf = config.ReplicaStrategyConfig{PeerSelection: config.PeerSelectionBackoff}
+	require.Error(t, conf.Validate(), "backoff selection requires a backoff")
+}
+
+func TestRegionSelector(t *testing.T) {
+	fixtures := loadFixtures(t)
+	candidates := []*peers.Peer{fixtures["michelangelo"], fixtures["leonardo"], fixtures["donatello"]}
+
+	// Local peers should be preferred but peers in other regions must be selected
+	selector := &replica.RegionSelector{Region: fixtures["michelangelo"].Region}
+	counter := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counter[selector.Select(candidates).Region]++
+	}
+	require.Greater(t, counter[fixtures["michelangelo"].Region], 700)
+	require.Less(t, counter[fixtures["michelangelo"].Region], 1000)
+
+	// If there are no local peers, any peer can be selected
+	selector = &replica.RegionSelector{Region: "antarctica"}
+	require.NotNil(t, selector.Select(candidates))
+}
+
+func TestLeastRecentSelector(t *testing.T) {
+	fixtures := loadFixtures(t)
+	candidates := []*peers.Peer{fixtures["michelangelo"], fixtures["leonardo"], fixtures["donatello"]}
+
+	// Peers should be selected in a round robin as they are synchronized with
+	stats := replica.NewSyncStats()
+	selector := &replica.LeastRecentSelector{Stats: stats}
+	order := make([]uint64, 0, len(candidates))
+	for i := 0; i < len(candidates); i++ {
+		peer := selector.Select(candidates)
+		require.NotContains(t, order, peer.Id, "peer selected before all peers were synchronized")
+		order = append(order, peer.Id)
+		stats.Record(peer.Id, time.Now(), nil, nil)
+		time.Sleep(time.Millisecond)
+	}
+
+	// The first peer synchronized with is now the least recent
+	require.Equal(t, order[0], selector.Select(candidates).Id)
+}
+
+func TestBackoffSelector(t *testing.T) {
+	fixtures := loadFixtures(t)
+	candidates := []*peers.Peer{fixtures["michelangelo"], fixtures["leonardo"]}
+
+	stats := replica.NewSyncStats()
+	selector := &replica.BackoffSelector{Stats: stats, Backoff: time.Hour}
+
+	// A failing peer should not be selected until its backoff expires
+	stats.Record(fixtures["leonardo"].Id, time.Now(), nil, errors.New("connection refused"))
+	for i := 0; i < 100; i++ {
+		require.Equal(t, fixtures["michelangelo"].Id, selector.Select(candidates).Id)
+	}
+
+	// If all peers are failing then no peer is selected
+	stats.Record(fixtures["michelangelo"].Id, time.Now(), nil, errors.New("connection refused"))
+	require.Nil(t, selector.Select(candidates))
+
+	// A successful sync resets the backoff
+	stats.Record(fixtures["michelangelo"].Id, time.Now(), nil, nil)
+	require.Equal(t, fixtures["michelangelo"].Id, selector.Select(candidates).Id)
+
+	// The backoff doubles with each consecutive failure
+	last := stats.Get(fixtures["leonardo"].Id)
+	require.Equal(t, uint64(1), last.ConsecutiveFailures)
+	retry := stats.RetryAt(fixtures["leonardo"].Id, time.Hour)
+	stats.Record(fixtures["leonardo"].Id, time.Now(), nil, errors.New("connection refused"))
+	require.Greater(t, stats.RetryAt(fixtures["leonardo"].Id, time.Hour).Sub(retry), time.Hour)
+
+	// Expired backoffs can be selected again
+	selector.Backoff = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	require.NotNil(t, selector.Select(candidates))
+}
+
+func TestSyncStats(t *testing.T) {
+	stats := replica.NewSyncStats()
+	require.Nil(t, stats.Get(42))
+	require.True(t, stats.LastSync(42).IsZero())
+	require.True(t, stats.SyncedAt(42, "vasps").IsZero())
+	require.True(t, stats.RetryAt(42, time.Minute).IsZero())
+
+	started := time.Now()
+	stats.Record(42, started, []string{"vasps"}, nil)
+	stats.Record(42, time.Now(), nil, errors.New("deadline exceeded"))
+	stats.Record(42, time.Now(), nil, errors.New("connection refused"))
+
+	out := stats.Get(42)
+	require.Equal(t, uint64(3), out.Syncs)
+	require.Equal(t, uint64(2), out.Failures)
+	require.Equal(t, uint64(2), out.ConsecutiveFailures)
+	require.Equal(t, "connection refused", out.LastError)
+	require.NotEmpty(t, out.LastSync)
+	require.NotEmpty(t, out.LastSuccess)
+	require.Equal(t, out.LastSync, out.LastFailure)
+	require.Equal(t, started, stats.SyncedAt(42, "vasps"), "failed sessions should not change the sync time")
+	require.True(t, stats.SyncedAt(42, "certreqs").IsZero(), "namespaces that were not exchanged should not be synced")
+
+	stats.Record(42, time.Now(), nil, nil)
+	out = stats.Get(42)
+	require.Zero(t, out.ConsecutiveFailures)
+	require.Equal(t, uint64(2), out.Failures)
+	require.True(t, stats.RetryAt(42, time.Minute).IsZero())
+}
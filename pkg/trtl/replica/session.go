@@ -0,0 +1,81 @@
+package replica
+
+import (
+	"context"
+
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// The initiator describes the anti-entropy session to the remote in the metadata of the
+// Compare and Gossip streams so that the remote only exchanges the namespaces that are
+// scheduled for the session and that its own policies allow it to exchange with the
+// region of the initiator.
+const (
+	regionKey     = "trtl-region"
+	namespacesKey = "trtl-namespaces"
+)
+
+// Returns the namespaces of the policies that allow objects to be exchanged between
+// the local and the remote region.
+func allowedNamespaces(policies []*namespaces.Policy, local, remote string) []string {
+	allowed := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Allows(local, remote) {
+			allowed = append(allowed, policy.Namespace)
+		}
+	}
+	return allowed
+}
+
+// Add the region of the initiator and the namespaces of the session to the context.
+func appendSessionToOutgoingContext(ctx context.Context, region string, namespaces []string) context.Context {
+	kv := make([]string, 0, 2*len(namespaces)+2)
+	kv = append(kv, regionKey, region)
+	for _, namespace := range namespaces {
+		kv = append(kv, namespacesKey, namespace)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// Determine the namespaces the remote exchanges in the session described by the
+// initiator: the requested namespaces whose local policies allow them to be exchanged
+// with the region of the initiator. If the initiator did not describe the session
+// (e.g. it is running an older version of trtl) all of the namespaces that can be
+// exchanged with a replica in an unknown region are exchanged.
+func (r *Service) remoteNamespaces(ctx context.Context) (_ []string, err error) {
+	var policies []*namespaces.Policy
+	if policies, err = r.registry.Replicated(); err != nil {
+		return nil, err
+	}
+
+	var region string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(regionKey); len(values) > 0 {
+		region = values[0]
+	}
+
+	allowed := allowedNamespaces(policies, r.conf.Region, region)
+	requested := md.Get(namespacesKey)
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+
+	session := make([]string, 0, len(requested))
+	for _, namespace := range allowed {
+		if contains(requested, namespace) {
+			session = append(session, namespace)
+		}
+	}
+	return session, nil
+}
+
+// Returns true if the namespace is in the list of namespaces.
+func contains(namespaces []string, namespace string) bool {
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package replica
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rotationalio/honu/options"
+	"github.com/rotationalio/honu/replica"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
+	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGossipSchedule(t *testing.T) {
+	schedule := make(gossipSchedule)
+	policies := []*namespaces.Policy{
+		{Namespace: "vasps", Replicated: true},
+		{Namespace: "announcements", Replicated: true, GossipInterval: "1h", GossipSigma: "1m"},
+	}
+
+	// New namespaces are scheduled but are not due
+	require.Empty(t, schedule.Update(policies, time.Minute, time.Second))
+	require.Len(t, schedule, 2)
+	require.Equal(t, time.Minute, schedule["vasps"].interval)
+	require.Equal(t, time.Hour, schedule["announcements"].interval)
+	require.LessOrEqual(t, schedule.Wait(10*time.Minute), time.Minute+5*time.Second)
+	require.Equal(t, time.Second, schedule.Wait(time.Second))
+
+	// Namespaces that are due are returned and rescheduled
+	schedule["vasps"].next = time.Now().Add(-time.Second)
+	require.Zero(t, schedule.Wait(time.Minute))
+	due := schedule.Update(policies, time.Minute, time.Second)
+	require.Len(t, due, 1)
+	require.Equal(t, "vasps", due[0].Namespace)
+	require.True(t, schedule["vasps"].next.After(time.Now()))
+
+	// Changing the cadence reschedules the namespace and namespaces that are no
+	// longer replicated are removed from the schedule
+	schedule["vasps"].next = time.Now().Add(-time.Second)
+	policies = []*namespaces.Policy{{Namespace: "vasps", Replicated: true, GossipInterval: "10m"}}
+	require.Empty(t, schedule.Update(policies, time.Minute, time.Second))
+	require.Len(t, schedule, 1)
+	require.Equal(t, 10*time.Minute, schedule["vasps"].interval)
+}
+
+func TestSessionNamespaces(t *testing.T) {
+	svc := newTestService(t, openDB(t), 1)
+	_, err := svc.Registry().Set(&namespaces.Policy{Namespace: "organizations", Replicated: true, SameRegion: true})
+	require.NoError(t, err)
+
+	// Initiators in other regions can only exchange globally replicated namespaces
+	ctx := appendSessionToOutgoingContext(context.Background(), "eu-west-1", []string{"vasps", "organizations", "index"})
+	md, _ := metadata.FromOutgoingContext(ctx)
+	session, err := svc.remoteNamespaces(metadata.NewIncomingContext(ctx, md))
+	require.NoError(t, err)
+	require.Equal(t, []string{"vasps"}, session)
+
+	ctx = appendSessionToOutgoingContext(context.Background(), "us-east-1", []string{"vasps", "organizations", "index"})
+	md, _ = metadata.FromOutgoingContext(ctx)
+	session, err = svc.remoteNamespaces(metadata.NewIncomingContext(ctx, md))
+	require.NoError(t, err)
+	require.Equal(t, []string{"organizations", "vasps"}, session)
+
+	// Initiators that do not describe the session exchange all global namespaces
+	session, err = svc.remoteNamespaces(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"certreqs", "peers", "policies", "vasps"}, session)
+
+	// Only peers that a due namespace can be exchanged with are selected
+	for _, peer := range []*peers.Peer{{Id: 2, Region: "eu-west-1"}, {Id: 3, Region: "us-east-1"}} {
+		putPeer(t, svc, peer)
+	}
+
+	due := []*namespaces.Policy{{Namespace: "organizations", Replicated: true, SameRegion: true}}
+	for i := 0; i < 10; i++ {
+		peer := svc.selectPeer(due)
+		require.NotNil(t, peer)
+		require.Equal(t, uint64(3), peer.Id)
+	}
+
+	due[0].Regions = []string{"ap-southeast-1"}
+	require.Nil(t, svc.selectPeer(due))
+}
+
+// Test that anti-entropy only exchanges the namespaces that the policies of both
+// replicas allow to be replicated between their regions.
+func TestAntiEntropyPolicies(t *testing.T) {
+	local, remote := openDB(t), openDB(t)
+	for _, namespace := range []string{"vasps", "certreqs", "organizations"} {
+		putObjects(t, remote, namespace, 10)
+	}
+	_, err := local.Put([]byte("announcement"), []byte("regional"), options.WithNamespace("organizations"))
+	require.NoError(t, err)
+
+	// Metrics must be initialized before anti-entropy is run
+	_, err = prom.New()
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	svc := newTestService(t, remote, 2)
+	svc.conf.Region = "eu-west-1"
+	replica.RegisterReplicationServer(srv, svc)
+	merkle.RegisterMerkleServer(srv, svc)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	// The initiator replicates organizations to every region but the remote only
+	// replicates organizations within its own region, and neither replica exchanges
+	// certreqs with the other region.
+	initiator := newTestService(t, local, 1)
+	for _, reg := range []*Registry{initiator.Registry(), svc.Registry()} {
+		_, err = reg.Set(&namespaces.Policy{Namespace: "certreqs", Replicated: true, SameRegion: true})
+		require.NoError(t, err)
+	}
+
+	_, err = initiator.Registry().Set(&namespaces.Policy{Namespace: "organizations", Replicated: true})
+	require.NoError(t, err)
+	_, err = svc.Registry().Set(&namespaces.Policy{Namespace: "organizations", Replicated: true, SameRegion: true})
+	require.NoError(t, err)
+
+	// Objects repaired by anti-entropy should be published to the change feed
+	feed := watch.New(64)
+	initiator.SetFeed(feed)
+	watcher, err := feed.Watch("vasps", nil, "")
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	peer := &peers.Peer{Id: 2, Addr: lis.Addr().String(), Name: "remote", Region: "eu-west-1"}
+	require.NoError(t, initiator.AntiEntropySync(peer, log.Logger))
+
+	for i := 0; i < 10; i++ {
+		_, err = local.Get([]byte(fmt.Sprintf("vasp%03d", i)), options.WithNamespace("vasps"))
+		require.NoError(t, err, "vasps should be replicated to every region")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 10; i++ {
+		event, err := watcher.Next(ctx)
+		require.NoError(t, err, "replicated vasps should be published to the change feed")
+		require.Equal(t, "vasps", event.Object.Namespace)
+	}
+
+	_, err = local.Get([]byte("vasp000"), options.WithNamespace("certreqs"))
+	require.Error(t, err, "certreqs should not be replicated from another region")
+
+	_, err = local.Get([]byte("vasp000"), options.WithNamespace("organizations"))
+	require.Error(t, err, "organizations should not be replicated from another region")
+
+	_, err = remote.Get([]byte("announcement"), options.WithNamespace("organizations"))
+	require.Error(t, err, "organizations should not be replicated to another region")
+}
+
+func putPeer(t *testing.T, svc *Service, peer *peers.Peer) {
+	data, err := proto.Marshal(peer)
+	require.NoError(t, err)
+	_, err = svc.db.Put([]byte(peer.Key()), data, options.WithNamespace(wire.NamespaceReplicas))
+	require.NoError(t, err)
+}
@@ -0,0 +1,166 @@
+package replica
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"time"
+
+	honuldb "github.com/rotationalio/honu/engines/leveldb"
+	"github.com/rs/zerolog/log"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultChunkSize is the maximum number of compressed bytes sent in each chunk of
+	// a snapshot if the request does not specify a chunk size.
+	DefaultChunkSize = 1024 * 1024
+
+	// MaxChunkSize ensures that chunks are well under the default gRPC message limit.
+	MaxChunkSize = 3 * 1024 * 1024
+)
+
+// Transfer streams a consistent snapshot of the replicated namespaces to a new replica
+// that is bootstrapping its database. The snapshot is taken from the underlying leveldb
+// database so that objects written during the transfer are not included; they will be
+// exchanged by anti-entropy once the new replica joins the network. The honu objects
+// are sent as they are stored, including tombstones, so that the version metadata of
+// every object is preserved on the new replica.
+func (r *Service) Transfer(in *snapshot.SnapshotRequest, stream snapshot.Snapshot_TransferServer) (err error) {
+	logctx := log.With().Str("service", "snapshot").Logger()
+
+	// Determine the namespaces to include in the snapshot; only namespaces whose
+	// policies replicate them to the region of the new replica can be transferred.
+	var policies []*namespaces.Policy
+	if policies, err = r.registry.Replicated(); err != nil {
+		logctx.Error().Err(err).Msg("could not load replication policies")
+		return status.Error(codes.Internal, "could not load replication policies")
+	}
+
+	namespaces := allowedNamespaces(policies, r.conf.Region, in.Region)
+	if len(in.Namespaces) > 0 {
+		for _, namespace := range in.Namespaces {
+			if !contains(namespaces, namespace) {
+				return status.Errorf(codes.InvalidArgument, "namespace %q is not replicated", namespace)
+			}
+		}
+		namespaces = in.Namespaces
+	}
+
+	chunkSize := int(in.ChunkSize)
+	switch {
+	case chunkSize < 0 || chunkSize > MaxChunkSize:
+		return status.Errorf(codes.InvalidArgument, "chunk size must be between 0 and %d bytes", MaxChunkSize)
+	case chunkSize == 0:
+		chunkSize = DefaultChunkSize
+	}
+
+	// A consistent snapshot requires access to the underlying leveldb database
+	engine, ok := r.db.Engine().(*honuldb.LevelDBEngine)
+	if !ok {
+		logctx.Error().Str("engine", r.db.Engine().Engine()).Msg("snapshots require a leveldb engine")
+		return status.Error(codes.Unimplemented, "snapshots are not supported by the database engine")
+	}
+
+	var snap *leveldb.Snapshot
+	if snap, err = engine.DB().GetSnapshot(); err != nil {
+		logctx.Error().Err(err).Msg("could not take database snapshot")
+		return status.Error(codes.Internal, "could not take database snapshot")
+	}
+	defer snap.Release()
+
+	manifest := &snapshot.Manifest{
+		Pid:        r.conf.PID,
+		Region:     r.conf.Region,
+		Name:       r.conf.Name,
+		Created:    time.Now().Format(time.RFC3339Nano),
+		Namespaces: make(map[string]uint64, len(namespaces)),
+	}
+
+	// Objects are written to the checksum and to the compressor, which writes
+	// compressed bytes to the chunker to be sent on the stream.
+	chunks := &chunker{size: chunkSize, stream: stream}
+	gz := gzip.NewWriter(chunks)
+	checksum := sha256.New()
+	w := io.MultiWriter(gz, checksum)
+
+	for _, namespace := range namespaces {
+		iter := snap.NewIterator(util.BytesPrefix(namespacePrefix(namespace)), nil)
+		for iter.Next() {
+			if err = writeObject(w, iter.Value()); err != nil {
+				iter.Release()
+				logctx.Warn().Err(err).Str("namespace", namespace).Msg("could not send snapshot")
+				return status.Error(codes.Aborted, "could not send snapshot")
+			}
+			manifest.Namespaces[namespace]++
+			manifest.Objects++
+		}
+
+		iter.Release()
+		if err = iter.Error(); err != nil {
+			logctx.Error().Err(err).Str("namespace", namespace).Msg("could not iterate over namespace")
+			return status.Error(codes.Internal, "could not read snapshot")
+		}
+	}
+
+	if err = gz.Close(); err != nil {
+		logctx.Warn().Err(err).Msg("could not send snapshot")
+		return status.Error(codes.Aborted, "could not send snapshot")
+	}
+
+	// Send the final chunk with the remaining data and the manifest
+	manifest.Checksum = checksum.Sum(nil)
+	if err = stream.Send(&snapshot.Chunk{Data: chunks.buf.Bytes(), Manifest: manifest}); err != nil {
+		logctx.Warn().Err(err).Msg("could not send snapshot manifest")
+		return status.Error(codes.Aborted, "could not send snapshot")
+	}
+
+	logctx.Info().
+		Uint64("objects", manifest.Objects).
+		Int("chunks", chunks.sent+1).
+		Msg("snapshot transferred")
+	return nil
+}
+
+// Honu stores objects in leveldb with the namespace prepended to the key.
+func namespacePrefix(namespace string) []byte {
+	return append([]byte(namespace), ':', ':')
+}
+
+// Write the object prefixed by its length as a uvarint.
+func writeObject(w io.Writer, obj []byte) (err error) {
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, uint64(len(obj)))
+	if _, err = w.Write(prefix[:n]); err != nil {
+		return err
+	}
+	_, err = w.Write(obj)
+	return err
+}
+
+// chunker buffers the compressed snapshot and sends a chunk on the stream whenever the
+// buffer reaches the chunk size. The remaining data is sent with the manifest.
+type chunker struct {
+	buf    bytes.Buffer
+	size   int
+	sent   int
+	stream snapshot.Snapshot_TransferServer
+}
+
+func (c *chunker) Write(p []byte) (n int, err error) {
+	n, _ = c.buf.Write(p)
+	for c.buf.Len() >= c.size {
+		if err = c.stream.Send(&snapshot.Chunk{Data: c.buf.Next(c.size)}); err != nil {
+			return 0, err
+		}
+		c.sent++
+	}
+	return n, nil
+}
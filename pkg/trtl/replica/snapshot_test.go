@@ -0,0 +1,129 @@
+package replica_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/options"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"github.com/trisacrypto/directory/pkg/utils/bufconn"
+	"github.com/trisacrypto/directory/pkg/utils/wire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Test that a new replica can be bootstrapped from a snapshot of a peer's database.
+func TestBootstrap(t *testing.T) {
+	fixtures := loadFixtures(t)
+	src := createDB(t, []*peers.Peer{fixtures["raphael"], fixtures["michelangelo"]})
+
+	// Add objects in replicated and unreplicated namespaces, including a tombstone
+	for i := 0; i < 50; i++ {
+		_, err := src.Put([]byte(fmt.Sprintf("vasp%02d", i)), []byte(fmt.Sprintf("vasp data %d", i)), options.WithNamespace(wire.NamespaceVASPs))
+		require.NoError(t, err)
+	}
+	_, err := src.Put([]byte("vasp00"), []byte("updated vasp data"), options.WithNamespace(wire.NamespaceVASPs))
+	require.NoError(t, err)
+	_, err = src.Delete([]byte("vasp01"), options.WithNamespace(wire.NamespaceVASPs))
+	require.NoError(t, err)
+	_, err = src.Put([]byte("certreq"), []byte("certreq data"), options.WithNamespace(wire.NamespaceCertReqs))
+	require.NoError(t, err)
+	_, err = src.Put([]byte("index"), []byte("index data"), options.WithNamespace(wire.NamespaceIndices))
+	require.NoError(t, err)
+
+	// Serve snapshots from the source database
+	namespaces := []string{wire.NamespaceVASPs, wire.NamespaceCertReqs, wire.NamespaceReplicas}
+	client := serveSnapshots(t, src, fixtures["raphael"], namespaces)
+
+	// Bootstrap an empty database using a small chunk size to send multiple chunks
+	dst := createDB(t, nil)
+	stats, err := replica.Bootstrap(context.Background(), dst, client, &snapshot.SnapshotRequest{ChunkSize: 64})
+	require.NoError(t, err, "could not bootstrap database")
+	require.Equal(t, uint64(53), stats.Updated)
+	require.Zero(t, stats.Skipped)
+	require.Equal(t, uint64(53), stats.Manifest.Objects)
+	require.Equal(t, map[string]uint64{wire.NamespaceVASPs: 50, wire.NamespaceCertReqs: 1, wire.NamespaceReplicas: 2}, stats.Manifest.Namespaces)
+	require.Equal(t, fixtures["raphael"].Id, stats.Manifest.Pid)
+
+	// The objects should be identical to the source, including their versions
+	for _, namespace := range namespaces {
+		iter, err := src.Iter(nil, options.WithNamespace(namespace), options.WithTombstones())
+		require.NoError(t, err)
+		for iter.Next() {
+			expected, err := iter.Object()
+			require.NoError(t, err)
+
+			actual, err := dst.Object(expected.Key, options.WithNamespace(namespace))
+			require.NoError(t, err, "object not bootstrapped")
+			require.True(t, proto.Equal(expected, actual), "bootstrapped object does not match source")
+		}
+		require.NoError(t, iter.Error())
+		iter.Release()
+	}
+
+	// The tombstone should have been transferred and unreplicated namespaces skipped
+	obj, err := dst.Object([]byte("vasp01"), options.WithNamespace(wire.NamespaceVASPs))
+	require.NoError(t, err)
+	require.True(t, obj.Tombstone())
+
+	_, err = dst.Get([]byte("index"), options.WithNamespace(wire.NamespaceIndices))
+	require.Error(t, err, "unreplicated namespace should not be bootstrapped")
+
+	// Bootstrapping again should not modify any objects
+	stats, err = replica.Bootstrap(context.Background(), dst, client, &snapshot.SnapshotRequest{})
+	require.NoError(t, err, "could not bootstrap database")
+	require.Zero(t, stats.Updated)
+	require.Equal(t, uint64(53), stats.Skipped)
+
+	// Only the requested namespaces should be transferred
+	stats, err = replica.Bootstrap(context.Background(), createDB(t, nil), client, &snapshot.SnapshotRequest{Namespaces: []string{wire.NamespaceCertReqs}})
+	require.NoError(t, err, "could not bootstrap database")
+	require.Equal(t, uint64(1), stats.Updated)
+	require.Equal(t, map[string]uint64{wire.NamespaceCertReqs: 1}, stats.Manifest.Namespaces)
+
+	// Unreplicated namespaces and invalid chunk sizes cannot be requested
+	_, err = replica.Bootstrap(context.Background(), createDB(t, nil), client, &snapshot.SnapshotRequest{Namespaces: []string{wire.NamespaceIndices}})
+	require.Equal(t, codes.InvalidArgument, status.Code(errors.Unwrap(err)))
+
+	_, err = replica.Bootstrap(context.Background(), createDB(t, nil), client, &snapshot.SnapshotRequest{ChunkSize: replica.MaxChunkSize + 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(errors.Unwrap(err)))
+}
+
+func serveSnapshots(t *testing.T, db *honu.DB, self *peers.Peer, namespaces []string) snapshot.SnapshotClient {
+	conf := config.Config{
+		Replica: config.ReplicaConfig{
+			PID:    self.Id,
+			Name:   self.Name,
+			Region: self.Region,
+		},
+		MTLS: config.MTLSConfig{
+			Insecure: true,
+		},
+	}
+
+	svc, err := replica.New(conf, db, namespaces)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	snapshot.RegisterSnapshotServer(srv, svc)
+
+	sock := bufconn.New(1024*1024, "")
+	go srv.Serve(sock.Listener)
+	require.NoError(t, sock.Connect(context.Background()))
+
+	t.Cleanup(func() {
+		sock.Close()
+		srv.GracefulStop()
+		sock.Release()
+	})
+	return snapshot.NewSnapshotClient(sock.Conn)
+}
@@ -6,7 +6,6 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/url"
 	"sync"
 	"time"
@@ -17,8 +16,10 @@ import (
 	"github.com/rotationalio/honu/replica"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/trisacrypto/directory/pkg/trtl/config"
 	"github.com/trisacrypto/directory/pkg/trtl/jitter"
 	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
 	"github.com/trisacrypto/directory/pkg/utils/wire"
 	"google.golang.org/grpc"
@@ -32,9 +33,13 @@ import (
 //===========================================================================
 
 // AntiEntropy is a service that periodically selects a remote peer to synchronize with
-// via bilateral anti-entropy using the Gossip service. Jitter is applied to the
-// interval between anti-entropy synchronizations to ensure that message traffic isn't
-// bursty to disrupt normal messages to the GDS service.
+// via bilateral anti-entropy using the Gossip service. Each replicated namespace is
+// scheduled independently using the gossip interval and sigma of its replication
+// policy (or of the replica configuration if the policy does not specify them), and
+// each session exchanges the namespaces that are due with a peer whose region their
+// policies allow. Jitter is applied to the interval between anti-entropy
+// synchronizations to ensure that message traffic isn't bursty to disrupt normal
+// messages to the GDS service.
 //
 // The AntiEntropy background routine accepts a stop channel that can be used to stop
 // the routine before the process shuts down. This is primarily used in tests, but is
@@ -46,9 +51,11 @@ func (r *Service) AntiEntropy(stop chan struct{}) {
 		return
 	}
 
-	// Create the anti-entropy ticker and store the channel for shutdown
-	ticker := jitter.New(r.conf.GossipInterval, r.conf.GossipSigma)
+	// Create the anti-entropy schedule and store the channel for shutdown
+	schedule := make(gossipSchedule)
 	r.aestop = stop
+	r.aedone = make(chan struct{})
+	defer close(r.aedone)
 
 	// Log the start of the anti-entropy routine
 	log.Info().
@@ -56,111 +63,280 @@ func (r *Service) AntiEntropy(stop chan struct{}) {
 		Dur("sigma", r.conf.GossipSigma).
 		Msg("anti-entropy routine started")
 
-		// Run anti-entropy at a stochastic interval
+		// Run anti-entropy for each namespace at a stochastic interval
 		// NOTE: bayou is the name of the original academic system that described anti-entropy
 bayou:
 	for {
-		// Block until next tick or until stop signal is received
+		// Reload the policies so that changes received from other replicas are applied
+		// and schedule any namespaces that have started replicating.
+		policies, err := r.registry.Replicated()
+		if err != nil {
+			log.Error().Err(err).Msg("could not load replication policies")
+		}
+		due := schedule.Update(policies, r.conf.GossipInterval, r.conf.GossipSigma)
+
+		if len(due) > 0 {
+			r.gossip(due)
+		}
+
+		// Block until the next namespace is due or until stop signal is received. Wake
+		// up at least once per gossip interval to pick up new and modified policies.
+		timer := time.NewTimer(schedule.Wait(r.conf.GossipInterval))
 		select {
 		case <-stop:
+			timer.Stop()
 			log.Info().Msg("stopping anti-entropy service")
 			break bayou
-		case <-ticker.C:
+		case <-timer.C:
 		}
+	}
+}
+
+// Select a remote peer to synchronize the namespaces that are due with and perform the
+// anti-entropy session, recording its outcome.
+func (r *Service) gossip(due []*namespaces.Policy) {
+	// Select a remote peer to synchronize with, returning if we cannot select a peer
+	// or no remote peers replicate the namespaces yet.
+	var peer *peers.Peer
+	if peer = r.selectPeer(due); peer == nil {
+		log.Debug().Msg("no remote peer available, skipping synchronization")
+		return
+	}
+	namespaces := allowedNamespaces(due, r.conf.Region, peer.Region)
+
+	// Create a logctx with the peer information for future logging
+	logctx := log.With().
+		Dict("peer", zerolog.Dict().Uint64("id", peer.Id).Str("addr", peer.Addr).Str("name", peer.Name)).
+		Strs("namespaces", namespaces).
+		Str("service", "anti-entropy").
+		Bool("initiator", true).
+		Logger()
+
+	// Perform the anti-entropy synchronization session with the remote peer and
+	// record the outcome for peer selection strategies that consider peer health.
+	started := time.Now()
+	err := r.sync(peer, namespaces, logctx)
+	if err != nil {
+		logctx.Warn().Err(err).Msg("anti-entropy synchronization was unsuccessful")
+	}
+	r.stats.Record(peer.Id, started, namespaces, err)
 
-		// Randomly select a remote peer to synchronize with, continuing if we cannot
-		// select a peer or no remote peers exist yet.
-		var peer *peers.Peer
-		if peer = r.SelectPeer(); peer == nil {
-			log.Debug().Msg("no remote peer available, skipping synchronization")
-			continue bayou
+	// Update prometheus metrics
+	prom.PmAESyncs.WithLabelValues(peer.Name, peer.Region, "initiator").Inc()
+}
+
+// gossipSchedule tracks when the next anti-entropy session of each replicated namespace
+// is due along with the cadence the namespace was scheduled with.
+type gossipSchedule map[string]*gossipCadence
+
+type gossipCadence struct {
+	next     time.Time
+	interval time.Duration
+	sigma    time.Duration
+}
+
+// Update the schedule with the current policies, returning the policies that are due
+// and scheduling their next session. Namespaces that are no longer replicated are
+// removed and namespaces that are new or whose cadence has changed are (re)scheduled
+// a jittered interval from now.
+func (s gossipSchedule) Update(policies []*namespaces.Policy, interval, sigma time.Duration) (due []*namespaces.Policy) {
+	now := time.Now()
+	current := make(map[string]struct{}, len(policies))
+	for _, policy := range policies {
+		current[policy.Namespace] = struct{}{}
+		pinterval, psigma := policy.Cadence(interval, sigma)
+
+		cadence, ok := s[policy.Namespace]
+		if !ok || cadence.interval != pinterval || cadence.sigma != psigma {
+			s[policy.Namespace] = &gossipCadence{
+				next:     now.Add(jitter.Delay(pinterval, psigma)),
+				interval: pinterval,
+				sigma:    psigma,
+			}
+			continue
 		}
 
-		// Create a logctx with the peer information for future logging
-		logctx := log.With().
-			Dict("peer", zerolog.Dict().Uint64("id", peer.Id).Str("addr", peer.Addr).Str("name", peer.Name)).
-			Str("service", "anti-entropy").
-			Bool("initiator", true).
-			Logger()
+		if !cadence.next.After(now) {
+			due = append(due, policy)
+			cadence.next = now.Add(jitter.Delay(cadence.interval, cadence.sigma))
+		}
+	}
 
-		// Perform the anti-entropy synchronization session with the remote peer.
-		if err := r.AntiEntropySync(peer, logctx); err != nil {
-			logctx.Warn().Err(err).Msg("anti-entropy synchronization was unsuccessful")
+	for namespace := range s {
+		if _, ok := current[namespace]; !ok {
+			delete(s, namespace)
 		}
+	}
+	return due
+}
 
-		// Update prometheus metrics
-		prom.PmAESyncs.WithLabelValues(peer.Name, peer.Region, "initiator").Inc()
+// Wait returns the duration until the next namespace is due, which is at most max.
+func (s gossipSchedule) Wait(max time.Duration) time.Duration {
+	wait := max
+	for _, cadence := range s {
+		if until := time.Until(cadence.next); until < wait {
+			wait = until
+		}
 	}
+
+	if wait < 0 {
+		return 0
+	}
+	return wait
 }
 
-// SelectPeer randomly to perform anti-entropy with, ensuring that the current replica
-// is not selected if it is stored in the database. If a peer cannot be selected, then
-// nil is returned. This method handles logging.
+// SelectPeer to perform anti-entropy with using the configured peer selection strategy,
+// ensuring that the current replica is not selected if it is stored in the database.
+// Only peers that at least one replicated namespace can be exchanged with are selected.
+// If a peer cannot be selected, then nil is returned. This method handles logging.
 func (r *Service) SelectPeer() (peer *peers.Peer) {
-	// Create a list of keys to select from so we don't unmarshal all peers in the
-	// database in the case where we have a very large number of peers.
-	keys := make([][]byte, 0)
-	iter, err := r.db.Iter(nil, options.WithNamespace(wire.NamespaceReplicas))
+	policies, err := r.registry.Replicated()
+	if err != nil {
+		log.Error().Err(err).Msg("could not load replication policies")
+		return nil
+	}
+	return r.selectPeer(policies)
+}
+
+// Select a peer that at least one of the namespaces of the policies can be exchanged
+// with using the configured peer selection strategy.
+func (r *Service) selectPeer(policies []*namespaces.Policy) (peer *peers.Peer) {
+	// Load all of the remote peers so that the selection strategy can consider their
+	// regions and sync history; the number of peers in the network is small.
+	candidates, nPeers, err := r.remotePeers()
 	if err != nil {
 		log.Error().Err(err).Msg("could not fetch peers from database")
 		return nil
 	}
-	defer iter.Release()
 
-	for iter.Next() {
-		// Fetch the key and copy it into a new byte slice; otherwise on the next
-		// iteration the pointer to the key byte slice will reference the next key.
-		src := iter.Key()
-		dst := make([]byte, len(src))
-		copy(dst, src)
-		keys = append(keys, dst)
+	if nPeers == 0 {
+		log.Warn().Msg("database does not contain any peers")
+		return nil
 	}
 
-	if err = iter.Error(); err != nil {
-		log.Error().Err(err).Msg("could not iterate over peers in the database")
+	if len(candidates) == 0 {
+		log.Warn().Int("nPeers", nPeers).Msg("database does not contain any remote peers")
 		return nil
 	}
 
-	// If we have at least 1 peer in the database, randomly select one. However, the
-	// database probably contains a peer record that describes the current replica,
-	// which we don't want to select - given a database of at least 2 items, trying 10
-	// times to select a peer that is not self balances the amount of unmarshaling work
-	// we do with the likelihood that we will probably get a peer that is not current,
-	// given a large enough number of peers in the database.
-	if len(keys) > 0 {
-		// 10 attempts to select a random peer that is not the current peer.
-		for i := 0; i < 10; i++ {
-			var key, data []byte
-			key = keys[rand.Intn(len(keys))]
-			if data, err = r.db.Get(key, options.WithNamespace(wire.NamespaceReplicas)); err != nil {
-				log.Warn().Str("key", string(key)).Err(err).Msg("could not fetch peer from the database")
-				continue
+	// Do not attempt to synchronize with peers that membership has detected are dead;
+	// they will be removed by membership after the grace period unless they recover.
+	if r.membership.Enabled {
+		now := time.Now()
+		alive := make([]*peers.Peer, 0, len(candidates))
+		for _, candidate := range candidates {
+			if candidate.Liveness(now, r.membership.SuspectTimeout, r.membership.DeadTimeout) != peers.PeerState_DEAD {
+				alive = append(alive, candidate)
 			}
+		}
 
-			peer = new(peers.Peer)
-			if err = proto.Unmarshal(data, peer); err != nil {
-				log.Warn().Str("key", string(key)).Err(err).Msg("could not unmarshal peer from database")
-				continue
-			}
+		if len(alive) == 0 {
+			log.Warn().Int("nPeers", nPeers).Msg("all remote peers are dead")
+			return nil
+		}
+		candidates = alive
+	}
 
-			if peer.Id != r.conf.PID {
-				return peer
-			}
+	// Only select peers in regions that the namespaces are replicated to
+	eligible := make([]*peers.Peer, 0, len(candidates))
+	for _, candidate := range candidates {
+		if len(allowedNamespaces(policies, r.conf.Region, candidate.Region)) > 0 {
+			eligible = append(eligible, candidate)
 		}
+	}
 
-		log.Warn().Int("nPeers", len(keys)).Msg("could not select peer after 10 attempts")
+	if len(eligible) == 0 {
+		log.Debug().Int("nPeers", nPeers).Int("namespaces", len(policies)).Msg("no remote peers in the regions the namespaces are replicated to")
 		return nil
 	}
+	candidates = eligible
 
-	log.Warn().Msg("database does not contain any peers")
-	return nil
+	if peer = r.selector.Select(candidates); peer == nil {
+		log.Debug().Int("nPeers", len(candidates)).Msg("peer selection strategy did not select a peer")
+	}
+	return peer
 }
 
-// AntiEntropySync performs bilateral anti-entropy with the specified remote peer using
-// the streaming Gossip RPC. This method initiates the Gossip stream with the remote
+// PeersSyncedAt returns the time before which every version of the namespace stored
+// locally has been exchanged with all of the known remote peers that the namespace is
+// replicated to, e.g. the earliest start of the most recent successful anti-entropy
+// session that exchanged the namespace with each of those peers. If the namespace is
+// not replicated to any remote peers the current time is returned. Sync stats are not
+// persisted, so the zero time is returned until the local replica has synchronized the
+// namespace with every peer since it started.
+func (r *Service) PeersSyncedAt(namespace string) (synced time.Time, err error) {
+	var policy *namespaces.Policy
+	if policy, err = r.registry.Policy(namespace); err != nil {
+		return time.Time{}, err
+	}
+
+	var remotes []*peers.Peer
+	if remotes, _, err = r.remotePeers(); err != nil {
+		return time.Time{}, err
+	}
+
+	synced = time.Now()
+	for _, peer := range remotes {
+		if !policy.Allows(r.conf.Region, peer.Region) {
+			continue
+		}
+
+		if ts := r.stats.SyncedAt(peer.Id, namespace); ts.Before(synced) {
+			synced = ts
+		}
+	}
+	return synced, nil
+}
+
+// Load all of the peers other than the local replica from the database, also returning
+// the total number of peers in the database for logging.
+func (r *Service) remotePeers() (remotes []*peers.Peer, nPeers int, err error) {
+	iter, err := r.db.Iter(nil, options.WithNamespace(wire.NamespaceReplicas))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer iter.Release()
+
+	remotes = make([]*peers.Peer, 0)
+	for iter.Next() {
+		nPeers++
+		peer := new(peers.Peer)
+		if err = proto.Unmarshal(iter.Value(), peer); err != nil {
+			log.Warn().Str("key", string(iter.Key())).Err(err).Msg("could not unmarshal peer from database")
+			continue
+		}
+
+		if peer.Id != r.conf.PID {
+			remotes = append(remotes, peer)
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, 0, err
+	}
+	return remotes, nPeers, nil
+}
+
+// AntiEntropySync performs bilateral anti-entropy of all of the replicated namespaces
+// that can be exchanged with the region of the specified remote peer.
+func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err error) {
+	var policies []*namespaces.Policy
+	if policies, err = r.registry.Replicated(); err != nil {
+		return fmt.Errorf("could not load replication policies: %s", err)
+	}
+	return r.sync(peer, allowedNamespaces(policies, r.conf.Region, peer.Region), log)
+}
+
+// sync performs bilateral anti-entropy of the namespaces with the specified remote
+// peer using the streaming Gossip RPC. This method initiates the Gossip stream with the remote
 // peer, exiting if it cannot connect to the replica (e.g. this method acts as the
 // client in an anti-entropy session).
 //
+// Before the session begins, the initiator compares the merkle trees of its replicated
+// namespaces with the remote to find the leaves (ranges of hashed keys) that differ.
+// Only objects in divergent leaves are exchanged, and if no leaves differ the session
+// ends without opening the Gossip stream.
+//
 // The sync method for the initiator has two phases. In the first phase, the initiator
 // loops over all objects in its local database and sends check requests to the remote,
 // collecting all repair messages sent back from the remote (sometimes this is referred
@@ -176,7 +352,13 @@ func (r *Service) SelectPeer() (peer *peers.Peer) {
 // remote. The send go routine ends when there are no more messages on its channel. Once
 // all go routines are completed the initiator closes the channel, ending the
 // synchronization between the initiator and the remote.
-func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err error) {
+func (r *Service) sync(peer *peers.Peer, namespaces []string, log zerolog.Logger) (err error) {
+	// Nothing to exchange if the peer is not in a region the namespaces replicate to
+	if len(namespaces) == 0 {
+		log.Debug().Msg("no replicated namespaces can be exchanged with the remote peer")
+		return nil
+	}
+
 	// Start a timer to track latency
 	start := time.Now()
 
@@ -187,6 +369,9 @@ func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err err
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
+	// Describe the session to the remote so that it only exchanges these namespaces
+	ctx = appendSessionToOutgoingContext(ctx, r.conf.Region, namespaces)
+
 	// Dial the remote peer and establish a connection
 	var cc *grpc.ClientConn
 	if cc, err = r.connect(ctx, peer); err != nil {
@@ -194,6 +379,25 @@ func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err err
 	}
 	defer cc.Close()
 
+	// Compare merkle trees with the remote to find the objects that have diverged. If
+	// the remote cannot compare trees (e.g. it is running an older version of trtl),
+	// fall back to exchanging the versions of all objects.
+	var diverged divergence
+	if diverged, err = r.compare(ctx, cc, namespaces, log); err != nil {
+		log.Debug().Err(err).Msg("could not compare merkle trees, exchanging all versions")
+		diverged = nil
+	}
+
+	// If no namespaces have diverged then the replicas are already synchronized.
+	if diverged != nil && len(diverged) == 0 {
+		log.Debug().Msg("anti-entropy complete with no synchronization: merkle trees are identical")
+		return nil
+	}
+
+	if ctx, err = diverged.AppendToOutgoingContext(ctx); err != nil {
+		return fmt.Errorf("could not send merkle divergence: %s", err)
+	}
+
 	// Initiate the Gossip Stream
 	client := replica.NewReplicationClient(cc)
 	var stream replica.Replication_GossipClient
@@ -217,7 +421,7 @@ func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err err
 	// objects to this replica from the remote. Phase 1 ends when we've completed
 	// looping over the local database.
 	wg.Add(1)
-	go r.initiatorPhase1(ctx, wg, log, sender)
+	go r.initiatorPhase1(ctx, wg, log, sender, namespaces, diverged)
 
 	// Start phase 2: this phase is concurrent with phase 1 since it listens for and
 	// responds to all messages from the remote replica. This is also called the "push"
@@ -226,7 +430,7 @@ func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err err
 	// messages. At that point, we will no longer send any messages so this phase will
 	// close the sender go routine, which will stop when all messages have been sent.
 	wg.Add(1)
-	go r.initiatorPhase2(ctx, wg, log, sender, stream)
+	go r.initiatorPhase2(ctx, wg, log, sender, namespaces, stream)
 
 	// Wait for the initiatorPhase1, initiatorPhase2, and sender anti-entropy routines
 	wg.Wait()
@@ -253,27 +457,34 @@ func (r *Service) AntiEntropySync(peer *peers.Peer, log zerolog.Logger) (err err
 // This method blocks until the connection has been established to prevent any
 // anti-entropy work from happening until we know the remote peer is live.
 func (r *Service) connect(ctx context.Context, peer *peers.Peer) (cc *grpc.ClientConn, err error) {
+	return Dial(ctx, r.mtls, peer.Addr)
+}
+
+// Dial a remote replica at the specified address, blocking until the connection is
+// established. If mTLS is enabled, the replica connects with its own certificate so that
+// the remote replica can authenticate it.
+func Dial(ctx context.Context, mtls config.MTLSConfig, addr string) (cc *grpc.ClientConn, err error) {
 	// Create the base dial options - ensure blocking
 	opts := make([]grpc.DialOption, 0, 2)
 	opts = append(opts, grpc.WithBlock())
 
 	// Add mTLS credentials if required
-	if r.mtls.Insecure {
+	if mtls.Insecure {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
 		var certPool *x509.CertPool
-		if certPool, err = r.mtls.GetCertPool(); err != nil {
+		if certPool, err = mtls.GetCertPool(); err != nil {
 			return nil, fmt.Errorf("could not get cert pool: %v", err)
 		}
 
 		var cert tls.Certificate
-		if cert, err = r.mtls.GetCert(); err != nil {
+		if cert, err = mtls.GetCert(); err != nil {
 			return nil, fmt.Errorf("could not get cert: %v", err)
 		}
 
 		var u *url.URL
-		if u, err = url.Parse(peer.Addr); err != nil {
-			return nil, fmt.Errorf("could not parse %q: %v", peer.Addr, err)
+		if u, err = url.Parse(addr); err != nil {
+			return nil, fmt.Errorf("could not parse %q: %v", addr, err)
 		}
 
 		conf := &tls.Config{
@@ -285,14 +496,14 @@ func (r *Service) connect(ctx context.Context, peer *peers.Peer) (cc *grpc.Clien
 	}
 
 	// Dial the remote peer and establish a connection
-	return grpc.DialContext(ctx, peer.Addr, opts...)
+	return grpc.DialContext(ctx, addr, opts...)
 }
 
 // initiatorPhase1 is the go routine that starts the anti-entropy synchronization
 // between the initiator replica (run by AntiEntropySync) and the remote replica
 // (handled by Gossip). In this phase, the initiator loops over all objects in its local
-// database (possibly modified by a sampling methodology) and sends CHECK requests to
-// the remote peer. After looping through all objects in the database it sends a
+// database (skipping objects in merkle leaves that have not diverged) and sends CHECK
+// requests to the remote peer. After looping through all objects in the database it sends a
 // COMPLETE message to the remote, allowing it to begin its phase 2. This phase is the
 // initiators anti-entropy "pull" component of bilateral anti-entropy, since it is
 // asking the remote replica to send its later version.
@@ -300,7 +511,7 @@ func (r *Service) connect(ctx context.Context, peer *peers.Peer) (cc *grpc.Clien
 // Note that this go routine does not handle any of the replies from the remote replica,
 // all replies are handled in initiatorPhase2 whether they are replies to phase1 or
 // messages sent in the remote's phase2.
-func (r *Service) initiatorPhase1(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, sender *streamSender) {
+func (r *Service) initiatorPhase1(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, sender *streamSender, namespaces []string, diverged divergence) {
 	// Start a timer to track latency
 	start := time.Now()
 
@@ -313,7 +524,7 @@ func (r *Service) initiatorPhase1(ctx context.Context, wg *sync.WaitGroup, log z
 
 	// Access the objects in the object-store by namespace
 namespaces:
-	for _, namespace := range r.replicatedNamespaces {
+	for _, namespace := range namespaces {
 		iter, err := r.db.Iter(nil, options.WithNamespace(namespace), options.WithTombstones())
 		if err != nil {
 			log.Error().Err(err).Str("namespace", namespace).Msg("could not iterate over namespace")
@@ -330,6 +541,11 @@ namespaces:
 			default:
 			}
 
+			// Skip objects whose merkle leaf is identical on the remote.
+			if !diverged.Diverged(namespace, iter.Key()) {
+				continue objects
+			}
+
 			// Load the object metadata without the data itself, otherwise anti-
 			// entropy would exchange way more data than required, putting pressure
 			// on pod memory and increasing our cloud bill.
@@ -394,7 +610,7 @@ namespaces:
 // gets a COMPLETE message from the remote. This phase handles incoming messages from
 // the remote by responding to CHECK requests sending later versions to the remote (but
 // ignoring if local versions are equal or earlier), and handling REPAIR and error.
-func (r *Service) initiatorPhase2(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, sender *streamSender, stream gossipStream) {
+func (r *Service) initiatorPhase2(ctx context.Context, wg *sync.WaitGroup, log zerolog.Logger, sender *streamSender, namespaces []string, stream gossipStream) {
 	// Ensure that this routine signals when it exits
 	defer wg.Done()
 	log.Trace().Msg("starting initiator phase 2")
@@ -427,6 +643,13 @@ gossip:
 			return
 		}
 
+		// Ignore objects in namespaces that are not exchanged in this session, e.g. if
+		// the remote is running an older version of trtl that does not scope sessions.
+		if (sync.Status == replica.Sync_CHECK || sync.Status == replica.Sync_REPAIR) && !contains(namespaces, sync.GetObject().GetNamespace()) {
+			log.Debug().Str("namespace", sync.GetObject().GetNamespace()).Msg("ignoring object in namespace that is not replicated with remote")
+			continue gossip
+		}
+
 		switch sync.Status {
 		case replica.Sync_CHECK:
 			// Check to see if this replica's version is later than the remote's, if
@@ -476,6 +699,9 @@ gossip:
 					Msg("could not update object from remote peer")
 				continue gossip
 			}
+			r.feed.Publish(sync.Object)
+			r.trees.Update(sync.Object)
+
 			// Log update type in prometheus metrics.
 			switch updateType {
 			case honu.UpdateStomp:
@@ -1,6 +1,7 @@
 package trtl
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -8,14 +9,20 @@ import (
 	"time"
 
 	"github.com/rotationalio/honu"
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/options"
 	replication "github.com/rotationalio/honu/replica"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/trisacrypto/directory/pkg/trtl/config"
+	"github.com/trisacrypto/directory/pkg/trtl/merkle/v1"
 	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	"github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/peers/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/replica"
+	"github.com/trisacrypto/directory/pkg/trtl/snapshot/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
 	"github.com/trisacrypto/directory/pkg/utils/logger"
 	"github.com/trisacrypto/directory/pkg/utils/sentry"
 	"google.golang.org/grpc"
@@ -38,16 +45,20 @@ func init() {
 // 2. A peers management service for interacting with remote peers
 // 3. A replication service which implements auto-adapting anti-entropy replication.
 type Server struct {
-	srv     *grpc.Server         // The gRPC server that listens on its own independent port
-	conf    config.Config        // Configuration for the trtl server
-	db      *honu.DB             // Database connection for managing objects
-	trtl    *TrtlService         // Service for interacting with a Honu database
-	peers   *PeerService         // Service for managing remote peers
-	replica *replica.Service     // Service that handles anti-entropy replication
-	metrics *prom.MetricsService // Service for Prometheus metrics
-	backup  *BackupManager       // Manages backups of the trtl database
-	started time.Time            // The timestamp that the server was started (for uptime)
-	echan   chan error           // Channel for receiving errors from the gRPC server
+	srv        *grpc.Server         // The gRPC server that listens on its own independent port
+	conf       config.Config        // Configuration for the trtl server
+	db         *honu.DB             // Database connection for managing objects
+	trtl       *TrtlService         // Service for interacting with a Honu database
+	peers      *PeerService         // Service for managing remote peers
+	policies   *PolicyService       // Service for managing namespace replication policies
+	replica    *replica.Service     // Service that handles anti-entropy replication
+	metrics    *prom.MetricsService // Service for Prometheus metrics
+	backup     *BackupManager       // Manages backups of the trtl database
+	compaction *CompactionManager   // Removes tombstones that have been seen by all peers
+	membership *MembershipManager   // Writes heartbeats and removes dead peers
+	feed       *watch.Feed          // Publishes changes to objects to the Watch streams
+	started    time.Time            // The timestamp that the server was started (for uptime)
+	echan      chan error           // Channel for receiving errors from the gRPC server
 }
 
 // New creates a new trtl server given a configuration.
@@ -77,6 +88,7 @@ func New(conf config.Config) (s *Server, err error) {
 	// Create the server and prepare to serve
 	s = &Server{
 		conf:  conf,
+		feed:  watch.New(watchLogSize),
 		echan: make(chan error, 1),
 	}
 
@@ -113,6 +125,12 @@ func New(conf config.Config) (s *Server, err error) {
 		if s.backup, err = NewBackupManager(s.conf.Backup, s.db); err != nil {
 			return nil, err
 		}
+
+		// Resume the change feed from the cursor saved when the server was last shut
+		// down so that up to date watchers do not have to reload their namespaces.
+		if s.feed, err = loadFeed(s.db); err != nil {
+			return nil, fmt.Errorf("could not load watch cursor: %v", err)
+		}
 	}
 
 	// Initialize the Honu service
@@ -131,7 +149,28 @@ func New(conf config.Config) (s *Server, err error) {
 	if s.replica, err = replica.New(s.conf, s.db, replicatedNamespaces); err != nil {
 		return nil, err
 	}
+	s.replica.SetFeed(s.feed)
 	replication.RegisterReplicationServer(s.srv, s.replica)
+	snapshot.RegisterSnapshotServer(s.srv, s.replica)
+	merkle.RegisterMerkleServer(s.srv, s.replica)
+
+	// Initialize the Namespace Management service, which requires the replica
+	if s.policies, err = NewPolicyService(s); err != nil {
+		return nil, err
+	}
+	namespaces.RegisterNamespaceManagementServer(s.srv, s.policies)
+
+	// Initialize the compaction and membership managers, which require the replica to
+	// determine which tombstones have been seen by all peers and to join the network.
+	if !s.conf.Maintenance {
+		if s.compaction, err = NewCompactionManager(s.conf.Compaction, s.db, s.replica); err != nil {
+			return nil, err
+		}
+
+		if s.membership, err = NewMembershipManager(s.conf.Membership, s.conf.Replica, s.db, s.replica); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize Metrics service for Prometheus
 	if s.metrics, err = prom.New(); err != nil {
@@ -163,6 +202,12 @@ func (t *Server) Serve() (err error) {
 
 		// Run the backup manager if enabled
 		go t.backup.Run()
+
+		// Run the compaction manager if enabled
+		go t.compaction.Run()
+
+		// Run the membership manager if enabled
+		go t.membership.Run()
 	}
 
 	// If metrics are enabled, start Prometheus metrics server as separate go routine
@@ -219,6 +264,22 @@ func (t *Server) Shutdown() (err error) {
 		}
 	}
 
+	// Shutdown the compaction manager
+	if t.conf.Compaction.Enabled && t.compaction != nil {
+		if err = t.compaction.Shutdown(); err != nil {
+			log.Error().Err(err).Msg("could not shutdown compaction manager")
+			errs = append(errs, err)
+		}
+	}
+
+	// Shutdown the membership manager
+	if t.conf.Membership.Enabled && t.membership != nil {
+		if err = t.membership.Shutdown(); err != nil {
+			log.Error().Err(err).Msg("could not shutdown membership manager")
+			errs = append(errs, err)
+		}
+	}
+
 	// Shutdown the Prometheus metrics server
 	if t.conf.MetricsEnabled {
 		if err = t.metrics.Shutdown(); err != nil {
@@ -235,6 +296,12 @@ func (t *Server) Shutdown() (err error) {
 
 	// If we're in maintenance mode db will be nil, so check if available to avoid panic
 	if t.db != nil {
+		// Save the cursor of the change feed now that no more writes can be made
+		if err = saveFeed(t.db, t.feed); err != nil {
+			log.Error().Err(err).Msg("could not save watch cursor")
+			errs = append(errs, err)
+		}
+
 		if err = t.db.Close(); err != nil {
 			log.Error().Err(err).Msg("could not close database")
 			errs = append(errs, err)
@@ -250,6 +317,44 @@ func (t *Server) Shutdown() (err error) {
 	return nil
 }
 
+// The key in the watch namespace that the cursor of the change feed is saved to.
+var watchCursorKey = []byte("cursor")
+
+// loadFeed resumes the change feed from the cursor saved by saveFeed. The cursor is
+// deleted once it is loaded; if the server is not shut down gracefully then writes may
+// have been made that are not reflected by the cursor, so a new feed must be started
+// and all watchers must resync.
+func loadFeed(db *honu.DB) (_ *watch.Feed, err error) {
+	var cursor []byte
+	if cursor, err = db.Get(watchCursorKey, options.WithNamespace(NamespaceWatch)); err != nil {
+		if errors.Is(err, engine.ErrNotFound) {
+			return watch.New(watchLogSize), nil
+		}
+		return nil, err
+	}
+
+	if _, err = db.Delete(watchCursorKey, options.WithNamespace(NamespaceWatch)); err != nil {
+		return nil, err
+	}
+
+	var feed *watch.Feed
+	if feed, err = watch.Resume(watchLogSize, string(cursor)); err != nil {
+		log.Warn().Err(err).Msg("could not resume change feed from saved cursor")
+		return watch.New(watchLogSize), nil
+	}
+	log.Debug().Str("cursor", feed.Cursor()).Msg("resumed change feed")
+	return feed, nil
+}
+
+// saveFeed saves the cursor of the change feed so that it can be resumed when the
+// server is restarted. It must be called after all writes to the database have stopped.
+func saveFeed(db *honu.DB, feed *watch.Feed) (err error) {
+	if _, err = db.Put(watchCursorKey, []byte(feed.Cursor()), options.WithNamespace(NamespaceWatch)); err != nil {
+		return err
+	}
+	return nil
+}
+
 //===========================================================================
 // Accessors - used primarily for testing
 //===========================================================================
@@ -0,0 +1,3 @@
+package snapshot
+
+//go:generate protoc -I=$GOPATH/src/github.com/trisacrypto/directory/proto --go_out=. --go_opt=module=github.com/trisacrypto/directory/pkg/trtl/snapshot/v1 --go-grpc_out=. --go-grpc_opt=module=github.com/trisacrypto/directory/pkg/trtl/snapshot/v1 trtl/snapshot/v1/snapshot.proto
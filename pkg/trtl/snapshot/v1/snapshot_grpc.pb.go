@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: trtl/snapshot/v1/snapshot.proto
+
+package snapshot
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SnapshotClient is the client API for Snapshot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SnapshotClient interface {
+	Transfer(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Snapshot_TransferClient, error)
+}
+
+type snapshotClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotClient(cc grpc.ClientConnInterface) SnapshotClient {
+	return &snapshotClient{cc}
+}
+
+func (c *snapshotClient) Transfer(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Snapshot_TransferClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Snapshot_ServiceDesc.Streams[0], "/trtl.snapshot.v1.Snapshot/Transfer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &snapshotTransferClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Snapshot_TransferClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type snapshotTransferClient struct {
+	grpc.ClientStream
+}
+
+func (x *snapshotTransferClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SnapshotServer is the server API for Snapshot service.
+// All implementations must embed UnimplementedSnapshotServer
+// for forward compatibility
+type SnapshotServer interface {
+	Transfer(*SnapshotRequest, Snapshot_TransferServer) error
+	mustEmbedUnimplementedSnapshotServer()
+}
+
+// UnimplementedSnapshotServer must be embedded to have forward compatible implementations.
+type UnimplementedSnapshotServer struct {
+}
+
+func (UnimplementedSnapshotServer) Transfer(*SnapshotRequest, Snapshot_TransferServer) error {
+	return status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedSnapshotServer) mustEmbedUnimplementedSnapshotServer() {}
+
+// UnsafeSnapshotServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SnapshotServer will
+// result in compilation errors.
+type UnsafeSnapshotServer interface {
+	mustEmbedUnimplementedSnapshotServer()
+}
+
+func RegisterSnapshotServer(s grpc.ServiceRegistrar, srv SnapshotServer) {
+	s.RegisterService(&Snapshot_ServiceDesc, srv)
+}
+
+func _Snapshot_Transfer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SnapshotServer).Transfer(m, &snapshotTransferServer{stream})
+}
+
+type Snapshot_TransferServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type snapshotTransferServer struct {
+	grpc.ServerStream
+}
+
+func (x *snapshotTransferServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Snapshot_ServiceDesc is the grpc.ServiceDesc for Snapshot service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Snapshot_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trtl.snapshot.v1.Snapshot",
+	HandlerType: (*SnapshotServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Transfer",
+			Handler:       _Snapshot_Transfer_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "trtl/snapshot/v1/snapshot.proto",
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"hash/fnv"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/rotationalio/honu"
@@ -16,7 +19,9 @@ import (
 	"github.com/trisacrypto/directory/pkg"
 	"github.com/trisacrypto/directory/pkg/trtl/internal"
 	prom "github.com/trisacrypto/directory/pkg/trtl/metrics"
+	nspb "github.com/trisacrypto/directory/pkg/trtl/namespaces/v1"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 )
@@ -26,14 +31,37 @@ type TrtlService struct {
 	pb.UnimplementedTrtlServer
 	parent *Server
 	db     *honu.DB
+	feed   *watch.Feed
+	vm     *honu.VersionManager
+
+	// Serializes every Put, Delete and atomic batch (including the writes made by Sync
+	// and non-atomic Batch requests) so that write preconditions are checked atomically
+	// with the write. Anti-entropy repairs are not serialized by this lock; they only
+	// apply versions that are later than the local version of the object.
+	writes sync.Mutex
 }
 
-func NewTrtlService(s *Server) (*TrtlService, error) {
-	return &TrtlService{parent: s, db: s.db}, nil
+func NewTrtlService(s *Server) (svc *TrtlService, err error) {
+	svc = &TrtlService{parent: s, db: s.db, feed: s.feed}
+
+	// The version manager is used to version the objects of atomic batches, which are
+	// written to the database directly rather than with honu Put and Delete.
+	if s.db != nil {
+		if svc.vm, err = honu.NewVersionManager(s.conf.GetHonuReplicaConfig()); err != nil {
+			return nil, err
+		}
+	}
+	return svc, nil
 }
 
 const (
 	defaultPageSize = 100
+	syncBufferSize  = 64
+	syncWorkers     = 8
+	watchLogSize    = 4096
+
+	// Atomic batches are held in memory until the stream is closed.
+	maxAtomicBatchSize = 10000
 )
 
 // b64e encodes []byte keys and values as base64 encoded strings suitable for logging.
@@ -113,7 +141,9 @@ func (h *TrtlService) Get(ctx context.Context, in *pb.GetRequest) (*pb.GetReply,
 
 // Put is a unary request to store a value for a key.
 // If a namespace is provided, the namespace is passed to the internal honu Options,
-// to put the value to that namespace.
+// to put the value to that namespace. If the options specify preconditions (an
+// expected version, if_absent, or if_present) the value is only stored if they are
+// met, otherwise a FailedPrecondition error is returned.
 func (h *TrtlService) Put(ctx context.Context, in *pb.PutRequest) (out *pb.PutReply, err error) {
 	// Start a timer to track latency
 	start := time.Now()
@@ -137,6 +167,20 @@ func (h *TrtlService) Put(ctx context.Context, in *pb.PutRequest) (out *pb.PutRe
 		log.Debug().Bytes("key", in.Key).Msg("Trtl Put")
 	}
 
+	if err = validatePreconditions(in.Options); err != nil {
+		log.Warn().Err(err).Msg("invalid preconditions in Trtl Put request")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// All writes are serialized so that the preconditions of a conditional write are
+	// checked atomically with the write and cannot be invalidated by a concurrent write.
+	h.writes.Lock()
+	defer h.writes.Unlock()
+
+	if err = h.checkPreconditions(in.Key, in.Namespace, in.Options); err != nil {
+		return nil, err
+	}
+
 	// Check if we have a namespace
 	// NOTE: empty string in.Namespace will use default namespace after honu v0.2.4
 	var object *object.Object
@@ -145,6 +189,8 @@ func (h *TrtlService) Put(ctx context.Context, in *pb.PutRequest) (out *pb.PutRe
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	h.feed.Publish(object)
+	h.parent.replica.Observe(object)
 	out = &pb.PutReply{Success: true}
 
 	if in.Options != nil && in.Options.ReturnMeta {
@@ -158,17 +204,17 @@ func (h *TrtlService) Put(ctx context.Context, in *pb.PutRequest) (out *pb.PutRe
 	// Increment prometheus Put counter
 	prom.PmPuts.WithLabelValues(object.Namespace).Inc()
 
-	// TODO: prometheus; see sc-2576
-	// If in.Options.ReturnMeta is true, we will get metadata from honu
-	// Unfortunately, we currently we don't get tombstone information, but if we did,
-	// we could use it to decrement our `pmTombstone` counter here
+	// NOTE: the object and tombstone gauges are updated by the compaction manager,
+	// which counts them while scanning each namespace.
 
 	return out, nil
 }
 
 // Delete is a unary request to delete a key.
 // If a namespace is provided, the namespace is passed to the internal honu Options,
-// to delete the key from a specific namespace. Note that this does not delete tombstones.
+// to delete the key from a specific namespace. Note that this leaves a tombstone that is
+// replicated to the other peers; tombstones are removed by the CompactionManager.
+// Delete supports the same preconditions as Put.
 func (h *TrtlService) Delete(ctx context.Context, in *pb.DeleteRequest) (out *pb.DeleteReply, err error) {
 	// Start a timer to track latency
 	start := time.Now()
@@ -188,6 +234,20 @@ func (h *TrtlService) Delete(ctx context.Context, in *pb.DeleteRequest) (out *pb
 		log.Debug().Bytes("key", in.Key).Msg("Trtl Delete")
 	}
 
+	if err = validatePreconditions(in.Options); err != nil {
+		log.Warn().Err(err).Msg("invalid preconditions in Trtl Delete request")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// All writes are serialized so that the preconditions of a conditional write are
+	// checked atomically with the write and cannot be invalidated by a concurrent write.
+	h.writes.Lock()
+	defer h.writes.Unlock()
+
+	if err = h.checkPreconditions(in.Key, in.Namespace, in.Options); err != nil {
+		return nil, err
+	}
+
 	// Check if we have a namespace
 	// NOTE: empty string in.Namespace will use default namespace after honu v0.2.4
 	var object *object.Object
@@ -199,6 +259,8 @@ func (h *TrtlService) Delete(ctx context.Context, in *pb.DeleteRequest) (out *pb
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	h.feed.Publish(object)
+	h.parent.replica.Observe(object)
 	out = &pb.DeleteReply{Success: true}
 
 	if in.Options != nil && in.Options.ReturnMeta {
@@ -212,10 +274,6 @@ func (h *TrtlService) Delete(ctx context.Context, in *pb.DeleteRequest) (out *pb
 	// Increment Prometheus Delete counter
 	prom.PmDels.WithLabelValues(object.Namespace).Inc()
 
-	// TODO: Increment Prometheus Tombstone counter; see sc-2576
-	// Unfortunately we can't decrement yet! (see note in `Put`)
-	// prom.pmTombstones.WithLabelValues(object.Namespace).Inc()
-
 	return out, nil
 }
 
@@ -407,6 +465,9 @@ func (h *TrtlService) Iter(ctx context.Context, in *pb.IterRequest) (out *pb.Ite
 }
 
 // Batch is a client-side streaming request to issue multiple commands, usually Put and Delete.
+// By default each operation is applied independently as it is received and failures are
+// reported per operation. If the first request of the stream is marked atomic, all of
+// the operations are applied together or not at all (see atomicBatch).
 func (h *TrtlService) Batch(stream pb.Trtl_BatchServer) error {
 	log.Debug().Msg("Trtl Batch")
 	out := &pb.BatchReply{}
@@ -420,6 +481,11 @@ func (h *TrtlService) Batch(stream pb.Trtl_BatchServer) error {
 			return status.Error(codes.Internal, err.Error())
 		}
 
+		// If the first request is atomic the entire batch is applied as a transaction.
+		if out.Operations == 0 && in.Atomic {
+			return h.atomicBatch(stream, in)
+		}
+
 		out.Operations++
 		if in.Request == nil {
 			out.Failed++
@@ -614,8 +680,185 @@ func (h *TrtlService) Cursor(in *pb.CursorRequest, stream pb.Trtl_CursorServer)
 	return nil
 }
 
+// Sync is a bidirectional streaming request that allows a client to pipeline Get, Put,
+// Delete, and Iter requests over a single long-lived stream. Requests are handled by a
+// bounded pool of workers as soon as they are received and replies are sent back as
+// soon as they complete. Requests for the same namespace and key (or Iter prefix) are
+// always handled by the same worker in the order they were received, so a pipelined
+// Put followed by a Get of the same key observes the Put. Because an Iter covers many
+// keys, it is not handled until every Put and Delete received before it on the stream
+// has completed, so it observes all of the writes that were pipelined ahead of it.
+// Requests for different keys are handled concurrently and their replies may arrive
+// out of order; clients should
+// use the request id to correlate replies with their requests. Errors from an
+// individual request do not terminate the stream, instead the reply is marked
+// unsuccessful and the error message is returned in the reply. The stream is closed by
+// the server once the client has closed its send direction and all in-flight requests
+// have been replied to.
 func (h *TrtlService) Sync(stream pb.Trtl_SyncServer) (err error) {
-	return status.Error(codes.Unimplemented, "not implemented")
+	log.Debug().Msg("Trtl Sync")
+	ctx := stream.Context()
+
+	// Replies are sent from a single go routine since it is not safe to call Send on
+	// the same stream from multiple go routines.
+	var (
+		nRequests uint64
+		wg        sync.WaitGroup
+		writes    sync.WaitGroup
+		workers   = make([]chan *pb.SyncRequest, syncWorkers)
+		replies   = make(chan *pb.SyncReply, syncBufferSize)
+		sendErr   = make(chan error, 1)
+	)
+
+	go func() {
+		defer close(sendErr)
+		for reply := range replies {
+			if err := stream.Send(reply); err != nil {
+				log.Error().Err(err).Msg("could not send sync reply")
+				sendErr <- status.Errorf(codes.Aborted, "send error occurred: %s", err)
+
+				// Drain the replies channel so that handlers do not block
+				for range replies {
+				}
+				return
+			}
+		}
+	}()
+
+	// Each worker handles its requests sequentially; the receive loop blocks when a
+	// worker's queue is full, which applies backpressure to the client.
+	for i := range workers {
+		workers[i] = make(chan *pb.SyncRequest, syncBufferSize)
+		wg.Add(1)
+		go func(requests <-chan *pb.SyncRequest) {
+			defer wg.Done()
+			for in := range requests {
+				reply := h.sync(ctx, in)
+				if isSyncWrite(in) {
+					writes.Done()
+				}
+				replies <- reply
+			}
+		}(workers[i])
+	}
+
+	// Ensure that all in-flight requests are completed and the sender is stopped
+	// before the stream is closed, regardless of how the receive loop exits.
+	defer func() {
+		for _, requests := range workers {
+			close(requests)
+		}
+		wg.Wait()
+		close(replies)
+		if serr := <-sendErr; serr != nil && err == nil {
+			err = serr
+		}
+
+		log.Info().Uint64("count", nRequests).Msg("sync request complete")
+	}()
+
+	for {
+		var in *pb.SyncRequest
+		if in, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			if ctx.Err() != nil {
+				log.Info().Uint64("count", nRequests).Msg("sync request canceled by client")
+				return status.Errorf(codes.Canceled, "sync canceled by client: %s", ctx.Err())
+			}
+
+			log.Error().Err(err).Msg("could not receive sync request")
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		nRequests++
+
+		// Iter requests are not ordered with writes to the keys they cover by the
+		// worker hash, so wait for the in-flight writes before dispatching them. No
+		// other requests are received in the meantime, so only the writes received
+		// before the Iter are waited on.
+		switch {
+		case isSyncWrite(in):
+			writes.Add(1)
+		case in.GetIter() != nil:
+			writes.Wait()
+		}
+		workers[syncWorker(in)%uint32(len(workers))] <- in
+	}
+}
+
+// isSyncWrite returns true if the Sync request modifies the database.
+func isSyncWrite(in *pb.SyncRequest) bool {
+	return in.GetPut() != nil || in.GetDelete() != nil
+}
+
+// syncWorker hashes the namespace and key of a Sync request so that all requests for
+// the same key are handled by the same worker.
+func syncWorker(in *pb.SyncRequest) uint32 {
+	var namespace string
+	var key []byte
+	switch req := in.Request.(type) {
+	case *pb.SyncRequest_Get:
+		namespace, key = req.Get.GetNamespace(), req.Get.GetKey()
+	case *pb.SyncRequest_Put:
+		namespace, key = req.Put.GetNamespace(), req.Put.GetKey()
+	case *pb.SyncRequest_Delete:
+		namespace, key = req.Delete.GetNamespace(), req.Delete.GetKey()
+	case *pb.SyncRequest_Iter:
+		namespace, key = req.Iter.GetNamespace(), req.Iter.GetPrefix()
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(namespace))
+	hash.Write([]byte{0})
+	hash.Write(key)
+	return hash.Sum32()
+}
+
+// sync handles a single request from a Sync stream by delegating it to the matching
+// unary RPC handler and wrapping the result in a SyncReply with the request id.
+func (h *TrtlService) sync(ctx context.Context, in *pb.SyncRequest) (out *pb.SyncReply) {
+	var err error
+	out = &pb.SyncReply{Id: in.Id}
+
+	switch req := in.Request.(type) {
+	case *pb.SyncRequest_Get:
+		var reply *pb.GetReply
+		if reply, err = h.Get(ctx, req.Get); err == nil {
+			out.Reply = &pb.SyncReply_Get{Get: reply}
+		}
+	case *pb.SyncRequest_Put:
+		var reply *pb.PutReply
+		if reply, err = h.Put(ctx, req.Put); err == nil {
+			out.Reply = &pb.SyncReply_Put{Put: reply}
+		}
+	case *pb.SyncRequest_Delete:
+		var reply *pb.DeleteReply
+		if reply, err = h.Delete(ctx, req.Delete); err == nil {
+			out.Reply = &pb.SyncReply_Delete{Delete: reply}
+		}
+	case *pb.SyncRequest_Iter:
+		var reply *pb.IterReply
+		if reply, err = h.Iter(ctx, req.Iter); err == nil {
+			out.Reply = &pb.SyncReply_Iter{Iter: reply}
+		}
+	case nil:
+		out.Error = "missing request field"
+		return out
+	default:
+		out.Error = "unknown request type"
+		return out
+	}
+
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+
+	out.Success = true
+	return out
 }
 
 func (h *TrtlService) Status(ctx context.Context, in *pb.HealthCheck) (out *pb.ServerStatus, err error) {
@@ -641,6 +884,128 @@ func (h *TrtlService) Status(ctx context.Context, in *pb.HealthCheck) (out *pb.S
 	return out, nil
 }
 
+// Compact is a unary request to remove expired tombstones on demand rather than waiting
+// for the next run of the compaction manager. Tombstones are only removed once they
+// have been observed for longer than the compaction horizon and have been seen by all
+// peers; see CompactionManager for details. If a namespace is specified it must be a
+// replicated namespace, otherwise all replicated namespaces are compacted.
+func (h *TrtlService) Compact(ctx context.Context, in *pb.CompactRequest) (out *pb.CompactReply, err error) {
+	// Start a timer to track latency
+	start := time.Now()
+
+	var namespaces []string
+	if in.Namespace != "" {
+		var policy *nspb.Policy
+		if policy, err = h.parent.replica.Registry().Policy(in.Namespace); err != nil {
+			log.Error().Err(err).Str("namespace", in.Namespace).Msg("could not load replication policy")
+			return nil, status.Error(codes.Internal, "could not load replication policy")
+		}
+
+		if !policy.Replicated {
+			log.Warn().Str("namespace", in.Namespace).Msg("cannot compact unreplicated namespace")
+			return nil, status.Error(codes.InvalidArgument, "only replicated namespaces can be compacted")
+		}
+		namespaces = []string{in.Namespace}
+	}
+
+	log.Debug().Strs("namespaces", namespaces).Bool("dry_run", in.DryRun).Msg("Trtl Compact")
+
+	out = &pb.CompactReply{}
+	if out.Namespaces, err = h.parent.compaction.Compact(namespaces, in.DryRun); err != nil {
+		log.Error().Err(err).Msg("could not compact database")
+		return nil, status.Error(codes.Internal, "could not compact database")
+	}
+	out.Duration = time.Since(start).String()
+
+	// Compute Compact latency in milliseconds
+	latency := float64(time.Since(start)/1000) / 1000.0
+	prom.PmRPCLatency.WithLabelValues("Compact").Observe(latency)
+	return out, nil
+}
+
+// Watch is a server-side streaming request that sends an event for every change to the
+// objects in a namespace with the specified key prefix, including changes that are
+// replicated from peers by anti-entropy. Each event has a cursor; if the stream is
+// interrupted, the client can pass the cursor of the last event it received to resume
+// the stream without missing changes, including after the replica is restarted if it
+// was shut down gracefully. If the stream cannot be resumed from the cursor, e.g.
+// because the replica crashed or the cursor is too old, a RESYNC event is sent first to
+// indicate that changes may have been missed.
+//
+// The iter_no_values option excludes values from the events to reduce data transfer.
+func (h *TrtlService) Watch(in *pb.WatchRequest, stream pb.Trtl_WatchServer) (err error) {
+	ctx := stream.Context()
+
+	// Ensure the namespace is not reserved
+	if _, found := reservedNamespaces[in.Namespace]; found {
+		log.Warn().Str("namespace", in.Namespace).Msg("cannot use reserved namespace")
+		return status.Error(codes.PermissionDenied, "cannot use reserved namespace")
+	}
+
+	// NOTE: an empty namespace watches the default namespace, matching honu.
+	namespace := in.Namespace
+	if namespace == "" {
+		namespace = NamespaceDefault
+	}
+
+	var watcher *watch.Watcher
+	if watcher, err = h.feed.Watch(namespace, in.Prefix, in.Cursor); err != nil {
+		log.Debug().Err(err).Str("namespace", namespace).Msg("could not watch namespace")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer watcher.Close()
+
+	log.Debug().Str("namespace", namespace).Bool("resync", watcher.Resync()).Msg("Trtl Watch")
+	if watcher.Resync() {
+		resync := &pb.WatchEvent{
+			Type:      pb.WatchEvent_RESYNC,
+			Namespace: namespace,
+			Cursor:    watcher.Cursor(),
+		}
+		if err = stream.Send(resync); err != nil {
+			log.Warn().Err(err).Msg("could not send resync event")
+			return status.Error(codes.Aborted, "could not send resync event")
+		}
+	}
+
+	noValues := in.Options != nil && in.Options.IterNoValues
+	for {
+		var event *watch.Event
+		if event, err = watcher.Next(ctx); err != nil {
+			switch {
+			case ctx.Err() != nil:
+				log.Debug().Str("namespace", namespace).Msg("watch canceled by client")
+				return status.Errorf(codes.Canceled, "watch canceled by client: %s", ctx.Err())
+			case err == watch.ErrOverflow:
+				log.Warn().Str("namespace", namespace).Msg("watcher fell behind the change feed")
+				return status.Error(codes.ResourceExhausted, "watch fell too far behind, resume from the last cursor")
+			default:
+				log.Error().Err(err).Str("namespace", namespace).Msg("watch stopped")
+				return status.Error(codes.Unavailable, err.Error())
+			}
+		}
+
+		out := &pb.WatchEvent{
+			Type:      pb.WatchEvent_PUT,
+			Key:       event.Object.Key,
+			Namespace: event.Object.Namespace,
+			Meta:      returnMeta(event.Object),
+			Cursor:    event.Cursor,
+		}
+
+		if event.Deleted() {
+			out.Type = pb.WatchEvent_DELETE
+		} else if !noValues {
+			out.Value = event.Object.Data
+		}
+
+		if err = stream.Send(out); err != nil {
+			log.Warn().Err(err).Msg("could not send watch event")
+			return status.Error(codes.Aborted, "could not send watch event")
+		}
+	}
+}
+
 // returnMeta is a helper function for returning the metadata on an object
 func returnMeta(object *object.Object) *pb.Meta {
 	meta := &pb.Meta{
@@ -666,6 +1031,70 @@ func returnMeta(object *object.Object) *pb.Meta {
 	return meta
 }
 
+// validatePreconditions ensures that the write preconditions in the options can be met.
+func validatePreconditions(opts *pb.Options) error {
+	if opts == nil {
+		return nil
+	}
+
+	if opts.IfAbsent && opts.IfPresent {
+		return errors.New("cannot specify both if_absent and if_present preconditions")
+	}
+
+	if opts.IfAbsent && opts.ExpectedVersion != nil {
+		return errors.New("cannot specify both if_absent and expected_version preconditions")
+	}
+	return nil
+}
+
+// hasPreconditions returns true if the options specify any write preconditions.
+func hasPreconditions(opts *pb.Options) bool {
+	return opts != nil && (opts.ExpectedVersion != nil || opts.IfAbsent || opts.IfPresent)
+}
+
+// checkPreconditions compares the write preconditions in the options to the current
+// version of the object, returning a FailedPrecondition status error if they are not
+// met. Deleted objects are considered absent. The caller must hold the writes lock.
+func (h *TrtlService) checkPreconditions(key []byte, namespace string, opts *pb.Options) (err error) {
+	if !hasPreconditions(opts) {
+		return nil
+	}
+
+	var current *object.Object
+	if current, err = h.db.Object(key, options.WithNamespace(namespace)); err != nil {
+		if err != engine.ErrNotFound {
+			log.Error().Err(err).Str("key", string(key)).Msg("unable to retrieve object to check preconditions")
+			return status.Error(codes.Internal, err.Error())
+		}
+		current = nil
+	}
+	return preconditionsMet(key, current, opts)
+}
+
+// preconditionsMet compares the write preconditions in the options to the current
+// version of the object (nil if the object does not exist).
+func preconditionsMet(key []byte, current *object.Object, opts *pb.Options) error {
+	if opts == nil {
+		return nil
+	}
+
+	exists := current != nil && !current.Tombstone()
+	switch {
+	case opts.IfAbsent && exists:
+		log.Debug().Str("key", string(key)).Msg("if_absent precondition failed")
+		return status.Error(codes.FailedPrecondition, "object already exists")
+	case opts.IfPresent && !exists:
+		log.Debug().Str("key", string(key)).Msg("if_present precondition failed")
+		return status.Error(codes.FailedPrecondition, "object does not exist")
+	case opts.ExpectedVersion != nil:
+		if current == nil || current.Version.Pid != opts.ExpectedVersion.Pid || current.Version.Version != opts.ExpectedVersion.Version {
+			log.Debug().Str("key", string(key)).Msg("expected version precondition failed")
+			return status.Error(codes.FailedPrecondition, "object version does not match the expected version")
+		}
+	}
+	return nil
+}
+
 // uptime is a helper function that returns how long the server has been running, if known
 func (h *TrtlService) uptime() string {
 	if !h.parent.started.IsZero() {
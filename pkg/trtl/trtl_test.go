@@ -3,9 +3,15 @@ package trtl_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"time"
 
+	engine "github.com/rotationalio/honu/engines"
+	"github.com/rotationalio/honu/options"
+	"github.com/trisacrypto/directory/pkg/trtl"
 	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
 	codes "google.golang.org/grpc/codes"
 )
 
@@ -254,6 +260,109 @@ func (s *trtlTestSuite) TestDelete() {
 }
 
 // Test Unary operations: Get, Put, Get, Delete, Get, Put, Get sequence
+func (s *trtlTestSuite) TestConditionalWrites() {
+	require := s.Require()
+	ctx := context.Background()
+	tempNS := "temp"
+	tempKey := []byte("conditional")
+	defer s.reset()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	// Contradictory preconditions should be rejected
+	_, err := client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("first"),
+		Namespace: tempNS,
+		Options:   &pb.Options{IfAbsent: true, IfPresent: true},
+	})
+	s.StatusError(err, codes.InvalidArgument, "cannot specify both if_absent and if_present preconditions")
+
+	// If present should fail when the object does not exist
+	_, err = client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("first"),
+		Namespace: tempNS,
+		Options:   &pb.Options{IfPresent: true},
+	})
+	s.StatusError(err, codes.FailedPrecondition, "object does not exist")
+
+	// If absent should create the object
+	rep, err := client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("first"),
+		Namespace: tempNS,
+		Options:   &pb.Options{IfAbsent: true, ReturnMeta: true},
+	})
+	require.NoError(err)
+	require.True(rep.Success)
+	first := rep.Meta.Version
+
+	// If absent should fail when the object exists
+	_, err = client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("second"),
+		Namespace: tempNS,
+		Options:   &pb.Options{IfAbsent: true},
+	})
+	s.StatusError(err, codes.FailedPrecondition, "object already exists")
+
+	// Compare-and-swap with the current version should succeed
+	rep, err = client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("second"),
+		Namespace: tempNS,
+		Options:   &pb.Options{ExpectedVersion: first, ReturnMeta: true},
+	})
+	require.NoError(err)
+	require.True(rep.Success)
+	s.EqualVersion(first, rep.Meta.Parent, "parent")
+	second := rep.Meta.Version
+
+	// Compare-and-swap with a stale version should fail without modifying the object
+	_, err = client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("stale"),
+		Namespace: tempNS,
+		Options:   &pb.Options{ExpectedVersion: first},
+	})
+	s.StatusError(err, codes.FailedPrecondition, "object version does not match the expected version")
+
+	get, err := client.Get(ctx, &pb.GetRequest{Key: tempKey, Namespace: tempNS})
+	require.NoError(err)
+	require.Equal([]byte("second"), get.Value)
+
+	// Delete with a stale version should fail
+	_, err = client.Delete(ctx, &pb.DeleteRequest{
+		Key:       tempKey,
+		Namespace: tempNS,
+		Options:   &pb.Options{ExpectedVersion: first},
+	})
+	s.StatusError(err, codes.FailedPrecondition, "object version does not match the expected version")
+
+	// Delete with the current version should succeed
+	del, err := client.Delete(ctx, &pb.DeleteRequest{
+		Key:       tempKey,
+		Namespace: tempNS,
+		Options:   &pb.Options{ExpectedVersion: second, IfPresent: true},
+	})
+	require.NoError(err)
+	require.True(del.Success)
+
+	// Deleted objects are absent, so if absent should recreate the object
+	rep, err = client.Put(ctx, &pb.PutRequest{
+		Key:       tempKey,
+		Value:     []byte("third"),
+		Namespace: tempNS,
+		Options:   &pb.Options{IfAbsent: true},
+	})
+	require.NoError(err)
+	require.True(rep.Success)
+}
+
 func (s *trtlTestSuite) TestUnaryOperationsInNamespaces() {
 	require := s.Require()
 	ctx := context.Background()
@@ -360,6 +469,118 @@ func (s *trtlTestSuite) TestBatch() {
 	require.Equal(requests[reply.Errors[1].Id].Id, reply.Errors[1].Id)
 }
 
+func (s *trtlTestSuite) TestAtomicBatch() {
+	require := s.Require()
+	ctx := context.Background()
+	ns := "atomic"
+	defer s.reset()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	// Helper to send an atomic batch of requests
+	batch := func(requests ...*pb.BatchRequest) *pb.BatchReply {
+		stream, err := client.Batch(ctx)
+		require.NoError(err)
+		for i, r := range requests {
+			r.Id = int64(i + 1)
+			r.Atomic = i == 0
+			require.NoError(stream.Send(r))
+		}
+		reply, err := stream.CloseAndRecv()
+		require.NoError(err)
+		return reply
+	}
+
+	put := func(key, value string, opts *pb.Options) *pb.BatchRequest {
+		return &pb.BatchRequest{Request: &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: []byte(key), Value: []byte(value), Namespace: ns, Options: opts}}}
+	}
+
+	del := func(key string) *pb.BatchRequest {
+		return &pb.BatchRequest{Request: &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: []byte(key), Namespace: ns}}}
+	}
+
+	_, err := client.Put(ctx, &pb.PutRequest{Key: []byte("c"), Value: []byte("value"), Namespace: ns})
+	require.NoError(err)
+
+	// All operations should be applied and keys modified more than once should only be
+	// versioned once with the final value.
+	reply := batch(put("a", "first", nil), put("a", "second", nil), put("b", "value", nil), del("c"))
+	require.True(reply.Committed)
+	require.Equal(int64(4), reply.Operations)
+	require.Equal(int64(4), reply.Successful)
+	require.Zero(reply.Failed)
+	require.Empty(reply.Errors)
+
+	rep, err := client.Get(ctx, &pb.GetRequest{Key: []byte("a"), Namespace: ns, Options: &pb.Options{ReturnMeta: true}})
+	require.NoError(err)
+	require.Equal([]byte("second"), rep.Value)
+	require.Equal(uint64(1), rep.Meta.Version.Version)
+	version := rep.Meta.Version
+
+	_, err = client.Get(ctx, &pb.GetRequest{Key: []byte("c"), Namespace: ns})
+	s.StatusError(err, codes.NotFound, "not found")
+
+	// If a precondition fails none of the operations should be applied
+	reply = batch(put("a", "third", &pb.Options{ExpectedVersion: version}), put("b", "value", &pb.Options{IfAbsent: true}))
+	require.False(reply.Committed)
+	require.Equal(int64(2), reply.Failed)
+	require.Zero(reply.Successful)
+	require.Len(reply.Errors, 1)
+	require.Equal(int64(2), reply.Errors[0].Id)
+	require.Contains(reply.Errors[0].Error, "object already exists")
+
+	rep, err = client.Get(ctx, &pb.GetRequest{Key: []byte("a"), Namespace: ns, Options: &pb.Options{ReturnMeta: true}})
+	require.NoError(err)
+	require.Equal([]byte("second"), rep.Value)
+	s.EqualVersion(version, rep.Meta.Version, "version")
+
+	// Preconditions are checked against the previous operations of the batch
+	reply = batch(del("b"), put("b", "recreated", &pb.Options{IfAbsent: true}), put("a", "third", &pb.Options{ExpectedVersion: version}))
+	require.True(reply.Committed)
+
+	rep, err = client.Get(ctx, &pb.GetRequest{Key: []byte("b"), Namespace: ns, Options: &pb.Options{ReturnMeta: true}})
+	require.NoError(err)
+	require.Equal([]byte("recreated"), rep.Value)
+	require.Equal(uint64(2), rep.Meta.Version.Version)
+
+	// Deleting an object that does not exist should abort the batch
+	reply = batch(put("a", "fourth", nil), del("c"))
+	require.False(reply.Committed)
+	require.Len(reply.Errors, 1)
+	require.Equal(int64(2), reply.Errors[0].Id)
+
+	// Invalid operations should be reported before anything is applied
+	reply = batch(put("a", "fourth", nil), put("d", "", nil), &pb.BatchRequest{}, put("e", "value", &pb.Options{IfAbsent: true, IfPresent: true}))
+	require.False(reply.Committed)
+	require.Equal(int64(4), reply.Failed)
+	require.Len(reply.Errors, 3)
+
+	rep, err = client.Get(ctx, &pb.GetRequest{Key: []byte("a"), Namespace: ns})
+	require.NoError(err)
+	require.Equal([]byte("third"), rep.Value)
+
+	// Atomic batches that are too large should be rejected
+	maxAtomicBatchSize := 10000
+	stream, err := client.Batch(ctx)
+	require.NoError(err)
+	for i := 0; i <= maxAtomicBatchSize; i++ {
+		req := put(fmt.Sprintf("key%d", i), "value", nil)
+		req.Id, req.Atomic = int64(i), i == 0
+		if err = stream.Send(req); err != nil {
+			require.ErrorIs(err, io.EOF)
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	s.StatusError(err, codes.ResourceExhausted, fmt.Sprintf("atomic batch cannot contain more than %d operations", maxAtomicBatchSize))
+
+	_, err = client.Get(ctx, &pb.GetRequest{Key: []byte("key0"), Namespace: ns})
+	s.StatusError(err, codes.NotFound, "not found")
+}
+
 func (s *trtlTestSuite) TestIter() {
 	require := s.Require()
 	ctx := context.Background()
@@ -664,6 +885,312 @@ func (s *trtlTestSuite) TestCursor() {
 	require.Equal(2, i, "expected 3 results returned after seek, have fixtures changed?")
 }
 
+func (s *trtlTestSuite) TestSync() {
+	require := s.Require()
+	ctx := context.Background()
+	bob := dbFixtures["bob"]
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	requests := []*pb.SyncRequest{
+		{
+			Id:      1,
+			Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: bob.Namespace, Key: []byte(bob.Key)}},
+		},
+		{
+			Id:      2,
+			Request: &pb.SyncRequest_Put{Put: &pb.PutRequest{Namespace: "sync", Key: []byte("foo"), Value: []byte("bar")}},
+		},
+		{
+			Id:      3,
+			Request: &pb.SyncRequest_Iter{Iter: &pb.IterRequest{Namespace: "people", Prefix: []byte("215")}},
+		},
+		{
+			Id:      4,
+			Request: &pb.SyncRequest_Delete{Delete: &pb.DeleteRequest{Namespace: "people", Key: []byte("invalid")}},
+		},
+		{
+			Id:      5,
+			Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: "sequence", Key: []byte("foo")}},
+		},
+		{
+			Id: 6,
+		},
+	}
+
+	stream, err := client.Sync(ctx)
+	require.NoError(err, "could not create sync stream")
+	for _, req := range requests {
+		require.NoError(stream.Send(req), "could not send sync request")
+	}
+	require.NoError(stream.CloseSend())
+
+	// Replies may arrive in any order so collect them by request id
+	replies := make(map[int64]*pb.SyncReply)
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err, "received non-EOF error from recv")
+		require.NotContains(replies, rep.Id, "duplicate reply received")
+		replies[rep.Id] = rep
+	}
+	require.Len(replies, len(requests))
+
+	// Get should return the fixture value
+	require.True(replies[1].Success, replies[1].Error)
+	var actual interface{}
+	require.NoError(json.Unmarshal(replies[1].GetGet().Value, &actual))
+	require.Equal(bob.Value, actual)
+
+	// Put should succeed
+	require.True(replies[2].Success, replies[2].Error)
+	require.True(replies[2].GetPut().Success)
+
+	// Iter should return the prefixed people
+	require.True(replies[3].Success, replies[3].Error)
+	require.Len(replies[3].GetIter().Values, 5)
+
+	// Delete of a missing key should fail without closing the stream
+	require.False(replies[4].Success)
+	require.Contains(replies[4].Error, "not found")
+	require.Nil(replies[4].Reply)
+
+	// Reserved namespaces cannot be accessed from sync either
+	require.False(replies[5].Success)
+	require.Contains(replies[5].Error, "cannot use reserved namespace")
+
+	// Requests must have a request field
+	require.False(replies[6].Success)
+	require.Equal("missing request field", replies[6].Error)
+
+	// The put in the stream should be visible to subsequent requests
+	rep, err := client.Get(ctx, &pb.GetRequest{Namespace: "sync", Key: []byte("foo")})
+	require.NoError(err)
+	require.Equal([]byte("bar"), rep.Value)
+}
+
+func (s *trtlTestSuite) TestSyncOrdering() {
+	require := s.Require()
+	ctx := context.Background()
+	defer s.reset()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	stream, err := client.Sync(ctx)
+	require.NoError(err, "could not create sync stream")
+
+	// Pipeline a Put followed by a Get for many keys; each Get must observe its Put
+	// even though requests for different keys are handled concurrently.
+	nkeys := 100
+	for i := 0; i < nkeys; i++ {
+		key := []byte(fmt.Sprintf("key%03d", i))
+		value := []byte(fmt.Sprintf("value%03d", i))
+		require.NoError(stream.Send(&pb.SyncRequest{
+			Id:      int64(2 * i),
+			Request: &pb.SyncRequest_Put{Put: &pb.PutRequest{Namespace: "ordered", Key: key, Value: value}},
+		}))
+		require.NoError(stream.Send(&pb.SyncRequest{
+			Id:      int64(2*i + 1),
+			Request: &pb.SyncRequest_Get{Get: &pb.GetRequest{Namespace: "ordered", Key: key}},
+		}))
+	}
+
+	// An Iter covers keys handled by other workers, so it must observe all of the
+	// writes that were pipelined ahead of it, including a delete.
+	require.NoError(stream.Send(&pb.SyncRequest{
+		Id:      int64(2 * nkeys),
+		Request: &pb.SyncRequest_Delete{Delete: &pb.DeleteRequest{Namespace: "ordered", Key: []byte("key000")}},
+	}))
+	require.NoError(stream.Send(&pb.SyncRequest{
+		Id:      int64(2*nkeys + 1),
+		Request: &pb.SyncRequest_Iter{Iter: &pb.IterRequest{Namespace: "ordered", Prefix: []byte("key")}},
+	}))
+	require.NoError(stream.CloseSend())
+
+	replies := make(map[int64]*pb.SyncReply)
+	for {
+		rep, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err, "received non-EOF error from recv")
+		replies[rep.Id] = rep
+	}
+	require.Len(replies, 2*nkeys+2)
+
+	for i := 0; i < nkeys; i++ {
+		rep := replies[int64(2*i+1)]
+		require.True(rep.Success, rep.Error)
+		require.Equal([]byte(fmt.Sprintf("value%03d", i)), rep.GetGet().Value)
+	}
+
+	rep := replies[int64(2*nkeys+1)]
+	require.True(rep.Success, rep.Error)
+	require.Len(rep.GetIter().Values, nkeys-1)
+}
+
+func (s *trtlTestSuite) TestWatch() {
+	require := s.Require()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	defer s.reset()
+
+	// Start the gRPC client.
+	require.NoError(s.grpc.Connect(ctx))
+	defer s.grpc.Close()
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	// Should not be able to watch a reserved namespace
+	stream, err := client.Watch(ctx, &pb.WatchRequest{Namespace: "peers"})
+	require.NoError(err)
+	_, err = stream.Recv()
+	s.StatusError(err, codes.PermissionDenied, "cannot use reserved namespace")
+
+	// Should not be able to watch from an invalid cursor
+	stream, err = client.Watch(ctx, &pb.WatchRequest{Namespace: "watched", Cursor: "notacursor!"})
+	require.NoError(err)
+	_, err = stream.Recv()
+	s.StatusError(err, codes.InvalidArgument, "could not parse watch cursor")
+
+	// Watch the keys with the prefix
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	stream, err = client.Watch(watchCtx, &pb.WatchRequest{Namespace: "watched", Prefix: []byte("a")})
+	require.NoError(err)
+
+	// NOTE: the stream is not established until the server handles the request, so
+	// the writes are retried until the first event is received.
+	events := make(chan *pb.WatchEvent, 8)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			events <- event
+		}
+	}()
+
+	var first *pb.WatchEvent
+	for first == nil {
+		_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watched", Key: []byte("alpha"), Value: []byte("1")})
+		require.NoError(err)
+
+		select {
+		case first = <-events:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	require.Equal(pb.WatchEvent_PUT, first.Type)
+	require.Equal([]byte("alpha"), first.Key)
+	require.Equal([]byte("1"), first.Value)
+	require.Equal("watched", first.Namespace)
+	require.NotNil(first.Meta)
+	require.NotEmpty(first.Cursor)
+
+	// Drain any events from retried puts
+	drain := func() {
+		for {
+			select {
+			case <-events:
+			case <-time.After(50 * time.Millisecond):
+				return
+			}
+		}
+	}
+	drain()
+
+	// Keys without the prefix and other namespaces should not be watched
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watched", Key: []byte("bravo"), Value: []byte("2")})
+	require.NoError(err)
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "unwatched", Key: []byte("alpha"), Value: []byte("3")})
+	require.NoError(err)
+	_, err = client.Delete(ctx, &pb.DeleteRequest{Namespace: "watched", Key: []byte("alpha")})
+	require.NoError(err)
+
+	deleted := <-events
+	require.Equal(pb.WatchEvent_DELETE, deleted.Type)
+	require.Equal([]byte("alpha"), deleted.Key)
+	require.Empty(deleted.Value)
+	require.True(deleted.Meta.Version.Version > first.Meta.Version.Version)
+	stopWatch()
+
+	// Changes made while the client was disconnected should be replayed from the cursor
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watched", Key: []byte("alpha"), Value: []byte("4")})
+	require.NoError(err)
+
+	stream, err = client.Watch(ctx, &pb.WatchRequest{Namespace: "watched", Cursor: deleted.Cursor, Options: &pb.Options{IterNoValues: true}})
+	require.NoError(err)
+	event, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_PUT, event.Type)
+	require.Equal([]byte("alpha"), event.Key)
+	require.Empty(event.Value, "values should not be sent with iter_no_values")
+
+	// Resuming from a cursor of another process should require a resync
+	stream, err = client.Watch(ctx, &pb.WatchRequest{Namespace: "watched", Cursor: watch.New(1).Cursor()})
+	require.NoError(err)
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_RESYNC, event.Type)
+	require.NotEmpty(event.Cursor)
+}
+
+func (s *trtlTestSuite) TestWatchRestart() {
+	// NOTE: the context must be canceled to close the watch stream before the reset.
+	defer s.reset()
+	require := s.Require()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(s.grpc.Connect(ctx))
+	client := pb.NewTrtlClient(s.grpc.Conn)
+
+	_, err := client.Put(ctx, &pb.PutRequest{Namespace: "watched", Key: []byte("alpha"), Value: []byte("1")})
+	require.NoError(err)
+
+	// Fetch the current cursor of the change feed from a resync event
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	stream, err := client.Watch(watchCtx, &pb.WatchRequest{Namespace: "watched", Cursor: watch.New(1).Cursor()})
+	require.NoError(err)
+	event, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_RESYNC, event.Type)
+	cursor := event.Cursor
+	stopWatch()
+
+	// Gracefully restart the server on the same database
+	s.grpc.Release()
+	require.NoError(s.trtl.Shutdown())
+	require.NoError(s.setupServers())
+	require.NoError(s.grpc.Connect(ctx))
+	client = pb.NewTrtlClient(s.grpc.Conn)
+
+	// The saved cursor should be removed so that it is not resumed after a crash
+	_, err = s.trtl.GetDB().Get([]byte("cursor"), options.WithNamespace(trtl.NamespaceWatch))
+	require.ErrorIs(err, engine.ErrNotFound)
+
+	// Watchers should be able to resume from the cursor without a resync
+	_, err = client.Put(ctx, &pb.PutRequest{Namespace: "watched", Key: []byte("alpha"), Value: []byte("2")})
+	require.NoError(err)
+
+	stream, err = client.Watch(ctx, &pb.WatchRequest{Namespace: "watched", Cursor: cursor})
+	require.NoError(err)
+	event, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(pb.WatchEvent_PUT, event.Type)
+	require.Equal([]byte("alpha"), event.Key)
+	require.Equal([]byte("2"), event.Value)
+}
+
 func (s *trtlTestSuite) TestStatus() {
 	require := s.Require()
 	ctx := context.Background()
@@ -0,0 +1,242 @@
+/*
+Package watch implements an in-memory change feed of the objects that are written to a
+trtl replica, either by clients of the replica or by anti-entropy with its peers.
+
+Every change published to the feed is assigned a sequence number and is delivered to the
+watchers of its namespace and key prefix. The feed keeps a bounded log of the most recent
+changes so that a watcher that disconnects can resume from the cursor of the last change
+it received. The log is not persisted, so cursors that have fallen out of the log cannot
+be resumed and the watcher must reload the namespace instead. A feed can be resumed from
+the cursor of a previous feed (e.g. one saved when the replica was shut down) so that
+watchers that had received every change of the previous feed can resume after a restart.
+*/
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rotationalio/honu/object"
+	"github.com/trisacrypto/directory/pkg/trtl/internal"
+)
+
+// The number of events that can be queued for a watcher before it is closed because it
+// has fallen too far behind the feed.
+const watcherBufferSize = 512
+
+var (
+	ErrInvalidCursor = errors.New("could not parse watch cursor")
+	ErrOverflow      = errors.New("watcher fell too far behind the change feed")
+	ErrClosed        = errors.New("watcher is closed")
+)
+
+// Event is a change to an object; deletes are represented by a tombstone.
+type Event struct {
+	Sequence uint64
+	Cursor   string
+	Object   *object.Object
+}
+
+// Deleted returns true if the event is the deletion of the object.
+func (e *Event) Deleted() bool {
+	return e.Object.Tombstone()
+}
+
+// Feed fans out changes to objects to watchers and keeps a log of recent changes.
+type Feed struct {
+	sync.Mutex
+	epoch    int64
+	sequence uint64
+	offset   uint64
+	events   []*Event
+	watchers map[*Watcher]struct{}
+}
+
+// New creates a change feed that keeps a log of the specified number of events.
+func New(size int) *Feed {
+	return &Feed{
+		epoch:    time.Now().UnixNano(),
+		events:   make([]*Event, 0, size),
+		watchers: make(map[*Watcher]struct{}),
+	}
+}
+
+// Resume creates a change feed that continues the epoch and sequence of the cursor of a
+// previous feed. Watchers that are resumed from that cursor do not need to resync, but
+// any earlier cursors cannot be resumed since the events of the previous feed are lost.
+func Resume(size int, cursor string) (_ *Feed, err error) {
+	prev := &internal.WatchCursor{}
+	if err = prev.Load(cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	f := New(size)
+	f.epoch = prev.Epoch
+	f.sequence = prev.Sequence
+	f.offset = prev.Sequence
+	return f, nil
+}
+
+// Publish a change to an object to the feed. Publish is a no-op on a nil feed so that
+// components that write objects do not need to check if the feed is enabled.
+func (f *Feed) Publish(obj *object.Object) {
+	if f == nil || obj == nil {
+		return
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	f.sequence++
+	event := &Event{Sequence: f.sequence, Cursor: f.cursor(f.sequence), Object: obj}
+
+	if size := cap(f.events); size > 0 {
+		if len(f.events) < size {
+			f.events = append(f.events, event)
+		} else {
+			f.events[(f.sequence-f.offset-1)%uint64(size)] = event
+		}
+	}
+
+	for w := range f.watchers {
+		if !w.matches(obj) {
+			continue
+		}
+
+		select {
+		case w.c <- event:
+		default:
+			// The watcher is not keeping up with the feed; close it so that it can
+			// resume from its last cursor rather than blocking the writers.
+			w.err = ErrOverflow
+			close(w.c)
+			delete(f.watchers, w)
+		}
+	}
+}
+
+// Cursor returns the cursor of the latest event published to the feed.
+func (f *Feed) Cursor() string {
+	f.Lock()
+	defer f.Unlock()
+	return f.cursor(f.sequence)
+}
+
+// Watch the objects in the namespace that have the specified key prefix. If a cursor is
+// specified the events in the log that were published after the cursor are replayed;
+// if the cursor cannot be resumed the watcher is marked for resync and only receives
+// events published after it was created.
+func (f *Feed) Watch(namespace string, prefix []byte, cursor string) (w *Watcher, err error) {
+	var resume *internal.WatchCursor
+	if cursor != "" {
+		resume = &internal.WatchCursor{}
+		if err = resume.Load(cursor); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	w = &Watcher{
+		feed:      f,
+		namespace: namespace,
+		prefix:    prefix,
+		c:         make(chan *Event, watcherBufferSize),
+		cursor:    f.cursor(f.sequence),
+	}
+
+	if resume != nil {
+		// The oldest sequence in the log, any cursor from the sequence before it onward
+		// can be resumed without missing events.
+		oldest := f.sequence - uint64(len(f.events)) + 1
+		if resume.Epoch != f.epoch || resume.Sequence+1 < oldest || resume.Sequence > f.sequence {
+			w.resync = true
+		} else {
+			for seq := resume.Sequence + 1; seq <= f.sequence; seq++ {
+				event := f.events[(seq-f.offset-1)%uint64(cap(f.events))]
+				if w.matches(event.Object) {
+					w.replay = append(w.replay, event)
+				}
+			}
+		}
+	}
+
+	f.watchers[w] = struct{}{}
+	return w, nil
+}
+
+func (f *Feed) cursor(sequence uint64) string {
+	// Marshaling the cursor cannot fail since it only contains scalar fields.
+	cursor, _ := (&internal.WatchCursor{Epoch: f.epoch, Sequence: sequence}).Dump()
+	return cursor
+}
+
+// Watcher receives the events of a namespace and key prefix from the feed.
+type Watcher struct {
+	feed      *Feed
+	namespace string
+	prefix    []byte
+	c         chan *Event
+	replay    []*Event
+	resync    bool
+	cursor    string
+	err       error
+}
+
+// Next blocks until the next event is available, the watcher is closed, or the context
+// is done. Replayed events are returned before events published after the watcher was
+// created.
+func (w *Watcher) Next(ctx context.Context) (*Event, error) {
+	if len(w.replay) > 0 {
+		event := w.replay[0]
+		w.replay = w.replay[1:]
+		return event, nil
+	}
+
+	select {
+	case event, ok := <-w.c:
+		if !ok {
+			return nil, w.Err()
+		}
+		return event, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Resync returns true if the watcher could not be resumed from the requested cursor
+// and events may have been missed.
+func (w *Watcher) Resync() bool {
+	return w.resync
+}
+
+// Cursor returns the position of the feed when the watcher was created.
+func (w *Watcher) Cursor() string {
+	return w.cursor
+}
+
+// Err returns the reason the watcher was closed, if any.
+func (w *Watcher) Err() error {
+	w.feed.Lock()
+	defer w.feed.Unlock()
+	return w.err
+}
+
+// Close the watcher and remove it from the feed.
+func (w *Watcher) Close() {
+	w.feed.Lock()
+	defer w.feed.Unlock()
+	if _, ok := w.feed.watchers[w]; ok {
+		w.err = ErrClosed
+		close(w.c)
+		delete(w.feed.watchers, w)
+	}
+}
+
+func (w *Watcher) matches(obj *object.Object) bool {
+	return obj.Namespace == w.namespace && bytes.HasPrefix(obj.Key, w.prefix)
+}
@@ -0,0 +1,203 @@
+package watch_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rotationalio/honu/object"
+	"github.com/stretchr/testify/require"
+	"github.com/trisacrypto/directory/pkg/trtl/watch"
+)
+
+func TestWatch(t *testing.T) {
+	feed := watch.New(8)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w, err := feed.Watch("vasps", []byte("alice"), "")
+	require.NoError(t, err)
+	defer w.Close()
+	require.False(t, w.Resync(), "a new watcher should not require a resync")
+
+	// Only events in the namespace with the key prefix should be delivered
+	feed.Publish(obj("vasps", "alice1", false))
+	feed.Publish(obj("certreqs", "alice2", false))
+	feed.Publish(obj("vasps", "bob", false))
+	feed.Publish(obj("vasps", "alice3", true))
+
+	event, err := w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), event.Sequence)
+	require.Equal(t, []byte("alice1"), event.Object.Key)
+	require.False(t, event.Deleted())
+
+	event, err = w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), event.Sequence)
+	require.Equal(t, []byte("alice3"), event.Object.Key)
+	require.True(t, event.Deleted())
+
+	// Next should return when the context is done
+	short, stop := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer stop()
+	_, err = w.Next(short)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Closing the watcher should stop delivery
+	w.Close()
+	_, err = w.Next(ctx)
+	require.ErrorIs(t, err, watch.ErrClosed)
+}
+
+func TestWatchResume(t *testing.T) {
+	feed := watch.New(4)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// An invalid cursor should return an error
+	_, err := feed.Watch("vasps", nil, "notacursor!")
+	require.ErrorIs(t, err, watch.ErrInvalidCursor)
+
+	cursors := make([]string, 0, 6)
+	w, err := feed.Watch("vasps", nil, "")
+	require.NoError(t, err)
+	for i := 0; i < 6; i++ {
+		feed.Publish(obj("vasps", fmt.Sprintf("key%d", i), false))
+		event, err := w.Next(ctx)
+		require.NoError(t, err)
+		cursors = append(cursors, event.Cursor)
+	}
+	w.Close()
+	require.Equal(t, cursors[5], feed.Cursor())
+
+	// Resuming from a cursor in the log should replay the events after the cursor
+	w, err = feed.Watch("vasps", nil, cursors[2])
+	require.NoError(t, err)
+	require.False(t, w.Resync())
+	for i := 3; i < 6; i++ {
+		event, err := w.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, cursors[i], event.Cursor)
+	}
+
+	// Events published after resuming should be delivered after the replay
+	feed.Publish(obj("vasps", "key6", false))
+	event, err := w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key6"), event.Object.Key)
+	w.Close()
+
+	// Resuming from a cursor that has fallen out of the log requires a resync
+	w, err = feed.Watch("vasps", nil, cursors[0])
+	require.NoError(t, err)
+	require.True(t, w.Resync())
+	require.Equal(t, feed.Cursor(), w.Cursor())
+	w.Close()
+
+	// Resuming from a cursor of another feed (e.g. before a restart) requires a resync
+	other := watch.New(4)
+	other.Publish(obj("vasps", "key0", false))
+	w, err = feed.Watch("vasps", nil, other.Cursor())
+	require.NoError(t, err)
+	require.True(t, w.Resync())
+	w.Close()
+}
+
+func TestFeedResume(t *testing.T) {
+	feed := watch.New(4)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 6; i++ {
+		feed.Publish(obj("vasps", fmt.Sprintf("key%d", i), false))
+	}
+	stale := feed.Cursor()
+	feed.Publish(obj("vasps", "key6", false))
+	latest := feed.Cursor()
+
+	_, err := watch.Resume(4, "notacursor!")
+	require.ErrorIs(t, err, watch.ErrInvalidCursor)
+
+	// A resumed feed continues from the cursor of the previous feed
+	resumed, err := watch.Resume(4, latest)
+	require.NoError(t, err)
+	require.Equal(t, latest, resumed.Cursor())
+
+	// Watchers that had received every event of the previous feed can be resumed
+	w, err := resumed.Watch("vasps", nil, latest)
+	require.NoError(t, err)
+	require.False(t, w.Resync())
+
+	// Watchers that had missed events of the previous feed must resync
+	sw, err := resumed.Watch("vasps", nil, stale)
+	require.NoError(t, err)
+	require.True(t, sw.Resync())
+	sw.Close()
+
+	// Events published to the resumed feed are delivered and can be replayed, including
+	// after the log has wrapped around.
+	cursors := make([]string, 0, 6)
+	for i := 7; i < 13; i++ {
+		resumed.Publish(obj("vasps", fmt.Sprintf("key%d", i), false))
+		event, err := w.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("key%d", i)), event.Object.Key)
+		cursors = append(cursors, event.Cursor)
+	}
+	w.Close()
+
+	w, err = resumed.Watch("vasps", nil, cursors[2])
+	require.NoError(t, err)
+	require.False(t, w.Resync())
+	for i := 3; i < 6; i++ {
+		event, err := w.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, cursors[i], event.Cursor)
+	}
+	w.Close()
+}
+
+func TestWatchOverflow(t *testing.T) {
+	feed := watch.New(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w, err := feed.Watch("vasps", nil, "")
+	require.NoError(t, err)
+
+	// Publishing more events than the watcher buffers should close the watcher rather
+	// than blocking the publisher.
+	for i := 0; i < 1024; i++ {
+		feed.Publish(obj("vasps", fmt.Sprintf("key%d", i), false))
+	}
+
+	var n int
+	for {
+		if _, err = w.Next(ctx); err != nil {
+			break
+		}
+		n++
+	}
+	require.ErrorIs(t, err, watch.ErrOverflow)
+	require.Less(t, n, 1024)
+
+	// Publishing to a nil feed should not panic
+	var nilfeed *watch.Feed
+	nilfeed.Publish(obj("vasps", "key", false))
+}
+
+func obj(namespace, key string, tombstone bool) *object.Object {
+	return &object.Object{
+		Key:       []byte(key),
+		Namespace: namespace,
+		Version: &object.Version{
+			Pid:       8,
+			Version:   1,
+			Region:    "tauceti",
+			Tombstone: tombstone,
+		},
+		Data: []byte("data"),
+	}
+}
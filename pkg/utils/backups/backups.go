@@ -0,0 +1,243 @@
+/*
+Package backups lists, verifies, and restores the compressed database archives that are
+created by the GDS and trtl backup managers. Archives are gzipped tarballs of a leveldb
+database named with the prefix of the service that created them and the timestamp of
+the backup, e.g. gdsdb-202201021504.tgz or trtldb-202201021504.tgz.
+*/
+package backups
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/rotationalio/honu/object"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// Format describes how the records are stored in the leveldb database of an archive.
+type Format string
+
+const (
+	// GDS archives contain the raw records of the GDS leveldb store.
+	FormatGDS Format = "gdsdb"
+
+	// Trtl archives contain honu objects, including the version history of the record.
+	FormatTrtl Format = "trtldb"
+)
+
+const timestampFormat = "200601021504"
+
+var (
+	ErrUnknownArchive = errors.New("not a gds or trtl backup archive")
+	ErrCorrupted      = errors.New("archive is corrupted")
+
+	archiveName = regexp.MustCompile(`^(gdsdb|trtldb)-([0-9]{12})\.tgz$`)
+	nssep       = []byte("::")
+)
+
+// Archive is a compressed backup of a GDS or trtl database.
+type Archive struct {
+	Path    string    `json:"path"`
+	Format  Format    `json:"format"`
+	Created time.Time `json:"created"`
+	Size    int64     `json:"size"`
+}
+
+// Summary describes the contents of an archive that has been verified.
+type Summary struct {
+	Archive    *Archive          `json:"archive"`
+	Objects    uint64            `json:"objects"`
+	Tombstones uint64            `json:"tombstones"`
+	Namespaces map[string]uint64 `json:"namespaces"`
+}
+
+// List the archives in the backup directory ordered by the time they were created.
+func List(dir string) (archives []*Archive, err error) {
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(dir); err != nil {
+		return nil, err
+	}
+
+	archives = make([]*Archive, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !archiveName.MatchString(entry.Name()) {
+			continue
+		}
+
+		var archive *Archive
+		if archive, err = Open(filepath.Join(dir, entry.Name())); err != nil {
+			return nil, err
+		}
+		archives = append(archives, archive)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Created.Before(archives[j].Created)
+	})
+	return archives, nil
+}
+
+// Open an archive from its path. The format and creation timestamp of the archive are
+// parsed from the filename; the contents of the archive are not read until it is
+// verified or restored.
+func Open(path string) (_ *Archive, err error) {
+	parts := archiveName.FindStringSubmatch(filepath.Base(path))
+	if parts == nil {
+		return nil, ErrUnknownArchive
+	}
+
+	archive := &Archive{Path: path, Format: Format(parts[1])}
+	if archive.Created, err = time.Parse(timestampFormat, parts[2]); err != nil {
+		return nil, ErrUnknownArchive
+	}
+
+	var stat os.FileInfo
+	if stat, err = os.Stat(path); err != nil {
+		return nil, err
+	}
+	archive.Size = stat.Size()
+	return archive, nil
+}
+
+// Verify the integrity of the archive by extracting it and reading every record in
+// the database with strict checksum verification. Records in trtl archives must also
+// be valid honu objects that match their key.
+func (a *Archive) Verify() (_ *Summary, err error) {
+	var snap *snapshot
+	if snap, err = a.extract(); err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+	return snap.verify()
+}
+
+// snapshot is an archive that has been extracted to a temporary directory.
+type snapshot struct {
+	archive *Archive
+	dir     string
+	db      *leveldb.DB
+}
+
+// record is a key in a namespace of the archive database.
+type record struct {
+	namespace string
+	key       []byte
+	value     []byte
+	deleted   bool
+}
+
+func (a *Archive) extract() (snap *snapshot, err error) {
+	snap = &snapshot{archive: a}
+	if snap.dir, err = os.MkdirTemp("", string(a.Format)+"-restore-"); err != nil {
+		return nil, err
+	}
+
+	var root string
+	if root, err = utils.ExtractGzip(a.Path, snap.dir, false); err != nil {
+		os.RemoveAll(snap.dir)
+		return nil, fmt.Errorf("%w: could not extract archive: %s", ErrCorrupted, err)
+	}
+
+	// Archives written by the trtl backup manager do not have a root directory.
+	if root == "" {
+		root = snap.dir
+	}
+
+	if snap.db, err = leveldb.OpenFile(root, &opt.Options{ReadOnly: true, ErrorIfMissing: true, Strict: opt.StrictAll}); err != nil {
+		os.RemoveAll(snap.dir)
+		return nil, fmt.Errorf("%w: could not open archive database: %s", ErrCorrupted, err)
+	}
+	return snap, nil
+}
+
+func (s *snapshot) Close() error {
+	defer os.RemoveAll(s.dir)
+	return s.db.Close()
+}
+
+func (s *snapshot) verify() (summary *Summary, err error) {
+	summary = &Summary{Archive: s.archive, Namespaces: make(map[string]uint64)}
+	err = s.scan("", func(r *record) error {
+		if r.deleted {
+			summary.Tombstones++
+			if _, ok := summary.Namespaces[r.namespace]; !ok {
+				summary.Namespaces[r.namespace] = 0
+			}
+			return nil
+		}
+
+		summary.Objects++
+		summary.Namespaces[r.namespace]++
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// scan the records of the namespace (or of all namespaces if empty).
+func (s *snapshot) scan(namespace string, fn func(*record) error) (err error) {
+	var slice *util.Range
+	if namespace != "" {
+		slice = util.BytesPrefix(append([]byte(namespace), nssep...))
+	}
+
+	iter := s.db.NewIterator(slice, nil)
+	defer iter.Release()
+	for iter.Next() {
+		var r *record
+		if r, err = s.decode(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+
+		if err = fn(r); err != nil {
+			return err
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return fmt.Errorf("%w: %s", ErrCorrupted, err)
+	}
+	return nil
+}
+
+func (s *snapshot) decode(key, value []byte) (_ *record, err error) {
+	parts := bytes.SplitN(key, nssep, 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: key %q is not in a namespace", ErrCorrupted, key)
+	}
+
+	// The iterator reuses its buffers so the key and value must be copied.
+	r := &record{
+		namespace: string(parts[0]),
+		key:       append([]byte(nil), parts[1]...),
+		value:     append([]byte(nil), value...),
+	}
+
+	if s.archive.Format == FormatTrtl {
+		obj := &object.Object{}
+		if err = proto.Unmarshal(r.value, obj); err != nil {
+			return nil, fmt.Errorf("%w: could not unmarshal object %q: %s", ErrCorrupted, key, err)
+		}
+
+		if obj.Namespace != r.namespace || !bytes.Equal(obj.Key, r.key) || obj.Version == nil {
+			return nil, fmt.Errorf("%w: object %q does not match its key", ErrCorrupted, key)
+		}
+
+		r.value = obj.Data
+		r.deleted = obj.Tombstone()
+	}
+	return r, nil
+}
@@ -0,0 +1,239 @@
+package backups_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/config"
+	"github.com/rotationalio/honu/options"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+	"github.com/trisacrypto/directory/pkg/utils"
+	"github.com/trisacrypto/directory/pkg/utils/backups"
+	"google.golang.org/grpc"
+)
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"trtldb-202201021504.tgz", "gdsdb-202201011504.tgz", "gdsdb-latest.tgz", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("data"), 0644))
+	}
+
+	archives, err := backups.List(dir)
+	require.NoError(t, err)
+	require.Len(t, archives, 2, "only correctly named archives should be listed")
+
+	require.Equal(t, backups.FormatGDS, archives[0].Format)
+	require.Equal(t, time.Date(2022, 1, 1, 15, 4, 0, 0, time.UTC), archives[0].Created)
+	require.Equal(t, int64(4), archives[0].Size)
+	require.Equal(t, backups.FormatTrtl, archives[1].Format)
+
+	_, err = backups.Open(filepath.Join(dir, "notes.txt"))
+	require.ErrorIs(t, err, backups.ErrUnknownArchive)
+
+	// The contents of the archive are not valid so it cannot be verified
+	_, err = archives[0].Verify()
+	require.ErrorIs(t, err, backups.ErrCorrupted)
+}
+
+func TestRestoreLevelDB(t *testing.T) {
+	dir := t.TempDir()
+
+	// Create a GDS archive
+	src, err := leveldb.OpenFile(filepath.Join(dir, "src"), nil)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, src.Put([]byte(fmt.Sprintf("vasps::%d", i)), []byte(fmt.Sprintf("vasp %d", i)), nil))
+		require.NoError(t, src.Put([]byte(fmt.Sprintf("certreqs::%d", i)), []byte(fmt.Sprintf("certreq %d", i)), nil))
+	}
+	require.NoError(t, src.Put([]byte("index::names"), []byte("names"), nil))
+	require.NoError(t, src.Close())
+
+	archive := createArchive(t, filepath.Join(dir, "src"), filepath.Join(dir, "gdsdb-202201021504.tgz"))
+	summary, err := archive.Verify()
+	require.NoError(t, err)
+	require.Equal(t, uint64(21), summary.Objects)
+	require.Equal(t, map[string]uint64{"vasps": 10, "certreqs": 10, "index": 1}, summary.Namespaces)
+
+	// Modify the live database after the backup
+	db, err := leveldb.OpenFile(filepath.Join(dir, "live"), nil)
+	require.NoError(t, err)
+	defer db.Close()
+	for i := 0; i < 8; i++ {
+		require.NoError(t, db.Put([]byte(fmt.Sprintf("vasps::%d", i)), []byte(fmt.Sprintf("vasp %d", i)), nil))
+	}
+	require.NoError(t, db.Put([]byte("vasps::0"), []byte("modified"), nil))
+	require.NoError(t, db.Put([]byte("vasps::42"), []byte("vasp 42"), nil))
+	require.NoError(t, db.Put([]byte("index::names"), []byte("stale"), nil))
+
+	// A dry run should report the changes without modifying the database
+	opts := backups.RestoreOptions{Namespaces: []string{"vasps"}, Derived: []string{"index"}, DryRun: true}
+	report, err := archive.Restore(backups.LevelDB(db), opts)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Len(t, report.Namespaces, 2)
+	require.Equal(t, &backups.Changes{Created: 2, Updated: 1, Deleted: 1, Unchanged: 7}, report.Namespaces["vasps"])
+	require.Equal(t, &backups.Changes{Deleted: 1}, report.Namespaces["index"], "derived namespaces should be cleared on a partial restore")
+	require.Equal(t, uint64(5), report.Changed())
+
+	val, err := db.Get([]byte("vasps::0"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("modified"), val)
+
+	// Restore only the vasps namespace
+	opts.DryRun = false
+	_, err = archive.Restore(backups.LevelDB(db), opts)
+	require.NoError(t, err)
+
+	val, err = db.Get([]byte("vasps::0"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("vasp 0"), val)
+
+	_, err = db.Get([]byte("vasps::42"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound)
+
+	_, err = db.Get([]byte("certreqs::0"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound, "namespaces that are not selected should not be restored")
+
+	_, err = db.Get([]byte("index::names"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound, "stale indices should be deleted on a partial restore")
+
+	// Restoring all namespaces should create the certreqs and restore the indices
+	report, err = archive.Restore(backups.LevelDB(db), backups.RestoreOptions{Derived: []string{"index"}})
+	require.NoError(t, err)
+	require.Equal(t, &backups.Changes{Created: 10}, report.Namespaces["certreqs"])
+	require.Equal(t, &backups.Changes{Unchanged: 10}, report.Namespaces["vasps"])
+	require.Equal(t, &backups.Changes{Created: 1}, report.Namespaces["index"])
+
+	// Cannot restore a namespace that is not in the archive
+	_, err = archive.Restore(backups.LevelDB(db), backups.RestoreOptions{Namespaces: []string{"certs"}})
+	require.EqualError(t, err, `namespace "certs" is not in the archive`)
+}
+
+func TestRestoreHonu(t *testing.T) {
+	dir := t.TempDir()
+	conf := config.WithReplica(config.ReplicaConfig{PID: 8, Region: "tauceti"})
+
+	// Create a trtl archive that includes a deleted object
+	src, err := honu.Open("leveldb:///"+filepath.Join(dir, "src"), conf)
+	require.NoError(t, err)
+	for _, key := range []string{"alice", "bob", "carol"} {
+		_, err = src.Put([]byte(key), []byte(key+" v1"), options.WithNamespace("people"))
+		require.NoError(t, err)
+	}
+	_, err = src.Delete([]byte("carol"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	require.NoError(t, src.Close())
+
+	archive := createArchive(t, filepath.Join(dir, "src"), filepath.Join(dir, "trtldb-202201021504.tgz"))
+	summary, err := archive.Verify()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), summary.Objects)
+	require.Equal(t, uint64(1), summary.Tombstones)
+
+	// Modify the database after the backup
+	db, err := honu.Open("leveldb:///"+filepath.Join(dir, "src"), conf)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Put([]byte("alice"), []byte("alice v2"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	_, err = db.Delete([]byte("bob"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	_, err = db.Put([]byte("carol"), []byte("carol v2"), options.WithNamespace("people"))
+	require.NoError(t, err)
+
+	report, err := archive.Restore(backups.Honu(db), backups.RestoreOptions{})
+	require.NoError(t, err)
+	require.Equal(t, &backups.Changes{Created: 1, Updated: 1, Deleted: 1}, report.Namespaces["people"])
+
+	// Restored objects should be new versions of the live objects
+	obj, err := db.Object([]byte("alice"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("alice v1"), obj.Data)
+	require.Equal(t, uint64(3), obj.Version.Version)
+
+	val, err := db.Get([]byte("bob"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("bob v1"), val)
+
+	obj, err = db.Object([]byte("carol"), options.WithNamespace("people"))
+	require.NoError(t, err)
+	require.True(t, obj.Tombstone())
+}
+
+func TestTrtlTargetClosedStream(t *testing.T) {
+	// If trtl rejects the batch it closes the stream, so the remaining changes cannot be
+	// sent and the reason is returned when the stream is closed.
+	stream := &mockBatchStream{
+		accept: 1,
+		reply: &pb.BatchReply{
+			Errors: []*pb.BatchReply_Error{{Id: 1, Error: "atomic batch is too large"}},
+		},
+	}
+
+	target := backups.Trtl(context.Background(), &mockTrtlClient{stream: stream})
+	err := target.Apply([]*backups.Change{
+		{Namespace: "people", Key: []byte("alice"), Value: []byte("alice v1")},
+		{Namespace: "people", Key: []byte("bob"), Value: []byte("bob v1")},
+		{Namespace: "people", Key: []byte("carol")},
+	})
+	require.EqualError(t, err, "could not restore people::bob: atomic batch is too large")
+	require.Equal(t, 2, stream.sent)
+
+	// The batch must be committed to be applied
+	stream = &mockBatchStream{accept: 3, reply: &pb.BatchReply{Committed: true, Successful: 3}}
+	target = backups.Trtl(context.Background(), &mockTrtlClient{stream: stream})
+	err = target.Apply([]*backups.Change{
+		{Namespace: "people", Key: []byte("alice"), Value: []byte("alice v1")},
+		{Namespace: "people", Key: []byte("bob"), Value: []byte("bob v1")},
+		{Namespace: "people", Key: []byte("carol")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, stream.sent)
+}
+
+func createArchive(t *testing.T, src, path string) *backups.Archive {
+	require.NoError(t, utils.WriteGzip(src, path))
+	archive, err := backups.Open(path)
+	require.NoError(t, err)
+	return archive
+}
+
+// mockTrtlClient returns the mock stream for batch requests.
+type mockTrtlClient struct {
+	pb.TrtlClient
+	stream *mockBatchStream
+}
+
+func (c *mockTrtlClient) Batch(ctx context.Context, opts ...grpc.CallOption) (pb.Trtl_BatchClient, error) {
+	return c.stream, nil
+}
+
+// mockBatchStream accepts a number of requests before returning io.EOF as though the
+// server closed the stream, and then returns the reply when the stream is closed.
+type mockBatchStream struct {
+	grpc.ClientStream
+	accept int
+	sent   int
+	reply  *pb.BatchReply
+}
+
+func (s *mockBatchStream) Send(*pb.BatchRequest) error {
+	s.sent++
+	if s.sent > s.accept {
+		return io.EOF
+	}
+	return nil
+}
+
+func (s *mockBatchStream) CloseAndRecv() (*pb.BatchReply, error) {
+	return s.reply, nil
+}
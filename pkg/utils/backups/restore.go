@@ -0,0 +1,196 @@
+package backups
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// RestoreOptions specify what is restored from an archive.
+type RestoreOptions struct {
+	// The namespaces to restore, if empty all of the namespaces in the archive are
+	// restored. Namespaces that are not restored are not modified.
+	Namespaces []string
+
+	// Skip namespaces of the archive when no namespaces are specified, e.g. namespaces
+	// that cannot be written to the target.
+	Skip func(namespace string) bool
+
+	// Namespaces whose records are derived from the records of other namespaces, e.g.
+	// indices. When only some of the namespaces are restored, the derived namespaces
+	// that are not restored are cleared rather than left stale so that they are rebuilt
+	// from the restored records when the database is next opened.
+	Derived []string
+
+	// Compute the changes that the restore would make without applying them.
+	DryRun bool
+}
+
+// Changes counts the differences between the records of a namespace in the archive and
+// the live records in the database being restored.
+type Changes struct {
+	Created   uint64 `json:"created"`
+	Updated   uint64 `json:"updated"`
+	Deleted   uint64 `json:"deleted"`
+	Unchanged uint64 `json:"unchanged"`
+}
+
+// Total returns the number of records that are modified by the restore.
+func (c *Changes) Total() uint64 {
+	return c.Created + c.Updated + c.Deleted
+}
+
+// RestoreReport describes the changes made (or that would be made on a dry run) to the
+// database when an archive is restored.
+type RestoreReport struct {
+	Summary    *Summary            `json:"summary"`
+	DryRun     bool                `json:"dry_run"`
+	Namespaces map[string]*Changes `json:"namespaces"`
+}
+
+// Changed returns the number of records modified in all of the restored namespaces.
+func (r *RestoreReport) Changed() (n uint64) {
+	for _, changes := range r.Namespaces {
+		n += changes.Total()
+	}
+	return n
+}
+
+// Restore the archive into the target database so that the restored namespaces contain
+// exactly the records in the archive: records missing from the target are created,
+// records that differ are updated, and records that are not in the archive (or that
+// were deleted when the archive was created) are deleted.
+//
+// The integrity of the entire archive is verified and the changes to every namespace
+// are computed before the target is modified, so a corrupted archive or an error
+// reading the target leaves the target unchanged.
+func (a *Archive) Restore(target Target, opts RestoreOptions) (report *RestoreReport, err error) {
+	var snap *snapshot
+	if snap, err = a.extract(); err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+
+	report = &RestoreReport{DryRun: opts.DryRun, Namespaces: make(map[string]*Changes)}
+	if report.Summary, err = snap.verify(); err != nil {
+		return nil, err
+	}
+
+	namespaces := opts.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = make([]string, 0, len(report.Summary.Namespaces))
+		for namespace := range report.Summary.Namespaces {
+			if opts.Skip != nil && opts.Skip(namespace) {
+				continue
+			}
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+	}
+
+	var changeset []*Change
+	for _, namespace := range namespaces {
+		if _, ok := report.Summary.Namespaces[namespace]; !ok {
+			return nil, fmt.Errorf("namespace %q is not in the archive", namespace)
+		}
+
+		var changes []*Change
+		if report.Namespaces[namespace], changes, err = snap.diff(namespace, target); err != nil {
+			return nil, err
+		}
+		changeset = append(changeset, changes...)
+	}
+
+	// Derived namespaces are only cleared on a partial restore, otherwise they are
+	// restored from the archive along with the namespaces they are derived from.
+	if len(opts.Namespaces) > 0 {
+		for _, namespace := range opts.Derived {
+			if _, ok := report.Namespaces[namespace]; ok {
+				continue
+			}
+
+			var changes []*Change
+			if report.Namespaces[namespace], changes, err = clearNamespace(namespace, target); err != nil {
+				return nil, err
+			}
+			changeset = append(changeset, changes...)
+		}
+	}
+
+	if !opts.DryRun && len(changeset) > 0 {
+		if err = target.Apply(changeset); err != nil {
+			return nil, fmt.Errorf("could not apply changes to the database: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// Compare the records of the namespace in the archive to the live records of the
+// target, returning the changes required to restore the namespace.
+func (s *snapshot) diff(namespace string, target Target) (counts *Changes, changes []*Change, err error) {
+	var live map[string][]byte
+	if live, err = target.Values(namespace); err != nil {
+		return nil, nil, fmt.Errorf("could not read namespace %q from the database: %w", namespace, err)
+	}
+
+	counts = &Changes{}
+	err = s.scan(namespace, func(r *record) error {
+		current, exists := live[string(r.key)]
+		delete(live, string(r.key))
+
+		switch {
+		case r.deleted && exists:
+			counts.Deleted++
+			changes = append(changes, &Change{Namespace: namespace, Key: r.key})
+		case r.deleted:
+			// The record was deleted when the archive was created and does not exist.
+		case !exists:
+			counts.Created++
+			changes = append(changes, &Change{Namespace: namespace, Key: r.key, Value: r.value})
+		case !bytes.Equal(current, r.value):
+			counts.Updated++
+			changes = append(changes, &Change{Namespace: namespace, Key: r.key, Value: r.value})
+		default:
+			counts.Unchanged++
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Any live records that are not in the archive were created after the backup.
+	keys := make([]string, 0, len(live))
+	for key := range live {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		counts.Deleted++
+		changes = append(changes, &Change{Namespace: namespace, Key: []byte(key)})
+	}
+	return counts, changes, nil
+}
+
+// Returns the changes required to delete all of the live records of the namespace so
+// that a derived namespace is rebuilt after a partial restore.
+func clearNamespace(namespace string, target Target) (counts *Changes, changes []*Change, err error) {
+	var live map[string][]byte
+	if live, err = target.Values(namespace); err != nil {
+		return nil, nil, fmt.Errorf("could not read namespace %q from the database: %w", namespace, err)
+	}
+
+	keys := make([]string, 0, len(live))
+	for key := range live {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	counts = &Changes{Deleted: uint64(len(keys))}
+	for _, key := range keys {
+		changes = append(changes, &Change{Namespace: namespace, Key: []byte(key)})
+	}
+	return counts, changes, nil
+}
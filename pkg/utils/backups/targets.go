@@ -0,0 +1,197 @@
+package backups
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rotationalio/honu"
+	"github.com/rotationalio/honu/iterator"
+	"github.com/rotationalio/honu/object"
+	"github.com/rotationalio/honu/options"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"github.com/trisacrypto/directory/pkg/trtl/pb/v1"
+)
+
+// Target is a database that an archive can be restored into.
+type Target interface {
+	// Values returns the live records of the namespace by key.
+	Values(namespace string) (map[string][]byte, error)
+
+	// Apply the changes to the database.
+	Apply(changes []*Change) error
+}
+
+// Change is a record that is created or updated by a restore, or deleted if the value
+// is nil.
+type Change struct {
+	Namespace string
+	Key       []byte
+	Value     []byte
+}
+
+// LevelDB returns a target that restores the raw records of a GDS leveldb store, which
+// must not be in use by a running GDS. The changes are applied in a single write batch.
+func LevelDB(db *leveldb.DB) Target {
+	return &leveldbTarget{db: db}
+}
+
+type leveldbTarget struct {
+	db *leveldb.DB
+}
+
+func (t *leveldbTarget) Values(namespace string) (values map[string][]byte, err error) {
+	prefix := append([]byte(namespace), nssep...)
+	iter := t.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	values = make(map[string][]byte)
+	for iter.Next() {
+		values[string(iter.Key()[len(prefix):])] = append([]byte(nil), iter.Value()...)
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (t *leveldbTarget) Apply(changes []*Change) error {
+	batch := new(leveldb.Batch)
+	for _, change := range changes {
+		key := bytes.Join([][]byte{[]byte(change.Namespace), change.Key}, nssep)
+		if change.Value == nil {
+			batch.Delete(key)
+		} else {
+			batch.Put(key, change.Value)
+		}
+	}
+	return t.db.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+// Honu returns a target that restores the objects of a trtl database that is not being
+// served. Restored objects are written as new versions (and deleted objects as new
+// tombstones) so that the restore is replicated to the peers of the replica by
+// anti-entropy rather than being replaced by the later versions of its peers.
+func Honu(db *honu.DB) Target {
+	return &honuTarget{db: db}
+}
+
+type honuTarget struct {
+	db *honu.DB
+}
+
+func (t *honuTarget) Values(namespace string) (values map[string][]byte, err error) {
+	var iter iterator.Iterator
+	if iter, err = t.db.Iter(nil, options.WithNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	values = make(map[string][]byte)
+	for iter.Next() {
+		var obj *object.Object
+		if obj, err = iter.Object(); err != nil {
+			return nil, err
+		}
+
+		if !obj.Tombstone() {
+			values[string(obj.Key)] = obj.Data
+		}
+	}
+
+	if err = iter.Error(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (t *honuTarget) Apply(changes []*Change) (err error) {
+	for _, change := range changes {
+		if change.Value == nil {
+			_, err = t.db.Delete(change.Key, options.WithNamespace(change.Namespace))
+		} else {
+			_, err = t.db.Put(change.Key, change.Value, options.WithNamespace(change.Namespace))
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not restore %s::%s: %w", change.Namespace, change.Key, err)
+		}
+	}
+	return nil
+}
+
+// Trtl returns a target that restores the objects of a running trtl database through
+// its API. The changes are applied in a single atomic batch so that either all or none
+// of the namespaces are restored. Reserved trtl namespaces cannot be restored, and trtl
+// limits the size of atomic batches, so restores that change more records than the
+// limit must be applied to the database directly while trtl is stopped.
+func Trtl(ctx context.Context, client pb.TrtlClient) Target {
+	return &trtlTarget{ctx: ctx, client: client}
+}
+
+type trtlTarget struct {
+	ctx    context.Context
+	client pb.TrtlClient
+}
+
+func (t *trtlTarget) Values(namespace string) (values map[string][]byte, err error) {
+	var stream pb.Trtl_CursorClient
+	if stream, err = t.client.Cursor(t.ctx, &pb.CursorRequest{Namespace: namespace}); err != nil {
+		return nil, err
+	}
+
+	values = make(map[string][]byte)
+	for {
+		var pair *pb.KVPair
+		if pair, err = stream.Recv(); err != nil {
+			if err == io.EOF {
+				return values, nil
+			}
+			return nil, err
+		}
+		values[string(pair.Key)] = pair.Value
+	}
+}
+
+func (t *trtlTarget) Apply(changes []*Change) (err error) {
+	var stream pb.Trtl_BatchClient
+	if stream, err = t.client.Batch(t.ctx); err != nil {
+		return err
+	}
+
+	for i, change := range changes {
+		req := &pb.BatchRequest{Id: int64(i), Atomic: i == 0}
+		if change.Value == nil {
+			req.Request = &pb.BatchRequest_Delete{Delete: &pb.DeleteRequest{Key: change.Key, Namespace: change.Namespace}}
+		} else {
+			req.Request = &pb.BatchRequest_Put{Put: &pb.PutRequest{Key: change.Key, Value: change.Value, Namespace: change.Namespace}}
+		}
+
+		if err = stream.Send(req); err != nil {
+			// The server closed the stream, the reason is returned by CloseAndRecv
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+
+	var rep *pb.BatchReply
+	if rep, err = stream.CloseAndRecv(); err != nil {
+		return err
+	}
+
+	if !rep.Committed {
+		if len(rep.Errors) > 0 && rep.Errors[0].Id < int64(len(changes)) {
+			change := changes[rep.Errors[0].Id]
+			return fmt.Errorf("could not restore %s::%s: %s", change.Namespace, change.Key, rep.Errors[0].Error)
+		}
+		return errors.New("batch was not committed")
+	}
+	return nil
+}
@@ -29,12 +29,15 @@ var (
 
 // Namespace constants for all managed objects in GDS
 const (
-	NamespaceVASPs    = "vasps"
-	NamespaceCerts    = "certs"
-	NamespaceCertReqs = "certreqs"
-	NamespaceReplicas = "peers"
-	NamespaceIndices  = "index"
-	NamespaceSequence = "sequence"
+	NamespaceVASPs     = "vasps"
+	NamespaceCerts     = "certs"
+	NamespaceCertReqs  = "certreqs"
+	NamespaceReplicas  = "peers"
+	NamespaceIndices   = "index"
+	NamespaceSequence  = "sequence"
+	NamespaceActions   = "actions"
+	NamespaceAudit     = "audit"
+	NamespaceRevisions = "revisions"
 )
 
 // Namespaces defines all possible namespaces that GDS manages
@@ -63,6 +66,24 @@ func UnmarshalProto(namespace string, data []byte) (_ proto.Message, err error)
 			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, certreq, err)
 		}
 		return certreq, nil
+	case NamespaceActions:
+		action := &models.PendingAction{}
+		if err = proto.Unmarshal(data, action); err != nil {
+			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, action, err)
+		}
+		return action, nil
+	case NamespaceAudit:
+		record := &models.AuditRecord{}
+		if err = proto.Unmarshal(data, record); err != nil {
+			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, record, err)
+		}
+		return record, nil
+	case NamespaceRevisions:
+		revision := &models.VASPRevision{}
+		if err = proto.Unmarshal(data, revision); err != nil {
+			return nil, fmt.Errorf("could not unmarshal %s to %T: %s", namespace, revision, err)
+		}
+		return revision, nil
 	case NamespaceReplicas:
 		peer := &peers.Peer{}
 		if err = proto.Unmarshal(data, peer); err != nil {
@@ -130,6 +151,24 @@ func RemarshalJSON(namespace string, in []byte) (out []byte, err error) {
 			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, certreq, err)
 		}
 		return proto.Marshal(certreq)
+	case NamespaceActions:
+		action := &models.PendingAction{}
+		if err = jsonpb.Unmarshal(in, action); err != nil {
+			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, action, err)
+		}
+		return proto.Marshal(action)
+	case NamespaceAudit:
+		record := &models.AuditRecord{}
+		if err = jsonpb.Unmarshal(in, record); err != nil {
+			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, record, err)
+		}
+		return proto.Marshal(record)
+	case NamespaceRevisions:
+		revision := &models.VASPRevision{}
+		if err = jsonpb.Unmarshal(in, revision); err != nil {
+			return nil, fmt.Errorf("could not unmarshal json %s into %T: %s", namespace, revision, err)
+		}
+		return proto.Marshal(revision)
 	case NamespaceReplicas:
 		peer := &peers.Peer{}
 		if err = jsonpb.Unmarshal(in, peer); err != nil {
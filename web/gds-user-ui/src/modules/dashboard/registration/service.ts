@@ -1,13 +1,26 @@
 import axiosInstance, { setAuthorization } from 'utils/axios';
 import { getCookie } from 'utils/cookies';
+// the version of the registration form that was last loaded or saved, which must be
+// sent back when the form is saved so that changes from other sessions are not lost.
+let registrationETag: string | undefined;
+
+const saveRegistrationForm = async (data: any) => {
+  const response = await axiosInstance.put(`/register`, data, {
+    headers: registrationETag ? { 'If-Match': registrationETag } : {}
+  });
+  registrationETag = response.headers.etag;
+  return response;
+};
+
 export const getRegistrationData = async () => {
   setAuthorization();
   const response = await axiosInstance.get(`/register`);
+  registrationETag = response.headers.etag;
   return response;
 };
 export const postRegistrationData = async (data: any) => {
   setAuthorization();
-  const response = await axiosInstance.put(`/register`, { ...data });
+  const response = await saveRegistrationForm({ ...data });
   return response;
 };
 
@@ -31,7 +44,7 @@ export const submitMainnetRegistration = async () => {
 
 export const setRegistrationDefaultState = async () => {
   setAuthorization();
-  const response = await axiosInstance.put(`/register`, {
+  const response = await saveRegistrationForm({
     state: {
       current: 1,
       ready_to_submit: false,